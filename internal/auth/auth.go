@@ -74,6 +74,10 @@ type ExternalAccount struct {
 	Website string
 	// Whether the user should be prompted as a site admin.
 	Admin bool
+	// The team memberships to be synchronized for the account, keys are team IDs
+	// and values indicate whether the account should be a member of the team.
+	// Teams not present are left untouched.
+	Teams map[int64]bool
 }
 
 // Provider defines an authenticate provider which provides ability to authentication against
@@ -9,6 +9,7 @@ package ldap
 import (
 	"crypto/tls"
 	"fmt"
+	"strconv"
 	"strings"
 
 	ldap "github.com/go-ldap/ldap/v3"
@@ -58,6 +59,9 @@ type Config struct {
 	GroupFilter       string // Group name filter
 	GroupMemberUID    string `ini:"group_member_uid"` // Group Attribute containing array of UserUID
 	UserUID           string `ini:"user_uid"`         // User Attribute listed in group
+	// Mapping of group DNs to team IDs for synchronizing team memberships, in the
+	// form of "<group DN>:<team ID>" separated by semicolons.
+	GroupTeamMap string `ini:",omitempty"`
 }
 
 func (c *Config) SecurityProtocolName() string {
@@ -108,6 +112,85 @@ func (*Config) sanitizedGroupDN(groupDn string) (string, bool) {
 	return groupDn, true
 }
 
+// groupTeamMapping parses the group-to-team mapping and returns team IDs keyed
+// by group DNs. Invalid entries are skipped.
+func (c *Config) groupTeamMapping() map[string][]int64 {
+	mapping := make(map[string][]int64)
+	for _, entry := range strings.Split(c.GroupTeamMap, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		i := strings.LastIndex(entry, ":")
+		if i <= 0 {
+			log.Warn("LDAP: Invalid group-to-team mapping entry %q", entry)
+			continue
+		}
+		groupDN, ok := c.sanitizedGroupDN(strings.TrimSpace(entry[:i]))
+		if !ok {
+			continue
+		}
+		teamID, err := strconv.ParseInt(strings.TrimSpace(entry[i+1:]), 10, 64)
+		if err != nil || teamID <= 0 {
+			log.Warn("LDAP: Invalid team ID in group-to-team mapping entry %q", entry)
+			continue
+		}
+		mapping[groupDN] = append(mapping[groupDN], teamID)
+	}
+	return mapping
+}
+
+// searchTeams looks up the mapped groups that the user is a member of, and
+// returns whether the user should be a member of each mapped team. It returns
+// nil when no mapping is configured or any lookup fails, in which case team
+// memberships should be left untouched.
+func (c *Config) searchTeams(l *ldap.Conn, userDN, uid string) map[int64]bool {
+	mapping := c.groupTeamMapping()
+	if len(mapping) == 0 {
+		return nil
+	} else if c.GroupMemberUID == "" {
+		log.Warn("LDAP: Group-to-team mapping requires the group attribute containing list of users")
+		return nil
+	}
+
+	member := uid
+	if c.UserUID == "dn" {
+		member = userDN
+	}
+
+	teams := make(map[int64]bool)
+	for groupDN, teamIDs := range mapping {
+		log.Trace("LDAP: Fetching members '%v' of mapped group '%s'", c.GroupMemberUID, groupDN)
+		search := ldap.NewSearchRequest(
+			groupDN, ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false, "(objectClass=*)",
+			[]string{c.GroupMemberUID},
+			nil)
+
+		sr, err := l.Search(search)
+		if err != nil {
+			log.Error("LDAP: Mapped group search failed [group_dn: %s]: %v", groupDN, err)
+			return nil
+		}
+
+		isMember := false
+		for _, group := range sr.Entries {
+			for _, value := range group.GetAttributeValues(c.GroupMemberUID) {
+				if value == member {
+					isMember = true
+				}
+			}
+		}
+
+		// A user should be a member of the team when they are a member of any group
+		// mapped to the team.
+		for _, teamID := range teamIDs {
+			teams[teamID] = teams[teamID] || isMember
+		}
+	}
+	return teams
+}
+
 func (c *Config) findUserDN(l *ldap.Conn, name string) (string, bool) {
 	log.Trace("Search for LDAP user: %s", name)
 	if len(c.BindDN) > 0 && len(c.BindPassword) > 0 {
@@ -191,16 +274,16 @@ func bindUser(l *ldap.Conn, userDN, passwd string) error {
 }
 
 // searchEntry searches an LDAP source if an entry (name, passwd) is valid and in the specific filter.
-func (c *Config) searchEntry(name, passwd string, directBind bool) (string, string, string, string, bool, bool) {
+func (c *Config) searchEntry(name, passwd string, directBind bool) (string, string, string, string, bool, map[int64]bool, bool) {
 	// See https://tools.ietf.org/search/rfc4513#section-5.1.2
 	if passwd == "" {
 		log.Trace("authentication failed for '%s' with empty password", name)
-		return "", "", "", "", false, false
+		return "", "", "", "", false, nil, false
 	}
 	l, err := dial(c)
 	if err != nil {
 		log.Error("LDAP connect failed for '%s': %v", c.Host, err)
-		return "", "", "", "", false, false
+		return "", "", "", "", false, nil, false
 	}
 	defer l.Close()
 
@@ -211,7 +294,7 @@ func (c *Config) searchEntry(name, passwd string, directBind bool) (string, stri
 		var ok bool
 		userDN, ok = c.sanitizedUserDN(name)
 		if !ok {
-			return "", "", "", "", false, false
+			return "", "", "", "", false, nil, false
 		}
 	} else {
 		log.Trace("LDAP will use BindDN")
@@ -219,7 +302,7 @@ func (c *Config) searchEntry(name, passwd string, directBind bool) (string, stri
 		var found bool
 		userDN, found = c.findUserDN(l, name)
 		if !found {
-			return "", "", "", "", false, false
+			return "", "", "", "", false, nil, false
 		}
 	}
 
@@ -227,13 +310,13 @@ func (c *Config) searchEntry(name, passwd string, directBind bool) (string, stri
 		// binds user (checking password) before looking-up attributes in user context
 		err = bindUser(l, userDN, passwd)
 		if err != nil {
-			return "", "", "", "", false, false
+			return "", "", "", "", false, nil, false
 		}
 	}
 
 	userFilter, ok := c.sanitizedUserQuery(name)
 	if !ok {
-		return "", "", "", "", false, false
+		return "", "", "", "", false, nil, false
 	}
 
 	log.Trace("Fetching attributes %q, %q, %q, %q, %q with user filter %q and user DN %q",
@@ -246,7 +329,7 @@ func (c *Config) searchEntry(name, passwd string, directBind bool) (string, stri
 	sr, err := l.Search(search)
 	if err != nil {
 		log.Error("LDAP: User search failed: %v", err)
-		return "", "", "", "", false, false
+		return "", "", "", "", false, nil, false
 	} else if len(sr.Entries) < 1 {
 		if directBind {
 			log.Trace("LDAP: User filter inhibited user login")
@@ -254,7 +337,7 @@ func (c *Config) searchEntry(name, passwd string, directBind bool) (string, stri
 			log.Trace("LDAP: User search failed: 0 entries")
 		}
 
-		return "", "", "", "", false, false
+		return "", "", "", "", false, nil, false
 	}
 
 	username := sr.Entries[0].GetAttributeValue(c.AttributeUsername)
@@ -262,16 +345,17 @@ func (c *Config) searchEntry(name, passwd string, directBind bool) (string, stri
 	surname := sr.Entries[0].GetAttributeValue(c.AttributeSurname)
 	mail := sr.Entries[0].GetAttributeValue(c.AttributeMail)
 	uid := sr.Entries[0].GetAttributeValue(c.UserUID)
+	entryDN := sr.Entries[0].DN
 
 	// Check group membership
 	if c.GroupEnabled {
 		groupFilter, ok := c.sanitizedGroupFilter(c.GroupFilter)
 		if !ok {
-			return "", "", "", "", false, false
+			return "", "", "", "", false, nil, false
 		}
 		groupDN, ok := c.sanitizedGroupDN(c.GroupDN)
 		if !ok {
-			return "", "", "", "", false, false
+			return "", "", "", "", false, nil, false
 		}
 
 		log.Trace("LDAP: Fetching groups '%v' with filter '%s' and base '%s'", c.GroupMemberUID, groupFilter, groupDN)
@@ -283,10 +367,10 @@ func (c *Config) searchEntry(name, passwd string, directBind bool) (string, stri
 		srg, err := l.Search(groupSearch)
 		if err != nil {
 			log.Error("LDAP: Group search failed: %v", err)
-			return "", "", "", "", false, false
+			return "", "", "", "", false, nil, false
 		} else if len(srg.Entries) < 1 {
 			log.Trace("LDAP: Group search returned no entries")
-			return "", "", "", "", false, false
+			return "", "", "", "", false, nil, false
 		}
 
 		isMember := false
@@ -310,7 +394,7 @@ func (c *Config) searchEntry(name, passwd string, directBind bool) (string, stri
 
 		if !isMember {
 			log.Trace("LDAP: Group membership test failed [username: %s, group_member_uid: %s, user_uid: %s", username, c.GroupMemberUID, uid)
-			return "", "", "", "", false, false
+			return "", "", "", "", false, nil, false
 		}
 	}
 
@@ -336,9 +420,11 @@ func (c *Config) searchEntry(name, passwd string, directBind bool) (string, stri
 		// binds user (checking password) after looking-up attributes in BindDN context
 		err = bindUser(l, userDN, passwd)
 		if err != nil {
-			return "", "", "", "", false, false
+			return "", "", "", "", false, nil, false
 		}
 	}
 
-	return username, firstname, surname, mail, isAdmin, true
+	teams := c.searchTeams(l, entryDN, uid)
+
+	return username, firstname, surname, mail, isAdmin, teams, true
 }
@@ -27,7 +27,7 @@ func NewProvider(directBind bool, cfg *Config) auth.Provider {
 // Authenticate queries if login/password is valid against the LDAP directory pool,
 // and returns queried information when succeeded.
 func (p *Provider) Authenticate(login, password string) (*auth.ExternalAccount, error) {
-	username, fn, sn, email, isAdmin, succeed := p.config.searchEntry(login, password, p.directBind)
+	username, fn, sn, email, isAdmin, teams, succeed := p.config.searchEntry(login, password, p.directBind)
 	if !succeed {
 		return nil, auth.ErrBadCredentials{Args: map[string]any{"login": login}}
 	}
@@ -58,6 +58,7 @@ func (p *Provider) Authenticate(login, password string) (*auth.ExternalAccount,
 		FullName: composeFullName(fn, sn, username),
 		Email:    email,
 		Admin:    isAdmin,
+		Teams:    teams,
 	}, nil
 }
 
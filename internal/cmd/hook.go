@@ -7,6 +7,7 @@ package cmd
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"crypto/tls"
 	"fmt"
 	"net/url"
@@ -86,10 +87,18 @@ func runHookPreReceive(c *cli.Context) error {
 		}
 		oldCommitID := string(fields[0])
 		newCommitID := string(fields[1])
-		branchName := git.RefShortName(string(fields[2]))
+		refFullName := string(fields[2])
+		repoID := com.StrTo(os.Getenv(db.ENV_REPO_ID)).MustInt64()
+		userID := com.StrTo(os.Getenv(db.ENV_AUTH_USER_ID)).MustInt64()
+
+		// Tag protection
+		if strings.HasPrefix(refFullName, git.RefsTags) {
+			checkProtectedTag(repoID, userID, strings.TrimPrefix(refFullName, git.RefsTags))
+			continue
+		}
 
 		// Branch protection
-		repoID := com.StrTo(os.Getenv(db.ENV_REPO_ID)).MustInt64()
+		branchName := git.RefShortName(refFullName)
 		protectBranch, err := db.GetProtectBranchOfRepoByName(repoID, branchName)
 		if err != nil {
 			if db.IsErrBranchNotExist(err) {
@@ -105,7 +114,6 @@ func runHookPreReceive(c *cli.Context) error {
 		bypassRequirePullRequest := false
 
 		// Check if user is in whitelist when enabled
-		userID := com.StrTo(os.Getenv(db.ENV_AUTH_USER_ID)).MustInt64()
 		if protectBranch.EnableWhitelist {
 			if !db.IsUserInProtectBranchWhitelist(repoID, userID, branchName) {
 				fail(fmt.Sprintf("Branch '%s' is protected and you are not in the push whitelist", branchName), "")
@@ -155,6 +163,34 @@ func runHookPreReceive(c *cli.Context) error {
 	return nil
 }
 
+// checkProtectedTag fails the push when the tag matches any protected tag rule
+// of the repository that requires a higher access mode than the user has.
+func checkProtectedTag(repoID, userID int64, tagName string) {
+	ctx := context.Background()
+	tags, err := db.Repos.ListProtectedTags(ctx, repoID)
+	if err != nil {
+		fail("Internal error", "ListProtectedTags [repo_id: %d]: %v", repoID, err)
+	} else if len(tags) == 0 {
+		return
+	}
+
+	repo, err := db.Repos.GetByID(ctx, repoID)
+	if err != nil {
+		fail("Internal error", "GetRepositoryByID [repo_id: %d]: %v", repoID, err)
+	}
+	mode := db.Perms.AccessMode(ctx, userID, repoID,
+		db.AccessModeOptions{
+			OwnerID: repo.OwnerID,
+			Private: repo.IsPrivate,
+		},
+	)
+	for _, tag := range tags {
+		if tag.Match(tagName) && mode < tag.AllowedMode {
+			fail(fmt.Sprintf("Tag '%s' is protected and you are not allowed to create, update or delete it", tagName), "")
+		}
+	}
+}
+
 func runHookUpdate(c *cli.Context) error {
 	if os.Getenv("SSH_ORIGINAL_COMMAND") == "" {
 		return nil
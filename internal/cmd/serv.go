@@ -108,7 +108,7 @@ func parseSSHCmd(cmd string) (string, string) {
 	return ss[0], strings.Replace(ss[1], "'/", "'", 1)
 }
 
-func checkDeployKey(key *db.PublicKey, repo *db.Repository) {
+func checkDeployKey(key *db.PublicKey, repo *db.Repository) *db.DeployKey {
 	// Check if this deploy key belongs to current repository.
 	if !db.HasDeployKey(key.ID, repo.ID) {
 		fail("Key access denied", "Deploy key access denied: [key_id: %d, repo_id: %d]", key.ID, repo.ID)
@@ -124,6 +124,7 @@ func checkDeployKey(key *db.PublicKey, repo *db.Repository) {
 	if err = db.UpdateDeployKey(deployKey); err != nil {
 		fail("Internal error", "UpdateDeployKey: %v", err)
 	}
+	return deployKey
 }
 
 var allowedCommands = map[string]db.AccessMode{
@@ -189,6 +190,22 @@ func runServ(c *cli.Context) error {
 		fail("Mirror repository is read-only", "")
 	}
 
+	// Prohibit push to repositories of archived organizations.
+	if requestMode > db.AccessModeRead && owner.IsArchived {
+		fail("Repository owner is archived and its repositories are read-only", "")
+	}
+
+	// Prohibit push when the repository owner has reached the storage quota.
+	if requestMode > db.AccessModeRead {
+		err = db.Users.CheckStorageQuota(ctx, repo.OwnerID)
+		if err != nil {
+			if db.IsErrStorageQuotaExceeded(err) {
+				fail("Repository owner has reached the storage quota", "")
+			}
+			fail("Internal error", "Failed to check storage quota: %v", err)
+		}
+	}
+
 	// Allow anonymous (user is nil) clone for public repositories.
 	var user *db.User
 
@@ -200,12 +217,18 @@ func runServ(c *cli.Context) error {
 	if requestMode == db.AccessModeWrite || repo.IsPrivate {
 		// Check deploy key or user key.
 		if key.IsDeployKey() {
-			if key.Mode < requestMode {
-				fail("Key permission denied", "Cannot push with deployment key: %d", key.ID)
+			deployKey := checkDeployKey(key, repo)
+			if requestMode > db.AccessModeRead {
+				user, err = db.DeployKeyPusher(deployKey, repo)
+				if err != nil {
+					if db.IsErrDeployKeyReadOnly(err) {
+						fail("Key permission denied", "Cannot push with read-only deployment key: %d", deployKey.ID)
+					}
+					fail("Internal error", "Failed to get pusher of deploy key '%d': %v", deployKey.ID, err)
+				}
 			}
-			checkDeployKey(key, repo)
 		} else {
-			user, err = db.Users.GetByKeyID(ctx, key.ID)
+			user, err = db.Users.GetByPublicKeyID(ctx, key.ID)
 			if err != nil {
 				fail("Internal error", "Failed to get user by key ID '%d': %v", key.ID, err)
 			}
@@ -262,14 +285,18 @@ func runServ(c *cli.Context) error {
 		gitCmd = exec.Command(verb, repoFullName)
 	}
 	if requestMode == db.AccessModeWrite {
-		gitCmd.Env = append(os.Environ(), db.ComposeHookEnvs(db.ComposeHookEnvsOptions{
+		envs, err := db.ComposeHookEnvs(db.ComposeHookEnvsOptions{
 			AuthUser:  user,
 			OwnerName: owner.Name,
 			OwnerSalt: owner.Salt,
 			RepoID:    repo.ID,
 			RepoName:  repo.Name,
 			RepoPath:  repo.RepoPath(),
-		})...)
+		})
+		if err != nil {
+			fail("Internal error", "Failed to compose hook envs: %v", err)
+		}
+		gitCmd.Env = append(os.Environ(), envs...)
 	}
 	gitCmd.Dir = conf.Repository.Root
 	gitCmd.Stdout = os.Stdout
@@ -236,6 +236,7 @@ func runWeb(c *cli.Context) error {
 			m.Group("/organizations", func() {
 				m.Get("", user.SettingsOrganizations)
 				m.Post("/leave", user.SettingsLeaveOrganization)
+				m.Post("/notify_level", user.SettingsOrganizationNotifyLevel)
 			})
 			m.Combo("/applications").Get(user.SettingsApplications).
 				Post(bindIgnErr(form.NewAccessToken{}), user.SettingsApplicationsPost)
@@ -273,6 +274,9 @@ func runWeb(c *cli.Context) error {
 
 			m.Group("/orgs", func() {
 				m.Get("", admin.Organizations)
+				m.Get("/orphaned", admin.OrphanedOrganizations)
+				m.Post("/:orgid/verify", admin.VerifyOrganization)
+				m.Post("/:orgid/owner", admin.AssignOrganizationOwner)
 			})
 
 			m.Group("/repos", func() {
@@ -359,6 +363,7 @@ func runWeb(c *cli.Context) error {
 				m.Post("/slack/:id", bindIgnErr(form.NewSlackHook{}), repo.WebhooksSlackEditPost)
 				m.Post("/discord/:id", bindIgnErr(form.NewDiscordHook{}), repo.WebhooksDiscordEditPost)
 				m.Post("/dingtalk/:id", bindIgnErr(form.NewDingtalkHook{}), repo.WebhooksDingtalkEditPost)
+				m.Post("/:id/rotate_secret", repo.RotateWebhookSecret)
 			}, repo.InjectOrgRepoContext())
 		}
 
@@ -373,6 +378,8 @@ func runWeb(c *cli.Context) error {
 				}
 			})
 
+			m.Get("/invitations/:token", org.AcceptInvitation)
+
 			m.Group("/:org", func() {
 				m.Get("/dashboard", user.Dashboard)
 				m.Get("/^:type(issues|pulls)$", user.Issues)
@@ -401,11 +408,14 @@ func runWeb(c *cli.Context) error {
 						Post(bindIgnErr(form.UpdateOrgSetting{}), org.SettingsPost)
 					m.Post("/avatar", binding.MultipartForm(form.Avatar{}), org.SettingsAvatar)
 					m.Post("/avatar/delete", org.SettingsDeleteAvatar)
+					m.Post("/archive", org.SettingsArchive)
 					m.Group("/hooks", webhookRoutes)
 					m.Route("/delete", "GET,POST", org.SettingsDelete)
 				})
 
+				m.Get("/members/export", org.ExportMembers)
 				m.Route("/invitations/new", "GET,POST", org.Invitation)
+				m.Post("/invitations/:id/revoke", org.RevokeInvitation)
 			}, context.OrgAssignment(true, true))
 		}, reqSignIn)
 		// ***** END: Organization *****
@@ -418,6 +428,8 @@ func runWeb(c *cli.Context) error {
 			m.Post("/migrate", bindIgnErr(form.MigrateRepo{}), repo.MigratePost)
 			m.Combo("/fork/:repoid").Get(repo.Fork).
 				Post(bindIgnErr(form.CreateRepo{}), repo.ForkPost)
+			m.Combo("/generate/:repoid").Get(repo.CreateFromTemplate).
+				Post(bindIgnErr(form.CreateRepoFromTemplate{}), repo.CreateFromTemplatePost)
 		}, reqSignIn)
 
 		m.Group("/:username/:reponame", func() {
@@ -449,6 +461,7 @@ func runWeb(c *cli.Context) error {
 
 					m.Group("/:id", func() {
 						m.Post("/test", repo.TestWebhook)
+						m.Post("/ping", repo.PingWebhook)
 						m.Post("/redelivery", repo.RedeliveryWebhook)
 					})
 
@@ -461,7 +474,7 @@ func runWeb(c *cli.Context) error {
 
 				m.Group("/keys", func() {
 					m.Combo("").Get(repo.SettingsDeployKeys).
-						Post(bindIgnErr(form.AddSSHKey{}), repo.SettingsDeployKeysPost)
+						Post(bindIgnErr(form.AddDeployKey{}), repo.SettingsDeployKeysPost)
 					m.Post("/delete", repo.DeleteDeployKey)
 				})
 			}, func(c *context.Context) {
@@ -509,6 +522,7 @@ func runWeb(c *cli.Context) error {
 					m.Post("/label", repo.UpdateIssueLabel)
 					m.Post("/milestone", repo.UpdateIssueMilestone)
 					m.Post("/assignee", repo.UpdateIssueAssignee)
+					m.Post("/assignees", repo.UpdateIssueAssignees)
 				}, reqRepoWriter)
 			})
 			m.Group("/labels", func() {
@@ -604,6 +618,7 @@ func runWeb(c *cli.Context) error {
 				m.Get("/commits", context.RepoRef(), repo.ViewPullCommits)
 				m.Get("/files", context.RepoRef(), repo.ViewPullFiles)
 				m.Post("/merge", reqRepoWriter, repo.MergePullRequest)
+				m.Post("/approve", reqSignIn, repo.ApprovePullRequest)
 			}, repo.MustAllowPulls)
 
 			m.Group("", func() {
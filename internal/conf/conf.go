@@ -295,13 +295,28 @@ func Init(customConf string) error {
 		Picture.GravatarSource = "https://seccdn.libravatar.org/avatar/"
 	}
 
-	if Server.OfflineMode {
-		Picture.DisableGravatar = true
-		Picture.EnableFederatedAvatar = false
+	switch Picture.AvatarSource {
+	case "":
+		switch {
+		case Picture.DisableGravatar:
+			Picture.AvatarSource = AvatarSourceDisabled
+		case Picture.EnableFederatedAvatar:
+			Picture.AvatarSource = AvatarSourceLibravatar
+		default:
+			Picture.AvatarSource = AvatarSourceGravatar
+		}
+	case AvatarSourceGravatar, AvatarSourceLibravatar, AvatarSourceInternal, AvatarSourceDisabled:
+	default:
+		return errors.Errorf("invalid avatar source %q", Picture.AvatarSource)
 	}
-	if Picture.DisableGravatar {
-		Picture.EnableFederatedAvatar = false
+	if Server.OfflineMode &&
+		(Picture.AvatarSource == AvatarSourceGravatar || Picture.AvatarSource == AvatarSourceLibravatar) {
+		Picture.AvatarSource = AvatarSourceDisabled
 	}
+
+	// Keep the legacy options in sync with the effective avatar source.
+	Picture.DisableGravatar = Picture.AvatarSource != AvatarSourceGravatar && Picture.AvatarSource != AvatarSourceLibravatar
+	Picture.EnableFederatedAvatar = Picture.AvatarSource == AvatarSourceLibravatar
 	if Picture.EnableFederatedAvatar {
 		gravatarURL, err := url.Parse(Picture.GravatarSource)
 		if err != nil {
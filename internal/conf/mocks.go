@@ -25,6 +25,30 @@ func SetMockAuth(t *testing.T, otps AuthOpts) {
 	})
 }
 
+var mockUser sync.Mutex
+
+func SetMockUser(t *testing.T, opts UserOpts) {
+	mockUser.Lock()
+	before := User
+	User = opts
+	t.Cleanup(func() {
+		User = before
+		mockUser.Unlock()
+	})
+}
+
+var mockSecurity sync.Mutex
+
+func SetMockSecurity(t *testing.T, opts SecurityOpts) {
+	mockSecurity.Lock()
+	before := Security
+	Security = opts
+	t.Cleanup(func() {
+		Security = before
+		mockSecurity.Unlock()
+	})
+}
+
 var mockServer sync.Mutex
 
 func SetMockServer(t *testing.T, opts ServerOpts) {
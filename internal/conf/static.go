@@ -34,19 +34,6 @@ var (
 var CustomConf string
 
 var (
-	// Security settings
-	Security struct {
-		InstallLock             bool
-		SecretKey               string
-		LoginRememberDays       int
-		CookieRememberName      string
-		CookieUsername          string
-		CookieSecure            bool
-		EnableLoginStatusCookie bool
-		LoginStatusCookieName   string
-		LocalNetworkAllowlist   []string `delim:","`
-	}
-
 	// Email settings
 	Email struct {
 		Enabled       bool
@@ -71,11 +58,6 @@ var (
 		FromEmail string `ini:"-"` // Parsed email address of From without person's name.
 	}
 
-	// User settings
-	User struct {
-		EnableEmailNotification bool
-	}
-
 	// Session settings
 	Session struct {
 		Provider       string
@@ -129,6 +111,7 @@ var (
 	// Mirror settings
 	Mirror struct {
 		DefaultInterval int
+		MaxSyncFailures int
 	}
 
 	// Webhook settings
@@ -137,6 +120,7 @@ var (
 		DeliverTimeout int
 		SkipTLSVerify  bool `ini:"SKIP_TLS_VERIFY"`
 		PagingNum      int
+		MaxAttempts    int
 	}
 
 	// Markdown settings
@@ -185,6 +169,17 @@ var (
 			Schedule   string
 			OlderThan  time.Duration
 		} `ini:"cron.repo_archive_cleanup"`
+		DeleteExpiredCollaborations struct {
+			Enabled    bool
+			RunAtStart bool
+			Schedule   string
+		} `ini:"cron.delete_expired_collaborations"`
+		PurgeDeletedRepositories struct {
+			Enabled    bool
+			RunAtStart bool
+			Schedule   string
+			Retention  time.Duration
+		} `ini:"cron.purge_deleted_repositories"`
 	}
 
 	// Git settings
@@ -250,6 +245,11 @@ type AuthOpts struct {
 	DisableRegistration       bool
 	EnableRegistrationCaptcha bool
 
+	PasswordMinLength       int
+	PasswordRequiredClasses []string
+	PasswordRejectCommon    bool
+	PasswordBannedList      []string
+
 	EnableReverseProxyAuthentication   bool
 	EnableReverseProxyAutoRegistration bool
 	ReverseProxyAuthenticationHeader   string
@@ -258,6 +258,32 @@ type AuthOpts struct {
 // Authentication settings
 var Auth AuthOpts
 
+type UserOpts struct {
+	EnableEmailNotification bool
+	OrgInvitationExpiry     time.Duration
+}
+
+// User settings
+var User UserOpts
+
+type SecurityOpts struct {
+	InstallLock             bool
+	SecretKey               string
+	LoginRememberDays       int
+	CookieRememberName      string
+	CookieUsername          string
+	CookieSecure            bool
+	EnableLoginStatusCookie bool
+	LoginStatusCookieName   string
+	LocalNetworkAllowlist   []string `delim:","`
+	LoginLockoutThreshold   int
+	LoginLockoutWindow      time.Duration
+	TrustedProxies          []string `delim:","`
+}
+
+// Security settings
+var Security SecurityOpts
+
 type ServerOpts struct {
 	ExternalURL          string `ini:"EXTERNAL_URL"`
 	Domain               string
@@ -317,6 +343,7 @@ type RepositoryOpts struct {
 	ANSICharset              string `ini:"ANSI_CHARSET"`
 	ForcePrivate             bool
 	MaxCreationLimit         int
+	MaxOwnerStorageSize      int64
 	PreferredLicenses        []string
 	DisableHTTPGit           bool `ini:"DISABLE_HTTP_GIT"`
 	EnableLocalPathMigration bool
@@ -354,6 +381,12 @@ type DatabaseOpts struct {
 	Path         string
 	MaxOpenConns int
 	MaxIdleConns int
+	// The host of the read replica for heavy read-only queries, other connection
+	// settings are shared with the primary.
+	ReplicaHost string
+	// The minimum duration of a query to be logged as a slow query, 0 means
+	// disabled.
+	SlowQueryThreshold time.Duration
 }
 
 // Database settings
@@ -392,12 +425,29 @@ type UIOpts struct {
 // UI settings
 var UI UIOpts
 
+// Sources of avatars for users who do not upload custom avatars.
+const (
+	// AvatarSourceGravatar uses the Gravatar-like service of GravatarSource.
+	AvatarSourceGravatar = "gravatar"
+	// AvatarSourceLibravatar uses federated lookup of libravatar, falling back to
+	// the Gravatar-like service of GravatarSource.
+	AvatarSourceLibravatar = "libravatar"
+	// AvatarSourceInternal uses the default avatar without any external request.
+	AvatarSourceInternal = "internal"
+	// AvatarSourceDisabled uses identicons generated by the instance.
+	AvatarSourceDisabled = "disabled"
+)
+
 type PictureOpts struct {
 	AvatarUploadPath           string
 	RepositoryAvatarUploadPath string
 	GravatarSource             string
 	DisableGravatar            bool
 	EnableFederatedAvatar      bool
+	// The source of avatars, one of "gravatar", "libravatar", "internal" or
+	// "disabled". When empty, it is derived from DisableGravatar and
+	// EnableFederatedAvatar for backward compatibility.
+	AvatarSource string
 
 	// Derived from other static values
 	LibravatarService *libravatar.Libravatar `ini:"-"` // Initialized client for federated avatar.
@@ -20,6 +20,8 @@ import (
 	"gogs.io/gogs/internal/auth"
 	"gogs.io/gogs/internal/conf"
 	"gogs.io/gogs/internal/db"
+	"gogs.io/gogs/internal/errutil"
+	"gogs.io/gogs/internal/netutil"
 	"gogs.io/gogs/internal/tool"
 )
 
@@ -151,13 +153,23 @@ func authenticatedUserID(c *macaron.Context, sess session.Store) (_ int64, isTok
 		return 0, false
 	}
 	if id, ok := uid.(int64); ok {
-		_, err := db.Users.GetByID(c.Req.Context(), id)
+		u, err := db.Users.GetByID(c.Req.Context(), id)
 		if err != nil {
 			if !db.IsErrUserNotExist(err) {
 				log.Error("Failed to get user by ID: %v", err)
 			}
 			return 0, false
 		}
+
+		// Sessions created before the user's sessions were invalidated are no longer
+		// valid. Sessions without an epoch were created before epochs existed.
+		epoch, _ := sess.Get("sessionEpoch").(int64)
+		if epoch != u.SessionEpoch {
+			_ = sess.Delete("uid")
+			_ = sess.Delete("uname")
+			_ = sess.Delete("sessionEpoch")
+			return 0, false
+		}
 		return id, false
 	}
 	return 0, false
@@ -210,9 +222,12 @@ func authenticatedUser(ctx *macaron.Context, sess session.Store) (_ *db.User, is
 			if len(auths) == 2 && auths[0] == "Basic" {
 				uname, passwd, _ := tool.BasicAuthDecode(auths[1])
 
-				u, err := db.Users.Authenticate(ctx.Req.Context(), uname, passwd, -1)
+				remoteIP := RemoteIP(ctx.Req.Request)
+				u, err := AuthenticateByPassword(ctx.Req.Context(), remoteIP, uname, passwd)
 				if err != nil {
-					if !auth.IsErrBadCredentials(err) {
+					if auth.IsErrBadCredentials(err) {
+						RecordFailedLogin(ctx.Req.Context(), remoteIP, uname)
+					} else {
 						log.Error("Failed to authenticate user: %v", err)
 					}
 					return nil, false, false
@@ -232,6 +247,42 @@ func authenticatedUser(ctx *macaron.Context, sess session.Store) (_ *db.User, is
 	return u, false, isTokenAuth
 }
 
+// RemoteIP returns the IP address of the client of the request, see
+// netutil.ClientIP for how reverse proxies are handled.
+func RemoteIP(r *http.Request) string {
+	return netutil.ClientIP(r.RemoteAddr, r.Header.Get("X-Forwarded-For"), conf.Security.TrustedProxies)
+}
+
+// AuthenticateByPassword attempts to authenticate a user by the given username
+// and password from the remote IP address. It returns auth.ErrBadCredentials
+// without trying when the username is locked out from the remote IP address,
+// and clears failed login attempts when the authentication succeeds. Callers
+// are responsible for calling RecordFailedLogin once all of their
+// authentication methods have failed.
+func AuthenticateByPassword(ctx context.Context, remoteIP, username, password string) (*db.User, error) {
+	if db.Users.IsLockedOut(ctx, username, remoteIP) {
+		return nil, auth.ErrBadCredentials{Args: errutil.Args{"login": username, "lockedOut": true}}
+	}
+
+	u, err := db.Users.Authenticate(ctx, username, password, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = db.Users.ClearFailedLogins(ctx, username, remoteIP); err != nil {
+		log.Error("Failed to clear failed logins of %q: %v", username, err)
+	}
+	return u, nil
+}
+
+// RecordFailedLogin records a failed login attempt of the username from the
+// remote IP address.
+func RecordFailedLogin(ctx context.Context, remoteIP, username string) {
+	if err := db.Users.RecordFailedLogin(ctx, username, remoteIP); err != nil {
+		log.Error("Failed to record failed login of %q: %v", username, err)
+	}
+}
+
 // AuthenticateByToken attempts to authenticate a user by the given access
 // token. It returns db.ErrAccessTokenNotExist when the access token does not
 // exist.
@@ -68,13 +68,22 @@ func HandleOrgAssignment(c *Context, args ...bool) {
 		c.Org.IsTeamMember = true
 		c.Org.IsTeamAdmin = true
 	} else if c.IsLogged {
-		c.Org.IsOwner = org.IsOwnedBy(c.User.ID)
-		if c.Org.IsOwner {
-			c.Org.IsMember = true
-			c.Org.IsTeamMember = true
-			c.Org.IsTeamAdmin = true
-		} else if org.IsOrgMember(c.User.ID) {
+		membership, err := db.Orgs.GetMembership(c.Req.Context(), org.ID, c.User.ID)
+		if err != nil && !db.IsErrNotOrgMember(err) {
+			c.Error(err, "get membership")
+			return
+		}
+		if membership != nil {
+			if handleOrgTwoFactorRequirement(c, org) {
+				return
+			}
+
+			c.Org.IsOwner = membership.IsOwner
 			c.Org.IsMember = true
+			if c.Org.IsOwner {
+				c.Org.IsTeamMember = true
+				c.Org.IsTeamAdmin = true
+			}
 		}
 	} else {
 		// Fake data.
@@ -140,6 +149,19 @@ func HandleOrgAssignment(c *Context, args ...bool) {
 	}
 }
 
+// handleOrgTwoFactorRequirement redirects the signed in user to enable
+// two-factor authentication when the organization requires it of its members.
+// It returns true if the request has been handled.
+func handleOrgTwoFactorRequirement(c *Context, org *db.User) bool {
+	if !org.RequireTwoFactor || db.TwoFactors.IsEnabled(c.Req.Context(), c.User.ID) {
+		return false
+	}
+
+	c.Flash.Error(c.Tr("org.two_factor_required", org.Name))
+	c.RedirectSubpath("/user/settings/security")
+	return true
+}
+
 func OrgAssignment(args ...bool) macaron.Handler {
 	return func(c *Context) {
 		HandleOrgAssignment(c, args...)
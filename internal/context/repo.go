@@ -7,12 +7,14 @@ package context
 import (
 	"bytes"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/editorconfig/editorconfig-core-go/v2"
 	"github.com/pkg/errors"
 	"gopkg.in/macaron.v1"
+	log "unknwon.dev/clog/v2"
 
 	"github.com/gogs/git-module"
 
@@ -121,6 +123,53 @@ func (r *Repository) PullRequestURL(baseBranch, headBranch string) string {
 	return fmt.Sprintf("%s/compare/%s...%s:%s", repoLink, baseBranch, r.Owner.Name, headBranch)
 }
 
+// RedirectRenamedRepo redirects the request permanently to the new location of
+// the repository when the given name is an old name of a renamed repository,
+// the rest of the request path and the query are preserved. The viewer is nil
+// for anonymous requests, and no redirect is made when the viewer has no read
+// access to the repository to not reveal its new name. It returns true if the
+// response has been written.
+func RedirectRenamedRepo(c *macaron.Context, viewer *db.User, ownerID int64, repoName string) bool {
+	repo, err := db.Repos.GetByRedirect(c.Req.Context(), ownerID, repoName)
+	if err != nil {
+		if !db.IsErrRepoNotExist(err) {
+			log.Error("Failed to get repository by redirect [owner_id: %d, name: %s]: %v", ownerID, repoName, err)
+		}
+		return false
+	}
+
+	if viewer == nil || !viewer.IsAdmin {
+		var viewerID int64
+		if viewer != nil {
+			viewerID = viewer.ID
+		}
+		if !db.Perms.Authorize(c.Req.Context(), viewerID, repo.ID, db.AccessModeRead,
+			db.AccessModeOptions{
+				OwnerID: repo.OwnerID,
+				Private: repo.IsPrivate,
+			},
+		) {
+			return false
+		}
+	}
+
+	// The route parameter may carry suffixes like ".git" or ".wiki.git" that
+	// have been trimmed from the repository name.
+	param := c.Params(":reponame")
+	prefix := "/" + c.Params(":username") + "/" + param
+	i := strings.Index(c.Req.URL.Path, prefix)
+	if i < 0 || len(param) < len(repoName) {
+		return false
+	}
+
+	location := repo.Link() + param[len(repoName):] + c.Req.URL.Path[i+len(prefix):]
+	if c.Req.URL.RawQuery != "" {
+		location += "?" + c.Req.URL.RawQuery
+	}
+	c.Redirect(location, http.StatusMovedPermanently)
+	return true
+}
+
 // [0]: issues, [1]: wiki
 func RepoAssignment(pages ...bool) macaron.Handler {
 	return func(c *Context) {
@@ -156,6 +205,9 @@ func RepoAssignment(pages ...bool) macaron.Handler {
 
 		repo, err := db.GetRepositoryByName(owner.ID, repoName)
 		if err != nil {
+			if db.IsErrRepoNotExist(err) && RedirectRenamedRepo(c.Context, c.User, owner.ID, repoName) {
+				return
+			}
 			c.NotFoundOrError(err, "get repository by name")
 			return
 		}
@@ -171,6 +223,14 @@ func RepoAssignment(pages ...bool) macaron.Handler {
 		if c.IsLogged && c.User.IsAdmin {
 			c.Repo.AccessMode = db.AccessModeOwner
 		} else {
+			// Members of an organization that requires two-factor authentication must
+			// have it enabled to access its repositories.
+			if c.IsLogged && owner.IsOrganization() && owner.RequireTwoFactor &&
+				db.Orgs.HasMember(c.Req.Context(), owner.ID, c.User.ID) &&
+				handleOrgTwoFactorRequirement(c, owner) {
+				return
+			}
+
 			c.Repo.AccessMode = db.Perms.AccessMode(c.Req.Context(), c.UserID(), repo.ID,
 				db.AccessModeOptions{
 					OwnerID: repo.OwnerID,
@@ -196,6 +256,11 @@ func RepoAssignment(pages ...bool) macaron.Handler {
 			c.Repo.AccessMode = mode
 		}
 
+		// Repositories of an archived organization are read-only to everyone.
+		if owner.IsArchived && c.Repo.AccessMode > db.AccessModeRead {
+			c.Repo.AccessMode = db.AccessModeRead
+		}
+
 		// Check access
 		if c.Repo.AccessMode == db.AccessModeNone {
 			// Redirect to any accessible page if not yet on it
@@ -5,9 +5,13 @@
 package context
 
 import (
+	"strings"
+
 	"gopkg.in/macaron.v1"
 
+	"gogs.io/gogs/internal/conf"
 	"gogs.io/gogs/internal/db"
+	"gogs.io/gogs/internal/userutil"
 )
 
 // ParamsUser is the wrapper type of the target user defined by URL parameter, namely ':username'.
@@ -27,3 +31,18 @@ func InjectParamsUser() macaron.Handler {
 		c.Map(&ParamsUser{user})
 	}
 }
+
+// TrPasswordPolicy returns the localized message that describes requirements
+// of the password policy that the password has violated.
+func (c *Context) TrPasswordPolicy(err db.ErrPasswordPolicy) string {
+	violations := err.Violations()
+	reqs := make([]string, 0, len(violations))
+	for _, v := range violations {
+		if v == userutil.PasswordRuleMinLength {
+			reqs = append(reqs, c.Tr("form.password_policy.min_length", conf.Auth.PasswordMinLength))
+			continue
+		}
+		reqs = append(reqs, c.Tr("form.password_policy."+v))
+	}
+	return c.Tr("form.password_policy_violated", strings.Join(reqs, ", "))
+}
@@ -66,6 +66,28 @@ func NewContext() {
 			go db.DeleteOldRepositoryArchives()
 		}
 	}
+	if conf.Cron.DeleteExpiredCollaborations.Enabled {
+		entry, err = c.AddFunc("Delete expired collaborations", conf.Cron.DeleteExpiredCollaborations.Schedule, db.DeleteExpiredCollaborations)
+		if err != nil {
+			log.Fatal("Cron.(delete expired collaborations): %v", err)
+		}
+		if conf.Cron.DeleteExpiredCollaborations.RunAtStart {
+			entry.Prev = time.Now()
+			entry.ExecTimes++
+			go db.DeleteExpiredCollaborations()
+		}
+	}
+	if conf.Cron.PurgeDeletedRepositories.Enabled {
+		entry, err = c.AddFunc("Purge deleted repositories", conf.Cron.PurgeDeletedRepositories.Schedule, db.PurgeDeletedRepositories)
+		if err != nil {
+			log.Fatal("Cron.(purge deleted repositories): %v", err)
+		}
+		if conf.Cron.PurgeDeletedRepositories.RunAtStart {
+			entry.Prev = time.Now()
+			entry.ExecTimes++
+			go db.PurgeDeletedRepositories()
+		}
+	}
 	c.Start()
 }
 
@@ -488,7 +488,7 @@ type CommitRepoOptions struct {
 }
 
 func (db *actions) CommitRepo(ctx context.Context, opts CommitRepoOptions) error {
-	err := NewReposStore(db.DB).Touch(ctx, opts.Repo.ID)
+	err := NewReposStore(db.DB).Touch(ctx, opts.Repo.ID, db.NowFunc().Unix())
 	if err != nil {
 		return errors.Wrap(err, "touch repository")
 	}
@@ -632,7 +632,7 @@ type PushTagOptions struct {
 }
 
 func (db *actions) PushTag(ctx context.Context, opts PushTagOptions) error {
-	err := NewReposStore(db.DB).Touch(ctx, opts.Repo.ID)
+	err := NewReposStore(db.DB).Touch(ctx, opts.Repo.ID, db.NowFunc().Unix())
 	if err != nil {
 		return errors.Wrap(err, "touch repository")
 	}
@@ -31,7 +31,7 @@ func TestDumpAndImport(t *testing.T) {
 	}
 	t.Parallel()
 
-	const wantTables = 8
+	const wantTables = 21
 	if len(Tables) != wantTables {
 		t.Fatalf("New table has added (want %d got %d), please add new tests for the table and update this check", wantTables, len(Tables))
 	}
@@ -132,6 +132,24 @@ func setupDBToDump(t *testing.T, db *gorm.DB) {
 			CreatedUnix:  1588568886,
 		},
 
+		&AuditLog{
+			ID:           1,
+			OrgID:        3,
+			ActorID:      1,
+			Action:       AuditActionAddMember,
+			TargetUserID: 2,
+			CreatedUnix:  1588568886,
+		},
+		&AuditLog{
+			ID:           2,
+			OrgID:        3,
+			ActorID:      1,
+			Action:       AuditActionAddTeamMember,
+			TargetUserID: 2,
+			TeamID:       1,
+			CreatedUnix:  1588568886,
+		},
+
 		&EmailAddress{
 			ID:          1,
 			UserID:      1,
@@ -145,6 +163,14 @@ func setupDBToDump(t *testing.T, db *gorm.DB) {
 			IsActivated: true,
 		},
 
+		&FailedLogin{
+			ID:              1,
+			LowerName:       "alice",
+			IP:              "127.0.0.1",
+			NumAttempts:     3,
+			WindowStartUnix: 1588568886,
+		},
+
 		&Follow{
 			ID:       1,
 			UserID:   1,
@@ -156,6 +182,21 @@ func setupDBToDump(t *testing.T, db *gorm.DB) {
 			FollowID: 1,
 		},
 
+		&GPGKey{
+			ID:          1,
+			OwnerID:     1,
+			KeyID:       "6B6A5B5AA9A39B34",
+			Emails:      "alice@example.com",
+			Content:     "-----BEGIN PGP PUBLIC KEY BLOCK-----",
+			CreatedUnix: 1588568886,
+		},
+
+		&IssueAssignee{
+			ID:      1,
+			IssueID: 1,
+			UserID:  2,
+		},
+
 		&LFSObject{
 			RepoID:    1,
 			OID:       "ef797c8118f02dfb649607dd5d3f8c7623048c9c063d532cc95c5ed7a898a64f",
@@ -197,6 +238,93 @@ func setupDBToDump(t *testing.T, db *gorm.DB) {
 			Description: "This is a notice",
 			CreatedUnix: 1588568886,
 		},
+
+		&OrgInvitation{
+			ID:          1,
+			OrgID:       1,
+			Email:       "cindy@example.com",
+			TeamID:      1,
+			Token:       "Xk5ygXQfMvE5sf6YiAmEAgmpyZ2V4Tk4ZRL9ElGd",
+			CreatedUnix: 1588568886,
+			ExpiresUnix: 1589173686,
+		},
+
+		&OrgLabel{
+			ID:    1,
+			OrgID: 1,
+			Name:  "bug",
+			Color: "#ee0701",
+		},
+		&OrgLabel{
+			ID:    2,
+			OrgID: 1,
+			Name:  "enhancement",
+			Color: "#84b6eb",
+		},
+
+		&OrgProtectBranch{
+			ID:                 1,
+			OrgID:              1,
+			Name:               "main",
+			RequirePullRequest: true,
+			EnableWhitelist:    true,
+			WhitelistUserIDs:   "2,3",
+			WhitelistTeamIDs:   "1",
+		},
+
+		&PinnedRepo{
+			ID:       1,
+			OrgID:    1,
+			RepoID:   2,
+			Position: 0,
+		},
+		&PinnedRepo{
+			ID:       2,
+			OrgID:    1,
+			RepoID:   1,
+			Position: 1,
+		},
+
+		&ProtectedTag{
+			ID:          1,
+			RepoID:      1,
+			Pattern:     "v*",
+			AllowedMode: AccessModeAdmin,
+			CreatedUnix: 1588568886,
+		},
+
+		&PullApproval{
+			ID:          1,
+			PullID:      1,
+			UserID:      2,
+			CommitID:    "0eedd79eba4394bbef888c804e899731644367fe",
+			CreatedUnix: 1588568886,
+		},
+
+		&RepoRedirect{
+			ID:        1,
+			OwnerID:   1,
+			LowerName: "old-name",
+			RepoID:    1,
+		},
+
+		&RepoTopic{
+			ID:      1,
+			RepoID:  1,
+			TopicID: 1,
+		},
+		&RepoTopic{
+			ID:      2,
+			RepoID:  2,
+			TopicID: 1,
+		},
+
+		&Topic{
+			ID:          1,
+			Name:        "go",
+			NumRepos:    2,
+			CreatedUnix: 1588568886,
+		},
 	}
 	for _, val := range vals {
 		err := db.Create(val).Error
@@ -0,0 +1,150 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"gogs.io/gogs/internal/repoutil"
+)
+
+// CodeOwnersStore is the persistent interface for resolving owners designated
+// by CODEOWNERS files to users and teams.
+type CodeOwnersStore interface {
+	// IsOwner returns true if the user is one of the owners of the rule, either
+	// directly or by being a member of an owner team.
+	IsOwner(ctx context.Context, rule *repoutil.CodeOwnersRule, userID int64) (bool, error)
+	// MissingApprovals returns the rules that determine owners of the given
+	// paths, but none of whose owners is among the approvers. Owners that do not
+	// exist are ignored, and rules without any existing owner require no
+	// approval.
+	MissingApprovals(ctx context.Context, owners *repoutil.CodeOwners, paths []string, approverIDs []int64) ([]*repoutil.CodeOwnersRule, error)
+}
+
+var CodeOwners CodeOwnersStore
+
+var _ CodeOwnersStore = (*codeOwners)(nil)
+
+type codeOwners struct {
+	*gorm.DB
+}
+
+// NewCodeOwnersStore returns a persistent interface for code owners with given
+// database connection.
+func NewCodeOwnersStore(db *gorm.DB) CodeOwnersStore {
+	return &codeOwners{DB: db}
+}
+
+// resolvedCodeOwners is the set of existing users and teams that are owners of
+// a CODEOWNERS rule.
+type resolvedCodeOwners struct {
+	userIDs map[int64]struct{}
+	teamIDs []int64
+}
+
+func (o *resolvedCodeOwners) isEmpty() bool {
+	return len(o.userIDs) == 0 && len(o.teamIDs) == 0
+}
+
+func (db *codeOwners) resolve(ctx context.Context, rule *repoutil.CodeOwnersRule) (*resolvedCodeOwners, error) {
+	resolved := &resolvedCodeOwners{
+		userIDs: make(map[int64]struct{}, len(rule.Owners)),
+	}
+	for _, owner := range rule.Owners {
+		orgName, name := repoutil.ParseCodeOwnersOwner(owner)
+		if orgName == "" {
+			user, err := NewUsersStore(db.DB).GetByUsername(ctx, name)
+			if err != nil {
+				if IsErrUserNotExist(err) {
+					continue
+				}
+				return nil, errors.Wrapf(err, "get user %q", name)
+			}
+			resolved.userIDs[user.ID] = struct{}{}
+			continue
+		}
+
+		org, err := NewOrgsStore(db.DB).GetByName(ctx, orgName)
+		if err != nil {
+			if IsErrOrgNotExist(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "get organization %q", orgName)
+		}
+
+		var teamID int64
+		err = db.WithContext(ctx).
+			Model(&Team{}).
+			Select("id").
+			Where("org_id = ? AND lower_name = ?", org.ID, strings.ToLower(name)).
+			Scan(&teamID).
+			Error
+		if err != nil {
+			return nil, errors.Wrapf(err, "get team %q", owner)
+		} else if teamID > 0 {
+			resolved.teamIDs = append(resolved.teamIDs, teamID)
+		}
+	}
+	return resolved, nil
+}
+
+func (db *codeOwners) isResolvedOwner(ctx context.Context, owners *resolvedCodeOwners, userID int64) bool {
+	if _, ok := owners.userIDs[userID]; ok {
+		return true
+	}
+
+	teamsStore := NewTeamsStore(db.DB)
+	for _, teamID := range owners.teamIDs {
+		if teamsStore.IsTeamMember(ctx, teamID, userID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (db *codeOwners) IsOwner(ctx context.Context, rule *repoutil.CodeOwnersRule, userID int64) (bool, error) {
+	owners, err := db.resolve(ctx, rule)
+	if err != nil {
+		return false, err
+	}
+	return db.isResolvedOwner(ctx, owners, userID), nil
+}
+
+func (db *codeOwners) MissingApprovals(ctx context.Context, owners *repoutil.CodeOwners, paths []string, approverIDs []int64) ([]*repoutil.CodeOwnersRule, error) {
+	checked := make(map[*repoutil.CodeOwnersRule]struct{})
+	var missing []*repoutil.CodeOwnersRule
+	for _, path := range paths {
+		rule := owners.Match(path)
+		if rule == nil {
+			continue
+		} else if _, ok := checked[rule]; ok {
+			continue
+		}
+		checked[rule] = struct{}{}
+
+		resolved, err := db.resolve(ctx, rule)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolve owners of %q", rule.Pattern)
+		} else if resolved.isEmpty() {
+			continue
+		}
+
+		approved := false
+		for _, approverID := range approverIDs {
+			if db.isResolvedOwner(ctx, resolved, approverID) {
+				approved = true
+				break
+			}
+		}
+		if !approved {
+			missing = append(missing, rule)
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gogs.io/gogs/internal/dbtest"
+	"gogs.io/gogs/internal/repoutil"
+)
+
+func TestCodeOwners(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	tables := []any{new(User), new(EmailAddress), new(Team), new(TeamUser)}
+	db := &codeOwners{
+		DB: dbtest.NewDB(t, "codeOwners", tables...),
+	}
+
+	for _, tc := range []struct {
+		name string
+		test func(t *testing.T, db *codeOwners)
+	}{
+		{"IsOwner", codeOwnersIsOwner},
+		{"MissingApprovals", codeOwnersMissingApprovals},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Cleanup(func() {
+				err := clearTables(t, db.DB, tables...)
+				require.NoError(t, err)
+			})
+			tc.test(t, db)
+		})
+		if t.Failed() {
+			break
+		}
+	}
+}
+
+// createCodeOwnersTestUsers creates users "alice", "bob" and "cindy", and the
+// organization "org1" with the team "backend" that has "bob" as the member.
+func createCodeOwnersTestUsers(t *testing.T, db *codeOwners) (alice, bob, cindy *User) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err = usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	cindy, err = usersStore.Create(ctx, "cindy", "cindy@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+
+	createTestTeam(t, db.DB, &Team{OrgID: org1.ID, Name: "Backend", Authorize: AccessModeWrite}, []int64{bob.ID}, nil)
+	return alice, bob, cindy
+}
+
+func codeOwnersIsOwner(t *testing.T, db *codeOwners) {
+	ctx := context.Background()
+
+	alice, bob, cindy := createCodeOwnersTestUsers(t, db)
+
+	owners, err := repoutil.ParseCodeOwners(strings.NewReader("* @alice @org1/Backend @nobody @org404/team"))
+	require.NoError(t, err)
+	rule := owners.Rules[0]
+
+	for _, tc := range []struct {
+		name   string
+		userID int64
+		want   bool
+	}{
+		{name: "user owner", userID: alice.ID, want: true},
+		{name: "team owner", userID: bob.ID, want: true},
+		{name: "not an owner", userID: cindy.ID, want: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := db.IsOwner(ctx, rule, tc.userID)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func codeOwnersMissingApprovals(t *testing.T, db *codeOwners) {
+	ctx := context.Background()
+
+	alice, bob, cindy := createCodeOwnersTestUsers(t, db)
+
+	owners, err := repoutil.ParseCodeOwners(strings.NewReader(`
+*.go    @org1/backend
+/docs/  @alice
+/docs/generated/
+*.md    @nobody
+`))
+	require.NoError(t, err)
+
+	patterns := func(rules []*repoutil.CodeOwnersRule) []string {
+		got := make([]string, 0, len(rules))
+		for _, rule := range rules {
+			got = append(got, rule.Pattern)
+		}
+		return got
+	}
+
+	paths := []string{"main.go", "docs/index.go", "docs/generated/api.go", "README.md", "Makefile"}
+	tests := []struct {
+		name         string
+		approverIDs  []int64
+		wantPatterns []string
+	}{
+		{
+			name:         "no approvals",
+			wantPatterns: []string{"*.go", "/docs/"},
+		},
+		{
+			name:         "approved by team member",
+			approverIDs:  []int64{bob.ID},
+			wantPatterns: []string{"/docs/"},
+		},
+		{
+			name:         "approved by all owners",
+			approverIDs:  []int64{cindy.ID, bob.ID, alice.ID},
+			wantPatterns: []string{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := db.MissingApprovals(ctx, owners, paths, test.approverIDs)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantPatterns, patterns(got))
+		})
+	}
+}
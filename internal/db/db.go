@@ -41,28 +41,24 @@ func newLogWriter() (logger.Writer, error) {
 //
 // NOTE: Lines are sorted in alphabetical order, each letter in its own line.
 var Tables = []any{
-	new(Access), new(AccessToken), new(Action),
+	new(Access), new(AccessToken), new(Action), new(AuditLog),
 	new(EmailAddress),
-	new(Follow),
+	new(FailedLogin), new(Follow),
+	new(GPGKey),
+	new(IssueAssignee),
 	new(LFSObject), new(LoginSource),
 	new(Notice),
+	new(OrgInvitation), new(OrgLabel), new(OrgProtectBranch),
+	new(PinnedRepo), new(ProtectedTag), new(PullApproval),
+	new(RepoRedirect), new(RepoTopic),
+	new(Topic),
 }
 
-// Init initializes the database with given logger.
-func Init(w logger.Writer) (*gorm.DB, error) {
-	level := logger.Info
-	if conf.IsProdMode() {
-		level = logger.Warn
-	}
-
-	// NOTE: AutoMigrate does not respect logger passed in gorm.Config.
-	logger.Default = logger.New(w, logger.Config{
-		SlowThreshold: 100 * time.Millisecond,
-		LogLevel:      level,
-	})
-
+// openDB opens a database connection with given options and configures its
+// connection pool.
+func openDB(opts conf.DatabaseOpts) (*gorm.DB, error) {
 	db, err := dbutil.OpenDB(
-		conf.Database,
+		opts,
 		&gorm.Config{
 			SkipDefaultTransaction: true,
 			NamingStrategy: schema.NamingStrategy{
@@ -74,16 +70,54 @@ func Init(w logger.Writer) (*gorm.DB, error) {
 		},
 	)
 	if err != nil {
-		return nil, errors.Wrap(err, "open database")
+		return nil, err
 	}
 
 	sqlDB, err := db.DB()
 	if err != nil {
 		return nil, errors.Wrap(err, "get underlying *sql.DB")
 	}
-	sqlDB.SetMaxOpenConns(conf.Database.MaxOpenConns)
-	sqlDB.SetMaxIdleConns(conf.Database.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(opts.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(opts.MaxIdleConns)
 	sqlDB.SetConnMaxLifetime(time.Minute)
+	return db, nil
+}
+
+// Init initializes the database with given logger.
+func Init(w logger.Writer) (*gorm.DB, error) {
+	level := logger.Info
+	if conf.IsProdMode() {
+		level = logger.Warn
+	}
+
+	// NOTE: AutoMigrate does not respect logger passed in gorm.Config.
+	logger.Default = logger.New(w, logger.Config{
+		SlowThreshold: 100 * time.Millisecond,
+		LogLevel:      level,
+	})
+	if conf.Database.SlowQueryThreshold > 0 {
+		logger.Default = &dbutil.SlowQueryLogger{
+			Interface:     logger.Default,
+			Writer:        w,
+			Threshold:     conf.Database.SlowQueryThreshold,
+			CallerPackage: "gogs.io/gogs/internal/db",
+		}
+	}
+
+	db, err := openDB(conf.Database)
+	if err != nil {
+		return nil, errors.Wrap(err, "open database")
+	}
+
+	var replica *gorm.DB
+	if conf.Database.ReplicaHost != "" && conf.Database.Type != "sqlite3" {
+		opts := conf.Database
+		opts.Host = opts.ReplicaHost
+		replica, err = openDB(opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "open database replica")
+		}
+	}
 
 	switch conf.Database.Type {
 	case "postgres":
@@ -123,14 +157,23 @@ func Init(w logger.Writer) (*gorm.DB, error) {
 	// Initialize stores, sorted in alphabetical order.
 	AccessTokens = &accessTokens{DB: db}
 	Actions = NewActionsStore(db)
+	CodeOwners = NewCodeOwnersStore(db)
+	Issues = NewIssuesStore(db)
 	LoginSources = &loginSources{DB: db, files: sourceFiles}
 	LFS = &lfs{DB: db}
 	Notices = NewNoticesStore(db)
 	Orgs = NewOrgsStore(db)
+	OrgsReplica = Orgs
+	if replica != nil {
+		OrgsReplica = NewOrgsStoreWithReplica(db, replica)
+	}
 	Perms = NewPermsStore(db)
+	Pulls = NewPullsStore(db)
 	Repos = NewReposStore(db)
+	Teams = NewTeamsStore(db)
 	TwoFactors = &twoFactors{DB: db}
 	Users = NewUsersStore(db)
+	Webhooks = NewWebhooksStore(db)
 
 	return db, nil
 }
@@ -6,6 +6,8 @@ package db
 
 import (
 	"fmt"
+
+	"github.com/pkg/errors"
 )
 
 //  __      __.__ __   .__
@@ -145,7 +147,7 @@ type ErrLastOrgOwner struct {
 }
 
 func IsErrLastOrgOwner(err error) bool {
-	_, ok := err.(ErrLastOrgOwner)
+	_, ok := errors.Cause(err).(ErrLastOrgOwner)
 	return ok
 }
 
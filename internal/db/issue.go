@@ -33,7 +33,7 @@ type Issue struct {
 	Index           int64       `xorm:"UNIQUE(repo_index)" gorm:"uniqueIndex:issue_repo_index_unique;not null"` // Index in one repository.
 	PosterID        int64       `gorm:"index"`
 	Poster          *User       `xorm:"-" json:"-" gorm:"-"`
-	Title           string      `xorm:"name" gorm:"name"`
+	Title           string      `xorm:"name" gorm:"column:name"`
 	Content         string      `xorm:"TEXT" gorm:"type:TEXT"`
 	RenderedContent string      `xorm:"-" json:"-" gorm:"-"`
 	Labels          []*Label    `xorm:"-" json:"-" gorm:"-"`
@@ -608,53 +608,103 @@ func (issue *Issue) ChangeContent(doer *User, content string) (err error) {
 	return nil
 }
 
-func (issue *Issue) ChangeAssignee(doer *User, assigneeID int64) (err error) {
-	issue.AssigneeID = assigneeID
-	if err = UpdateIssueUserByAssignee(issue); err != nil {
-		return fmt.Errorf("UpdateIssueUserByAssignee: %v", err)
+// ChangeAssignee replaces all assignees of the issue with the single user, or
+// removes all assignees when the assigneeID is 0.
+func (issue *Issue) ChangeAssignee(doer *User, assigneeID int64) error {
+	var assigneeIDs []int64
+	if assigneeID > 0 {
+		assigneeIDs = []int64{assigneeID}
 	}
+	return issue.ChangeAssignees(doer, assigneeIDs)
+}
 
-	issue.Assignee, err = Users.GetByID(context.TODO(), issue.AssigneeID)
-	if err != nil && !IsErrUserNotExist(err) {
-		log.Error("Failed to get user by ID: %v", err)
-		return nil
+// ChangeAssignees replaces all assignees of the issue with given users. Webhooks
+// are triggered for assignees that have been removed or added, and newly
+// assigned users are notified by email.
+func (issue *Issue) ChangeAssignees(doer *User, assigneeIDs []int64) error {
+	ctx := context.TODO()
+
+	olds, err := Issues.ListAssignees(ctx, issue.ID)
+	if err != nil {
+		return fmt.Errorf("list old assignees: %v", err)
+	}
+	if err = Issues.SetAssignees(ctx, issue.ID, assigneeIDs); err != nil {
+		return fmt.Errorf("set assignees: %v", err)
+	}
+	news, err := Issues.ListAssignees(ctx, issue.ID)
+	if err != nil {
+		return fmt.Errorf("list new assignees: %v", err)
+	}
+
+	// The legacy single assignee is the first of given users that is valid.
+	issue.AssigneeID = 0
+	issue.Assignee = nil
+	isAssigned := make(map[int64]*User, len(news))
+	for _, u := range news {
+		isAssigned[u.ID] = u
+	}
+	for _, id := range assigneeIDs {
+		if u := isAssigned[id]; u != nil {
+			issue.AssigneeID = u.ID
+			issue.Assignee = u
+			break
+		}
 	}
 
-	// Error not nil here means user does not exist, which is remove assignee.
-	isRemoveAssignee := err != nil
+	wasAssigned := make(map[int64]bool, len(olds))
+	for _, u := range olds {
+		wasAssigned[u.ID] = true
+	}
+	added := make([]*User, 0, len(news))
+	for _, u := range news {
+		if !wasAssigned[u.ID] {
+			added = append(added, u)
+		}
+	}
+	hasRemoved := false
+	for _, u := range olds {
+		if isAssigned[u.ID] == nil {
+			hasRemoved = true
+			break
+		}
+	}
+
+	if hasRemoved {
+		issue.prepareAssigneeWebhooks(doer, api.HOOK_ISSUE_UNASSIGNED)
+	}
+	if len(added) > 0 {
+		issue.prepareAssigneeWebhooks(doer, api.HOOK_ISSUE_ASSIGNED)
+	}
+
+	if err = mailIssueAssignees(issue, doer, added); err != nil {
+		log.Error("mailIssueAssignees: %v", err)
+	}
+	return nil
+}
+
+func (issue *Issue) prepareAssigneeWebhooks(doer *User, action api.HookIssueAction) {
+	var err error
 	if issue.IsPull {
 		issue.PullRequest.Issue = issue
-		apiPullRequest := &api.PullRequestPayload{
+		err = PrepareWebhooks(issue.Repo, HOOK_EVENT_PULL_REQUEST, &api.PullRequestPayload{
+			Action:      action,
 			Index:       issue.Index,
 			PullRequest: issue.PullRequest.APIFormat(),
 			Repository:  issue.Repo.APIFormatLegacy(nil),
 			Sender:      doer.APIFormat(),
-		}
-		if isRemoveAssignee {
-			apiPullRequest.Action = api.HOOK_ISSUE_UNASSIGNED
-		} else {
-			apiPullRequest.Action = api.HOOK_ISSUE_ASSIGNED
-		}
-		err = PrepareWebhooks(issue.Repo, HOOK_EVENT_PULL_REQUEST, apiPullRequest)
+		})
 	} else {
-		apiIssues := &api.IssuesPayload{
+		err = PrepareWebhooks(issue.Repo, HOOK_EVENT_ISSUES, &api.IssuesPayload{
+			Action:     action,
 			Index:      issue.Index,
 			Issue:      issue.APIFormat(),
 			Repository: issue.Repo.APIFormatLegacy(nil),
 			Sender:     doer.APIFormat(),
-		}
-		if isRemoveAssignee {
-			apiIssues.Action = api.HOOK_ISSUE_UNASSIGNED
-		} else {
-			apiIssues.Action = api.HOOK_ISSUE_ASSIGNED
-		}
-		err = PrepareWebhooks(issue.Repo, HOOK_EVENT_ISSUES, apiIssues)
+		})
 	}
 	if err != nil {
-		log.Error("PrepareWebhooks [is_pull: %v, remove_assignee: %v]: %v", issue.IsPull, isRemoveAssignee, err)
+		log.Error("PrepareWebhooks [is_pull: %v, action: %v]: %v", issue.IsPull, action, err)
 	}
-
-	return nil
 }
 
 type NewIssueOptions struct {
@@ -706,6 +756,13 @@ func newIssue(e *xorm.Session, opts NewIssueOptions) (err error) {
 		return err
 	}
 
+	if opts.Issue.AssigneeID > 0 {
+		_, err = e.Exec("INSERT INTO `issue_assignee` (issue_id, user_id) VALUES (?, ?)", opts.Issue.ID, opts.Issue.AssigneeID)
+		if err != nil {
+			return fmt.Errorf("insert issue assignee: %v", err)
+		}
+	}
+
 	if opts.IsPull {
 		_, err = e.Exec("UPDATE `repository` SET num_pulls = num_pulls + 1 WHERE id = ?", opts.Issue.RepoID)
 	} else {
@@ -756,8 +813,13 @@ func newIssue(e *xorm.Session, opts NewIssueOptions) (err error) {
 	return opts.Issue.loadAttributes(e)
 }
 
-// NewIssue creates new issue with labels and attachments for repository.
+// NewIssue creates new issue with labels and attachments for repository. It
+// returns ErrIssuesDisabled when the repository uses an external issue tracker.
 func NewIssue(repo *Repository, issue *Issue, labelIDs []int64, uuids []string) (err error) {
+	if repo.EnableExternalTracker {
+		return ErrIssuesDisabled{args: errutil.Args{"repoID": repo.ID}}
+	}
+
 	sess := x.NewSession()
 	defer sess.Close()
 	if err = sess.Begin(); err != nil {
@@ -990,8 +1052,8 @@ func IssuesCount(opts *IssuesOptions) (int64, error) {
 	return sess.Count(&Issue{})
 }
 
-// Issues returns a list of issues by given conditions.
-func Issues(opts *IssuesOptions) ([]*Issue, error) {
+// ListIssues returns a list of issues by given conditions.
+func ListIssues(opts *IssuesOptions) ([]*Issue, error) {
 	sess := buildIssuesQuery(opts)
 	if sess == nil {
 		return make([]*Issue, 0), nil
@@ -1406,36 +1468,6 @@ func UpdateIssueUsersByStatus(issueID int64, isClosed bool) error {
 	return updateIssueUsersByStatus(x, issueID, isClosed)
 }
 
-func updateIssueUserByAssignee(e *xorm.Session, issue *Issue) (err error) {
-	if _, err = e.Exec("UPDATE `issue_user` SET is_assigned = ? WHERE issue_id = ?", false, issue.ID); err != nil {
-		return err
-	}
-
-	// Assignee ID equals to 0 means clear assignee.
-	if issue.AssigneeID > 0 {
-		if _, err = e.Exec("UPDATE `issue_user` SET is_assigned = ? WHERE uid = ? AND issue_id = ?", true, issue.AssigneeID, issue.ID); err != nil {
-			return err
-		}
-	}
-
-	return updateIssue(e, issue)
-}
-
-// UpdateIssueUserByAssignee updates issue-user relation for assignee.
-func UpdateIssueUserByAssignee(issue *Issue) (err error) {
-	sess := x.NewSession()
-	defer sess.Close()
-	if err = sess.Begin(); err != nil {
-		return err
-	}
-
-	if err = updateIssueUserByAssignee(sess, issue); err != nil {
-		return err
-	}
-
-	return sess.Commit()
-}
-
 // UpdateIssueUserByRead updates issue-user relation for reading.
 func UpdateIssueUserByRead(uid, issueID int64) error {
 	_, err := x.Exec("UPDATE `issue_user` SET is_read=? WHERE uid=? AND issue_id=?", true, uid, issueID)
@@ -115,6 +115,27 @@ func mailIssueCommentToParticipants(issue *Issue, doer *User, mentions []string)
 		return fmt.Errorf("GetParticipantsByIssueID [issue_id: %d]: %v", issue.ID, err)
 	}
 
+	// Members of the organization that owns the repository may have opted out of
+	// some notifications, the map is empty when the owner is not an organization.
+	notifyLevels, err := Orgs.ListMemberNotifyLevels(ctx, issue.Repo.OwnerID)
+	if err != nil {
+		return errors.Wrap(err, "list organization member notify levels")
+	}
+	isMuted := func(userID int64, participating bool) bool {
+		level, ok := notifyLevels[userID]
+		if !ok {
+			return false
+		}
+		switch level {
+		case OrgNotifyLevelAll:
+			return false
+		case OrgNotifyLevelNone:
+			return true
+		default:
+			return !participating
+		}
+	}
+
 	// In case the issue poster is not watching the repository,
 	// even if we have duplicated in watchers, can be safely filtered out.
 	if issue.PosterID != doer.ID {
@@ -124,7 +145,7 @@ func mailIssueCommentToParticipants(issue *Issue, doer *User, mentions []string)
 	tos := make([]string, 0, len(watchers)) // List of email addresses
 	names := make([]string, 0, len(watchers))
 	for i := range watchers {
-		if watchers[i].UserID == doer.ID {
+		if watchers[i].UserID == doer.ID || isMuted(watchers[i].UserID, false) {
 			continue
 		}
 
@@ -140,7 +161,7 @@ func mailIssueCommentToParticipants(issue *Issue, doer *User, mentions []string)
 		names = append(names, to.Name)
 	}
 	for i := range participants {
-		if participants[i].ID == doer.ID {
+		if participants[i].ID == doer.ID || isMuted(participants[i].ID, true) {
 			continue
 		} else if com.IsSliceContainsStr(names, participants[i].Name) {
 			continue
@@ -149,7 +170,7 @@ func mailIssueCommentToParticipants(issue *Issue, doer *User, mentions []string)
 		tos = append(tos, participants[i].Email)
 		names = append(names, participants[i].Name)
 	}
-	if issue.Assignee != nil && issue.Assignee.ID != doer.ID {
+	if issue.Assignee != nil && issue.Assignee.ID != doer.ID && !isMuted(issue.Assignee.ID, true) {
 		if !com.IsSliceContainsStr(names, issue.Assignee.Name) {
 			tos = append(tos, issue.Assignee.Email)
 			names = append(names, issue.Assignee.Name)
@@ -157,8 +178,24 @@ func mailIssueCommentToParticipants(issue *Issue, doer *User, mentions []string)
 	}
 	email.SendIssueCommentMail(NewMailerIssue(issue), NewMailerRepo(issue.Repo), NewMailerUser(doer), tos)
 
-	// Mail mentioned people and exclude watchers.
+	// Mail mentioned people and exclude watchers and members who have muted the
+	// organization.
 	names = append(names, doer.Name)
+	mutedIDs := make([]int64, 0, len(notifyLevels))
+	for userID := range notifyLevels {
+		if isMuted(userID, true) {
+			mutedIDs = append(mutedIDs, userID)
+		}
+	}
+	if len(mutedIDs) > 0 {
+		mutedUsers := make([]*User, 0, len(mutedIDs))
+		if err = x.Cols("name").In("id", mutedIDs).Find(&mutedUsers); err != nil {
+			return errors.Wrap(err, "get muted users")
+		}
+		for _, u := range mutedUsers {
+			names = append(names, u.Name)
+		}
+	}
 	toUsernames := make([]string, 0, len(mentions)) // list of user names.
 	for i := range mentions {
 		if com.IsSliceContainsStr(names, mentions[i]) {
@@ -176,6 +213,32 @@ func mailIssueCommentToParticipants(issue *Issue, doer *User, mentions []string)
 	return nil
 }
 
+// mailIssueAssignees sends emails to users who have been newly assigned to the
+// issue, except the doer and members who have muted all notifications of the
+// organization that owns the repository.
+func mailIssueAssignees(issue *Issue, doer *User, assignees []*User) error {
+	if !conf.User.EnableEmailNotification || len(assignees) == 0 {
+		return nil
+	}
+
+	notifyLevels, err := Orgs.ListMemberNotifyLevels(context.TODO(), issue.Repo.OwnerID)
+	if err != nil {
+		return errors.Wrap(err, "list organization member notify levels")
+	}
+
+	tos := make([]string, 0, len(assignees))
+	for _, u := range assignees {
+		if u.ID == doer.ID || u.IsOrganization() || !u.IsActive {
+			continue
+		} else if level, ok := notifyLevels[u.ID]; ok && level == OrgNotifyLevelNone {
+			continue
+		}
+		tos = append(tos, u.Email)
+	}
+	email.SendIssueAssignedMail(NewMailerIssue(issue), NewMailerRepo(issue.Repo), NewMailerUser(doer), tos)
+	return nil
+}
+
 // MailParticipants sends new issue thread created emails to repository watchers
 // and mentioned people.
 func (issue *Issue) MailParticipants() (err error) {
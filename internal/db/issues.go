@@ -0,0 +1,287 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	"gogs.io/gogs/internal/dbutil"
+	"gogs.io/gogs/internal/errutil"
+)
+
+// IssuesStore is the persistent interface for issues.
+type IssuesStore interface {
+	// SetAssignees replaces the set of assignees of the issue with given user IDs,
+	// the order of user IDs is preserved and duplicates are ignored. The legacy
+	// "issue.assignee_id" is kept in sync with the first assignee, and
+	// "issue_user.is_assigned" is updated for every user whose assignment has
+	// changed. It returns ErrIssueNotExist when not found.
+	SetAssignees(ctx context.Context, issueID int64, userIDs []int64) error
+	// ListAssignees returns all assignees of the issue, in the order they were
+	// assigned.
+	ListAssignees(ctx context.Context, issueID int64) ([]*User, error)
+	// Search returns a range of issues whose titles or contents contain the
+	// keyword case-insensitively, in repositories that the user has access to,
+	// sorted by the time of last update in descending order. Results are
+	// paginated by opts.Page and opts.PageSize, and a total count of all results
+	// is also returned. The userID can be 0 for anonymous users, who only have
+	// access to public repositories.
+	Search(ctx context.Context, userID int64, keyword string, opts SearchIssuesOptions) ([]*Issue, int64, error)
+}
+
+var Issues IssuesStore
+
+var _ IssuesStore = (*issues)(nil)
+
+type issues struct {
+	*gorm.DB
+}
+
+// NewIssuesStore returns a persistent interface for issues with given database
+// connection.
+func NewIssuesStore(db *gorm.DB) IssuesStore {
+	return &issues{DB: db}
+}
+
+type ErrIssuesDisabled struct {
+	args errutil.Args
+}
+
+// IsErrIssuesDisabled returns true if the underlying error has the type
+// ErrIssuesDisabled.
+func IsErrIssuesDisabled(err error) bool {
+	_, ok := errors.Cause(err).(ErrIssuesDisabled)
+	return ok
+}
+
+func (err ErrIssuesDisabled) Error() string {
+	return fmt.Sprintf("issues are disabled in favor of the external issue tracker: %v", err.args)
+}
+
+// IssueAssignee represents an assignee of an issue or pull request.
+type IssueAssignee struct {
+	ID      int64 `gorm:"primaryKey"`
+	IssueID int64 `gorm:"uniqueIndex:issue_assignee_issue_user_unique;not null"`
+	UserID  int64 `gorm:"uniqueIndex:issue_assignee_issue_user_unique;index;not null"`
+}
+
+func (db *issues) SetAssignees(ctx context.Context, issueID int64, userIDs []int64) error {
+	seen := make(map[int64]bool, len(userIDs))
+	wants := make([]int64, 0, len(userIDs))
+	for _, id := range userIDs {
+		if id <= 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		wants = append(wants, id)
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var issue Issue
+		err := tx.Select("id", "repo_id", "milestone_id", "is_closed").Where("id = ?", issueID).First(&issue).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrIssueNotExist{args: map[string]any{"issueID": issueID}}
+			}
+			return errors.Wrap(err, "get issue")
+		}
+
+		var currents []int64
+		err = tx.Model(&IssueAssignee{}).Where("issue_id = ?", issueID).Pluck("user_id", &currents).Error
+		if err != nil {
+			return errors.Wrap(err, "list current assignees")
+		}
+
+		hasCurrent := make(map[int64]bool, len(currents))
+		removes := make([]int64, 0, len(currents))
+		for _, id := range currents {
+			hasCurrent[id] = true
+			if !seen[id] {
+				removes = append(removes, id)
+			}
+		}
+
+		adds := make([]int64, 0, len(wants))
+		for _, id := range wants {
+			if !hasCurrent[id] {
+				adds = append(adds, id)
+			}
+		}
+
+		if len(removes) > 0 {
+			err = tx.Where("issue_id = ? AND user_id IN (?)", issueID, removes).Delete(&IssueAssignee{}).Error
+			if err != nil {
+				return errors.Wrap(err, "delete assignees")
+			}
+		}
+
+		// NOTE: The legacy single assignee may not have a corresponding row in
+		// "issue_assignee", so every issue-user relation that is no longer wanted is
+		// unset regardless.
+		unassigned := tx.Model(&IssueUser{}).Where("issue_id = ? AND is_assigned = ?", issueID, true)
+		if len(wants) > 0 {
+			unassigned = unassigned.Where("uid NOT IN (?)", wants)
+		}
+		err = unassigned.Update("is_assigned", false).Error
+		if err != nil {
+			return errors.Wrap(err, `unset "issue_user.is_assigned"`)
+		}
+
+		if len(adds) > 0 {
+			assignees := make([]*IssueAssignee, 0, len(adds))
+			for _, id := range adds {
+				assignees = append(assignees, &IssueAssignee{IssueID: issueID, UserID: id})
+			}
+			err = tx.Create(&assignees).Error
+			if err != nil {
+				return errors.Wrap(err, "create assignees")
+			}
+
+			for _, id := range adds {
+				err = db.markIssueUserAssigned(tx, &issue, id)
+				if err != nil {
+					return errors.Wrapf(err, "mark issue user %d as assigned", id)
+				}
+			}
+		}
+
+		var assigneeID int64
+		if len(wants) > 0 {
+			assigneeID = wants[0]
+		}
+
+		/*
+			Equivalent SQL for PostgreSQL:
+
+			UPDATE issue
+			SET assignee_id = @assigneeID
+			WHERE id = @issueID
+		*/
+		err = tx.Model(&Issue{}).Where("id = ?", issueID).Update("assignee_id", assigneeID).Error
+		if err != nil {
+			return errors.Wrap(err, `update "issue.assignee_id"`)
+		}
+		return nil
+	})
+}
+
+func (db *issues) ListAssignees(ctx context.Context, issueID int64) ([]*User, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT "user".* FROM "user"
+		JOIN issue_assignee ON issue_assignee.user_id = "user".id
+		WHERE issue_assignee.issue_id = @issueID
+		ORDER BY issue_assignee.id ASC
+	*/
+	users := make([]*User, 0, 2)
+	return users, db.WithContext(ctx).
+		Joins(dbutil.Quote("JOIN issue_assignee ON issue_assignee.user_id = %s.id", "user")).
+		Where("issue_assignee.issue_id = ?", issueID).
+		Order("issue_assignee.id ASC").
+		Find(&users).
+		Error
+}
+
+// markIssueUserAssigned sets "issue_user.is_assigned" for the user of the
+// issue, the issue-user relation is created if it does not exist yet.
+func (*issues) markIssueUserAssigned(tx *gorm.DB, issue *Issue, userID int64) error {
+	var count int64
+	err := tx.Model(&IssueUser{}).Where("issue_id = ? AND uid = ?", issue.ID, userID).Count(&count).Error
+	if err != nil {
+		return errors.Wrap(err, "count")
+	}
+
+	if count > 0 {
+		err = tx.Model(&IssueUser{}).
+			Where("issue_id = ? AND uid = ?", issue.ID, userID).
+			Update("is_assigned", true).
+			Error
+		return errors.Wrap(err, "update")
+	}
+
+	err = tx.Create(
+		&IssueUser{
+			UserID:      userID,
+			IssueID:     issue.ID,
+			RepoID:      issue.RepoID,
+			MilestoneID: issue.MilestoneID,
+			IsAssigned:  true,
+			IsClosed:    issue.IsClosed,
+		},
+	).Error
+	return errors.Wrap(err, "create")
+}
+
+type SearchIssuesOptions struct {
+	// Whether to only include closed (true) or open (false) issues, nil for
+	// both.
+	IsClosed *bool
+	// Whether to only include pull requests (true) or issues (false), nil for
+	// both.
+	IsPull *bool
+	// The page number of results, starting from 1.
+	Page int
+	// The number of results per page.
+	PageSize int
+}
+
+func (db *issues) Search(ctx context.Context, userID int64, keyword string, opts SearchIssuesOptions) ([]*Issue, int64, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT * FROM issue
+		WHERE
+			repo_id IN (<accessible repository IDs>)
+		AND (
+				LOWER(name) LIKE @keyword ESCAPE '!'
+			OR  LOWER(content) LIKE @keyword ESCAPE '!'
+		)
+		[AND is_closed = @isClosed]
+		[AND is_pull = @isPull]
+		ORDER BY updated_unix DESC
+		LIMIT @limit OFFSET @offset
+	*/
+	conn := db.WithContext(ctx)
+	pattern := "%" + dbutil.EscapeLike(strings.ToLower(keyword)) + "%"
+	query := func() *gorm.DB {
+		q := conn.Model(&Issue{}).
+			Where("repo_id IN (?)", accessibleRepositoryIDs(conn, userID)).
+			Where("LOWER(name) LIKE ? ESCAPE '!' OR LOWER(content) LIKE ? ESCAPE '!'", pattern, pattern)
+		if opts.IsClosed != nil {
+			q = q.Where("is_closed = ?", *opts.IsClosed)
+		}
+		if opts.IsPull != nil {
+			q = q.Where("is_pull = ?", *opts.IsPull)
+		}
+		return q
+	}
+
+	var count int64
+	err := query().Count(&count).Error
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "count")
+	}
+
+	page := opts.Page
+	if page <= 0 {
+		page = 1
+	}
+	var issues []*Issue
+	err = query().
+		Order("updated_unix DESC").
+		Limit(opts.PageSize).Offset((page - 1) * opts.PageSize).
+		Find(&issues).
+		Error
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "list")
+	}
+	return issues, count, nil
+}
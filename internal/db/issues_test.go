@@ -0,0 +1,222 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gogs.io/gogs/internal/dbtest"
+)
+
+func TestIssues(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	tables := []any{new(Issue), new(IssueAssignee), new(IssueUser), new(User), new(EmailAddress), new(Repository), new(Access), new(Collaboration)}
+	db := &issues{
+		DB: dbtest.NewDB(t, "issues", tables...),
+	}
+
+	for _, tc := range []struct {
+		name string
+		test func(t *testing.T, db *issues)
+	}{
+		{"SetAssignees", issuesSetAssignees},
+		{"ListAssignees", issuesListAssignees},
+		{"Search", issuesSearch},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Cleanup(func() {
+				err := clearTables(t, db.DB, tables...)
+				require.NoError(t, err)
+			})
+			tc.test(t, db)
+		})
+		if t.Failed() {
+			break
+		}
+	}
+}
+
+func issuesSetAssignees(t *testing.T, db *issues) {
+	ctx := context.Background()
+
+	t.Run("issue does not exist", func(t *testing.T) {
+		err := db.SetAssignees(ctx, 404, []int64{1})
+		assert.True(t, IsErrIssueNotExist(err))
+	})
+
+	issue := &Issue{RepoID: 1, Index: 1, PosterID: 1, AssigneeID: 3}
+	err := db.Create(issue).Error
+	require.NoError(t, err)
+
+	// Simulate relations created by the legacy single-assignee path.
+	err = db.Create(
+		[]*IssueUser{
+			{UserID: 1, IssueID: issue.ID, RepoID: 1, IsPoster: true},
+			{UserID: 3, IssueID: issue.ID, RepoID: 1, IsAssigned: true},
+		},
+	).Error
+	require.NoError(t, err)
+
+	assertAssignees := func(t *testing.T, wantAssigneeID int64, wantUserIDs ...int64) {
+		t.Helper()
+
+		var userIDs []int64
+		err := db.Model(&IssueAssignee{}).Where("issue_id = ?", issue.ID).Order("user_id").Pluck("user_id", &userIDs).Error
+		require.NoError(t, err)
+		assert.ElementsMatch(t, wantUserIDs, userIDs)
+
+		var assigneeID int64
+		err = db.Model(&Issue{}).Where("id = ?", issue.ID).Select("assignee_id").Scan(&assigneeID).Error
+		require.NoError(t, err)
+		assert.Equal(t, wantAssigneeID, assigneeID)
+
+		var assignedIDs []int64
+		err = db.Model(&IssueUser{}).Where("issue_id = ? AND is_assigned = ?", issue.ID, true).Pluck("uid", &assignedIDs).Error
+		require.NoError(t, err)
+		assert.ElementsMatch(t, wantUserIDs, assignedIDs)
+	}
+
+	// Duplicates and invalid IDs are ignored, the poster's existing relation is
+	// reused.
+	err = db.SetAssignees(ctx, issue.ID, []int64{2, 1, 2, 0})
+	require.NoError(t, err)
+	assertAssignees(t, 2, 1, 2)
+
+	var count int64
+	err = db.Model(&IssueUser{}).Where("issue_id = ? AND uid = ?", issue.ID, 1).Count(&count).Error
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	err = db.SetAssignees(ctx, issue.ID, []int64{1, 4})
+	require.NoError(t, err)
+	assertAssignees(t, 1, 1, 4)
+
+	err = db.SetAssignees(ctx, issue.ID, nil)
+	require.NoError(t, err)
+	assertAssignees(t, 0)
+}
+
+func issuesListAssignees(t *testing.T, db *issues) {
+	ctx := context.Background()
+
+	alice, err := NewUsersStore(db.DB).Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := NewUsersStore(db.DB).Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	issue := &Issue{RepoID: 1, Index: 1, PosterID: alice.ID}
+	err = db.Create(issue).Error
+	require.NoError(t, err)
+
+	assignees, err := db.ListAssignees(ctx, issue.ID)
+	require.NoError(t, err)
+	assert.Empty(t, assignees)
+
+	err = db.SetAssignees(ctx, issue.ID, []int64{bob.ID, alice.ID})
+	require.NoError(t, err)
+
+	assignees, err = db.ListAssignees(ctx, issue.ID)
+	require.NoError(t, err)
+	got := make([]string, 0, len(assignees))
+	for _, u := range assignees {
+		got = append(got, u.Name)
+	}
+	assert.Equal(t, []string{"bob", "alice"}, got)
+}
+
+func issuesSearch(t *testing.T, db *issues) {
+	ctx := context.Background()
+
+	repos := []*Repository{
+		{ID: 1, OwnerID: 1, LowerName: "public", Name: "public"},
+		{ID: 2, OwnerID: 1, LowerName: "private", Name: "private", IsPrivate: true},
+		{ID: 3, OwnerID: 2, LowerName: "owned", Name: "owned", IsPrivate: true},
+		{ID: 4, OwnerID: 1, LowerName: "collaborated", Name: "collaborated", IsPrivate: true},
+		{ID: 5, OwnerID: 1, LowerName: "expired", Name: "expired", IsPrivate: true},
+	}
+	err := db.Create(repos).Error
+	require.NoError(t, err)
+
+	err = db.Create(
+		[]*Access{
+			{RepoID: 4, UserID: 2, Mode: AccessModeRead},
+			{RepoID: 5, UserID: 2, Mode: AccessModeWrite},
+		},
+	).Error
+	require.NoError(t, err)
+	err = db.Create(&Collaboration{RepoID: 5, UserID: 2, Mode: AccessModeWrite, ExpiresUnix: 1}).Error
+	require.NoError(t, err)
+
+	issues := []*Issue{
+		{RepoID: 1, Index: 1, Title: "Fix the LOGIN page", UpdatedUnix: 1},
+		{RepoID: 1, Index: 2, Title: "Unrelated", Content: "Cannot login with 100% success", UpdatedUnix: 2, IsClosed: true},
+		{RepoID: 2, Index: 1, Title: "Login in private", UpdatedUnix: 3},
+		{RepoID: 3, Index: 1, Title: "Login in owned", UpdatedUnix: 4, IsPull: true},
+		{RepoID: 4, Index: 1, Title: "Login in collaborated", UpdatedUnix: 5},
+		{RepoID: 5, Index: 1, Title: "Login in expired", UpdatedUnix: 6},
+	}
+	for _, issue := range issues {
+		err = db.Create(issue).Error
+		require.NoError(t, err)
+	}
+
+	titles := func(issues []*Issue) []string {
+		got := make([]string, 0, len(issues))
+		for _, issue := range issues {
+			got = append(got, issue.Title)
+		}
+		return got
+	}
+
+	t.Run("anonymous", func(t *testing.T) {
+		got, count, err := db.Search(ctx, 0, "login", SearchIssuesOptions{Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+		assert.Equal(t, []string{"Unrelated", "Fix the LOGIN page"}, titles(got))
+	})
+
+	t.Run("accessible repositories", func(t *testing.T) {
+		got, count, err := db.Search(ctx, 2, "login", SearchIssuesOptions{Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), count)
+		assert.Equal(t, []string{"Login in collaborated", "Login in owned", "Unrelated", "Fix the LOGIN page"}, titles(got))
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		got, count, err := db.Search(ctx, 2, "login", SearchIssuesOptions{Page: 2, PageSize: 3})
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), count)
+		assert.Equal(t, []string{"Fix the LOGIN page"}, titles(got))
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		isClosed := true
+		got, count, err := db.Search(ctx, 2, "login", SearchIssuesOptions{IsClosed: &isClosed, Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+		assert.Equal(t, []string{"Unrelated"}, titles(got))
+
+		isPull := true
+		got, count, err = db.Search(ctx, 2, "login", SearchIssuesOptions{IsPull: &isPull, Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+		assert.Equal(t, []string{"Login in owned"}, titles(got))
+	})
+
+	t.Run("escape wildcards", func(t *testing.T) {
+		got, count, err := db.Search(ctx, 2, "100%", SearchIssuesOptions{Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+		assert.Equal(t, []string{"Unrelated"}, titles(got))
+	})
+}
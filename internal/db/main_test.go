@@ -5,17 +5,21 @@
 package db
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	_ "modernc.org/sqlite"
 	log "unknwon.dev/clog/v2"
 
 	"gogs.io/gogs/internal/conf"
+	"gogs.io/gogs/internal/dbutil"
 	"gogs.io/gogs/internal/testutil"
 )
 
@@ -64,3 +68,42 @@ func clearTables(t *testing.T, db *gorm.DB, tables ...any) error {
 	}
 	return nil
 }
+
+// createTestOrg creates an organization with the given name directly in the
+// database. Unlike OrgsStore.Create, it does not touch the file system, nor
+// does it add the Owners team or any member.
+func createTestOrg(t *testing.T, db *gorm.DB, name string, opts CreateUserOptions) *Organization {
+	t.Helper()
+
+	org, err := NewUsersStore(db).Create(context.Background(), name, name+"@example.com", opts)
+	require.NoError(t, err)
+	err = db.Exec(
+		dbutil.Quote("UPDATE %s SET type = ? WHERE id = ?", "user"),
+		UserTypeOrganization, org.ID,
+	).Error
+	require.NoError(t, err)
+	org.Type = UserTypeOrganization
+	return org
+}
+
+// createTestTeam creates the team directly in the database with given members
+// and repositories. Unlike TeamsStore.Create, it does not update any counter
+// or recalculate accesses.
+func createTestTeam(t *testing.T, db *gorm.DB, team *Team, userIDs, repoIDs []int64) *Team {
+	t.Helper()
+
+	if team.LowerName == "" {
+		team.LowerName = strings.ToLower(team.Name)
+	}
+	err := db.Create(team).Error
+	require.NoError(t, err)
+	for _, userID := range userIDs {
+		err = db.Create(&TeamUser{UID: userID, OrgID: team.OrgID, TeamID: team.ID}).Error
+		require.NoError(t, err)
+	}
+	for _, repoID := range repoIDs {
+		err = db.Create(&TeamRepo{OrgID: team.OrgID, TeamID: team.ID, RepoID: repoID}).Error
+		require.NoError(t, err)
+	}
+	return team
+}
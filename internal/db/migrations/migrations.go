@@ -63,6 +63,40 @@ var migrations = []Migration{
 	// on v22. Let's make a noop v22 to make sure every instance will not miss a
 	// real future migration.
 	NewMigration("noop", func(*gorm.DB) error { return nil }),
+	// v22 -> v23:v0.14.0
+	NewMigration("add user.default_repo_permission", addUserDefaultRepoPermission),
+	// v23 -> v24:v0.14.0
+	NewMigration("add hook_task.retry_count and hook_task.deliver_after", addHookTaskRetryColumns),
+	// v24 -> v25:v0.14.0
+	NewMigration("add user.require_two_factor", addUserRequireTwoFactor),
+	// v25 -> v26:v0.14.0
+	NewMigration("add collaboration.expires_unix", addCollaborationExpiresUnix),
+	// v26 -> v27:v0.14.0
+	NewMigration("add repository.deleted_unix", addRepositoryDeletedUnix),
+	// v27 -> v28:v0.14.0
+	NewMigration("add team.use_custom_avatar", addTeamUseCustomAvatar),
+	// v28 -> v29:v0.14.0
+	NewMigration("add deploy_key.read_only", addDeployKeyReadOnly),
+	// v29 -> v30:v0.14.0
+	NewMigration("add user.is_verified", addUserIsVerified),
+	// v30 -> v31:v0.14.0
+	NewMigration("add unique index to team.org_id and team.lower_name", addTeamOrgLowerNameUniqueIndex),
+	// v31 -> v32:v0.14.0
+	NewMigration("add org_user.notify_level", addOrgUserNotifyLevel),
+	// v32 -> v33:v0.14.0
+	NewMigration("add user.is_archived", addUserIsArchived),
+	// v33 -> v34:v0.14.0
+	NewMigration("add repository.is_template", addRepositoryIsTemplate),
+	// v34 -> v35:v0.14.0
+	NewMigration("add user.repo_create_permission", addUserRepoCreatePermission),
+	// v35 -> v36:v0.14.0
+	NewMigration("add user.session_epoch", addUserSessionEpoch),
+	// v36 -> v37:v0.14.0
+	NewMigration("add repository.is_archived", addRepositoryIsArchived),
+	// v37 -> v38:v0.14.0
+	NewMigration("backfill issue_assignee from issue.assignee_id", backfillIssueAssignees),
+	// v38 -> v39:v0.14.0
+	NewMigration("add user.last_login_unix", addUserLastLoginUnix),
 }
 
 var errMigrationSkipped = errors.New("the migration has been skipped")
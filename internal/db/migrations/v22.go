@@ -0,0 +1,19 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+func addUserDefaultRepoPermission(db *gorm.DB) error {
+	type user struct {
+		DefaultRepoPermission int `gorm:"not null;default:0"`
+	}
+	if db.Migrator().HasColumn(&user{}, "DefaultRepoPermission") {
+		return errMigrationSkipped
+	}
+	return db.Migrator().AddColumn(&user{}, "DefaultRepoPermission")
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gogs.io/gogs/internal/dbtest"
+)
+
+type userPreV22 struct {
+	ID         int64 `gorm:"primaryKey"`
+	LowerName  string
+	Name       string
+	Type       int
+	NumMembers int
+}
+
+func (*userPreV22) TableName() string {
+	return "user"
+}
+
+type userV22 struct {
+	ID                    int64 `gorm:"primaryKey"`
+	LowerName             string
+	Name                  string
+	Type                  int
+	NumMembers            int
+	DefaultRepoPermission int `gorm:"not null;default:0"`
+}
+
+func (*userV22) TableName() string {
+	return "user"
+}
+
+func TestAddUserDefaultRepoPermission(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	db := dbtest.NewDB(t, "addUserDefaultRepoPermission", new(userPreV22))
+	err := db.Create(
+		&userPreV22{
+			ID:         1,
+			LowerName:  "org1",
+			Name:       "org1",
+			Type:       1,
+			NumMembers: 1,
+		},
+	).Error
+	require.NoError(t, err)
+	assert.False(t, db.Migrator().HasColumn(&userV22{}, "DefaultRepoPermission"))
+
+	err = addUserDefaultRepoPermission(db)
+	require.NoError(t, err)
+	assert.True(t, db.Migrator().HasColumn(&userV22{}, "DefaultRepoPermission"))
+
+	var got userV22
+	err = db.First(&got, 1).Error
+	require.NoError(t, err)
+	assert.Equal(t, 0, got.DefaultRepoPermission)
+
+	// Re-run should be skipped
+	err = addUserDefaultRepoPermission(db)
+	require.Equal(t, errMigrationSkipped, err)
+}
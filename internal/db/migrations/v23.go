@@ -0,0 +1,36 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+func addHookTaskRetryColumns(db *gorm.DB) error {
+	type hookTask struct {
+		RetryCount   int   `gorm:"not null;default:0"`
+		DeliverAfter int64 `gorm:"not null;default:0"`
+	}
+	fields := make([]string, 0, 2)
+	for _, field := range []string{"RetryCount", "DeliverAfter"} {
+		if !db.Migrator().HasColumn(&hookTask{}, field) {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return errMigrationSkipped
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, field := range fields {
+			err := tx.Migrator().AddColumn(&hookTask{}, field)
+			if err != nil {
+				return errors.Wrapf(err, "add column %q", field)
+			}
+		}
+		return nil
+	})
+}
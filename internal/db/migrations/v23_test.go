@@ -0,0 +1,72 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gogs.io/gogs/internal/dbtest"
+)
+
+type hookTaskPreV23 struct {
+	ID          int64 `gorm:"primaryKey"`
+	RepoID      int64
+	HookID      int64
+	IsDelivered bool
+}
+
+func (*hookTaskPreV23) TableName() string {
+	return "hook_task"
+}
+
+type hookTaskV23 struct {
+	ID           int64 `gorm:"primaryKey"`
+	RepoID       int64
+	HookID       int64
+	IsDelivered  bool
+	RetryCount   int   `gorm:"not null;default:0"`
+	DeliverAfter int64 `gorm:"not null;default:0"`
+}
+
+func (*hookTaskV23) TableName() string {
+	return "hook_task"
+}
+
+func TestAddHookTaskRetryColumns(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	db := dbtest.NewDB(t, "addHookTaskRetryColumns", new(hookTaskPreV23))
+	err := db.Create(
+		&hookTaskPreV23{
+			ID:     1,
+			RepoID: 1,
+			HookID: 1,
+		},
+	).Error
+	require.NoError(t, err)
+	assert.False(t, db.Migrator().HasColumn(&hookTaskV23{}, "RetryCount"))
+	assert.False(t, db.Migrator().HasColumn(&hookTaskV23{}, "DeliverAfter"))
+
+	err = addHookTaskRetryColumns(db)
+	require.NoError(t, err)
+	assert.True(t, db.Migrator().HasColumn(&hookTaskV23{}, "RetryCount"))
+	assert.True(t, db.Migrator().HasColumn(&hookTaskV23{}, "DeliverAfter"))
+
+	var got hookTaskV23
+	err = db.First(&got, 1).Error
+	require.NoError(t, err)
+	assert.Equal(t, 0, got.RetryCount)
+	assert.Equal(t, int64(0), got.DeliverAfter)
+
+	// Re-run should be skipped
+	err = addHookTaskRetryColumns(db)
+	require.Equal(t, errMigrationSkipped, err)
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gogs.io/gogs/internal/dbtest"
+)
+
+type userPreV24 struct {
+	ID        int64 `gorm:"primaryKey"`
+	LowerName string
+	Name      string
+	Type      int
+}
+
+func (*userPreV24) TableName() string {
+	return "user"
+}
+
+type userV24 struct {
+	ID               int64 `gorm:"primaryKey"`
+	LowerName        string
+	Name             string
+	Type             int
+	RequireTwoFactor bool `gorm:"not null;default:FALSE"`
+}
+
+func (*userV24) TableName() string {
+	return "user"
+}
+
+func TestAddUserRequireTwoFactor(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	db := dbtest.NewDB(t, "addUserRequireTwoFactor", new(userPreV24))
+	err := db.Create(
+		&userPreV24{
+			ID:        1,
+			LowerName: "org1",
+			Name:      "org1",
+			Type:      1,
+		},
+	).Error
+	require.NoError(t, err)
+	assert.False(t, db.Migrator().HasColumn(&userV24{}, "RequireTwoFactor"))
+
+	err = addUserRequireTwoFactor(db)
+	require.NoError(t, err)
+	assert.True(t, db.Migrator().HasColumn(&userV24{}, "RequireTwoFactor"))
+
+	var got userV24
+	err = db.First(&got, 1).Error
+	require.NoError(t, err)
+	assert.False(t, got.RequireTwoFactor)
+
+	// Re-run should be skipped
+	err = addUserRequireTwoFactor(db)
+	require.Equal(t, errMigrationSkipped, err)
+}
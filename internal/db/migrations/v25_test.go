@@ -0,0 +1,69 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gogs.io/gogs/internal/dbtest"
+)
+
+type collaborationPreV25 struct {
+	ID     int64 `gorm:"primaryKey"`
+	UserID int64
+	RepoID int64
+	Mode   int
+}
+
+func (*collaborationPreV25) TableName() string {
+	return "collaboration"
+}
+
+type collaborationV25 struct {
+	ID          int64 `gorm:"primaryKey"`
+	UserID      int64
+	RepoID      int64
+	Mode        int
+	ExpiresUnix int64 `gorm:"not null;default:0"`
+}
+
+func (*collaborationV25) TableName() string {
+	return "collaboration"
+}
+
+func TestAddCollaborationExpiresUnix(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	db := dbtest.NewDB(t, "addCollaborationExpiresUnix", new(collaborationPreV25))
+	err := db.Create(
+		&collaborationPreV25{
+			ID:     1,
+			UserID: 1,
+			RepoID: 1,
+			Mode:   2,
+		},
+	).Error
+	require.NoError(t, err)
+	assert.False(t, db.Migrator().HasColumn(&collaborationV25{}, "ExpiresUnix"))
+
+	err = addCollaborationExpiresUnix(db)
+	require.NoError(t, err)
+	assert.True(t, db.Migrator().HasColumn(&collaborationV25{}, "ExpiresUnix"))
+
+	var got collaborationV25
+	err = db.First(&got, 1).Error
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), got.ExpiresUnix)
+
+	// Re-run should be skipped
+	err = addCollaborationExpiresUnix(db)
+	require.Equal(t, errMigrationSkipped, err)
+}
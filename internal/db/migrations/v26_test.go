@@ -0,0 +1,69 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gogs.io/gogs/internal/dbtest"
+)
+
+type repositoryPreV26 struct {
+	ID        int64 `gorm:"primaryKey"`
+	OwnerID   int64
+	LowerName string
+	Name      string
+}
+
+func (*repositoryPreV26) TableName() string {
+	return "repository"
+}
+
+type repositoryV26 struct {
+	ID          int64 `gorm:"primaryKey"`
+	OwnerID     int64
+	LowerName   string
+	Name        string
+	DeletedUnix int64 `gorm:"index;not null;default:0"`
+}
+
+func (*repositoryV26) TableName() string {
+	return "repository"
+}
+
+func TestAddRepositoryDeletedUnix(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	db := dbtest.NewDB(t, "addRepositoryDeletedUnix", new(repositoryPreV26))
+	err := db.Create(
+		&repositoryPreV26{
+			ID:        1,
+			OwnerID:   1,
+			LowerName: "repo1",
+			Name:      "repo1",
+		},
+	).Error
+	require.NoError(t, err)
+	assert.False(t, db.Migrator().HasColumn(&repositoryV26{}, "DeletedUnix"))
+
+	err = addRepositoryDeletedUnix(db)
+	require.NoError(t, err)
+	assert.True(t, db.Migrator().HasColumn(&repositoryV26{}, "DeletedUnix"))
+
+	var got repositoryV26
+	err = db.First(&got, 1).Error
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), got.DeletedUnix)
+
+	// Re-run should be skipped
+	err = addRepositoryDeletedUnix(db)
+	require.Equal(t, errMigrationSkipped, err)
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gogs.io/gogs/internal/dbtest"
+)
+
+type teamPreV27 struct {
+	ID          int64 `gorm:"primaryKey"`
+	OrgID       int64
+	LowerName   string
+	Name        string
+	Description string
+}
+
+func (*teamPreV27) TableName() string {
+	return "team"
+}
+
+type teamV27 struct {
+	ID              int64 `gorm:"primaryKey"`
+	OrgID           int64
+	LowerName       string
+	Name            string
+	Description     string
+	UseCustomAvatar bool `gorm:"not null;default:FALSE"`
+}
+
+func (*teamV27) TableName() string {
+	return "team"
+}
+
+func TestAddTeamUseCustomAvatar(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	db := dbtest.NewDB(t, "addTeamUseCustomAvatar", new(teamPreV27))
+	err := db.Create(
+		&teamPreV27{
+			ID:          1,
+			OrgID:       1,
+			LowerName:   "owners",
+			Name:        "Owners",
+			Description: "The owners",
+		},
+	).Error
+	require.NoError(t, err)
+	assert.False(t, db.Migrator().HasColumn(&teamV27{}, "UseCustomAvatar"))
+
+	err = addTeamUseCustomAvatar(db)
+	require.NoError(t, err)
+	assert.True(t, db.Migrator().HasColumn(&teamV27{}, "UseCustomAvatar"))
+
+	var got teamV27
+	err = db.First(&got, 1).Error
+	require.NoError(t, err)
+	assert.False(t, got.UseCustomAvatar)
+
+	// Re-run should be skipped
+	err = addTeamUseCustomAvatar(db)
+	require.Equal(t, errMigrationSkipped, err)
+}
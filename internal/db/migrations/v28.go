@@ -0,0 +1,21 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+func addDeployKeyReadOnly(db *gorm.DB) error {
+	// NOTE: Existing deploy keys have never been allowed to push, the default
+	// value makes them read-only.
+	type deployKey struct {
+		ReadOnly bool `gorm:"not null;default:TRUE"`
+	}
+	if db.Migrator().HasColumn(&deployKey{}, "ReadOnly") {
+		return errMigrationSkipped
+	}
+	return db.Migrator().AddColumn(&deployKey{}, "ReadOnly")
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gogs.io/gogs/internal/dbtest"
+)
+
+type deployKeyPreV28 struct {
+	ID          int64 `gorm:"primaryKey"`
+	KeyID       int64
+	RepoID      int64
+	Name        string
+	Fingerprint string
+}
+
+func (*deployKeyPreV28) TableName() string {
+	return "deploy_key"
+}
+
+type deployKeyV28 struct {
+	ID          int64 `gorm:"primaryKey"`
+	KeyID       int64
+	RepoID      int64
+	Name        string
+	Fingerprint string
+	ReadOnly    bool `gorm:"not null;default:TRUE"`
+}
+
+func (*deployKeyV28) TableName() string {
+	return "deploy_key"
+}
+
+func TestAddDeployKeyReadOnly(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	db := dbtest.NewDB(t, "addDeployKeyReadOnly", new(deployKeyPreV28))
+	err := db.Create(
+		&deployKeyPreV28{
+			ID:          1,
+			KeyID:       1,
+			RepoID:      1,
+			Name:        "deploy",
+			Fingerprint: "12:f8:7e:78:61:b4:bf:e2:de:24:15:96:4e:d4:72:53",
+		},
+	).Error
+	require.NoError(t, err)
+	assert.False(t, db.Migrator().HasColumn(&deployKeyV28{}, "ReadOnly"))
+
+	err = addDeployKeyReadOnly(db)
+	require.NoError(t, err)
+	assert.True(t, db.Migrator().HasColumn(&deployKeyV28{}, "ReadOnly"))
+
+	var got deployKeyV28
+	err = db.First(&got, 1).Error
+	require.NoError(t, err)
+	assert.True(t, got.ReadOnly)
+
+	// Re-run should be skipped
+	err = addDeployKeyReadOnly(db)
+	require.Equal(t, errMigrationSkipped, err)
+}
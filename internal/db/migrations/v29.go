@@ -0,0 +1,19 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+func addUserIsVerified(db *gorm.DB) error {
+	type user struct {
+		IsVerified bool `gorm:"not null;default:FALSE"`
+	}
+	if db.Migrator().HasColumn(&user{}, "IsVerified") {
+		return errMigrationSkipped
+	}
+	return db.Migrator().AddColumn(&user{}, "IsVerified")
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gogs.io/gogs/internal/dbtest"
+)
+
+type userPreV29 struct {
+	ID        int64 `gorm:"primaryKey"`
+	LowerName string
+	Name      string
+	Type      int
+}
+
+func (*userPreV29) TableName() string {
+	return "user"
+}
+
+type userV29 struct {
+	ID         int64 `gorm:"primaryKey"`
+	LowerName  string
+	Name       string
+	Type       int
+	IsVerified bool `gorm:"not null;default:FALSE"`
+}
+
+func (*userV29) TableName() string {
+	return "user"
+}
+
+func TestAddUserIsVerified(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	db := dbtest.NewDB(t, "addUserIsVerified", new(userPreV29))
+	err := db.Create(
+		&userPreV29{
+			ID:        1,
+			LowerName: "org1",
+			Name:      "org1",
+			Type:      1,
+		},
+	).Error
+	require.NoError(t, err)
+	assert.False(t, db.Migrator().HasColumn(&userV29{}, "IsVerified"))
+
+	err = addUserIsVerified(db)
+	require.NoError(t, err)
+	assert.True(t, db.Migrator().HasColumn(&userV29{}, "IsVerified"))
+
+	var got userV29
+	err = db.First(&got, 1).Error
+	require.NoError(t, err)
+	assert.False(t, got.IsVerified)
+
+	// Re-run should be skipped
+	err = addUserIsVerified(db)
+	require.Equal(t, errMigrationSkipped, err)
+}
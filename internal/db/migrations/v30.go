@@ -0,0 +1,80 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+func addTeamOrgLowerNameUniqueIndex(db *gorm.DB) error {
+	// NOTE: The index name must match the one created by XORM for fresh
+	// installations, i.e. `xorm:"UNIQUE(org_lower_name)"`.
+	type team struct {
+		ID        int64
+		OrgID     int64  `gorm:"uniqueIndex:UQE_team_org_lower_name"`
+		LowerName string `gorm:"uniqueIndex:UQE_team_org_lower_name"`
+		Name      string
+	}
+	if db.Migrator().HasIndex(&team{}, "UQE_team_org_lower_name") {
+		return errMigrationSkipped
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		// 1. Rename teams that collide case-insensitively with an older team of the
+		// same organization, by suffixing their names with their IDs. Suffixed names
+		// may be taken as well, in which case a counter is appended until the name
+		// is free in the organization.
+		var duplicates []*team
+		err := tx.Raw(`
+SELECT t1.* FROM team t1
+WHERE EXISTS (
+	SELECT 1 FROM team t2
+	WHERE t2.org_id = t1.org_id AND t2.lower_name = t1.lower_name AND t2.id < t1.id
+)
+ORDER BY t1.id`).
+			Scan(&duplicates).
+			Error
+		if err != nil {
+			return errors.Wrap(err, "list duplicates")
+		}
+
+		for _, t := range duplicates {
+			suffix := "-" + strconv.FormatInt(t.ID, 10)
+			for i := 2; ; i++ {
+				var count int64
+				err = tx.Model(&team{}).
+					Where("org_id = ? AND lower_name = ?", t.OrgID, t.LowerName+suffix).
+					Count(&count).
+					Error
+				if err != nil {
+					return errors.Wrapf(err, "check name collision for team %d", t.ID)
+				} else if count == 0 {
+					break
+				}
+				suffix = "-" + strconv.FormatInt(t.ID, 10) + "-" + strconv.Itoa(i)
+			}
+
+			err = tx.Model(&team{}).
+				Where("id = ?", t.ID).
+				Updates(map[string]any{
+					"name":       t.Name + suffix,
+					"lower_name": t.LowerName + suffix,
+				}).
+				Error
+			if err != nil {
+				return errors.Wrapf(err, "rename team %d", t.ID)
+			}
+		}
+
+		// 2. We are now safe to create the unique index.
+		err = tx.Migrator().CreateIndex(&team{}, "UQE_team_org_lower_name")
+		if err != nil {
+			return errors.Wrap(err, "create index")
+		}
+		return nil
+	})
+}
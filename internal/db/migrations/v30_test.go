@@ -0,0 +1,79 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gogs.io/gogs/internal/dbtest"
+)
+
+type teamPreV30 struct {
+	ID        int64 `gorm:"primaryKey"`
+	OrgID     int64
+	LowerName string
+	Name      string
+}
+
+func (*teamPreV30) TableName() string {
+	return "team"
+}
+
+type teamV30 struct {
+	ID        int64  `gorm:"primaryKey"`
+	OrgID     int64  `gorm:"uniqueIndex:UQE_team_org_lower_name"`
+	LowerName string `gorm:"uniqueIndex:UQE_team_org_lower_name"`
+	Name      string
+}
+
+func (*teamV30) TableName() string {
+	return "team"
+}
+
+func TestAddTeamOrgLowerNameUniqueIndex(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	db := dbtest.NewDB(t, "addTeamOrgLowerNameUniqueIndex", new(teamPreV30))
+	err := db.Create(
+		[]*teamPreV30{
+			{ID: 1, OrgID: 1, LowerName: "devops", Name: "DevOps"},
+			{ID: 2, OrgID: 1, LowerName: "devops", Name: "devops"},
+			{ID: 3, OrgID: 2, LowerName: "devops", Name: "DEVOPS"},
+			// The name with the ID suffix is already taken.
+			{ID: 4, OrgID: 1, LowerName: "qa-6", Name: "QA-6"},
+			{ID: 5, OrgID: 1, LowerName: "qa", Name: "QA"},
+			{ID: 6, OrgID: 1, LowerName: "qa", Name: "qa"},
+		},
+	).Error
+	require.NoError(t, err)
+	assert.False(t, db.Migrator().HasIndex(&teamV30{}, "UQE_team_org_lower_name"))
+
+	err = addTeamOrgLowerNameUniqueIndex(db)
+	require.NoError(t, err)
+	assert.True(t, db.Migrator().HasIndex(&teamV30{}, "UQE_team_org_lower_name"))
+
+	var got []*teamV30
+	err = db.Order("id").Find(&got).Error
+	require.NoError(t, err)
+	want := []*teamV30{
+		{ID: 1, OrgID: 1, LowerName: "devops", Name: "DevOps"},
+		{ID: 2, OrgID: 1, LowerName: "devops-2", Name: "devops-2"},
+		{ID: 3, OrgID: 2, LowerName: "devops", Name: "DEVOPS"},
+		{ID: 4, OrgID: 1, LowerName: "qa-6", Name: "QA-6"},
+		{ID: 5, OrgID: 1, LowerName: "qa", Name: "QA"},
+		{ID: 6, OrgID: 1, LowerName: "qa-6-2", Name: "qa-6-2"},
+	}
+	assert.Equal(t, want, got)
+
+	// Re-run should be skipped
+	err = addTeamOrgLowerNameUniqueIndex(db)
+	require.Equal(t, errMigrationSkipped, err)
+}
@@ -0,0 +1,21 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+func addOrgUserNotifyLevel(db *gorm.DB) error {
+	// NOTE: Existing members default to the "all" level (1), which is what they
+	// have been receiving before the level existed.
+	type orgUser struct {
+		NotifyLevel int `gorm:"not null;default:1"`
+	}
+	if db.Migrator().HasColumn(&orgUser{}, "NotifyLevel") {
+		return errMigrationSkipped
+	}
+	return db.Migrator().AddColumn(&orgUser{}, "NotifyLevel")
+}
@@ -0,0 +1,66 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gogs.io/gogs/internal/dbtest"
+)
+
+type orgUserPreV31 struct {
+	ID    int64 `gorm:"primaryKey"`
+	Uid   int64
+	OrgID int64
+}
+
+func (*orgUserPreV31) TableName() string {
+	return "org_user"
+}
+
+type orgUserV31 struct {
+	ID          int64 `gorm:"primaryKey"`
+	Uid         int64
+	OrgID       int64
+	NotifyLevel int `gorm:"not null;default:1"`
+}
+
+func (*orgUserV31) TableName() string {
+	return "org_user"
+}
+
+func TestAddOrgUserNotifyLevel(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	db := dbtest.NewDB(t, "addOrgUserNotifyLevel", new(orgUserPreV31))
+	err := db.Create(
+		&orgUserPreV31{
+			ID:    1,
+			Uid:   1,
+			OrgID: 2,
+		},
+	).Error
+	require.NoError(t, err)
+	assert.False(t, db.Migrator().HasColumn(&orgUserV31{}, "NotifyLevel"))
+
+	err = addOrgUserNotifyLevel(db)
+	require.NoError(t, err)
+	assert.True(t, db.Migrator().HasColumn(&orgUserV31{}, "NotifyLevel"))
+
+	var got orgUserV31
+	err = db.First(&got, 1).Error
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.NotifyLevel)
+
+	// Re-run should be skipped
+	err = addOrgUserNotifyLevel(db)
+	require.Equal(t, errMigrationSkipped, err)
+}
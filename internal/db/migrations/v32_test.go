@@ -0,0 +1,69 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gogs.io/gogs/internal/dbtest"
+)
+
+type userPreV32 struct {
+	ID        int64 `gorm:"primaryKey"`
+	LowerName string
+	Name      string
+	Type      int
+}
+
+func (*userPreV32) TableName() string {
+	return "user"
+}
+
+type userV32 struct {
+	ID         int64 `gorm:"primaryKey"`
+	LowerName  string
+	Name       string
+	Type       int
+	IsArchived bool `gorm:"not null;default:FALSE"`
+}
+
+func (*userV32) TableName() string {
+	return "user"
+}
+
+func TestAddUserIsArchived(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	db := dbtest.NewDB(t, "addUserIsArchived", new(userPreV32))
+	err := db.Create(
+		&userPreV32{
+			ID:        1,
+			LowerName: "org1",
+			Name:      "org1",
+			Type:      1,
+		},
+	).Error
+	require.NoError(t, err)
+	assert.False(t, db.Migrator().HasColumn(&userV32{}, "IsArchived"))
+
+	err = addUserIsArchived(db)
+	require.NoError(t, err)
+	assert.True(t, db.Migrator().HasColumn(&userV32{}, "IsArchived"))
+
+	var got userV32
+	err = db.First(&got, 1).Error
+	require.NoError(t, err)
+	assert.False(t, got.IsArchived)
+
+	// Re-run should be skipped
+	err = addUserIsArchived(db)
+	require.Equal(t, errMigrationSkipped, err)
+}
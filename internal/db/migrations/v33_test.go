@@ -0,0 +1,69 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gogs.io/gogs/internal/dbtest"
+)
+
+type repositoryPreV33 struct {
+	ID        int64 `gorm:"primaryKey"`
+	OwnerID   int64
+	LowerName string
+	Name      string
+}
+
+func (*repositoryPreV33) TableName() string {
+	return "repository"
+}
+
+type repositoryV33 struct {
+	ID         int64 `gorm:"primaryKey"`
+	OwnerID    int64
+	LowerName  string
+	Name       string
+	IsTemplate bool `gorm:"not null;default:FALSE"`
+}
+
+func (*repositoryV33) TableName() string {
+	return "repository"
+}
+
+func TestAddRepositoryIsTemplate(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	db := dbtest.NewDB(t, "addRepositoryIsTemplate", new(repositoryPreV33))
+	err := db.Create(
+		&repositoryPreV33{
+			ID:        1,
+			OwnerID:   1,
+			LowerName: "repo1",
+			Name:      "repo1",
+		},
+	).Error
+	require.NoError(t, err)
+	assert.False(t, db.Migrator().HasColumn(&repositoryV33{}, "IsTemplate"))
+
+	err = addRepositoryIsTemplate(db)
+	require.NoError(t, err)
+	assert.True(t, db.Migrator().HasColumn(&repositoryV33{}, "IsTemplate"))
+
+	var got repositoryV33
+	err = db.First(&got, 1).Error
+	require.NoError(t, err)
+	assert.False(t, got.IsTemplate)
+
+	// Re-run should be skipped
+	err = addRepositoryIsTemplate(db)
+	require.Equal(t, errMigrationSkipped, err)
+}
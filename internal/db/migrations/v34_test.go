@@ -0,0 +1,69 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gogs.io/gogs/internal/dbtest"
+)
+
+type userPreV34 struct {
+	ID        int64 `gorm:"primaryKey"`
+	LowerName string
+	Name      string
+	Type      int
+}
+
+func (*userPreV34) TableName() string {
+	return "user"
+}
+
+type userV34 struct {
+	ID                   int64 `gorm:"primaryKey"`
+	LowerName            string
+	Name                 string
+	Type                 int
+	RepoCreatePermission int `gorm:"not null;default:0"`
+}
+
+func (*userV34) TableName() string {
+	return "user"
+}
+
+func TestAddUserRepoCreatePermission(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	db := dbtest.NewDB(t, "addUserRepoCreatePermission", new(userPreV34))
+	err := db.Create(
+		&userPreV34{
+			ID:        1,
+			LowerName: "org1",
+			Name:      "org1",
+			Type:      1,
+		},
+	).Error
+	require.NoError(t, err)
+	assert.False(t, db.Migrator().HasColumn(&userV34{}, "RepoCreatePermission"))
+
+	err = addUserRepoCreatePermission(db)
+	require.NoError(t, err)
+	assert.True(t, db.Migrator().HasColumn(&userV34{}, "RepoCreatePermission"))
+
+	var got userV34
+	err = db.First(&got, 1).Error
+	require.NoError(t, err)
+	assert.Equal(t, 0, got.RepoCreatePermission)
+
+	// Re-run should be skipped
+	err = addUserRepoCreatePermission(db)
+	require.Equal(t, errMigrationSkipped, err)
+}
@@ -0,0 +1,19 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+func addUserSessionEpoch(db *gorm.DB) error {
+	type user struct {
+		SessionEpoch int64 `gorm:"not null;default:0"`
+	}
+	if db.Migrator().HasColumn(&user{}, "SessionEpoch") {
+		return errMigrationSkipped
+	}
+	return db.Migrator().AddColumn(&user{}, "SessionEpoch")
+}
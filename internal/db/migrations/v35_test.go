@@ -0,0 +1,69 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gogs.io/gogs/internal/dbtest"
+)
+
+type userPreV35 struct {
+	ID        int64 `gorm:"primaryKey"`
+	LowerName string
+	Name      string
+	Type      int
+}
+
+func (*userPreV35) TableName() string {
+	return "user"
+}
+
+type userV35 struct {
+	ID           int64 `gorm:"primaryKey"`
+	LowerName    string
+	Name         string
+	Type         int
+	SessionEpoch int64 `gorm:"not null;default:0"`
+}
+
+func (*userV35) TableName() string {
+	return "user"
+}
+
+func TestAddUserSessionEpoch(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	db := dbtest.NewDB(t, "addUserSessionEpoch", new(userPreV35))
+	err := db.Create(
+		&userPreV35{
+			ID:        1,
+			LowerName: "org1",
+			Name:      "org1",
+			Type:      1,
+		},
+	).Error
+	require.NoError(t, err)
+	assert.False(t, db.Migrator().HasColumn(&userV35{}, "SessionEpoch"))
+
+	err = addUserSessionEpoch(db)
+	require.NoError(t, err)
+	assert.True(t, db.Migrator().HasColumn(&userV35{}, "SessionEpoch"))
+
+	var got userV35
+	err = db.First(&got, 1).Error
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), got.SessionEpoch)
+
+	// Re-run should be skipped
+	err = addUserSessionEpoch(db)
+	require.Equal(t, errMigrationSkipped, err)
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gogs.io/gogs/internal/dbtest"
+)
+
+type repositoryPreV36 struct {
+	ID        int64 `gorm:"primaryKey"`
+	OwnerID   int64
+	LowerName string
+	Name      string
+}
+
+func (*repositoryPreV36) TableName() string {
+	return "repository"
+}
+
+type repositoryV36 struct {
+	ID         int64 `gorm:"primaryKey"`
+	OwnerID    int64
+	LowerName  string
+	Name       string
+	IsArchived bool `gorm:"not null;default:FALSE"`
+}
+
+func (*repositoryV36) TableName() string {
+	return "repository"
+}
+
+func TestAddRepositoryIsArchived(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	db := dbtest.NewDB(t, "addRepositoryIsArchived", new(repositoryPreV36))
+	err := db.Create(
+		&repositoryPreV36{
+			ID:        1,
+			OwnerID:   1,
+			LowerName: "repo1",
+			Name:      "repo1",
+		},
+	).Error
+	require.NoError(t, err)
+	assert.False(t, db.Migrator().HasColumn(&repositoryV36{}, "IsArchived"))
+
+	err = addRepositoryIsArchived(db)
+	require.NoError(t, err)
+	assert.True(t, db.Migrator().HasColumn(&repositoryV36{}, "IsArchived"))
+
+	var got repositoryV36
+	err = db.First(&got, 1).Error
+	require.NoError(t, err)
+	assert.False(t, got.IsArchived)
+
+	// Re-run should be skipped
+	err = addRepositoryIsArchived(db)
+	require.Equal(t, errMigrationSkipped, err)
+}
@@ -0,0 +1,39 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+func backfillIssueAssignees(db *gorm.DB) error {
+	type issueAssignee struct {
+		ID      int64 `gorm:"primaryKey"`
+		IssueID int64 `gorm:"uniqueIndex:issue_assignee_issue_user_unique;not null"`
+		UserID  int64 `gorm:"uniqueIndex:issue_assignee_issue_user_unique;index;not null"`
+	}
+	if !db.Migrator().HasTable(&issueAssignee{}) {
+		err := db.Migrator().CreateTable(&issueAssignee{})
+		if err != nil {
+			return errors.Wrap(err, "create table")
+		}
+	}
+
+	// Copy the legacy single assignee of every issue to the join table, unless the
+	// issue already has the assignee in there.
+	err := db.Exec(`
+INSERT INTO issue_assignee (issue_id, user_id)
+SELECT issue.id, issue.assignee_id FROM issue
+WHERE issue.assignee_id > 0
+AND NOT EXISTS (
+	SELECT 1 FROM issue_assignee
+	WHERE issue_assignee.issue_id = issue.id AND issue_assignee.user_id = issue.assignee_id
+)`).Error
+	if err != nil {
+		return errors.Wrap(err, "copy assignees")
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gogs.io/gogs/internal/dbtest"
+)
+
+type issuePreV37 struct {
+	ID         int64 `gorm:"primaryKey"`
+	RepoID     int64
+	AssigneeID int64
+}
+
+func (*issuePreV37) TableName() string {
+	return "issue"
+}
+
+type issueAssigneeV37 struct {
+	ID      int64 `gorm:"primaryKey"`
+	IssueID int64
+	UserID  int64
+}
+
+func (*issueAssigneeV37) TableName() string {
+	return "issue_assignee"
+}
+
+func TestBackfillIssueAssignees(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	db := dbtest.NewDB(t, "backfillIssueAssignees", new(issuePreV37))
+	err := db.Create(
+		[]*issuePreV37{
+			{ID: 1, RepoID: 1, AssigneeID: 2},
+			{ID: 2, RepoID: 1, AssigneeID: 0},
+			{ID: 3, RepoID: 1, AssigneeID: 3},
+		},
+	).Error
+	require.NoError(t, err)
+
+	err = backfillIssueAssignees(db)
+	require.NoError(t, err)
+
+	assertAssignees := func(t *testing.T) {
+		t.Helper()
+
+		var got []*issueAssigneeV37
+		err := db.Order("issue_id").Find(&got).Error
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, [2]int64{1, 2}, [2]int64{got[0].IssueID, got[0].UserID})
+		assert.Equal(t, [2]int64{3, 3}, [2]int64{got[1].IssueID, got[1].UserID})
+	}
+	assertAssignees(t)
+
+	// Re-run should not duplicate assignees
+	err = backfillIssueAssignees(db)
+	require.NoError(t, err)
+	assertAssignees(t)
+}
@@ -0,0 +1,36 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+func addUserLastLoginUnix(db *gorm.DB) error {
+	type user struct {
+		LastLoginUnix int64 `gorm:"not null;default:0"`
+	}
+	if db.Migrator().HasColumn(&user{}, "LastLoginUnix") {
+		return errMigrationSkipped
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Migrator().AddColumn(&user{}, "LastLoginUnix")
+		if err != nil {
+			return errors.Wrap(err, "add column")
+		}
+
+		// The time of last update is the closest approximation we have for users who
+		// have not logged in since the upgrade.
+		err = tx.Session(&gorm.Session{AllowGlobalUpdate: true}).
+			Model(&user{}).
+			Update("last_login_unix", gorm.Expr("updated_unix")).
+			Error
+		if err != nil {
+			return errors.Wrap(err, "backfill")
+		}
+		return nil
+	})
+}
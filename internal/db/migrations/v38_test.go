@@ -0,0 +1,69 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gogs.io/gogs/internal/dbtest"
+)
+
+type userPreV38 struct {
+	ID          int64 `gorm:"primaryKey"`
+	LowerName   string
+	Name        string
+	UpdatedUnix int64
+}
+
+func (*userPreV38) TableName() string {
+	return "user"
+}
+
+type userV38 struct {
+	ID            int64 `gorm:"primaryKey"`
+	LowerName     string
+	Name          string
+	UpdatedUnix   int64
+	LastLoginUnix int64 `gorm:"not null;default:0"`
+}
+
+func (*userV38) TableName() string {
+	return "user"
+}
+
+func TestAddUserLastLoginUnix(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	db := dbtest.NewDB(t, "addUserLastLoginUnix", new(userPreV38))
+	err := db.Create(
+		&userPreV38{
+			ID:          1,
+			LowerName:   "alice",
+			Name:        "alice",
+			UpdatedUnix: 100,
+		},
+	).Error
+	require.NoError(t, err)
+	assert.False(t, db.Migrator().HasColumn(&userV38{}, "LastLoginUnix"))
+
+	err = addUserLastLoginUnix(db)
+	require.NoError(t, err)
+	assert.True(t, db.Migrator().HasColumn(&userV38{}, "LastLoginUnix"))
+
+	var got userV38
+	err = db.First(&got, 1).Error
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), got.LastLoginUnix)
+
+	// Re-run should be skipped
+	err = addUserLastLoginUnix(db)
+	require.Equal(t, errMigrationSkipped, err)
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/unknwon/com"
 	"gopkg.in/ini.v1"
+	"gorm.io/gorm"
 	log "unknwon.dev/clog/v2"
 	"xorm.io/xorm"
 
@@ -24,23 +25,39 @@ import (
 	"gogs.io/gogs/internal/sync"
 )
 
-var MirrorQueue = sync.NewUniqueQueue(1000)
+// mirrorQueueLength is the length of the mirror queue, which also bounds the
+// number of mirrors to be queued in a single run of MirrorUpdate.
+const mirrorQueueLength = 1000
+
+var MirrorQueue = sync.NewUniqueQueue(mirrorQueueLength)
 
 // Mirror represents mirror information of a repository.
 type Mirror struct {
 	ID          int64
 	RepoID      int64
-	Repo        *Repository `xorm:"-" json:"-"`
+	Repo        *Repository `xorm:"-" gorm:"-" json:"-"`
 	Interval    int         // Hour.
-	EnablePrune bool        `xorm:"NOT NULL DEFAULT true"`
+	EnablePrune bool        `xorm:"NOT NULL DEFAULT true" gorm:"not null;default:TRUE"`
 
 	// Last and next sync time of Git data from upstream
-	LastSync     time.Time `xorm:"-" json:"-"`
-	LastSyncUnix int64     `xorm:"updated_unix"`
-	NextSync     time.Time `xorm:"-" json:"-"`
-	NextSyncUnix int64     `xorm:"next_update_unix"`
+	LastSync     time.Time `xorm:"-" gorm:"-" json:"-"`
+	LastSyncUnix int64     `xorm:"updated_unix" gorm:"column:updated_unix"`
+	NextSync     time.Time `xorm:"-" gorm:"-" json:"-"`
+	NextSyncUnix int64     `xorm:"next_update_unix" gorm:"column:next_update_unix"`
+
+	// The error message of the last failed sync, and the number of consecutive
+	// failed syncs since the last successful one.
+	LastError       string `xorm:"TEXT" gorm:"type:TEXT"`
+	NumSyncFailures int    `xorm:"NOT NULL DEFAULT 0" gorm:"not null;default:0"`
+
+	address string `xorm:"-" gorm:"-"`
+}
 
-	address string `xorm:"-"`
+// AfterFind implements the GORM query hook.
+func (m *Mirror) AfterFind(_ *gorm.DB) error {
+	m.LastSync = time.Unix(m.LastSyncUnix, 0).Local()
+	m.NextSync = time.Unix(m.NextSyncUnix, 0).Local()
+	return nil
 }
 
 func (m *Mirror) BeforeInsert() {
@@ -205,8 +222,9 @@ func parseRemoteUpdateOutput(output string) []*mirrorSyncResult {
 	return results
 }
 
-// runSync returns true if sync finished without error.
-func (m *Mirror) runSync() ([]*mirrorSyncResult, bool) {
+// runSync returns an error describing the failure if sync did not finish
+// successfully.
+func (m *Mirror) runSync() ([]*mirrorSyncResult, error) {
 	repoPath := m.Repo.RepoPath()
 	wikiPath := m.Repo.WikiPath()
 	timeout := time.Duration(conf.Git.Timeout.Mirror) * time.Second
@@ -218,7 +236,7 @@ func (m *Mirror) runSync() ([]*mirrorSyncResult, bool) {
 		if err := Notices.Create(context.TODO(), NoticeTypeRepository, desc); err != nil {
 			log.Error("CreateRepositoryNotice: %v", err)
 		}
-		return nil, false
+		return nil, errors.New(desc)
 	}
 
 	gitArgs := []string{"remote", "update"}
@@ -234,7 +252,7 @@ func (m *Mirror) runSync() ([]*mirrorSyncResult, bool) {
 		if err = Notices.Create(context.TODO(), NoticeTypeRepository, desc); err != nil {
 			log.Error("CreateRepositoryNotice: %v", err)
 		}
-		return nil, false
+		return nil, errors.New(desc)
 	}
 	output := stderr
 
@@ -255,7 +273,7 @@ func (m *Mirror) runSync() ([]*mirrorSyncResult, bool) {
 		}
 	}
 
-	return parseRemoteUpdateOutput(output), true
+	return parseRemoteUpdateOutput(output), nil
 }
 
 func getMirrorByRepoID(e Engine, repoID int64) (*Mirror, error) {
@@ -298,17 +316,18 @@ func MirrorUpdate() {
 
 	log.Trace("Doing: MirrorUpdate")
 
-	if err := x.Where("next_update_unix<=?", time.Now().Unix()).Iterate(new(Mirror), func(idx int, bean any) error {
-		m := bean.(*Mirror)
-		if m.Repo == nil {
-			log.Error("Disconnected mirror repository found: %d", m.ID)
-			return nil
-		}
+	ctx := context.Background()
 
-		MirrorQueue.Add(m.RepoID)
-		return nil
-	}); err != nil {
+	// Mirrors beyond the batch size are picked up by subsequent runs, the most
+	// overdue ones first.
+	mirrors, err := Repos.ListMirrorsToSync(ctx, time.Now().Unix(), mirrorQueueLength, conf.Mirror.MaxSyncFailures)
+	if err != nil {
 		log.Error("MirrorUpdate: %v", err)
+		return
+	}
+
+	for _, m := range mirrors {
+		MirrorQueue.Add(m.RepoID)
 	}
 }
 
@@ -328,8 +347,11 @@ func SyncMirrors() {
 			continue
 		}
 
-		results, ok := m.runSync()
-		if !ok {
+		results, err := m.runSync()
+		if err != nil {
+			if err = Repos.SetMirrorSyncResult(ctx, m.RepoID, false, err.Error()); err != nil {
+				log.Error("Failed to set mirror sync result [repo_id: %d]: %v", m.RepoID, err)
+			}
 			continue
 		}
 
@@ -339,6 +361,11 @@ func SyncMirrors() {
 			continue
 		}
 
+		if err = Repos.SetMirrorSyncResult(ctx, m.RepoID, true, ""); err != nil {
+			log.Error("Failed to set mirror sync result [repo_id: %d]: %v", m.RepoID, err)
+			continue
+		}
+
 		// TODO:
 		// - Create "Mirror Sync" webhook event
 		// - Create mirror sync (create, push and delete) events and trigger the "mirror sync" webhooks
@@ -435,11 +462,6 @@ func SyncMirrors() {
 			}
 		}
 
-		if _, err = x.Exec("UPDATE mirror SET updated_unix = ? WHERE repo_id = ?", time.Now().Unix(), m.RepoID); err != nil {
-			log.Error("Update 'mirror.updated_unix' [%d]: %v", m.RepoID, err)
-			continue
-		}
-
 		// Get latest commit date and compare to current repository updated time,
 		// update if latest commit date is newer.
 		latestCommitTime, err := gitRepo.LatestCommitTime()
@@ -40,6 +40,14 @@ func setMockLoginSourceFilesStore(t *testing.T, db *loginSources, mock loginSour
 	})
 }
 
+func SetMockOrgsStore(t *testing.T, mock OrgsStore) {
+	before := Orgs
+	Orgs = mock
+	t.Cleanup(func() {
+		Orgs = before
+	})
+}
+
 func SetMockPermsStore(t *testing.T, mock PermsStore) {
 	before := Perms
 	Perms = mock
@@ -6,26 +6,12 @@ package db
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"os"
-	"strings"
 
 	"xorm.io/builder"
 	"xorm.io/xorm"
-
-	"gogs.io/gogs/internal/errutil"
-	"gogs.io/gogs/internal/repoutil"
-	"gogs.io/gogs/internal/userutil"
 )
 
-var ErrOrgNotExist = errors.New("Organization does not exist")
-
-// IsOwnedBy returns true if given user is in the owner team.
-func (org *User) IsOwnedBy(userID int64) bool {
-	return IsOrganizationOwner(org.ID, userID)
-}
-
 // IsOrgMember returns true if given user is member of organization.
 func (org *User) IsOrgMember(uid int64) bool {
 	return org.IsOrganization() && IsOrganizationMember(org.ID, uid)
@@ -65,20 +51,9 @@ func (org *User) TeamsHaveAccessToRepo(repoID int64, mode AccessMode) ([]*Team,
 }
 
 // GetMembers returns all members of organization.
-func (org *User) GetMembers(limit int) error {
-	ous, err := GetOrgUsersByOrgID(org.ID, limit)
-	if err != nil {
-		return err
-	}
-
-	org.Members = make([]*User, len(ous))
-	for i, ou := range ous {
-		org.Members[i], err = Users.GetByID(context.TODO(), ou.Uid)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+func (org *User) GetMembers(limit int) (err error) {
+	org.Members, _, err = Orgs.ListMembers(context.TODO(), org.ID, ListOrgMembersOptions{Limit: limit})
+	return err
 }
 
 // AddMember adds new member to organization.
@@ -100,98 +75,6 @@ func (org *User) RemoveOrgRepo(repoID int64) error {
 	return org.removeOrgRepo(x, repoID)
 }
 
-// CreateOrganization creates record of a new organization.
-func CreateOrganization(org, owner *User) (err error) {
-	if err = isUsernameAllowed(org.Name); err != nil {
-		return err
-	}
-
-	if Users.IsUsernameUsed(context.TODO(), org.Name, 0) {
-		return ErrUserAlreadyExist{
-			args: errutil.Args{
-				"name": org.Name,
-			},
-		}
-	}
-
-	org.LowerName = strings.ToLower(org.Name)
-	if org.Rands, err = userutil.RandomSalt(); err != nil {
-		return err
-	}
-	if org.Salt, err = userutil.RandomSalt(); err != nil {
-		return err
-	}
-	org.UseCustomAvatar = true
-	org.MaxRepoCreation = -1
-	org.NumTeams = 1
-	org.NumMembers = 1
-
-	sess := x.NewSession()
-	defer sess.Close()
-	if err = sess.Begin(); err != nil {
-		return err
-	}
-
-	if _, err = sess.Insert(org); err != nil {
-		return fmt.Errorf("insert organization: %v", err)
-	}
-	_ = userutil.GenerateRandomAvatar(org.ID, org.Name, org.Email)
-
-	// Add initial creator to organization and owner team.
-	if _, err = sess.Insert(&OrgUser{
-		Uid:      owner.ID,
-		OrgID:    org.ID,
-		IsOwner:  true,
-		NumTeams: 1,
-	}); err != nil {
-		return fmt.Errorf("insert org-user relation: %v", err)
-	}
-
-	// Create default owner team.
-	t := &Team{
-		OrgID:      org.ID,
-		LowerName:  strings.ToLower(OWNER_TEAM),
-		Name:       OWNER_TEAM,
-		Authorize:  AccessModeOwner,
-		NumMembers: 1,
-	}
-	if _, err = sess.Insert(t); err != nil {
-		return fmt.Errorf("insert owner team: %v", err)
-	}
-
-	if _, err = sess.Insert(&TeamUser{
-		UID:    owner.ID,
-		OrgID:  org.ID,
-		TeamID: t.ID,
-	}); err != nil {
-		return fmt.Errorf("insert team-user relation: %v", err)
-	}
-
-	if err = os.MkdirAll(repoutil.UserPath(org.Name), os.ModePerm); err != nil {
-		return fmt.Errorf("create directory: %v", err)
-	}
-
-	return sess.Commit()
-}
-
-// GetOrgByName returns organization by given name.
-func GetOrgByName(name string) (*User, error) {
-	if name == "" {
-		return nil, ErrOrgNotExist
-	}
-	u := &User{
-		LowerName: strings.ToLower(name),
-		Type:      UserTypeOrganization,
-	}
-	has, err := x.Get(u)
-	if err != nil {
-		return nil, err
-	} else if !has {
-		return nil, ErrOrgNotExist
-	}
-	return u, nil
-}
-
 // CountOrganizations returns number of organizations.
 func CountOrganizations() int64 {
 	count, _ := x.Where("type=1").Count(new(User))
@@ -204,37 +87,50 @@ func Organizations(page, pageSize int) ([]*User, error) {
 	return orgs, x.Limit(pageSize, (page-1)*pageSize).Where("type=1").Asc("id").Find(&orgs)
 }
 
-// deleteBeans deletes all given beans, beans should contain delete conditions.
-func deleteBeans(e Engine, beans ...any) (err error) {
-	for i := range beans {
-		if _, err = e.Delete(beans[i]); err != nil {
-			return err
+// RecountOrganizations recomputes cached counters of all organizations.
+func RecountOrganizations() error {
+	var orgIDs []int64
+	err := x.Table("user").Where("type = ?", UserTypeOrganization).Cols("id").Find(&orgIDs)
+	if err != nil {
+		return fmt.Errorf("list organization IDs: %v", err)
+	}
+
+	for _, orgID := range orgIDs {
+		if err = Orgs.RecountAll(context.TODO(), orgID); err != nil {
+			return fmt.Errorf("recount organization [id: %d]: %v", orgID, err)
 		}
 	}
 	return nil
 }
 
-// DeleteOrganization completely and permanently deletes everything of organization.
-func DeleteOrganization(org *User) error {
-	err := Users.DeleteByID(context.TODO(), org.ID, false)
+// DeduplicateOrganizationMembers removes duplicate memberships of all
+// organizations and returns the total number of rows deleted.
+func DeduplicateOrganizationMembers() (int64, error) {
+	var orgIDs []int64
+	err := x.Table("user").Where("type = ?", UserTypeOrganization).Cols("id").Find(&orgIDs)
 	if err != nil {
-		return err
+		return 0, fmt.Errorf("list organization IDs: %v", err)
 	}
 
-	sess := x.NewSession()
-	defer sess.Close()
-	if err = sess.Begin(); err != nil {
-		return err
+	var total int64
+	for _, orgID := range orgIDs {
+		deleted, err := Orgs.DeduplicateMembers(context.TODO(), orgID)
+		if err != nil {
+			return total, fmt.Errorf("deduplicate members of organization [id: %d]: %v", orgID, err)
+		}
+		total += deleted
 	}
+	return total, nil
+}
 
-	if err = deleteBeans(sess,
-		&Team{OrgID: org.ID},
-		&OrgUser{OrgID: org.ID},
-		&TeamUser{OrgID: org.ID},
-	); err != nil {
-		return fmt.Errorf("deleteBeans: %v", err)
+// deleteBeans deletes all given beans, beans should contain delete conditions.
+func deleteBeans(e Engine, beans ...any) (err error) {
+	for i := range beans {
+		if _, err = e.Delete(beans[i]); err != nil {
+			return err
+		}
 	}
-	return sess.Commit()
+	return nil
 }
 
 // ________                ____ ___
@@ -252,18 +148,13 @@ type OrgUser struct {
 	IsPublic bool  `gorm:"not null;default:FALSE"`
 	IsOwner  bool  `gorm:"not null;default:FALSE"`
 	NumTeams int   `gorm:"not null;default:0"`
-}
 
-// IsOrganizationOwner returns true if given user is in the owner team.
-func IsOrganizationOwner(orgID, userID int64) bool {
-	has, _ := x.Where("is_owner = ?", true).And("uid = ?", userID).And("org_id = ?", orgID).Get(new(OrgUser))
-	return has
+	NotifyLevel int `xorm:"NOT NULL DEFAULT 1" gorm:"not null;default:1"`
 }
 
 // IsOrganizationMember returns true if given user is member of organization.
-func IsOrganizationMember(orgId, uid int64) bool {
-	has, _ := x.Where("uid=?", uid).And("org_id=?", orgId).Get(new(OrgUser))
-	return has
+func IsOrganizationMember(orgID, userID int64) bool {
+	return Orgs.HasMember(context.TODO(), orgID, userID)
 }
 
 // IsPublicMembership returns true if given user public his/her membership.
@@ -299,13 +190,6 @@ func GetOwnedOrgsByUserID(userID int64) ([]*User, error) {
 	return getOwnedOrgsByUserID(sess, userID)
 }
 
-// GetOwnedOrganizationsByUserIDDesc returns a list of organizations are owned by
-// given user ID, ordered descending by the given condition.
-func GetOwnedOrgsByUserIDDesc(userID int64, desc string) ([]*User, error) {
-	sess := x.NewSession()
-	return getOwnedOrgsByUserID(sess.Desc(desc), userID)
-}
-
 func getOrgUsersByOrgID(e Engine, orgID int64, limit int) ([]*OrgUser, error) {
 	orgUsers := make([]*OrgUser, 0, 10)
 
@@ -316,26 +200,6 @@ func getOrgUsersByOrgID(e Engine, orgID int64, limit int) ([]*OrgUser, error) {
 	return orgUsers, sess.Find(&orgUsers)
 }
 
-// GetOrgUsersByOrgID returns all organization-user relations by organization ID.
-func GetOrgUsersByOrgID(orgID int64, limit int) ([]*OrgUser, error) {
-	return getOrgUsersByOrgID(x, orgID, limit)
-}
-
-// ChangeOrgUserStatus changes public or private membership status.
-func ChangeOrgUserStatus(orgID, uid int64, public bool) error {
-	ou := new(OrgUser)
-	has, err := x.Where("uid=?", uid).And("org_id=?", orgID).Get(ou)
-	if err != nil {
-		return err
-	} else if !has {
-		return nil
-	}
-
-	ou.IsPublic = public
-	_, err = x.Id(ou.ID).AllCols().Update(ou)
-	return err
-}
-
 // AddOrgUser adds new user to given organization.
 func AddOrgUser(orgID, uid int64) error {
 	if IsOrganizationMember(orgID, uid) {
@@ -349,8 +213,9 @@ func AddOrgUser(orgID, uid int64) error {
 	}
 
 	ou := &OrgUser{
-		Uid:   uid,
-		OrgID: orgID,
+		Uid:         uid,
+		OrgID:       orgID,
+		NotifyLevel: OrgNotifyLevelAll,
 	}
 
 	if _, err := sess.Insert(ou); err != nil {
@@ -364,80 +229,7 @@ func AddOrgUser(orgID, uid int64) error {
 
 // RemoveOrgUser removes user from given organization.
 func RemoveOrgUser(orgID, userID int64) error {
-	ou := new(OrgUser)
-
-	has, err := x.Where("uid=?", userID).And("org_id=?", orgID).Get(ou)
-	if err != nil {
-		return fmt.Errorf("get org-user: %v", err)
-	} else if !has {
-		return nil
-	}
-
-	user, err := Users.GetByID(context.TODO(), userID)
-	if err != nil {
-		return fmt.Errorf("GetUserByID [%d]: %v", userID, err)
-	}
-	org, err := Users.GetByID(context.TODO(), orgID)
-	if err != nil {
-		return fmt.Errorf("GetUserByID [%d]: %v", orgID, err)
-	}
-
-	// FIXME: only need to get IDs here, not all fields of repository.
-	repos, _, err := org.GetUserRepositories(user.ID, 1, org.NumRepos)
-	if err != nil {
-		return fmt.Errorf("GetUserRepositories [%d]: %v", user.ID, err)
-	}
-
-	// Check if the user to delete is the last member in owner team.
-	if IsOrganizationOwner(orgID, userID) {
-		t, err := org.GetOwnerTeam()
-		if err != nil {
-			return err
-		}
-		if t.NumMembers == 1 {
-			return ErrLastOrgOwner{UID: userID}
-		}
-	}
-
-	sess := x.NewSession()
-	defer sess.Close()
-	if err := sess.Begin(); err != nil {
-		return err
-	}
-
-	if _, err := sess.ID(ou.ID).Delete(ou); err != nil {
-		return err
-	} else if _, err = sess.Exec("UPDATE `user` SET num_members=num_members-1 WHERE id=?", orgID); err != nil {
-		return err
-	}
-
-	// Delete all repository accesses and unwatch them.
-	repoIDs := make([]int64, len(repos))
-	for i := range repos {
-		repoIDs = append(repoIDs, repos[i].ID)
-		if err = watchRepo(sess, user.ID, repos[i].ID, false); err != nil {
-			return err
-		}
-	}
-
-	if len(repoIDs) > 0 {
-		if _, err = sess.Where("user_id = ?", user.ID).In("repo_id", repoIDs).Delete(new(Access)); err != nil {
-			return err
-		}
-	}
-
-	// Delete member in his/her teams.
-	teams, err := getUserTeams(sess, org.ID, user.ID)
-	if err != nil {
-		return err
-	}
-	for _, t := range teams {
-		if err = removeTeamMember(sess, org.ID, t.ID, user.ID); err != nil {
-			return err
-		}
-	}
-
-	return sess.Commit()
+	return Orgs.RemoveMember(context.TODO(), orgID, userID)
 }
 
 func removeOrgRepo(e Engine, orgID, repoID int64) error {
@@ -484,48 +276,8 @@ func (org *User) GetUserTeams(userID int64) ([]*Team, error) {
 // GetUserRepositories returns a range of repositories in organization which the user has access to,
 // and total number of records based on given condition.
 func (org *User) GetUserRepositories(userID int64, page, pageSize int) ([]*Repository, int64, error) {
-	teamIDs, err := org.GetUserTeamIDs(userID)
-	if err != nil {
-		return nil, 0, fmt.Errorf("GetUserTeamIDs: %v", err)
-	}
-	if len(teamIDs) == 0 {
-		// user has no team but "IN ()" is invalid SQL
-		teamIDs = []int64{-1} // there is no team with id=-1
-	}
-
-	var teamRepoIDs []int64
-	if err = x.Table("team_repo").In("team_id", teamIDs).Distinct("repo_id").Find(&teamRepoIDs); err != nil {
-		return nil, 0, fmt.Errorf("get team repository IDs: %v", err)
-	}
-	if len(teamRepoIDs) == 0 {
-		// team has no repo but "IN ()" is invalid SQL
-		teamRepoIDs = []int64{-1} // there is no repo with id=-1
-	}
-
-	if page <= 0 {
-		page = 1
-	}
-	repos := make([]*Repository, 0, pageSize)
-	if err = x.Where("owner_id = ?", org.ID).
-		And(builder.Or(
-			builder.And(builder.Expr("is_private = ?", false), builder.Expr("is_unlisted = ?", false)),
-			builder.In("id", teamRepoIDs))).
-		Desc("updated_unix").
-		Limit(pageSize, (page-1)*pageSize).
-		Find(&repos); err != nil {
-		return nil, 0, fmt.Errorf("get user repositories: %v", err)
-	}
-
-	repoCount, err := x.Where("owner_id = ?", org.ID).
-		And(builder.Or(
-			builder.Expr("is_private = ?", false),
-			builder.In("id", teamRepoIDs))).
-		Count(new(Repository))
-	if err != nil {
-		return nil, 0, fmt.Errorf("count user repositories: %v", err)
-	}
-
-	return repos, repoCount, nil
+	repos, count, _, err := Orgs.AccessibleRepositoriesByUser(context.TODO(), org.ID, userID, page, pageSize, AccessibleRepositoriesByUserOptions{})
+	return repos, count, err
 }
 
 // GetUserMirrorRepositories returns mirror repositories of the organization which the user has access to.
@@ -553,6 +305,7 @@ func (org *User) GetUserMirrorRepositories(userID int64) ([]*Repository, error)
 		And("is_private = ?", false).
 		Or(builder.In("id", teamRepoIDs)).
 		And("is_mirror = ?", true). // Don't move up because it's an independent condition
+		And("deleted_unix = 0").
 		Desc("updated_unix").
 		Find(&repos); err != nil {
 		return nil, fmt.Errorf("get user repositories: %v", err)
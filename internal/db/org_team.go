@@ -8,11 +8,15 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"xorm.io/xorm"
 
+	"gogs.io/gogs/internal/conf"
 	"gogs.io/gogs/internal/db/errors"
 	"gogs.io/gogs/internal/errutil"
+	"gogs.io/gogs/internal/osutil"
+	"gogs.io/gogs/internal/userutil"
 )
 
 const OWNER_TEAM = "Owners"
@@ -20,15 +24,17 @@ const OWNER_TEAM = "Owners"
 // Team represents a organization team.
 type Team struct {
 	ID          int64
-	OrgID       int64 `xorm:"INDEX"`
-	LowerName   string
+	OrgID       int64  `xorm:"INDEX UNIQUE(org_lower_name)" gorm:"index;uniqueIndex:UQE_team_org_lower_name"`
+	LowerName   string `xorm:"UNIQUE(org_lower_name)" gorm:"uniqueIndex:UQE_team_org_lower_name"`
 	Name        string
 	Description string
 	Authorize   AccessMode
-	Repos       []*Repository `xorm:"-" json:"-"`
-	Members     []*User       `xorm:"-" json:"-"`
+	Repos       []*Repository `xorm:"-" gorm:"-" json:"-"`
+	Members     []*User       `xorm:"-" gorm:"-" json:"-"`
 	NumRepos    int
 	NumMembers  int
+
+	UseCustomAvatar bool `xorm:"NOT NULL DEFAULT false" gorm:"not null;default:FALSE"`
 }
 
 func (t *Team) AfterSet(colName string, _ xorm.Cell) {
@@ -46,6 +52,15 @@ func (t *Team) IsOwnerTeam() bool {
 	return t.Name == OWNER_TEAM
 }
 
+// AvatarURLPath returns the URL path to the team custom avatar, or an empty
+// string if the team does not have one.
+func (t *Team) AvatarURLPath() string {
+	if !t.UseCustomAvatar || !osutil.IsFile(userutil.CustomTeamAvatarPath(t.ID)) {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s/teams/%d", conf.Server.Subpath, conf.UsersAvatarPathPrefix, t.ID)
+}
+
 // HasWriteAccess returns true if team has at least write level access mode.
 func (t *Team) HasWriteAccess() bool {
 	return t.Authorize >= AccessModeWrite
@@ -78,25 +93,10 @@ func (t *Team) GetRepositories() error {
 	return t.getRepositories(x)
 }
 
-func (t *Team) getMembers(e Engine) (err error) {
-	t.Members, err = getTeamMembers(e, t.ID)
-	return err
-}
-
 // GetMembers returns all members in team of organization.
 func (t *Team) GetMembers() (err error) {
-	return t.getMembers(x)
-}
-
-// AddMember adds new membership of the team to the organization,
-// the user will have membership to the organization automatically when needed.
-func (t *Team) AddMember(uid int64) error {
-	return AddTeamMember(t.OrgID, t.ID, uid)
-}
-
-// RemoveMember removes member from team of organization.
-func (t *Team) RemoveMember(uid int64) error {
-	return RemoveTeamMember(t.OrgID, t.ID, uid)
+	t.Members, err = Teams.ListMembers(context.TODO(), t.ID)
+	return err
 }
 
 func (t *Team) hasRepository(e Engine, repoID int64) bool {
@@ -118,12 +118,8 @@ func (t *Team) addRepository(e Engine, repo *Repository) (err error) {
 		return fmt.Errorf("update team: %v", err)
 	}
 
-	if err = repo.recalculateTeamAccesses(e, 0); err != nil {
-		return fmt.Errorf("recalculateAccesses: %v", err)
-	}
-
-	if err = t.getMembers(e); err != nil {
-		return fmt.Errorf("getMembers: %v", err)
+	if err = t.GetMembers(); err != nil {
+		return fmt.Errorf("get team members: %v", err)
 	}
 	for _, u := range t.Members {
 		if err = watchRepo(e, u.ID, repo.ID, true); err != nil {
@@ -133,8 +129,25 @@ func (t *Team) addRepository(e Engine, repo *Repository) (err error) {
 	return nil
 }
 
-// AddRepository adds new repository to team of organization.
-func (t *Team) AddRepository(repo *Repository) (err error) {
+// createTeamRepoAuditLog records the audit log of a repository being added to
+// or removed from the team with the actor carried by the context.
+//
+// TODO: Delete me when team repositories are migrated to use GORM.
+func (t *Team) createTeamRepoAuditLog(ctx context.Context, e Engine, action AuditAction, repoID int64) error {
+	actorID, _ := ctx.Value(auditActorKey{}).(int64)
+	_, err := e.Exec(
+		"INSERT INTO audit_log (org_id, actor_id, action, target_user_id, team_id, repo_id, created_unix) VALUES (?, ?, ?, 0, ?, ?, ?)",
+		t.OrgID, actorID, string(action), t.ID, repoID, time.Now().Unix(),
+	)
+	if err != nil {
+		return fmt.Errorf("create audit log: %v", err)
+	}
+	return nil
+}
+
+// AddRepository adds new repository to team of organization. The actor of the
+// change is taken from the context for the audit log.
+func (t *Team) AddRepository(ctx context.Context, repo *Repository) (err error) {
 	if repo.OwnerID != t.OrgID {
 		return errors.New("Repository does not belong to organization")
 	} else if t.HasRepository(repo.ID) {
@@ -149,29 +162,31 @@ func (t *Team) AddRepository(repo *Repository) (err error) {
 
 	if err = t.addRepository(sess, repo); err != nil {
 		return err
+	} else if err = t.createTeamRepoAuditLog(ctx, sess, AuditActionAddTeamRepo, repo.ID); err != nil {
+		return err
 	}
 
-	return sess.Commit()
+	if err = sess.Commit(); err != nil {
+		return err
+	}
+	return repo.RecalculateAccesses()
 }
 
-func (t *Team) removeRepository(e Engine, repo *Repository, recalculate bool) (err error) {
+func (t *Team) removeRepository(e Engine, repo *Repository) (err error) {
 	if err = removeTeamRepo(e, t.ID, repo.ID); err != nil {
 		return err
 	}
 
 	t.NumRepos--
-	if _, err = e.ID(t.ID).AllCols().Update(t); err != nil {
-		return err
-	}
-
-	// Don't need to recalculate when delete a repository from organization.
-	if recalculate {
-		if err = repo.recalculateTeamAccesses(e, t.ID); err != nil {
-			return err
-		}
-	}
+	_, err = e.ID(t.ID).AllCols().Update(t)
+	return err
+}
 
-	if err = t.getMembers(e); err != nil {
+// unwatchRepositoryOfLostMembers removes watches of team members who no longer
+// have read access to the repository. It must be called after accesses of the
+// repository have been recalculated.
+func (t *Team) unwatchRepositoryOfLostMembers(e Engine, repo *Repository) (err error) {
+	if err = t.GetMembers(); err != nil {
 		return fmt.Errorf("get team members: %v", err)
 	}
 
@@ -222,8 +237,9 @@ func (t *Team) removeRepository(e Engine, repo *Repository, recalculate bool) (e
 	return nil
 }
 
-// RemoveRepository removes repository from team of organization.
-func (t *Team) RemoveRepository(repoID int64) error {
+// RemoveRepository removes repository from team of organization. The actor of
+// the change is taken from the context for the audit log.
+func (t *Team) RemoveRepository(ctx context.Context, repoID int64) error {
 	if !t.HasRepository(repoID) {
 		return nil
 	}
@@ -239,11 +255,18 @@ func (t *Team) RemoveRepository(repoID int64) error {
 		return err
 	}
 
-	if err = t.removeRepository(sess, repo, true); err != nil {
+	if err = t.removeRepository(sess, repo); err != nil {
+		return err
+	} else if err = t.createTeamRepoAuditLog(ctx, sess, AuditActionRemoveTeamRepo, repo.ID); err != nil {
 		return err
 	}
 
-	return sess.Commit()
+	if err = sess.Commit(); err != nil {
+		return err
+	} else if err = repo.RecalculateAccesses(); err != nil {
+		return err
+	}
+	return t.unwatchRepositoryOfLostMembers(x, repo)
 }
 
 var reservedTeamNames = map[string]struct{}{
@@ -255,52 +278,6 @@ func IsUsableTeamName(name string) error {
 	return isNameAllowed(reservedTeamNames, nil, name)
 }
 
-// NewTeam creates a record of new team.
-// It's caller's responsibility to assign organization ID.
-func NewTeam(t *Team) error {
-	if t.Name == "" {
-		return errors.New("empty team name")
-	} else if t.OrgID == 0 {
-		return errors.New("OrgID is not assigned")
-	}
-
-	if err := IsUsableTeamName(t.Name); err != nil {
-		return err
-	}
-
-	has, err := x.Id(t.OrgID).Get(new(User))
-	if err != nil {
-		return err
-	} else if !has {
-		return ErrOrgNotExist
-	}
-
-	t.LowerName = strings.ToLower(t.Name)
-	existingTeam := Team{}
-	has, err = x.Where("org_id=?", t.OrgID).And("lower_name=?", t.LowerName).Get(&existingTeam)
-	if err != nil {
-		return err
-	} else if has {
-		return ErrTeamAlreadyExist{existingTeam.ID, t.OrgID, t.LowerName}
-	}
-
-	sess := x.NewSession()
-	defer sess.Close()
-	if err = sess.Begin(); err != nil {
-		return err
-	}
-
-	if _, err = sess.Insert(t); err != nil {
-		return err
-	}
-
-	// Update organization number of teams.
-	if _, err = sess.Exec("UPDATE `user` SET num_teams=num_teams+1 WHERE id = ?", t.OrgID); err != nil {
-		return err
-	}
-	return sess.Commit()
-}
-
 var _ errutil.NotFound = (*ErrTeamNotExist)(nil)
 
 type ErrTeamNotExist struct {
@@ -365,94 +342,6 @@ func GetTeamsByOrgID(orgID int64) ([]*Team, error) {
 	return getTeamsByOrgID(x, orgID)
 }
 
-// UpdateTeam updates information of team.
-func UpdateTeam(t *Team, authChanged bool) (err error) {
-	if t.Name == "" {
-		return errors.New("empty team name")
-	}
-
-	if len(t.Description) > 255 {
-		t.Description = t.Description[:255]
-	}
-
-	sess := x.NewSession()
-	defer sess.Close()
-	if err = sess.Begin(); err != nil {
-		return err
-	}
-
-	t.LowerName = strings.ToLower(t.Name)
-	existingTeam := new(Team)
-	has, err := x.Where("org_id=?", t.OrgID).And("lower_name=?", t.LowerName).And("id!=?", t.ID).Get(existingTeam)
-	if err != nil {
-		return err
-	} else if has {
-		return ErrTeamAlreadyExist{existingTeam.ID, t.OrgID, t.LowerName}
-	}
-
-	if _, err = sess.ID(t.ID).AllCols().Update(t); err != nil {
-		return fmt.Errorf("update: %v", err)
-	}
-
-	// Update access for team members if needed.
-	if authChanged {
-		if err = t.getRepositories(sess); err != nil {
-			return fmt.Errorf("getRepositories:%v", err)
-		}
-
-		for _, repo := range t.Repos {
-			if err = repo.recalculateTeamAccesses(sess, 0); err != nil {
-				return fmt.Errorf("recalculateTeamAccesses: %v", err)
-			}
-		}
-	}
-
-	return sess.Commit()
-}
-
-// DeleteTeam deletes given team.
-// It's caller's responsibility to assign organization ID.
-func DeleteTeam(t *Team) error {
-	if err := t.GetRepositories(); err != nil {
-		return err
-	}
-
-	// Get organization.
-	org, err := Users.GetByID(context.TODO(), t.OrgID)
-	if err != nil {
-		return err
-	}
-
-	sess := x.NewSession()
-	defer sess.Close()
-	if err = sess.Begin(); err != nil {
-		return err
-	}
-
-	// Delete all accesses.
-	for _, repo := range t.Repos {
-		if err = repo.recalculateTeamAccesses(sess, t.ID); err != nil {
-			return err
-		}
-	}
-
-	// Delete team-user.
-	if _, err = sess.Where("org_id=?", org.ID).Where("team_id=?", t.ID).Delete(new(TeamUser)); err != nil {
-		return err
-	}
-
-	// Delete team.
-	if _, err = sess.ID(t.ID).Delete(new(Team)); err != nil {
-		return err
-	}
-	// Update organization number of teams.
-	if _, err = sess.Exec("UPDATE `user` SET num_teams=num_teams-1 WHERE id=?", t.OrgID); err != nil {
-		return err
-	}
-
-	return sess.Commit()
-}
-
 // ___________                    ____ ___
 // \__    ___/___ _____    _____ |    |   \______ ___________
 //   |    |_/ __ \\__  \  /     \|    |   /  ___// __ \_  __ \
@@ -468,36 +357,14 @@ type TeamUser struct {
 	UID    int64 `xorm:"UNIQUE(s)"`
 }
 
-func isTeamMember(e Engine, orgID, teamID, uid int64) bool {
-	has, _ := e.Where("org_id=?", orgID).And("team_id=?", teamID).And("uid=?", uid).Get(new(TeamUser))
-	return has
-}
-
 // IsTeamMember returns true if given user is a member of team.
 func IsTeamMember(orgID, teamID, uid int64) bool {
-	return isTeamMember(x, orgID, teamID, uid)
-}
-
-func getTeamMembers(e Engine, teamID int64) (_ []*User, err error) {
-	teamUsers := make([]*TeamUser, 0, 10)
-	if err = e.Sql("SELECT `id`, `org_id`, `team_id`, `uid` FROM `team_user` WHERE team_id = ?", teamID).
-		Find(&teamUsers); err != nil {
-		return nil, fmt.Errorf("get team-users: %v", err)
-	}
-	members := make([]*User, 0, len(teamUsers))
-	for i := range teamUsers {
-		member := new(User)
-		if _, err = e.ID(teamUsers[i].UID).Get(member); err != nil {
-			return nil, fmt.Errorf("get user '%d': %v", teamUsers[i].UID, err)
-		}
-		members = append(members, member)
-	}
-	return members, nil
+	return Teams.IsTeamMember(context.TODO(), teamID, uid)
 }
 
 // GetTeamMembers returns all members in given team of organization.
 func GetTeamMembers(teamID int64) ([]*User, error) {
-	return getTeamMembers(x, teamID)
+	return Teams.ListMembers(context.TODO(), teamID)
 }
 
 func getUserTeams(e Engine, orgID, userID int64) ([]*Team, error) {
@@ -521,143 +388,6 @@ func GetUserTeams(orgID, userID int64) ([]*Team, error) {
 	return getUserTeams(x, orgID, userID)
 }
 
-// AddTeamMember adds new membership of given team to given organization,
-// the user will have membership to given organization automatically when needed.
-func AddTeamMember(orgID, teamID, userID int64) error {
-	if IsTeamMember(orgID, teamID, userID) {
-		return nil
-	}
-
-	if err := AddOrgUser(orgID, userID); err != nil {
-		return err
-	}
-
-	// Get team and its repositories.
-	t, err := GetTeamByID(teamID)
-	if err != nil {
-		return err
-	}
-	t.NumMembers++
-
-	if err = t.GetRepositories(); err != nil {
-		return err
-	}
-
-	sess := x.NewSession()
-	defer sess.Close()
-	if err = sess.Begin(); err != nil {
-		return err
-	}
-
-	tu := &TeamUser{
-		UID:    userID,
-		OrgID:  orgID,
-		TeamID: teamID,
-	}
-	if _, err = sess.Insert(tu); err != nil {
-		return err
-	} else if _, err = sess.ID(t.ID).Update(t); err != nil {
-		return err
-	}
-
-	// Give access to team repositories.
-	for _, repo := range t.Repos {
-		if err = repo.recalculateTeamAccesses(sess, 0); err != nil {
-			return err
-		}
-	}
-
-	// We make sure it exists before.
-	ou := new(OrgUser)
-	if _, err = sess.Where("uid = ?", userID).And("org_id = ?", orgID).Get(ou); err != nil {
-		return err
-	}
-	ou.NumTeams++
-	if t.IsOwnerTeam() {
-		ou.IsOwner = true
-	}
-	if _, err = sess.ID(ou.ID).AllCols().Update(ou); err != nil {
-		return err
-	}
-
-	return sess.Commit()
-}
-
-func removeTeamMember(e Engine, orgID, teamID, uid int64) error {
-	if !isTeamMember(e, orgID, teamID, uid) {
-		return nil
-	}
-
-	// Get team and its repositories.
-	t, err := getTeamByID(e, teamID)
-	if err != nil {
-		return err
-	}
-
-	// Check if the user to delete is the last member in owner team.
-	if t.IsOwnerTeam() && t.NumMembers == 1 {
-		return ErrLastOrgOwner{UID: uid}
-	}
-
-	t.NumMembers--
-
-	if err = t.getRepositories(e); err != nil {
-		return err
-	}
-
-	// Get organization.
-	org, err := getUserByID(e, orgID)
-	if err != nil {
-		return err
-	}
-
-	tu := &TeamUser{
-		UID:    uid,
-		OrgID:  orgID,
-		TeamID: teamID,
-	}
-	if _, err := e.Delete(tu); err != nil {
-		return err
-	} else if _, err = e.ID(t.ID).AllCols().Update(t); err != nil {
-		return err
-	}
-
-	// Delete access to team repositories.
-	for _, repo := range t.Repos {
-		if err = repo.recalculateTeamAccesses(e, 0); err != nil {
-			return err
-		}
-	}
-
-	// This must exist.
-	ou := new(OrgUser)
-	_, err = e.Where("uid = ?", uid).And("org_id = ?", org.ID).Get(ou)
-	if err != nil {
-		return err
-	}
-	ou.NumTeams--
-	if t.IsOwnerTeam() {
-		ou.IsOwner = false
-	}
-	if _, err = e.ID(ou.ID).AllCols().Update(ou); err != nil {
-		return err
-	}
-	return nil
-}
-
-// RemoveTeamMember removes member from given team of given organization.
-func RemoveTeamMember(orgID, teamID, uid int64) error {
-	sess := x.NewSession()
-	defer sess.Close()
-	if err := sess.Begin(); err != nil {
-		return err
-	}
-	if err := removeTeamMember(sess, orgID, teamID, uid); err != nil {
-		return err
-	}
-	return sess.Commit()
-}
-
 // ___________                  __________
 // \__    ___/___ _____    _____\______   \ ____ ______   ____
 //   |    |_/ __ \\__  \  /     \|       _// __ \\____ \ /  _ \
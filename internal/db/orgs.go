@@ -6,34 +6,328 @@ package db
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	log "unknwon.dev/clog/v2"
 
+	"gogs.io/gogs/internal/conf"
 	"gogs.io/gogs/internal/dbutil"
+	"gogs.io/gogs/internal/errutil"
+	"gogs.io/gogs/internal/lazyregexp"
+	"gogs.io/gogs/internal/osutil"
+	"gogs.io/gogs/internal/repoutil"
+	"gogs.io/gogs/internal/strutil"
+	"gogs.io/gogs/internal/tool"
+	"gogs.io/gogs/internal/userutil"
 )
 
 // OrgsStore is the persistent interface for organizations.
 type OrgsStore interface {
 	// List returns a list of organizations filtered by options.
 	List(ctx context.Context, opts ListOrgsOptions) ([]*Organization, error)
+	// ListOrgsWithRole is like List but also returns the membership flags of the
+	// member in each organization.
+	ListOrgsWithRole(ctx context.Context, opts ListOrgsOptions) ([]*OrgWithRole, error)
+	// ListOrgsWithRepoCreatePermission returns a list of organizations that the
+	// given user is allowed to create repositories in, i.e. the user is an owner,
+	// or belongs to a team with the write access mode or higher when the
+	// organization allows members to create repositories (see
+	// RepoCreatePermission). Results are
+	// sorted by the time of last update in descending order.
+	ListOrgsWithRepoCreatePermission(ctx context.Context, userID int64) ([]*Organization, error)
+	// ListCommon returns a list of organizations that both users are members of,
+	// sorted by organization name in ascending order. The userA is the viewer,
+	// who is a member of every returned organization and thus can see private
+	// memberships of userB in them.
+	ListCommon(ctx context.Context, userA, userB int64) ([]*Organization, error)
+	// ListOrphaned returns a list of organizations that nobody can administer,
+	// i.e. those whose Owners team has no member or is missing. Results are
+	// sorted by organization ID in ascending order. It is intended for site
+	// admins to repair organizations, callers are responsible for access control.
+	ListOrphaned(ctx context.Context) ([]*Organization, error)
 	// SearchByName returns a list of organizations whose username or full name
 	// matches the given keyword case-insensitively. Results are paginated by given
 	// page and page size, and sorted by the given order (e.g. "id DESC"). A total
 	// count of all results is also returned. If the order is not given, it's up to
-	// the database to decide.
-	SearchByName(ctx context.Context, keyword string, page, pageSize int, orderBy string) ([]*Organization, int64, error)
+	// the database to decide. Archived organizations are excluded unless
+	// includeArchived is true.
+	SearchByName(ctx context.Context, keyword string, page, pageSize int, orderBy string, includeArchived bool) ([]*Organization, int64, error)
+	// SearchVisibleByName is like SearchByName but excludes organizations that
+	// are effectively private to the viewer, i.e. those without any public member
+	// and the viewer is not a member of, as well as archived organizations. The
+	// viewer is anonymous when viewerID is 0. Unlike SearchByName, an empty
+	// keyword lists all visible organizations, e.g. for browsing.
+	SearchVisibleByName(ctx context.Context, keyword string, page, pageSize int, orderBy string, viewerID int64) ([]*Organization, int64, error)
+	// GetByName returns the organization with given name case-insensitively.
+	// It returns ErrOrgNotExist when not found, including when the name belongs
+	// to an individual user.
+	GetByName(ctx context.Context, name string) (*Organization, error)
+
+	// Create creates a new organization with the given name and makes the given
+	// user the owner of it, including the membership of its Owners team. It
+	// returns ErrNameNotAllowed when the name is not allowed, or
+	// ErrOrgAlreadyExist when the name is already taken by a user or an
+	// organization.
+	Create(ctx context.Context, name string, ownerID int64, opts CreateOrgOptions) (*Organization, error)
+
+	// DeleteByID deletes the given organization and all its members, teams and
+	// accesses. It returns ErrOrgOwnsRepos when the organization still owns
+	// repositories, unless opts.Force is set in which case the repositories are
+	// deleted as well.
+	DeleteByID(ctx context.Context, orgID int64, opts DeleteOrgOptions) error
 
 	// CountByUser returns the number of organizations the user is a member of.
 	CountByUser(ctx context.Context, userID int64) (int64, error)
+	// CountOwnedByUser returns the number of organizations the user is an owner
+	// of.
+	CountOwnedByUser(ctx context.Context, userID int64) (int64, error)
+
+	// AccessibleRepositoriesByUser returns a range of repositories in the
+	// organization that the user has access to, sorted by the time of last update
+	// in descending order. Results are paginated by given page and page size. A
+	// total count of all results is also returned unless opts.SkipCount is set,
+	// and whether there are more results after the current page, see
+	// AccessibleRepositoriesByUserOptions for how it is determined.
+	AccessibleRepositoriesByUser(ctx context.Context, orgID, userID int64, page, pageSize int, opts AccessibleRepositoriesByUserOptions) (repos []*Repository, count int64, hasMore bool, err error)
+	// AccessibleRepositoryIDsByUser returns IDs of all repositories in the
+	// organization that the user has access to, including archived ones, sorted
+	// by ID in ascending order. It is a cheaper alternative to
+	// AccessibleRepositoriesByUser when only the set membership matters.
+	AccessibleRepositoryIDsByUser(ctx context.Context, orgID, userID int64) ([]int64, error)
+	// CountAccessibleRepositoriesByUser returns the number of repositories in the
+	// organization that the user has access to, including archived ones. It is
+	// the same as the total count returned by AccessibleRepositoriesByUser with
+	// default options, but without fetching any repository.
+	CountAccessibleRepositoriesByUser(ctx context.Context, orgID, userID int64) (int64, error)
+	// ListAllRepos returns a range of all repositories in the organization
+	// regardless of visibility and team memberships, sorted by the time of last
+	// update in descending order. Results are paginated by given page and page
+	// size, and a total count of all results is also returned. It is intended for
+	// site admins, callers are responsible for access control.
+	ListAllRepos(ctx context.Context, orgID int64, page, pageSize int, opts ListAllReposOptions) ([]*Repository, int64, error)
+
+	// AddMember adds a new member to the given organization. It is a no-op when
+	// the user is already a member.
+	AddMember(ctx context.Context, orgID, userID int64) error
+	// AddMembers adds the given list of users as members of the organization in
+	// a single transaction. Users who are already members and duplicated IDs are
+	// skipped.
+	AddMembers(ctx context.Context, orgID int64, userIDs []int64) error
+	// AddMemberToTeam adds the user as a member of the organization if not
+	// already, and as a member of the team in a single transaction. Accesses of
+	// repositories of the team are recalculated for its members. It returns
+	// ErrTeamNotExist when the team does not exist, or ErrTeamOrgMismatch when
+	// the team does not belong to the organization.
+	AddMemberToTeam(ctx context.Context, orgID, userID, teamID int64) error
+	// RemoveMember removes the user from the given organization, along with their
+	// memberships of teams in the organization. It is a no-op when the user is not
+	// a member. It returns ErrLastOrgOwner when the user is the last member of the
+	// Owners team.
+	RemoveMember(ctx context.Context, orgID, userID int64) error
+	// EnsureOwnersTeam returns the Owners team of the given organization. When
+	// the team is missing, e.g. for some legacy organizations, it creates the team
+	// with current owners of the organization as members. It returns
+	// ErrOrgNotExist when the organization does not exist.
+	EnsureOwnersTeam(ctx context.Context, orgID int64) (*Team, error)
+	// InviteByEmail invites the email address to join the organization, and the
+	// team as well when the team ID is positive. Invitations expire after
+	// conf.User.OrgInvitationExpiry, and inviting the same email again replaces
+	// the pending invitation. It returns ErrOrgNotExist when the organization
+	// does not exist, ErrTeamNotExist when the team does not exist, or
+	// ErrTeamOrgMismatch when the team does not belong to the organization.
+	InviteByEmail(ctx context.Context, orgID int64, email string, teamID int64) (*OrgInvitation, error)
+	// ListInvitations returns pending invitations of the organization that have
+	// not expired, sorted by the time of invitation in ascending order.
+	ListInvitations(ctx context.Context, orgID int64) ([]*OrgInvitation, error)
+	// RevokeInvitation deletes the invitation with given ID of the organization.
+	// It returns ErrOrgInvitationNotExist when not found.
+	RevokeInvitation(ctx context.Context, orgID, invitationID int64) error
+	// ConsumeInvitations accepts all pending invitations of the email addresses
+	// that have been verified by the user, adding the user to invited
+	// organizations and teams. The primary email only counts as verified when
+	// email confirmation is required. Consumed and expired invitations of those
+	// email addresses are deleted.
+	ConsumeInvitations(ctx context.Context, userID int64) error
+	// AcceptInvitation accepts the pending invitation with given token on behalf
+	// of the user, adding the user to the invited organization and team. Having
+	// the token proves the ownership of the invited email address. It returns
+	// ErrOrgInvitationNotExist when no pending invitation has the token.
+	AcceptInvitation(ctx context.Context, userID int64, token string) (*OrgInvitation, error)
+	// GetMembership returns the membership of the user in the organization. It
+	// returns ErrNotOrgMember when the user is not a member of the organization.
+	GetMembership(ctx context.Context, orgID, userID int64) (*OrgUser, error)
+	// HasMember returns true if the user is a member of the organization.
+	HasMember(ctx context.Context, orgID, userID int64) bool
+	// FilterMembers returns the membership status of each given user in the
+	// organization, keyed by user ID. Every given user ID is present in the
+	// result.
+	FilterMembers(ctx context.Context, orgID int64, userIDs []int64) (map[int64]bool, error)
+	// IsOwnedBy returns true if the user is an owner of the organization.
+	IsOwnedBy(ctx context.Context, orgID, userID int64) bool
+	// SetOwner promotes the member to an owner of the organization by adding them
+	// to the Owners team, or demotes the owner to a regular member by removing
+	// them from the Owners team, "org_user.is_owner" is kept in sync either way.
+	// It returns ErrNotOrgMember when the user is not a member of the
+	// organization, or ErrLastOrgOwner when demoting the last member of the
+	// Owners team.
+	SetOwner(ctx context.Context, orgID, userID int64, isOwner bool) error
+	// LeaveOrg removes the user from the given organization on their own behalf.
+	// Unlike RemoveMember, it returns ErrNotOrgMember when the user is not a
+	// member. It returns ErrLastOrgOwner when the user is the last member of the
+	// Owners team.
+	LeaveOrg(ctx context.Context, orgID, userID int64) error
+	// CountMembers returns the number of members of the organization. Unlike the
+	// cached "user.num_members" column, the value is always computed live.
+	CountMembers(ctx context.Context, orgID int64) (int64, error)
+	// RecountAll recomputes all cached counters of the organization in a single
+	// transaction, including the number of members of the organization and each
+	// of its teams, and the number of teams of each membership.
+	RecountAll(ctx context.Context, orgID int64) error
+	// DeduplicateMembers deletes duplicated memberships of the organization that
+	// were created before the unique index was enforced, keeping the oldest one
+	// for each user, and recounts members of the organization. It returns the
+	// number of deleted memberships, and is a no-op when there are no duplicates.
+	DeduplicateMembers(ctx context.Context, orgID int64) (int64, error)
+	// ListMembers returns a list of members of the organization, sorted by user ID
+	// in ascending order unless opts.OrderBy is set. A total count of all members
+	// is also returned.
+	ListMembers(ctx context.Context, orgID int64, opts ListOrgMembersOptions) ([]*User, int64, error)
+	// ListActiveMembers returns all members of the organization who have signed
+	// in at or after the given time in Unix seconds, sorted by user ID in
+	// ascending order.
+	ListActiveMembers(ctx context.Context, orgID int64, sinceUnix int64) ([]*User, error)
+	// SearchMembers returns a range of members of the organization whose
+	// usernames or full names contain the keyword case-insensitively, sorted by
+	// username in ascending order, and a total count of all results. An empty
+	// keyword matches all members. Results are paginated by given page and page
+	// size.
+	SearchMembers(ctx context.Context, orgID int64, keyword string, page, pageSize int, opts SearchOrgMembersOptions) ([]*User, int64, error)
+	// ListOrgMembersWithRole is like ListMembers but also returns the membership
+	// flags of each member, without a total count.
+	ListOrgMembersWithRole(ctx context.Context, orgID int64, opts ListOrgMembersOptions) ([]*OrgMemberWithRole, error)
+	// StreamMembers calls fn for each member of the organization along with
+	// their membership flags, sorted by user ID in ascending order. Members are
+	// read from the database row by row so that they are never all held in
+	// memory. Iteration stops at the first error returned by fn.
+	StreamMembers(ctx context.Context, orgID int64, fn func(*OrgMemberWithRole) error) error
+
+	// GetTeamsByUser returns the list of teams in the organization that the user
+	// is a member of, sorted by team ID in ascending order.
+	GetTeamsByUser(ctx context.Context, orgID, userID int64) ([]*Team, error)
+
+	// SetDefaultRepoPermission sets the access mode that members of the
+	// organization get on its public repositories by default, it must be one of
+	// AccessModeNone, AccessModeRead or AccessModeWrite. Accesses of existing
+	// members are updated accordingly.
+	SetDefaultRepoPermission(ctx context.Context, orgID int64, perm AccessMode) error
+	// SetRequireTwoFactor sets whether members of the organization must enable
+	// two-factor authentication to access its resources. It returns
+	// ErrOrgOwnersWithoutTwoFactor when enabling the requirement would lock out
+	// any owner of the organization.
+	SetRequireTwoFactor(ctx context.Context, orgID int64, required bool) error
+	// SetVerified sets whether the organization is verified as official. The
+	// caller is responsible for checking that the doer is a site admin.
+	SetVerified(ctx context.Context, orgID int64, verified bool) error
+	// SetArchived sets whether the organization is archived. Archived
+	// organizations are excluded from listings and searches by default, and
+	// pushes to their repositories are rejected. It returns ErrOrgNotExist when
+	// not found.
+	SetArchived(ctx context.Context, orgID int64, archived bool) error
+	// SetRepoCreatePermission sets who are allowed to create repositories in the
+	// organization. It returns ErrOrgNotExist when not found.
+	SetRepoCreatePermission(ctx context.Context, orgID int64, perm RepoCreatePermission) error
+
+	// GetDefaultProtection returns branch protection templates of the
+	// organization, sorted by branch name in ascending order.
+	GetDefaultProtection(ctx context.Context, orgID int64) ([]*OrgProtectBranch, error)
+	// SetDefaultProtection replaces branch protection templates of the
+	// organization with the given list, which are copied to repositories created
+	// under the organization afterwards. Existing repositories are unaffected
+	// until ApplyDefaultProtectionToAll is called. Templates with empty or
+	// duplicated branch names are ignored.
+	SetDefaultProtection(ctx context.Context, orgID int64, protections []*OrgProtectBranch) error
+	// ApplyDefaultProtection copies branch protection templates of the
+	// organization to the repository. Existing protections of branches with same
+	// names are overwritten.
+	ApplyDefaultProtection(ctx context.Context, orgID, repoID int64) error
+	// ApplyDefaultProtectionToAll copies branch protection templates of the
+	// organization to all of its existing repositories. Existing protections of
+	// branches with same names are overwritten.
+	ApplyDefaultProtectionToAll(ctx context.Context, orgID int64) error
+
+	// GetDefaultLabels returns issue label templates of the organization, sorted
+	// by label name in ascending order.
+	GetDefaultLabels(ctx context.Context, orgID int64) ([]*OrgLabel, error)
+	// SetDefaultLabels replaces issue label templates of the organization with
+	// the given list, which are copied to repositories created under the
+	// organization afterwards. Existing repositories are unaffected until
+	// ApplyDefaultLabelsToAll is called. Templates with empty or duplicated names,
+	// or invalid colors are ignored.
+	SetDefaultLabels(ctx context.Context, orgID int64, labels []*OrgLabel) error
+	// ApplyDefaultLabelsToAll copies issue label templates of the organization to
+	// all of its existing repositories. See ReposStore.ApplyOrgLabels for details.
+	ApplyDefaultLabelsToAll(ctx context.Context, orgID int64) error
+
+	// SetPinnedRepos replaces pinned repositories of the organization with the
+	// given list of repository IDs, the order of IDs is preserved and duplicates
+	// are ignored. It returns ErrInvalidPinnedRepo when any repository does not
+	// belong to the organization, or there are too many repositories.
+	SetPinnedRepos(ctx context.Context, orgID int64, repoIDs []int64) error
+	// ListPinnedRepos returns pinned repositories of the organization in the
+	// pinned order. The caller is responsible for filtering out repositories that
+	// the viewer does not have access to.
+	ListPinnedRepos(ctx context.Context, orgID int64) ([]*Repository, error)
+
+	// ListAuditLog returns a range of audit logs of the organization in reverse
+	// chronological order, and a total count of all audit logs of the
+	// organization. Results are paginated by opts.Page and opts.PageSize.
+	ListAuditLog(ctx context.Context, orgID int64, opts ListAuditLogOptions) ([]*AuditLog, int64, error)
+
+	// SetMemberVisibility sets whether the membership of the given user in the
+	// organization is publicly visible. It returns ErrNotOrgMember when the user
+	// is not a member of the organization.
+	SetMemberVisibility(ctx context.Context, orgID, userID int64, public bool) error
+	// SetMemberNotifyLevel sets the level of email notifications the given user
+	// receives for repositories of the organization, the level must be one of
+	// OrgNotifyLevelAll, OrgNotifyLevelParticipating and OrgNotifyLevelNone. It
+	// returns an error wrapping gorm.ErrRecordNotFound when the user is not a
+	// member of the organization.
+	SetMemberNotifyLevel(ctx context.Context, orgID, userID int64, level int) error
+	// ListMemberNotifyLevels returns the email notification levels of all members
+	// of the organization, keyed by user IDs.
+	ListMemberNotifyLevels(ctx context.Context, orgID int64) (map[int64]int, error)
+	// ListNotifyLevelsOfMember returns the email notification levels of the user
+	// in all organizations it is a member of, keyed by organization IDs.
+	ListNotifyLevelsOfMember(ctx context.Context, userID int64) (map[int64]int, error)
+
+	// TransferOwnership transfers the repository to the given organization and
+	// grants the Owners team of the organization access to it. It returns
+	// ErrOrgNotExist when the new owner is not an organization, or
+	// ErrRepoAlreadyExist when a repository with same name already exists for the
+	// organization.
+	TransferOwnership(ctx context.Context, repoID, newOrgID int64, opts TransferOptions) error
 }
 
 var Orgs OrgsStore
 
+// OrgsReplica is like Orgs but sends heavy read-only queries (e.g. listing and
+// searching) to the read replica of the database when configured, and it falls
+// back to the primary connection otherwise. Call sites must opt into it
+// explicitly and only for reads that tolerate replication lag.
+var OrgsReplica OrgsStore
+
 var _ OrgsStore = (*orgs)(nil)
 
 type orgs struct {
 	*gorm.DB
+	// The optional read replica of the database for heavy read-only queries.
+	replica *gorm.DB
 }
 
 // NewOrgsStore returns a persistent interface for orgs with given database
@@ -42,18 +336,86 @@ func NewOrgsStore(db *gorm.DB) OrgsStore {
 	return &orgs{DB: db}
 }
 
+// NewOrgsStoreWithReplica returns a persistent interface for orgs with given
+// database connection, and the read replica that heavy read-only queries (e.g.
+// listing and searching) are sent to. Writes and transactional flows always use
+// the primary connection.
+func NewOrgsStoreWithReplica(db, replica *gorm.DB) OrgsStore {
+	return &orgs{DB: db, replica: replica}
+}
+
+// reader returns the read replica of the database if configured, or the primary
+// connection otherwise. It must only be used for queries that tolerate
+// replication lag.
+func (db *orgs) reader() *gorm.DB {
+	if db.replica != nil {
+		return db.replica
+	}
+	return db.DB
+}
+
 type ListOrgsOptions struct {
 	// Filter by the membership with the given user ID.
 	MemberID int64
 	// Whether to include private memberships.
 	IncludePrivateMembers bool
+	// Whether to only include organizations the member is an owner of.
+	OwnedOnly bool
+	// Whether to include archived organizations.
+	IncludeArchived bool
+	// The column to sort by with an optional direction (e.g. "lower_name DESC"),
+	// it must be one of "id", "lower_name", "num_members" or "created_unix".
+	// Default is "id ASC".
+	OrderBy string
 }
 
-func (db *orgs) List(ctx context.Context, opts ListOrgsOptions) ([]*Organization, error) {
+// listOrgsOrderByColumns is the allowlist of columns that organizations can be
+// sorted by.
+var listOrgsOrderByColumns = map[string]struct{}{
+	"id":           {},
+	"lower_name":   {},
+	"num_members":  {},
+	"created_unix": {},
+}
+
+// parseListOrgsOrderBy validates the given order and returns the ORDER BY
+// clause for the "user" table.
+func parseListOrgsOrderBy(orderBy string) (string, error) {
+	if orderBy == "" {
+		return dbutil.Quote("%s.id ASC", "user"), nil
+	}
+
+	fields := strings.Fields(orderBy)
+	if len(fields) > 2 {
+		return "", errors.Errorf("invalid order %q", orderBy)
+	}
+
+	column := strings.ToLower(fields[0])
+	if _, ok := listOrgsOrderByColumns[column]; !ok {
+		return "", errors.Errorf("invalid order column %q", fields[0])
+	}
+
+	direction := "ASC"
+	if len(fields) == 2 {
+		direction = strings.ToUpper(fields[1])
+		if direction != "ASC" && direction != "DESC" {
+			return "", errors.Errorf("invalid order direction %q", fields[1])
+		}
+	}
+	return dbutil.Quote("%s."+column+" "+direction, "user"), nil
+}
+
+// listQuery returns a query for organizations filtered by options.
+func (db *orgs) listQuery(ctx context.Context, opts ListOrgsOptions) (*gorm.DB, error) {
 	if opts.MemberID <= 0 {
 		return nil, errors.New("MemberID must be greater than 0")
 	}
 
+	orderBy, err := parseListOrgsOrderBy(opts.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+
 	/*
 		Equivalent SQL for PostgreSQL:
 
@@ -62,22 +424,450 @@ func (db *orgs) List(ctx context.Context, opts ListOrgsOptions) ([]*Organization
 		WHERE
 			org_user.uid = @memberID
 		[AND org_user.is_public = @includePrivateMembers]
-		ORDER BY org.id ASC
+		[AND org_user.is_owner = TRUE]
+		[AND org.is_archived = FALSE]
+		ORDER BY @orderBy
 	*/
-	tx := db.WithContext(ctx).
+	tx := db.reader().WithContext(ctx).
 		Joins(dbutil.Quote("JOIN org_user ON org_user.org_id = %s.id", "user")).
 		Where("org_user.uid = ?", opts.MemberID).
-		Order(dbutil.Quote("%s.id ASC", "user"))
+		Order(orderBy)
 	if !opts.IncludePrivateMembers {
 		tx = tx.Where("org_user.is_public = ?", true)
 	}
+	if opts.OwnedOnly {
+		tx = tx.Where("org_user.is_owner = ?", true)
+	}
+	if !opts.IncludeArchived {
+		tx = tx.Where(dbutil.Quote("%s.is_archived = ?", "user"), false)
+	}
+	return tx, nil
+}
+
+func (db *orgs) List(ctx context.Context, opts ListOrgsOptions) ([]*Organization, error) {
+	tx, err := db.listQuery(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
 
 	var orgs []*Organization
 	return orgs, tx.Find(&orgs).Error
 }
 
-func (db *orgs) SearchByName(ctx context.Context, keyword string, page, pageSize int, orderBy string) ([]*Organization, int64, error) {
-	return searchUserByName(ctx, db.DB, UserTypeOrganization, keyword, page, pageSize, orderBy)
+// OrgWithRole is an organization along with the membership flags of a member.
+type OrgWithRole struct {
+	Organization *Organization
+	// Whether the member is in the Owners team of the organization.
+	IsOwner bool
+	// Whether the membership is publicly visible.
+	IsPublic bool
+}
+
+func (db *orgs) ListOrgsWithRole(ctx context.Context, opts ListOrgsOptions) ([]*OrgWithRole, error) {
+	tx, err := db.listQuery(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []*struct {
+		Organization    `gorm:"embedded"`
+		OrgUserIsOwner  bool
+		OrgUserIsPublic bool
+	}
+	err = tx.Table("user").
+		Select(dbutil.Quote("%s.*, org_user.is_owner AS org_user_is_owner, org_user.is_public AS org_user_is_public", "user")).
+		Find(&rows).
+		Error
+	if err != nil {
+		return nil, errors.Wrap(err, "list organizations")
+	}
+
+	orgs := make([]*OrgWithRole, 0, len(rows))
+	for _, row := range rows {
+		org := row.Organization
+		// Hooks are not run for embedded structs.
+		_ = org.AfterFind(tx)
+		orgs = append(orgs, &OrgWithRole{
+			Organization: &org,
+			IsOwner:      row.OrgUserIsOwner,
+			IsPublic:     row.OrgUserIsPublic,
+		})
+	}
+	return orgs, nil
+}
+
+func (db *orgs) ListOrgsWithRepoCreatePermission(ctx context.Context, userID int64) ([]*Organization, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT "user".* FROM "user"
+		JOIN org_user ON org_user.org_id = "user".id
+		WHERE
+			org_user.uid = @userID
+		AND (
+				org_user.is_owner = TRUE
+			OR  "user".repo_create_permission = @repoCreatePermissionMembers
+			AND "user".id IN (
+					SELECT team.org_id FROM team
+					JOIN team_user ON team_user.team_id = team.id
+					WHERE team_user.uid = @userID AND team.authorize >= @accessModeWrite
+				)
+		)
+		ORDER BY "user".updated_unix DESC
+	*/
+	var orgs []*Organization
+	return orgs, db.WithContext(ctx).
+		Joins(dbutil.Quote("JOIN org_user ON org_user.org_id = %s.id", "user")).
+		Where("org_user.uid = ?", userID).
+		Where(dbutil.Quote("org_user.is_owner = ? OR %[1]s.repo_create_permission = ? AND %[1]s.id IN (?)", "user"),
+			true,
+			RepoCreatePermissionMembers,
+			db.WithContext(ctx).
+				Model(&Team{}).
+				Select("team.org_id").
+				Joins("JOIN team_user ON team_user.team_id = team.id").
+				Where("team_user.uid = ? AND team.authorize >= ?", userID, AccessModeWrite),
+		).
+		Order(dbutil.Quote("%s.updated_unix DESC", "user")).
+		Find(&orgs).
+		Error
+}
+
+func (db *orgs) ListCommon(ctx context.Context, userA, userB int64) ([]*Organization, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT "user".* FROM "user"
+		JOIN org_user AS org_user_a ON org_user_a.org_id = "user".id
+		JOIN org_user AS org_user_b ON org_user_b.org_id = "user".id
+		WHERE
+			org_user_a.uid = @userA
+		AND org_user_b.uid = @userB
+		ORDER BY "user".lower_name ASC
+	*/
+	var orgs []*Organization
+	return orgs, db.reader().WithContext(ctx).
+		Joins(dbutil.Quote("JOIN org_user AS org_user_a ON org_user_a.org_id = %s.id", "user")).
+		Joins(dbutil.Quote("JOIN org_user AS org_user_b ON org_user_b.org_id = %s.id", "user")).
+		Where("org_user_a.uid = ? AND org_user_b.uid = ?", userA, userB).
+		Order(dbutil.Quote("%s.lower_name ASC", "user")).
+		Find(&orgs).
+		Error
+}
+
+func (db *orgs) ListOrphaned(ctx context.Context) ([]*Organization, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT * FROM "user"
+		WHERE
+			type = @userType
+		AND (
+			SELECT COUNT(*) FROM team_user
+			JOIN team ON team.id = team_user.team_id
+			WHERE team.org_id = "user".id AND team.lower_name = 'owners'
+		) = 0
+		ORDER BY id ASC
+	*/
+	tx := db.WithContext(ctx)
+	numOwners := tx.Session(&gorm.Session{NewDB: true}).
+		Model(&TeamUser{}).
+		Select("COUNT(*)").
+		Joins("JOIN team ON team.id = team_user.team_id").
+		Where(dbutil.Quote("team.org_id = %s.id AND team.lower_name = ?", "user"), strings.ToLower(OWNER_TEAM))
+
+	var orgs []*Organization
+	return orgs, tx.
+		Where("type = ? AND (?) = 0", UserTypeOrganization, numOwners).
+		Order("id ASC").
+		Find(&orgs).
+		Error
+}
+
+// notArchived is a query scope that excludes archived organizations.
+func notArchived(tx *gorm.DB) *gorm.DB {
+	return tx.Where("is_archived = ?", false)
+}
+
+func (db *orgs) SearchByName(ctx context.Context, keyword string, page, pageSize int, orderBy string, includeArchived bool) ([]*Organization, int64, error) {
+	if includeArchived {
+		return searchUserByName(ctx, db.reader(), UserTypeOrganization, keyword, page, pageSize, orderBy)
+	}
+	return searchUserByName(ctx, db.reader(), UserTypeOrganization, keyword, page, pageSize, orderBy, notArchived)
+}
+
+func (db *orgs) SearchVisibleByName(ctx context.Context, keyword string, page, pageSize int, orderBy string, viewerID int64) ([]*Organization, int64, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT * FROM "user"
+		WHERE
+			type = @userType
+		AND (lower_name LIKE @keyword OR LOWER(full_name) LIKE @keyword) -- Only when keyword is not empty
+		AND id IN (
+			SELECT org_id FROM org_user
+			WHERE is_public = TRUE OR uid = @viewerID
+		)
+		AND is_archived = FALSE
+		ORDER BY @orderBy
+		LIMIT @limit OFFSET @offset
+	*/
+	visible := func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("id IN (?)",
+			tx.Session(&gorm.Session{NewDB: true}).
+				Model(&OrgUser{}).
+				Select("org_id").
+				Where("is_public = ? OR uid = ?", true, viewerID),
+		)
+	}
+	if keyword == "" {
+		return listUsersByType(ctx, db.reader(), UserTypeOrganization, page, pageSize, orderBy, visible, notArchived)
+	}
+	return searchUserByName(ctx, db.reader(), UserTypeOrganization, keyword, page, pageSize, orderBy, visible, notArchived)
+}
+
+var _ errutil.NotFound = (*ErrOrgNotExist)(nil)
+
+type ErrOrgNotExist struct {
+	args errutil.Args
+}
+
+// IsErrOrgNotExist returns true if the underlying error has the type
+// ErrOrgNotExist.
+func IsErrOrgNotExist(err error) bool {
+	_, ok := errors.Cause(err).(ErrOrgNotExist)
+	return ok
+}
+
+func (err ErrOrgNotExist) Error() string {
+	return fmt.Sprintf("organization does not exist: %v", err.args)
+}
+
+func (ErrOrgNotExist) NotFound() bool {
+	return true
+}
+
+func (db *orgs) GetByName(ctx context.Context, name string) (*Organization, error) {
+	if name == "" {
+		return nil, ErrOrgNotExist{args: errutil.Args{"name": name}}
+	}
+
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT * FROM "user"
+		WHERE lower_name = @name AND type = @userType
+		LIMIT 1
+	*/
+	org := new(Organization)
+	err := db.WithContext(ctx).
+		Where("lower_name = ? AND type = ?", strings.ToLower(name), UserTypeOrganization).
+		First(org).
+		Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrOrgNotExist{args: errutil.Args{"name": name}}
+		}
+		return nil, err
+	}
+	return org, nil
+}
+
+type CreateOrgOptions struct {
+	FullName    string
+	Description string
+	Website     string
+	Location    string
+}
+
+type ErrOrgAlreadyExist struct {
+	args errutil.Args
+}
+
+// IsErrOrgAlreadyExist returns true if the underlying error has the type
+// ErrOrgAlreadyExist.
+func IsErrOrgAlreadyExist(err error) bool {
+	_, ok := errors.Cause(err).(ErrOrgAlreadyExist)
+	return ok
+}
+
+func (err ErrOrgAlreadyExist) Error() string {
+	return fmt.Sprintf("organization already exists: %v", err.args)
+}
+
+func (db *orgs) Create(ctx context.Context, name string, ownerID int64, opts CreateOrgOptions) (*Organization, error) {
+	err := isUsernameAllowed(name)
+	if err != nil {
+		return nil, err
+	}
+
+	used, err := NewUsersStore(db.DB).IsUsernameUsed(ctx, name, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "check username")
+	} else if used {
+		return nil, ErrOrgAlreadyExist{
+			args: errutil.Args{
+				"name": name,
+			},
+		}
+	}
+
+	org := &Organization{
+		LowerName:       strings.ToLower(name),
+		Name:            name,
+		FullName:        opts.FullName,
+		Description:     opts.Description,
+		Website:         opts.Website,
+		Location:        opts.Location,
+		Type:            UserTypeOrganization,
+		IsActive:        true,
+		UseCustomAvatar: true,
+		MaxRepoCreation: -1,
+		NumTeams:        1,
+		NumMembers:      1,
+	}
+	org.Rands, err = userutil.RandomSalt()
+	if err != nil {
+		return nil, err
+	}
+	org.Salt, err = userutil.RandomSalt()
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(org).Error
+		if err != nil {
+			return errors.Wrap(err, "create organization")
+		}
+
+		err = tx.Create(&OrgUser{
+			Uid:      ownerID,
+			OrgID:    org.ID,
+			IsOwner:  true,
+			NumTeams: 1,
+		}).Error
+		if err != nil {
+			return errors.Wrap(err, "create owner membership")
+		}
+
+		ownersTeam := &Team{
+			OrgID:      org.ID,
+			LowerName:  strings.ToLower(OWNER_TEAM),
+			Name:       OWNER_TEAM,
+			Authorize:  AccessModeOwner,
+			NumMembers: 1,
+		}
+		err = tx.Create(ownersTeam).Error
+		if err != nil {
+			return errors.Wrap(err, "create owners team")
+		}
+
+		err = tx.Create(&TeamUser{
+			UID:    ownerID,
+			OrgID:  org.ID,
+			TeamID: ownersTeam.ID,
+		}).Error
+		if err != nil {
+			return errors.Wrap(err, "create owners team membership")
+		}
+
+		err = os.MkdirAll(repoutil.UserPath(org.Name), os.ModePerm)
+		if err != nil {
+			return errors.Wrap(err, "create directory")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_ = userutil.GenerateRandomAvatar(org.ID, org.Name, org.Email)
+	return org, nil
+}
+
+type DeleteOrgOptions struct {
+	// Whether to delete repositories owned by the organization.
+	Force bool
+}
+
+type ErrOrgOwnsRepos struct {
+	args errutil.Args
+}
+
+// IsErrOrgOwnsRepos returns true if the underlying error has the type
+// ErrOrgOwnsRepos.
+func IsErrOrgOwnsRepos(err error) bool {
+	_, ok := errors.Cause(err).(ErrOrgOwnsRepos)
+	return ok
+}
+
+func (err ErrOrgOwnsRepos) Error() string {
+	return fmt.Sprintf("organization still has repository ownership: %v", err.args)
+}
+
+func (db *orgs) DeleteByID(ctx context.Context, orgID int64, opts DeleteOrgOptions) error {
+	org, err := NewUsersStore(db.DB).GetByID(ctx, orgID)
+	if err != nil {
+		if IsErrUserNotExist(err) {
+			return nil
+		}
+		return errors.Wrap(err, "get organization")
+	} else if !org.IsOrganization() {
+		return ErrOrgNotExist{args: errutil.Args{"orgID": orgID}}
+	}
+
+	var count int64
+	err = db.WithContext(ctx).Model(&Repository{}).Where("owner_id = ?", orgID).Count(&count).Error
+	if err != nil {
+		return errors.Wrap(err, "count repositories")
+	} else if count > 0 && !opts.Force {
+		return ErrOrgOwnsRepos{args: errutil.Args{"orgID": orgID}}
+	}
+
+	var repoPaths []string
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		repoPaths, err = (&repos{DB: tx}).deleteAllByOwner(tx, org)
+		if err != nil {
+			return errors.Wrap(err, "delete repositories")
+		}
+
+		for _, t := range []struct {
+			table any
+			where string
+			arg   any
+		}{
+			{&TeamRepo{}, "org_id = ?", orgID},
+			{&TeamUser{}, "org_id = ?", orgID},
+			{&Team{}, "org_id = ?", orgID},
+			{&OrgUser{}, "org_id = ?", orgID},
+			{&PinnedRepo{}, "org_id = ?", orgID},
+			{&OrgInvitation{}, "org_id = ?", orgID},
+			{&OrgProtectBranch{}, "org_id = ?", orgID},
+			{&Webhook{}, "org_id = ?", orgID},
+			{&OrgLabel{}, "org_id = ?", orgID},
+		} {
+			err := tx.Where(t.where, t.arg).Delete(t.table).Error
+			if err != nil {
+				return errors.Wrapf(err, "clean up table %T", t.table)
+			}
+		}
+
+		_, err = (&users{DB: tx}).deleteByID(tx, orgID, true)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// Only remove files after the transaction is committed to not leave the
+	// organization half-deleted.
+	for _, p := range repoPaths {
+		RemoveAllWithNotice("Delete repository files", p)
+	}
+	_ = os.RemoveAll(repoutil.UserPath(org.Name))
+	_ = os.Remove(userutil.CustomAvatarPath(orgID))
+	return nil
 }
 
 func (db *orgs) CountByUser(ctx context.Context, userID int64) (int64, error) {
@@ -85,6 +875,2272 @@ func (db *orgs) CountByUser(ctx context.Context, userID int64) (int64, error) {
 	return count, db.WithContext(ctx).Model(&OrgUser{}).Where("uid = ?", userID).Count(&count).Error
 }
 
+func (db *orgs) CountOwnedByUser(ctx context.Context, userID int64) (int64, error) {
+	var count int64
+	return count, db.WithContext(ctx).Model(&OrgUser{}).Where("uid = ? AND is_owner = ?", userID, true).Count(&count).Error
+}
+
+type AccessibleRepositoriesByUserOptions struct {
+	// Whether to skip counting the total number of repositories, the returned
+	// count is 0 when set.
+	SkipCount bool
+	// Whether to fetch one extra row to determine if there are more repositories
+	// after the current page, which is much cheaper than counting on large
+	// organizations. It is typically used along with SkipCount, e.g. for infinite
+	// scrolling. When not set, whether there are more repositories is derived from
+	// the total count, and is always false if SkipCount is also set.
+	CheckHasMore bool
+	// The keyword to filter repositories by name case-insensitively.
+	Keyword string
+	// Whether to exclude archived repositories.
+	ExcludeArchived bool
+	// Whether to only include mirror repositories.
+	MirrorsOnly bool
+}
+
+// accessibleRepositoriesByUser returns a query for repositories in the
+// organization that the user has access to, i.e. public and listed ones or
+// those the user has access to through team memberships.
+func (*orgs) accessibleRepositoriesByUser(tx *gorm.DB, orgID, userID int64, opts AccessibleRepositoriesByUserOptions) *gorm.DB {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT * FROM repository
+		WHERE
+			owner_id = @orgID
+		AND deleted_unix = 0
+		AND (
+				(is_private = FALSE AND is_unlisted = FALSE)
+			OR  id IN (
+					SELECT repo_id FROM team_repo
+					WHERE team_id IN (
+						SELECT team_id FROM team_user
+						WHERE org_id = @orgID AND uid = @userID
+					)
+				)
+		)
+		[AND lower_name LIKE @keyword ESCAPE '!']
+		[AND is_archived = FALSE]
+		[AND is_mirror = TRUE]
+	*/
+	tx = tx.Model(&Repository{}).
+		Where("owner_id = ? AND deleted_unix = 0", orgID).
+		Where("(is_private = ? AND is_unlisted = ?) OR id IN (?)",
+			false, false,
+			tx.Model(&TeamRepo{}).
+				Select("repo_id").
+				Where("team_id IN (?)",
+					tx.Model(&TeamUser{}).Select("team_id").Where("org_id = ? AND uid = ?", orgID, userID),
+				),
+		)
+	return filterOrgRepos(tx, opts.Keyword, !opts.ExcludeArchived, opts.MirrorsOnly)
+}
+
+// filterOrgRepos applies filters that are shared by queries for repositories
+// in an organization.
+func filterOrgRepos(tx *gorm.DB, keyword string, includeArchived, mirrorsOnly bool) *gorm.DB {
+	if keyword != "" {
+		tx = tx.Where("lower_name LIKE ? ESCAPE '!'", "%"+dbutil.EscapeLike(strings.ToLower(keyword))+"%")
+	}
+	if !includeArchived {
+		tx = tx.Where("is_archived = ?", false)
+	}
+	if mirrorsOnly {
+		tx = tx.Where("is_mirror = ?", true)
+	}
+	return tx
+}
+
+func (db *orgs) AccessibleRepositoriesByUser(ctx context.Context, orgID, userID int64, page, pageSize int, opts AccessibleRepositoriesByUserOptions) ([]*Repository, int64, bool, error) {
+	conn := db.reader().WithContext(ctx)
+
+	var count int64
+	if !opts.SkipCount {
+		err := db.accessibleRepositoriesByUser(conn, orgID, userID, opts).Count(&count).Error
+		if err != nil {
+			return nil, 0, false, errors.Wrap(err, "count")
+		}
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+	limit := pageSize
+	if opts.CheckHasMore {
+		limit++
+	}
+	var repos []*Repository
+	err := db.accessibleRepositoriesByUser(conn, orgID, userID, opts).
+		Order("updated_unix DESC").
+		Limit(limit).Offset((page - 1) * pageSize).
+		Find(&repos).
+		Error
+	if err != nil {
+		return nil, 0, false, errors.Wrap(err, "list")
+	}
+
+	var hasMore bool
+	if opts.CheckHasMore {
+		hasMore = len(repos) > pageSize
+		if hasMore {
+			repos = repos[:pageSize]
+		}
+	} else if !opts.SkipCount {
+		hasMore = int64(page*pageSize) < count
+	}
+	return repos, count, hasMore, nil
+}
+
+func (db *orgs) AccessibleRepositoryIDsByUser(ctx context.Context, orgID, userID int64) ([]int64, error) {
+	var repoIDs []int64
+	return repoIDs, db.accessibleRepositoriesByUser(
+		db.WithContext(ctx),
+		orgID,
+		userID,
+		AccessibleRepositoriesByUserOptions{},
+	).
+		Order("id ASC").
+		Pluck("id", &repoIDs).
+		Error
+}
+
+func (db *orgs) CountAccessibleRepositoriesByUser(ctx context.Context, orgID, userID int64) (int64, error) {
+	var count int64
+	return count, db.accessibleRepositoriesByUser(
+		db.reader().WithContext(ctx),
+		orgID,
+		userID,
+		AccessibleRepositoriesByUserOptions{},
+	).
+		Count(&count).
+		Error
+}
+
+type ListAllReposOptions struct {
+	// The keyword to filter repositories by name case-insensitively.
+	Keyword string
+	// Whether to include archived repositories.
+	IncludeArchived bool
+	// Whether to only include mirror repositories.
+	MirrorsOnly bool
+}
+
+func (db *orgs) ListAllRepos(ctx context.Context, orgID int64, page, pageSize int, opts ListAllReposOptions) ([]*Repository, int64, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT * FROM repository
+		WHERE
+			owner_id = @orgID
+		AND deleted_unix = 0
+		[AND lower_name LIKE @keyword ESCAPE '!']
+		[AND is_archived = FALSE]
+		[AND is_mirror = TRUE]
+		ORDER BY updated_unix DESC
+		LIMIT @limit OFFSET @offset
+	*/
+	conn := db.reader().WithContext(ctx)
+	query := func() *gorm.DB {
+		return filterOrgRepos(
+			conn.Model(&Repository{}).Where("owner_id = ? AND deleted_unix = 0", orgID),
+			opts.Keyword,
+			opts.IncludeArchived,
+			opts.MirrorsOnly,
+		)
+	}
+
+	var count int64
+	err := query().Count(&count).Error
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "count")
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+	var repos []*Repository
+	err = query().
+		Order("updated_unix DESC").
+		Limit(pageSize).Offset((page - 1) * pageSize).
+		Find(&repos).
+		Error
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "list")
+	}
+	return repos, count, nil
+}
+
+func (db *orgs) AddMember(ctx context.Context, orgID, userID int64) error {
+	return db.AddMembers(ctx, orgID, []int64{userID})
+}
+
+// recountMembers recounts the number of members of the given organization.
+func (*orgs) recountMembers(tx *gorm.DB, orgID int64) error {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		UPDATE "user"
+		SET num_members = (
+			SELECT COUNT(*) FROM org_user WHERE org_id = @orgID
+		)
+		WHERE id = @orgID
+	*/
+	err := tx.Model(&User{}).
+		Where("id = ?", orgID).
+		Update(
+			"num_members",
+			tx.Model(&OrgUser{}).Select("COUNT(*)").Where("org_id = ?", orgID),
+		).
+		Error
+	if err != nil {
+		return errors.Wrap(err, `update "user.num_members"`)
+	}
+	return nil
+}
+
+func (db *orgs) AddMembers(ctx context.Context, orgID int64, userIDs []int64) error {
+	seen := make(map[int64]struct{}, len(userIDs))
+	orgUsers := make([]*OrgUser, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if _, ok := seen[userID]; ok {
+			continue
+		}
+		seen[userID] = struct{}{}
+
+		orgUsers = append(orgUsers, &OrgUser{
+			Uid:   userID,
+			OrgID: orgID,
+		})
+	}
+	if len(orgUsers) == 0 {
+		return nil
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existingIDs []int64
+		err := tx.Model(&OrgUser{}).
+			Where("org_id = ? AND uid IN (?)", orgID, userIDs).
+			Pluck("uid", &existingIDs).
+			Error
+		if err != nil {
+			return errors.Wrap(err, "list existing members")
+		}
+		existing := make(map[int64]bool, len(existingIDs))
+		for _, id := range existingIDs {
+			existing[id] = true
+		}
+
+		/*
+			Equivalent SQL for PostgreSQL:
+
+			INSERT INTO org_user (uid, org_id)
+			VALUES (@userID1, @orgID), (@userID2, @orgID), ...
+			ON CONFLICT DO NOTHING
+		*/
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&orgUsers)
+		if result.Error != nil {
+			return errors.Wrap(result.Error, "insert")
+		} else if result.RowsAffected <= 0 {
+			return nil // All of them are already members
+		}
+
+		for _, orgUser := range orgUsers {
+			if existing[orgUser.Uid] {
+				continue
+			}
+			err = createAuditLog(ctx, tx, &AuditLog{OrgID: orgID, Action: AuditActionAddMember, TargetUserID: orgUser.Uid})
+			if err != nil {
+				return err
+			}
+		}
+
+		err = db.recountMembers(tx, orgID)
+		if err != nil {
+			return err
+		}
+
+		org := new(User)
+		err = tx.Select("id", "default_repo_permission").Where("id = ?", orgID).First(org).Error
+		if err != nil {
+			return errors.Wrap(err, "get organization")
+		} else if org.DefaultRepoPermission <= AccessModeNone {
+			return nil
+		}
+		return db.recalculatePublicRepoAccesses(tx, orgID)
+	})
+}
+
+func (db *orgs) AddMemberToTeam(ctx context.Context, orgID, userID, teamID int64) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		team := new(Team)
+		err := tx.Select("id", "org_id").Where("id = ?", teamID).First(team).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrTeamNotExist{args: errutil.Args{"teamID": teamID}}
+			}
+			return errors.Wrap(err, "get team")
+		} else if team.OrgID != orgID {
+			return ErrTeamOrgMismatch{args: errutil.Args{"orgID": orgID, "teamID": teamID}}
+		}
+
+		err = (&orgs{DB: tx}).AddMembers(ctx, orgID, []int64{userID})
+		if err != nil {
+			return errors.Wrap(err, "add organization member")
+		}
+
+		err = NewTeamsStore(tx).AddTeamMember(ctx, teamID, userID)
+		if err != nil {
+			return errors.Wrap(err, "add team member")
+		}
+		return nil
+	})
+}
+
+// getOwnersTeam returns the Owners team of the given organization.
+func (*orgs) getOwnersTeam(tx *gorm.DB, orgID int64) (*Team, error) {
+	team := new(Team)
+	err := tx.Where("org_id = ? AND lower_name = ?", orgID, strings.ToLower(OWNER_TEAM)).First(team).Error
+	if err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+// ensureOwnersTeam returns the Owners team of the given organization, and
+// creates the team when missing.
+func (db *orgs) ensureOwnersTeam(tx *gorm.DB, orgID int64) (*Team, error) {
+	team, err := db.getOwnersTeam(tx, orgID)
+	if err == nil {
+		return team, nil
+	} else if err != gorm.ErrRecordNotFound {
+		return nil, errors.Wrap(err, "get owners team")
+	}
+
+	var ownerIDs []int64
+	err = tx.Model(&OrgUser{}).Where("org_id = ? AND is_owner = ?", orgID, true).Order("uid").Pluck("uid", &ownerIDs).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "list owners")
+	}
+
+	var repoIDs []int64
+	err = tx.Model(&Repository{}).Where("owner_id = ? AND deleted_unix = 0", orgID).Order("id").Pluck("id", &repoIDs).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "list repositories")
+	}
+
+	team = &Team{
+		OrgID:      orgID,
+		LowerName:  strings.ToLower(OWNER_TEAM),
+		Name:       OWNER_TEAM,
+		Authorize:  AccessModeOwner,
+		NumRepos:   len(repoIDs),
+		NumMembers: len(ownerIDs),
+	}
+	err = tx.Create(team).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "create owners team")
+	}
+
+	if len(ownerIDs) > 0 {
+		teamUsers := make([]*TeamUser, 0, len(ownerIDs))
+		for _, ownerID := range ownerIDs {
+			teamUsers = append(teamUsers, &TeamUser{OrgID: orgID, TeamID: team.ID, UID: ownerID})
+		}
+		err = tx.Create(&teamUsers).Error
+		if err != nil {
+			return nil, errors.Wrap(err, "create owners team memberships")
+		}
+
+		err = tx.Model(&OrgUser{}).
+			Where("org_id = ? AND uid IN (?)", orgID, ownerIDs).
+			Update("num_teams", gorm.Expr("num_teams + 1")).
+			Error
+		if err != nil {
+			return nil, errors.Wrap(err, `update "org_user.num_teams"`)
+		}
+	}
+
+	if len(repoIDs) > 0 {
+		teamRepos := make([]*TeamRepo, 0, len(repoIDs))
+		for _, repoID := range repoIDs {
+			teamRepos = append(teamRepos, &TeamRepo{OrgID: orgID, TeamID: team.ID, RepoID: repoID})
+		}
+		err = tx.Create(&teamRepos).Error
+		if err != nil {
+			return nil, errors.Wrap(err, "create owners team repositories")
+		}
+	}
+
+	err = tx.Model(&User{}).Where("id = ?", orgID).Update("num_teams", gorm.Expr("num_teams + 1")).Error
+	if err != nil {
+		return nil, errors.Wrap(err, `update "user.num_teams"`)
+	}
+
+	err = (&teams{DB: tx}).recalculateTeamAccesses(tx, team.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "recalculate accesses")
+	}
+	return team, nil
+}
+
+func (db *orgs) EnsureOwnersTeam(ctx context.Context, orgID int64) (*Team, error) {
+	var team *Team
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(&User{}).Where("id = ? AND type = ?", orgID, UserTypeOrganization).Count(&count).Error
+		if err != nil {
+			return errors.Wrap(err, "count organization")
+		} else if count == 0 {
+			return ErrOrgNotExist{args: errutil.Args{"orgID": orgID}}
+		}
+
+		team, err = db.ensureOwnersTeam(tx, orgID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+func (db *orgs) RemoveMember(ctx context.Context, orgID, userID int64) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		orgUser := new(OrgUser)
+		err := tx.Where("org_id = ? AND uid = ?", orgID, userID).First(orgUser).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil
+			}
+			return errors.Wrap(err, "get organization member")
+		}
+
+		if orgUser.IsOwner {
+			ownersTeam, err := db.ensureOwnersTeam(tx, orgID)
+			if err != nil {
+				return errors.Wrap(err, "ensure owners team")
+			} else if ownersTeam.NumMembers <= 1 {
+				return ErrLastOrgOwner{UID: userID}
+			}
+		}
+
+		var teamIDs []int64
+		err = tx.Model(&TeamUser{}).Where("org_id = ? AND uid = ?", orgID, userID).Pluck("team_id", &teamIDs).Error
+		if err != nil {
+			return errors.Wrap(err, "list teams")
+		}
+
+		if len(teamIDs) > 0 {
+			err = tx.Where("org_id = ? AND uid = ?", orgID, userID).Delete(&TeamUser{}).Error
+			if err != nil {
+				return errors.Wrap(err, "delete team memberships")
+			}
+
+			/*
+				Equivalent SQL for PostgreSQL:
+
+				UPDATE team
+				SET num_members = num_members - 1
+				WHERE id IN (@teamIDs)
+			*/
+			err = tx.Model(&Team{}).
+				Where("id IN (?)", teamIDs).
+				Update("num_members", gorm.Expr("num_members - 1")).
+				Error
+			if err != nil {
+				return errors.Wrap(err, `update "team.num_members"`)
+			}
+
+			err = tx.Model(&OrgUser{}).
+				Where("id = ?", orgUser.ID).
+				Update("num_teams", gorm.Expr("num_teams - ?", len(teamIDs))).
+				Error
+			if err != nil {
+				return errors.Wrap(err, `update "org_user.num_teams"`)
+			}
+		}
+
+		err = tx.Delete(&OrgUser{}, orgUser.ID).Error
+		if err != nil {
+			return errors.Wrap(err, "delete organization member")
+		}
+
+		err = createAuditLog(ctx, tx, &AuditLog{OrgID: orgID, Action: AuditActionRemoveMember, TargetUserID: userID})
+		if err != nil {
+			return err
+		}
+
+		err = db.recountMembers(tx, orgID)
+		if err != nil {
+			return errors.Wrap(err, "recount members")
+		}
+
+		var repoIDs []int64
+		err = tx.Model(&Repository{}).Where("owner_id = ?", orgID).Pluck("id", &repoIDs).Error
+		if err != nil {
+			return errors.Wrap(err, "list repositories")
+		}
+		for _, repoID := range repoIDs {
+			err = recalculateAccesses(tx, repoID)
+			if err != nil {
+				return errors.Wrapf(err, "recalculate accesses for repository %d", repoID)
+			}
+		}
+
+		// Unwatch private repositories the user no longer has access to.
+		/*
+			Equivalent SQL for PostgreSQL:
+
+			SELECT repo_id FROM watch
+			WHERE
+				user_id = @userID
+			AND repo_id IN (SELECT id FROM repository WHERE owner_id = @orgID AND is_private = TRUE)
+			AND repo_id NOT IN (SELECT repo_id FROM access WHERE user_id = @userID)
+		*/
+		var unwatchRepoIDs []int64
+		err = tx.Model(&Watch{}).
+			Where("user_id = ? AND repo_id IN (?) AND repo_id NOT IN (?)",
+				userID,
+				tx.Model(&Repository{}).Select("id").Where("owner_id = ? AND is_private = ?", orgID, true),
+				tx.Model(&Access{}).Select("repo_id").Where("user_id = ?", userID),
+			).
+			Pluck("repo_id", &unwatchRepoIDs).
+			Error
+		if err != nil {
+			return errors.Wrap(err, "list watches")
+		} else if len(unwatchRepoIDs) == 0 {
+			return nil
+		}
+
+		err = tx.Where("user_id = ? AND repo_id IN (?)", userID, unwatchRepoIDs).Delete(&Watch{}).Error
+		if err != nil {
+			return errors.Wrap(err, "delete watches")
+		}
+
+		reposStore := &repos{DB: tx}
+		for _, repoID := range unwatchRepoIDs {
+			err = reposStore.recountWatches(tx, repoID)
+			if err != nil {
+				return errors.Wrapf(err, "recount watches for repository %d", repoID)
+			}
+		}
+		return nil
+	})
+}
+
+var _ errutil.NotFound = (*ErrNotOrgMember)(nil)
+
+type ErrNotOrgMember struct {
+	args errutil.Args
+}
+
+// IsErrNotOrgMember returns true if the underlying error has the type
+// ErrNotOrgMember.
+func IsErrNotOrgMember(err error) bool {
+	_, ok := errors.Cause(err).(ErrNotOrgMember)
+	return ok
+}
+
+func (err ErrNotOrgMember) Error() string {
+	return fmt.Sprintf("user is not a member of the organization: %v", err.args)
+}
+
+func (ErrNotOrgMember) NotFound() bool {
+	return true
+}
+
+func (db *orgs) GetMembership(ctx context.Context, orgID, userID int64) (*OrgUser, error) {
+	orgUser := new(OrgUser)
+	err := db.WithContext(ctx).Where("org_id = ? AND uid = ?", orgID, userID).First(orgUser).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrNotOrgMember{args: errutil.Args{"orgID": orgID, "userID": userID}}
+		}
+		return nil, err
+	}
+	return orgUser, nil
+}
+
+func (db *orgs) HasMember(ctx context.Context, orgID, userID int64) bool {
+	_, err := db.GetMembership(ctx, orgID, userID)
+	return err == nil
+}
+
+func (db *orgs) FilterMembers(ctx context.Context, orgID int64, userIDs []int64) (map[int64]bool, error) {
+	members := make(map[int64]bool, len(userIDs))
+	if len(userIDs) == 0 {
+		return members, nil
+	}
+	for _, userID := range userIDs {
+		members[userID] = false
+	}
+
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT uid FROM org_user
+		WHERE org_id = @orgID AND uid IN (@userIDs)
+	*/
+	var memberIDs []int64
+	err := db.WithContext(ctx).
+		Model(&OrgUser{}).
+		Where("org_id = ? AND uid IN (?)", orgID, userIDs).
+		Pluck("uid", &memberIDs).
+		Error
+	if err != nil {
+		return nil, errors.Wrap(err, "list members")
+	}
+
+	for _, memberID := range memberIDs {
+		members[memberID] = true
+	}
+	return members, nil
+}
+
+func (db *orgs) IsOwnedBy(ctx context.Context, orgID, userID int64) bool {
+	orgUser, err := db.GetMembership(ctx, orgID, userID)
+	return err == nil && orgUser.IsOwner
+}
+
+func (db *orgs) SetOwner(ctx context.Context, orgID, userID int64, isOwner bool) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		orgUser, err := (&orgs{DB: tx}).GetMembership(ctx, orgID, userID)
+		if err != nil {
+			return err
+		}
+
+		ownersTeam, err := db.ensureOwnersTeam(tx, orgID)
+		if err != nil {
+			return errors.Wrap(err, "ensure owners team")
+		}
+
+		teamsStore := &teams{DB: tx}
+		if isOwner {
+			err = teamsStore.AddTeamMember(ctx, ownersTeam.ID, userID)
+		} else {
+			err = teamsStore.RemoveTeamMember(ctx, ownersTeam.ID, userID)
+		}
+		if err != nil {
+			return err
+		}
+
+		// NOTE: Team membership changes are no-ops when the membership is already
+		// in the desired state, recount unconditionally to correct a stale
+		// "org_user.is_owner" nevertheless.
+		err = teamsStore.recountOrgUserTeams(tx, orgID, userID)
+		if err != nil {
+			return err
+		}
+
+		if orgUser.IsOwner == isOwner {
+			return nil
+		}
+		action := AuditActionRevokeOwner
+		if isOwner {
+			action = AuditActionGrantOwner
+		}
+		return createAuditLog(ctx, tx, &AuditLog{OrgID: orgID, Action: action, TargetUserID: userID})
+	})
+}
+
+func (db *orgs) LeaveOrg(ctx context.Context, orgID, userID int64) error {
+	_, err := db.GetMembership(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+
+	err = db.RemoveMember(ctx, orgID, userID)
+	if IsErrLastOrgOwner(err) {
+		return errors.Wrap(err, "the last owner cannot leave the organization, transfer the ownership first")
+	}
+	return err
+}
+
+func (db *orgs) CountMembers(ctx context.Context, orgID int64) (int64, error) {
+	var count int64
+	return count, db.WithContext(ctx).Model(&OrgUser{}).Where("org_id = ?", orgID).Count(&count).Error
+}
+
+func (db *orgs) DeduplicateMembers(ctx context.Context, orgID int64) (int64, error) {
+	var removed int64
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		/*
+			Equivalent SQL for PostgreSQL:
+
+			SELECT uid, MIN(id) AS keep_id FROM org_user
+			WHERE org_id = @orgID
+			GROUP BY uid
+			HAVING COUNT(*) > 1
+		*/
+		var duplicates []struct {
+			UID    int64 `gorm:"column:uid"`
+			KeepID int64
+		}
+		err := tx.Model(&OrgUser{}).
+			Select("uid, MIN(id) AS keep_id").
+			Where("org_id = ?", orgID).
+			Group("uid").
+			Having("COUNT(*) > 1").
+			Scan(&duplicates).
+			Error
+		if err != nil {
+			return errors.Wrap(err, "list duplicated members")
+		} else if len(duplicates) == 0 {
+			return nil
+		}
+
+		teamsStore := &teams{DB: tx}
+		for _, d := range duplicates {
+			result := tx.Where("org_id = ? AND uid = ? AND id != ?", orgID, d.UID, d.KeepID).Delete(&OrgUser{})
+			if result.Error != nil {
+				return errors.Wrapf(result.Error, "delete duplicated memberships of user %d", d.UID)
+			}
+			removed += result.RowsAffected
+
+			err = teamsStore.recountOrgUserTeams(tx, orgID, d.UID)
+			if err != nil {
+				return errors.Wrapf(err, "recount teams of user %d", d.UID)
+			}
+		}
+		return db.recountMembers(tx, orgID)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
+
+func (db *orgs) RecountAll(ctx context.Context, orgID int64) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := db.recountMembers(tx, orgID)
+		if err != nil {
+			return errors.Wrap(err, "recount members")
+		}
+
+		/*
+			Equivalent SQL for PostgreSQL:
+
+			UPDATE team
+			SET num_members = (
+				SELECT COUNT(*) FROM team_user WHERE team_user.team_id = team.id
+			)
+			WHERE org_id = @orgID
+		*/
+		err = tx.Model(&Team{}).
+			Where("org_id = ?", orgID).
+			Update(
+				"num_members",
+				tx.Model(&TeamUser{}).Select("COUNT(*)").Where("team_user.team_id = team.id"),
+			).
+			Error
+		if err != nil {
+			return errors.Wrap(err, `update "team.num_members"`)
+		}
+
+		/*
+			Equivalent SQL for PostgreSQL:
+
+			UPDATE org_user
+			SET num_teams = (
+				SELECT COUNT(*) FROM team_user
+				WHERE team_user.org_id = org_user.org_id AND team_user.uid = org_user.uid
+			)
+			WHERE org_id = @orgID
+		*/
+		err = tx.Model(&OrgUser{}).
+			Where("org_id = ?", orgID).
+			Update(
+				"num_teams",
+				tx.Model(&TeamUser{}).Select("COUNT(*)").Where("team_user.org_id = org_user.org_id AND team_user.uid = org_user.uid"),
+			).
+			Error
+		if err != nil {
+			return errors.Wrap(err, `update "org_user.num_teams"`)
+		}
+		return nil
+	})
+}
+
+type ListOrgMembersOptions struct {
+	// The maximum number of members to return, it is ignored when both Page and
+	// PageSize are set.
+	Limit int
+	// The page (starting from 1) and page size of members to return.
+	Page     int
+	PageSize int
+	// Whether to only include members with public membership.
+	PublicOnly bool
+	// The column to sort by with an optional direction (e.g. "org_user.id DESC"),
+	// it must be either "user.id" or "org_user.id", the latter sorts members by
+	// the order they joined the organization. Default is "user.id ASC".
+	OrderBy string
+}
+
+// parseListOrgMembersOrderBy validates the given order and returns the ORDER BY
+// clause for members of an organization.
+func parseListOrgMembersOrderBy(orderBy string) (string, error) {
+	if orderBy == "" {
+		return dbutil.Quote("%s.id ASC", "user"), nil
+	}
+
+	fields := strings.Fields(orderBy)
+	if len(fields) > 2 {
+		return "", errors.Errorf("invalid order %q", orderBy)
+	}
+
+	var column string
+	switch strings.ToLower(fields[0]) {
+	case "user.id":
+		column = dbutil.Quote("%s.id", "user")
+	case "org_user.id":
+		column = "org_user.id"
+	default:
+		return "", errors.Errorf("invalid order column %q", fields[0])
+	}
+
+	direction := "ASC"
+	if len(fields) == 2 {
+		direction = strings.ToUpper(fields[1])
+		if direction != "ASC" && direction != "DESC" {
+			return "", errors.Errorf("invalid order direction %q", fields[1])
+		}
+	}
+	return column + " " + direction, nil
+}
+
+func (db *orgs) ListMembers(ctx context.Context, orgID int64, opts ListOrgMembersOptions) ([]*User, int64, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT * FROM "user"
+		JOIN org_user ON org_user.uid = "user".id
+		WHERE
+			org_user.org_id = @orgID
+		[AND org_user.is_public = TRUE]
+		ORDER BY "user".id ASC | org_user.id ASC
+		[LIMIT @limit OFFSET @offset]
+	*/
+	orderBy, err := parseListOrgMembersOrderBy(opts.OrderBy)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tx := db.reader().WithContext(ctx).
+		Joins(dbutil.Quote("JOIN org_user ON org_user.uid = %s.id", "user")).
+		Where("org_user.org_id = ?", orgID)
+	if opts.PublicOnly {
+		tx = tx.Where("org_user.is_public = ?", true)
+	}
+
+	var count int64
+	err = tx.Model(&User{}).Count(&count).Error
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "count")
+	}
+
+	// NOTE: Columns of "org_user" must not be selected to not override those of
+	// "user" with the same names, e.g. "id".
+	tx = tx.Select(dbutil.Quote("%s.*", "user")).Order(orderBy)
+	if opts.Page > 0 && opts.PageSize > 0 {
+		tx = tx.Limit(opts.PageSize).Offset((opts.Page - 1) * opts.PageSize)
+	} else if opts.Limit > 0 {
+		tx = tx.Limit(opts.Limit)
+	}
+
+	var members []*User
+	return members, count, tx.Find(&members).Error
+}
+
+func (db *orgs) ListActiveMembers(ctx context.Context, orgID int64, sinceUnix int64) ([]*User, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT "user".* FROM "user"
+		JOIN org_user ON org_user.uid = "user".id
+		WHERE
+			org_user.org_id = @orgID
+		AND "user".last_login_unix >= @sinceUnix
+		ORDER BY "user".id ASC
+	*/
+	var members []*User
+	return members, db.reader().WithContext(ctx).
+		Select(dbutil.Quote("%s.*", "user")).
+		Joins(dbutil.Quote("JOIN org_user ON org_user.uid = %s.id", "user")).
+		Where("org_user.org_id = ?", orgID).
+		Where(dbutil.Quote("%s.last_login_unix >= ?", "user"), sinceUnix).
+		Order(dbutil.Quote("%s.id ASC", "user")).
+		Find(&members).
+		Error
+}
+
+type SearchOrgMembersOptions struct {
+	// Whether to only include members with public membership, e.g. when the viewer
+	// is not a member of the organization.
+	PublicOnly bool
+}
+
+func (db *orgs) SearchMembers(ctx context.Context, orgID int64, keyword string, page, pageSize int, opts SearchOrgMembersOptions) ([]*User, int64, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT "user".* FROM "user"
+		JOIN org_user ON org_user.uid = "user".id
+		WHERE
+			org_user.org_id = @orgID
+		[AND org_user.is_public = TRUE]
+		[AND ("user".lower_name LIKE @keyword ESCAPE '!' OR LOWER("user".full_name) LIKE @keyword ESCAPE '!')]
+		ORDER BY "user".lower_name ASC
+		LIMIT @limit OFFSET @offset
+	*/
+	query := func() *gorm.DB {
+		tx := db.reader().WithContext(ctx).
+			Model(&User{}).
+			Joins(dbutil.Quote("JOIN org_user ON org_user.uid = %s.id", "user")).
+			Where("org_user.org_id = ?", orgID)
+		if opts.PublicOnly {
+			tx = tx.Where("org_user.is_public = ?", true)
+		}
+		if keyword != "" {
+			pattern := "%" + dbutil.EscapeLike(strings.ToLower(keyword)) + "%"
+			tx = tx.Where(
+				dbutil.Quote("%s.lower_name LIKE ? ESCAPE '!' OR LOWER(%[1]s.full_name) LIKE ? ESCAPE '!'", "user"),
+				pattern, pattern,
+			)
+		}
+		return tx
+	}
+
+	var count int64
+	err := query().Count(&count).Error
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "count")
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+	members := make([]*User, 0, pageSize)
+	err = query().
+		Select(dbutil.Quote("%s.*", "user")).
+		Order(dbutil.Quote("%s.lower_name ASC", "user")).
+		Limit(pageSize).Offset((page - 1) * pageSize).
+		Find(&members).
+		Error
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "list")
+	}
+	return members, count, nil
+}
+
+// OrgMemberWithRole is a member of an organization along with their membership
+// flags.
+type OrgMemberWithRole struct {
+	User *User
+	// Whether the member is in the Owners team of the organization.
+	IsOwner bool
+	// Whether the membership is publicly visible.
+	IsPublic bool
+	// The number of teams in the organization the member belongs to.
+	NumTeams int
+}
+
+func (db *orgs) ListOrgMembersWithRole(ctx context.Context, orgID int64, opts ListOrgMembersOptions) ([]*OrgMemberWithRole, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT
+			"user".*,
+			org_user.is_owner AS org_user_is_owner,
+			org_user.is_public AS org_user_is_public,
+			org_user.num_teams AS org_user_num_teams
+		FROM "user"
+		JOIN org_user ON org_user.uid = "user".id
+		WHERE
+			org_user.org_id = @orgID
+		[AND org_user.is_public = TRUE]
+		ORDER BY "user".id ASC | org_user.id ASC
+		[LIMIT @limit OFFSET @offset]
+	*/
+	orderBy, err := parseListOrgMembersOrderBy(opts.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := db.WithContext(ctx).
+		Table("user").
+		Select(dbutil.Quote("%s.*, org_user.is_owner AS org_user_is_owner, org_user.is_public AS org_user_is_public, org_user.num_teams AS org_user_num_teams", "user")).
+		Joins(dbutil.Quote("JOIN org_user ON org_user.uid = %s.id", "user")).
+		Where("org_user.org_id = ?", orgID)
+	if opts.PublicOnly {
+		tx = tx.Where("org_user.is_public = ?", true)
+	}
+
+	tx = tx.Order(orderBy)
+	if opts.Page > 0 && opts.PageSize > 0 {
+		tx = tx.Limit(opts.PageSize).Offset((opts.Page - 1) * opts.PageSize)
+	} else if opts.Limit > 0 {
+		tx = tx.Limit(opts.Limit)
+	}
+
+	var rows []*struct {
+		User            `gorm:"embedded"`
+		OrgUserIsOwner  bool
+		OrgUserIsPublic bool
+		OrgUserNumTeams int
+	}
+	err = tx.Find(&rows).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "list members")
+	}
+
+	members := make([]*OrgMemberWithRole, 0, len(rows))
+	for _, row := range rows {
+		u := row.User
+		// Hooks are not run for embedded structs.
+		_ = u.AfterFind(tx)
+		members = append(members, &OrgMemberWithRole{
+			User:     &u,
+			IsOwner:  row.OrgUserIsOwner,
+			IsPublic: row.OrgUserIsPublic,
+			NumTeams: row.OrgUserNumTeams,
+		})
+	}
+	return members, nil
+}
+
+func (db *orgs) StreamMembers(ctx context.Context, orgID int64, fn func(*OrgMemberWithRole) error) error {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT
+			"user".*,
+			org_user.is_owner AS org_user_is_owner,
+			org_user.is_public AS org_user_is_public,
+			org_user.num_teams AS org_user_num_teams
+		FROM "user"
+		JOIN org_user ON org_user.uid = "user".id
+		WHERE org_user.org_id = @orgID
+		ORDER BY "user".id ASC
+	*/
+	tx := db.WithContext(ctx)
+	rows, err := tx.
+		Table("user").
+		Select(dbutil.Quote("%s.*, org_user.is_owner AS org_user_is_owner, org_user.is_public AS org_user_is_public, org_user.num_teams AS org_user_num_teams", "user")).
+		Joins(dbutil.Quote("JOIN org_user ON org_user.uid = %s.id", "user")).
+		Where("org_user.org_id = ?", orgID).
+		Order(dbutil.Quote("%s.id ASC", "user")).
+		Rows()
+	if err != nil {
+		return errors.Wrap(err, "iterate members")
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var row struct {
+			User            `gorm:"embedded"`
+			OrgUserIsOwner  bool
+			OrgUserIsPublic bool
+			OrgUserNumTeams int
+		}
+		err = tx.ScanRows(rows, &row)
+		if err != nil {
+			return errors.Wrap(err, "scan rows")
+		}
+
+		u := row.User
+		// Hooks are not run for embedded structs.
+		_ = u.AfterFind(tx)
+		err = fn(
+			&OrgMemberWithRole{
+				User:     &u,
+				IsOwner:  row.OrgUserIsOwner,
+				IsPublic: row.OrgUserIsPublic,
+				NumTeams: row.OrgUserNumTeams,
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return errors.Wrap(err, "check rows.Err")
+	}
+	return nil
+}
+
+func (db *orgs) GetTeamsByUser(ctx context.Context, orgID, userID int64) ([]*Team, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT team.* FROM team
+		JOIN team_user ON team_user.team_id = team.id
+		WHERE
+			team.org_id = @orgID
+		AND team_user.uid = @userID
+		ORDER BY team.id ASC
+	*/
+	teams := make([]*Team, 0)
+	return teams, db.WithContext(ctx).
+		Joins("JOIN team_user ON team_user.team_id = team.id").
+		Where("team.org_id = ? AND team_user.uid = ?", orgID, userID).
+		Order("team.id ASC").
+		Find(&teams).
+		Error
+}
+
+// recalculatePublicRepoAccesses recalculates accesses of all public
+// repositories of the given organization.
+func (*orgs) recalculatePublicRepoAccesses(tx *gorm.DB, orgID int64) error {
+	var repoIDs []int64
+	err := tx.Model(&Repository{}).Where("owner_id = ? AND is_private = ?", orgID, false).Pluck("id", &repoIDs).Error
+	if err != nil {
+		return errors.Wrap(err, "list public repositories")
+	}
+
+	for _, repoID := range repoIDs {
+		err = recalculateAccesses(tx, repoID)
+		if err != nil {
+			return errors.Wrapf(err, "recalculate accesses for repository %d", repoID)
+		}
+	}
+	return nil
+}
+
+func (db *orgs) SetDefaultRepoPermission(ctx context.Context, orgID int64, perm AccessMode) error {
+	switch perm {
+	case AccessModeNone, AccessModeRead, AccessModeWrite:
+	default:
+		return errors.Errorf("invalid default repository permission %q", perm)
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&User{}).
+			Where("id = ? AND type = ?", orgID, UserTypeOrganization).
+			Update("default_repo_permission", perm).
+			Error
+		if err != nil {
+			return errors.Wrap(err, "update")
+		}
+		return db.recalculatePublicRepoAccesses(tx, orgID)
+	})
+}
+
+type ErrOrgOwnersWithoutTwoFactor struct {
+	args errutil.Args
+}
+
+// IsErrOrgOwnersWithoutTwoFactor returns true if the underlying error has the
+// type ErrOrgOwnersWithoutTwoFactor.
+func IsErrOrgOwnersWithoutTwoFactor(err error) bool {
+	_, ok := errors.Cause(err).(ErrOrgOwnersWithoutTwoFactor)
+	return ok
+}
+
+func (err ErrOrgOwnersWithoutTwoFactor) Error() string {
+	return fmt.Sprintf("organization owners have not enabled two-factor authentication: %v", err.args)
+}
+
+func (db *orgs) SetRequireTwoFactor(ctx context.Context, orgID int64, required bool) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if required {
+			/*
+				Equivalent SQL for PostgreSQL:
+
+				SELECT uid FROM org_user
+				WHERE
+					org_id = @orgID
+				AND is_owner = TRUE
+				AND uid NOT IN (SELECT user_id FROM two_factor)
+				ORDER BY uid
+			*/
+			var userIDs []int64
+			err := tx.Model(&OrgUser{}).
+				Where("org_id = ? AND is_owner = ? AND uid NOT IN (?)", orgID, true, tx.Model(&TwoFactor{}).Select("user_id")).
+				Order("uid").
+				Pluck("uid", &userIDs).
+				Error
+			if err != nil {
+				return errors.Wrap(err, "list owners without two-factor authentication")
+			} else if len(userIDs) > 0 {
+				return ErrOrgOwnersWithoutTwoFactor{args: errutil.Args{"orgID": orgID, "userIDs": userIDs}}
+			}
+		}
+
+		err := tx.Model(&User{}).
+			Where("id = ? AND type = ?", orgID, UserTypeOrganization).
+			Update("require_two_factor", required).
+			Error
+		if err != nil {
+			return errors.Wrap(err, "update")
+		}
+		return nil
+	})
+}
+
+func (db *orgs) SetVerified(ctx context.Context, orgID int64, verified bool) error {
+	return db.WithContext(ctx).
+		Model(&User{}).
+		Where("id = ? AND type = ?", orgID, UserTypeOrganization).
+		Update("is_verified", verified).
+		Error
+}
+
+func (db *orgs) SetArchived(ctx context.Context, orgID int64, archived bool) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(&User{}).Where("id = ? AND type = ?", orgID, UserTypeOrganization).Count(&count).Error
+		if err != nil {
+			return errors.Wrap(err, "count organization")
+		} else if count == 0 {
+			return ErrOrgNotExist{args: errutil.Args{"orgID": orgID}}
+		}
+
+		err = tx.Model(&User{}).
+			Where("id = ?", orgID).
+			Update("is_archived", archived).
+			Error
+		if err != nil {
+			return errors.Wrap(err, "update")
+		}
+		return nil
+	})
+}
+
+func (db *orgs) SetRepoCreatePermission(ctx context.Context, orgID int64, perm RepoCreatePermission) error {
+	switch perm {
+	case RepoCreatePermissionMembers, RepoCreatePermissionOwners:
+	default:
+		return errors.Errorf("invalid repository creation permission %d", perm)
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(&User{}).Where("id = ? AND type = ?", orgID, UserTypeOrganization).Count(&count).Error
+		if err != nil {
+			return errors.Wrap(err, "count organization")
+		} else if count == 0 {
+			return ErrOrgNotExist{args: errutil.Args{"orgID": orgID}}
+		}
+
+		err = tx.Model(&User{}).
+			Where("id = ?", orgID).
+			Update("repo_create_permission", perm).
+			Error
+		if err != nil {
+			return errors.Wrap(err, "update")
+		}
+		return nil
+	})
+}
+
+// OrgProtectBranch is a branch protection template of an organization, which
+// is copied to repositories created under the organization.
+type OrgProtectBranch struct {
+	ID                 int64  `gorm:"primaryKey"`
+	OrgID              int64  `gorm:"uniqueIndex:org_protect_branch_org_name_unique;not null"`
+	Name               string `gorm:"type:VARCHAR(255);uniqueIndex:org_protect_branch_org_name_unique;not null"`
+	RequirePullRequest bool   `gorm:"not null;default:FALSE"`
+	EnableWhitelist    bool   `gorm:"not null;default:FALSE"`
+	// Comma-separated IDs of users and teams that are allowed to push when the
+	// whitelist is enabled, same as ProtectBranch.
+	WhitelistUserIDs string `gorm:"type:TEXT"`
+	WhitelistTeamIDs string `gorm:"type:TEXT"`
+}
+
+func (db *orgs) GetDefaultProtection(ctx context.Context, orgID int64) ([]*OrgProtectBranch, error) {
+	var protections []*OrgProtectBranch
+	return protections, db.WithContext(ctx).Where("org_id = ?", orgID).Order("name ASC").Find(&protections).Error
+}
+
+func (db *orgs) SetDefaultProtection(ctx context.Context, orgID int64, protections []*OrgProtectBranch) error {
+	seen := make(map[string]bool, len(protections))
+	templates := make([]*OrgProtectBranch, 0, len(protections))
+	for _, p := range protections {
+		name := strings.TrimSpace(p.Name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		templates = append(templates,
+			&OrgProtectBranch{
+				OrgID:              orgID,
+				Name:               name,
+				RequirePullRequest: p.RequirePullRequest,
+				EnableWhitelist:    p.EnableWhitelist,
+				WhitelistUserIDs:   p.WhitelistUserIDs,
+				WhitelistTeamIDs:   p.WhitelistTeamIDs,
+			},
+		)
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("org_id = ?", orgID).Delete(&OrgProtectBranch{}).Error
+		if err != nil {
+			return errors.Wrap(err, "delete existing templates")
+		}
+
+		if len(templates) == 0 {
+			return nil
+		}
+		err = tx.Create(&templates).Error
+		if err != nil {
+			return errors.Wrap(err, "create templates")
+		}
+		return nil
+	})
+}
+
+// applyDefaultProtection copies branch protection templates of the
+// organization to the given repositories, along with whitelists of users that
+// are allowed to push.
+func (*orgs) applyDefaultProtection(tx *gorm.DB, orgID int64, repoIDs []int64) error {
+	if len(repoIDs) == 0 {
+		return nil
+	}
+
+	var templates []*OrgProtectBranch
+	err := tx.Where("org_id = ?", orgID).Order("name ASC").Find(&templates).Error
+	if err != nil {
+		return errors.Wrap(err, "list templates")
+	} else if len(templates) == 0 {
+		return nil
+	}
+
+	for _, t := range templates {
+		var whitelistUserIDs []int64
+		if t.EnableWhitelist {
+			seen := make(map[int64]bool)
+			for _, userID := range tool.StringsToInt64s(strings.Split(t.WhitelistUserIDs, ",")) {
+				if userID > 0 && !seen[userID] {
+					seen[userID] = true
+					whitelistUserIDs = append(whitelistUserIDs, userID)
+				}
+			}
+
+			teamIDs := tool.StringsToInt64s(strings.Split(t.WhitelistTeamIDs, ","))
+			if len(teamIDs) > 0 {
+				var memberIDs []int64
+				err = tx.Model(&TeamUser{}).
+					Where("org_id = ? AND team_id IN (?)", orgID, teamIDs).
+					Order("uid").
+					Pluck("uid", &memberIDs).
+					Error
+				if err != nil {
+					return errors.Wrapf(err, "list members of whitelist teams of %q", t.Name)
+				}
+				for _, userID := range memberIDs {
+					if !seen[userID] {
+						seen[userID] = true
+						whitelistUserIDs = append(whitelistUserIDs, userID)
+					}
+				}
+			}
+		}
+
+		for _, repoID := range repoIDs {
+			protectBranch := &ProtectBranch{
+				RepoID:             repoID,
+				Name:               t.Name,
+				Protected:          true,
+				RequirePullRequest: t.RequirePullRequest,
+				EnableWhitelist:    t.EnableWhitelist,
+				WhitelistUserIDs:   t.WhitelistUserIDs,
+				WhitelistTeamIDs:   t.WhitelistTeamIDs,
+			}
+
+			var existingID int64
+			err = tx.Model(&ProtectBranch{}).
+				Where("repo_id = ? AND name = ?", repoID, t.Name).
+				Limit(1).
+				Pluck("id", &existingID).
+				Error
+			if err != nil {
+				return errors.Wrapf(err, "get protection of %q for repository %d", t.Name, repoID)
+			}
+
+			if existingID > 0 {
+				protectBranch.ID = existingID
+				err = tx.Select("*").Updates(protectBranch).Error
+			} else {
+				err = tx.Create(protectBranch).Error
+			}
+			if err != nil {
+				return errors.Wrapf(err, "save protection of %q for repository %d", t.Name, repoID)
+			}
+
+			err = tx.Where("protect_branch_id = ?", protectBranch.ID).Delete(&ProtectBranchWhitelist{}).Error
+			if err != nil {
+				return errors.Wrapf(err, "delete whitelists of %q for repository %d", t.Name, repoID)
+			}
+
+			if len(whitelistUserIDs) == 0 {
+				continue
+			}
+			whitelists := make([]*ProtectBranchWhitelist, 0, len(whitelistUserIDs))
+			for _, userID := range whitelistUserIDs {
+				whitelists = append(whitelists,
+					&ProtectBranchWhitelist{
+						ProtectBranchID: protectBranch.ID,
+						RepoID:          repoID,
+						Name:            t.Name,
+						UserID:          userID,
+					},
+				)
+			}
+			err = tx.Create(&whitelists).Error
+			if err != nil {
+				return errors.Wrapf(err, "create whitelists of %q for repository %d", t.Name, repoID)
+			}
+		}
+	}
+	return nil
+}
+
+func (db *orgs) ApplyDefaultProtection(ctx context.Context, orgID, repoID int64) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return db.applyDefaultProtection(tx, orgID, []int64{repoID})
+	})
+}
+
+func (db *orgs) ApplyDefaultProtectionToAll(ctx context.Context, orgID int64) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var repoIDs []int64
+		err := tx.Model(&Repository{}).
+			Where("owner_id = ? AND deleted_unix = 0", orgID).
+			Order("id").
+			Pluck("id", &repoIDs).
+			Error
+		if err != nil {
+			return errors.Wrap(err, "list repositories")
+		}
+		return db.applyDefaultProtection(tx, orgID, repoIDs)
+	})
+}
+
+// OrgInvitation is a pending invitation of an email address to join an
+// organization, which is consumed once a user follows the link with its token
+// in the invitation email, or has verified the email address.
+type OrgInvitation struct {
+	ID    int64  `gorm:"primaryKey"`
+	OrgID int64  `gorm:"uniqueIndex:org_invitation_org_email_unique;not null"`
+	Email string `gorm:"type:VARCHAR(254);uniqueIndex:org_invitation_org_email_unique;not null"`
+	// The team to join along with the organization, 0 means none.
+	TeamID      int64  `gorm:"not null;default:0"`
+	Token       string `gorm:"type:VARCHAR(40);unique;not null"`
+	CreatedUnix int64  `gorm:"not null"`
+	ExpiresUnix int64  `gorm:"not null"`
+}
+
+// Expires returns the time when the invitation expires.
+func (i *OrgInvitation) Expires() time.Time {
+	return time.Unix(i.ExpiresUnix, 0)
+}
+
+// BeforeCreate implements the GORM create hook.
+func (i *OrgInvitation) BeforeCreate(tx *gorm.DB) error {
+	if i.CreatedUnix == 0 {
+		i.CreatedUnix = tx.NowFunc().Unix()
+	}
+	return nil
+}
+
+func (db *orgs) InviteByEmail(ctx context.Context, orgID int64, email string, teamID int64) (*OrgInvitation, error) {
+	token, err := strutil.RandomChars(40)
+	if err != nil {
+		return nil, errors.Wrap(err, "generate token")
+	}
+
+	invitation := &OrgInvitation{
+		OrgID:  orgID,
+		Email:  strings.ToLower(strings.TrimSpace(email)),
+		TeamID: teamID,
+		Token:  token,
+	}
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(&User{}).Where("id = ? AND type = ?", orgID, UserTypeOrganization).Count(&count).Error
+		if err != nil {
+			return errors.Wrap(err, "count organization")
+		} else if count == 0 {
+			return ErrOrgNotExist{args: errutil.Args{"orgID": orgID}}
+		}
+
+		if teamID > 0 {
+			team := new(Team)
+			err = tx.Select("id", "org_id").Where("id = ?", teamID).First(team).Error
+			if err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return ErrTeamNotExist{args: errutil.Args{"teamID": teamID}}
+				}
+				return errors.Wrap(err, "get team")
+			} else if team.OrgID != orgID {
+				return ErrTeamOrgMismatch{args: errutil.Args{"orgID": orgID, "teamID": teamID}}
+			}
+		}
+
+		err = tx.Where("org_id = ? AND email = ?", orgID, invitation.Email).Delete(&OrgInvitation{}).Error
+		if err != nil {
+			return errors.Wrap(err, "delete pending invitation")
+		}
+
+		invitation.ExpiresUnix = tx.NowFunc().Add(conf.User.OrgInvitationExpiry).Unix()
+		return tx.Create(invitation).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+func (db *orgs) ListInvitations(ctx context.Context, orgID int64) ([]*OrgInvitation, error) {
+	var invitations []*OrgInvitation
+	return invitations, db.WithContext(ctx).
+		Where("org_id = ? AND expires_unix > ?", orgID, db.NowFunc().Unix()).
+		Order("id").
+		Find(&invitations).
+		Error
+}
+
+var _ errutil.NotFound = (*ErrOrgInvitationNotExist)(nil)
+
+type ErrOrgInvitationNotExist struct {
+	args errutil.Args
+}
+
+// IsErrOrgInvitationNotExist returns true if the underlying error has the type
+// ErrOrgInvitationNotExist.
+func IsErrOrgInvitationNotExist(err error) bool {
+	_, ok := errors.Cause(err).(ErrOrgInvitationNotExist)
+	return ok
+}
+
+func (err ErrOrgInvitationNotExist) Error() string {
+	return fmt.Sprintf("organization invitation does not exist: %v", err.args)
+}
+
+func (ErrOrgInvitationNotExist) NotFound() bool {
+	return true
+}
+
+func (db *orgs) RevokeInvitation(ctx context.Context, orgID, invitationID int64) error {
+	result := db.WithContext(ctx).Where("id = ? AND org_id = ?", invitationID, orgID).Delete(&OrgInvitation{})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return ErrOrgInvitationNotExist{args: errutil.Args{"orgID": orgID, "invitationID": invitationID}}
+	}
+	return nil
+}
+
+func (db *orgs) ConsumeInvitations(ctx context.Context, userID int64) error {
+	user, err := NewUsersStore(db.DB).GetByID(ctx, userID)
+	if err != nil {
+		return errors.Wrap(err, "get user")
+	}
+
+	var emails []string
+	err = db.WithContext(ctx).
+		Model(&EmailAddress{}).
+		Where("uid = ? AND is_activated = ?", userID, true).
+		Pluck("email", &emails).
+		Error
+	if err != nil {
+		return errors.Wrap(err, "list activated email addresses")
+	}
+	// NOTE: Users are activated without confirming their primary emails unless
+	// email confirmation is required.
+	if conf.Auth.RequireEmailConfirmation && user.IsActive {
+		emails = append(emails, strings.ToLower(user.Email))
+	}
+	if len(emails) == 0 {
+		return nil
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var invitations []*OrgInvitation
+		err := tx.Where("email IN (?) AND expires_unix > ?", emails, tx.NowFunc().Unix()).
+			Order("id").
+			Find(&invitations).
+			Error
+		if err != nil {
+			return errors.Wrap(err, "list invitations")
+		}
+
+		orgsStore := &orgs{DB: tx}
+		for _, invitation := range invitations {
+			err = orgsStore.acceptInvitation(ctx, invitation, userID)
+			if err != nil {
+				return errors.Wrapf(err, "accept invitation %d", invitation.ID)
+			}
+		}
+
+		err = tx.Where("email IN (?)", emails).Delete(&OrgInvitation{}).Error
+		if err != nil {
+			return errors.Wrap(err, "delete invitations")
+		}
+		return nil
+	})
+}
+
+func (db *orgs) AcceptInvitation(ctx context.Context, userID int64, token string) (*OrgInvitation, error) {
+	invitation := new(OrgInvitation)
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("token = ? AND expires_unix > ?", token, tx.NowFunc().Unix()).First(invitation).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrOrgInvitationNotExist{args: errutil.Args{"token": token}}
+			}
+			return errors.Wrap(err, "get invitation")
+		}
+
+		err = (&orgs{DB: tx}).acceptInvitation(ctx, invitation, userID)
+		if err != nil {
+			return errors.Wrap(err, "accept invitation")
+		}
+		return tx.Delete(invitation).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// acceptInvitation adds the user to the organization, and the team of the
+// invitation if any.
+func (db *orgs) acceptInvitation(ctx context.Context, invitation *OrgInvitation, userID int64) error {
+	if invitation.TeamID > 0 {
+		err := db.AddMemberToTeam(ctx, invitation.OrgID, userID, invitation.TeamID)
+		if !IsErrTeamNotExist(err) {
+			return err
+		}
+		// The team has been deleted since the invitation was sent, joining the
+		// organization is still expected.
+	}
+	return db.AddMembers(ctx, invitation.OrgID, []int64{userID})
+}
+
+// OrgLabel is an issue label template of an organization, which is copied to
+// repositories created under the organization.
+type OrgLabel struct {
+	ID    int64  `gorm:"primaryKey"`
+	OrgID int64  `gorm:"uniqueIndex:org_label_org_name_unique;not null"`
+	Name  string `gorm:"type:VARCHAR(255);uniqueIndex:org_label_org_name_unique;not null"`
+	Color string `gorm:"type:VARCHAR(7);not null"`
+}
+
+func (db *orgs) GetDefaultLabels(ctx context.Context, orgID int64) ([]*OrgLabel, error) {
+	var labels []*OrgLabel
+	return labels, db.WithContext(ctx).Where("org_id = ?", orgID).Order("name ASC").Find(&labels).Error
+}
+
+var orgLabelColorPattern = lazyregexp.New("^#[a-fA-F0-9]{6}$")
+
+func (db *orgs) SetDefaultLabels(ctx context.Context, orgID int64, labels []*OrgLabel) error {
+	seen := make(map[string]bool, len(labels))
+	templates := make([]*OrgLabel, 0, len(labels))
+	for _, l := range labels {
+		name := strings.TrimSpace(l.Name)
+		if name == "" || seen[name] || !orgLabelColorPattern.MatchString(l.Color) {
+			continue
+		}
+		seen[name] = true
+
+		templates = append(templates,
+			&OrgLabel{
+				OrgID: orgID,
+				Name:  name,
+				Color: strings.ToLower(l.Color),
+			},
+		)
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("org_id = ?", orgID).Delete(&OrgLabel{}).Error
+		if err != nil {
+			return errors.Wrap(err, "delete existing templates")
+		}
+
+		if len(templates) == 0 {
+			return nil
+		}
+		err = tx.Create(&templates).Error
+		if err != nil {
+			return errors.Wrap(err, "create templates")
+		}
+		return nil
+	})
+}
+
+func (db *orgs) ApplyDefaultLabelsToAll(ctx context.Context, orgID int64) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var repoIDs []int64
+		err := tx.Model(&Repository{}).
+			Where("owner_id = ? AND deleted_unix = 0", orgID).
+			Order("id").
+			Pluck("id", &repoIDs).
+			Error
+		if err != nil {
+			return errors.Wrap(err, "list repositories")
+		}
+
+		reposStore := NewReposStore(tx)
+		for _, repoID := range repoIDs {
+			err = reposStore.ApplyOrgLabels(ctx, repoID, orgID)
+			if err != nil {
+				return errors.Wrapf(err, "apply labels to repository %d", repoID)
+			}
+		}
+		return nil
+	})
+}
+
+// PinnedRepo is a repository pinned to the profile of an organization.
+type PinnedRepo struct {
+	ID     int64 `gorm:"primaryKey"`
+	OrgID  int64 `gorm:"uniqueIndex:pinned_repo_org_repo_unique;not null"`
+	RepoID int64 `gorm:"uniqueIndex:pinned_repo_org_repo_unique;index;not null"`
+	// The position of the repository in the pinned list, starting from 0.
+	Position int `gorm:"not null;default:0"`
+}
+
+// maxPinnedRepos is the maximum number of repositories that can be pinned to
+// an organization.
+const maxPinnedRepos = 6
+
+type ErrInvalidPinnedRepo struct {
+	args errutil.Args
+}
+
+// IsErrInvalidPinnedRepo returns true if the underlying error has the type
+// ErrInvalidPinnedRepo.
+func IsErrInvalidPinnedRepo(err error) bool {
+	_, ok := errors.Cause(err).(ErrInvalidPinnedRepo)
+	return ok
+}
+
+func (err ErrInvalidPinnedRepo) Error() string {
+	return fmt.Sprintf("invalid pinned repository: %v", err.args)
+}
+
+func (db *orgs) SetPinnedRepos(ctx context.Context, orgID int64, repoIDs []int64) error {
+	seen := make(map[int64]bool, len(repoIDs))
+	pinned := make([]*PinnedRepo, 0, len(repoIDs))
+	for _, repoID := range repoIDs {
+		if seen[repoID] {
+			continue
+		}
+		seen[repoID] = true
+
+		pinned = append(pinned,
+			&PinnedRepo{
+				OrgID:    orgID,
+				RepoID:   repoID,
+				Position: len(pinned),
+			},
+		)
+	}
+	if len(pinned) > maxPinnedRepos {
+		return ErrInvalidPinnedRepo{args: errutil.Args{"count": len(pinned), "max": maxPinnedRepos}}
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(pinned) > 0 {
+			ids := make([]int64, 0, len(pinned))
+			for _, p := range pinned {
+				ids = append(ids, p.RepoID)
+			}
+
+			var ownedIDs []int64
+			err := tx.Model(&Repository{}).
+				Where("id IN (?) AND owner_id = ? AND deleted_unix = 0", ids, orgID).
+				Pluck("id", &ownedIDs).
+				Error
+			if err != nil {
+				return errors.Wrap(err, "list repositories")
+			}
+
+			owned := make(map[int64]bool, len(ownedIDs))
+			for _, id := range ownedIDs {
+				owned[id] = true
+			}
+			for _, id := range ids {
+				if !owned[id] {
+					return ErrInvalidPinnedRepo{args: errutil.Args{"orgID": orgID, "repoID": id}}
+				}
+			}
+		}
+
+		err := tx.Where("org_id = ?", orgID).Delete(&PinnedRepo{}).Error
+		if err != nil {
+			return errors.Wrap(err, "delete pinned repositories")
+		} else if len(pinned) == 0 {
+			return nil
+		}
+
+		err = tx.Create(&pinned).Error
+		if err != nil {
+			return errors.Wrap(err, "create pinned repositories")
+		}
+		return nil
+	})
+}
+
+func (db *orgs) ListPinnedRepos(ctx context.Context, orgID int64) ([]*Repository, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT repository.* FROM repository
+		JOIN pinned_repo ON pinned_repo.repo_id = repository.id
+		WHERE
+			pinned_repo.org_id = @orgID
+		AND repository.owner_id = @orgID
+		AND repository.deleted_unix = 0
+		ORDER BY pinned_repo.position ASC
+	*/
+	repos := make([]*Repository, 0, maxPinnedRepos)
+	return repos, db.WithContext(ctx).
+		Select("repository.*").
+		Joins("JOIN pinned_repo ON pinned_repo.repo_id = repository.id").
+		Where("pinned_repo.org_id = ? AND repository.owner_id = ? AND repository.deleted_unix = 0", orgID, orgID).
+		Order("pinned_repo.position ASC").
+		Find(&repos).
+		Error
+}
+
+// AuditAction is the type of an administrative action in an organization.
+type AuditAction string
+
+const (
+	AuditActionAddMember        AuditAction = "add_member"
+	AuditActionRemoveMember     AuditAction = "remove_member"
+	AuditActionPublicizeMember  AuditAction = "publicize_member"
+	AuditActionConcealMember    AuditAction = "conceal_member"
+	AuditActionGrantOwner       AuditAction = "grant_owner"
+	AuditActionRevokeOwner      AuditAction = "revoke_owner"
+	AuditActionCreateTeam       AuditAction = "create_team"
+	AuditActionUpdateTeam       AuditAction = "update_team"
+	AuditActionDeleteTeam       AuditAction = "delete_team"
+	AuditActionAddTeamMember    AuditAction = "add_team_member"
+	AuditActionRemoveTeamMember AuditAction = "remove_team_member"
+	AuditActionAddTeamRepo      AuditAction = "add_team_repo"
+	AuditActionRemoveTeamRepo   AuditAction = "remove_team_repo"
+)
+
+// AuditLog is a record of an administrative action in an organization, e.g.
+// changes of membership and permissions.
+type AuditLog struct {
+	ID    int64 `gorm:"primaryKey"`
+	OrgID int64 `gorm:"index;not null"`
+	// The ID of the user who performed the action, 0 means the action was
+	// performed by the system.
+	ActorID int64       `gorm:"not null;default:0"`
+	Action  AuditAction `gorm:"not null"`
+	// The ID of the user affected by the action, if any.
+	TargetUserID int64 `gorm:"not null;default:0"`
+	// The ID of the team affected by the action, if any.
+	TeamID int64 `gorm:"not null;default:0"`
+	// The ID of the repository affected by the action, if any.
+	RepoID int64 `gorm:"not null;default:0"`
+
+	Created     time.Time `gorm:"-" json:"-"`
+	CreatedUnix int64
+}
+
+// BeforeCreate implements the GORM create hook.
+func (l *AuditLog) BeforeCreate(tx *gorm.DB) error {
+	if l.CreatedUnix == 0 {
+		l.CreatedUnix = tx.NowFunc().Unix()
+	}
+	return nil
+}
+
+// AfterFind implements the GORM query hook.
+func (l *AuditLog) AfterFind(_ *gorm.DB) error {
+	l.Created = time.Unix(l.CreatedUnix, 0).Local()
+	return nil
+}
+
+type auditActorKey struct{}
+
+// WithAuditActor returns a copy of the context that carries the ID of the user
+// who performs changes, it is recorded as the actor of audit logs written
+// within the context.
+func WithAuditActor(ctx context.Context, actorID int64) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actorID)
+}
+
+// createAuditLog creates the audit log in the given transaction with the actor
+// carried by the context, so the log never diverges from the change it
+// records.
+func createAuditLog(ctx context.Context, tx *gorm.DB, log *AuditLog) error {
+	log.ActorID, _ = ctx.Value(auditActorKey{}).(int64)
+	err := tx.Create(log).Error
+	if err != nil {
+		return errors.Wrap(err, "create audit log")
+	}
+	return nil
+}
+
+type ListAuditLogOptions struct {
+	// The page number of results, starting from 1.
+	Page int
+	// The number of results per page.
+	PageSize int
+}
+
+func (db *orgs) ListAuditLog(ctx context.Context, orgID int64, opts ListAuditLogOptions) ([]*AuditLog, int64, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT * FROM audit_log
+		WHERE org_id = @orgID
+		ORDER BY id DESC
+		LIMIT @limit OFFSET @offset
+	*/
+	tx := db.WithContext(ctx).Model(&AuditLog{}).Where("org_id = ?", orgID)
+
+	var count int64
+	err := tx.Count(&count).Error
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "count")
+	}
+
+	page := opts.Page
+	if page <= 0 {
+		page = 1
+	}
+	logs := make([]*AuditLog, 0, opts.PageSize)
+	err = tx.Order("id DESC").
+		Limit(opts.PageSize).Offset((page - 1) * opts.PageSize).
+		Find(&logs).
+		Error
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "list")
+	}
+	return logs, count, nil
+}
+
+func (db *orgs) SetMemberVisibility(ctx context.Context, orgID, userID int64, public bool) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		orgUser, err := (&orgs{DB: tx}).GetMembership(ctx, orgID, userID)
+		if err != nil {
+			return err
+		} else if orgUser.IsPublic == public {
+			return nil
+		}
+
+		/*
+			Equivalent SQL for PostgreSQL:
+
+			UPDATE org_user
+			SET is_public = @public
+			WHERE id = @orgUserID
+		*/
+		err = tx.Model(&OrgUser{}).Where("id = ?", orgUser.ID).Update("is_public", public).Error
+		if err != nil {
+			return errors.Wrap(err, "update visibility")
+		}
+
+		action := AuditActionConcealMember
+		if public {
+			action = AuditActionPublicizeMember
+		}
+		return createAuditLog(ctx, tx, &AuditLog{OrgID: orgID, Action: action, TargetUserID: userID})
+	})
+}
+
+// Email notification levels of organization members.
+const (
+	// Receive notifications of everything watched in the organization.
+	OrgNotifyLevelAll = iota + 1
+	// Only receive notifications of threads the member participates in or is
+	// mentioned in.
+	OrgNotifyLevelParticipating
+	// Receive no notifications of the organization at all.
+	OrgNotifyLevelNone
+)
+
+func (db *orgs) SetMemberNotifyLevel(ctx context.Context, orgID, userID int64, level int) error {
+	switch level {
+	case OrgNotifyLevelAll, OrgNotifyLevelParticipating, OrgNotifyLevelNone:
+	default:
+		return errors.Errorf("invalid notify level %d", level)
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		orgUser := new(OrgUser)
+		err := tx.Where("org_id = ? AND uid = ?", orgID, userID).First(orgUser).Error
+		if err != nil {
+			return errors.Wrap(err, "get organization member")
+		} else if orgUser.NotifyLevel == level {
+			return nil
+		}
+
+		/*
+			Equivalent SQL for PostgreSQL:
+
+			UPDATE org_user
+			SET notify_level = @level
+			WHERE id = @orgUserID
+		*/
+		return tx.Model(&OrgUser{}).Where("id = ?", orgUser.ID).Update("notify_level", level).Error
+	})
+}
+
+func (db *orgs) ListMemberNotifyLevels(ctx context.Context, orgID int64) (map[int64]int, error) {
+	var orgUsers []*OrgUser
+	err := db.WithContext(ctx).
+		Select("uid", "notify_level").
+		Where("org_id = ?", orgID).
+		Find(&orgUsers).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	levels := make(map[int64]int, len(orgUsers))
+	for _, orgUser := range orgUsers {
+		levels[orgUser.Uid] = orgUser.NotifyLevel
+	}
+	return levels, nil
+}
+
+func (db *orgs) ListNotifyLevelsOfMember(ctx context.Context, userID int64) (map[int64]int, error) {
+	var orgUsers []*OrgUser
+	err := db.WithContext(ctx).
+		Select("org_id", "notify_level").
+		Where("uid = ?", userID).
+		Find(&orgUsers).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	levels := make(map[int64]int, len(orgUsers))
+	for _, orgUser := range orgUsers {
+		levels[orgUser.OrgID] = orgUser.NotifyLevel
+	}
+	return levels, nil
+}
+
+type TransferOptions struct {
+	// Whether to keep collaborations of users who are already members of the new
+	// organization. By default, they are removed because the access is managed
+	// through teams of the organization.
+	KeepCollaborators bool
+}
+
+// recountRepos recounts the number of repositories owned by the given user or
+// organization.
+func (*orgs) recountRepos(tx *gorm.DB, ownerID int64) error {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		UPDATE "user"
+		SET num_repos = (
+			SELECT COUNT(*) FROM repository WHERE owner_id = @ownerID
+		)
+		WHERE id = @ownerID
+	*/
+	err := tx.Model(&User{}).
+		Where("id = ?", ownerID).
+		Update(
+			"num_repos",
+			tx.Model(&Repository{}).Select("COUNT(*)").Where("owner_id = ?", ownerID),
+		).
+		Error
+	if err != nil {
+		return errors.Wrap(err, `update "user.num_repos"`)
+	}
+	return nil
+}
+
+// recountTeamRepos recounts the number of repositories of all teams in the
+// given organization.
+func (*orgs) recountTeamRepos(tx *gorm.DB, orgID int64) error {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		UPDATE team
+		SET num_repos = (
+			SELECT COUNT(*) FROM team_repo WHERE team_repo.team_id = team.id
+		)
+		WHERE org_id = @orgID
+	*/
+	err := tx.Model(&Team{}).
+		Where("org_id = ?", orgID).
+		Update(
+			"num_repos",
+			tx.Model(&TeamRepo{}).Select("COUNT(*)").Where("team_repo.team_id = team.id"),
+		).
+		Error
+	if err != nil {
+		return errors.Wrap(err, `update "team.num_repos"`)
+	}
+	return nil
+}
+
+func (db *orgs) TransferOwnership(ctx context.Context, repoID, newOrgID int64, opts TransferOptions) error {
+	usersStore := NewUsersStore(db.DB)
+	org, err := usersStore.GetByID(ctx, newOrgID)
+	if err != nil {
+		return errors.Wrap(err, "get organization")
+	} else if !org.IsOrganization() {
+		return ErrOrgNotExist{args: errutil.Args{"orgID": newOrgID}}
+	}
+
+	reposStore := NewReposStore(db.DB)
+	repo, err := reposStore.GetByID(ctx, repoID)
+	if err != nil {
+		return errors.Wrap(err, "get repository")
+	} else if repo.OwnerID == newOrgID {
+		return nil
+	}
+
+	owner, err := usersStore.GetByID(ctx, repo.OwnerID)
+	if err != nil {
+		return errors.Wrap(err, "get owner")
+	}
+
+	_, err = reposStore.GetByName(ctx, newOrgID, repo.Name)
+	if err == nil {
+		return ErrRepoAlreadyExist{
+			args: errutil.Args{
+				"ownerID": newOrgID,
+				"name":    repo.Name,
+			},
+		}
+	} else if !IsErrRepoNotExist(err) {
+		return errors.Wrap(err, "check existing repository")
+	}
+
+	ownersTeam, err := db.getOwnersTeam(db.WithContext(ctx), newOrgID)
+	if err != nil {
+		return errors.Wrap(err, "get owners team")
+	}
+
+	// Directories that have been moved on disk, they are moved back when the
+	// transaction is rolled back.
+	var moved [][2]string
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&Repository{}).
+			Where("id = ?", repo.ID).
+			Updates(map[string]any{
+				"owner_id":     newOrgID,
+				"updated_unix": tx.NowFunc().Unix(),
+			}).
+			Error
+		if err != nil {
+			return errors.Wrap(err, "update owner")
+		}
+
+		if !opts.KeepCollaborators {
+			/*
+				Equivalent SQL for PostgreSQL:
+
+				DELETE FROM collaboration
+				WHERE
+					repo_id = @repoID
+				AND user_id IN (
+					SELECT uid FROM org_user WHERE org_id = @newOrgID
+				)
+			*/
+			err = tx.Where("repo_id = ? AND user_id IN (?)",
+				repo.ID,
+				tx.Model(&OrgUser{}).Select("uid").Where("org_id = ?", newOrgID),
+			).
+				Delete(&Collaboration{}).
+				Error
+			if err != nil {
+				return errors.Wrap(err, "remove redundant collaborations")
+			}
+		}
+
+		// Remove team-repository relations of the old owner.
+		if owner.IsOrganization() {
+			err = tx.Where("org_id = ? AND repo_id = ?", owner.ID, repo.ID).Delete(&TeamRepo{}).Error
+			if err != nil {
+				return errors.Wrap(err, "remove team-repository relations")
+			}
+
+			err = db.recountTeamRepos(tx, owner.ID)
+			if err != nil {
+				return errors.Wrap(err, "recount team repositories of the old owner")
+			}
+		}
+
+		err = tx.Create(
+			&TeamRepo{
+				OrgID:  newOrgID,
+				TeamID: ownersTeam.ID,
+				RepoID: repo.ID,
+			},
+		).Error
+		if err != nil {
+			return errors.Wrap(err, "add to owners team")
+		}
+
+		err = db.recountTeamRepos(tx, newOrgID)
+		if err != nil {
+			return errors.Wrap(err, "recount team repositories of the organization")
+		}
+
+		err = recalculateAccesses(tx, repo.ID)
+		if err != nil {
+			return errors.Wrap(err, "recalculate accesses")
+		}
+
+		for _, ownerID := range []int64{owner.ID, newOrgID} {
+			err = db.recountRepos(tx, ownerID)
+			if err != nil {
+				return errors.Wrapf(err, "recount repositories of %d", ownerID)
+			}
+		}
+
+		err = NewReposStore(tx).Watch(ctx, newOrgID, repo.ID)
+		if err != nil {
+			return errors.Wrap(err, "watch")
+		}
+
+		// Move repository and its wiki on disk if exist, as the last step so that
+		// nothing but the commit can fail afterwards.
+		if osutil.IsExist(RepoPath(owner.Name, repo.Name)) {
+			err = os.MkdirAll(repoutil.UserPath(org.Name), os.ModePerm)
+			if err != nil {
+				return errors.Wrap(err, "create organization directory")
+			}
+
+			oldPath, newPath := RepoPath(owner.Name, repo.Name), RepoPath(org.Name, repo.Name)
+			err = os.Rename(oldPath, newPath)
+			if err != nil {
+				return errors.Wrap(err, "rename repository directory")
+			}
+			moved = append(moved, [2]string{oldPath, newPath})
+		}
+		if osutil.IsExist(WikiPath(owner.Name, repo.Name)) {
+			oldPath, newPath := WikiPath(owner.Name, repo.Name), WikiPath(org.Name, repo.Name)
+			err = os.Rename(oldPath, newPath)
+			if err != nil {
+				return errors.Wrap(err, "rename repository wiki")
+			}
+			moved = append(moved, [2]string{oldPath, newPath})
+		}
+		return nil
+	})
+	if err != nil {
+		for i := len(moved) - 1; i >= 0; i-- {
+			if err := os.Rename(moved[i][1], moved[i][0]); err != nil {
+				log.Error("Failed to move %q back to %q: %v", moved[i][1], moved[i][0], err)
+			}
+		}
+		return err
+	}
+
+	deleteRepoLocalCopy(repo.ID)
+	RemoveAllWithNotice("Delete repository wiki local copy", repoutil.RepositoryLocalWikiPath(repo.ID))
+	return nil
+}
+
 type Organization = User
 
 func (o *Organization) TableName() string {
@@ -6,13 +6,23 @@ package db
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 
+	"gogs.io/gogs/internal/conf"
 	"gogs.io/gogs/internal/dbtest"
 	"gogs.io/gogs/internal/dbutil"
+	"gogs.io/gogs/internal/errutil"
+	"gogs.io/gogs/internal/osutil"
+	"gogs.io/gogs/internal/repoutil"
 )
 
 func TestOrgs(t *testing.T) {
@@ -21,7 +31,15 @@ func TestOrgs(t *testing.T) {
 	}
 	t.Parallel()
 
-	tables := []any{new(User), new(EmailAddress), new(OrgUser)}
+	tables := []any{
+		new(User), new(EmailAddress), new(OrgUser), new(Team), new(TeamUser), new(TeamRepo),
+		new(Repository), new(Watch), new(Access), new(Collaboration), new(Follow), new(Star),
+		new(PublicKey), new(GPGKey), new(Issue), new(IssueUser), new(AccessToken), new(Action), new(TwoFactor),
+		new(OrgProtectBranch), new(ProtectBranch), new(ProtectBranchWhitelist), new(PinnedRepo), new(AuditLog),
+		new(OrgLabel), new(Label), new(OrgInvitation), new(IssueLabel), new(IssueAssignee), new(Comment), new(Attachment),
+		new(PullRequest), new(PullApproval), new(Mirror), new(Milestone), new(Release), new(ProtectedTag),
+		new(Webhook), new(HookTask), new(LFSObject), new(RepoRedirect), new(RepoTopic), new(Topic),
+	}
 	db := &orgs{
 		DB: dbtest.NewDB(t, "orgs", tables...),
 	}
@@ -31,8 +49,53 @@ func TestOrgs(t *testing.T) {
 		test func(t *testing.T, db *orgs)
 	}{
 		{"List", orgsList},
+		{"ListOrgsWithRole", orgsListOrgsWithRole},
+		{"ListOrgsWithRepoCreatePermission", orgsListOrgsWithRepoCreatePermission},
+		{"ListCommon", orgsListCommon},
+		{"ListOrphaned", orgsListOrphaned},
 		{"SearchByName", orgsSearchByName},
+		{"SearchVisibleByName", orgsSearchVisibleByName},
+		{"GetByName", orgsGetByName},
+		{"Create", orgsCreate},
+		{"DeleteByID", orgsDeleteByID},
 		{"CountByUser", orgsCountByUser},
+		{"CountOwnedByUser", orgsCountOwnedByUser},
+		{"AccessibleRepositoriesByUser", orgsAccessibleRepositoriesByUser},
+		{"AccessibleRepositoryIDsByUser", orgsAccessibleRepositoryIDsByUser},
+		{"CountAccessibleRepositoriesByUser", orgsCountAccessibleRepositoriesByUser},
+		{"ListAllRepos", orgsListAllRepos},
+		{"AddMembers", orgsAddMembers},
+		{"AddMemberToTeam", orgsAddMemberToTeam},
+		{"RemoveMember", orgsRemoveMember},
+		{"EnsureOwnersTeam", orgsEnsureOwnersTeam},
+		{"Invitations", orgsInvitations},
+		{"ConsumeInvitations", orgsConsumeInvitations},
+		{"AcceptInvitation", orgsAcceptInvitation},
+		{"GetMembership", orgsGetMembership},
+		{"FilterMembers", orgsFilterMembers},
+		{"LeaveOrg", orgsLeaveOrg},
+		{"SetOwner", orgsSetOwner},
+		{"CountMembers", orgsCountMembers},
+		{"RecountAll", orgsRecountAll},
+		{"DeduplicateMembers", orgsDeduplicateMembers},
+		{"ListMembers", orgsListMembers},
+		{"ListActiveMembers", orgsListActiveMembers},
+		{"SearchMembers", orgsSearchMembers},
+		{"ListOrgMembersWithRole", orgsListOrgMembersWithRole},
+		{"StreamMembers", orgsStreamMembers},
+		{"GetTeamsByUser", orgsGetTeamsByUser},
+		{"SetDefaultRepoPermission", orgsSetDefaultRepoPermission},
+		{"SetRequireTwoFactor", orgsSetRequireTwoFactor},
+		{"SetVerified", orgsSetVerified},
+		{"SetArchived", orgsSetArchived},
+		{"SetRepoCreatePermission", orgsSetRepoCreatePermission},
+		{"DefaultProtection", orgsDefaultProtection},
+		{"DefaultLabels", orgsDefaultLabels},
+		{"PinnedRepos", orgsPinnedRepos},
+		{"ListAuditLog", orgsListAuditLog},
+		{"SetMemberVisibility", orgsSetMemberVisibility},
+		{"SetMemberNotifyLevel", orgsSetMemberNotifyLevel},
+		{"TransferOwnership", orgsTransferOwnership},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Cleanup(func() {
@@ -47,30 +110,59 @@ func TestOrgs(t *testing.T) {
 	}
 }
 
-func orgsList(t *testing.T, db *orgs) {
+func TestOrgsWithReplica(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
 	ctx := context.Background()
+	tables := []any{new(User), new(EmailAddress), new(OrgUser), new(Team), new(TeamUser), new(AuditLog)}
+	primary := dbtest.NewDB(t, "orgs-primary", tables...)
+	replica := dbtest.NewDB(t, "orgs-replica", tables...)
+	db := NewOrgsStoreWithReplica(primary, replica)
 
-	usersStore := NewUsersStore(db.DB)
+	usersStore := NewUsersStore(primary)
 	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
 	require.NoError(t, err)
-	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+
+	org1 := createTestOrg(t, primary, "org1", CreateUserOptions{})
+
+	// Writes go to the primary.
+	err = db.AddMember(ctx, org1.ID, alice.ID)
+	require.NoError(t, err)
+	assert.True(t, db.HasMember(ctx, org1.ID, alice.ID))
+
+	// Heavy reads go to the replica, which has not caught up.
+	_, count, err := db.SearchByName(ctx, "org", 1, 10, "", false)
 	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
 
-	// TODO: Use Orgs.Create to replace SQL hack when the method is available.
-	org1, err := usersStore.Create(ctx, "org1", "org1@example.com", CreateUserOptions{})
+	_, count, err = db.ListMembers(ctx, org1.ID, ListOrgMembersOptions{})
 	require.NoError(t, err)
-	org2, err := usersStore.Create(ctx, "org2", "org2@example.com", CreateUserOptions{})
+	assert.Equal(t, int64(0), count)
+
+	got, err := db.List(ctx, ListOrgsOptions{MemberID: alice.ID, IncludePrivateMembers: true})
 	require.NoError(t, err)
-	err = db.Exec(
-		dbutil.Quote("UPDATE %s SET type = ? WHERE id IN (?, ?)", "user"),
-		UserTypeOrganization, org1.ID, org2.ID,
-	).Error
+	assert.Empty(t, got)
+}
+
+func orgsList(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
 	require.NoError(t, err)
 
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+	org2 := createTestOrg(t, db.DB, "org2", CreateUserOptions{})
+
 	// TODO: Use Orgs.Join to replace SQL hack when the method is available.
 	err = db.Exec(`INSERT INTO org_user (uid, org_id, is_public) VALUES (?, ?, ?)`, alice.ID, org1.ID, false).Error
 	require.NoError(t, err)
-	err = db.Exec(`INSERT INTO org_user (uid, org_id, is_public) VALUES (?, ?, ?)`, alice.ID, org2.ID, true).Error
+	err = db.Exec(`INSERT INTO org_user (uid, org_id, is_public, is_owner) VALUES (?, ?, ?, ?)`, alice.ID, org2.ID, true, true).Error
 	require.NoError(t, err)
 	err = db.Exec(`INSERT INTO org_user (uid, org_id, is_public) VALUES (?, ?, ?)`, bob.ID, org2.ID, true).Error
 	require.NoError(t, err)
@@ -104,6 +196,24 @@ func orgsList(t *testing.T, db *orgs) {
 			},
 			wantOrgNames: []string{},
 		},
+		{
+			name: "only owned organizations",
+			opts: ListOrgsOptions{
+				MemberID:              alice.ID,
+				IncludePrivateMembers: true,
+				OwnedOnly:             true,
+			},
+			wantOrgNames: []string{org2.Name},
+		},
+		{
+			name: "order by name descending",
+			opts: ListOrgsOptions{
+				MemberID:              alice.ID,
+				IncludePrivateMembers: true,
+				OrderBy:               "lower_name DESC",
+			},
+			wantOrgNames: []string{org2.Name, org1.Name},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -117,25 +227,206 @@ func orgsList(t *testing.T, db *orgs) {
 			assert.Equal(t, test.wantOrgNames, gotOrgNames)
 		})
 	}
+
+	t.Run("invalid order", func(t *testing.T) {
+		for _, orderBy := range []string{"password", "id; DROP TABLE user", "id SIDEWAYS"} {
+			_, err := db.List(ctx, ListOrgsOptions{MemberID: alice.ID, OrderBy: orderBy})
+			assert.Error(t, err, orderBy)
+		}
+	})
 }
 
-func orgsSearchByName(t *testing.T, db *orgs) {
+func orgsListOrgsWithRole(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{FullName: "Org 1"})
+	org2 := createTestOrg(t, db.DB, "org2", CreateUserOptions{})
+
+	err = db.Exec(`INSERT INTO org_user (uid, org_id, is_public) VALUES (?, ?, ?)`, alice.ID, org1.ID, false).Error
+	require.NoError(t, err)
+	err = db.Exec(`INSERT INTO org_user (uid, org_id, is_public, is_owner) VALUES (?, ?, ?, ?)`, alice.ID, org2.ID, true, true).Error
+	require.NoError(t, err)
+
+	got, err := db.ListOrgsWithRole(ctx, ListOrgsOptions{MemberID: alice.ID, IncludePrivateMembers: true})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	assert.Equal(t, org1.ID, got[0].Organization.ID)
+	assert.Equal(t, "Org 1", got[0].Organization.FullName)
+	assert.False(t, got[0].Organization.Created.IsZero())
+	assert.False(t, got[0].IsOwner)
+	assert.False(t, got[0].IsPublic)
+
+	assert.Equal(t, org2.ID, got[1].Organization.ID)
+	assert.True(t, got[1].IsOwner)
+	assert.True(t, got[1].IsPublic)
+
+	got, err = db.ListOrgsWithRole(ctx, ListOrgsOptions{MemberID: alice.ID})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, org2.ID, got[0].Organization.ID)
+
+	_, err = db.ListOrgsWithRole(ctx, ListOrgsOptions{})
+	assert.Error(t, err)
+}
+
+func orgsListCommon(t *testing.T, db *orgs) {
 	ctx := context.Background()
 
-	// TODO: Use Orgs.Create to replace SQL hack when the method is available.
 	usersStore := NewUsersStore(db.DB)
-	org1, err := usersStore.Create(ctx, "org1", "org1@example.com", CreateUserOptions{FullName: "Acme Corp"})
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
 	require.NoError(t, err)
-	org2, err := usersStore.Create(ctx, "org2", "org2@example.com", CreateUserOptions{FullName: "Acme Corp 2"})
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
 	require.NoError(t, err)
+
+	var orgIDs []int64
+	for _, name := range []string{"org2", "org1", "org3"} {
+		org := createTestOrg(t, db.DB, name, CreateUserOptions{})
+		orgIDs = append(orgIDs, org.ID)
+	}
+	org2, org1, org3 := orgIDs[0], orgIDs[1], orgIDs[2]
+
 	err = db.Exec(
-		dbutil.Quote("UPDATE %s SET type = ? WHERE id IN (?, ?)", "user"),
-		UserTypeOrganization, org1.ID, org2.ID,
+		`INSERT INTO org_user (uid, org_id, is_public) VALUES (?, ?, ?), (?, ?, ?), (?, ?, ?), (?, ?, ?), (?, ?, ?)`,
+		alice.ID, org2, true,
+		bob.ID, org2, false,
+		alice.ID, org1, false,
+		bob.ID, org1, true,
+		alice.ID, org3, true,
 	).Error
 	require.NoError(t, err)
 
+	got, err := db.ListCommon(ctx, alice.ID, bob.ID)
+	require.NoError(t, err)
+	gotNames := make([]string, 0, len(got))
+	for _, org := range got {
+		gotNames = append(gotNames, org.Name)
+	}
+	assert.Equal(t, []string{"org1", "org2"}, gotNames)
+
+	got, err = db.ListCommon(ctx, bob.ID, alice.ID)
+	require.NoError(t, err)
+	assert.Len(t, got, 2)
+
+	got, err = db.ListCommon(ctx, alice.ID, 404)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func orgsListOrgsWithRepoCreatePermission(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+	org2 := createTestOrg(t, db.DB, "org2", CreateUserOptions{})
+	org3 := createTestOrg(t, db.DB, "org3", CreateUserOptions{})
+	org4 := createTestOrg(t, db.DB, "org4", CreateUserOptions{})
+	err = db.Exec(dbutil.Quote("UPDATE %s SET updated_unix = id WHERE id IN (?, ?, ?, ?)", "user"), org1.ID, org2.ID, org3.ID, org4.ID).Error
+	require.NoError(t, err)
+
+	// Alice is an owner of org1, belongs to a team with the admin access mode in
+	// org2, a team with the write access mode in org3, and a team with the read
+	// access mode in org4.
+	err = db.Exec(`INSERT INTO org_user (uid, org_id, is_owner) VALUES (?, ?, ?)`, alice.ID, org1.ID, true).Error
+	require.NoError(t, err)
+	for _, org := range []struct {
+		id   int64
+		mode AccessMode
+	}{
+		{org2.ID, AccessModeAdmin},
+		{org3.ID, AccessModeWrite},
+		{org4.ID, AccessModeRead},
+	} {
+		err = db.Exec(`INSERT INTO org_user (uid, org_id) VALUES (?, ?)`, alice.ID, org.id).Error
+		require.NoError(t, err)
+
+		createTestTeam(t, db.DB, &Team{
+			OrgID:     org.id,
+			LowerName: "team1",
+			Name:      "team1",
+			Authorize: org.mode,
+		}, []int64{alice.ID}, nil)
+	}
+
+	got, err := db.ListOrgsWithRepoCreatePermission(ctx, alice.ID)
+	require.NoError(t, err)
+	gotIDs := make([]int64, 0, len(got))
+	for _, org := range got {
+		gotIDs = append(gotIDs, org.ID)
+	}
+	assert.Equal(t, []int64{org3.ID, org2.ID, org1.ID}, gotIDs)
+
+	// Only owners are allowed when the organization restricts creation to owners
+	for _, org := range []int64{org1.ID, org2.ID, org3.ID} {
+		err = db.SetRepoCreatePermission(ctx, org, RepoCreatePermissionOwners)
+		require.NoError(t, err)
+	}
+	got, err = db.ListOrgsWithRepoCreatePermission(ctx, alice.ID)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, org1.ID, got[0].ID)
+
+	got, err = db.ListOrgsWithRepoCreatePermission(ctx, 404)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func orgsListOrphaned(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+	org2 := createTestOrg(t, db.DB, "org2", CreateUserOptions{})
+	org3 := createTestOrg(t, db.DB, "org3", CreateUserOptions{})
+
+	// org1 has an owner, org2 has an empty Owners team, and org3 has no Owners
+	// team at all.
+	err = db.AddMember(ctx, org1.ID, alice.ID)
+	require.NoError(t, err)
+	err = db.SetOwner(ctx, org1.ID, alice.ID, true)
+	require.NoError(t, err)
+	err = db.AddMember(ctx, org2.ID, bob.ID)
+	require.NoError(t, err)
+	_, err = db.EnsureOwnersTeam(ctx, org2.ID)
+	require.NoError(t, err)
+
+	listOrgIDs := func() []int64 {
+		orgs, err := db.ListOrphaned(ctx)
+		require.NoError(t, err)
+		ids := make([]int64, 0, len(orgs))
+		for _, org := range orgs {
+			ids = append(ids, org.ID)
+		}
+		return ids
+	}
+	assert.Equal(t, []int64{org2.ID, org3.ID}, listOrgIDs())
+
+	// Assigning a new owner repairs the organization
+	err = db.SetOwner(ctx, org2.ID, bob.ID, true)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{org3.ID}, listOrgIDs())
+}
+
+func orgsSearchByName(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{FullName: "Acme Corp"})
+	org2 := createTestOrg(t, db.DB, "org2", CreateUserOptions{FullName: "Acme Corp 2"})
+
 	t.Run("search for username org1", func(t *testing.T) {
-		orgs, count, err := db.SearchByName(ctx, "G1", 1, 1, "")
+		orgs, count, err := db.SearchByName(ctx, "G1", 1, 1, "", false)
 		require.NoError(t, err)
 		require.Len(t, orgs, int(count))
 		assert.Equal(t, int64(1), count)
@@ -143,7 +434,7 @@ func orgsSearchByName(t *testing.T, db *orgs) {
 	})
 
 	t.Run("search for username org2", func(t *testing.T) {
-		orgs, count, err := db.SearchByName(ctx, "G2", 1, 1, "")
+		orgs, count, err := db.SearchByName(ctx, "G2", 1, 1, "", false)
 		require.NoError(t, err)
 		require.Len(t, orgs, int(count))
 		assert.Equal(t, int64(1), count)
@@ -151,14 +442,14 @@ func orgsSearchByName(t *testing.T, db *orgs) {
 	})
 
 	t.Run("search for full name acme", func(t *testing.T) {
-		orgs, count, err := db.SearchByName(ctx, "ACME", 1, 10, "")
+		orgs, count, err := db.SearchByName(ctx, "ACME", 1, 10, "", false)
 		require.NoError(t, err)
 		require.Len(t, orgs, int(count))
 		assert.Equal(t, int64(2), count)
 	})
 
 	t.Run("search for full name acme ORDER BY id DESC LIMIT 1", func(t *testing.T) {
-		orgs, count, err := db.SearchByName(ctx, "ACME", 1, 1, "id DESC")
+		orgs, count, err := db.SearchByName(ctx, "ACME", 1, 1, "id DESC", false)
 		require.NoError(t, err)
 		require.Len(t, orgs, 1)
 		assert.Equal(t, int64(2), count)
@@ -166,6 +457,281 @@ func orgsSearchByName(t *testing.T, db *orgs) {
 	})
 }
 
+func orgsSearchVisibleByName(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{FullName: "Acme Corp"})
+	org2 := createTestOrg(t, db.DB, "org2", CreateUserOptions{FullName: "Acme Corp 2"})
+
+	// org1 has a public member, org2 only has a private member
+	err = db.AddMember(ctx, org1.ID, alice.ID)
+	require.NoError(t, err)
+	err = db.SetMemberVisibility(ctx, org1.ID, alice.ID, true)
+	require.NoError(t, err)
+	err = db.AddMember(ctx, org2.ID, bob.ID)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		viewerID  int64
+		wantIDs   []int64
+		wantCount int64
+	}{
+		{
+			name:      "anonymous viewer",
+			viewerID:  0,
+			wantIDs:   []int64{org1.ID},
+			wantCount: 1,
+		},
+		{
+			name:      "viewer is not a member",
+			viewerID:  alice.ID,
+			wantIDs:   []int64{org1.ID},
+			wantCount: 1,
+		},
+		{
+			name:      "viewer is a private member",
+			viewerID:  bob.ID,
+			wantIDs:   []int64{org1.ID, org2.ID},
+			wantCount: 2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			orgs, count, err := db.SearchVisibleByName(ctx, "ACME", 1, 10, "id ASC", test.viewerID)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantCount, count)
+
+			gotIDs := make([]int64, len(orgs))
+			for i := range orgs {
+				gotIDs[i] = orgs[i].ID
+			}
+			assert.Equal(t, test.wantIDs, gotIDs)
+		})
+	}
+
+	t.Run("empty keyword lists all visible organizations", func(t *testing.T) {
+		orgs, count, err := db.SearchVisibleByName(ctx, "", 1, 10, "id ASC", alice.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+		require.Len(t, orgs, 1)
+		assert.Equal(t, org1.ID, orgs[0].ID)
+	})
+}
+
+func orgsGetByName(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	org1, err := db.Create(ctx, "Org1", alice.ID, CreateOrgOptions{})
+	require.NoError(t, err)
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := db.GetByName(ctx, "404")
+		wantErr := ErrOrgNotExist{args: errutil.Args{"name": "404"}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	t.Run("individual user", func(t *testing.T) {
+		_, err := db.GetByName(ctx, "alice")
+		assert.True(t, IsErrOrgNotExist(err))
+	})
+
+	got, err := db.GetByName(ctx, "org1")
+	require.NoError(t, err)
+	assert.Equal(t, org1.ID, got.ID)
+	assert.Equal(t, "Org1", got.Name)
+}
+
+func orgsCreate(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	t.Run("name not allowed", func(t *testing.T) {
+		_, err := db.Create(ctx, "-", alice.ID, CreateOrgOptions{})
+		wantErr := ErrNameNotAllowed{
+			args: errutil.Args{
+				"reason": "reserved",
+				"name":   "-",
+			},
+		}
+		assert.Equal(t, wantErr, err)
+	})
+
+	t.Run("name already exists", func(t *testing.T) {
+		_, err := db.Create(ctx, alice.Name, alice.ID, CreateOrgOptions{})
+		wantErr := ErrOrgAlreadyExist{
+			args: errutil.Args{
+				"name": alice.Name,
+			},
+		}
+		assert.Equal(t, wantErr, err)
+	})
+
+	tempRepositoryRoot := filepath.Join(os.TempDir(), "orgsCreate-tempRepositoryRoot")
+	conf.SetMockRepository(
+		t,
+		conf.RepositoryOpts{
+			Root: tempRepositoryRoot,
+		},
+	)
+	err = os.RemoveAll(tempRepositoryRoot)
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(tempRepositoryRoot) }()
+
+	tempPictureAvatarUploadPath := filepath.Join(os.TempDir(), "orgsCreate-tempPictureAvatarUploadPath")
+	conf.SetMockPicture(
+		t,
+		conf.PictureOpts{
+			AvatarUploadPath: tempPictureAvatarUploadPath,
+		},
+	)
+	err = os.RemoveAll(tempPictureAvatarUploadPath)
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(tempPictureAvatarUploadPath) }()
+
+	org, err := db.Create(ctx, "org1", alice.ID, CreateOrgOptions{FullName: "Organization 1"})
+	require.NoError(t, err)
+	assert.True(t, org.IsOrganization())
+	assert.Equal(t, "Organization 1", org.FullName)
+	assert.DirExists(t, filepath.Join(tempRepositoryRoot, "org1"))
+
+	org, err = usersStore.GetByID(ctx, org.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, org.NumMembers)
+	assert.Equal(t, 1, org.NumTeams)
+
+	orgUser := new(OrgUser)
+	err = db.Where("org_id = ? AND uid = ?", org.ID, alice.ID).First(orgUser).Error
+	require.NoError(t, err)
+	assert.True(t, orgUser.IsOwner)
+
+	teams, err := db.GetTeamsByUser(ctx, org.ID, alice.ID)
+	require.NoError(t, err)
+	require.Len(t, teams, 1)
+	assert.Equal(t, OWNER_TEAM, teams[0].Name)
+	assert.Equal(t, AccessModeOwner, teams[0].Authorize)
+	assert.Equal(t, 1, teams[0].NumMembers)
+}
+
+func orgsDeleteByID(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+
+	team1 := createTestTeam(t, db.DB, &Team{
+		OrgID:     org1.ID,
+		LowerName: "owners",
+		Name:      OWNER_TEAM,
+		Authorize: AccessModeOwner,
+	}, nil, nil)
+	err = NewTeamsStore(db.DB).AddTeamMember(ctx, team1.ID, alice.ID)
+	require.NoError(t, err)
+
+	t.Run("not an organization", func(t *testing.T) {
+		err := db.DeleteByID(ctx, alice.ID, DeleteOrgOptions{})
+		wantErr := ErrOrgNotExist{args: errutil.Args{"orgID": alice.ID}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	repo1, err := NewReposStore(db.DB).Create(ctx, org1.ID, CreateRepoOptions{Name: "repo1"})
+	require.NoError(t, err)
+	err = db.SetPinnedRepos(ctx, org1.ID, []int64{repo1.ID})
+	require.NoError(t, err)
+	err = db.SetDefaultProtection(ctx, org1.ID, []*OrgProtectBranch{{Name: "main", RequirePullRequest: true}})
+	require.NoError(t, err)
+	err = NewWebhooksStore(db.DB).CreateForOrg(ctx, org1.ID, &Webhook{URL: "https://example.com/hook"})
+	require.NoError(t, err)
+	err = db.SetDefaultLabels(ctx, org1.ID, []*OrgLabel{{Name: "bug", Color: "#ee0701"}})
+	require.NoError(t, err)
+
+	t.Run("organization still owns repositories", func(t *testing.T) {
+		err := db.DeleteByID(ctx, org1.ID, DeleteOrgOptions{})
+		wantErr := ErrOrgOwnsRepos{
+			args: errutil.Args{
+				"orgID": org1.ID,
+			},
+		}
+		assert.Equal(t, wantErr, err)
+	})
+
+	reposStore := NewReposStore(db.DB)
+	err = reposStore.Watch(ctx, alice.ID, repo1.ID)
+	require.NoError(t, err)
+	err = reposStore.SetTopics(ctx, repo1.ID, []string{"go"})
+	require.NoError(t, err)
+	issue := &Issue{RepoID: repo1.ID, Index: 1, PosterID: alice.ID, Title: "issue1"}
+	err = db.DB.Create(issue).Error
+	require.NoError(t, err)
+	err = db.DB.Create(&Comment{IssueID: issue.ID, PosterID: alice.ID}).Error
+	require.NoError(t, err)
+
+	// Create files of the repository on disk to make sure they are removed.
+	conf.SetMockRepository(t, conf.RepositoryOpts{Root: t.TempDir()})
+	repoPath := repoutil.RepositoryPath(org1.Name, repo1.Name)
+	err = os.MkdirAll(repoPath, os.ModePerm)
+	require.NoError(t, err)
+
+	t.Run("failure keeps everything", func(t *testing.T) {
+		// Make the cleanup of a table fail after repositories are deleted in the
+		// transaction.
+		err := db.Migrator().RenameTable("org_invitation", "org_invitation_backup")
+		require.NoError(t, err)
+		err = db.DeleteByID(ctx, org1.ID, DeleteOrgOptions{Force: true})
+		assert.Error(t, err)
+		err = db.Migrator().RenameTable("org_invitation_backup", "org_invitation")
+		require.NoError(t, err)
+
+		_, err = reposStore.GetByID(ctx, repo1.ID)
+		require.NoError(t, err)
+		assert.True(t, osutil.IsDir(repoPath))
+	})
+
+	err = db.DeleteByID(ctx, org1.ID, DeleteOrgOptions{Force: true})
+	require.NoError(t, err)
+
+	_, err = usersStore.GetByID(ctx, org1.ID)
+	assert.True(t, IsErrUserNotExist(err))
+	assert.False(t, osutil.IsExist(repoPath))
+
+	for _, table := range []any{&Team{}, &TeamUser{}, &OrgUser{}, &PinnedRepo{}, &OrgProtectBranch{}, &Webhook{}, &OrgLabel{}} {
+		var count int64
+		err = db.Model(table).Where("org_id = ?", org1.ID).Count(&count).Error
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count, "%T", table)
+	}
+	for _, table := range []any{&Repository{}, &Watch{}, &RepoTopic{}, &Issue{}, &Comment{}} {
+		var count int64
+		err = db.Model(table).Count(&count).Error
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count, "%T", table)
+	}
+
+	var topic Topic
+	err = db.Where("name = ?", "go").First(&topic).Error
+	require.NoError(t, err)
+	assert.Equal(t, 0, topic.NumRepos)
+
+	// Deleting a non-existent organization should be a no-op
+	err = db.DeleteByID(ctx, org1.ID, DeleteOrgOptions{})
+	require.NoError(t, err)
+}
+
 func orgsCountByUser(t *testing.T, db *orgs) {
 	ctx := context.Background()
 
@@ -183,3 +749,2118 @@ func orgsCountByUser(t *testing.T, db *orgs) {
 	require.NoError(t, err)
 	assert.Equal(t, int64(0), got)
 }
+
+func orgsCountOwnedByUser(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	err := db.Exec(`INSERT INTO org_user (uid, org_id, is_owner) VALUES (?, ?, ?)`, 1, 1, true).Error
+	require.NoError(t, err)
+	err = db.Exec(`INSERT INTO org_user (uid, org_id, is_owner) VALUES (?, ?, ?)`, 1, 2, false).Error
+	require.NoError(t, err)
+
+	got, err := db.CountOwnedByUser(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), got)
+
+	got, err = db.CountOwnedByUser(ctx, 404)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), got)
+}
+
+func orgsAccessibleRepositoriesByUser(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+	err = db.AddMember(ctx, org1.ID, alice.ID)
+	require.NoError(t, err)
+
+	reposStore := NewReposStore(db.DB)
+	repo1, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo1"})
+	require.NoError(t, err)
+	repo2, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo-2", Private: true})
+	require.NoError(t, err)
+	_, err = reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo_3", Private: true})
+	require.NoError(t, err)
+	repo4, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo_4"})
+	require.NoError(t, err)
+
+	createTestTeam(t, db.DB, &Team{
+		OrgID:     org1.ID,
+		LowerName: "team1",
+		Name:      "team1",
+		Authorize: AccessModeRead,
+	}, []int64{alice.ID}, []int64{repo2.ID})
+
+	repo5, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo5"})
+	require.NoError(t, err)
+	err = db.Model(&Repository{}).Where("id = ?", repo5.ID).Update("is_archived", true).Error
+	require.NoError(t, err)
+	err = db.Model(&Repository{}).Where("id IN (?)", []int64{repo1.ID, repo5.ID}).Update("is_mirror", true).Error
+	require.NoError(t, err)
+
+	// Soft-deleted repositories should never be included
+	repo6, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo6"})
+	require.NoError(t, err)
+	err = reposStore.SoftDelete(ctx, repo6.ID)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		page      int
+		pageSize  int
+		opts      AccessibleRepositoriesByUserOptions
+		wantNames []string
+		wantCount int64
+	}{
+		{
+			name:      "all accessible",
+			page:      1,
+			pageSize:  10,
+			wantNames: []string{repo1.Name, repo2.Name, repo4.Name, repo5.Name},
+			wantCount: 4,
+		},
+		{
+			name:      "skip count",
+			page:      1,
+			pageSize:  10,
+			opts:      AccessibleRepositoriesByUserOptions{SkipCount: true},
+			wantNames: []string{repo1.Name, repo2.Name, repo4.Name, repo5.Name},
+			wantCount: 0,
+		},
+		{
+			name:      "keyword is case-insensitive",
+			page:      1,
+			pageSize:  10,
+			opts:      AccessibleRepositoriesByUserOptions{Keyword: "REPO-"},
+			wantNames: []string{repo2.Name},
+			wantCount: 1,
+		},
+		{
+			name:      "keyword with escaped wildcard",
+			page:      1,
+			pageSize:  10,
+			opts:      AccessibleRepositoriesByUserOptions{Keyword: "o_"},
+			wantNames: []string{repo4.Name},
+			wantCount: 1,
+		},
+		{
+			name:      "exclude archived",
+			page:      1,
+			pageSize:  10,
+			opts:      AccessibleRepositoriesByUserOptions{ExcludeArchived: true},
+			wantNames: []string{repo1.Name, repo2.Name, repo4.Name},
+			wantCount: 3,
+		},
+		{
+			name:      "mirrors only",
+			page:      1,
+			pageSize:  10,
+			opts:      AccessibleRepositoriesByUserOptions{MirrorsOnly: true},
+			wantNames: []string{repo1.Name, repo5.Name},
+			wantCount: 2,
+		},
+		{
+			name:      "unarchived mirrors",
+			page:      1,
+			pageSize:  10,
+			opts:      AccessibleRepositoriesByUserOptions{ExcludeArchived: true, MirrorsOnly: true},
+			wantNames: []string{repo1.Name},
+			wantCount: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, count, _, err := db.AccessibleRepositoriesByUser(ctx, org1.ID, alice.ID, test.page, test.pageSize, test.opts)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantCount, count)
+
+			gotNames := make([]string, len(got))
+			for i := range got {
+				gotNames[i] = got[i].Name
+			}
+			assert.ElementsMatch(t, test.wantNames, gotNames)
+		})
+	}
+
+	t.Run("paginated", func(t *testing.T) {
+		got, count, hasMore, err := db.AccessibleRepositoriesByUser(ctx, org1.ID, alice.ID, 1, 3, AccessibleRepositoriesByUserOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), count)
+		assert.Len(t, got, 3)
+		assert.True(t, hasMore)
+
+		got, count, hasMore, err = db.AccessibleRepositoriesByUser(ctx, org1.ID, alice.ID, 2, 3, AccessibleRepositoriesByUserOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), count)
+		assert.Len(t, got, 1)
+		assert.False(t, hasMore)
+	})
+
+	t.Run("check has more without counting", func(t *testing.T) {
+		opts := AccessibleRepositoriesByUserOptions{SkipCount: true, CheckHasMore: true}
+		got, count, hasMore, err := db.AccessibleRepositoriesByUser(ctx, org1.ID, alice.ID, 1, 3, opts)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+		assert.Len(t, got, 3)
+		assert.True(t, hasMore)
+
+		got, count, hasMore, err = db.AccessibleRepositoriesByUser(ctx, org1.ID, alice.ID, 2, 3, opts)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+		assert.Len(t, got, 1)
+		assert.False(t, hasMore)
+
+		_, _, hasMore, err = db.AccessibleRepositoriesByUser(ctx, org1.ID, alice.ID, 1, 4, opts)
+		require.NoError(t, err)
+		assert.False(t, hasMore)
+	})
+}
+
+func orgsAccessibleRepositoryIDsByUser(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+	err = db.AddMember(ctx, org1.ID, alice.ID)
+	require.NoError(t, err)
+
+	reposStore := NewReposStore(db.DB)
+	repo1, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo1"})
+	require.NoError(t, err)
+	repo2, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo2", Private: true})
+	require.NoError(t, err)
+	_, err = reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo3", Private: true})
+	require.NoError(t, err)
+	repo4, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo4"})
+	require.NoError(t, err)
+	err = db.Model(&Repository{}).Where("id = ?", repo4.ID).Update("is_archived", true).Error
+	require.NoError(t, err)
+
+	createTestTeam(t, db.DB, &Team{
+		OrgID:     org1.ID,
+		LowerName: "team1",
+		Name:      "team1",
+		Authorize: AccessModeRead,
+	}, []int64{alice.ID}, []int64{repo2.ID})
+
+	got, err := db.AccessibleRepositoryIDsByUser(ctx, org1.ID, alice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{repo1.ID, repo2.ID, repo4.ID}, got)
+
+	got, err = db.AccessibleRepositoryIDsByUser(ctx, org1.ID, bob.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{repo1.ID, repo4.ID}, got)
+}
+
+func orgsCountAccessibleRepositoriesByUser(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+	err = db.AddMember(ctx, org1.ID, alice.ID)
+	require.NoError(t, err)
+
+	reposStore := NewReposStore(db.DB)
+	_, err = reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo1"})
+	require.NoError(t, err)
+	repo2, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo2", Private: true})
+	require.NoError(t, err)
+	_, err = reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo3", Private: true})
+	require.NoError(t, err)
+	repo4, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo4"})
+	require.NoError(t, err)
+	err = db.Model(&Repository{}).Where("id = ?", repo4.ID).Update("is_archived", true).Error
+	require.NoError(t, err)
+
+	createTestTeam(t, db.DB, &Team{
+		OrgID:     org1.ID,
+		LowerName: "team1",
+		Name:      "team1",
+		Authorize: AccessModeRead,
+	}, []int64{alice.ID}, []int64{repo2.ID})
+
+	// Archived repositories are counted
+	got, err := db.CountAccessibleRepositoriesByUser(ctx, org1.ID, alice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), got)
+
+	_, wantCount, _, err := db.AccessibleRepositoriesByUser(ctx, org1.ID, alice.ID, 1, 1, AccessibleRepositoriesByUserOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, wantCount, got)
+
+	got, err = db.CountAccessibleRepositoriesByUser(ctx, org1.ID, bob.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), got)
+}
+
+func orgsListAllRepos(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+
+	reposStore := NewReposStore(db.DB)
+	repo1, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo1"})
+	require.NoError(t, err)
+	repo2, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo-2", Private: true})
+	require.NoError(t, err)
+	repo3, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo_3", Private: true, Unlisted: true})
+	require.NoError(t, err)
+	repo4, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo4"})
+	require.NoError(t, err)
+	err = db.Model(&Repository{}).Where("id = ?", repo4.ID).Updates(map[string]any{"is_archived": true, "is_mirror": true}).Error
+	require.NoError(t, err)
+
+	// Soft-deleted repositories should never be included
+	repo5, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo5"})
+	require.NoError(t, err)
+	err = reposStore.SoftDelete(ctx, repo5.ID)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		page      int
+		pageSize  int
+		opts      ListAllReposOptions
+		wantNames []string
+		wantCount int64
+	}{
+		{
+			name:      "all including private",
+			page:      1,
+			pageSize:  10,
+			wantNames: []string{repo1.Name, repo2.Name, repo3.Name},
+			wantCount: 3,
+		},
+		{
+			name:      "keyword with escaped wildcard",
+			page:      1,
+			pageSize:  10,
+			opts:      ListAllReposOptions{Keyword: "O_"},
+			wantNames: []string{repo3.Name},
+			wantCount: 1,
+		},
+		{
+			name:      "include archived",
+			page:      1,
+			pageSize:  10,
+			opts:      ListAllReposOptions{IncludeArchived: true},
+			wantNames: []string{repo1.Name, repo2.Name, repo3.Name, repo4.Name},
+			wantCount: 4,
+		},
+		{
+			name:      "archived mirrors",
+			page:      1,
+			pageSize:  10,
+			opts:      ListAllReposOptions{IncludeArchived: true, MirrorsOnly: true},
+			wantNames: []string{repo4.Name},
+			wantCount: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, count, err := db.ListAllRepos(ctx, org1.ID, test.page, test.pageSize, test.opts)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantCount, count)
+
+			gotNames := make([]string, len(got))
+			for i := range got {
+				gotNames[i] = got[i].Name
+			}
+			assert.ElementsMatch(t, test.wantNames, gotNames)
+		})
+	}
+
+	t.Run("paginated", func(t *testing.T) {
+		got, count, err := db.ListAllRepos(ctx, org1.ID, 2, 2, ListAllReposOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+		assert.Len(t, got, 1)
+	})
+}
+
+func orgsAddMembers(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	cindy, err := usersStore.Create(ctx, "cindy", "cindy@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+
+	err = db.AddMember(ctx, org1.ID, alice.ID)
+	require.NoError(t, err)
+
+	// Adding the same member again should be a no-op
+	err = db.AddMember(ctx, org1.ID, alice.ID)
+	require.NoError(t, err)
+
+	org1, err = usersStore.GetByID(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, org1.NumMembers)
+
+	// Existing members and duplicated IDs should be skipped
+	err = db.AddMembers(ctx, org1.ID, []int64{alice.ID, bob.ID, cindy.ID, bob.ID})
+	require.NoError(t, err)
+
+	org1, err = usersStore.GetByID(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, org1.NumMembers)
+
+	var count int64
+	err = db.Model(&OrgUser{}).Where("org_id = ?", org1.ID).Count(&count).Error
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	err = db.AddMembers(ctx, org1.ID, nil)
+	require.NoError(t, err)
+}
+
+func orgsAddMemberToTeam(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	teamsStore := NewTeamsStore(db.DB)
+	org1, _ := createTeamsTestOrg(t, &teams{DB: db.DB}, "org1")
+	org2, _ := createTeamsTestOrg(t, &teams{DB: db.DB}, "org2")
+	team1, err := teamsStore.Create(ctx, org1.ID, "team1", CreateTeamOptions{Authorize: AccessModeWrite})
+	require.NoError(t, err)
+	team2, err := teamsStore.Create(ctx, org2.ID, "team2", CreateTeamOptions{})
+	require.NoError(t, err)
+
+	repo1, err := NewReposStore(db.DB).Create(ctx, org1.ID, CreateRepoOptions{Name: "repo1", Private: true})
+	require.NoError(t, err)
+	err = db.DB.Create(&TeamRepo{OrgID: org1.ID, TeamID: team1.ID, RepoID: repo1.ID}).Error
+	require.NoError(t, err)
+
+	t.Run("team does not exist", func(t *testing.T) {
+		err := db.AddMemberToTeam(ctx, org1.ID, alice.ID, 404)
+		wantErr := ErrTeamNotExist{args: errutil.Args{"teamID": int64(404)}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	t.Run("team of another organization", func(t *testing.T) {
+		err := db.AddMemberToTeam(ctx, org1.ID, alice.ID, team2.ID)
+		assert.True(t, IsErrTeamOrgMismatch(err))
+
+		// Nothing should be changed
+		assert.False(t, db.HasMember(ctx, org1.ID, alice.ID))
+		assert.False(t, db.HasMember(ctx, org2.ID, alice.ID))
+	})
+
+	err = db.AddMemberToTeam(ctx, org1.ID, alice.ID, team1.ID)
+	require.NoError(t, err)
+
+	// Adding the same member again should be a no-op
+	err = db.AddMemberToTeam(ctx, org1.ID, alice.ID, team1.ID)
+	require.NoError(t, err)
+
+	assert.True(t, db.HasMember(ctx, org1.ID, alice.ID))
+	assert.True(t, teamsStore.IsTeamMember(ctx, team1.ID, alice.ID))
+
+	org1, err = usersStore.GetByID(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, org1.NumMembers)
+	err = db.First(team1, team1.ID).Error
+	require.NoError(t, err)
+	assert.Equal(t, 1, team1.NumMembers)
+
+	access := new(Access)
+	err = db.Where("user_id = ? AND repo_id = ?", alice.ID, repo1.ID).First(access).Error
+	require.NoError(t, err)
+	assert.Equal(t, AccessModeWrite, access.Mode)
+}
+
+func orgsRemoveMember(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+
+	err = db.AddMembers(ctx, org1.ID, []int64{alice.ID, bob.ID})
+	require.NoError(t, err)
+
+	createTestTeam(t, db.DB, &Team{
+		OrgID:      org1.ID,
+		LowerName:  "owners",
+		Name:       OWNER_TEAM,
+		Authorize:  AccessModeOwner,
+		NumMembers: 1,
+	}, []int64{alice.ID}, nil)
+	err = db.Exec(`UPDATE org_user SET is_owner = ? WHERE uid = ? AND org_id = ?`, true, alice.ID, org1.ID).Error
+	require.NoError(t, err)
+
+	var teams []*Team
+	for _, name := range []string{"team1", "team2"} {
+		team := createTestTeam(t, db.DB, &Team{
+			OrgID:      org1.ID,
+			LowerName:  name,
+			Name:       name,
+			Authorize:  AccessModeWrite,
+			NumMembers: 1,
+		}, []int64{bob.ID}, nil)
+		teams = append(teams, team)
+	}
+	err = db.Exec(`UPDATE org_user SET num_teams = ? WHERE uid = ? AND org_id = ?`, len(teams), bob.ID, org1.ID).Error
+	require.NoError(t, err)
+
+	t.Run("last owner", func(t *testing.T) {
+		err := db.RemoveMember(ctx, org1.ID, alice.ID)
+		assert.Equal(t, ErrLastOrgOwner{UID: alice.ID}, err)
+	})
+
+	err = db.RemoveMember(ctx, org1.ID, bob.ID)
+	require.NoError(t, err)
+
+	for _, team := range teams {
+		err = db.First(team, team.ID).Error
+		require.NoError(t, err)
+		assert.Equal(t, 0, team.NumMembers)
+	}
+
+	var count int64
+	err = db.Model(&TeamUser{}).Where("uid = ?", bob.ID).Count(&count).Error
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+	err = db.Model(&OrgUser{}).Where("uid = ?", bob.ID).Count(&count).Error
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	org1, err = usersStore.GetByID(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, org1.NumMembers)
+
+	// Removing a non-member should be a no-op
+	err = db.RemoveMember(ctx, org1.ID, bob.ID)
+	require.NoError(t, err)
+}
+
+func orgsEnsureOwnersTeam(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	t.Run("organization does not exist", func(t *testing.T) {
+		_, err := db.EnsureOwnersTeam(ctx, 404)
+		wantErr := ErrOrgNotExist{args: errutil.Args{"orgID": int64(404)}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	// Create a legacy organization without the Owners team.
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+
+	err = db.AddMembers(ctx, org1.ID, []int64{alice.ID, bob.ID})
+	require.NoError(t, err)
+	err = db.Exec(`UPDATE org_user SET is_owner = ? WHERE uid = ? AND org_id = ?`, true, alice.ID, org1.ID).Error
+	require.NoError(t, err)
+
+	reposStore := NewReposStore(db.DB)
+	repo1, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo1"})
+	require.NoError(t, err)
+	// Soft-deleted repositories should not be added to the team
+	repo2, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo2"})
+	require.NoError(t, err)
+	err = reposStore.SoftDelete(ctx, repo2.ID)
+	require.NoError(t, err)
+
+	ownersTeam, err := db.EnsureOwnersTeam(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.True(t, ownersTeam.IsOwnerTeam())
+	assert.Equal(t, AccessModeOwner, ownersTeam.Authorize)
+	assert.Equal(t, 1, ownersTeam.NumMembers)
+	assert.Equal(t, 1, ownersTeam.NumRepos)
+
+	teamsStore := NewTeamsStore(db.DB)
+	assert.True(t, teamsStore.IsTeamMember(ctx, ownersTeam.ID, alice.ID))
+	assert.False(t, teamsStore.IsTeamMember(ctx, ownersTeam.ID, bob.ID))
+
+	access := new(Access)
+	err = db.Where("user_id = ? AND repo_id = ?", alice.ID, repo1.ID).First(access).Error
+	require.NoError(t, err)
+	assert.Equal(t, AccessModeOwner, access.Mode)
+
+	gotOrg, err := usersStore.GetByID(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, gotOrg.NumTeams)
+
+	// Should return the existing team
+	got, err := db.EnsureOwnersTeam(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ownersTeam.ID, got.ID)
+
+	gotOrg, err = usersStore.GetByID(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, gotOrg.NumTeams)
+
+	// The last owner should still be protected
+	err = db.RemoveMember(ctx, org1.ID, alice.ID)
+	assert.Equal(t, ErrLastOrgOwner{UID: alice.ID}, err)
+}
+
+func orgsInvitations(t *testing.T, db *orgs) {
+	ctx := context.Background()
+	conf.SetMockUser(t, conf.UserOpts{OrgInvitationExpiry: time.Hour})
+
+	t.Run("organization does not exist", func(t *testing.T) {
+		_, err := db.InviteByEmail(ctx, 404, "alice@example.com", 0)
+		wantErr := ErrOrgNotExist{args: errutil.Args{"orgID": int64(404)}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+	org2 := createTestOrg(t, db.DB, "org2", CreateUserOptions{})
+
+	teamsStore := NewTeamsStore(db.DB)
+	team2, err := teamsStore.Create(ctx, org2.ID, "team2", CreateTeamOptions{})
+	require.NoError(t, err)
+
+	t.Run("team does not exist", func(t *testing.T) {
+		_, err := db.InviteByEmail(ctx, org1.ID, "alice@example.com", 404)
+		assert.True(t, IsErrTeamNotExist(err))
+	})
+
+	t.Run("team of another organization", func(t *testing.T) {
+		_, err := db.InviteByEmail(ctx, org1.ID, "alice@example.com", team2.ID)
+		assert.True(t, IsErrTeamOrgMismatch(err))
+	})
+
+	invitation1, err := db.InviteByEmail(ctx, org1.ID, " Alice@example.com ", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", invitation1.Email)
+	assert.Len(t, invitation1.Token, 40)
+	assert.Equal(t, invitation1.CreatedUnix+int64(time.Hour.Seconds()), invitation1.ExpiresUnix)
+
+	invitation2, err := db.InviteByEmail(ctx, org1.ID, "bob@example.com", 0)
+	require.NoError(t, err)
+
+	// Inviting the same email again should replace the pending invitation
+	invitation3, err := db.InviteByEmail(ctx, org1.ID, "alice@example.com", 0)
+	require.NoError(t, err)
+	assert.NotEqual(t, invitation1.Token, invitation3.Token)
+
+	// Expired invitations should not be listed
+	err = db.DB.Create(
+		&OrgInvitation{
+			OrgID:       org1.ID,
+			Email:       "cindy@example.com",
+			Token:       "expired",
+			ExpiresUnix: db.NowFunc().Add(-time.Minute).Unix(),
+		},
+	).Error
+	require.NoError(t, err)
+
+	got, err := db.ListInvitations(ctx, org1.ID)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, invitation2.ID, got[0].ID)
+	assert.Equal(t, invitation3.ID, got[1].ID)
+
+	// Should not be able to revoke an invitation of another organization
+	err = db.RevokeInvitation(ctx, org2.ID, invitation2.ID)
+	assert.True(t, IsErrOrgInvitationNotExist(err))
+
+	err = db.RevokeInvitation(ctx, org1.ID, invitation2.ID)
+	require.NoError(t, err)
+	got, err = db.ListInvitations(ctx, org1.ID)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, invitation3.ID, got[0].ID)
+}
+
+func orgsConsumeInvitations(t *testing.T, db *orgs) {
+	ctx := context.Background()
+	conf.SetMockUser(t, conf.UserOpts{OrgInvitationExpiry: time.Hour})
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{Activated: true})
+	require.NoError(t, err)
+	err = usersStore.AddEmail(ctx, alice.ID, "alice2@example.com", true)
+	require.NoError(t, err)
+	err = usersStore.AddEmail(ctx, alice.ID, "alice3@example.com", false)
+	require.NoError(t, err)
+
+	var orgIDs []int64
+	for _, name := range []string{"org1", "org2", "org3", "org4"} {
+		org := createTestOrg(t, db.DB, name, CreateUserOptions{})
+		orgIDs = append(orgIDs, org.ID)
+	}
+
+	team1, err := NewTeamsStore(db.DB).Create(ctx, orgIDs[1], "team1", CreateTeamOptions{})
+	require.NoError(t, err)
+
+	_, err = db.InviteByEmail(ctx, orgIDs[0], "alice@example.com", 0)
+	require.NoError(t, err)
+	_, err = db.InviteByEmail(ctx, orgIDs[1], "alice2@example.com", team1.ID)
+	require.NoError(t, err)
+	// Invitations of unverified email addresses should not be consumed
+	_, err = db.InviteByEmail(ctx, orgIDs[2], "alice3@example.com", 0)
+	require.NoError(t, err)
+	// Expired invitations should not be consumed
+	err = db.DB.Create(
+		&OrgInvitation{
+			OrgID:       orgIDs[3],
+			Email:       "alice@example.com",
+			Token:       "expired",
+			ExpiresUnix: db.NowFunc().Add(-time.Minute).Unix(),
+		},
+	).Error
+	require.NoError(t, err)
+
+	err = db.ConsumeInvitations(ctx, alice.ID)
+	require.NoError(t, err)
+
+	// The primary email is not verified when email confirmation is not required
+	assert.False(t, db.HasMember(ctx, orgIDs[0], alice.ID))
+	assert.True(t, db.HasMember(ctx, orgIDs[1], alice.ID))
+
+	conf.SetMockAuth(t, conf.AuthOpts{RequireEmailConfirmation: true})
+	err = db.ConsumeInvitations(ctx, alice.ID)
+	require.NoError(t, err)
+
+	assert.True(t, db.HasMember(ctx, orgIDs[0], alice.ID))
+	assert.True(t, NewTeamsStore(db.DB).IsTeamMember(ctx, team1.ID, alice.ID))
+	assert.False(t, db.HasMember(ctx, orgIDs[2], alice.ID))
+	assert.False(t, db.HasMember(ctx, orgIDs[3], alice.ID))
+
+	var emails []string
+	err = db.Model(&OrgInvitation{}).Order("email").Pluck("email", &emails).Error
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice3@example.com"}, emails)
+}
+
+func orgsAcceptInvitation(t *testing.T, db *orgs) {
+	ctx := context.Background()
+	conf.SetMockUser(t, conf.UserOpts{OrgInvitationExpiry: time.Hour})
+
+	alice, err := NewUsersStore(db.DB).Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+	team1, err := NewTeamsStore(db.DB).Create(ctx, org1.ID, "team1", CreateTeamOptions{})
+	require.NoError(t, err)
+
+	t.Run("invitation does not exist", func(t *testing.T) {
+		_, err := db.AcceptInvitation(ctx, alice.ID, "404")
+		wantErr := ErrOrgInvitationNotExist{args: errutil.Args{"token": "404"}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	t.Run("invitation has expired", func(t *testing.T) {
+		err := db.DB.Create(
+			&OrgInvitation{
+				OrgID:       org1.ID,
+				Email:       "bob@example.com",
+				Token:       "expired",
+				ExpiresUnix: db.NowFunc().Add(-time.Minute).Unix(),
+			},
+		).Error
+		require.NoError(t, err)
+
+		_, err = db.AcceptInvitation(ctx, alice.ID, "expired")
+		assert.True(t, IsErrOrgInvitationNotExist(err))
+		assert.False(t, db.HasMember(ctx, org1.ID, alice.ID))
+	})
+
+	// The invited email does not have to be an email of the user, the token is
+	// only delivered to the invited email address.
+	invitation, err := db.InviteByEmail(ctx, org1.ID, "alice@work.example.com", team1.ID)
+	require.NoError(t, err)
+
+	got, err := db.AcceptInvitation(ctx, alice.ID, invitation.Token)
+	require.NoError(t, err)
+	assert.Equal(t, invitation.ID, got.ID)
+	assert.True(t, db.HasMember(ctx, org1.ID, alice.ID))
+	assert.True(t, NewTeamsStore(db.DB).IsTeamMember(ctx, team1.ID, alice.ID))
+
+	// The invitation should be consumed
+	_, err = db.AcceptInvitation(ctx, alice.ID, invitation.Token)
+	assert.True(t, IsErrOrgInvitationNotExist(err))
+}
+
+func orgsGetMembership(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	err := db.Exec(`INSERT INTO org_user (uid, org_id, is_owner, num_teams) VALUES (?, ?, ?, ?)`, 1, 3, true, 2).Error
+	require.NoError(t, err)
+	err = db.Exec(`INSERT INTO org_user (uid, org_id, is_owner) VALUES (?, ?, ?)`, 2, 3, false).Error
+	require.NoError(t, err)
+
+	t.Run("not a member", func(t *testing.T) {
+		_, err := db.GetMembership(ctx, 3, 404)
+		wantErr := ErrNotOrgMember{
+			args: errutil.Args{
+				"orgID":  int64(3),
+				"userID": int64(404),
+			},
+		}
+		assert.Equal(t, wantErr, err)
+		assert.False(t, db.HasMember(ctx, 3, 404))
+		assert.False(t, db.IsOwnedBy(ctx, 3, 404))
+	})
+
+	got, err := db.GetMembership(ctx, 3, 1)
+	require.NoError(t, err)
+	assert.True(t, got.IsOwner)
+	assert.Equal(t, 2, got.NumTeams)
+	assert.True(t, db.HasMember(ctx, 3, 1))
+	assert.True(t, db.IsOwnedBy(ctx, 3, 1))
+
+	assert.True(t, db.HasMember(ctx, 3, 2))
+	assert.False(t, db.IsOwnedBy(ctx, 3, 2))
+}
+
+func orgsFilterMembers(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	err := db.Exec(`INSERT INTO org_user (uid, org_id) VALUES (?, ?)`, 1, 3).Error
+	require.NoError(t, err)
+	err = db.Exec(`INSERT INTO org_user (uid, org_id) VALUES (?, ?)`, 2, 3).Error
+	require.NoError(t, err)
+	err = db.Exec(`INSERT INTO org_user (uid, org_id) VALUES (?, ?)`, 3, 4).Error
+	require.NoError(t, err)
+
+	got, err := db.FilterMembers(ctx, 3, []int64{1, 2, 3, 404})
+	require.NoError(t, err)
+	want := map[int64]bool{
+		1:   true,
+		2:   true,
+		3:   false,
+		404: false,
+	}
+	assert.Equal(t, want, got)
+
+	got, err = db.FilterMembers(ctx, 3, nil)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func orgsLeaveOrg(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	cindy, err := usersStore.Create(ctx, "cindy", "cindy@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+	err = db.AddMembers(ctx, org1.ID, []int64{alice.ID, bob.ID})
+	require.NoError(t, err)
+
+	createTestTeam(t, db.DB, &Team{
+		OrgID:      org1.ID,
+		LowerName:  "owners",
+		Name:       OWNER_TEAM,
+		Authorize:  AccessModeOwner,
+		NumMembers: 1,
+	}, []int64{alice.ID}, nil)
+	err = db.Exec(`UPDATE org_user SET is_owner = ? WHERE uid = ? AND org_id = ?`, true, alice.ID, org1.ID).Error
+	require.NoError(t, err)
+
+	t.Run("not a member", func(t *testing.T) {
+		err := db.LeaveOrg(ctx, org1.ID, cindy.ID)
+		wantErr := ErrNotOrgMember{
+			args: errutil.Args{
+				"orgID":  org1.ID,
+				"userID": cindy.ID,
+			},
+		}
+		assert.Equal(t, wantErr, err)
+	})
+
+	t.Run("last owner", func(t *testing.T) {
+		err := db.LeaveOrg(ctx, org1.ID, alice.ID)
+		assert.True(t, IsErrLastOrgOwner(err))
+	})
+
+	err = db.LeaveOrg(ctx, org1.ID, bob.ID)
+	require.NoError(t, err)
+
+	got, err := db.CountMembers(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), got)
+}
+
+func orgsSetOwner(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	cindy, err := usersStore.Create(ctx, "cindy", "cindy@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+	err = db.AddMembers(ctx, org1.ID, []int64{alice.ID, bob.ID})
+	require.NoError(t, err)
+
+	ownersTeam := createTestTeam(t, db.DB, &Team{
+		OrgID:      org1.ID,
+		LowerName:  "owners",
+		Name:       OWNER_TEAM,
+		Authorize:  AccessModeOwner,
+		NumMembers: 1,
+	}, []int64{alice.ID}, nil)
+	err = db.Exec(`UPDATE org_user SET is_owner = ? WHERE uid = ? AND org_id = ?`, true, alice.ID, org1.ID).Error
+	require.NoError(t, err)
+
+	t.Run("not a member", func(t *testing.T) {
+		err := db.SetOwner(ctx, org1.ID, cindy.ID, true)
+		wantErr := ErrNotOrgMember{
+			args: errutil.Args{
+				"orgID":  org1.ID,
+				"userID": cindy.ID,
+			},
+		}
+		assert.Equal(t, wantErr, err)
+	})
+
+	t.Run("last owner", func(t *testing.T) {
+		err := db.SetOwner(ctx, org1.ID, alice.ID, false)
+		assert.Equal(t, ErrLastOrgOwner{UID: alice.ID}, err)
+		assert.True(t, db.IsOwnedBy(ctx, org1.ID, alice.ID))
+	})
+
+	assertOwners := func(t *testing.T, wantUserIDs ...int64) {
+		t.Helper()
+
+		var ownerIDs []int64
+		err := db.Model(&OrgUser{}).Where("org_id = ? AND is_owner = ?", org1.ID, true).Order("uid").Pluck("uid", &ownerIDs).Error
+		require.NoError(t, err)
+		assert.Equal(t, wantUserIDs, ownerIDs)
+
+		var memberIDs []int64
+		err = db.Model(&TeamUser{}).Where("team_id = ?", ownersTeam.ID).Order("uid").Pluck("uid", &memberIDs).Error
+		require.NoError(t, err)
+		assert.Equal(t, wantUserIDs, memberIDs)
+
+		team := new(Team)
+		err = db.First(team, ownersTeam.ID).Error
+		require.NoError(t, err)
+		assert.Equal(t, len(wantUserIDs), team.NumMembers)
+	}
+
+	err = db.SetOwner(ctx, org1.ID, bob.ID, true)
+	require.NoError(t, err)
+	assertOwners(t, alice.ID, bob.ID)
+
+	// Promoting an existing owner should be a no-op
+	err = db.SetOwner(ctx, org1.ID, bob.ID, true)
+	require.NoError(t, err)
+	assertOwners(t, alice.ID, bob.ID)
+
+	err = db.SetOwner(ctx, org1.ID, alice.ID, false)
+	require.NoError(t, err)
+	assertOwners(t, bob.ID)
+	assert.True(t, db.HasMember(ctx, org1.ID, alice.ID))
+}
+
+func orgsCountMembers(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+
+	got, err := db.CountMembers(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), got)
+
+	err = db.AddMembers(ctx, org1.ID, []int64{alice.ID, bob.ID})
+	require.NoError(t, err)
+
+	got, err = db.CountMembers(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), got)
+}
+
+func orgsRecountAll(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+
+	team1 := createTestTeam(t, db.DB, &Team{OrgID: org1.ID, Name: "team1", NumMembers: 99}, []int64{alice.ID}, nil)
+	team2 := createTestTeam(t, db.DB, &Team{OrgID: org1.ID, Name: "team2", NumMembers: 99}, []int64{alice.ID, bob.ID}, nil)
+
+	err = db.Exec(`INSERT INTO org_user (uid, org_id, num_teams) VALUES (?, ?, 99), (?, ?, 99)`, alice.ID, org1.ID, bob.ID, org1.ID).Error
+	require.NoError(t, err)
+	err = db.Exec(dbutil.Quote("UPDATE %s SET num_members = 99 WHERE id = ?", "user"), org1.ID).Error
+	require.NoError(t, err)
+
+	err = db.RecountAll(ctx, org1.ID)
+	require.NoError(t, err)
+
+	org1, err = usersStore.GetByID(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, org1.NumMembers)
+
+	err = db.First(team1, team1.ID).Error
+	require.NoError(t, err)
+	assert.Equal(t, 1, team1.NumMembers)
+	err = db.First(team2, team2.ID).Error
+	require.NoError(t, err)
+	assert.Equal(t, 2, team2.NumMembers)
+
+	for userID, want := range map[int64]int{alice.ID: 2, bob.ID: 1} {
+		orgUser, err := db.GetMembership(ctx, org1.ID, userID)
+		require.NoError(t, err)
+		assert.Equal(t, want, orgUser.NumTeams)
+	}
+}
+
+func orgsDeduplicateMembers(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+
+	t.Run("no duplicates", func(t *testing.T) {
+		err := db.AddMembers(ctx, org1.ID, []int64{alice.ID, bob.ID})
+		require.NoError(t, err)
+
+		removed, err := db.DeduplicateMembers(ctx, org1.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), removed)
+	})
+
+	// Simulate duplicated memberships created before the unique index.
+	err = db.Migrator().DropIndex(&OrgUser{}, "org_user_user_org_unique")
+	require.NoError(t, err)
+	err = db.DB.Create(
+		[]*OrgUser{
+			{Uid: alice.ID, OrgID: org1.ID, IsPublic: true},
+			{Uid: alice.ID, OrgID: org1.ID},
+			{Uid: bob.ID, OrgID: org1.ID, IsPublic: true},
+		},
+	).Error
+	require.NoError(t, err)
+	err = db.Exec(dbutil.Quote("UPDATE %s SET num_members = 5 WHERE id = ?", "user"), org1.ID).Error
+	require.NoError(t, err)
+
+	removed, err := db.DeduplicateMembers(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), removed)
+
+	// The oldest memberships are kept.
+	var orgUsers []*OrgUser
+	err = db.Where("org_id = ?", org1.ID).Order("uid").Find(&orgUsers).Error
+	require.NoError(t, err)
+	require.Len(t, orgUsers, 2)
+	assert.False(t, orgUsers[0].IsPublic)
+	assert.False(t, orgUsers[1].IsPublic)
+
+	org1, err = usersStore.GetByID(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, org1.NumMembers)
+
+	// Running again should be a no-op.
+	removed, err = db.DeduplicateMembers(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), removed)
+
+	err = db.Migrator().CreateIndex(&OrgUser{}, "org_user_user_org_unique")
+	require.NoError(t, err)
+}
+
+func orgsListMembers(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	cindy, err := usersStore.Create(ctx, "cindy", "cindy@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+
+	err = db.AddMembers(ctx, org1.ID, []int64{cindy.ID, alice.ID, bob.ID})
+	require.NoError(t, err)
+	err = db.SetMemberVisibility(ctx, org1.ID, bob.ID, true)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		opts      ListOrgMembersOptions
+		wantNames []string
+		wantCount int64
+	}{
+		{
+			name:      "all members",
+			opts:      ListOrgMembersOptions{},
+			wantNames: []string{alice.Name, bob.Name, cindy.Name},
+			wantCount: 3,
+		},
+		{
+			name:      "limit",
+			opts:      ListOrgMembersOptions{Limit: 2},
+			wantNames: []string{alice.Name, bob.Name},
+			wantCount: 3,
+		},
+		{
+			name:      "first page",
+			opts:      ListOrgMembersOptions{Page: 1, PageSize: 2},
+			wantNames: []string{alice.Name, bob.Name},
+			wantCount: 3,
+		},
+		{
+			name:      "second page",
+			opts:      ListOrgMembersOptions{Page: 2, PageSize: 2},
+			wantNames: []string{cindy.Name},
+			wantCount: 3,
+		},
+		{
+			name:      "page takes precedence over limit",
+			opts:      ListOrgMembersOptions{Limit: 1, Page: 2, PageSize: 2},
+			wantNames: []string{cindy.Name},
+			wantCount: 3,
+		},
+		{
+			name:      "public only",
+			opts:      ListOrgMembersOptions{PublicOnly: true},
+			wantNames: []string{bob.Name},
+			wantCount: 1,
+		},
+		{
+			name:      "join order",
+			opts:      ListOrgMembersOptions{OrderBy: "org_user.id"},
+			wantNames: []string{cindy.Name, alice.Name, bob.Name},
+			wantCount: 3,
+		},
+		{
+			name:      "reverse join order with page",
+			opts:      ListOrgMembersOptions{OrderBy: "ORG_USER.ID desc", Page: 1, PageSize: 2},
+			wantNames: []string{bob.Name, alice.Name},
+			wantCount: 3,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, count, err := db.ListMembers(ctx, org1.ID, test.opts)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantCount, count)
+
+			gotNames := make([]string, len(got))
+			for i := range got {
+				gotNames[i] = got[i].Name
+			}
+			assert.Equal(t, test.wantNames, gotNames)
+		})
+	}
+
+	t.Run("returned rows are users", func(t *testing.T) {
+		got, _, err := db.ListMembers(ctx, org1.ID, ListOrgMembersOptions{OrderBy: "org_user.id", Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, cindy.ID, got[0].ID)
+	})
+
+	t.Run("invalid order", func(t *testing.T) {
+		for _, orderBy := range []string{"lower_name", "org_user.id sideways", "org_user.id ASC; DROP TABLE user"} {
+			_, _, err := db.ListMembers(ctx, org1.ID, ListOrgMembersOptions{OrderBy: orderBy})
+			assert.Error(t, err, orderBy)
+		}
+	})
+}
+
+func orgsListActiveMembers(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	cindy, err := usersStore.Create(ctx, "cindy", "cindy@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+
+	err = db.AddMembers(ctx, org1.ID, []int64{alice.ID, bob.ID})
+	require.NoError(t, err)
+
+	for id, lastLoginUnix := range map[int64]int64{alice.ID: 100, bob.ID: 200, cindy.ID: 300} {
+		err = db.Exec(dbutil.Quote("UPDATE %s SET last_login_unix = ?, updated_unix = ? WHERE id = ?", "user"), lastLoginUnix, 1000, id).Error
+		require.NoError(t, err)
+	}
+
+	memberNames := func(members []*User) []string {
+		names := make([]string, 0, len(members))
+		for _, m := range members {
+			names = append(names, m.Name)
+		}
+		return names
+	}
+
+	got, err := db.ListActiveMembers(ctx, org1.ID, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []string{alice.Name, bob.Name}, memberNames(got))
+	assert.Equal(t, alice.ID, got[0].ID)
+
+	// Non-members are never included even when active
+	got, err = db.ListActiveMembers(ctx, org1.ID, 150)
+	require.NoError(t, err)
+	assert.Equal(t, []string{bob.Name}, memberNames(got))
+
+	got, err = db.ListActiveMembers(ctx, org1.ID, 250)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func orgsSearchMembers(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{FullName: "Alice Smith"})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{FullName: "Bob Smith"})
+	require.NoError(t, err)
+	cindy, err := usersStore.Create(ctx, "cindy", "cindy@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	_, err = usersStore.Create(ctx, "smith", "smith@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+
+	err = db.AddMembers(ctx, org1.ID, []int64{cindy.ID, alice.ID, bob.ID})
+	require.NoError(t, err)
+	err = db.SetMemberVisibility(ctx, org1.ID, bob.ID, true)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		keyword   string
+		page      int
+		pageSize  int
+		opts      SearchOrgMembersOptions
+		wantNames []string
+		wantCount int64
+	}{
+		{
+			name:      "empty keyword",
+			page:      1,
+			pageSize:  10,
+			wantNames: []string{alice.Name, bob.Name, cindy.Name},
+			wantCount: 3,
+		},
+		{
+			name:      "match full name case-insensitively",
+			keyword:   "SMITH",
+			page:      1,
+			pageSize:  10,
+			wantNames: []string{alice.Name, bob.Name},
+			wantCount: 2,
+		},
+		{
+			name:      "match username",
+			keyword:   "ind",
+			page:      1,
+			pageSize:  10,
+			wantNames: []string{cindy.Name},
+			wantCount: 1,
+		},
+		{
+			name:      "paginated",
+			keyword:   "smith",
+			page:      2,
+			pageSize:  1,
+			wantNames: []string{bob.Name},
+			wantCount: 2,
+		},
+		{
+			name:      "public only",
+			keyword:   "smith",
+			page:      1,
+			pageSize:  10,
+			opts:      SearchOrgMembersOptions{PublicOnly: true},
+			wantNames: []string{bob.Name},
+			wantCount: 1,
+		},
+		{
+			name:      "escape wildcards",
+			keyword:   "%",
+			page:      1,
+			pageSize:  10,
+			wantNames: []string{},
+			wantCount: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, count, err := db.SearchMembers(ctx, org1.ID, test.keyword, test.page, test.pageSize, test.opts)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantCount, count)
+
+			gotNames := make([]string, len(got))
+			for i := range got {
+				gotNames[i] = got[i].Name
+			}
+			assert.Equal(t, test.wantNames, gotNames)
+		})
+	}
+}
+
+func orgsListOrgMembersWithRole(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{FullName: "Alice"})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+
+	err = db.Exec(
+		`INSERT INTO org_user (uid, org_id, is_owner, is_public, num_teams) VALUES (?, ?, ?, ?, ?), (?, ?, ?, ?, ?)`,
+		alice.ID, org1.ID, true, false, 2,
+		bob.ID, org1.ID, false, true, 0,
+	).Error
+	require.NoError(t, err)
+
+	got, err := db.ListOrgMembersWithRole(ctx, org1.ID, ListOrgMembersOptions{})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	assert.Equal(t, alice.ID, got[0].User.ID)
+	assert.Equal(t, "Alice", got[0].User.FullName)
+	assert.False(t, got[0].User.Created.IsZero())
+	assert.True(t, got[0].IsOwner)
+	assert.False(t, got[0].IsPublic)
+	assert.Equal(t, 2, got[0].NumTeams)
+
+	assert.Equal(t, bob.ID, got[1].User.ID)
+	assert.False(t, got[1].IsOwner)
+	assert.True(t, got[1].IsPublic)
+	assert.Equal(t, 0, got[1].NumTeams)
+
+	got, err = db.ListOrgMembersWithRole(ctx, org1.ID, ListOrgMembersOptions{PublicOnly: true})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, bob.ID, got[0].User.ID)
+
+	got, err = db.ListOrgMembersWithRole(ctx, org1.ID, ListOrgMembersOptions{OrderBy: "org_user.id DESC"})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, bob.ID, got[0].User.ID)
+	assert.Equal(t, alice.ID, got[1].User.ID)
+}
+
+func orgsStreamMembers(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{FullName: "Alice"})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+
+	// Insert memberships in reverse order to make sure results are sorted by
+	// user ID.
+	err = db.Exec(
+		`INSERT INTO org_user (uid, org_id, is_owner, is_public, num_teams) VALUES (?, ?, ?, ?, ?), (?, ?, ?, ?, ?)`,
+		bob.ID, org1.ID, false, true, 0,
+		alice.ID, org1.ID, true, false, 2,
+	).Error
+	require.NoError(t, err)
+
+	var got []*OrgMemberWithRole
+	err = db.StreamMembers(ctx, org1.ID, func(m *OrgMemberWithRole) error {
+		got = append(got, m)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	assert.Equal(t, alice.ID, got[0].User.ID)
+	assert.Equal(t, "Alice", got[0].User.FullName)
+	assert.False(t, got[0].User.Created.IsZero())
+	assert.True(t, got[0].IsOwner)
+	assert.False(t, got[0].IsPublic)
+	assert.Equal(t, 2, got[0].NumTeams)
+
+	assert.Equal(t, bob.ID, got[1].User.ID)
+	assert.False(t, got[1].IsOwner)
+	assert.True(t, got[1].IsPublic)
+
+	t.Run("stop at the first error", func(t *testing.T) {
+		wantErr := errors.New("stop")
+		var calls int
+		err := db.StreamMembers(ctx, org1.ID, func(*OrgMemberWithRole) error {
+			calls++
+			return wantErr
+		})
+		assert.Equal(t, wantErr, err)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func orgsGetTeamsByUser(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+	org2 := createTestOrg(t, db.DB, "org2", CreateUserOptions{})
+
+	got, err := db.GetTeamsByUser(ctx, org1.ID, alice.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+	assert.Empty(t, got)
+
+	addTeamMember := func(orgID int64, name string) *Team {
+		return createTestTeam(t, db.DB, &Team{OrgID: orgID, Name: name, Authorize: AccessModeRead}, []int64{alice.ID}, nil)
+	}
+	team1 := addTeamMember(org1.ID, "team1")
+	_ = addTeamMember(org2.ID, "team2")
+	team3 := addTeamMember(org1.ID, "team3")
+
+	got, err = db.GetTeamsByUser(ctx, org1.ID, alice.ID)
+	require.NoError(t, err)
+	gotIDs := make([]int64, len(got))
+	for i := range got {
+		gotIDs[i] = got[i].ID
+	}
+	assert.Equal(t, []int64{team1.ID, team3.ID}, gotIDs)
+}
+
+func orgsSetDefaultRepoPermission(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+
+	reposStore := NewReposStore(db.DB)
+	publicRepo, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "public"})
+	require.NoError(t, err)
+	privateRepo, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "private", Private: true})
+	require.NoError(t, err)
+
+	t.Run("invalid permission", func(t *testing.T) {
+		err := db.SetDefaultRepoPermission(ctx, org1.ID, AccessModeAdmin)
+		assert.Error(t, err)
+	})
+
+	err = db.AddMember(ctx, org1.ID, alice.ID)
+	require.NoError(t, err)
+
+	err = db.SetDefaultRepoPermission(ctx, org1.ID, AccessModeWrite)
+	require.NoError(t, err)
+
+	org1, err = usersStore.GetByID(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, AccessModeWrite, org1.DefaultRepoPermission)
+
+	// New members should inherit the default permission on public repositories
+	err = db.AddMember(ctx, org1.ID, bob.ID)
+	require.NoError(t, err)
+
+	permsStore := NewPermsStore(db.DB)
+	for _, userID := range []int64{alice.ID, bob.ID} {
+		mode := permsStore.AccessMode(ctx, userID, publicRepo.ID, AccessModeOptions{OwnerID: org1.ID})
+		assert.Equal(t, AccessModeWrite, mode)
+
+		mode = permsStore.AccessMode(ctx, userID, privateRepo.ID, AccessModeOptions{OwnerID: org1.ID, Private: true})
+		assert.Equal(t, AccessModeNone, mode)
+	}
+
+	err = db.SetDefaultRepoPermission(ctx, org1.ID, AccessModeNone)
+	require.NoError(t, err)
+
+	mode := permsStore.AccessMode(ctx, bob.ID, publicRepo.ID, AccessModeOptions{OwnerID: org1.ID})
+	assert.Equal(t, AccessModeRead, mode)
+}
+
+func orgsSetRequireTwoFactor(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+	err = db.Exec(`INSERT INTO org_user (uid, org_id, is_owner) VALUES (?, ?, ?), (?, ?, ?)`,
+		alice.ID, org1.ID, true,
+		bob.ID, org1.ID, false,
+	).Error
+	require.NoError(t, err)
+
+	t.Run("owners without two-factor", func(t *testing.T) {
+		err := db.SetRequireTwoFactor(ctx, org1.ID, true)
+		wantErr := ErrOrgOwnersWithoutTwoFactor{args: errutil.Args{"orgID": org1.ID, "userIDs": []int64{alice.ID}}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	// Regular members without two-factor authentication should not block it
+	err = db.DB.Create(&TwoFactor{UserID: alice.ID, Secret: "secret"}).Error
+	require.NoError(t, err)
+
+	err = db.SetRequireTwoFactor(ctx, org1.ID, true)
+	require.NoError(t, err)
+
+	org1, err = usersStore.GetByID(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.True(t, org1.RequireTwoFactor)
+
+	err = db.SetRequireTwoFactor(ctx, org1.ID, false)
+	require.NoError(t, err)
+
+	org1, err = usersStore.GetByID(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.False(t, org1.RequireTwoFactor)
+}
+
+func orgsSetVerified(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+
+	err = db.SetVerified(ctx, org1.ID, true)
+	require.NoError(t, err)
+
+	org1, err = usersStore.GetByID(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.True(t, org1.IsVerified)
+
+	err = db.SetVerified(ctx, org1.ID, false)
+	require.NoError(t, err)
+
+	org1, err = usersStore.GetByID(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.False(t, org1.IsVerified)
+
+	// Individual users should never be verified
+	err = db.SetVerified(ctx, alice.ID, true)
+	require.NoError(t, err)
+
+	alice, err = usersStore.GetByID(ctx, alice.ID)
+	require.NoError(t, err)
+	assert.False(t, alice.IsVerified)
+}
+
+func orgsSetArchived(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{FullName: "Acme Corp"})
+	org2 := createTestOrg(t, db.DB, "org2", CreateUserOptions{FullName: "Acme Corp 2"})
+
+	err = db.AddMember(ctx, org1.ID, alice.ID)
+	require.NoError(t, err)
+	err = db.AddMember(ctx, org2.ID, alice.ID)
+	require.NoError(t, err)
+
+	err = db.SetArchived(ctx, org1.ID, true)
+	require.NoError(t, err)
+
+	org1, err = usersStore.GetByID(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.True(t, org1.IsArchived)
+
+	listOrgIDs := func(opts ListOrgsOptions) []int64 {
+		orgs, err := db.List(ctx, opts)
+		require.NoError(t, err)
+		ids := make([]int64, 0, len(orgs))
+		for _, org := range orgs {
+			ids = append(ids, org.ID)
+		}
+		return ids
+	}
+	searchOrgIDs := func(includeArchived bool) []int64 {
+		orgs, count, err := db.SearchByName(ctx, "acme", 1, 10, "id ASC", includeArchived)
+		require.NoError(t, err)
+		require.Len(t, orgs, int(count))
+		ids := make([]int64, 0, len(orgs))
+		for _, org := range orgs {
+			ids = append(ids, org.ID)
+		}
+		return ids
+	}
+
+	// Archived organizations are excluded by default
+	assert.Equal(t, []int64{org2.ID}, listOrgIDs(ListOrgsOptions{MemberID: alice.ID, IncludePrivateMembers: true}))
+	assert.Equal(t, []int64{org1.ID, org2.ID}, listOrgIDs(ListOrgsOptions{MemberID: alice.ID, IncludePrivateMembers: true, IncludeArchived: true}))
+	assert.Equal(t, []int64{org2.ID}, searchOrgIDs(false))
+	assert.Equal(t, []int64{org1.ID, org2.ID}, searchOrgIDs(true))
+
+	orgs, _, err := db.SearchVisibleByName(ctx, "acme", 1, 10, "id ASC", alice.ID)
+	require.NoError(t, err)
+	require.Len(t, orgs, 1)
+	assert.Equal(t, org2.ID, orgs[0].ID)
+
+	// Unarchiving restores visibility
+	err = db.SetArchived(ctx, org1.ID, false)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{org1.ID, org2.ID}, listOrgIDs(ListOrgsOptions{MemberID: alice.ID, IncludePrivateMembers: true}))
+	assert.Equal(t, []int64{org1.ID, org2.ID}, searchOrgIDs(false))
+
+	// Individual users cannot be archived
+	err = db.SetArchived(ctx, alice.ID, true)
+	wantErr := ErrOrgNotExist{args: errutil.Args{"orgID": alice.ID}}
+	assert.Equal(t, wantErr, err)
+}
+
+func orgsSetRepoCreatePermission(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+	assert.Equal(t, RepoCreatePermissionMembers, org1.RepoCreatePermission)
+
+	t.Run("organization does not exist", func(t *testing.T) {
+		err := db.SetRepoCreatePermission(ctx, 404, RepoCreatePermissionOwners)
+		wantErr := ErrOrgNotExist{args: errutil.Args{"orgID": int64(404)}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	t.Run("invalid permission", func(t *testing.T) {
+		err := db.SetRepoCreatePermission(ctx, org1.ID, 404)
+		assert.Error(t, err)
+	})
+
+	err := db.SetRepoCreatePermission(ctx, org1.ID, RepoCreatePermissionOwners)
+	require.NoError(t, err)
+
+	got, err := NewUsersStore(db.DB).GetByID(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, RepoCreatePermissionOwners, got.RepoCreatePermission)
+}
+
+func orgsDefaultProtection(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+
+	team1 := createTestTeam(t, db.DB, &Team{
+		OrgID:     org1.ID,
+		LowerName: "team1",
+		Name:      "team1",
+		Authorize: AccessModeWrite,
+	}, []int64{bob.ID}, nil)
+
+	reposStore := NewReposStore(db.DB)
+	existingRepo, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "existing"})
+	require.NoError(t, err)
+
+	got, err := db.GetDefaultProtection(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	err = db.SetDefaultProtection(ctx, org1.ID,
+		[]*OrgProtectBranch{
+			{Name: "master", RequirePullRequest: true},
+			{Name: " "},
+			{
+				Name:             "develop",
+				EnableWhitelist:  true,
+				WhitelistUserIDs: strconv.FormatInt(alice.ID, 10),
+				WhitelistTeamIDs: strconv.FormatInt(team1.ID, 10),
+			},
+			{Name: "master"},
+		},
+	)
+	require.NoError(t, err)
+
+	got, err = db.GetDefaultProtection(ctx, org1.ID)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "develop", got[0].Name)
+	assert.Equal(t, "master", got[1].Name)
+	assert.True(t, got[1].RequirePullRequest)
+
+	listProtectBranches := func(t *testing.T, repoID int64) []*ProtectBranch {
+		t.Helper()
+
+		var protectBranches []*ProtectBranch
+		err := db.Where("repo_id = ?", repoID).Order("name").Find(&protectBranches).Error
+		require.NoError(t, err)
+		return protectBranches
+	}
+
+	// Templates should not affect existing repositories until applied explicitly
+	assert.Empty(t, listProtectBranches(t, existingRepo.ID))
+
+	newRepo, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "new"})
+	require.NoError(t, err)
+	err = db.ApplyDefaultProtection(ctx, org1.ID, newRepo.ID)
+	require.NoError(t, err)
+
+	protectBranches := listProtectBranches(t, newRepo.ID)
+	require.Len(t, protectBranches, 2)
+	assert.Equal(t, "develop", protectBranches[0].Name)
+	assert.True(t, protectBranches[0].Protected)
+	assert.True(t, protectBranches[0].EnableWhitelist)
+	assert.Equal(t, "master", protectBranches[1].Name)
+	assert.True(t, protectBranches[1].Protected)
+	assert.True(t, protectBranches[1].RequirePullRequest)
+
+	var whitelistUserIDs []int64
+	err = db.Model(&ProtectBranchWhitelist{}).
+		Where("protect_branch_id = ?", protectBranches[0].ID).
+		Order("user_id").
+		Pluck("user_id", &whitelistUserIDs).
+		Error
+	require.NoError(t, err)
+	assert.Equal(t, []int64{alice.ID, bob.ID}, whitelistUserIDs)
+
+	// Applying to all should overwrite existing protections of same branch names
+	err = db.DB.Create(&ProtectBranch{RepoID: existingRepo.ID, Name: "master", Protected: false}).Error
+	require.NoError(t, err)
+	err = db.ApplyDefaultProtectionToAll(ctx, org1.ID)
+	require.NoError(t, err)
+
+	protectBranches = listProtectBranches(t, existingRepo.ID)
+	require.Len(t, protectBranches, 2)
+	assert.True(t, protectBranches[1].Protected)
+	assert.True(t, protectBranches[1].RequirePullRequest)
+	assert.Len(t, listProtectBranches(t, newRepo.ID), 2)
+}
+
+func orgsDefaultLabels(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+
+	reposStore := NewReposStore(db.DB)
+	repo1, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo1"})
+	require.NoError(t, err)
+	repo2, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo2"})
+	require.NoError(t, err)
+
+	got, err := db.GetDefaultLabels(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	err = db.SetDefaultLabels(ctx, org1.ID,
+		[]*OrgLabel{
+			{Name: "enhancement", Color: "#84B6EB"},
+			{Name: " ", Color: "#000000"},
+			{Name: "bug", Color: "#ee0701"},
+			{Name: "invalid", Color: "e6e6e6"},
+			{Name: "bug", Color: "#000000"},
+		},
+	)
+	require.NoError(t, err)
+
+	got, err = db.GetDefaultLabels(ctx, org1.ID)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "bug", got[0].Name)
+	assert.Equal(t, "#ee0701", got[0].Color)
+	assert.Equal(t, "enhancement", got[1].Name)
+	assert.Equal(t, "#84b6eb", got[1].Color)
+
+	// Templates should not affect existing repositories until applied explicitly
+	var count int64
+	err = db.Model(&Label{}).Count(&count).Error
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	err = db.ApplyDefaultLabelsToAll(ctx, org1.ID)
+	require.NoError(t, err)
+
+	for _, repoID := range []int64{repo1.ID, repo2.ID} {
+		var names []string
+		err = db.Model(&Label{}).Where("repo_id = ?", repoID).Order("name").Pluck("name", &names).Error
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bug", "enhancement"}, names)
+	}
+}
+
+func orgsPinnedRepos(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+
+	reposStore := NewReposStore(db.DB)
+	var repoIDs []int64
+	for i := 1; i <= 7; i++ {
+		repo, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo" + strconv.Itoa(i)})
+		require.NoError(t, err)
+		repoIDs = append(repoIDs, repo.ID)
+	}
+	aliceRepo, err := reposStore.Create(ctx, alice.ID, CreateRepoOptions{Name: "alice-repo"})
+	require.NoError(t, err)
+
+	pinnedIDs := func(t *testing.T) []int64 {
+		t.Helper()
+
+		repos, err := db.ListPinnedRepos(ctx, org1.ID)
+		require.NoError(t, err)
+		ids := make([]int64, 0, len(repos))
+		for _, repo := range repos {
+			ids = append(ids, repo.ID)
+		}
+		return ids
+	}
+
+	t.Run("repository not owned by the organization", func(t *testing.T) {
+		err := db.SetPinnedRepos(ctx, org1.ID, []int64{repoIDs[0], aliceRepo.ID})
+		wantErr := ErrInvalidPinnedRepo{args: errutil.Args{"orgID": org1.ID, "repoID": aliceRepo.ID}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	t.Run("too many repositories", func(t *testing.T) {
+		err := db.SetPinnedRepos(ctx, org1.ID, repoIDs)
+		wantErr := ErrInvalidPinnedRepo{args: errutil.Args{"count": 7, "max": maxPinnedRepos}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	// Duplicates are ignored and the order is preserved
+	err = db.SetPinnedRepos(ctx, org1.ID, []int64{repoIDs[2], repoIDs[0], repoIDs[2], repoIDs[1]})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{repoIDs[2], repoIDs[0], repoIDs[1]}, pinnedIDs(t))
+
+	// Soft-deleted repositories are not listed
+	err = reposStore.SoftDelete(ctx, repoIDs[0])
+	require.NoError(t, err)
+	assert.Equal(t, []int64{repoIDs[2], repoIDs[1]}, pinnedIDs(t))
+
+	err = db.SetPinnedRepos(ctx, org1.ID, []int64{repoIDs[1]})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{repoIDs[1]}, pinnedIDs(t))
+
+	err = db.SetPinnedRepos(ctx, org1.ID, nil)
+	require.NoError(t, err)
+	assert.Empty(t, pinnedIDs(t))
+}
+
+func orgsListAuditLog(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	cindy, err := usersStore.Create(ctx, "cindy", "cindy@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+
+	ownersTeam := createTestTeam(t, db.DB, &Team{
+		OrgID:     org1.ID,
+		LowerName: "owners",
+		Name:      OWNER_TEAM,
+		Authorize: AccessModeOwner,
+	}, nil, nil)
+
+	// Changes without an actor are recorded as performed by the system.
+	err = db.AddMember(ctx, org1.ID, alice.ID)
+	require.NoError(t, err)
+
+	actorCtx := WithAuditActor(ctx, alice.ID)
+	err = db.AddMembers(actorCtx, org1.ID, []int64{alice.ID, bob.ID, cindy.ID})
+	require.NoError(t, err)
+	err = db.SetOwner(actorCtx, org1.ID, bob.ID, true)
+	require.NoError(t, err)
+	err = db.RemoveMember(actorCtx, org1.ID, cindy.ID)
+	require.NoError(t, err)
+
+	type entry struct {
+		ActorID      int64
+		Action       AuditAction
+		TargetUserID int64
+		TeamID       int64
+	}
+	entries := func(logs []*AuditLog) []entry {
+		got := make([]entry, 0, len(logs))
+		for _, l := range logs {
+			assert.Equal(t, org1.ID, l.OrgID)
+			assert.NotZero(t, l.CreatedUnix)
+			got = append(got, entry{l.ActorID, l.Action, l.TargetUserID, l.TeamID})
+		}
+		return got
+	}
+
+	logs, count, err := db.ListAuditLog(ctx, org1.ID, ListAuditLogOptions{Page: 1, PageSize: 3})
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), count)
+	want := []entry{
+		{alice.ID, AuditActionRemoveMember, cindy.ID, 0},
+		{alice.ID, AuditActionGrantOwner, bob.ID, 0},
+		{alice.ID, AuditActionAddTeamMember, bob.ID, ownersTeam.ID},
+	}
+	assert.Equal(t, want, entries(logs))
+
+	logs, count, err = db.ListAuditLog(ctx, org1.ID, ListAuditLogOptions{Page: 2, PageSize: 3})
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), count)
+	want = []entry{
+		{alice.ID, AuditActionAddMember, cindy.ID, 0},
+		{alice.ID, AuditActionAddMember, bob.ID, 0},
+		{0, AuditActionAddMember, alice.ID, 0},
+	}
+	assert.Equal(t, want, entries(logs))
+
+	// Nothing is recorded for failed changes.
+	err = db.SetOwner(actorCtx, org1.ID, bob.ID, false)
+	assert.Equal(t, ErrLastOrgOwner{UID: bob.ID}, err)
+	_, count, err = db.ListAuditLog(ctx, org1.ID, ListAuditLogOptions{Page: 1, PageSize: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), count)
+}
+
+func orgsSetMemberVisibility(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+
+	t.Run("not a member", func(t *testing.T) {
+		err := db.SetMemberVisibility(ctx, org1.ID, alice.ID, true)
+		assert.True(t, IsErrNotOrgMember(err))
+	})
+
+	err = db.AddMember(ctx, org1.ID, alice.ID)
+	require.NoError(t, err)
+
+	isPublic := func() bool {
+		orgUser := new(OrgUser)
+		err := db.Where("org_id = ? AND uid = ?", org1.ID, alice.ID).First(orgUser).Error
+		require.NoError(t, err)
+		return orgUser.IsPublic
+	}
+	assert.False(t, isPublic())
+
+	actorCtx := WithAuditActor(ctx, alice.ID)
+	err = db.SetMemberVisibility(actorCtx, org1.ID, alice.ID, true)
+	require.NoError(t, err)
+	assert.True(t, isPublic())
+
+	// Setting the same visibility again should be a no-op
+	err = db.SetMemberVisibility(actorCtx, org1.ID, alice.ID, true)
+	require.NoError(t, err)
+	assert.True(t, isPublic())
+
+	err = db.SetMemberVisibility(actorCtx, org1.ID, alice.ID, false)
+	require.NoError(t, err)
+	assert.False(t, isPublic())
+
+	var logs []*AuditLog
+	err = db.Where("org_id = ? AND action IN (?)", org1.ID, []AuditAction{AuditActionPublicizeMember, AuditActionConcealMember}).
+		Order("id").
+		Find(&logs).
+		Error
+	require.NoError(t, err)
+	if assert.Len(t, logs, 2) {
+		assert.Equal(t, AuditActionPublicizeMember, logs[0].Action)
+		assert.Equal(t, AuditActionConcealMember, logs[1].Action)
+		for _, log := range logs {
+			assert.Equal(t, alice.ID, log.ActorID)
+			assert.Equal(t, alice.ID, log.TargetUserID)
+		}
+	}
+}
+
+func orgsSetMemberNotifyLevel(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+
+	t.Run("not a member", func(t *testing.T) {
+		err := db.SetMemberNotifyLevel(ctx, org1.ID, alice.ID, OrgNotifyLevelNone)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+
+	err = db.AddMembers(ctx, org1.ID, []int64{alice.ID, bob.ID})
+	require.NoError(t, err)
+
+	t.Run("invalid level", func(t *testing.T) {
+		err := db.SetMemberNotifyLevel(ctx, org1.ID, alice.ID, 0)
+		assert.Error(t, err)
+	})
+
+	// New members default to the all level
+	got, err := db.ListMemberNotifyLevels(ctx, org1.ID)
+	require.NoError(t, err)
+	want := map[int64]int{
+		alice.ID: OrgNotifyLevelAll,
+		bob.ID:   OrgNotifyLevelAll,
+	}
+	assert.Equal(t, want, got)
+
+	err = db.SetMemberNotifyLevel(ctx, org1.ID, alice.ID, OrgNotifyLevelNone)
+	require.NoError(t, err)
+	err = db.SetMemberNotifyLevel(ctx, org1.ID, bob.ID, OrgNotifyLevelParticipating)
+	require.NoError(t, err)
+
+	// Setting the same level again should be a no-op
+	err = db.SetMemberNotifyLevel(ctx, org1.ID, bob.ID, OrgNotifyLevelParticipating)
+	require.NoError(t, err)
+
+	got, err = db.ListMemberNotifyLevels(ctx, org1.ID)
+	require.NoError(t, err)
+	want = map[int64]int{
+		alice.ID: OrgNotifyLevelNone,
+		bob.ID:   OrgNotifyLevelParticipating,
+	}
+	assert.Equal(t, want, got)
+
+	// Users who are not organizations have no members
+	got, err = db.ListMemberNotifyLevels(ctx, alice.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	got, err = db.ListNotifyLevelsOfMember(ctx, alice.ID)
+	require.NoError(t, err)
+	want = map[int64]int{
+		org1.ID: OrgNotifyLevelNone,
+	}
+	assert.Equal(t, want, got)
+}
+
+func orgsTransferOwnership(t *testing.T, db *orgs) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+	ownersTeam := createTestTeam(t, db.DB, &Team{
+		OrgID:      org1.ID,
+		LowerName:  "owners",
+		Name:       OWNER_TEAM,
+		Authorize:  AccessModeOwner,
+		NumMembers: 1,
+	}, []int64{bob.ID}, nil)
+	err = db.Exec(`INSERT INTO org_user (uid, org_id, is_owner) VALUES (?, ?, ?)`, bob.ID, org1.ID, true).Error
+	require.NoError(t, err)
+
+	reposStore := NewReposStore(db.DB)
+	repo1, err := reposStore.Create(ctx, alice.ID, CreateRepoOptions{Name: "repo1"})
+	require.NoError(t, err)
+	_, err = reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo2"})
+	require.NoError(t, err)
+	repo2, err := reposStore.Create(ctx, alice.ID, CreateRepoOptions{Name: "repo2"})
+	require.NoError(t, err)
+
+	t.Run("not an organization", func(t *testing.T) {
+		err := db.TransferOwnership(ctx, repo1.ID, bob.ID, TransferOptions{})
+		wantErr := ErrOrgNotExist{args: errutil.Args{"orgID": bob.ID}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	t.Run("repository already exists", func(t *testing.T) {
+		err := db.TransferOwnership(ctx, repo2.ID, org1.ID, TransferOptions{})
+		wantErr := ErrRepoAlreadyExist{
+			args: errutil.Args{
+				"ownerID": org1.ID,
+				"name":    repo2.Name,
+			},
+		}
+		assert.Equal(t, wantErr, err)
+	})
+
+	err = db.TransferOwnership(ctx, repo1.ID, org1.ID, TransferOptions{})
+	require.NoError(t, err)
+
+	repo1, err = reposStore.GetByID(ctx, repo1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, org1.ID, repo1.OwnerID)
+	assert.Equal(t, 2, repo1.NumWatches) // The old owner keeps watching
+
+	alice, err = usersStore.GetByID(ctx, alice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, alice.NumRepos)
+	org1, err = usersStore.GetByID(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, org1.NumRepos)
+
+	err = db.First(ownersTeam, ownersTeam.ID).Error
+	require.NoError(t, err)
+	assert.Equal(t, 1, ownersTeam.NumRepos)
+
+	permsStore := NewPermsStore(db.DB)
+	mode := permsStore.AccessMode(ctx, bob.ID, repo1.ID, AccessModeOptions{OwnerID: repo1.OwnerID, Private: true})
+	assert.Equal(t, AccessModeOwner, mode)
+	mode = permsStore.AccessMode(ctx, alice.ID, repo1.ID, AccessModeOptions{OwnerID: repo1.OwnerID, Private: true})
+	assert.Equal(t, AccessModeNone, mode)
+}
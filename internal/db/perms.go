@@ -6,14 +6,20 @@ package db
 
 import (
 	"context"
+	"strings"
 
+	"github.com/pkg/errors"
 	"gorm.io/gorm"
 	log "unknwon.dev/clog/v2"
+
+	"gogs.io/gogs/internal/errutil"
 )
 
 // PermsStore is the persistent interface for permissions.
 type PermsStore interface {
 	// AccessMode returns the access mode of given user has to the repository.
+	// Members of an organization that requires two-factor authentication only
+	// get the access of non-members until they have enabled it.
 	AccessMode(ctx context.Context, userID, repoID int64, opts AccessModeOptions) AccessMode
 	// Authorize returns true if the user has as good as desired access mode to the
 	// repository.
@@ -21,6 +27,26 @@ type PermsStore interface {
 	// SetRepoPerms does a full update to which users have which level of access to
 	// given repository. Keys of the "accessMap" are user IDs.
 	SetRepoPerms(ctx context.Context, repoID int64, accessMap map[int64]AccessMode) error
+	// HighestAccessMode computes the access mode of the user to the repository
+	// from its sources directly, without relying on the cached access records.
+	// The access mode is the highest of all sources, they never add up:
+	//
+	//  1. The owner of the repository always has owner access.
+	//  2. Members of the Owners team of the organization that owns the repository
+	//     always have owner access.
+	//  3. Members of teams that have access to the repository get the permission
+	//     of the team.
+	//  4. Collaborators get the permission of their unexpired collaboration.
+	//  5. Members of the organization get the default repository permission of
+	//     the organization to public repositories.
+	//  6. Everyone has read access to public repositories.
+	//
+	// It returns ErrRepoNotExist when the repository does not exist.
+	HighestAccessMode(ctx context.Context, userID, repoID int64) (AccessMode, error)
+	// RecalculateAccesses recomputes and replaces all access records of the given
+	// repository from its collaborations, teams and the default repository
+	// permission of its owner organization.
+	RecalculateAccesses(ctx context.Context, repoID int64) error
 }
 
 var Perms PermsStore
@@ -110,15 +136,77 @@ func (db *perms) AccessMode(ctx context.Context, userID, repoID int64, opts Acce
 		return AccessModeOwner
 	}
 
-	access := new(Access)
-	err := db.WithContext(ctx).Where("user_id = ? AND repo_id = ?", userID, repoID).First(access).Error
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT access.mode, COALESCE(collaboration.expires_unix, 0) AS expires_unix FROM access
+		LEFT JOIN collaboration ON collaboration.user_id = access.user_id AND collaboration.repo_id = access.repo_id
+		WHERE access.user_id = @userID AND access.repo_id = @repoID
+		LIMIT 1
+	*/
+	var access struct {
+		Mode        AccessMode
+		ExpiresUnix int64
+	}
+	err := db.WithContext(ctx).
+		Model(&Access{}).
+		Select("access.mode, COALESCE(collaboration.expires_unix, 0) AS expires_unix").
+		Joins("LEFT JOIN collaboration ON collaboration.user_id = access.user_id AND collaboration.repo_id = access.repo_id").
+		Where("access.user_id = ? AND access.repo_id = ?", userID, repoID).
+		Take(&access).
+		Error
 	if err != nil {
 		if err != gorm.ErrRecordNotFound {
 			log.Error("Failed to get access [user_id: %d, repo_id: %d]: %v", userID, repoID, err)
 		}
 		return mode
 	}
-	return access.Mode
+
+	// Members of an organization that requires two-factor authentication only get
+	// the default access to its repositories until they have enabled it.
+	if !db.meetsOrgTwoFactorRequirement(ctx, userID, opts.OwnerID) {
+		return mode
+	}
+
+	// The access record may still include an expired collaboration that has not
+	// been swept yet, in which case the access mode is computed without it.
+	if access.ExpiresUnix == 0 || access.ExpiresUnix > db.NowFunc().Unix() {
+		return access.Mode
+	}
+
+	accessMap, err := computeAccesses(db.WithContext(ctx), repoID)
+	if err != nil {
+		log.Error("Failed to compute accesses [repo_id: %d]: %v", repoID, err)
+		return mode
+	}
+	if accessMap[userID] > mode {
+		mode = accessMap[userID]
+	}
+	return mode
+}
+
+// meetsOrgTwoFactorRequirement returns true if the owner does not require
+// two-factor authentication of its members or the user has enabled it.
+func (db *perms) meetsOrgTwoFactorRequirement(ctx context.Context, userID, ownerID int64) bool {
+	var count int64
+	err := db.WithContext(ctx).
+		Model(&User{}).
+		Where("id = ? AND require_two_factor = ?", ownerID, true).
+		Count(&count).
+		Error
+	if err != nil {
+		log.Error("Failed to check two-factor requirement [owner_id: %d]: %v", ownerID, err)
+		return false
+	} else if count == 0 {
+		return true
+	}
+
+	err = db.WithContext(ctx).Model(&TwoFactor{}).Where("user_id = ?", userID).Count(&count).Error
+	if err != nil {
+		log.Error("Failed to count two-factor tokens [user_id: %d]: %v", userID, err)
+		return false
+	}
+	return count > 0
 }
 
 func (db *perms) Authorize(ctx context.Context, userID, repoID int64, desired AccessMode, opts AccessModeOptions) bool {
@@ -144,3 +232,227 @@ func (db *perms) SetRepoPerms(ctx context.Context, repoID int64, accessMap map[i
 		return tx.Create(&records).Error
 	})
 }
+
+func (db *perms) HighestAccessMode(ctx context.Context, userID, repoID int64) (AccessMode, error) {
+	repo := new(Repository)
+	err := db.WithContext(ctx).Select("id", "owner_id", "is_private").Where("id = ?", repoID).First(repo).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return AccessModeNone, ErrRepoNotExist{args: errutil.Args{"repoID": repoID}}
+		}
+		return AccessModeNone, errors.Wrap(err, "get repository")
+	}
+
+	mode := AccessModeNone
+	if !repo.IsPrivate {
+		mode = AccessModeRead
+	}
+	if userID <= 0 {
+		return mode, nil
+	} else if userID == repo.OwnerID {
+		return AccessModeOwner, nil
+	}
+
+	raise := func(m AccessMode) {
+		if m > mode {
+			mode = m
+		}
+	}
+
+	collaboration := new(Collaboration)
+	err = db.WithContext(ctx).
+		Where("repo_id = ? AND user_id = ? AND (expires_unix = 0 OR expires_unix > ?)", repoID, userID, db.NowFunc().Unix()).
+		First(collaboration).
+		Error
+	if err == nil {
+		raise(collaboration.Mode)
+	} else if err != gorm.ErrRecordNotFound {
+		return AccessModeNone, errors.Wrap(err, "get collaboration")
+	}
+
+	owner := new(User)
+	err = db.WithContext(ctx).Select("id", "type", "default_repo_permission").Where("id = ?", repo.OwnerID).First(owner).Error
+	if err != nil {
+		return AccessModeNone, errors.Wrap(err, "get owner")
+	} else if !owner.IsOrganization() {
+		return mode, nil
+	}
+
+	if !repo.IsPrivate && owner.DefaultRepoPermission > AccessModeNone {
+		var count int64
+		err = db.WithContext(ctx).Model(&OrgUser{}).Where("org_id = ? AND uid = ?", owner.ID, userID).Count(&count).Error
+		if err != nil {
+			return AccessModeNone, errors.Wrap(err, "count organization membership")
+		} else if count > 0 {
+			raise(owner.DefaultRepoPermission)
+		}
+	}
+
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT
+			team.lower_name,
+			team.authorize
+		FROM team_user
+		JOIN team ON team.id = team_user.team_id
+		WHERE
+			team_user.uid = @userID
+		AND team.org_id = @ownerID
+		AND (
+				team.lower_name = @ownersTeam
+			OR  team.id IN (SELECT team_id FROM team_repo WHERE repo_id = @repoID)
+		)
+	*/
+	var teams []*Team
+	err = db.WithContext(ctx).
+		Select("team.lower_name", "team.authorize").
+		Joins("JOIN team_user ON team_user.team_id = team.id").
+		Where("team_user.uid = ? AND team.org_id = ? AND (team.lower_name = ? OR team.id IN (?))",
+			userID,
+			owner.ID,
+			strings.ToLower(OWNER_TEAM),
+			db.Model(&TeamRepo{}).Select("team_id").Where("repo_id = ?", repoID),
+		).
+		Find(&teams).
+		Error
+	if err != nil {
+		return AccessModeNone, errors.Wrap(err, "list teams")
+	}
+
+	for _, t := range teams {
+		if t.LowerName == strings.ToLower(OWNER_TEAM) {
+			return AccessModeOwner, nil
+		}
+		raise(t.Authorize)
+	}
+	return mode, nil
+}
+
+func (db *perms) RecalculateAccesses(ctx context.Context, repoID int64) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return recalculateAccesses(tx, repoID)
+	})
+}
+
+// computeAccesses computes access modes of all users who have access to the
+// given repository based on its unexpired collaborations and, when the
+// repository is owned by an organization, memberships of teams that have access
+// to the repository. Members of the Owners team always get owner access, and
+// members of the organization get at least the default repository permission of
+// the organization to public repositories. Keys of the returned map are user
+// IDs.
+func computeAccesses(tx *gorm.DB, repoID int64) (map[int64]AccessMode, error) {
+	repo := new(Repository)
+	err := tx.Select("id", "owner_id", "is_private").Where("id = ?", repoID).First(repo).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "get repository")
+	}
+
+	var collaborations []*Collaboration
+	err = tx.Where("repo_id = ? AND (expires_unix = 0 OR expires_unix > ?)", repoID, tx.NowFunc().Unix()).
+		Find(&collaborations).
+		Error
+	if err != nil {
+		return nil, errors.Wrap(err, "list collaborations")
+	}
+
+	accessMap := make(map[int64]AccessMode, len(collaborations))
+	for _, c := range collaborations {
+		accessMap[c.UserID] = c.Mode
+	}
+
+	if !repo.IsPrivate {
+		owner := new(User)
+		err = tx.Select("id", "type", "default_repo_permission").Where("id = ?", repo.OwnerID).First(owner).Error
+		if err != nil {
+			return nil, errors.Wrap(err, "get owner")
+		}
+
+		if owner.IsOrganization() && owner.DefaultRepoPermission > AccessModeNone {
+			var memberIDs []int64
+			err = tx.Model(&OrgUser{}).Where("org_id = ?", owner.ID).Pluck("uid", &memberIDs).Error
+			if err != nil {
+				return nil, errors.Wrap(err, "list organization members")
+			}
+
+			for _, memberID := range memberIDs {
+				if owner.DefaultRepoPermission > accessMap[memberID] {
+					accessMap[memberID] = owner.DefaultRepoPermission
+				}
+			}
+		}
+	}
+
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT
+			team_user.uid,
+			team.lower_name,
+			team.authorize
+		FROM team_user
+		JOIN team ON team.id = team_user.team_id
+		WHERE
+			team.org_id = @ownerID
+		AND (
+				team.lower_name = @ownersTeam
+			OR  team.id IN (SELECT team_id FROM team_repo WHERE repo_id = @repoID)
+		)
+	*/
+	var teamMembers []*struct {
+		UID       int64
+		LowerName string
+		Authorize AccessMode
+	}
+	err = tx.Table("team_user").
+		Select("team_user.uid", "team.lower_name", "team.authorize").
+		Joins("JOIN team ON team.id = team_user.team_id").
+		Where("team.org_id = ? AND (team.lower_name = ? OR team.id IN (?))",
+			repo.OwnerID,
+			strings.ToLower(OWNER_TEAM),
+			tx.Model(&TeamRepo{}).Select("team_id").Where("repo_id = ?", repoID),
+		).
+		Find(&teamMembers).
+		Error
+	if err != nil {
+		return nil, errors.Wrap(err, "list team members")
+	}
+
+	for _, m := range teamMembers {
+		mode := m.Authorize
+		if m.LowerName == strings.ToLower(OWNER_TEAM) {
+			mode = AccessModeOwner
+		}
+		if mode > accessMap[m.UID] {
+			accessMap[m.UID] = mode
+		}
+	}
+	return accessMap, nil
+}
+
+// recalculateAccesses recalculates and replaces all access records of the given
+// repository, see computeAccesses for how access modes are computed.
+func recalculateAccesses(tx *gorm.DB, repoID int64) error {
+	accessMap, err := computeAccesses(tx, repoID)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Where("repo_id = ?", repoID).Delete(new(Access)).Error
+	if err != nil {
+		return errors.Wrap(err, "delete old accesses")
+	} else if len(accessMap) == 0 {
+		return nil
+	}
+
+	records := make([]*Access, 0, len(accessMap))
+	for userID, mode := range accessMap {
+		records = append(records, &Access{
+			UserID: userID,
+			RepoID: repoID,
+			Mode:   mode,
+		})
+	}
+	return tx.Create(&records).Error
+}
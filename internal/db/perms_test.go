@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"gogs.io/gogs/internal/dbtest"
+	"gogs.io/gogs/internal/errutil"
 )
 
 func TestPerms(t *testing.T) {
@@ -20,7 +21,10 @@ func TestPerms(t *testing.T) {
 	}
 	t.Parallel()
 
-	tables := []any{new(Access)}
+	tables := []any{
+		new(Access), new(Collaboration), new(Repository), new(Watch), new(User), new(EmailAddress),
+		new(OrgUser), new(Team), new(TeamUser), new(TeamRepo), new(TwoFactor),
+	}
 	db := &perms{
 		DB: dbtest.NewDB(t, "perms", tables...),
 	}
@@ -32,6 +36,8 @@ func TestPerms(t *testing.T) {
 		{"AccessMode", permsAccessMode},
 		{"Authorize", permsAuthorize},
 		{"SetRepoPerms", permsSetRepoPerms},
+		{"HighestAccessMode", permsHighestAccessMode},
+		{"RecalculateAccesses", permsRecalculateAccesses},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Cleanup(func() {
@@ -157,6 +163,26 @@ func permsAccessMode(t *testing.T, db *perms) {
 			assert.Equal(t, test.wantAccessMode, mode)
 		})
 	}
+
+	t.Run("organization requires two-factor authentication", func(t *testing.T) {
+		org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+		err := db.Model(&User{}).Where("id = ?", org1.ID).Update("require_two_factor", true).Error
+		require.NoError(t, err)
+
+		const orgRepoID = 3
+		err = db.SetRepoPerms(ctx, orgRepoID, map[int64]AccessMode{2: AccessModeWrite})
+		require.NoError(t, err)
+
+		orgRepoOpts := AccessModeOptions{
+			OwnerID: org1.ID,
+			Private: true,
+		}
+		assert.Equal(t, AccessModeNone, db.AccessMode(ctx, 2, orgRepoID, orgRepoOpts))
+
+		err = db.Create(&TwoFactor{UserID: 2}).Error
+		require.NoError(t, err)
+		assert.Equal(t, AccessModeWrite, db.AccessMode(ctx, 2, orgRepoID, orgRepoOpts))
+	})
 }
 
 func permsAuthorize(t *testing.T, db *perms) {
@@ -312,3 +338,156 @@ func permsSetRepoPerms(t *testing.T, db *perms) {
 	}
 	assert.Equal(t, wantAccesses, accesses)
 }
+
+func permsHighestAccessMode(t *testing.T, db *perms) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	cindy, err := usersStore.Create(ctx, "cindy", "cindy@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+	err = db.Model(&User{}).Where("id = ?", org1.ID).Update("default_repo_permission", AccessModeWrite).Error
+	require.NoError(t, err)
+
+	reposStore := NewReposStore(db.DB)
+	publicRepo, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "public"})
+	require.NoError(t, err)
+	privateRepo, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "private", Private: true})
+	require.NoError(t, err)
+
+	createTestTeam(t, db.DB, &Team{OrgID: org1.ID, Name: OWNER_TEAM, Authorize: AccessModeOwner}, []int64{alice.ID}, nil)
+	createTestTeam(t, db.DB, &Team{OrgID: org1.ID, Name: "readers", Authorize: AccessModeRead}, []int64{bob.ID}, []int64{privateRepo.ID})
+
+	err = db.Exec(`INSERT INTO org_user (uid, org_id, is_owner) VALUES (?, ?, ?), (?, ?, ?)`,
+		alice.ID, org1.ID, true,
+		bob.ID, org1.ID, false,
+	).Error
+	require.NoError(t, err)
+
+	// The collaboration has a higher permission than the team
+	err = db.DB.Create(&Collaboration{RepoID: privateRepo.ID, UserID: bob.ID, Mode: AccessModeAdmin}).Error
+	require.NoError(t, err)
+	// The expired collaboration should not take effect
+	err = db.DB.Create(&Collaboration{RepoID: privateRepo.ID, UserID: cindy.ID, Mode: AccessModeWrite, ExpiresUnix: 1}).Error
+	require.NoError(t, err)
+
+	t.Run("repository does not exist", func(t *testing.T) {
+		_, err := db.HighestAccessMode(ctx, alice.ID, 404)
+		wantErr := ErrRepoNotExist{args: errutil.Args{"repoID": int64(404)}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	tests := []struct {
+		name   string
+		userID int64
+		repoID int64
+		want   AccessMode
+	}{
+		{
+			name:   "anonymous user has read access to public repository",
+			userID: 0,
+			repoID: publicRepo.ID,
+			want:   AccessModeRead,
+		},
+		{
+			name:   "anonymous user has no access to private repository",
+			userID: 0,
+			repoID: privateRepo.ID,
+			want:   AccessModeNone,
+		},
+		{
+			name:   "owner",
+			userID: org1.ID,
+			repoID: privateRepo.ID,
+			want:   AccessModeOwner,
+		},
+		{
+			name:   "member of Owners team",
+			userID: alice.ID,
+			repoID: privateRepo.ID,
+			want:   AccessModeOwner,
+		},
+		{
+			name:   "highest of team and collaboration",
+			userID: bob.ID,
+			repoID: privateRepo.ID,
+			want:   AccessModeAdmin,
+		},
+		{
+			name:   "default repository permission of organization",
+			userID: bob.ID,
+			repoID: publicRepo.ID,
+			want:   AccessModeWrite,
+		},
+		{
+			name:   "expired collaboration",
+			userID: cindy.ID,
+			repoID: privateRepo.ID,
+			want:   AccessModeNone,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := db.HighestAccessMode(ctx, test.userID, test.repoID)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func permsRecalculateAccesses(t *testing.T, db *perms) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	cindy, err := usersStore.Create(ctx, "cindy", "cindy@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+	err = db.Model(&User{}).Where("id = ?", org1.ID).Update("default_repo_permission", AccessModeWrite).Error
+	require.NoError(t, err)
+
+	reposStore := NewReposStore(db.DB)
+	repo, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "public"})
+	require.NoError(t, err)
+
+	createTestTeam(t, db.DB, &Team{OrgID: org1.ID, Name: OWNER_TEAM, Authorize: AccessModeOwner}, []int64{alice.ID}, nil)
+	err = db.Exec(`INSERT INTO org_user (uid, org_id, is_owner) VALUES (?, ?, ?), (?, ?, ?)`,
+		alice.ID, org1.ID, true,
+		bob.ID, org1.ID, false,
+	).Error
+	require.NoError(t, err)
+	err = db.DB.Create(&Collaboration{RepoID: repo.ID, UserID: cindy.ID, Mode: AccessModeRead}).Error
+	require.NoError(t, err)
+
+	// Stale access records should be replaced
+	err = db.SetRepoPerms(ctx, repo.ID, map[int64]AccessMode{bob.ID: AccessModeAdmin})
+	require.NoError(t, err)
+
+	err = db.RecalculateAccesses(ctx, repo.ID)
+	require.NoError(t, err)
+
+	var accesses []*Access
+	err = db.Where("repo_id = ?", repo.ID).Order("user_id").Find(&accesses).Error
+	require.NoError(t, err)
+
+	// Ignore ID fields
+	for _, a := range accesses {
+		a.ID = 0
+	}
+
+	wantAccesses := []*Access{
+		{UserID: alice.ID, RepoID: repo.ID, Mode: AccessModeOwner},
+		{UserID: bob.ID, RepoID: repo.ID, Mode: AccessModeWrite},
+		{UserID: cindy.ID, RepoID: repo.ID, Mode: AccessModeRead},
+	}
+	assert.Equal(t, wantAccesses, accesses)
+}
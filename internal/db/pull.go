@@ -661,6 +661,12 @@ func (pr *PullRequest) UpdatePatch() (err error) {
 	return nil
 }
 
+// GitRefName returns the Git reference name of the pull request in the base
+// repository, which points to the head commit.
+func (pr *PullRequest) GitRefName() string {
+	return fmt.Sprintf("refs/pull/%d/head", pr.Index)
+}
+
 // PushToBaseRepo pushes commits from branches of head repository to
 // corresponding branches of base repository.
 // FIXME: Only push branches that are actually updates?
@@ -685,7 +691,7 @@ func (pr *PullRequest) PushToBaseRepo() (err error) {
 		}
 	}()
 
-	headRefspec := fmt.Sprintf("refs/pull/%d/head", pr.Index)
+	headRefspec := pr.GitRefName()
 	headFile := filepath.Join(pr.BaseRepo.RepoPath(), headRefspec)
 	if osutil.IsExist(headFile) {
 		err = os.Remove(headFile)
@@ -0,0 +1,87 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PullsStore is the persistent interface for pull requests.
+type PullsStore interface {
+	// Approve records an approval of the pull request by the user for the given
+	// head commit. Approving the same commit again is a no-op. It returns
+	// ErrPullRequestNotExist when not found.
+	Approve(ctx context.Context, pullID, userID int64, commitID string) error
+	// ListApproverIDs returns IDs of users who have approved the given head
+	// commit of the pull request, sorted by the time of approval. Approvals of
+	// other commits are not included, so that pushing new commits dismisses
+	// existing approvals.
+	ListApproverIDs(ctx context.Context, pullID int64, commitID string) ([]int64, error)
+}
+
+var Pulls PullsStore
+
+var _ PullsStore = (*pulls)(nil)
+
+type pulls struct {
+	*gorm.DB
+}
+
+// NewPullsStore returns a persistent interface for pull requests with given
+// database connection.
+func NewPullsStore(db *gorm.DB) PullsStore {
+	return &pulls{DB: db}
+}
+
+// PullApproval is an approval of a head commit of a pull request by a user.
+type PullApproval struct {
+	ID          int64  `gorm:"primaryKey"`
+	PullID      int64  `gorm:"uniqueIndex:pull_approval_pull_user_commit_unique;not null"`
+	UserID      int64  `gorm:"uniqueIndex:pull_approval_pull_user_commit_unique;not null"`
+	CommitID    string `gorm:"type:VARCHAR(40);uniqueIndex:pull_approval_pull_user_commit_unique;not null"`
+	CreatedUnix int64  `gorm:"not null"`
+}
+
+func (db *pulls) Approve(ctx context.Context, pullID, userID int64, commitID string) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(&PullRequest{}).Where("id = ?", pullID).Count(&count).Error
+		if err != nil {
+			return errors.Wrap(err, "count pull request")
+		} else if count == 0 {
+			return ErrPullRequestNotExist{args: map[string]any{"pullID": pullID}}
+		}
+
+		/*
+			Equivalent SQL for PostgreSQL:
+
+			INSERT INTO pull_approval (pull_id, user_id, commit_id, created_unix)
+			VALUES (@pullID, @userID, @commitID, @createdUnix)
+			ON CONFLICT DO NOTHING
+		*/
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(
+			&PullApproval{
+				PullID:      pullID,
+				UserID:      userID,
+				CommitID:    commitID,
+				CreatedUnix: tx.NowFunc().Unix(),
+			},
+		).Error
+	})
+}
+
+func (db *pulls) ListApproverIDs(ctx context.Context, pullID int64, commitID string) ([]int64, error) {
+	var userIDs []int64
+	return userIDs, db.WithContext(ctx).
+		Model(&PullApproval{}).
+		Where("pull_id = ? AND commit_id = ?", pullID, commitID).
+		Order("id").
+		Pluck("user_id", &userIDs).
+		Error
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gogs.io/gogs/internal/dbtest"
+)
+
+func TestPulls(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	tables := []any{new(PullRequest), new(PullApproval)}
+	db := &pulls{
+		DB: dbtest.NewDB(t, "pulls", tables...),
+	}
+
+	for _, tc := range []struct {
+		name string
+		test func(t *testing.T, db *pulls)
+	}{
+		{"Approve", pullsApprove},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Cleanup(func() {
+				err := clearTables(t, db.DB, tables...)
+				require.NoError(t, err)
+			})
+			tc.test(t, db)
+		})
+		if t.Failed() {
+			break
+		}
+	}
+}
+
+func pullsApprove(t *testing.T, db *pulls) {
+	ctx := context.Background()
+
+	t.Run("pull request does not exist", func(t *testing.T) {
+		err := db.Approve(ctx, 404, 1, "commit1")
+		wantErr := ErrPullRequestNotExist{args: map[string]any{"pullID": int64(404)}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	pr := &PullRequest{IssueID: 1, Index: 1, HeadRepoID: 1, BaseRepoID: 1}
+	err := db.DB.Create(pr).Error
+	require.NoError(t, err)
+
+	err = db.Approve(ctx, pr.ID, 2, "commit1")
+	require.NoError(t, err)
+	err = db.Approve(ctx, pr.ID, 1, "commit1")
+	require.NoError(t, err)
+
+	// Approving the same commit again should be a no-op
+	err = db.Approve(ctx, pr.ID, 2, "commit1")
+	require.NoError(t, err)
+
+	got, err := db.ListApproverIDs(ctx, pr.ID, "commit1")
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 1}, got)
+
+	// Approvals of previous commits do not count for new commits
+	err = db.Approve(ctx, pr.ID, 1, "commit2")
+	require.NoError(t, err)
+	got, err = db.ListApproverIDs(ctx, pr.ID, "commit2")
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, got)
+}
@@ -183,6 +183,9 @@ type Repository struct {
 	// see docs in https://gorm.io/docs/migration.html.
 	IsUnlisted bool `xorm:"NOT NULL DEFAULT false" gorm:"not null;default:FALSE"`
 	IsBare     bool
+	IsArchived bool `xorm:"NOT NULL DEFAULT false" gorm:"not null;default:FALSE"`
+	// Whether the repository can be used as a template to create new repositories.
+	IsTemplate bool `xorm:"NOT NULL DEFAULT false" gorm:"not null;default:FALSE"`
 
 	IsMirror bool
 	*Mirror  `xorm:"-" gorm:"-" json:"-"`
@@ -211,6 +214,10 @@ type Repository struct {
 	CreatedUnix int64
 	Updated     time.Time `xorm:"-" gorm:"-" json:"-"`
 	UpdatedUnix int64
+
+	// The time in Unix seconds when the repository was soft-deleted, 0 means not
+	// deleted. Soft-deleted repositories are purged after the retention window.
+	DeletedUnix int64 `xorm:"INDEX NOT NULL DEFAULT 0" gorm:"index;not null;default:0"`
 }
 
 func (repo *Repository) BeforeInsert() {
@@ -439,10 +446,11 @@ func (repo *Repository) UpdateSize() error {
 		return fmt.Errorf("count repository objects: %v", err)
 	}
 
-	repo.Size = countObject.Size + countObject.SizePack
-	if _, err = x.Id(repo.ID).Cols("size").Update(repo); err != nil {
+	size := countObject.Size + countObject.SizePack
+	if err = Repos.UpdateSize(context.TODO(), repo.ID, size); err != nil {
 		return fmt.Errorf("update size: %v", err)
 	}
+	repo.Size = size
 	return nil
 }
 
@@ -1114,6 +1122,31 @@ var (
 	reservedRepoNames = map[string]struct{}{
 		".":  {},
 		"..": {},
+		"-":  {},
+
+		// Device names that cannot be used as directory names on Windows.
+		"con":  {},
+		"prn":  {},
+		"aux":  {},
+		"nul":  {},
+		"com1": {},
+		"com2": {},
+		"com3": {},
+		"com4": {},
+		"com5": {},
+		"com6": {},
+		"com7": {},
+		"com8": {},
+		"com9": {},
+		"lpt1": {},
+		"lpt2": {},
+		"lpt3": {},
+		"lpt4": {},
+		"lpt5": {},
+		"lpt6": {},
+		"lpt7": {},
+		"lpt8": {},
+		"lpt9": {},
 	}
 	reservedRepoPatterns = []string{
 		"*.git",
@@ -1155,11 +1188,6 @@ func createRepository(e *xorm.Session, doer, owner *User, repo *Repository) (err
 		} else if err = t.addRepository(e, repo); err != nil {
 			return fmt.Errorf("addRepository: %v", err)
 		}
-	} else {
-		// Organization automatically called this in addRepository method.
-		if err = repo.recalculateAccesses(e); err != nil {
-			return fmt.Errorf("recalculateAccesses: %v", err)
-		}
 	}
 
 	if err = watchRepo(e, owner.ID, repo.ID, true); err != nil {
@@ -1254,6 +1282,22 @@ func CreateRepository(doer, owner *User, opts CreateRepoOptionsLegacy) (_ *Repos
 		return nil, err
 	}
 
+	if err = repo.RecalculateAccesses(); err != nil {
+		return nil, errors.Wrap(err, "recalculate accesses")
+	}
+
+	if owner.IsOrganization() {
+		err = Orgs.ApplyDefaultProtection(context.TODO(), owner.ID, repo.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "apply default branch protection")
+		}
+
+		err = Repos.ApplyOrgLabels(context.TODO(), repo.ID, owner.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "apply organization labels")
+		}
+	}
+
 	// Remember visibility preference
 	err = Users.Update(context.TODO(), owner.ID, UpdateUserOptions{LastRepoVisibility: &repo.IsPrivate})
 	if err != nil {
@@ -1264,7 +1308,7 @@ func CreateRepository(doer, owner *User, opts CreateRepoOptionsLegacy) (_ *Repos
 }
 
 func countRepositories(userID int64, private bool) int64 {
-	sess := x.Where("id > 0")
+	sess := x.Where("id > 0").And("deleted_unix = 0")
 
 	if userID > 0 {
 		sess.And("owner_id = ?", userID)
@@ -1296,7 +1340,7 @@ func CountUserRepositories(userID int64, private bool) int64 {
 
 func Repositories(page, pageSize int) (_ []*Repository, err error) {
 	repos := make([]*Repository, 0, pageSize)
-	return repos, x.Limit(pageSize, (page-1)*pageSize).Asc("id").Find(&repos)
+	return repos, x.Limit(pageSize, (page-1)*pageSize).Where("deleted_unix = 0").Asc("id").Find(&repos)
 }
 
 // RepositoriesWithUsers returns number of repos in given page.
@@ -1389,11 +1433,20 @@ func TransferOwnership(doer *User, newOwnerName string, repo *Repository) error
 		return fmt.Errorf("getCollaborators: %v", err)
 	}
 
+	userIDs := make([]int64, len(collaborators))
+	for i := range collaborators {
+		userIDs[i] = collaborators[i].ID
+	}
+	isMember, err := Orgs.FilterMembers(context.TODO(), newOwner.ID, userIDs)
+	if err != nil {
+		return fmt.Errorf("filter members: %v", err)
+	}
+
 	// Dummy object.
 	collaboration := &Collaboration{RepoID: repo.ID}
 	for _, c := range collaborators {
 		collaboration.UserID = c.ID
-		if c.ID == newOwner.ID || newOwner.IsOrgMember(c.ID) {
+		if c.ID == newOwner.ID || isMember[c.ID] {
 			if _, err = sess.Delete(collaboration); err != nil {
 				return fmt.Errorf("remove collaborator '%d': %v", c.ID, err)
 			}
@@ -1428,11 +1481,6 @@ func TransferOwnership(doer *User, newOwnerName string, repo *Repository) error
 		} else if err = t.addRepository(sess, repo); err != nil {
 			return fmt.Errorf("add to owner team: %v", err)
 		}
-	} else {
-		// Organization called this in addRepository method.
-		if err = repo.recalculateAccesses(sess); err != nil {
-			return fmt.Errorf("recalculateAccesses: %v", err)
-		}
 	}
 
 	// Update repository count.
@@ -1492,7 +1540,10 @@ func TransferOwnership(doer *User, newOwnerName string, repo *Repository) error
 		}
 	}
 
-	return sess.Commit()
+	if err = sess.Commit(); err != nil {
+		return err
+	}
+	return repo.RecalculateAccesses()
 }
 
 func deleteRepoLocalCopy(repoID int64) {
@@ -1503,6 +1554,7 @@ func deleteRepoLocalCopy(repoID int64) {
 
 // ChangeRepositoryName changes all corresponding setting from old repository name to new one.
 func ChangeRepositoryName(u *User, oldRepoName, newRepoName string) (err error) {
+	newName := newRepoName
 	oldRepoName = strings.ToLower(oldRepoName)
 	newRepoName = strings.ToLower(newRepoName)
 	if err = isRepoNameAllowed(newRepoName); err != nil {
@@ -1522,18 +1574,45 @@ func ChangeRepositoryName(u *User, oldRepoName, newRepoName string) (err error)
 	}
 
 	// Change repository directory name
-	if err = os.Rename(repo.RepoPath(), RepoPath(u.Name, newRepoName)); err != nil {
+	oldRepoPath := repo.RepoPath()
+	newRepoPath := RepoPath(u.Name, newRepoName)
+	if err = os.Rename(oldRepoPath, newRepoPath); err != nil {
 		return fmt.Errorf("rename repository directory: %v", err)
 	}
 
-	wikiPath := repo.WikiPath()
-	if com.IsExist(wikiPath) {
-		if err = os.Rename(wikiPath, WikiPath(u.Name, newRepoName)); err != nil {
+	// Undo renames on disk when any of the following steps fails to keep the file
+	// system in sync with the database.
+	oldWikiPath := repo.WikiPath()
+	newWikiPath := WikiPath(u.Name, newRepoName)
+	wikiRenamed := false
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		if wikiRenamed {
+			if err := os.Rename(newWikiPath, oldWikiPath); err != nil {
+				log.Error("Failed to undo rename of repository wiki %q: %v", newWikiPath, err)
+			}
+		}
+		if err := os.Rename(newRepoPath, oldRepoPath); err != nil {
+			log.Error("Failed to undo rename of repository directory %q: %v", newRepoPath, err)
+		}
+	}()
+
+	if com.IsExist(oldWikiPath) {
+		if err = os.Rename(oldWikiPath, newWikiPath); err != nil {
 			return fmt.Errorf("rename repository wiki: %v", err)
 		}
+		wikiRenamed = true
 		RemoveAllWithNotice("Delete repository wiki local copy", repo.LocalWikiPath())
 	}
 
+	// Record the redirect from the old name as part of the rename.
+	if err = Repos.Rename(context.TODO(), repo.ID, newName); err != nil {
+		return errors.Wrap(err, "rename repository")
+	}
+
 	deleteRepoLocalCopy(repo.ID)
 	return nil
 }
@@ -1558,6 +1637,23 @@ func GetNonMirrorRepositories() ([]*Repository, error) {
 	return getNonMirrorRepositories(x)
 }
 
+// syncGitDaemonExportFile creates or removes the git-daemon-export-ok file of
+// the repository according to its visibility.
+func syncGitDaemonExportFile(repoPath string, private bool) {
+	daemonExportFile := path.Join(repoPath, "git-daemon-export-ok")
+	if private && com.IsExist(daemonExportFile) {
+		if err := os.Remove(daemonExportFile); err != nil {
+			log.Error("Failed to remove %s: %v", daemonExportFile, err)
+		}
+	} else if !private && !com.IsExist(daemonExportFile) {
+		if f, err := os.Create(daemonExportFile); err != nil {
+			log.Error("Failed to create %s: %v", daemonExportFile, err)
+		} else {
+			f.Close()
+		}
+	}
+}
+
 func updateRepository(e Engine, repo *Repository, visibilityChanged bool) (err error) {
 	repo.LowerName = strings.ToLower(repo.Name)
 
@@ -1577,25 +1673,18 @@ func updateRepository(e Engine, repo *Repository, visibilityChanged bool) (err e
 			return fmt.Errorf("getOwner: %v", err)
 		}
 		if repo.Owner.IsOrganization() {
-			// Organization repository need to recalculate access table when visibility is changed
-			if err = repo.recalculateTeamAccesses(e, 0); err != nil {
-				return fmt.Errorf("recalculateTeamAccesses: %v", err)
+			// Organization repository need to recalculate access table when visibility
+			// is changed, because the default repository permission of the organization
+			// only applies to public repositories.
+			//
+			// NOTE: The only caller that changes visibility does not update within a
+			// transaction, so it is safe to recalculate with a different ORM object.
+			if err = repo.RecalculateAccesses(); err != nil {
+				return fmt.Errorf("recalculate accesses: %v", err)
 			}
 		}
 
-		// Create/Remove git-daemon-export-ok for git-daemon
-		daemonExportFile := path.Join(repo.RepoPath(), "git-daemon-export-ok")
-		if repo.IsPrivate && com.IsExist(daemonExportFile) {
-			if err = os.Remove(daemonExportFile); err != nil {
-				log.Error("Failed to remove %s: %v", daemonExportFile, err)
-			}
-		} else if !repo.IsPrivate && !com.IsExist(daemonExportFile) {
-			if f, err := os.Create(daemonExportFile); err != nil {
-				log.Error("Failed to create %s: %v", daemonExportFile, err)
-			} else {
-				f.Close()
-			}
-		}
+		syncGitDaemonExportFile(repo.RepoPath(), repo.IsPrivate)
 
 		forkRepos, err := getRepositoriesByForkID(e, repo.ID)
 		if err != nil {
@@ -1663,12 +1752,17 @@ func DeleteRepository(ownerID, repoID int64) error {
 		for _, t := range org.Teams {
 			if !t.hasRepository(sess, repoID) {
 				continue
-			} else if err = t.removeRepository(sess, repo, false); err != nil {
+			} else if err = t.removeRepository(sess, repo); err != nil {
 				return err
 			}
 		}
 	}
 
+	// Approvals must be deleted before the pull requests they belong to.
+	if _, err = sess.Exec("DELETE FROM pull_approval WHERE pull_id IN (SELECT id FROM pull_request WHERE base_repo_id = ?)", repoID); err != nil {
+		return fmt.Errorf("delete pull approvals: %v", err)
+	}
+
 	if err = deleteBeans(sess,
 		&Repository{ID: repoID},
 		&Access{RepoID: repo.ID},
@@ -1686,6 +1780,9 @@ func DeleteRepository(ownerID, repoID int64) error {
 		&Webhook{RepoID: repoID},
 		&HookTask{RepoID: repoID},
 		&LFSObject{RepoID: repoID},
+		&ProtectedTag{RepoID: repoID},
+		&PinnedRepo{RepoID: repoID},
+		&RepoRedirect{RepoID: repoID},
 	); err != nil {
 		return fmt.Errorf("deleteBeans: %v", err)
 	}
@@ -1700,6 +1797,9 @@ func DeleteRepository(ownerID, repoID int64) error {
 		if _, err = sess.Delete(&Comment{IssueID: issues[i].ID}); err != nil {
 			return err
 		}
+		if _, err = sess.Delete(&IssueAssignee{IssueID: issues[i].ID}); err != nil {
+			return err
+		}
 
 		attachments := make([]*Attachment, 0, 5)
 		if err = sess.Where("issue_id=?", issues[i].ID).Find(&attachments); err != nil {
@@ -1749,9 +1849,23 @@ func DeleteRepository(ownerID, repoID int64) error {
 		}
 	}
 
+	if err = Repos.SetTopics(context.TODO(), repo.ID, nil); err != nil {
+		log.Error("remove topics of repository %d: %v", repo.ID, err)
+	}
+
 	return nil
 }
 
+// DeleteRepositoryWithRetention soft-deletes the repository when a retention
+// window for deleted repositories is configured, so it can be restored within
+// the window. Otherwise, the repository is deleted permanently.
+func DeleteRepositoryWithRetention(ownerID, repoID int64) error {
+	if conf.Cron.PurgeDeletedRepositories.Retention > 0 {
+		return Repos.SoftDelete(context.TODO(), repoID)
+	}
+	return DeleteRepository(ownerID, repoID)
+}
+
 // GetRepositoryByRef returns a Repository specified by a GFM reference.
 // See https://help.github.com/articles/writing-on-github#references for more information on the syntax.
 func GetRepositoryByRef(ref string) (*Repository, error) {
@@ -1779,7 +1893,7 @@ func GetRepositoryByName(ownerID int64, name string) (*Repository, error) {
 	has, err := x.Get(repo)
 	if err != nil {
 		return nil, err
-	} else if !has {
+	} else if !has || repo.DeletedUnix > 0 {
 		return nil, ErrRepoNotExist{args: map[string]any{"ownerID": ownerID, "name": name}}
 	}
 	return repo, repo.LoadAttributes()
@@ -1790,7 +1904,7 @@ func getRepositoryByID(e Engine, id int64) (*Repository, error) {
 	has, err := e.ID(id).Get(repo)
 	if err != nil {
 		return nil, err
-	} else if !has {
+	} else if !has || repo.DeletedUnix > 0 {
 		return nil, ErrRepoNotExist{args: map[string]any{"repoID": id}}
 	}
 	return repo, repo.loadAttributes(e)
@@ -1810,7 +1924,7 @@ type UserRepoOptions struct {
 
 // GetUserRepositories returns a list of repositories of given user.
 func GetUserRepositories(opts *UserRepoOptions) ([]*Repository, error) {
-	sess := x.Where("owner_id=?", opts.UserID).Desc("updated_unix")
+	sess := x.Where("owner_id=?", opts.UserID).And("deleted_unix = 0").Desc("updated_unix")
 	if !opts.Private {
 		sess.And("is_private=?", false)
 		sess.And("is_unlisted=?", false)
@@ -1828,13 +1942,13 @@ func GetUserRepositories(opts *UserRepoOptions) ([]*Repository, error) {
 // GetUserRepositories returns a list of mirror repositories of given user.
 func GetUserMirrorRepositories(userID int64) ([]*Repository, error) {
 	repos := make([]*Repository, 0, 10)
-	return repos, x.Where("owner_id = ?", userID).And("is_mirror = ?", true).Find(&repos)
+	return repos, x.Where("owner_id = ?", userID).And("is_mirror = ?", true).And("deleted_unix = 0").Find(&repos)
 }
 
 // GetRecentUpdatedRepositories returns the list of repositories that are recently updated.
 func GetRecentUpdatedRepositories(page, pageSize int) (repos []*Repository, err error) {
 	return repos, x.Limit(pageSize, (page-1)*pageSize).
-		Where("is_private=?", false).Limit(pageSize).Desc("updated_unix").Find(&repos)
+		Where("is_private=?", false).And("deleted_unix = 0").Limit(pageSize).Desc("updated_unix").Find(&repos)
 }
 
 // GetUserAndCollaborativeRepositories returns list of repositories the user owns and collaborates.
@@ -1843,12 +1957,13 @@ func GetUserAndCollaborativeRepositories(userID int64) ([]*Repository, error) {
 	if err := x.Alias("repo").
 		Join("INNER", "collaboration", "collaboration.repo_id = repo.id").
 		Where("collaboration.user_id = ?", userID).
+		And("repo.deleted_unix = 0").
 		Find(&repos); err != nil {
 		return nil, fmt.Errorf("select collaborative repositories: %v", err)
 	}
 
 	ownRepos := make([]*Repository, 0, 10)
-	if err := x.Where("owner_id = ?", userID).Find(&ownRepos); err != nil {
+	if err := x.Where("owner_id = ?", userID).And("deleted_unix = 0").Find(&ownRepos); err != nil {
 		return nil, fmt.Errorf("select own repositories: %v", err)
 	}
 
@@ -1891,6 +2006,7 @@ func SearchRepositoryByName(opts *SearchRepoOptions) (repos []*Repository, count
 	if opts.OwnerID > 0 {
 		sess.And("repo.owner_id = ?", opts.OwnerID)
 	}
+	sess.And("repo.deleted_unix = 0")
 
 	// We need all fields (repo.*) in final list but only ID (repo.id) is good enough for counting.
 	count, err = sess.Clone().Distinct("repo.id").Count(new(Repository))
@@ -1960,6 +2076,37 @@ func DeleteOldRepositoryArchives() {
 	}
 }
 
+// PurgeDeletedRepositories permanently deletes repositories that have been
+// soft-deleted for longer than the retention window.
+func PurgeDeletedRepositories() {
+	if taskStatusTable.IsRunning(_PURGE_DELETED_REPOSITORIES) {
+		return
+	}
+	taskStatusTable.Start(_PURGE_DELETED_REPOSITORIES)
+	defer taskStatusTable.Stop(_PURGE_DELETED_REPOSITORIES)
+
+	log.Trace("Doing: PurgeDeletedRepositories")
+
+	repos := make([]*Repository, 0, 10)
+	deletedBefore := time.Now().Add(-conf.Cron.PurgeDeletedRepositories.Retention).Unix()
+	if err := x.Where("deleted_unix > 0 AND deleted_unix <= ?", deletedBefore).Find(&repos); err != nil {
+		log.Error("Failed to list deleted repositories: %v", err)
+		return
+	}
+
+	for _, repo := range repos {
+		if err := DeleteRepository(repo.OwnerID, repo.ID); err != nil {
+			desc := fmt.Sprintf("Failed to purge deleted repository [%d]: %v", repo.ID, err)
+			log.Warn(desc)
+			if err = Notices.Create(context.TODO(), NoticeTypeRepository, desc); err != nil {
+				log.Error("CreateRepositoryNotice: %v", err)
+			}
+			continue
+		}
+		log.Trace("Deleted repository purged: %d", repo.ID)
+	}
+}
+
 // DeleteRepositoryArchives deletes all repositories' archives.
 func DeleteRepositoryArchives() error {
 	if taskStatusTable.IsRunning(_CLEAN_OLD_ARCHIVES) {
@@ -2061,6 +2208,9 @@ const (
 	_GIT_FSCK           = "git_fsck"
 	_CHECK_REPO_STATS   = "check_repos_stats"
 	_CLEAN_OLD_ARCHIVES = "clean_old_archives"
+
+	_DELETE_EXPIRED_COLLABORATIONS = "delete_expired_collaborations"
+	_PURGE_DELETED_REPOSITORIES    = "purge_deleted_repositories"
 )
 
 // GitFsck calls 'git fsck' to check repository health.
@@ -2576,6 +2726,17 @@ func ForkRepository(doer, owner *User, baseRepo *Repository, name, desc string)
 		return nil, fmt.Errorf("Commit: %v", err)
 	}
 
+	if err = repo.RecalculateAccesses(); err != nil {
+		return nil, errors.Wrap(err, "recalculate accesses")
+	}
+
+	if owner.IsOrganization() {
+		err = Orgs.ApplyDefaultProtection(context.TODO(), owner.ID, repo.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "apply default branch protection")
+		}
+	}
+
 	// Remember visibility preference
 	err = Users.Update(context.TODO(), owner.ID, UpdateUserOptions{LastRepoVisibility: &repo.IsPrivate})
 	if err != nil {
@@ -2638,105 +2799,9 @@ func (repo *Repository) CreateNewBranch(oldBranch, newBranch string) (err error)
 	return nil
 }
 
-// Deprecated: Use Perms.SetRepoPerms instead.
-func (repo *Repository) refreshAccesses(e Engine, accessMap map[int64]AccessMode) (err error) {
-	newAccesses := make([]Access, 0, len(accessMap))
-	for userID, mode := range accessMap {
-		newAccesses = append(newAccesses, Access{
-			UserID: userID,
-			RepoID: repo.ID,
-			Mode:   mode,
-		})
-	}
-
-	// Delete old accesses and insert new ones for repository.
-	if _, err = e.Delete(&Access{RepoID: repo.ID}); err != nil {
-		return fmt.Errorf("delete old accesses: %v", err)
-	} else if _, err = e.Insert(newAccesses); err != nil {
-		return fmt.Errorf("insert new accesses: %v", err)
-	}
-	return nil
-}
-
-// refreshCollaboratorAccesses retrieves repository collaborations with their access modes.
-func (repo *Repository) refreshCollaboratorAccesses(e Engine, accessMap map[int64]AccessMode) error {
-	collaborations, err := repo.getCollaborations(e)
-	if err != nil {
-		return fmt.Errorf("getCollaborations: %v", err)
-	}
-	for _, c := range collaborations {
-		accessMap[c.UserID] = c.Mode
-	}
-	return nil
-}
-
-// recalculateTeamAccesses recalculates new accesses for teams of an organization
-// except the team whose ID is given. It is used to assign a team ID when
-// remove repository from that team.
-func (repo *Repository) recalculateTeamAccesses(e Engine, ignTeamID int64) (err error) {
-	accessMap := make(map[int64]AccessMode, 20)
-
-	if err = repo.getOwner(e); err != nil {
-		return err
-	} else if !repo.Owner.IsOrganization() {
-		return fmt.Errorf("owner is not an organization: %d", repo.OwnerID)
-	}
-
-	if err = repo.refreshCollaboratorAccesses(e, accessMap); err != nil {
-		return fmt.Errorf("refreshCollaboratorAccesses: %v", err)
-	}
-
-	if err = repo.Owner.getTeams(e); err != nil {
-		return err
-	}
-
-	maxAccessMode := func(modes ...AccessMode) AccessMode {
-		max := AccessModeNone
-		for _, mode := range modes {
-			if mode > max {
-				max = mode
-			}
-		}
-		return max
-	}
-
-	for _, t := range repo.Owner.Teams {
-		if t.ID == ignTeamID {
-			continue
-		}
-
-		// Owner team gets owner access, and skip for teams that do not
-		// have relations with repository.
-		if t.IsOwnerTeam() {
-			t.Authorize = AccessModeOwner
-		} else if !t.hasRepository(e, repo.ID) {
-			continue
-		}
-
-		if err = t.getMembers(e); err != nil {
-			return fmt.Errorf("getMembers '%d': %v", t.ID, err)
-		}
-		for _, m := range t.Members {
-			accessMap[m.ID] = maxAccessMode(accessMap[m.ID], t.Authorize)
-		}
-	}
-
-	return repo.refreshAccesses(e, accessMap)
-}
-
-func (repo *Repository) recalculateAccesses(e Engine) error {
-	if repo.Owner.IsOrganization() {
-		return repo.recalculateTeamAccesses(e, 0)
-	}
-
-	accessMap := make(map[int64]AccessMode, 10)
-	if err := repo.refreshCollaboratorAccesses(e, accessMap); err != nil {
-		return fmt.Errorf("refreshCollaboratorAccesses: %v", err)
-	}
-	return repo.refreshAccesses(e, accessMap)
-}
-
 // RecalculateAccesses recalculates all accesses for repository.
+//
+// Deprecated: Use Perms.RecalculateAccesses instead.
 func (repo *Repository) RecalculateAccesses() error {
-	return repo.recalculateAccesses(x)
+	return Perms.RecalculateAccesses(context.TODO(), repo.ID)
 }
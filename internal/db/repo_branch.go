@@ -87,7 +87,7 @@ func (br *Branch) GetCommit() (*git.Commit, error) {
 }
 
 type ProtectBranchWhitelist struct {
-	ID              int64
+	ID              int64 `gorm:"primaryKey"`
 	ProtectBranchID int64
 	RepoID          int64  `xorm:"UNIQUE(protect_branch_whitelist)"`
 	Name            string `xorm:"UNIQUE(protect_branch_whitelist)"`
@@ -102,14 +102,14 @@ func IsUserInProtectBranchWhitelist(repoID, userID int64, branch string) bool {
 
 // ProtectBranch contains options of a protected branch.
 type ProtectBranch struct {
-	ID                 int64
+	ID                 int64  `gorm:"primaryKey"`
 	RepoID             int64  `xorm:"UNIQUE(protect_branch)"`
 	Name               string `xorm:"UNIQUE(protect_branch)"`
 	Protected          bool
 	RequirePullRequest bool
 	EnableWhitelist    bool
-	WhitelistUserIDs   string `xorm:"TEXT"`
-	WhitelistTeamIDs   string `xorm:"TEXT"`
+	WhitelistUserIDs   string `xorm:"TEXT" gorm:"column:whitelist_user_i_ds;type:TEXT"`
+	WhitelistTeamIDs   string `xorm:"TEXT" gorm:"column:whitelist_team_i_ds;type:TEXT"`
 }
 
 // GetProtectBranchOfRepoByName returns *ProtectBranch by branch name in given repository.
@@ -5,7 +5,9 @@
 package db
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	log "unknwon.dev/clog/v2"
 
@@ -18,6 +20,14 @@ type Collaboration struct {
 	UserID int64      `xorm:"UNIQUE(s) INDEX NOT NULL" gorm:"uniqueIndex:collaboration_user_repo_unique;index;not null"`
 	RepoID int64      `xorm:"UNIQUE(s) INDEX NOT NULL" gorm:"uniqueIndex:collaboration_user_repo_unique;index;not null"`
 	Mode   AccessMode `xorm:"DEFAULT 2 NOT NULL" gorm:"not null;default:2"`
+	// The time when the collaboration expires, 0 means never.
+	ExpiresUnix int64 `xorm:"NOT NULL DEFAULT 0" gorm:"not null;default:0"`
+}
+
+// IsExpired returns true if the collaboration has an expiry time and it has
+// passed.
+func (c *Collaboration) IsExpired() bool {
+	return c.ExpiresUnix > 0 && c.ExpiresUnix <= time.Now().Unix()
 }
 
 func (c *Collaboration) ModeI18nKey() string {
@@ -74,11 +84,12 @@ func (repo *Repository) AddCollaborator(u *User) error {
 
 	if _, err = sess.Insert(collaboration); err != nil {
 		return err
-	} else if err = repo.recalculateAccesses(sess); err != nil {
-		return fmt.Errorf("recalculateAccesses [repo_id: %v]: %v", repo.ID, err)
+	} else if err = sess.Commit(); err != nil {
+		return err
+	} else if err = repo.RecalculateAccesses(); err != nil {
+		return fmt.Errorf("recalculate accesses [repo_id: %v]: %v", repo.ID, err)
 	}
-
-	return sess.Commit()
+	return nil
 }
 
 func (repo *Repository) getCollaborations(e Engine) ([]*Collaboration, error) {
@@ -151,48 +162,10 @@ func (repo *Repository) ChangeCollaborationAccessMode(userID int64, mode AccessM
 	}
 	collaboration.Mode = mode
 
-	// If it's an organizational repository, merge with team access level for highest permission
-	if repo.Owner.IsOrganization() {
-		teams, err := GetUserTeams(repo.OwnerID, userID)
-		if err != nil {
-			return fmt.Errorf("GetUserTeams: [org_id: %d, user_id: %d]: %v", repo.OwnerID, userID, err)
-		}
-		for i := range teams {
-			if mode < teams[i].Authorize {
-				mode = teams[i].Authorize
-			}
-		}
-	}
-
-	sess := x.NewSession()
-	defer sess.Close()
-	if err = sess.Begin(); err != nil {
-		return err
-	}
-
-	if _, err = sess.ID(collaboration.ID).AllCols().Update(collaboration); err != nil {
+	if _, err = x.ID(collaboration.ID).AllCols().Update(collaboration); err != nil {
 		return fmt.Errorf("update collaboration: %v", err)
 	}
-
-	access := &Access{
-		UserID: userID,
-		RepoID: repo.ID,
-	}
-	has, err = sess.Get(access)
-	if err != nil {
-		return fmt.Errorf("get access record: %v", err)
-	}
-	if has {
-		_, err = sess.Exec("UPDATE access SET mode = ? WHERE user_id = ? AND repo_id = ?", mode, userID, repo.ID)
-	} else {
-		access.Mode = mode
-		_, err = sess.Insert(access)
-	}
-	if err != nil {
-		return fmt.Errorf("update/insert access table: %v", err)
-	}
-
-	return sess.Commit()
+	return repo.RecalculateAccesses()
 }
 
 // DeleteCollaboration removes collaboration relation between the user and repository.
@@ -214,13 +187,28 @@ func DeleteCollaboration(repo *Repository, userID int64) (err error) {
 
 	if has, err := sess.Delete(collaboration); err != nil || has == 0 {
 		return err
-	} else if err = repo.recalculateAccesses(sess); err != nil {
+	} else if err = sess.Commit(); err != nil {
 		return err
 	}
-
-	return sess.Commit()
+	return repo.RecalculateAccesses()
 }
 
 func (repo *Repository) DeleteCollaboration(userID int64) error {
 	return DeleteCollaboration(repo, userID)
 }
+
+// DeleteExpiredCollaborations deletes all expired collaborations along with
+// their accesses.
+func DeleteExpiredCollaborations() {
+	if taskStatusTable.IsRunning(_DELETE_EXPIRED_COLLABORATIONS) {
+		return
+	}
+	taskStatusTable.Start(_DELETE_EXPIRED_COLLABORATIONS)
+	defer taskStatusTable.Stop(_DELETE_EXPIRED_COLLABORATIONS)
+
+	log.Trace("Doing: DeleteExpiredCollaborations")
+
+	if err := Repos.DeleteExpiredCollaborators(context.TODO()); err != nil {
+		log.Error("Failed to delete expired collaborators: %v", err)
+	}
+}
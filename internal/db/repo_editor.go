@@ -51,7 +51,14 @@ type ComposeHookEnvsOptions struct {
 	RepoPath  string
 }
 
-func ComposeHookEnvs(opts ComposeHookEnvsOptions) []string {
+// ComposeHookEnvs returns the environment variables that Git hooks rely on to
+// identify the pusher and the repository. It returns an error if the
+// authenticated user is not given.
+func ComposeHookEnvs(opts ComposeHookEnvsOptions) ([]string, error) {
+	if opts.AuthUser == nil {
+		return nil, errors.New("authenticated user is required")
+	}
+
 	envs := []string{
 		"SSH_ORIGINAL_COMMAND=1",
 		ENV_AUTH_USER_ID + "=" + com.ToStr(opts.AuthUser.ID),
@@ -63,7 +70,7 @@ func ComposeHookEnvs(opts ComposeHookEnvsOptions) []string {
 		ENV_REPO_NAME + "=" + opts.RepoName,
 		ENV_REPO_CUSTOM_HOOKS_PATH + "=" + filepath.Join(opts.RepoPath, "custom_hooks"),
 	}
-	return envs
+	return envs, nil
 }
 
 // ___________    .___.__  __    ___________.__.__
@@ -198,17 +205,22 @@ func (repo *Repository) UpdateRepoFile(doer *User, opts UpdateRepoFileOptions) (
 		return fmt.Errorf("commit changes on %q: %v", localPath, err)
 	}
 
+	envs, err := ComposeHookEnvs(ComposeHookEnvsOptions{
+		AuthUser:  doer,
+		OwnerName: repo.MustOwner().Name,
+		OwnerSalt: repo.MustOwner().Salt,
+		RepoID:    repo.ID,
+		RepoName:  repo.Name,
+		RepoPath:  repo.RepoPath(),
+	})
+	if err != nil {
+		return fmt.Errorf("compose hook envs: %v", err)
+	}
+
 	err = git.Push(localPath, "origin", opts.NewBranch,
 		git.PushOptions{
 			CommandOptions: git.CommandOptions{
-				Envs: ComposeHookEnvs(ComposeHookEnvsOptions{
-					AuthUser:  doer,
-					OwnerName: repo.MustOwner().Name,
-					OwnerSalt: repo.MustOwner().Salt,
-					RepoID:    repo.ID,
-					RepoName:  repo.Name,
-					RepoPath:  repo.RepoPath(),
-				}),
+				Envs: envs,
 			},
 		},
 	)
@@ -320,17 +332,22 @@ func (repo *Repository) DeleteRepoFile(doer *User, opts DeleteRepoFileOptions) (
 		return fmt.Errorf("commit changes to %q: %v", localPath, err)
 	}
 
+	envs, err := ComposeHookEnvs(ComposeHookEnvsOptions{
+		AuthUser:  doer,
+		OwnerName: repo.MustOwner().Name,
+		OwnerSalt: repo.MustOwner().Salt,
+		RepoID:    repo.ID,
+		RepoName:  repo.Name,
+		RepoPath:  repo.RepoPath(),
+	})
+	if err != nil {
+		return fmt.Errorf("compose hook envs: %v", err)
+	}
+
 	err = git.Push(localPath, "origin", opts.NewBranch,
 		git.PushOptions{
 			CommandOptions: git.CommandOptions{
-				Envs: ComposeHookEnvs(ComposeHookEnvsOptions{
-					AuthUser:  doer,
-					OwnerName: repo.MustOwner().Name,
-					OwnerSalt: repo.MustOwner().Salt,
-					RepoID:    repo.ID,
-					RepoName:  repo.Name,
-					RepoPath:  repo.RepoPath(),
-				}),
+				Envs: envs,
 			},
 		},
 	)
@@ -571,17 +588,22 @@ func (repo *Repository) UploadRepoFiles(doer *User, opts UploadRepoFileOptions)
 		return fmt.Errorf("commit changes on %q: %v", localPath, err)
 	}
 
+	envs, err := ComposeHookEnvs(ComposeHookEnvsOptions{
+		AuthUser:  doer,
+		OwnerName: repo.MustOwner().Name,
+		OwnerSalt: repo.MustOwner().Salt,
+		RepoID:    repo.ID,
+		RepoName:  repo.Name,
+		RepoPath:  repo.RepoPath(),
+	})
+	if err != nil {
+		return fmt.Errorf("compose hook envs: %v", err)
+	}
+
 	err = git.Push(localPath, "origin", opts.NewBranch,
 		git.PushOptions{
 			CommandOptions: git.CommandOptions{
-				Envs: ComposeHookEnvs(ComposeHookEnvsOptions{
-					AuthUser:  doer,
-					OwnerName: repo.MustOwner().Name,
-					OwnerSalt: repo.MustOwner().Salt,
-					RepoID:    repo.ID,
-					RepoName:  repo.Name,
-					RepoPath:  repo.RepoPath(),
-				}),
+				Envs: envs,
 			},
 		},
 	)
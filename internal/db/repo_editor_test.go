@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsRepositoryGitPath(t *testing.T) {
@@ -57,3 +58,22 @@ func TestIsRepositoryGitPath(t *testing.T) {
 		})
 	}
 }
+
+func TestComposeHookEnvs(t *testing.T) {
+	_, err := ComposeHookEnvs(ComposeHookEnvsOptions{RepoID: 1})
+	assert.Error(t, err)
+
+	envs, err := ComposeHookEnvs(
+		ComposeHookEnvsOptions{
+			AuthUser:  &User{ID: 1, Name: "alice", Email: "alice@example.com"},
+			OwnerName: "alice",
+			RepoID:    2,
+			RepoName:  "repo1",
+			RepoPath:  "/repos/alice/repo1.git",
+		},
+	)
+	require.NoError(t, err)
+	assert.Contains(t, envs, ENV_AUTH_USER_ID+"=1")
+	assert.Contains(t, envs, ENV_AUTH_USER_EMAIL+"=alice@example.com")
+	assert.Contains(t, envs, ENV_REPO_ID+"=2")
+}
@@ -7,14 +7,26 @@ package db
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/gogs/git-module"
 	api "github.com/gogs/go-gogs-client"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	log "unknwon.dev/clog/v2"
 
+	"gogs.io/gogs/internal/conf"
 	"gogs.io/gogs/internal/errutil"
+	"gogs.io/gogs/internal/lazyregexp"
+	"gogs.io/gogs/internal/markup"
+	"gogs.io/gogs/internal/osutil"
+	"gogs.io/gogs/internal/process"
 	"gogs.io/gogs/internal/repoutil"
 )
 
@@ -36,24 +48,166 @@ type ReposStore interface {
 	// included.
 	GetByCollaboratorIDWithAccessMode(ctx context.Context, collaboratorID int64) (map[*Repository]AccessMode, error)
 	// GetByID returns the repository with given ID. It returns ErrRepoNotExist when
-	// not found.
+	// not found or soft-deleted.
 	GetByID(ctx context.Context, id int64) (*Repository, error)
 	// GetByName returns the repository with given owner and name. It returns
-	// ErrRepoNotExist when not found.
+	// ErrRepoNotExist when not found or soft-deleted.
 	GetByName(ctx context.Context, ownerID int64, name string) (*Repository, error)
+	// IsNameAvailable returns true if the given name can be used by a new
+	// repository of the owner, the name is matched case-insensitively and names
+	// held by soft-deleted repositories are not available. It returns
+	// ErrNameNotAllowed when the name is not allowed.
+	IsNameAvailable(ctx context.Context, ownerID int64, name string) (bool, error)
+	// Rename renames the repository and records a redirect from its old name, so
+	// that links and clone URLs using the old name keep working. A redirect that
+	// occupies the new name is removed, e.g. when a repository reclaims its old
+	// name. It returns ErrRepoNotExist when not found, ErrNameNotAllowed when the
+	// new name is not allowed, or ErrRepoAlreadyExist when a repository with the
+	// same name already exists for the owner.
+	Rename(ctx context.Context, repoID int64, newName string) error
+	// GetByRedirect returns the repository that the given owner and old name of
+	// a renamed repository redirect to. It returns ErrRepoNotExist when there is
+	// no such redirect, or the repository has been deleted or soft-deleted.
+	GetByRedirect(ctx context.Context, ownerID int64, name string) (*Repository, error)
 	// Star marks the user to star the repository.
 	Star(ctx context.Context, userID, repoID int64) error
-	// Touch updates the updated time to the current time and removes the bare state
-	// of the given repository.
-	Touch(ctx context.Context, id int64) error
+	// Touch updates the updated time to the given Unix timestamp and removes the
+	// bare state of the given repository. The updated time is left unchanged if
+	// it is already newer, e.g. set by a concurrent push.
+	Touch(ctx context.Context, repoID int64, unix int64) error
+	// UpdateSize updates the size (in bytes) of the given repository on disk.
+	UpdateSize(ctx context.Context, repoID int64, size int64) error
+	// SetExternalTracker sets the external issue tracker of the repository, which
+	// disables issues of the repository and makes issue references to be
+	// rendered as links to the external tracker. An empty opts.URL disables the
+	// external tracker. It returns ErrRepoNotExist when not found, or
+	// ErrInvalidExternalTracker when any of options is invalid.
+	SetExternalTracker(ctx context.Context, repoID int64, opts SetExternalTrackerOptions) error
+	// SetPrivate sets the visibility of the repository and all of its forks, and
+	// recalculates accesses accordingly, i.e. accesses granted by the default
+	// repository permission of the owner organization only apply to public
+	// repositories. When a repository becomes private, watches of users who no
+	// longer have access to it are removed. It is a no-op when the visibility is
+	// unchanged, and returns ErrRepoNotExist when not found.
+	SetPrivate(ctx context.Context, repoID int64, private bool) error
 
 	// ListWatches returns all watches of the given repository.
 	ListWatches(ctx context.Context, repoID int64) ([]*Watch, error)
+	// ListWatchedByUserInOrg returns repositories owned by the given organization
+	// that the user is watching and still has read access to, sorted by the time
+	// of last update in descending order. Repositories that the user has lost
+	// access to are excluded even if the watch remains.
+	ListWatchedByUserInOrg(ctx context.Context, orgID, userID int64) ([]*Repository, error)
 	// Watch marks the user to watch the repository.
 	Watch(ctx context.Context, userID, repoID int64) error
 
 	// HasForkedBy returns true if the given repository has forked by the given user.
 	HasForkedBy(ctx context.Context, repoID, userID int64) bool
+	// Fork creates a new repository record for the given owner as a fork of the
+	// base repository, and updates the number of forks of the base repository and
+	// the number of repositories of the owner. It returns ErrRepoAlreadyExist when
+	// a repository with same name already exists for the owner, or
+	// ErrRepoNotExist when the base repository does not exist or is private and
+	// not accessible by the doer.
+	Fork(ctx context.Context, ownerID, baseRepoID int64, opts ForkOptions) (*Repository, error)
+	// CreateFromTemplate creates a new repository for the given owner from the
+	// template repository. The git content of the default branch of the template
+	// is copied as a single initial commit without history, and issue labels
+	// and issues are copied as requested by options. The new repository starts
+	// with fresh counters and is never a template itself. It returns
+	// ErrRepoNotExist when the template repository does not exist or is private
+	// and not accessible by the doer, ErrRepoNotTemplate when the repository is
+	// not a template, ErrReachLimitOfRepo when the owner cannot create more
+	// repositories, or ErrRepoAlreadyExist when a repository with same name
+	// already exists for the owner.
+	CreateFromTemplate(ctx context.Context, templateRepoID, ownerID int64, opts CreateFromTemplateOptions) (*Repository, error)
+
+	// SetCollaboratorExpiry sets the time in Unix seconds when the collaboration
+	// of the user on the repository expires, 0 means never. It returns an error
+	// wrapping gorm.ErrRecordNotFound when the user is not a collaborator of the
+	// repository. Expired collaborators have no access to the repository even
+	// before being deleted.
+	SetCollaboratorExpiry(ctx context.Context, repoID, userID int64, expires int64) error
+	// DeleteExpiredCollaborators deletes all expired collaborations and
+	// recalculates accesses of affected repositories.
+	DeleteExpiredCollaborators(ctx context.Context) error
+
+	// SetArchived sets whether the repository is archived. Archived repositories
+	// are excluded from listings of accessible repositories unless asked for. It
+	// returns ErrRepoNotExist when not found.
+	SetArchived(ctx context.Context, repoID int64, archived bool) error
+	// SoftDelete marks the repository as deleted, which excludes it from listings
+	// and makes it inaccessible by name until restored or purged after the
+	// retention window. It returns ErrRepoNotExist when not found or already
+	// soft-deleted.
+	SoftDelete(ctx context.Context, repoID int64) error
+	// Restore restores the soft-deleted repository. It returns ErrRepoNotExist
+	// when not found or not soft-deleted.
+	Restore(ctx context.Context, repoID int64) error
+	// TransferAllByOwner transfers all repositories owned by the user to the new
+	// owner, which can be either a user or an organization. Repositories whose
+	// names are already used by the new owner are skipped and returned. Accesses
+	// and team-repository relations are rewritten for every transferred
+	// repository.
+	TransferAllByOwner(ctx context.Context, fromUserID, toOwnerID int64) (skipped []*Repository, err error)
+
+	// AddDeployKey adds the public key content as a deploy key of the repository
+	// with given title, the content should have been verified by
+	// CheckPublicKeyString. A read-only deploy key is rejected on push. It returns
+	// ErrKeyAlreadyExist when the content is used by a user key,
+	// ErrDeployKeyAlreadyExist when the key is already a deploy key of the
+	// repository, or ErrDeployKeyNameAlreadyUsed when the title is used by another
+	// deploy key of the repository.
+	AddDeployKey(ctx context.Context, repoID int64, title, content string, readOnly bool) error
+
+	// AddProtectedTag adds a rule to protect tags whose names match the glob
+	// pattern (see path.Match) from being created, updated or deleted by users
+	// whose access mode to the repository is lower than the allowed mode, which
+	// must be one of AccessModeWrite, AccessModeAdmin or AccessModeOwner. It
+	// returns ErrProtectedTagAlreadyExist when a rule with the same pattern
+	// already exists for the repository.
+	AddProtectedTag(ctx context.Context, repoID int64, pattern string, allowedMode AccessMode) (*ProtectedTag, error)
+	// ListProtectedTags returns all protected tag rules of the repository, sorted
+	// by pattern in ascending order.
+	ListProtectedTags(ctx context.Context, repoID int64) ([]*ProtectedTag, error)
+
+	// CleanupOrphanedAccess deletes accesses and team-repository relations that
+	// reference repositories that no longer exist, and returns the number of
+	// rows deleted. Rows are deleted in batches to avoid locking large tables for a
+	// long time.
+	CleanupOrphanedAccess(ctx context.Context) (int64, error)
+
+	// ListMirrorsToSync returns a list of mirrors that are due to sync at the
+	// given time in Unix seconds, sorted by the time they were due in ascending
+	// order so the most overdue mirrors come first. Results are limited to the
+	// given limit. Mirrors whose repositories no longer exist are not included,
+	// nor are those that have failed to sync maxFailures times or more in a row
+	// unless maxFailures is 0.
+	ListMirrorsToSync(ctx context.Context, now int64, limit, maxFailures int) ([]*Mirror, error)
+	// SetMirrorSyncResult records the outcome of a sync of the mirror of the
+	// given repository. A successful sync updates the last sync time and clears
+	// the last error, a failed one records the error message and increments the
+	// number of consecutive failures.
+	SetMirrorSyncResult(ctx context.Context, repoID int64, success bool, errMsg string) error
+
+	// ListTopics returns all topics of the given repository, sorted by name in
+	// ascending order.
+	ListTopics(ctx context.Context, repoID int64) ([]*Topic, error)
+	// SetTopics replaces topics of the given repository with the given list.
+	// Topic names are normalized to lowercase and deduplicated. It returns
+	// ErrInvalidTopic when a topic name is not valid, or there are too many
+	// topics.
+	SetTopics(ctx context.Context, repoID int64, topics []string) error
+	// SearchByTopic returns a list of public and listed repositories that are
+	// tagged with the given topic, sorted by the time of last update in
+	// descending order. Results are paginated by given page and page size, and a
+	// total count of all results is also returned.
+	SearchByTopic(ctx context.Context, topic string, page, pageSize int) ([]*Repository, int64, error)
+
+	// ApplyOrgLabels copies issue label templates of the organization to the
+	// repository. Existing labels with same names have their colors updated, and
+	// other existing labels are left untouched.
+	ApplyOrgLabels(ctx context.Context, repoID, orgID int64) error
 }
 
 var Repos ReposStore
@@ -153,6 +307,7 @@ type CreateRepoOptions struct {
 	EnableWiki    bool
 	EnableIssues  bool
 	EnablePulls   bool
+	Unlisted      bool
 	Fork          bool
 	ForkID        int64
 }
@@ -163,16 +318,16 @@ func (db *repos) Create(ctx context.Context, ownerID int64, opts CreateRepoOptio
 		return nil, err
 	}
 
-	_, err = db.GetByName(ctx, ownerID, opts.Name)
-	if err == nil {
+	available, err := db.IsNameAvailable(ctx, ownerID, opts.Name)
+	if err != nil {
+		return nil, err
+	} else if !available {
 		return nil, ErrRepoAlreadyExist{
 			args: errutil.Args{
 				"ownerID": ownerID,
 				"name":    opts.Name,
 			},
 		}
-	} else if !IsErrRepoNotExist(err) {
-		return nil, err
 	}
 
 	repo := &Repository{
@@ -182,6 +337,7 @@ func (db *repos) Create(ctx context.Context, ownerID int64, opts CreateRepoOptio
 		Description:   opts.Description,
 		DefaultBranch: opts.DefaultBranch,
 		IsPrivate:     opts.Private,
+		IsUnlisted:    opts.Unlisted,
 		IsMirror:      opts.Mirror,
 		EnableWiki:    opts.EnableWiki,
 		EnableIssues:  opts.EnableIssues,
@@ -203,20 +359,69 @@ func (db *repos) Create(ctx context.Context, ownerID int64, opts CreateRepoOptio
 	})
 }
 
+// accessibleRepositoryIDs returns a query for IDs of repositories that the user
+// has access to, i.e. public and listed ones, those owned by the user, or those
+// the user has access to through collaborations or team memberships. It
+// returns only public and listed repositories for anonymous users (userID <=
+// 0).
+//
+// NOTE: Accesses granted by expired collaborations may not have been swept yet,
+// these repositories are excluded regardless of other sources of access to err
+// on the safe side.
+func accessibleRepositoryIDs(tx *gorm.DB, userID int64) *gorm.DB {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT id FROM repository
+		WHERE
+			deleted_unix = 0
+		AND (
+				(is_private = FALSE AND is_unlisted = FALSE)
+			OR  owner_id = @userID
+			OR  id IN (
+					SELECT repo_id FROM access
+					WHERE
+						user_id = @userID
+					AND mode >= @AccessModeRead
+					AND repo_id NOT IN (
+						SELECT repo_id FROM collaboration
+						WHERE user_id = @userID AND expires_unix > 0 AND expires_unix <= @now
+					)
+				)
+		)
+	*/
+	query := tx.Model(&Repository{}).Select("id").Where("deleted_unix = 0")
+	if userID <= 0 {
+		return query.Where("is_private = ? AND is_unlisted = ?", false, false)
+	}
+	return query.Where("(is_private = ? AND is_unlisted = ?) OR owner_id = ? OR id IN (?)",
+		false, false,
+		userID,
+		tx.Model(&Access{}).
+			Select("repo_id").
+			Where("user_id = ? AND mode >= ?", userID, AccessModeRead).
+			Where("repo_id NOT IN (?)",
+				tx.Model(&Collaboration{}).
+					Select("repo_id").
+					Where("user_id = ? AND expires_unix > 0 AND expires_unix <= ?", userID, tx.NowFunc().Unix()),
+			),
+	)
+}
+
 func (db *repos) GetByCollaboratorID(ctx context.Context, collaboratorID int64, limit int, orderBy string) ([]*Repository, error) {
 	/*
 		Equivalent SQL for PostgreSQL:
 
 		SELECT * FROM repository
 		JOIN access ON access.repo_id = repository.id AND access.user_id = @collaboratorID
-		WHERE access.mode >= @accessModeRead
+		WHERE access.mode >= @accessModeRead AND repository.deleted_unix = 0
 		ORDER BY @orderBy
 		LIMIT @limit
 	*/
 	var repos []*Repository
 	return repos, db.WithContext(ctx).
 		Joins("JOIN access ON access.repo_id = repository.id AND access.user_id = ?", collaboratorID).
-		Where("access.mode >= ?", AccessModeRead).
+		Where("access.mode >= ? AND repository.deleted_unix = 0", AccessModeRead).
 		Order(orderBy).
 		Limit(limit).
 		Find(&repos).
@@ -232,7 +437,7 @@ func (db *repos) GetByCollaboratorIDWithAccessMode(ctx context.Context, collabor
 			access.mode
 		FROM repository
 		JOIN access ON access.repo_id = repository.id AND access.user_id = @collaboratorID
-		WHERE access.mode >= @accessModeRead
+		WHERE access.mode >= @accessModeRead AND repository.deleted_unix = 0
 	*/
 	var reposWithAccessMode []*struct {
 		*Repository
@@ -242,7 +447,7 @@ func (db *repos) GetByCollaboratorIDWithAccessMode(ctx context.Context, collabor
 		Select("repository.*", "access.mode").
 		Table("repository").
 		Joins("JOIN access ON access.repo_id = repository.id AND access.user_id = ?", collaboratorID).
-		Where("access.mode >= ?", AccessModeRead).
+		Where("access.mode >= ? AND repository.deleted_unix = 0", AccessModeRead).
 		Find(&reposWithAccessMode).
 		Error
 	if err != nil {
@@ -277,7 +482,7 @@ func (ErrRepoNotExist) NotFound() bool {
 
 func (db *repos) GetByID(ctx context.Context, id int64) (*Repository, error) {
 	repo := new(Repository)
-	err := db.WithContext(ctx).Where("id = ?", id).First(repo).Error
+	err := db.WithContext(ctx).Where("id = ? AND deleted_unix = 0", id).First(repo).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, ErrRepoNotExist{errutil.Args{"repoID": id}}
@@ -290,7 +495,7 @@ func (db *repos) GetByID(ctx context.Context, id int64) (*Repository, error) {
 func (db *repos) GetByName(ctx context.Context, ownerID int64, name string) (*Repository, error) {
 	repo := new(Repository)
 	err := db.WithContext(ctx).
-		Where("owner_id = ? AND lower_name = ?", ownerID, strings.ToLower(name)).
+		Where("owner_id = ? AND lower_name = ? AND deleted_unix = 0", ownerID, strings.ToLower(name)).
 		First(repo).
 		Error
 	if err != nil {
@@ -307,6 +512,144 @@ func (db *repos) GetByName(ctx context.Context, ownerID int64, name string) (*Re
 	return repo, nil
 }
 
+func (db *repos) IsNameAvailable(ctx context.Context, ownerID int64, name string) (bool, error) {
+	err := isRepoNameAllowed(name)
+	if err != nil {
+		return false, err
+	}
+
+	// NOTE: Soft-deleted repositories still hold their names until purged.
+	var count int64
+	err = db.WithContext(ctx).
+		Model(&Repository{}).
+		Where("owner_id = ? AND lower_name = ?", ownerID, strings.ToLower(name)).
+		Count(&count).
+		Error
+	if err != nil {
+		return false, errors.Wrap(err, "count")
+	}
+	return count == 0, nil
+}
+
+// RepoRedirect is a redirect from an old name of a renamed repository.
+type RepoRedirect struct {
+	ID        int64  `gorm:"primaryKey"`
+	OwnerID   int64  `gorm:"uniqueIndex:repo_redirect_owner_name_unique;not null"`
+	LowerName string `gorm:"type:VARCHAR(255);uniqueIndex:repo_redirect_owner_name_unique;not null"`
+	RepoID    int64  `gorm:"index;not null"`
+}
+
+func (db *repos) Rename(ctx context.Context, repoID int64, newName string) error {
+	err := isRepoNameAllowed(newName)
+	if err != nil {
+		return err
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		repo := new(Repository)
+		err := tx.Where("id = ?", repoID).First(repo).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrRepoNotExist{args: errutil.Args{"repoID": repoID}}
+			}
+			return errors.Wrap(err, "get repository")
+		} else if repo.Name == newName {
+			return nil
+		}
+
+		// NOTE: Soft-deleted repositories still hold their names until purged.
+		newLowerName := strings.ToLower(newName)
+		var count int64
+		err = tx.Model(&Repository{}).
+			Where("owner_id = ? AND lower_name = ? AND id != ?", repo.OwnerID, newLowerName, repo.ID).
+			Count(&count).
+			Error
+		if err != nil {
+			return errors.Wrap(err, "count repositories with the new name")
+		} else if count > 0 {
+			return ErrRepoAlreadyExist{
+				args: errutil.Args{
+					"ownerID": repo.OwnerID,
+					"name":    newName,
+				},
+			}
+		}
+
+		// Redirects are only needed when the name is changed other than in case.
+		if repo.LowerName != newLowerName {
+			err = tx.Where("owner_id = ? AND lower_name = ?", repo.OwnerID, newLowerName).Delete(&RepoRedirect{}).Error
+			if err != nil {
+				return errors.Wrap(err, "delete redirect of the new name")
+			}
+
+			/*
+				Equivalent SQL for PostgreSQL:
+
+				INSERT INTO repo_redirect (owner_id, lower_name, repo_id)
+				VALUES (@ownerID, @oldLowerName, @repoID)
+				ON CONFLICT (owner_id, lower_name) DO UPDATE SET repo_id = @repoID
+			*/
+			err = tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "owner_id"}, {Name: "lower_name"}},
+				DoUpdates: clause.AssignmentColumns([]string{"repo_id"}),
+			}).Create(&RepoRedirect{
+				OwnerID:   repo.OwnerID,
+				LowerName: repo.LowerName,
+				RepoID:    repo.ID,
+			}).Error
+			if err != nil {
+				return errors.Wrap(err, "create redirect of the old name")
+			}
+		}
+
+		err = tx.Model(&Repository{}).
+			Where("id = ?", repo.ID).
+			Updates(map[string]any{
+				"name":         newName,
+				"lower_name":   newLowerName,
+				"updated_unix": tx.NowFunc().Unix(),
+			}).
+			Error
+		if err != nil {
+			return errors.Wrap(err, "update name")
+		}
+		return nil
+	})
+}
+
+func (db *repos) GetByRedirect(ctx context.Context, ownerID int64, name string) (*Repository, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT repository.* FROM repository
+		JOIN repo_redirect ON repo_redirect.repo_id = repository.id
+		WHERE
+			repo_redirect.owner_id = @ownerID
+		AND repo_redirect.lower_name = @name
+		AND repository.deleted_unix = 0
+		LIMIT 1
+	*/
+	repo := new(Repository)
+	err := db.WithContext(ctx).
+		Joins("JOIN repo_redirect ON repo_redirect.repo_id = repository.id").
+		Where("repo_redirect.owner_id = ? AND repo_redirect.lower_name = ?", ownerID, strings.ToLower(name)).
+		Where("repository.deleted_unix = 0").
+		First(repo).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRepoNotExist{
+				args: errutil.Args{
+					"ownerID": ownerID,
+					"name":    name,
+				},
+			}
+		}
+		return nil, errors.Wrap(err, "get repository by redirect")
+	}
+	return repo, nil
+}
+
 func (db *repos) recountStars(tx *gorm.DB, userID, repoID int64) error {
 	/*
 		Equivalent SQL for PostgreSQL:
@@ -367,22 +710,255 @@ func (db *repos) Star(ctx context.Context, userID, repoID int64) error {
 	})
 }
 
-func (db *repos) Touch(ctx context.Context, id int64) error {
+func (db *repos) Touch(ctx context.Context, repoID int64, unix int64) error {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		UPDATE repository
+		SET
+			is_bare = FALSE,
+			updated_unix = CASE WHEN updated_unix < @unix THEN @unix ELSE updated_unix END
+		WHERE id = @repoID
+	*/
 	return db.WithContext(ctx).
 		Model(new(Repository)).
-		Where("id = ?", id).
+		Where("id = ?", repoID).
 		Updates(map[string]any{
 			"is_bare":      false,
-			"updated_unix": db.NowFunc().Unix(),
+			"updated_unix": gorm.Expr("CASE WHEN updated_unix < ? THEN ? ELSE updated_unix END", unix, unix),
 		}).
 		Error
 }
 
+func (db *repos) UpdateSize(ctx context.Context, repoID int64, size int64) error {
+	return db.WithContext(ctx).
+		Model(new(Repository)).
+		Where("id = ?", repoID).
+		Update("size", size).
+		Error
+}
+
+type SetExternalTrackerOptions struct {
+	// The URL of the external issue tracker, empty means to disable the external
+	// issue tracker.
+	URL string
+	// The URL format of external issues, placeholders "{user}", "{repo}" and
+	// "{index}" are replaced by the owner name, repository name and issue ID
+	// respectively.
+	Format string
+	// The style of issue IDs, either markup.IssueNameStyleNumeric or
+	// markup.IssueNameStyleAlphanumeric. Default is markup.IssueNameStyleNumeric.
+	Style string
+}
+
+type ErrInvalidExternalTracker struct {
+	args errutil.Args
+}
+
+// IsErrInvalidExternalTracker returns true if the underlying error has the type
+// ErrInvalidExternalTracker.
+func IsErrInvalidExternalTracker(err error) bool {
+	_, ok := errors.Cause(err).(ErrInvalidExternalTracker)
+	return ok
+}
+
+func (err ErrInvalidExternalTracker) Error() string {
+	return fmt.Sprintf("invalid external issue tracker: %v", err.args)
+}
+
+// isHTTPURL returns true if the given string is an absolute HTTP(S) URL.
+func isHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func (db *repos) SetExternalTracker(ctx context.Context, repoID int64, opts SetExternalTrackerOptions) error {
+	updates := map[string]any{
+		"enable_external_tracker": false,
+	}
+	if opts.URL != "" {
+		if opts.Style == "" {
+			opts.Style = markup.IssueNameStyleNumeric
+		}
+
+		switch {
+		case !isHTTPURL(opts.URL):
+			return ErrInvalidExternalTracker{args: errutil.Args{"repoID": repoID, "url": opts.URL}}
+		case !strings.Contains(opts.Format, "{index}") || !isHTTPURL(opts.Format):
+			return ErrInvalidExternalTracker{args: errutil.Args{"repoID": repoID, "format": opts.Format}}
+		case opts.Style != markup.IssueNameStyleNumeric && opts.Style != markup.IssueNameStyleAlphanumeric:
+			return ErrInvalidExternalTracker{args: errutil.Args{"repoID": repoID, "style": opts.Style}}
+		}
+
+		updates = map[string]any{
+			"enable_external_tracker": true,
+			"external_tracker_url":    opts.URL,
+			"external_tracker_format": opts.Format,
+			"external_tracker_style":  opts.Style,
+			// Issues of the repository are no longer accessible.
+			"allow_public_issues": false,
+		}
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Select("id").Where("id = ?", repoID).First(&Repository{}).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrRepoNotExist{args: errutil.Args{"repoID": repoID}}
+			}
+			return errors.Wrap(err, "get repository")
+		}
+
+		err = tx.Model(&Repository{}).Where("id = ?", repoID).Updates(updates).Error
+		if err != nil {
+			return errors.Wrap(err, "update")
+		}
+		return nil
+	})
+}
+
+func (db *repos) SetPrivate(ctx context.Context, repoID int64, private bool) error {
+	var changed []*Repository
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		repo := new(Repository)
+		err := tx.Select("id", "owner_id", "name", "is_private", "is_unlisted").Where("id = ?", repoID).First(repo).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrRepoNotExist{args: errutil.Args{"repoID": repoID}}
+			}
+			return errors.Wrap(err, "get repository")
+		} else if repo.IsPrivate == private {
+			return nil
+		}
+
+		// Visibility of forks is always in sync with their base repository, walk
+		// down the fork tree to collect all of them.
+		repos := []*Repository{repo}
+		for i := 0; i < len(repos); i++ {
+			var forks []*Repository
+			err = tx.Select("id", "owner_id", "name", "is_private", "is_unlisted").Where("fork_id = ?", repos[i].ID).Find(&forks).Error
+			if err != nil {
+				return errors.Wrapf(err, "list forks of repository %d", repos[i].ID)
+			}
+			repos = append(repos, forks...)
+		}
+
+		for _, r := range repos {
+			if r.IsPrivate == private {
+				continue
+			}
+
+			err = db.setPrivate(tx, r, private)
+			if err != nil {
+				return errors.Wrapf(err, "set visibility of repository %d", r.ID)
+			}
+			changed = append(changed, r)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Git daemon only serves public repositories that have the export file.
+	for _, r := range changed {
+		owner, err := NewUsersStore(db.DB).GetByID(ctx, r.OwnerID)
+		if err != nil {
+			log.Error("Failed to get owner of repository %d: %v", r.ID, err)
+			continue
+		}
+		syncGitDaemonExportFile(RepoPath(owner.Name, r.Name), private)
+	}
+	return nil
+}
+
+// setPrivate sets the visibility of the repository, the repository must be
+// loaded with at least "id", "owner_id" and "is_unlisted" columns.
+func (db *repos) setPrivate(tx *gorm.DB, repo *Repository, private bool) error {
+	err := tx.Model(&Repository{}).Where("id = ?", repo.ID).Update("is_private", private).Error
+	if err != nil {
+		return errors.Wrap(err, "update")
+	}
+
+	err = recalculateAccesses(tx, repo.ID)
+	if err != nil {
+		return errors.Wrap(err, "recalculate accesses")
+	}
+
+	err = tx.Model(&Action{}).Where("repo_id = ?", repo.ID).Update("is_private", private || repo.IsUnlisted).Error
+	if err != nil {
+		return errors.Wrap(err, "update action visibility")
+	}
+
+	if !private {
+		return nil
+	}
+
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		DELETE FROM watch
+		WHERE
+			repo_id = @repoID
+		AND user_id != @ownerID
+		AND user_id NOT IN (
+			SELECT user_id FROM access
+			WHERE repo_id = @repoID AND mode >= @accessModeRead
+		)
+	*/
+	err = tx.
+		Where("repo_id = ? AND user_id != ? AND user_id NOT IN (?)",
+			repo.ID,
+			repo.OwnerID,
+			tx.Model(&Access{}).Select("user_id").Where("repo_id = ? AND mode >= ?", repo.ID, AccessModeRead),
+		).
+		Delete(&Watch{}).
+		Error
+	if err != nil {
+		return errors.Wrap(err, "delete watches without access")
+	}
+	return db.recountWatches(tx, repo.ID)
+}
+
 func (db *repos) ListWatches(ctx context.Context, repoID int64) ([]*Watch, error) {
 	var watches []*Watch
 	return watches, db.WithContext(ctx).Where("repo_id = ?", repoID).Find(&watches).Error
 }
 
+func (db *repos) ListWatchedByUserInOrg(ctx context.Context, orgID, userID int64) ([]*Repository, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT repository.* FROM repository
+		JOIN watch ON watch.repo_id = repository.id AND watch.user_id = @userID
+		WHERE
+			repository.owner_id = @orgID
+		AND repository.deleted_unix = 0
+		AND (
+				repository.is_private = FALSE
+			OR  repository.id IN (
+					SELECT repo_id FROM access
+					WHERE user_id = @userID AND mode >= @accessModeRead
+				)
+		)
+		ORDER BY repository.updated_unix DESC
+	*/
+	var repos []*Repository
+	return repos, db.WithContext(ctx).
+		Joins("JOIN watch ON watch.repo_id = repository.id AND watch.user_id = ?", userID).
+		Where("repository.owner_id = ? AND repository.deleted_unix = 0", orgID).
+		Where("repository.is_private = ? OR repository.id IN (?)",
+			false,
+			db.WithContext(ctx).
+				Model(&Access{}).
+				Select("repo_id").
+				Where("user_id = ? AND mode >= ?", userID, AccessModeRead),
+		).
+		Order("repository.updated_unix DESC").
+		Find(&repos).
+		Error
+}
+
 func (db *repos) recountWatches(tx *gorm.DB, repoID int64) error {
 	/*
 		Equivalent SQL for PostgreSQL:
@@ -424,3 +1000,1397 @@ func (db *repos) HasForkedBy(ctx context.Context, repoID, userID int64) bool {
 	db.WithContext(ctx).Model(new(Repository)).Where("owner_id = ? AND fork_id = ?", userID, repoID).Count(&count)
 	return count > 0
 }
+
+type ForkOptions struct {
+	// The name of the fork, defaults to the name of the base repository.
+	Name string
+	// The description of the fork, defaults to the description of the base
+	// repository.
+	Description string
+	// The ID of the user who performs the fork, defaults to the owner.
+	DoerID int64
+}
+
+func (db *repos) Fork(ctx context.Context, ownerID, baseRepoID int64, opts ForkOptions) (*Repository, error) {
+	baseRepo, err := db.GetByID(ctx, baseRepoID)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.DoerID <= 0 {
+		opts.DoerID = ownerID
+	}
+	if baseRepo.IsPrivate &&
+		!NewPermsStore(db.DB).Authorize(ctx, opts.DoerID, baseRepo.ID, AccessModeRead,
+			AccessModeOptions{
+				OwnerID: baseRepo.OwnerID,
+				Private: baseRepo.IsPrivate,
+			},
+		) {
+		return nil, ErrRepoNotExist{args: errutil.Args{"repoID": baseRepoID}}
+	}
+
+	if opts.Name == "" {
+		opts.Name = baseRepo.Name
+	}
+	if opts.Description == "" {
+		opts.Description = baseRepo.Description
+	}
+
+	var repo *Repository
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		repo, err = NewReposStore(tx).Create(ctx, ownerID,
+			CreateRepoOptions{
+				Name:          opts.Name,
+				Description:   opts.Description,
+				DefaultBranch: baseRepo.DefaultBranch,
+				Private:       baseRepo.IsPrivate,
+				Unlisted:      baseRepo.IsUnlisted,
+				EnableWiki:    baseRepo.EnableWiki,
+				EnableIssues:  baseRepo.EnableIssues,
+				EnablePulls:   baseRepo.EnablePulls,
+				Fork:          true,
+				ForkID:        baseRepo.ID,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		/*
+			Equivalent SQL for PostgreSQL:
+
+			UPDATE repository
+			SET num_forks = num_forks + 1
+			WHERE id = @baseRepoID
+		*/
+		err = tx.Model(&Repository{}).
+			Where("id = ?", baseRepo.ID).
+			Update("num_forks", gorm.Expr("num_forks + 1")).
+			Error
+		if err != nil {
+			return errors.Wrap(err, `update "repository.num_forks"`)
+		}
+
+		/*
+			Equivalent SQL for PostgreSQL:
+
+			UPDATE "user"
+			SET num_repos = (
+				SELECT COUNT(*) FROM repository WHERE owner_id = @ownerID
+			)
+			WHERE id = @ownerID
+		*/
+		err = tx.Model(&User{}).
+			Where("id = ?", ownerID).
+			Update(
+				"num_repos",
+				tx.Model(&Repository{}).Select("COUNT(*)").Where("owner_id = ?", ownerID),
+			).
+			Error
+		if err != nil {
+			return errors.Wrap(err, `update "user.num_repos"`)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+var _ errutil.NotFound = (*ErrRepoNotTemplate)(nil)
+
+type ErrRepoNotTemplate struct {
+	args errutil.Args
+}
+
+// IsErrRepoNotTemplate returns true if the underlying error has the type
+// ErrRepoNotTemplate.
+func IsErrRepoNotTemplate(err error) bool {
+	_, ok := errors.Cause(err).(ErrRepoNotTemplate)
+	return ok
+}
+
+func (err ErrRepoNotTemplate) Error() string {
+	return fmt.Sprintf("repository is not a template: %v", err.args)
+}
+
+func (ErrRepoNotTemplate) NotFound() bool {
+	return true
+}
+
+type CreateFromTemplateOptions struct {
+	// The name of the new repository.
+	Name string
+	// The description of the new repository, defaults to the description of the
+	// template repository.
+	Description string
+	// Whether the new repository is private.
+	Private bool
+	// Whether to copy issue labels of the template repository.
+	IncludeLabels bool
+	// Whether to copy issues of the template repository. Pull requests, comments,
+	// milestones and assignees are never copied.
+	IncludeIssues bool
+	// The ID of the user who performs the creation, defaults to the owner. The
+	// doer is also the author of the initial commit.
+	DoerID int64
+}
+
+func (db *repos) CreateFromTemplate(ctx context.Context, templateRepoID, ownerID int64, opts CreateFromTemplateOptions) (*Repository, error) {
+	template, err := db.GetByID(ctx, templateRepoID)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.DoerID <= 0 {
+		opts.DoerID = ownerID
+	}
+	if template.IsPrivate &&
+		!NewPermsStore(db.DB).Authorize(ctx, opts.DoerID, template.ID, AccessModeRead,
+			AccessModeOptions{
+				OwnerID: template.OwnerID,
+				Private: template.IsPrivate,
+			},
+		) {
+		return nil, ErrRepoNotExist{args: errutil.Args{"repoID": templateRepoID}}
+	} else if !template.IsTemplate {
+		return nil, ErrRepoNotTemplate{args: errutil.Args{"repoID": templateRepoID}}
+	}
+
+	if opts.Description == "" {
+		opts.Description = template.Description
+	}
+
+	usersStore := NewUsersStore(db.DB)
+	templateOwner, err := usersStore.GetByID(ctx, template.OwnerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get template owner")
+	}
+	owner, err := usersStore.GetByID(ctx, ownerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get owner")
+	} else if !owner.canCreateRepo() {
+		return nil, ErrReachLimitOfRepo{Limit: owner.maxNumRepos()}
+	}
+	doer, err := usersStore.GetByID(ctx, opts.DoerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get doer")
+	}
+
+	var repo *Repository
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		repo, err = NewReposStore(tx).Create(ctx, ownerID,
+			CreateRepoOptions{
+				Name:          opts.Name,
+				Description:   opts.Description,
+				DefaultBranch: template.DefaultBranch,
+				Private:       opts.Private,
+				EnableWiki:    template.EnableWiki,
+				EnableIssues:  template.EnableIssues,
+				EnablePulls:   template.EnablePulls,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		orgsStore := &orgs{DB: tx}
+		if owner.IsOrganization() {
+			ownersTeam, err := orgsStore.ensureOwnersTeam(tx, owner.ID)
+			if err != nil {
+				return errors.Wrap(err, "ensure owners team")
+			}
+
+			err = tx.Create(
+				&TeamRepo{
+					OrgID:  owner.ID,
+					TeamID: ownersTeam.ID,
+					RepoID: repo.ID,
+				},
+			).Error
+			if err != nil {
+				return errors.Wrap(err, "add to owners team")
+			}
+
+			err = orgsStore.recountTeamRepos(tx, owner.ID)
+			if err != nil {
+				return errors.Wrap(err, "recount team repositories")
+			}
+		}
+
+		err = recalculateAccesses(tx, repo.ID)
+		if err != nil {
+			return errors.Wrap(err, "recalculate accesses")
+		}
+
+		err = orgsStore.recountRepos(tx, owner.ID)
+		if err != nil {
+			return errors.Wrap(err, "recount repositories")
+		}
+
+		err = copyTemplateIssues(tx, template.ID, repo.ID, opts.IncludeLabels, opts.IncludeIssues)
+		if err != nil {
+			return err
+		}
+
+		// The git content is copied last so that the new repository on disk is
+		// removed when anything fails before committing the transaction.
+		if template.IsBare {
+			return tx.Model(&Repository{}).Where("id = ?", repo.ID).Update("is_bare", true).Error
+		}
+
+		repoPath := RepoPath(owner.Name, repo.Name)
+		err = copyTemplateContent(RepoPath(templateOwner.Name, template.Name), repoPath, template.DefaultBranch, doer)
+		if err != nil {
+			RemoveAllWithNotice("Delete repository for template copy failure", repoPath)
+			return errors.Wrap(err, "copy template content")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return db.GetByID(ctx, repo.ID)
+}
+
+// copyTemplateIssues copies issue labels and/or issues of the template
+// repository to the new repository. Counters of the new repository and its
+// labels are set according to what has been copied.
+func copyTemplateIssues(tx *gorm.DB, templateRepoID, repoID int64, includeLabels, includeIssues bool) error {
+	labelIDs := make(map[int64]*Label) // Template label ID -> new label
+	if includeLabels {
+		var labels []*Label
+		err := tx.Where("repo_id = ?", templateRepoID).Order("id").Find(&labels).Error
+		if err != nil {
+			return errors.Wrap(err, "list template labels")
+		}
+		for _, l := range labels {
+			label := &Label{
+				RepoID: repoID,
+				Name:   l.Name,
+				Color:  l.Color,
+			}
+			err = tx.Create(label).Error
+			if err != nil {
+				return errors.Wrapf(err, "create label %q", l.Name)
+			}
+			labelIDs[l.ID] = label
+		}
+	}
+
+	if !includeIssues {
+		return nil
+	}
+
+	var issues []*Issue
+	err := tx.Where("repo_id = ? AND is_pull = ?", templateRepoID, false).Order("id").Find(&issues).Error
+	if err != nil {
+		return errors.Wrap(err, "list template issues")
+	}
+
+	var numClosedIssues int
+	now := tx.NowFunc().Unix()
+	for i, tmpl := range issues {
+		issue := &Issue{
+			RepoID:      repoID,
+			Index:       int64(i + 1),
+			PosterID:    tmpl.PosterID,
+			Title:       tmpl.Title,
+			Content:     tmpl.Content,
+			Priority:    tmpl.Priority,
+			IsClosed:    tmpl.IsClosed,
+			CreatedUnix: now,
+			UpdatedUnix: now,
+		}
+		err = tx.Create(issue).Error
+		if err != nil {
+			return errors.Wrapf(err, "create issue %d", issue.Index)
+		}
+		if issue.IsClosed {
+			numClosedIssues++
+		}
+
+		if len(labelIDs) == 0 {
+			continue
+		}
+
+		var templateLabelIDs []int64
+		err = tx.Model(&IssueLabel{}).Where("issue_id = ?", tmpl.ID).Order("label_id").Pluck("label_id", &templateLabelIDs).Error
+		if err != nil {
+			return errors.Wrapf(err, "list labels of template issue %d", tmpl.Index)
+		}
+		for _, templateLabelID := range templateLabelIDs {
+			label, ok := labelIDs[templateLabelID]
+			if !ok {
+				continue
+			}
+
+			err = tx.Create(&IssueLabel{IssueID: issue.ID, LabelID: label.ID}).Error
+			if err != nil {
+				return errors.Wrapf(err, "add label %q to issue %d", label.Name, issue.Index)
+			}
+			label.NumIssues++
+			if issue.IsClosed {
+				label.NumClosedIssues++
+			}
+		}
+	}
+
+	for _, label := range labelIDs {
+		if label.NumIssues == 0 {
+			continue
+		}
+
+		err = tx.Model(&Label{}).
+			Where("id = ?", label.ID).
+			Updates(map[string]any{
+				"num_issues":        label.NumIssues,
+				"num_closed_issues": label.NumClosedIssues,
+			}).
+			Error
+		if err != nil {
+			return errors.Wrapf(err, "update counters of label %q", label.Name)
+		}
+	}
+
+	err = tx.Model(&Repository{}).
+		Where("id = ?", repoID).
+		Updates(map[string]any{
+			"num_issues":        len(issues),
+			"num_closed_issues": numClosedIssues,
+		}).
+		Error
+	if err != nil {
+		return errors.Wrap(err, `update "repository.num_issues"`)
+	}
+	return nil
+}
+
+// copyTemplateContent initializes a bare repository at the given path with the
+// content of the given branch of the template repository, which is committed
+// by the doer as a single initial commit.
+func copyTemplateContent(templatePath, repoPath, branch string, doer *User) error {
+	if osutil.IsExist(repoPath) {
+		return errors.Errorf("path already exists: %s", repoPath)
+	}
+
+	err := git.Init(repoPath, git.InitOptions{Bare: true})
+	if err != nil {
+		return errors.Wrap(err, "init repository")
+	}
+	_, err = git.SymbolicRef(
+		repoPath,
+		git.SymbolicRefOptions{
+			Name: "HEAD",
+			Ref:  git.RefsHeads + branch,
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "set default branch")
+	}
+
+	tmpDir := filepath.Join(os.TempDir(), fmt.Sprintf("gogs-template-%d", time.Now().UnixNano()))
+	defer RemoveAllWithNotice("Delete temporary copy of template repository", tmpDir)
+
+	err = git.Clone(templatePath, tmpDir, git.CloneOptions{Branch: branch})
+	if err != nil {
+		return errors.Wrap(err, "clone template")
+	}
+
+	// Reset the history by re-initializing the working tree.
+	err = os.RemoveAll(filepath.Join(tmpDir, ".git"))
+	if err != nil {
+		return errors.Wrap(err, "remove template history")
+	}
+	err = git.Init(tmpDir)
+	if err != nil {
+		return errors.Wrap(err, "init working tree")
+	}
+	_, err = git.SymbolicRef(
+		tmpDir,
+		git.SymbolicRefOptions{
+			Name: "HEAD",
+			Ref:  git.RefsHeads + branch,
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "set working tree branch")
+	}
+
+	err = git.Add(tmpDir, git.AddOptions{All: true})
+	if err != nil {
+		return errors.Wrap(err, "add files")
+	}
+	err = git.CreateCommit(
+		tmpDir,
+		&git.Signature{
+			Name:  doer.DisplayName(),
+			Email: doer.Email,
+			When:  time.Now(),
+		},
+		"Initial commit",
+	)
+	if err != nil {
+		return errors.Wrap(err, "commit")
+	}
+
+	// NOTE: Push before creating delegate hooks so that the initial commit does
+	// not go through hooks that require the environment of a user push.
+	err = git.Push(tmpDir, repoPath, branch)
+	if err != nil {
+		return errors.Wrap(err, "push")
+	}
+
+	err = createDelegateHooks(repoPath)
+	if err != nil {
+		return errors.Wrap(err, "create delegate hooks")
+	}
+
+	_, stderr, err := process.ExecDir(-1, repoPath, fmt.Sprintf("copyTemplateContent 'git update-server-info': %s", repoPath), "git", "update-server-info")
+	if err != nil {
+		return errors.Errorf("git update-server-info: %s", stderr)
+	}
+	return nil
+}
+
+func (db *repos) SetCollaboratorExpiry(ctx context.Context, repoID, userID int64, expires int64) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		collaboration := new(Collaboration)
+		err := tx.Where("repo_id = ? AND user_id = ?", repoID, userID).First(collaboration).Error
+		if err != nil {
+			return errors.Wrap(err, "get collaboration")
+		}
+
+		err = tx.Model(collaboration).Update("expires_unix", expires).Error
+		if err != nil {
+			return errors.Wrap(err, "update")
+		}
+		return recalculateAccesses(tx, repoID)
+	})
+}
+
+func (db *repos) DeleteExpiredCollaborators(ctx context.Context) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var collaborations []*Collaboration
+		err := tx.Where("expires_unix > 0 AND expires_unix <= ?", tx.NowFunc().Unix()).Find(&collaborations).Error
+		if err != nil {
+			return errors.Wrap(err, "list expired collaborations")
+		} else if len(collaborations) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, 0, len(collaborations))
+		repoIDs := make(map[int64]struct{})
+		for _, c := range collaborations {
+			ids = append(ids, c.ID)
+			repoIDs[c.RepoID] = struct{}{}
+		}
+
+		err = tx.Where("id IN (?)", ids).Delete(&Collaboration{}).Error
+		if err != nil {
+			return errors.Wrap(err, "delete expired collaborations")
+		}
+
+		for repoID := range repoIDs {
+			err = recalculateAccesses(tx, repoID)
+			if err != nil {
+				return errors.Wrapf(err, "recalculate accesses for repository %d", repoID)
+			}
+		}
+		return nil
+	})
+}
+
+func (db *repos) SetArchived(ctx context.Context, repoID int64, archived bool) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(&Repository{}).Where("id = ? AND deleted_unix = 0", repoID).Count(&count).Error
+		if err != nil {
+			return errors.Wrap(err, "count repository")
+		} else if count == 0 {
+			return ErrRepoNotExist{args: errutil.Args{"repoID": repoID}}
+		}
+
+		err = tx.Model(&Repository{}).
+			Where("id = ?", repoID).
+			Update("is_archived", archived).
+			Error
+		if err != nil {
+			return errors.Wrap(err, "update")
+		}
+		return nil
+	})
+}
+
+func (db *repos) SoftDelete(ctx context.Context, repoID int64) error {
+	result := db.WithContext(ctx).
+		Model(&Repository{}).
+		Where("id = ? AND deleted_unix = 0", repoID).
+		Update("deleted_unix", db.NowFunc().Unix())
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected <= 0 {
+		return ErrRepoNotExist{args: errutil.Args{"repoID": repoID}}
+	}
+	return nil
+}
+
+func (db *repos) Restore(ctx context.Context, repoID int64) error {
+	result := db.WithContext(ctx).
+		Model(&Repository{}).
+		Where("id = ? AND deleted_unix > 0", repoID).
+		Update("deleted_unix", 0)
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected <= 0 {
+		return ErrRepoNotExist{args: errutil.Args{"repoID": repoID}}
+	}
+	return nil
+}
+
+func (db *repos) TransferAllByOwner(ctx context.Context, fromUserID, toOwnerID int64) ([]*Repository, error) {
+	usersStore := NewUsersStore(db.DB)
+	owner, err := usersStore.GetByID(ctx, fromUserID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get owner")
+	}
+	newOwner, err := usersStore.GetByID(ctx, toOwnerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get new owner")
+	} else if owner.ID == newOwner.ID {
+		return nil, nil
+	}
+
+	var skipped, transfers []*Repository
+	// Directories that have been moved on disk, they are moved back when the
+	// transaction is rolled back.
+	var moved [][2]string
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var repos []*Repository
+		err := tx.Where("owner_id = ?", owner.ID).Order("id ASC").Find(&repos).Error
+		if err != nil {
+			return errors.Wrap(err, "list repositories")
+		} else if len(repos) == 0 {
+			return nil
+		}
+
+		var existingNames []string
+		err = tx.Model(&Repository{}).Where("owner_id = ?", newOwner.ID).Pluck("lower_name", &existingNames).Error
+		if err != nil {
+			return errors.Wrap(err, "list repository names of the new owner")
+		}
+		nameUsed := make(map[string]bool, len(existingNames))
+		for _, name := range existingNames {
+			nameUsed[name] = true
+		}
+
+		transfers = make([]*Repository, 0, len(repos))
+		for _, repo := range repos {
+			if nameUsed[repo.LowerName] {
+				skipped = append(skipped, repo)
+				continue
+			}
+			transfers = append(transfers, repo)
+		}
+		if len(transfers) == 0 {
+			return nil
+		}
+
+		repoIDs := make([]int64, 0, len(transfers))
+		for _, repo := range transfers {
+			repoIDs = append(repoIDs, repo.ID)
+		}
+
+		err = tx.Model(&Repository{}).
+			Where("id IN (?)", repoIDs).
+			Updates(map[string]any{
+				"owner_id":     newOwner.ID,
+				"updated_unix": tx.NowFunc().Unix(),
+			}).
+			Error
+		if err != nil {
+			return errors.Wrap(err, "update owner")
+		}
+
+		// Collaborations of the new owner itself, or members of the new
+		// organization, are redundant because access is already granted by the
+		// ownership or teams.
+		redundant := tx.Where("repo_id IN (?) AND user_id = ?", repoIDs, newOwner.ID)
+		if newOwner.IsOrganization() {
+			/*
+				Equivalent SQL for PostgreSQL:
+
+				DELETE FROM collaboration
+				WHERE
+					repo_id IN (@repoIDs)
+				AND user_id IN (
+					SELECT uid FROM org_user WHERE org_id = @toOwnerID
+				)
+			*/
+			redundant = tx.Where("repo_id IN (?) AND user_id IN (?)",
+				repoIDs,
+				tx.Model(&OrgUser{}).Select("uid").Where("org_id = ?", newOwner.ID),
+			)
+		}
+		err = redundant.Delete(&Collaboration{}).Error
+		if err != nil {
+			return errors.Wrap(err, "remove redundant collaborations")
+		}
+
+		orgsStore := &orgs{DB: tx}
+		if owner.IsOrganization() {
+			err = tx.Where("org_id = ? AND repo_id IN (?)", owner.ID, repoIDs).Delete(&TeamRepo{}).Error
+			if err != nil {
+				return errors.Wrap(err, "remove team-repository relations")
+			}
+
+			err = orgsStore.recountTeamRepos(tx, owner.ID)
+			if err != nil {
+				return errors.Wrap(err, "recount team repositories of the old owner")
+			}
+		}
+
+		if newOwner.IsOrganization() {
+			ownersTeam, err := orgsStore.getOwnersTeam(tx, newOwner.ID)
+			if err != nil {
+				return errors.Wrap(err, "get owners team")
+			}
+
+			teamRepos := make([]*TeamRepo, 0, len(repoIDs))
+			for _, repoID := range repoIDs {
+				teamRepos = append(teamRepos,
+					&TeamRepo{
+						OrgID:  newOwner.ID,
+						TeamID: ownersTeam.ID,
+						RepoID: repoID,
+					},
+				)
+			}
+			err = tx.Create(&teamRepos).Error
+			if err != nil {
+				return errors.Wrap(err, "add to owners team")
+			}
+
+			err = orgsStore.recountTeamRepos(tx, newOwner.ID)
+			if err != nil {
+				return errors.Wrap(err, "recount team repositories of the new owner")
+			}
+		}
+
+		reposStore := NewReposStore(tx)
+		for _, repoID := range repoIDs {
+			err = recalculateAccesses(tx, repoID)
+			if err != nil {
+				return errors.Wrapf(err, "recalculate accesses for repository %d", repoID)
+			}
+
+			err = reposStore.Watch(ctx, newOwner.ID, repoID)
+			if err != nil {
+				return errors.Wrapf(err, "watch repository %d", repoID)
+			}
+		}
+
+		for _, ownerID := range []int64{owner.ID, newOwner.ID} {
+			err = orgsStore.recountRepos(tx, ownerID)
+			if err != nil {
+				return errors.Wrapf(err, "recount repositories of %d", ownerID)
+			}
+		}
+
+		// Move repositories and their wikis on disk if exist, as the last step so
+		// that nothing but the commit can fail afterwards.
+		for _, repo := range transfers {
+			if osutil.IsExist(RepoPath(owner.Name, repo.Name)) {
+				err = os.MkdirAll(repoutil.UserPath(newOwner.Name), os.ModePerm)
+				if err != nil {
+					return errors.Wrap(err, "create new owner directory")
+				}
+
+				oldPath, newPath := RepoPath(owner.Name, repo.Name), RepoPath(newOwner.Name, repo.Name)
+				err = os.Rename(oldPath, newPath)
+				if err != nil {
+					return errors.Wrapf(err, "rename repository directory of %d", repo.ID)
+				}
+				moved = append(moved, [2]string{oldPath, newPath})
+			}
+			if osutil.IsExist(WikiPath(owner.Name, repo.Name)) {
+				oldPath, newPath := WikiPath(owner.Name, repo.Name), WikiPath(newOwner.Name, repo.Name)
+				err = os.Rename(oldPath, newPath)
+				if err != nil {
+					return errors.Wrapf(err, "rename repository wiki of %d", repo.ID)
+				}
+				moved = append(moved, [2]string{oldPath, newPath})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		for i := len(moved) - 1; i >= 0; i-- {
+			if err := os.Rename(moved[i][1], moved[i][0]); err != nil {
+				log.Error("Failed to move %q back to %q: %v", moved[i][1], moved[i][0], err)
+			}
+		}
+		return nil, err
+	}
+
+	for _, repo := range transfers {
+		deleteRepoLocalCopy(repo.ID)
+		RemoveAllWithNotice("Delete repository wiki local copy", repoutil.RepositoryLocalWikiPath(repo.ID))
+	}
+	return skipped, nil
+}
+
+// deleteAllByOwner permanently deletes all repositories of the owner along with
+// their related data in the given transaction. It returns paths on the file
+// system that belong to the deleted repositories, which should only be removed
+// after the transaction is committed.
+func (db *repos) deleteAllByOwner(tx *gorm.DB, owner *User) ([]string, error) {
+	var repos []*Repository
+	err := tx.Where("owner_id = ?", owner.ID).Find(&repos).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "list repositories")
+	} else if len(repos) == 0 {
+		return nil, nil
+	}
+
+	repoIDs := make([]int64, 0, len(repos))
+	paths := make([]string, 0, len(repos)*4)
+	for _, repo := range repos {
+		repoIDs = append(repoIDs, repo.ID)
+		paths = append(paths,
+			repoutil.RepositoryPath(owner.Name, repo.Name),
+			WikiPath(owner.Name, repo.Name),
+			repoutil.RepositoryLocalPath(repo.ID),
+			repoutil.RepositoryLocalWikiPath(repo.ID),
+		)
+	}
+
+	issueIDs := tx.Model(&Issue{}).Select("id").Where("repo_id IN (?)", repoIDs)
+	var attachmentUUIDs []string
+	err = tx.Model(&Attachment{}).Where("issue_id IN (?)", issueIDs).Pluck("uuid", &attachmentUUIDs).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "list attachments")
+	}
+	for _, uuid := range attachmentUUIDs {
+		paths = append(paths, AttachmentLocalPath(uuid))
+	}
+
+	var topicIDs []int64
+	err = tx.Model(&RepoTopic{}).Where("repo_id IN (?)", repoIDs).Distinct().Pluck("topic_id", &topicIDs).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "list topics")
+	}
+
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		UPDATE repository
+		SET num_forks = num_forks - 1
+		WHERE id IN (
+			SELECT fork_id FROM repository WHERE id IN (@repoIDs) AND is_fork = TRUE
+		)
+	*/
+	err = tx.Model(&Repository{}).
+		Where("id IN (?)", tx.Model(&Repository{}).Select("fork_id").Where("id IN (?) AND is_fork = ?", repoIDs, true)).
+		UpdateColumn("num_forks", gorm.Expr("num_forks - 1")).
+		Error
+	if err != nil {
+		return nil, errors.Wrap(err, "decrease fork counts")
+	}
+
+	err = tx.Model(&Repository{}).
+		Where("fork_id IN (?) AND owner_id != ?", repoIDs, owner.ID).
+		Updates(map[string]any{
+			"fork_id": 0,
+			"is_fork": false,
+		}).
+		Error
+	if err != nil {
+		return nil, errors.Wrap(err, "detach forks")
+	}
+
+	pullIDs := tx.Model(&PullRequest{}).Select("id").Where("base_repo_id IN (?)", repoIDs)
+	for _, t := range []struct {
+		table any
+		where string
+		arg   any
+	}{
+		// NOTE: Tables that are filtered by subqueries must be cleaned up before the
+		// tables the subqueries read from.
+		{&PullApproval{}, "pull_id IN (?)", pullIDs},
+		{&Comment{}, "issue_id IN (?)", issueIDs},
+		{&Attachment{}, "issue_id IN (?)", issueIDs},
+		{&IssueAssignee{}, "issue_id IN (?)", issueIDs},
+		{&IssueLabel{}, "issue_id IN (?)", issueIDs},
+		{&Issue{}, "repo_id IN (?)", repoIDs},
+		{&PullRequest{}, "base_repo_id IN (?)", repoIDs},
+
+		{&Access{}, "repo_id IN (?)", repoIDs},
+		{&Action{}, "repo_id IN (?)", repoIDs},
+		{&Watch{}, "repo_id IN (?)", repoIDs},
+		{&Star{}, "repo_id IN (?)", repoIDs},
+		{&Mirror{}, "repo_id IN (?)", repoIDs},
+		{&IssueUser{}, "repo_id IN (?)", repoIDs},
+		{&Label{}, "repo_id IN (?)", repoIDs},
+		{&Milestone{}, "repo_id IN (?)", repoIDs},
+		{&Release{}, "repo_id IN (?)", repoIDs},
+		{&Collaboration{}, "repo_id IN (?)", repoIDs},
+		{&ProtectBranch{}, "repo_id IN (?)", repoIDs},
+		{&ProtectBranchWhitelist{}, "repo_id IN (?)", repoIDs},
+		{&ProtectedTag{}, "repo_id IN (?)", repoIDs},
+		{&Webhook{}, "repo_id IN (?)", repoIDs},
+		{&HookTask{}, "repo_id IN (?)", repoIDs},
+		{&LFSObject{}, "repo_id IN (?)", repoIDs},
+		{&TeamRepo{}, "repo_id IN (?)", repoIDs},
+		{&PinnedRepo{}, "repo_id IN (?)", repoIDs},
+		{&RepoRedirect{}, "repo_id IN (?)", repoIDs},
+		{&RepoTopic{}, "repo_id IN (?)", repoIDs},
+		{&Repository{}, "id IN (?)", repoIDs},
+	} {
+		err = tx.Where(t.where, t.arg).Delete(t.table).Error
+		if err != nil {
+			return nil, errors.Wrapf(err, "clean up table %T", t.table)
+		}
+	}
+
+	err = db.recountTopicRepos(tx, topicIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "recount topics")
+	}
+
+	err = tx.Model(&User{}).Where("id = ?", owner.ID).Update("num_repos", 0).Error
+	if err != nil {
+		return nil, errors.Wrap(err, `reset "user.num_repos"`)
+	}
+	return paths, nil
+}
+
+func (db *repos) AddDeployKey(ctx context.Context, repoID int64, title, content string, readOnly bool) error {
+	var pkey *PublicKey
+	var isNew bool
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// NOTE: It is OK to use same key as deploy key for multiple repositories, but
+		// not as a user key.
+		var count int64
+		err := tx.Model(&PublicKey{}).Where("content = ? AND type = ?", content, KEY_TYPE_USER).Count(&count).Error
+		if err != nil {
+			return errors.Wrap(err, "count user keys")
+		} else if count > 0 {
+			return ErrKeyAlreadyExist{0, content}
+		}
+
+		pkey = new(PublicKey)
+		err = tx.Where("content = ? AND type = ?", content, KEY_TYPE_DEPLOY).First(pkey).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.Wrap(err, "get public key")
+		}
+
+		// First time use this deploy key.
+		isNew = pkey.ID == 0
+		if isNew {
+			fingerprint, err := calcFingerprint(content)
+			if err != nil {
+				return errors.Wrap(err, "calculate fingerprint")
+			}
+
+			pkey = &PublicKey{
+				Fingerprint: fingerprint,
+				Content:     content,
+				Mode:        AccessModeRead,
+				Type:        KEY_TYPE_DEPLOY,
+				CreatedUnix: tx.NowFunc().Unix(),
+			}
+			err = tx.Create(pkey).Error
+			if err != nil {
+				return errors.Wrap(err, "create public key")
+			}
+		}
+
+		err = tx.Model(&DeployKey{}).Where("key_id = ? AND repo_id = ?", pkey.ID, repoID).Count(&count).Error
+		if err != nil {
+			return errors.Wrap(err, "count deploy keys by key")
+		} else if count > 0 {
+			return ErrDeployKeyAlreadyExist{pkey.ID, repoID}
+		}
+
+		err = tx.Model(&DeployKey{}).Where("repo_id = ? AND name = ?", repoID, title).Count(&count).Error
+		if err != nil {
+			return errors.Wrap(err, "count deploy keys by name")
+		} else if count > 0 {
+			return ErrDeployKeyNameAlreadyUsed{repoID, title}
+		}
+
+		err = tx.Create(
+			&DeployKey{
+				KeyID:       pkey.ID,
+				RepoID:      repoID,
+				Name:        title,
+				Fingerprint: pkey.Fingerprint,
+				ReadOnly:    readOnly,
+				CreatedUnix: tx.NowFunc().Unix(),
+			},
+		).Error
+		return errors.Wrap(err, "create deploy key")
+	})
+	if err != nil {
+		return err
+	}
+
+	// NOTE: The file is only written after the transaction is committed to not
+	// leave keys that do not exist in the database.
+	//
+	// Don't need to rewrite this file if builtin SSH server is enabled.
+	if !isNew || conf.SSH.StartBuiltinServer {
+		return nil
+	}
+	err = appendAuthorizedKeysToFile(pkey)
+	return errors.Wrap(err, `append to "authorized_keys"`)
+}
+
+// orphanedAccessBatchSize is the maximum number of orphaned rows to be deleted
+// in a single statement.
+const orphanedAccessBatchSize = 1000
+
+func (db *repos) CleanupOrphanedAccess(ctx context.Context) (int64, error) {
+	var total int64
+	for {
+		/*
+			Equivalent SQL for PostgreSQL:
+
+			SELECT id FROM access
+			WHERE repo_id NOT IN (SELECT id FROM repository)
+			LIMIT @orphanedAccessBatchSize
+		*/
+		var accessIDs []int64
+		err := db.WithContext(ctx).
+			Model(&Access{}).
+			Where("repo_id NOT IN (?)", db.Model(&Repository{}).Select("id")).
+			Limit(orphanedAccessBatchSize).
+			Pluck("id", &accessIDs).
+			Error
+		if err != nil {
+			return total, errors.Wrap(err, "list orphaned accesses")
+		} else if len(accessIDs) == 0 {
+			break
+		}
+
+		err = db.WithContext(ctx).Where("id IN (?)", accessIDs).Delete(&Access{}).Error
+		if err != nil {
+			return total, errors.Wrap(err, "delete orphaned accesses")
+		}
+		total += int64(len(accessIDs))
+	}
+
+	for {
+		var teamRepos []*TeamRepo
+		err := db.WithContext(ctx).
+			Select("id", "org_id").
+			Where("repo_id NOT IN (?)", db.Model(&Repository{}).Select("id")).
+			Limit(orphanedAccessBatchSize).
+			Find(&teamRepos).
+			Error
+		if err != nil {
+			return total, errors.Wrap(err, "list orphaned team repositories")
+		} else if len(teamRepos) == 0 {
+			break
+		}
+
+		ids := make([]int64, 0, len(teamRepos))
+		orgIDs := make(map[int64]struct{})
+		for _, tr := range teamRepos {
+			ids = append(ids, tr.ID)
+			orgIDs[tr.OrgID] = struct{}{}
+		}
+
+		err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			err := tx.Where("id IN (?)", ids).Delete(&TeamRepo{}).Error
+			if err != nil {
+				return errors.Wrap(err, "delete")
+			}
+
+			orgsStore := &orgs{DB: tx}
+			for orgID := range orgIDs {
+				err = orgsStore.recountTeamRepos(tx, orgID)
+				if err != nil {
+					return errors.Wrapf(err, "recount team repositories of %d", orgID)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return total, errors.Wrap(err, "delete orphaned team repositories")
+		}
+		total += int64(len(ids))
+	}
+	return total, nil
+}
+
+func (db *repos) ListMirrorsToSync(ctx context.Context, now int64, limit, maxFailures int) ([]*Mirror, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT mirror.* FROM mirror
+		JOIN repository ON repository.id = mirror.repo_id
+		WHERE
+			mirror.next_update_unix <= @now
+		AND repository.deleted_unix = 0
+		[AND mirror.num_sync_failures < @maxFailures]
+		ORDER BY mirror.next_update_unix ASC, mirror.id ASC
+		LIMIT @limit
+	*/
+	tx := db.WithContext(ctx).
+		Select("mirror.*").
+		Joins("JOIN repository ON repository.id = mirror.repo_id").
+		Where("mirror.next_update_unix <= ? AND repository.deleted_unix = 0", now)
+	if maxFailures > 0 {
+		tx = tx.Where("mirror.num_sync_failures < ?", maxFailures)
+	}
+
+	mirrors := make([]*Mirror, 0, limit)
+	return mirrors, tx.
+		Order("mirror.next_update_unix ASC, mirror.id ASC").
+		Limit(limit).
+		Find(&mirrors).
+		Error
+}
+
+func (db *repos) SetMirrorSyncResult(ctx context.Context, repoID int64, success bool, errMsg string) error {
+	updates := map[string]any{
+		"last_error":        "",
+		"num_sync_failures": 0,
+		"updated_unix":      db.NowFunc().Unix(),
+	}
+	if !success {
+		updates = map[string]any{
+			"last_error":        errMsg,
+			"num_sync_failures": gorm.Expr("num_sync_failures + 1"),
+		}
+	}
+	return db.WithContext(ctx).
+		Model(&Mirror{}).
+		Where("repo_id = ?", repoID).
+		Updates(updates).
+		Error
+}
+
+// Topic is a label that can be attached to repositories.
+type Topic struct {
+	ID          int64  `gorm:"primaryKey"`
+	Name        string `gorm:"type:VARCHAR(35);uniqueIndex;not null"`
+	NumRepos    int    `gorm:"not null;default:0"`
+	CreatedUnix int64  `gorm:"not null"`
+}
+
+// BeforeCreate implements the GORM create hook.
+func (t *Topic) BeforeCreate(tx *gorm.DB) error {
+	if t.CreatedUnix == 0 {
+		t.CreatedUnix = tx.NowFunc().Unix()
+	}
+	return nil
+}
+
+// RepoTopic is the relation between a repository and a topic.
+type RepoTopic struct {
+	ID      int64 `gorm:"primaryKey"`
+	RepoID  int64 `gorm:"uniqueIndex:repo_topic_repo_topic_unique;not null"`
+	TopicID int64 `gorm:"uniqueIndex:repo_topic_repo_topic_unique;index;not null"`
+}
+
+const (
+	maxTopicNameLength = 35
+	maxTopicsPerRepo   = 20
+)
+
+var topicNamePattern = lazyregexp.New(`^[a-z0-9][a-z0-9-]*$`)
+
+type ErrInvalidTopic struct {
+	args errutil.Args
+}
+
+func IsErrInvalidTopic(err error) bool {
+	_, ok := errors.Cause(err).(ErrInvalidTopic)
+	return ok
+}
+
+func (err ErrInvalidTopic) Error() string {
+	return fmt.Sprintf("invalid topic: %v", err.args)
+}
+
+// normalizeTopics lowercases, trims and deduplicates the given topic names,
+// empty names are skipped. It returns ErrInvalidTopic when a topic name is not
+// valid, or there are too many topics.
+func normalizeTopics(topics []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(topics))
+	names := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		name := strings.ToLower(strings.TrimSpace(topic))
+		if name == "" {
+			continue
+		} else if _, ok := seen[name]; ok {
+			continue
+		}
+
+		if len(name) > maxTopicNameLength || !topicNamePattern.MatchString(name) {
+			return nil, ErrInvalidTopic{args: errutil.Args{"name": topic}}
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+
+	if len(names) > maxTopicsPerRepo {
+		return nil, ErrInvalidTopic{args: errutil.Args{"count": len(names), "max": maxTopicsPerRepo}}
+	}
+	return names, nil
+}
+
+func (db *repos) ListTopics(ctx context.Context, repoID int64) ([]*Topic, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT topic.* FROM topic
+		JOIN repo_topic ON repo_topic.topic_id = topic.id
+		WHERE repo_topic.repo_id = @repoID
+		ORDER BY topic.name ASC
+	*/
+	topics := make([]*Topic, 0)
+	return topics, db.WithContext(ctx).
+		Joins("JOIN repo_topic ON repo_topic.topic_id = topic.id").
+		Where("repo_topic.repo_id = ?", repoID).
+		Order("topic.name ASC").
+		Find(&topics).
+		Error
+}
+
+// recountTopicRepos recounts the number of repositories of the given topics.
+func (db *repos) recountTopicRepos(tx *gorm.DB, topicIDs []int64) error {
+	if len(topicIDs) == 0 {
+		return nil
+	}
+
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		UPDATE topic
+		SET num_repos = (
+			SELECT COUNT(*) FROM repo_topic WHERE repo_topic.topic_id = topic.id
+		)
+		WHERE id IN @topicIDs
+	*/
+	err := tx.Model(&Topic{}).
+		Where("id IN (?)", topicIDs).
+		Update(
+			"num_repos",
+			tx.Model(&RepoTopic{}).Select("COUNT(*)").Where("repo_topic.topic_id = topic.id"),
+		).
+		Error
+	if err != nil {
+		return errors.Wrap(err, `update "topic.num_repos"`)
+	}
+	return nil
+}
+
+func (db *repos) SetTopics(ctx context.Context, repoID int64, topics []string) error {
+	names, err := normalizeTopics(topics)
+	if err != nil {
+		return err
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var oldTopicIDs []int64
+		err := tx.Model(&RepoTopic{}).Where("repo_id = ?", repoID).Pluck("topic_id", &oldTopicIDs).Error
+		if err != nil {
+			return errors.Wrap(err, "list old topics")
+		}
+
+		err = tx.Where("repo_id = ?", repoID).Delete(&RepoTopic{}).Error
+		if err != nil {
+			return errors.Wrap(err, "delete old topics")
+		}
+
+		topicIDs := make([]int64, 0, len(names))
+		for _, name := range names {
+			t := &Topic{Name: name}
+			err = tx.Where("name = ?", name).FirstOrCreate(t).Error
+			if err != nil {
+				return errors.Wrapf(err, "upsert topic %q", name)
+			}
+
+			err = tx.Create(&RepoTopic{RepoID: repoID, TopicID: t.ID}).Error
+			if err != nil {
+				return errors.Wrapf(err, "attach topic %q", name)
+			}
+			topicIDs = append(topicIDs, t.ID)
+		}
+
+		return db.recountTopicRepos(tx, append(oldTopicIDs, topicIDs...))
+	})
+}
+
+func (db *repos) SearchByTopic(ctx context.Context, topic string, page, pageSize int) ([]*Repository, int64, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT repository.* FROM repository
+		JOIN repo_topic ON repo_topic.repo_id = repository.id
+		JOIN topic ON topic.id = repo_topic.topic_id
+		WHERE
+			topic.name = @topic
+		AND repository.is_private = FALSE
+		AND repository.is_unlisted = FALSE
+		AND repository.deleted_unix = 0
+		ORDER BY repository.updated_unix DESC
+		LIMIT @limit OFFSET @offset
+	*/
+	tx := db.WithContext(ctx).
+		Model(&Repository{}).
+		Joins("JOIN repo_topic ON repo_topic.repo_id = repository.id").
+		Joins("JOIN topic ON topic.id = repo_topic.topic_id").
+		Where("topic.name = ? AND repository.is_private = ? AND repository.is_unlisted = ? AND repository.deleted_unix = 0",
+			strings.ToLower(strings.TrimSpace(topic)), false, false,
+		)
+
+	var count int64
+	err := tx.Count(&count).Error
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "count")
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+	var repos []*Repository
+	err = tx.Order("repository.updated_unix DESC").
+		Limit(pageSize).Offset((page - 1) * pageSize).
+		Find(&repos).
+		Error
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "list")
+	}
+	return repos, count, nil
+}
+
+func (db *repos) ApplyOrgLabels(ctx context.Context, repoID, orgID int64) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var templates []*OrgLabel
+		err := tx.Where("org_id = ?", orgID).Order("name ASC").Find(&templates).Error
+		if err != nil {
+			return errors.Wrap(err, "list templates")
+		} else if len(templates) == 0 {
+			return nil
+		}
+
+		var existing []*Label
+		err = tx.Where("repo_id = ?", repoID).Find(&existing).Error
+		if err != nil {
+			return errors.Wrap(err, "list existing labels")
+		}
+		existingByName := make(map[string]*Label, len(existing))
+		for _, l := range existing {
+			existingByName[l.Name] = l
+		}
+
+		var labels []*Label
+		for _, t := range templates {
+			l, ok := existingByName[t.Name]
+			if !ok {
+				labels = append(labels,
+					&Label{
+						RepoID: repoID,
+						Name:   t.Name,
+						Color:  t.Color,
+					},
+				)
+				continue
+			} else if l.Color == t.Color {
+				continue
+			}
+
+			err = tx.Model(&Label{}).Where("id = ?", l.ID).Update("color", t.Color).Error
+			if err != nil {
+				return errors.Wrapf(err, "update color of label %q", t.Name)
+			}
+		}
+
+		if len(labels) == 0 {
+			return nil
+		}
+		return tx.Create(&labels).Error
+	})
+}
+
+// ProtectedTag is a rule to protect tags whose names match the pattern from
+// being changed by users without sufficient access to the repository.
+type ProtectedTag struct {
+	ID     int64 `gorm:"primaryKey"`
+	RepoID int64 `gorm:"uniqueIndex:protected_tag_repo_pattern_unique;not null"`
+	// The glob pattern of tag names, see path.Match for the syntax.
+	Pattern string `gorm:"type:VARCHAR(255);uniqueIndex:protected_tag_repo_pattern_unique;not null"`
+	// The minimum access mode required to create, update or delete matching tags.
+	AllowedMode AccessMode `gorm:"not null"`
+	CreatedUnix int64      `gorm:"not null"`
+}
+
+// Match returns true if the given tag name matches the pattern of the rule.
+func (t *ProtectedTag) Match(name string) bool {
+	matched, _ := path.Match(t.Pattern, name)
+	return matched
+}
+
+type ErrProtectedTagAlreadyExist struct {
+	args errutil.Args
+}
+
+// IsErrProtectedTagAlreadyExist returns true if the underlying error has the
+// type ErrProtectedTagAlreadyExist.
+func IsErrProtectedTagAlreadyExist(err error) bool {
+	_, ok := errors.Cause(err).(ErrProtectedTagAlreadyExist)
+	return ok
+}
+
+func (err ErrProtectedTagAlreadyExist) Error() string {
+	return fmt.Sprintf("protected tag already exists: %v", err.args)
+}
+
+func (db *repos) AddProtectedTag(ctx context.Context, repoID int64, pattern string, allowedMode AccessMode) (*ProtectedTag, error) {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return nil, errors.New("empty pattern")
+	} else if _, err := path.Match(pattern, ""); err != nil {
+		return nil, errors.Wrapf(err, "invalid pattern %q", pattern)
+	}
+
+	switch allowedMode {
+	case AccessModeWrite, AccessModeAdmin, AccessModeOwner:
+	default:
+		return nil, errors.Errorf("invalid allowed access mode %q", allowedMode)
+	}
+
+	tag := &ProtectedTag{
+		RepoID:      repoID,
+		Pattern:     pattern,
+		AllowedMode: allowedMode,
+	}
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(&ProtectedTag{}).Where("repo_id = ? AND pattern = ?", repoID, pattern).Count(&count).Error
+		if err != nil {
+			return errors.Wrap(err, "count protected tags")
+		} else if count > 0 {
+			return ErrProtectedTagAlreadyExist{args: errutil.Args{"repoID": repoID, "pattern": pattern}}
+		}
+
+		tag.CreatedUnix = tx.NowFunc().Unix()
+		return tx.Create(tag).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+func (db *repos) ListProtectedTags(ctx context.Context, repoID int64) ([]*ProtectedTag, error) {
+	var tags []*ProtectedTag
+	return tags, db.WithContext(ctx).Where("repo_id = ?", repoID).Order("pattern").Find(&tags).Error
+}
@@ -6,15 +6,22 @@ package db
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gogs/git-module"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 
+	"gogs.io/gogs/internal/conf"
 	"gogs.io/gogs/internal/dbtest"
 	"gogs.io/gogs/internal/errutil"
+	"gogs.io/gogs/internal/markup"
 )
 
 func TestRepository_BeforeCreate(t *testing.T) {
@@ -85,7 +92,13 @@ func TestRepos(t *testing.T) {
 	}
 	t.Parallel()
 
-	tables := []any{new(Repository), new(Access), new(Watch), new(User), new(EmailAddress), new(Star)}
+	tables := []any{
+		new(Repository), new(Access), new(Watch), new(User), new(EmailAddress), new(Star), new(Topic), new(RepoTopic),
+		new(RepoRedirect),
+		new(Collaboration), new(OrgUser), new(Team), new(TeamUser), new(TeamRepo), new(Mirror),
+		new(PublicKey), new(DeployKey), new(OrgLabel), new(Label), new(Issue), new(IssueLabel),
+		new(ProtectedTag), new(Action),
+	}
 	db := &repos{
 		DB: dbtest.NewDB(t, "repos", tables...),
 	}
@@ -99,11 +112,32 @@ func TestRepos(t *testing.T) {
 		{"GetByCollaboratorIDWithAccessMode", reposGetByCollaboratorIDWithAccessMode},
 		{"GetByID", reposGetByID},
 		{"GetByName", reposGetByName},
+		{"IsNameAvailable", reposIsNameAvailable},
+		{"Rename", reposRename},
 		{"Star", reposStar},
 		{"Touch", reposTouch},
+		{"UpdateSize", reposUpdateSize},
+		{"SetExternalTracker", reposSetExternalTracker},
+		{"SetPrivate", reposSetPrivate},
 		{"ListByRepo", reposListWatches},
 		{"Watch", reposWatch},
+		{"ListWatchedByUserInOrg", reposListWatchedByUserInOrg},
 		{"HasForkedBy", reposHasForkedBy},
+		{"Fork", reposFork},
+		{"CreateFromTemplate", reposCreateFromTemplate},
+		{"SetCollaboratorExpiry", reposSetCollaboratorExpiry},
+		{"DeleteExpiredCollaborators", reposDeleteExpiredCollaborators},
+		{"SetArchived", reposSetArchived},
+		{"SoftDelete", reposSoftDelete},
+		{"TransferAllByOwner", reposTransferAllByOwner},
+		{"AddDeployKey", reposAddDeployKey},
+		{"AddProtectedTag", reposAddProtectedTag},
+		{"CleanupOrphanedAccess", reposCleanupOrphanedAccess},
+		{"ListMirrorsToSync", reposListMirrorsToSync},
+		{"SetMirrorSyncResult", reposSetMirrorSyncResult},
+		{"SetTopics", reposSetTopics},
+		{"SearchByTopic", reposSearchByTopic},
+		{"ApplyOrgLabels", reposApplyOrgLabels},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Cleanup(func() {
@@ -253,6 +287,119 @@ func reposGetByName(t *testing.T, db *repos) {
 	assert.Equal(t, wantErr, err)
 }
 
+func reposIsNameAvailable(t *testing.T, db *repos) {
+	ctx := context.Background()
+
+	repo1, err := db.Create(ctx, 1, CreateRepoOptions{Name: "Repo1"})
+	require.NoError(t, err)
+	repo2, err := db.Create(ctx, 1, CreateRepoOptions{Name: "repo2"})
+	require.NoError(t, err)
+	err = db.SoftDelete(ctx, repo2.ID)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name    string
+		ownerID int64
+		repo    string
+		want    bool
+	}{
+		{name: "available", ownerID: 1, repo: "repo3", want: true},
+		{name: "taken", ownerID: 1, repo: repo1.Name, want: false},
+		{name: "taken in different case", ownerID: 1, repo: "REPO1", want: false},
+		{name: "taken by soft-deleted", ownerID: 1, repo: repo2.Name, want: false},
+		{name: "taken by different owner", ownerID: 2, repo: repo1.Name, want: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := db.IsNameAvailable(ctx, tc.ownerID, tc.repo)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	for _, name := range []string{"", "-", "..", "repo.git", "CON", "lpt1"} {
+		t.Run("not allowed "+name, func(t *testing.T) {
+			_, err := db.IsNameAvailable(ctx, 1, name)
+			assert.True(t, IsErrNameNotAllowed(err))
+		})
+	}
+
+	_, err = db.Create(ctx, 1, CreateRepoOptions{Name: repo2.Name})
+	assert.True(t, IsErrRepoAlreadyExist(err))
+}
+
+func reposRename(t *testing.T, db *repos) {
+	ctx := context.Background()
+
+	t.Run("repository does not exist", func(t *testing.T) {
+		err := db.Rename(ctx, 404, "repo404")
+		wantErr := ErrRepoNotExist{args: errutil.Args{"repoID": int64(404)}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	repo1, err := db.Create(ctx, 1, CreateRepoOptions{Name: "repo1"})
+	require.NoError(t, err)
+	repo2, err := db.Create(ctx, 1, CreateRepoOptions{Name: "repo2"})
+	require.NoError(t, err)
+
+	t.Run("name not allowed", func(t *testing.T) {
+		err := db.Rename(ctx, repo1.ID, "repo.wiki")
+		assert.True(t, IsErrNameNotAllowed(err))
+	})
+
+	t.Run("name already exists", func(t *testing.T) {
+		err := db.Rename(ctx, repo1.ID, "REPO2")
+		wantErr := ErrRepoAlreadyExist{args: errutil.Args{"ownerID": int64(1), "name": "REPO2"}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	// Changing only the case does not create a redirect
+	err = db.Rename(ctx, repo1.ID, "Repo1")
+	require.NoError(t, err)
+	got, err := db.GetByID(ctx, repo1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Repo1", got.Name)
+	assert.Equal(t, "repo1", got.LowerName)
+	_, err = db.GetByRedirect(ctx, 1, "repo1")
+	assert.True(t, IsErrRepoNotExist(err))
+
+	err = db.Rename(ctx, repo1.ID, "renamed")
+	require.NoError(t, err)
+	_, err = db.GetByName(ctx, 1, "repo1")
+	assert.True(t, IsErrRepoNotExist(err))
+	got, err = db.GetByRedirect(ctx, 1, "REPO1")
+	require.NoError(t, err)
+	assert.Equal(t, repo1.ID, got.ID)
+	assert.Equal(t, "renamed", got.Name)
+
+	// Redirects of other owners are not affected
+	_, err = db.GetByRedirect(ctx, 2, "repo1")
+	assert.True(t, IsErrRepoNotExist(err))
+
+	// Renaming another repository to the old name takes over the redirect
+	err = db.Rename(ctx, repo2.ID, "repo1")
+	require.NoError(t, err)
+	_, err = db.GetByRedirect(ctx, 1, "repo1")
+	assert.True(t, IsErrRepoNotExist(err))
+	got, err = db.GetByRedirect(ctx, 1, "repo2")
+	require.NoError(t, err)
+	assert.Equal(t, repo2.ID, got.ID)
+
+	// Reclaiming the old name removes the redirect that points to itself
+	err = db.Rename(ctx, repo2.ID, "repo2")
+	require.NoError(t, err)
+	_, err = db.GetByRedirect(ctx, 1, "repo2")
+	assert.True(t, IsErrRepoNotExist(err))
+	got, err = db.GetByRedirect(ctx, 1, "repo1")
+	require.NoError(t, err)
+	assert.Equal(t, repo2.ID, got.ID)
+
+	// Redirects to soft-deleted repositories are ignored
+	err = db.SoftDelete(ctx, repo2.ID)
+	require.NoError(t, err)
+	_, err = db.GetByRedirect(ctx, 1, "repo1")
+	assert.True(t, IsErrRepoNotExist(err))
+}
+
 func reposStar(t *testing.T, db *repos) {
 	ctx := context.Background()
 
@@ -293,13 +440,198 @@ func reposTouch(t *testing.T, db *repos) {
 	assert.True(t, got.IsBare)
 
 	// Touch it
-	err = db.Touch(ctx, repo.ID)
+	now := time.Now().Add(time.Hour).Unix()
+	err = db.Touch(ctx, repo.ID, now)
 	require.NoError(t, err)
 
 	// It should not be bare anymore
 	got, err = db.GetByName(ctx, repo.OwnerID, repo.Name)
 	require.NoError(t, err)
 	assert.False(t, got.IsBare)
+	assert.Equal(t, now, got.UpdatedUnix)
+
+	// An older timestamp, e.g. from a concurrent push, should not clobber the
+	// newer one.
+	err = db.Touch(ctx, repo.ID, now-60)
+	require.NoError(t, err)
+	got, err = db.GetByName(ctx, repo.OwnerID, repo.Name)
+	require.NoError(t, err)
+	assert.Equal(t, now, got.UpdatedUnix)
+}
+
+func reposUpdateSize(t *testing.T, db *repos) {
+	ctx := context.Background()
+
+	repo, err := db.Create(ctx, 1,
+		CreateRepoOptions{
+			Name: "repo1",
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), repo.Size)
+
+	err = db.UpdateSize(ctx, repo.ID, 1024)
+	require.NoError(t, err)
+
+	got, err := db.GetByID(ctx, repo.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1024), got.Size)
+}
+
+func reposSetExternalTracker(t *testing.T, db *repos) {
+	ctx := context.Background()
+
+	t.Run("repository does not exist", func(t *testing.T) {
+		err := db.SetExternalTracker(ctx, 404, SetExternalTrackerOptions{})
+		wantErr := ErrRepoNotExist{args: errutil.Args{"repoID": int64(404)}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	repo, err := db.Create(ctx, 1,
+		CreateRepoOptions{
+			Name: "repo1",
+		},
+	)
+	require.NoError(t, err)
+	err = db.Model(&Repository{}).Where("id = ?", repo.ID).Update("allow_public_issues", true).Error
+	require.NoError(t, err)
+
+	t.Run("invalid options", func(t *testing.T) {
+		for _, opts := range []SetExternalTrackerOptions{
+			{URL: "jira.example.com", Format: "https://jira.example.com/browse/{index}"},
+			{URL: "https://jira.example.com", Format: "https://jira.example.com/browse/"},
+			{URL: "https://jira.example.com", Format: "https://jira.example.com/browse/{index}", Style: "roman"},
+		} {
+			err := db.SetExternalTracker(ctx, repo.ID, opts)
+			assert.True(t, IsErrInvalidExternalTracker(err), "%+v", opts)
+		}
+	})
+
+	err = db.SetExternalTracker(ctx, repo.ID,
+		SetExternalTrackerOptions{
+			URL:    "https://jira.example.com",
+			Format: "https://jira.example.com/browse/{user}-{index}",
+		},
+	)
+	require.NoError(t, err)
+
+	got, err := db.GetByID(ctx, repo.ID)
+	require.NoError(t, err)
+	assert.True(t, got.EnableExternalTracker)
+	assert.Equal(t, "https://jira.example.com", got.ExternalTrackerURL)
+	assert.Equal(t, "https://jira.example.com/browse/{user}-{index}", got.ExternalTrackerFormat)
+	assert.Equal(t, markup.IssueNameStyleNumeric, got.ExternalTrackerStyle)
+	assert.False(t, got.AllowPublicIssues)
+	assert.False(t, got.CanGuestViewIssues())
+
+	err = db.SetExternalTracker(ctx, repo.ID, SetExternalTrackerOptions{})
+	require.NoError(t, err)
+
+	got, err = db.GetByID(ctx, repo.ID)
+	require.NoError(t, err)
+	assert.False(t, got.EnableExternalTracker)
+}
+
+func reposSetPrivate(t *testing.T, db *repos) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	cindy, err := usersStore.Create(ctx, "cindy", "cindy@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	// Alice is a member of the organization which grants read access to public
+	// repositories.
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+	err = db.Model(&User{}).Where("id = ?", org1.ID).Update("default_repo_permission", AccessModeRead).Error
+	require.NoError(t, err)
+	err = db.Exec(`INSERT INTO org_user (uid, org_id) VALUES (?, ?)`, alice.ID, org1.ID).Error
+	require.NoError(t, err)
+
+	repo1, err := db.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo1", Private: true})
+	require.NoError(t, err)
+	// Cindy is a collaborator of the repository.
+	err = db.DB.Create(&Collaboration{UserID: cindy.ID, RepoID: repo1.ID, Mode: AccessModeWrite}).Error
+	require.NoError(t, err)
+
+	t.Run("repository does not exist", func(t *testing.T) {
+		err := db.SetPrivate(ctx, 404, false)
+		wantErr := ErrRepoNotExist{args: errutil.Args{"repoID": int64(404)}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	permsStore := NewPermsStore(db.DB)
+	orgsStore := NewOrgsStore(db.DB)
+	accessMode := func(t *testing.T, userID int64) AccessMode {
+		t.Helper()
+		repo, err := db.GetByID(ctx, repo1.ID)
+		require.NoError(t, err)
+		return permsStore.AccessMode(ctx, userID, repo.ID,
+			AccessModeOptions{
+				OwnerID: repo.OwnerID,
+				Private: repo.IsPrivate,
+			},
+		)
+	}
+
+	err = db.SetPrivate(ctx, repo1.ID, false)
+	require.NoError(t, err)
+	repo1, err = db.GetByID(ctx, repo1.ID)
+	require.NoError(t, err)
+	assert.False(t, repo1.IsPrivate)
+	assert.Equal(t, AccessModeRead, accessMode(t, alice.ID))
+	assert.Equal(t, AccessModeWrite, accessMode(t, cindy.ID))
+
+	// The repository is discoverable by non-members once public
+	_, count, _, err := orgsStore.AccessibleRepositoriesByUser(ctx, org1.ID, bob.ID, 1, 10, AccessibleRepositoriesByUserOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	for _, userID := range []int64{alice.ID, bob.ID, cindy.ID} {
+		err = db.Watch(ctx, userID, repo1.ID)
+		require.NoError(t, err)
+	}
+
+	err = db.SetPrivate(ctx, repo1.ID, true)
+	require.NoError(t, err)
+	repo1, err = db.GetByID(ctx, repo1.ID)
+	require.NoError(t, err)
+	assert.True(t, repo1.IsPrivate)
+	assert.Equal(t, AccessModeNone, accessMode(t, alice.ID))
+	assert.Equal(t, AccessModeWrite, accessMode(t, cindy.ID))
+
+	_, count, _, err = orgsStore.AccessibleRepositoriesByUser(ctx, org1.ID, bob.ID, 1, 10, AccessibleRepositoriesByUserOptions{})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	// Only watches of the owner and users with access remain
+	var watcherIDs []int64
+	err = db.Model(&Watch{}).Where("repo_id = ?", repo1.ID).Order("user_id").Pluck("user_id", &watcherIDs).Error
+	require.NoError(t, err)
+	assert.Equal(t, []int64{cindy.ID, org1.ID}, watcherIDs)
+	assert.Equal(t, 2, repo1.NumWatches)
+
+	// No-op when the visibility is unchanged
+	err = db.SetPrivate(ctx, repo1.ID, true)
+	require.NoError(t, err)
+
+	t.Run("forks follow the base repository", func(t *testing.T) {
+		fork1, err := db.Create(ctx, bob.ID, CreateRepoOptions{Name: "fork1", Private: true, Fork: true, ForkID: repo1.ID})
+		require.NoError(t, err)
+		fork2, err := db.Create(ctx, cindy.ID, CreateRepoOptions{Name: "fork2", Private: true, Fork: true, ForkID: fork1.ID})
+		require.NoError(t, err)
+
+		err = db.SetPrivate(ctx, repo1.ID, false)
+		require.NoError(t, err)
+		for _, id := range []int64{repo1.ID, fork1.ID, fork2.ID} {
+			repo, err := db.GetByID(ctx, id)
+			require.NoError(t, err)
+			assert.False(t, repo.IsPrivate, repo.Name)
+		}
+	})
 }
 
 func reposListWatches(t *testing.T, db *repos) {
@@ -344,6 +676,39 @@ func reposWatch(t *testing.T, db *repos) {
 	assert.Equal(t, 2, repo1.NumWatches) // The owner is watching the repo by default.
 }
 
+func reposListWatchedByUserInOrg(t *testing.T, db *repos) {
+	ctx := context.Background()
+
+	public, err := db.Create(ctx, 1, CreateRepoOptions{Name: "public"})
+	require.NoError(t, err)
+	private, err := db.Create(ctx, 1, CreateRepoOptions{Name: "private", Private: true})
+	require.NoError(t, err)
+	lostAccess, err := db.Create(ctx, 1, CreateRepoOptions{Name: "lost-access", Private: true})
+	require.NoError(t, err)
+	notWatched, err := db.Create(ctx, 1, CreateRepoOptions{Name: "not-watched"})
+	require.NoError(t, err)
+	otherOwner, err := db.Create(ctx, 3, CreateRepoOptions{Name: "other-owner"})
+	require.NoError(t, err)
+	err = db.Exec(`UPDATE repository SET updated_unix = id`).Error
+	require.NoError(t, err)
+
+	for _, repoID := range []int64{public.ID, private.ID, lostAccess.ID, otherOwner.ID} {
+		err = db.Watch(ctx, 2, repoID)
+		require.NoError(t, err)
+	}
+	err = db.DB.Create(&Access{UserID: 2, RepoID: private.ID, Mode: AccessModeRead}).Error
+	require.NoError(t, err)
+
+	got, err := db.ListWatchedByUserInOrg(ctx, 1, 2)
+	require.NoError(t, err)
+	gotIDs := make([]int64, 0, len(got))
+	for _, repo := range got {
+		gotIDs = append(gotIDs, repo.ID)
+	}
+	assert.Equal(t, []int64{private.ID, public.ID}, gotIDs)
+	assert.NotContains(t, gotIDs, notWatched.ID)
+}
+
 func reposHasForkedBy(t *testing.T, db *repos) {
 	ctx := context.Background()
 
@@ -363,3 +728,851 @@ func reposHasForkedBy(t *testing.T, db *repos) {
 	has = db.HasForkedBy(ctx, 1, 2)
 	assert.True(t, has)
 }
+
+func reposFork(t *testing.T, db *repos) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	baseRepo, err := db.Create(ctx, alice.ID,
+		CreateRepoOptions{
+			Name:          "repo1",
+			Description:   "The first repository",
+			DefaultBranch: "main",
+			EnableIssues:  true,
+		},
+	)
+	require.NoError(t, err)
+	privateRepo, err := db.Create(ctx, alice.ID, CreateRepoOptions{Name: "private", Private: true})
+	require.NoError(t, err)
+
+	t.Run("base repository does not exist", func(t *testing.T) {
+		_, err := db.Fork(ctx, bob.ID, 404, ForkOptions{})
+		wantErr := ErrRepoNotExist{args: errutil.Args{"repoID": int64(404)}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	t.Run("private repository not accessible", func(t *testing.T) {
+		_, err := db.Fork(ctx, bob.ID, privateRepo.ID, ForkOptions{})
+		wantErr := ErrRepoNotExist{args: errutil.Args{"repoID": privateRepo.ID}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	fork, err := db.Fork(ctx, bob.ID, baseRepo.ID, ForkOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "repo1", fork.Name)
+	assert.Equal(t, "The first repository", fork.Description)
+	assert.Equal(t, "main", fork.DefaultBranch)
+	assert.True(t, fork.EnableIssues)
+	assert.True(t, fork.IsFork)
+	assert.Equal(t, baseRepo.ID, fork.ForkID)
+	assert.True(t, db.HasForkedBy(ctx, baseRepo.ID, bob.ID))
+
+	t.Run("already exists", func(t *testing.T) {
+		_, err := db.Fork(ctx, bob.ID, baseRepo.ID, ForkOptions{})
+		wantErr := ErrRepoAlreadyExist{args: errutil.Args{"ownerID": bob.ID, "name": "repo1"}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	// Collaborators should be able to fork private repositories
+	err = NewPermsStore(db.DB).SetRepoPerms(ctx, privateRepo.ID, map[int64]AccessMode{bob.ID: AccessModeRead})
+	require.NoError(t, err)
+	_, err = db.Fork(ctx, bob.ID, privateRepo.ID, ForkOptions{Name: "private-fork"})
+	require.NoError(t, err)
+
+	baseRepo, err = db.GetByID(ctx, baseRepo.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, baseRepo.NumForks)
+
+	bob, err = usersStore.GetByID(ctx, bob.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, bob.NumRepos)
+}
+
+func reposCreateFromTemplate(t *testing.T, db *repos) {
+	ctx := context.Background()
+	conf.SetMockRepository(t, conf.RepositoryOpts{Root: t.TempDir(), MaxCreationLimit: -1})
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	template, err := db.Create(ctx, alice.ID,
+		CreateRepoOptions{
+			Name:          "template",
+			Description:   "The template repository",
+			DefaultBranch: "main",
+			EnableIssues:  true,
+		},
+	)
+	require.NoError(t, err)
+
+	t.Run("template repository does not exist", func(t *testing.T) {
+		_, err := db.CreateFromTemplate(ctx, 404, bob.ID, CreateFromTemplateOptions{Name: "repo1"})
+		wantErr := ErrRepoNotExist{args: errutil.Args{"repoID": int64(404)}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	t.Run("not a template repository", func(t *testing.T) {
+		_, err := db.CreateFromTemplate(ctx, template.ID, bob.ID, CreateFromTemplateOptions{Name: "repo1"})
+		wantErr := ErrRepoNotTemplate{args: errutil.Args{"repoID": template.ID}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	err = db.Model(&Repository{}).Where("id = ?", template.ID).Update("is_template", true).Error
+	require.NoError(t, err)
+
+	t.Run("owner reached the limit of repositories", func(t *testing.T) {
+		carol, err := usersStore.Create(ctx, "carol", "carol@example.com", CreateUserOptions{})
+		require.NoError(t, err)
+		maxRepoCreation := 0
+		err = usersStore.Update(ctx, carol.ID, UpdateUserOptions{MaxRepoCreation: &maxRepoCreation})
+		require.NoError(t, err)
+
+		_, err = db.CreateFromTemplate(ctx, template.ID, carol.ID, CreateFromTemplateOptions{Name: "repo1"})
+		assert.Equal(t, ErrReachLimitOfRepo{Limit: 0}, err)
+	})
+
+	// Mock git content of the template repository with two commits
+	templatePath := RepoPath(alice.Name, template.Name)
+	err = git.Init(templatePath, git.InitOptions{Bare: true})
+	require.NoError(t, err)
+
+	worktree := t.TempDir()
+	err = git.Init(worktree)
+	require.NoError(t, err)
+	_, err = git.SymbolicRef(worktree, git.SymbolicRefOptions{Name: "HEAD", Ref: git.RefsHeads + "main"})
+	require.NoError(t, err)
+	for _, file := range []string{"README.md", "LICENSE"} {
+		err = os.WriteFile(filepath.Join(worktree, file), []byte(file), 0600)
+		require.NoError(t, err)
+		err = git.Add(worktree, git.AddOptions{All: true})
+		require.NoError(t, err)
+		err = git.CreateCommit(worktree, &git.Signature{Name: "alice", Email: "alice@example.com", When: time.Now()}, "Add "+file)
+		require.NoError(t, err)
+	}
+	err = git.Push(worktree, templatePath, "main")
+	require.NoError(t, err)
+
+	// Mock labels and issues of the template repository
+	bug := &Label{RepoID: template.ID, Name: "bug", Color: "#ee0701", NumIssues: 2, NumClosedIssues: 1}
+	err = db.DB.Create(
+		[]*Label{
+			bug,
+			{RepoID: template.ID, Name: "enhancement", Color: "#84b6eb"},
+		},
+	).Error
+	require.NoError(t, err)
+
+	issues := []*Issue{
+		{RepoID: template.ID, Index: 1, PosterID: alice.ID, Title: "Open issue"},
+		{RepoID: template.ID, Index: 2, PosterID: alice.ID, Title: "Pull request", IsPull: true},
+		{RepoID: template.ID, Index: 3, PosterID: alice.ID, Title: "Closed issue", IsClosed: true},
+	}
+	err = db.DB.Create(issues).Error
+	require.NoError(t, err)
+	err = db.DB.Create(
+		[]*IssueLabel{
+			{IssueID: issues[0].ID, LabelID: bug.ID},
+			{IssueID: issues[2].ID, LabelID: bug.ID},
+		},
+	).Error
+	require.NoError(t, err)
+
+	t.Run("without labels and issues", func(t *testing.T) {
+		repo, err := db.CreateFromTemplate(ctx, template.ID, bob.ID, CreateFromTemplateOptions{Name: "repo1"})
+		require.NoError(t, err)
+		assert.Equal(t, "The template repository", repo.Description)
+		assert.Equal(t, "main", repo.DefaultBranch)
+		assert.False(t, repo.IsTemplate)
+		assert.Zero(t, repo.NumIssues)
+
+		var count int64
+		err = db.Model(&Label{}).Where("repo_id = ?", repo.ID).Count(&count).Error
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	repo, err := db.CreateFromTemplate(ctx, template.ID, bob.ID,
+		CreateFromTemplateOptions{
+			Name:          "repo2",
+			Description:   "The second repository",
+			IncludeLabels: true,
+			IncludeIssues: true,
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "The second repository", repo.Description)
+	assert.False(t, repo.IsTemplate)
+	assert.False(t, repo.IsBare)
+	assert.Equal(t, 2, repo.NumIssues)
+	assert.Equal(t, 1, repo.NumClosedIssues)
+	assert.Zero(t, repo.NumStars)
+	assert.Zero(t, repo.NumForks)
+	assert.Equal(t, AccessModeOwner, NewPermsStore(db.DB).AccessMode(ctx, bob.ID, repo.ID, AccessModeOptions{OwnerID: bob.ID}))
+
+	var labels []*Label
+	err = db.Where("repo_id = ?", repo.ID).Order("id").Find(&labels).Error
+	require.NoError(t, err)
+	require.Len(t, labels, 2)
+	assert.Equal(t, "bug", labels[0].Name)
+	assert.Equal(t, 2, labels[0].NumIssues)
+	assert.Equal(t, 1, labels[0].NumClosedIssues)
+	assert.Equal(t, "enhancement", labels[1].Name)
+	assert.Zero(t, labels[1].NumIssues)
+
+	var titles []string
+	err = db.Model(&Issue{}).Where("repo_id = ?", repo.ID).Order("id").Pluck("name", &titles).Error
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Open issue", "Closed issue"}, titles)
+
+	// The history of the template repository should be reset
+	gitRepo, err := git.Open(RepoPath(bob.Name, repo.Name))
+	require.NoError(t, err)
+	commit, err := gitRepo.BranchCommit("main")
+	require.NoError(t, err)
+	assert.Equal(t, "Initial commit", strings.TrimSpace(commit.Message))
+	count, err := commit.CommitsCount()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	_, err = commit.Blob("LICENSE")
+	require.NoError(t, err)
+
+	t.Run("already exists", func(t *testing.T) {
+		_, err := db.CreateFromTemplate(ctx, template.ID, bob.ID, CreateFromTemplateOptions{Name: "repo2"})
+		wantErr := ErrRepoAlreadyExist{args: errutil.Args{"ownerID": bob.ID, "name": "repo2"}}
+		assert.Equal(t, wantErr, err)
+	})
+}
+
+func reposSetCollaboratorExpiry(t *testing.T, db *repos) {
+	ctx := context.Background()
+
+	repo1, err := db.Create(ctx, 1, CreateRepoOptions{Name: "repo1", Private: true})
+	require.NoError(t, err)
+
+	t.Run("not a collaborator", func(t *testing.T) {
+		err := db.SetCollaboratorExpiry(ctx, repo1.ID, 2, 0)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+
+	err = db.DB.Create(&Collaboration{RepoID: repo1.ID, UserID: 2, Mode: AccessModeWrite}).Error
+	require.NoError(t, err)
+	err = recalculateAccesses(db.DB, repo1.ID)
+	require.NoError(t, err)
+
+	permsStore := NewPermsStore(db.DB)
+	opts := AccessModeOptions{OwnerID: 1, Private: true}
+	assert.Equal(t, AccessModeWrite, permsStore.AccessMode(ctx, 2, repo1.ID, opts))
+
+	err = db.SetCollaboratorExpiry(ctx, repo1.ID, 2, time.Now().Add(time.Hour).Unix())
+	require.NoError(t, err)
+	assert.Equal(t, AccessModeWrite, permsStore.AccessMode(ctx, 2, repo1.ID, opts))
+
+	err = db.SetCollaboratorExpiry(ctx, repo1.ID, 2, time.Now().Add(-time.Hour).Unix())
+	require.NoError(t, err)
+	assert.Equal(t, AccessModeNone, permsStore.AccessMode(ctx, 2, repo1.ID, opts))
+
+	// The collaboration should be kept until the sweep
+	collaboration := new(Collaboration)
+	err = db.Where("repo_id = ? AND user_id = ?", repo1.ID, 2).First(collaboration).Error
+	require.NoError(t, err)
+	assert.True(t, collaboration.IsExpired())
+}
+
+func reposDeleteExpiredCollaborators(t *testing.T, db *repos) {
+	ctx := context.Background()
+
+	repo1, err := db.Create(ctx, 1, CreateRepoOptions{Name: "repo1", Private: true})
+	require.NoError(t, err)
+
+	now := time.Now()
+	err = db.DB.Create(
+		[]*Collaboration{
+			{RepoID: repo1.ID, UserID: 2, Mode: AccessModeWrite},
+			{RepoID: repo1.ID, UserID: 3, Mode: AccessModeWrite, ExpiresUnix: now.Add(time.Hour).Unix()},
+			{RepoID: repo1.ID, UserID: 4, Mode: AccessModeWrite, ExpiresUnix: now.Add(-time.Hour).Unix()},
+		},
+	).Error
+	require.NoError(t, err)
+
+	// Simulate access records that were created before the collaboration expired
+	err = NewPermsStore(db.DB).SetRepoPerms(ctx, repo1.ID,
+		map[int64]AccessMode{
+			2: AccessModeWrite,
+			3: AccessModeWrite,
+			4: AccessModeWrite,
+		},
+	)
+	require.NoError(t, err)
+
+	err = db.DeleteExpiredCollaborators(ctx)
+	require.NoError(t, err)
+
+	var userIDs []int64
+	err = db.Model(&Collaboration{}).Where("repo_id = ?", repo1.ID).Order("user_id").Pluck("user_id", &userIDs).Error
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 3}, userIDs)
+
+	err = db.Model(&Access{}).Where("repo_id = ?", repo1.ID).Order("user_id").Pluck("user_id", &userIDs).Error
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 3}, userIDs)
+}
+
+func reposSetArchived(t *testing.T, db *repos) {
+	ctx := context.Background()
+
+	t.Run("repository does not exist", func(t *testing.T) {
+		err := db.SetArchived(ctx, 404, true)
+		wantErr := ErrRepoNotExist{args: errutil.Args{"repoID": int64(404)}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	repo1, err := db.Create(ctx, 1, CreateRepoOptions{Name: "repo1"})
+	require.NoError(t, err)
+	assert.False(t, repo1.IsArchived)
+
+	err = db.SetArchived(ctx, repo1.ID, true)
+	require.NoError(t, err)
+	got, err := db.GetByID(ctx, repo1.ID)
+	require.NoError(t, err)
+	assert.True(t, got.IsArchived)
+
+	err = db.SetArchived(ctx, repo1.ID, false)
+	require.NoError(t, err)
+	got, err = db.GetByID(ctx, repo1.ID)
+	require.NoError(t, err)
+	assert.False(t, got.IsArchived)
+}
+
+func reposSoftDelete(t *testing.T, db *repos) {
+	ctx := context.Background()
+
+	repo1, err := db.Create(ctx, 1, CreateRepoOptions{Name: "repo1"})
+	require.NoError(t, err)
+
+	err = db.SoftDelete(ctx, repo1.ID)
+	require.NoError(t, err)
+
+	_, err = db.GetByID(ctx, repo1.ID)
+	wantErr := ErrRepoNotExist{args: errutil.Args{"repoID": repo1.ID}}
+	assert.Equal(t, wantErr, err)
+
+	_, err = db.GetByName(ctx, 1, repo1.Name)
+	wantErr = ErrRepoNotExist{args: errutil.Args{"ownerID": int64(1), "name": repo1.Name}}
+	assert.Equal(t, wantErr, err)
+
+	// Soft-delete again should fail
+	err = db.SoftDelete(ctx, repo1.ID)
+	wantErr = ErrRepoNotExist{args: errutil.Args{"repoID": repo1.ID}}
+	assert.Equal(t, wantErr, err)
+
+	err = db.Restore(ctx, repo1.ID)
+	require.NoError(t, err)
+
+	got, err := db.GetByName(ctx, 1, repo1.Name)
+	require.NoError(t, err)
+	assert.Zero(t, got.DeletedUnix)
+
+	// Restore again should fail
+	err = db.Restore(ctx, repo1.ID)
+	assert.Equal(t, wantErr, err)
+}
+
+func reposTransferAllByOwner(t *testing.T, db *repos) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+	ownersTeam := createTestTeam(t, db.DB, &Team{
+		OrgID:      org1.ID,
+		LowerName:  "owners",
+		Name:       OWNER_TEAM,
+		Authorize:  AccessModeOwner,
+		NumMembers: 1,
+	}, []int64{bob.ID}, nil)
+	err = db.Exec(`INSERT INTO org_user (uid, org_id, is_owner) VALUES (?, ?, ?)`, bob.ID, org1.ID, true).Error
+	require.NoError(t, err)
+
+	repo1, err := db.Create(ctx, alice.ID, CreateRepoOptions{Name: "repo1", Private: true})
+	require.NoError(t, err)
+	repo2, err := db.Create(ctx, alice.ID, CreateRepoOptions{Name: "Repo2"})
+	require.NoError(t, err)
+	_, err = db.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo2"})
+	require.NoError(t, err)
+
+	// Bob's collaboration is redundant once the repository belongs to the
+	// organization.
+	err = db.DB.Create(&Collaboration{RepoID: repo1.ID, UserID: bob.ID, Mode: AccessModeRead}).Error
+	require.NoError(t, err)
+
+	skipped, err := db.TransferAllByOwner(ctx, alice.ID, org1.ID)
+	require.NoError(t, err)
+	require.Len(t, skipped, 1)
+	assert.Equal(t, repo2.ID, skipped[0].ID)
+
+	repo1, err = db.GetByID(ctx, repo1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, org1.ID, repo1.OwnerID)
+	repo2, err = db.GetByID(ctx, repo2.ID)
+	require.NoError(t, err)
+	assert.Equal(t, alice.ID, repo2.OwnerID)
+
+	alice, err = usersStore.GetByID(ctx, alice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, alice.NumRepos)
+	org1, err = usersStore.GetByID(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, org1.NumRepos)
+
+	err = db.First(ownersTeam, ownersTeam.ID).Error
+	require.NoError(t, err)
+	assert.Equal(t, 1, ownersTeam.NumRepos)
+
+	var count int64
+	err = db.Model(&Collaboration{}).Where("repo_id = ?", repo1.ID).Count(&count).Error
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	permsStore := NewPermsStore(db.DB)
+	mode := permsStore.AccessMode(ctx, bob.ID, repo1.ID, AccessModeOptions{OwnerID: repo1.OwnerID, Private: true})
+	assert.Equal(t, AccessModeOwner, mode)
+	mode = permsStore.AccessMode(ctx, alice.ID, repo1.ID, AccessModeOptions{OwnerID: repo1.OwnerID, Private: true})
+	assert.Equal(t, AccessModeNone, mode)
+
+	// Transfer the rest back to a user.
+	skipped, err = db.TransferAllByOwner(ctx, org1.ID, bob.ID)
+	require.NoError(t, err)
+	assert.Empty(t, skipped)
+
+	err = db.First(ownersTeam, ownersTeam.ID).Error
+	require.NoError(t, err)
+	assert.Equal(t, 0, ownersTeam.NumRepos)
+
+	bob, err = usersStore.GetByID(ctx, bob.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, bob.NumRepos)
+}
+
+func reposAddDeployKey(t *testing.T, db *repos) {
+	ctx := context.Background()
+
+	conf.SetMockSSH(t,
+		conf.SSHOpts{
+			RootPath:   t.TempDir(),
+			KeygenPath: "ssh-keygen",
+		},
+	)
+
+	const content = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAICHJSzVxly0t+K63wSCXVj0AbaXPdDSEZVgBZJQEGUCi"
+	err := db.AddDeployKey(ctx, 1, "deploy", content, false)
+	require.NoError(t, err)
+
+	// The same key can be used by multiple repositories with different scopes.
+	err = db.AddDeployKey(ctx, 2, "deploy", content, true)
+	require.NoError(t, err)
+
+	var pkeys []*PublicKey
+	err = db.Where("content = ?", content).Find(&pkeys).Error
+	require.NoError(t, err)
+	require.Len(t, pkeys, 1)
+	assert.Equal(t, KeyType(KEY_TYPE_DEPLOY), pkeys[0].Type)
+	assert.NotEmpty(t, pkeys[0].Fingerprint)
+
+	authorizedKeys, err := os.ReadFile(authorizedKeysPath())
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(authorizedKeys), content))
+
+	var keys []*DeployKey
+	err = db.Where("key_id = ?", pkeys[0].ID).Order("repo_id").Find(&keys).Error
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, "deploy", keys[0].Name)
+	assert.Equal(t, pkeys[0].Fingerprint, keys[0].Fingerprint)
+	assert.False(t, keys[0].ReadOnly)
+	assert.True(t, keys[1].ReadOnly)
+
+	t.Run("already exists", func(t *testing.T) {
+		err := db.AddDeployKey(ctx, 1, "another", content, true)
+		assert.Equal(t, ErrDeployKeyAlreadyExist{pkeys[0].ID, 1}, err)
+	})
+
+	t.Run("name already used", func(t *testing.T) {
+		const otherContent = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIE2YilQXo4cmf2Khf3RxX9jwcqTGWbgjnlDwNfF6xP2A"
+		err := db.AddDeployKey(ctx, 1, "deploy", otherContent, true)
+		assert.Equal(t, ErrDeployKeyNameAlreadyUsed{1, "deploy"}, err)
+
+		// The key should not be written when the transaction is rolled back.
+		authorizedKeys, err := os.ReadFile(authorizedKeysPath())
+		require.NoError(t, err)
+		assert.NotContains(t, string(authorizedKeys), otherContent)
+	})
+
+	t.Run("used by user key", func(t *testing.T) {
+		const userContent = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHJ1ZXllcy1rZXktdGhhdC1pcy11c2VkLWJ5LWEtdXNlcg"
+		err := db.DB.Create(&PublicKey{OwnerID: 1, Name: "user", Fingerprint: "user", Content: userContent}).Error
+		require.NoError(t, err)
+
+		err = db.AddDeployKey(ctx, 1, "user", userContent, true)
+		assert.Equal(t, ErrKeyAlreadyExist{0, userContent}, err)
+	})
+}
+
+func reposAddProtectedTag(t *testing.T, db *repos) {
+	ctx := context.Background()
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := db.AddProtectedTag(ctx, 1, "v[", AccessModeAdmin)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid allowed access mode", func(t *testing.T) {
+		_, err := db.AddProtectedTag(ctx, 1, "v*", AccessModeRead)
+		assert.Error(t, err)
+	})
+
+	tag, err := db.AddProtectedTag(ctx, 1, "v*", AccessModeAdmin)
+	require.NoError(t, err)
+	assert.NotZero(t, tag.ID)
+	_, err = db.AddProtectedTag(ctx, 1, "release/*", AccessModeWrite)
+	require.NoError(t, err)
+	// Same pattern for another repository is fine
+	_, err = db.AddProtectedTag(ctx, 2, "v*", AccessModeOwner)
+	require.NoError(t, err)
+
+	t.Run("already exists", func(t *testing.T) {
+		_, err := db.AddProtectedTag(ctx, 1, "v*", AccessModeOwner)
+		wantErr := ErrProtectedTagAlreadyExist{args: errutil.Args{"repoID": int64(1), "pattern": "v*"}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	tags, err := db.ListProtectedTags(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+	assert.Equal(t, "release/*", tags[0].Pattern)
+	assert.Equal(t, AccessModeWrite, tags[0].AllowedMode)
+	assert.Equal(t, "v*", tags[1].Pattern)
+	assert.Equal(t, AccessModeAdmin, tags[1].AllowedMode)
+
+	assert.True(t, tags[1].Match("v1.0.0"))
+	assert.False(t, tags[1].Match("1.0.0"))
+	assert.True(t, tags[0].Match("release/2024"))
+	assert.False(t, tags[0].Match("release/2024/rc1"))
+}
+
+func reposCleanupOrphanedAccess(t *testing.T, db *repos) {
+	ctx := context.Background()
+
+	repo1, err := db.Create(ctx, 1, CreateRepoOptions{Name: "repo1"})
+	require.NoError(t, err)
+	// Soft-deleted repositories can still be restored, their rows are kept.
+	repo2, err := db.Create(ctx, 1, CreateRepoOptions{Name: "repo2"})
+	require.NoError(t, err)
+	err = db.SoftDelete(ctx, repo2.ID)
+	require.NoError(t, err)
+	const deletedRepoID = 404
+
+	team := createTestTeam(t, db.DB,
+		&Team{OrgID: 2, Name: "team1", NumRepos: 3},
+		nil,
+		[]int64{repo1.ID, repo2.ID, deletedRepoID},
+	)
+	err = db.DB.Create(
+		[]*Access{
+			{UserID: 1, RepoID: repo1.ID, Mode: AccessModeRead},
+			{UserID: 1, RepoID: repo2.ID, Mode: AccessModeRead},
+			{UserID: 1, RepoID: deletedRepoID, Mode: AccessModeRead},
+			{UserID: 2, RepoID: deletedRepoID, Mode: AccessModeWrite},
+		},
+	).Error
+	require.NoError(t, err)
+
+	deleted, err := db.CleanupOrphanedAccess(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+
+	var repoIDs []int64
+	err = db.Model(&Access{}).Order("repo_id").Pluck("repo_id", &repoIDs).Error
+	require.NoError(t, err)
+	assert.Equal(t, []int64{repo1.ID, repo2.ID}, repoIDs)
+
+	err = db.Model(&TeamRepo{}).Order("repo_id").Pluck("repo_id", &repoIDs).Error
+	require.NoError(t, err)
+	assert.Equal(t, []int64{repo1.ID, repo2.ID}, repoIDs)
+
+	err = db.First(team, team.ID).Error
+	require.NoError(t, err)
+	assert.Equal(t, 2, team.NumRepos)
+
+	// Running again should be a no-op
+	deleted, err = db.CleanupOrphanedAccess(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+}
+
+func reposListMirrorsToSync(t *testing.T, db *repos) {
+	ctx := context.Background()
+
+	repo1, err := db.Create(ctx, 1, CreateRepoOptions{Name: "repo1", Mirror: true})
+	require.NoError(t, err)
+	repo2, err := db.Create(ctx, 1, CreateRepoOptions{Name: "repo2", Mirror: true})
+	require.NoError(t, err)
+	repo3, err := db.Create(ctx, 1, CreateRepoOptions{Name: "repo3", Mirror: true})
+	require.NoError(t, err)
+
+	mirrors := []*Mirror{
+		{RepoID: repo1.ID, NextSyncUnix: 200},
+		{RepoID: repo2.ID, NextSyncUnix: 100},
+		{RepoID: repo3.ID, NextSyncUnix: 400}, // Not due yet
+		{RepoID: 404, NextSyncUnix: 50},       // Disconnected
+	}
+	err = db.DB.Create(mirrors).Error
+	require.NoError(t, err)
+
+	repoIDs := func(mirrors []*Mirror) []int64 {
+		ids := make([]int64, 0, len(mirrors))
+		for _, m := range mirrors {
+			ids = append(ids, m.RepoID)
+		}
+		return ids
+	}
+
+	got, err := db.ListMirrorsToSync(ctx, 300, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{repo2.ID, repo1.ID}, repoIDs(got))
+	assert.Equal(t, int64(100), got[0].NextSync.Unix())
+
+	got, err = db.ListMirrorsToSync(ctx, 300, 1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{repo2.ID}, repoIDs(got))
+
+	t.Run("skip mirrors failed too many times", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			err := db.SetMirrorSyncResult(ctx, repo2.ID, false, "oops")
+			require.NoError(t, err)
+		}
+
+		got, err := db.ListMirrorsToSync(ctx, 300, 10, 3)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{repo1.ID}, repoIDs(got))
+
+		got, err = db.ListMirrorsToSync(ctx, 300, 10, 4)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{repo2.ID, repo1.ID}, repoIDs(got))
+	})
+
+	t.Run("skip soft-deleted repositories", func(t *testing.T) {
+		err := db.SoftDelete(ctx, repo1.ID)
+		require.NoError(t, err)
+
+		got, err := db.ListMirrorsToSync(ctx, 300, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{repo2.ID}, repoIDs(got))
+	})
+}
+
+func reposSetMirrorSyncResult(t *testing.T, db *repos) {
+	ctx := context.Background()
+
+	repo1, err := db.Create(ctx, 1, CreateRepoOptions{Name: "repo1", Mirror: true})
+	require.NoError(t, err)
+	err = db.DB.Create(&Mirror{RepoID: repo1.ID}).Error
+	require.NoError(t, err)
+
+	getMirror := func(t *testing.T) *Mirror {
+		t.Helper()
+
+		m := new(Mirror)
+		err := db.Where("repo_id = ?", repo1.ID).First(m).Error
+		require.NoError(t, err)
+		return m
+	}
+
+	err = db.SetMirrorSyncResult(ctx, repo1.ID, false, "connection refused")
+	require.NoError(t, err)
+	err = db.SetMirrorSyncResult(ctx, repo1.ID, false, "authentication failed")
+	require.NoError(t, err)
+
+	got := getMirror(t)
+	assert.Equal(t, "authentication failed", got.LastError)
+	assert.Equal(t, 2, got.NumSyncFailures)
+	assert.Zero(t, got.LastSyncUnix)
+
+	err = db.SetMirrorSyncResult(ctx, repo1.ID, true, "")
+	require.NoError(t, err)
+
+	got = getMirror(t)
+	assert.Empty(t, got.LastError)
+	assert.Zero(t, got.NumSyncFailures)
+	assert.Equal(t, db.NowFunc().Unix(), got.LastSyncUnix)
+}
+
+func reposSetTopics(t *testing.T, db *repos) {
+	ctx := context.Background()
+
+	repo1, err := db.Create(ctx, 1, CreateRepoOptions{Name: "repo1"})
+	require.NoError(t, err)
+	repo2, err := db.Create(ctx, 1, CreateRepoOptions{Name: "repo2"})
+	require.NoError(t, err)
+
+	topicNames := func(t *testing.T, repoID int64) []string {
+		topics, err := db.ListTopics(ctx, repoID)
+		require.NoError(t, err)
+
+		names := make([]string, len(topics))
+		for i := range topics {
+			names[i] = topics[i].Name
+		}
+		return names
+	}
+	numRepos := func(t *testing.T, name string) int {
+		topic := new(Topic)
+		err := db.Where("name = ?", name).First(topic).Error
+		require.NoError(t, err)
+		return topic.NumRepos
+	}
+
+	err = db.SetTopics(ctx, repo1.ID, []string{"Go", "git", " go ", "", "web-ui"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"git", "go", "web-ui"}, topicNames(t, repo1.ID))
+
+	err = db.SetTopics(ctx, repo2.ID, []string{"go"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, numRepos(t, "go"))
+
+	// Replace topics of the repository
+	err = db.SetTopics(ctx, repo1.ID, []string{"git"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"git"}, topicNames(t, repo1.ID))
+	assert.Equal(t, 1, numRepos(t, "go"))
+	assert.Equal(t, 0, numRepos(t, "web-ui"))
+
+	t.Run("invalid topics", func(t *testing.T) {
+		tooMany := make([]string, maxTopicsPerRepo+1)
+		for i := range tooMany {
+			tooMany[i] = fmt.Sprintf("topic%d", i)
+		}
+
+		for _, topics := range [][]string{
+			{"-go"},
+			{"go lang"},
+			{"c++"},
+			{strings.Repeat("a", maxTopicNameLength+1)},
+			tooMany,
+		} {
+			err := db.SetTopics(ctx, repo1.ID, topics)
+			assert.True(t, IsErrInvalidTopic(err), "%v", topics)
+		}
+		assert.Equal(t, []string{"git"}, topicNames(t, repo1.ID))
+	})
+
+	// Clear all topics
+	err = db.SetTopics(ctx, repo1.ID, nil)
+	require.NoError(t, err)
+	assert.Empty(t, topicNames(t, repo1.ID))
+	assert.Equal(t, 0, numRepos(t, "git"))
+}
+
+func reposSearchByTopic(t *testing.T, db *repos) {
+	ctx := context.Background()
+
+	repo1, err := db.Create(ctx, 1, CreateRepoOptions{Name: "repo1"})
+	require.NoError(t, err)
+	repo2, err := db.Create(ctx, 1, CreateRepoOptions{Name: "repo2"})
+	require.NoError(t, err)
+	repo3, err := db.Create(ctx, 1, CreateRepoOptions{Name: "repo3", Private: true})
+	require.NoError(t, err)
+	_, err = db.Create(ctx, 1, CreateRepoOptions{Name: "repo4"})
+	require.NoError(t, err)
+
+	for _, repoID := range []int64{repo1.ID, repo2.ID, repo3.ID} {
+		err = db.SetTopics(ctx, repoID, []string{"go"})
+		require.NoError(t, err)
+	}
+
+	got, count, err := db.SearchByTopic(ctx, "Go", 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	gotNames := make([]string, len(got))
+	for i := range got {
+		gotNames[i] = got[i].Name
+	}
+	assert.ElementsMatch(t, []string{repo1.Name, repo2.Name}, gotNames)
+
+	got, count, err = db.SearchByTopic(ctx, "go", 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.Len(t, got, 1)
+
+	got, count, err = db.SearchByTopic(ctx, "rust", 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+	assert.Empty(t, got)
+}
+
+func reposApplyOrgLabels(t *testing.T, db *repos) {
+	ctx := context.Background()
+
+	repo1, err := db.Create(ctx, 1, CreateRepoOptions{Name: "repo1"})
+	require.NoError(t, err)
+
+	// No-op when the organization has no templates
+	err = db.ApplyOrgLabels(ctx, repo1.ID, 1)
+	require.NoError(t, err)
+
+	err = NewOrgsStore(db.DB).SetDefaultLabels(ctx, 1,
+		[]*OrgLabel{
+			{Name: "bug", Color: "#ee0701"},
+			{Name: "enhancement", Color: "#84b6eb"},
+		},
+	)
+	require.NoError(t, err)
+
+	err = db.DB.Create(
+		[]*Label{
+			{RepoID: repo1.ID, Name: "bug", Color: "#000000"},
+			{RepoID: repo1.ID, Name: "wontfix", Color: "#ffffff"},
+		},
+	).Error
+	require.NoError(t, err)
+
+	listLabels := func(t *testing.T, repoID int64) map[string]string {
+		t.Helper()
+
+		var labels []*Label
+		err := db.Where("repo_id = ?", repoID).Find(&labels).Error
+		require.NoError(t, err)
+
+		colors := make(map[string]string, len(labels))
+		for _, l := range labels {
+			colors[l.Name] = l.Color
+		}
+		return colors
+	}
+
+	err = db.ApplyOrgLabels(ctx, repo1.ID, 1)
+	require.NoError(t, err)
+	want := map[string]string{
+		"bug":         "#ee0701",
+		"enhancement": "#84b6eb",
+		"wontfix":     "#ffffff",
+	}
+	assert.Equal(t, want, listLabels(t, repo1.ID))
+
+	// Applying again should not create duplicates
+	err = db.ApplyOrgLabels(ctx, repo1.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, want, listLabels(t, repo1.ID))
+}
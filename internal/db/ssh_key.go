@@ -372,21 +372,29 @@ func checkKeyContent(content string) error {
 	return nil
 }
 
-func addKey(e Engine, key *PublicKey) (err error) {
-	// Calculate fingerprint.
+// calcFingerprint returns the fingerprint of the public key content calculated
+// by ssh-keygen.
+func calcFingerprint(content string) (string, error) {
 	tmpPath := strings.ReplaceAll(path.Join(os.TempDir(), fmt.Sprintf("%d", time.Now().Nanosecond()), "id_rsa.pub"), "\\", "/")
 	_ = os.MkdirAll(path.Dir(tmpPath), os.ModePerm)
-	if err = os.WriteFile(tmpPath, []byte(key.Content), 0644); err != nil {
-		return err
+	if err := os.WriteFile(tmpPath, []byte(content), 0644); err != nil {
+		return "", err
 	}
 
 	stdout, stderr, err := process.Exec("AddPublicKey", conf.SSH.KeygenPath, "-lf", tmpPath)
 	if err != nil {
-		return fmt.Errorf("fail to parse public key: %s - %s", err, stderr)
+		return "", fmt.Errorf("fail to parse public key: %s - %s", err, stderr)
 	} else if len(stdout) < 2 {
-		return errors.New("not enough output for calculating fingerprint: " + stdout)
+		return "", errors.New("not enough output for calculating fingerprint: " + stdout)
+	}
+	return strings.Split(stdout, " ")[1], nil
+}
+
+func addKey(e Engine, key *PublicKey) (err error) {
+	key.Fingerprint, err = calcFingerprint(key.Content)
+	if err != nil {
+		return err
 	}
-	key.Fingerprint = strings.Split(stdout, " ")[1]
 
 	// Save SSH key.
 	if _, err = e.Insert(key); err != nil {
@@ -564,19 +572,20 @@ func RewriteAuthorizedKeys() error {
 
 // DeployKey represents deploy key information and its relation with repository.
 type DeployKey struct {
-	ID          int64
-	KeyID       int64 `xorm:"UNIQUE(s) INDEX"`
-	RepoID      int64 `xorm:"UNIQUE(s) INDEX"`
+	ID          int64 `gorm:"primaryKey"`
+	KeyID       int64 `xorm:"UNIQUE(s) INDEX" gorm:"uniqueIndex:deploy_key_key_repo_unique;index"`
+	RepoID      int64 `xorm:"UNIQUE(s) INDEX" gorm:"uniqueIndex:deploy_key_key_repo_unique;index"`
 	Name        string
 	Fingerprint string
-	Content     string `xorm:"-" json:"-"`
+	ReadOnly    bool   `xorm:"NOT NULL DEFAULT true" gorm:"not null"`
+	Content     string `xorm:"-" json:"-" gorm:"-"`
 
-	Created           time.Time `xorm:"-" json:"-"`
+	Created           time.Time `xorm:"-" json:"-" gorm:"-"`
 	CreatedUnix       int64
-	Updated           time.Time `xorm:"-" json:"-"` // Note: Updated must below Created for AfterSet.
+	Updated           time.Time `xorm:"-" json:"-" gorm:"-"` // Note: Updated must below Created for AfterSet.
 	UpdatedUnix       int64
-	HasRecentActivity bool `xorm:"-" json:"-"`
-	HasUsed           bool `xorm:"-" json:"-"`
+	HasRecentActivity bool `xorm:"-" json:"-" gorm:"-"`
+	HasUsed           bool `xorm:"-" json:"-" gorm:"-"`
 }
 
 func (k *DeployKey) BeforeInsert() {
@@ -638,6 +647,7 @@ func addDeployKey(e *xorm.Session, keyID, repoID int64, name, fingerprint string
 		RepoID:      repoID,
 		Name:        name,
 		Fingerprint: fingerprint,
+		ReadOnly:    true,
 	}
 	_, err := e.Insert(key)
 	return key, err
@@ -649,7 +659,8 @@ func HasDeployKey(keyID, repoID int64) bool {
 	return has
 }
 
-// AddDeployKey add new deploy key to database and authorized_keys file.
+// AddDeployKey add new read-only deploy key to database and authorized_keys
+// file.
 func AddDeployKey(repoID int64, name, content string) (*DeployKey, error) {
 	if err := checkKeyContent(content); err != nil {
 		return nil, err
@@ -738,6 +749,32 @@ func UpdateDeployKey(key *DeployKey) error {
 	return err
 }
 
+type ErrDeployKeyReadOnly struct {
+	args map[string]any
+}
+
+func IsErrDeployKeyReadOnly(err error) bool {
+	_, ok := err.(ErrDeployKeyReadOnly)
+	return ok
+}
+
+func (err ErrDeployKeyReadOnly) Error() string {
+	return fmt.Sprintf("deploy key is read-only: %v", err.args)
+}
+
+// DeployKeyPusher returns the user that pushes with the deploy key are made on
+// behalf of. Deploy keys do not belong to any user, so pushes are attributed to
+// the owner of the repository, which must be loaded beforehand. It returns
+// ErrDeployKeyReadOnly if the deploy key does not allow pushes.
+func DeployKeyPusher(key *DeployKey, repo *Repository) (*User, error) {
+	if key.ReadOnly {
+		return nil, ErrDeployKeyReadOnly{args: map[string]any{"deployKeyID": key.ID}}
+	} else if repo.Owner == nil {
+		return nil, errors.New("repository owner is not loaded")
+	}
+	return repo.Owner, nil
+}
+
 // DeleteDeployKey deletes deploy key from its repository authorized_keys file if needed.
 func DeleteDeployKey(doer *User, id int64) error {
 	key, err := GetDeployKeyByID(id)
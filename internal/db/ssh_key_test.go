@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"gogs.io/gogs/internal/conf"
 )
@@ -76,3 +77,30 @@ func Test_SSHParsePublicKey(t *testing.T) {
 		})
 	}
 }
+
+func TestDeployKeyPusher(t *testing.T) {
+	owner := &User{ID: 1, Name: "alice"}
+	repo := &Repository{ID: 1, OwnerID: owner.ID, Owner: owner}
+
+	t.Run("read-only", func(t *testing.T) {
+		_, err := DeployKeyPusher(&DeployKey{ID: 1, ReadOnly: true}, repo)
+		assert.True(t, IsErrDeployKeyReadOnly(err))
+	})
+
+	t.Run("read-write", func(t *testing.T) {
+		got, err := DeployKeyPusher(&DeployKey{ID: 2}, repo)
+		require.NoError(t, err)
+		assert.Equal(t, owner, got)
+
+		// The pusher must be usable by Git hooks.
+		envs, err := ComposeHookEnvs(ComposeHookEnvsOptions{AuthUser: got, RepoID: repo.ID})
+		require.NoError(t, err)
+		assert.Contains(t, envs, ENV_AUTH_USER_ID+"=1")
+		assert.Contains(t, envs, ENV_AUTH_USER_NAME+"=alice")
+	})
+
+	t.Run("owner not loaded", func(t *testing.T) {
+		_, err := DeployKeyPusher(&DeployKey{ID: 2}, &Repository{ID: 1, OwnerID: owner.ID})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,947 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"gogs.io/gogs/internal/dbutil"
+	"gogs.io/gogs/internal/errutil"
+	"gogs.io/gogs/internal/userutil"
+)
+
+// TeamsStore is the persistent interface for teams of organizations.
+type TeamsStore interface {
+	// Create creates a new team with the given name in the organization. Team
+	// names are unique case-insensitively within the organization. It returns
+	// ErrNameNotAllowed when the name is not allowed, ErrOrgNotExist when the
+	// organization does not exist, or ErrTeamAlreadyExist when a team with the
+	// same name already exists.
+	Create(ctx context.Context, orgID int64, name string, opts CreateTeamOptions) (*Team, error)
+	// IsTeamMember returns true if the user is a member of the team.
+	IsTeamMember(ctx context.Context, teamID, userID int64) bool
+	// ListMembers returns all members of the team, sorted by user ID in ascending
+	// order.
+	ListMembers(ctx context.Context, teamID int64) ([]*User, error)
+	// RepoAccessMode returns the access mode that the team grants to its members
+	// on the repository, or AccessModeNone when the repository is not associated
+	// with the team. It returns ErrTeamNotExist when the team does not exist.
+	RepoAccessMode(ctx context.Context, teamID, repoID int64) (AccessMode, error)
+	// RecomputeAccess recalculates accesses of all repositories associated with
+	// the team, or all repositories of the organization for the Owners team, in a
+	// single transaction using set-based queries. It is idempotent, and returns
+	// ErrTeamNotExist when the team does not exist.
+	RecomputeAccess(ctx context.Context, teamID int64) error
+	// ListRepos returns a range of repositories that are associated with the
+	// team, sorted by the time of last update in descending order. Results are
+	// paginated by given page and page size, and a total count of all results is
+	// also returned. Soft-deleted repositories are not included.
+	ListRepos(ctx context.Context, teamID int64, page, pageSize int) ([]*Repository, int64, error)
+	// ListByOrg returns all teams of the organization along with their numbers of
+	// members and repositories, sorted by team ID in ascending order with the
+	// Owners team first.
+	ListByOrg(ctx context.Context, orgID int64) ([]*TeamWithCounts, error)
+	// AddTeamMember adds the user to the team, the user also becomes a member of
+	// the organization if not already. It is a no-op when the user is already a
+	// member of the team.
+	AddTeamMember(ctx context.Context, teamID, userID int64) error
+	// RemoveTeamMember removes the user from the team. It is a no-op when the user
+	// is not a member of the team. It returns ErrLastOrgOwner when the user is the
+	// last member of the Owners team.
+	RemoveTeamMember(ctx context.Context, teamID, userID int64) error
+	// Update updates fields for the given team. When the access level is changed,
+	// accesses of members are recalculated in the same transaction. It returns
+	// ErrTeamNotExist when not found, ErrTeamAlreadyExist when the new name is
+	// already used by another team of the organization, ErrOwnersTeamRename when
+	// renaming the Owners team, or ErrTeamDescriptionTooLong when the description
+	// is too long.
+	Update(ctx context.Context, teamID int64, opts UpdateTeamOptions) error
+	// MoveRepos moves the given repositories from one team to another team of the
+	// same organization, and recalculates accesses of the repositories for members
+	// of both teams. Duplicated repository IDs are ignored. It returns
+	// ErrTeamNotExist when either team does not exist, ErrTeamOrgMismatch when
+	// teams belong to different organizations, or ErrTeamRepoNotExist when any
+	// repository is not associated with the source team.
+	MoveRepos(ctx context.Context, fromTeamID, toTeamID int64, repoIDs []int64) error
+	// DeleteByID deletes the team along with its relations to members and
+	// repositories, and recalculates accesses of the repositories that were
+	// associated with the team. Pending invitations to join the team fall back to
+	// joining the organization only. It returns ErrTeamNotExist when not found.
+	// The Owners team cannot be deleted.
+	DeleteByID(ctx context.Context, teamID int64) error
+}
+
+var Teams TeamsStore
+
+var _ TeamsStore = (*teams)(nil)
+
+type teams struct {
+	*gorm.DB
+}
+
+// NewTeamsStore returns a persistent interface for teams with given database
+// connection.
+func NewTeamsStore(db *gorm.DB) TeamsStore {
+	return &teams{DB: db}
+}
+
+type CreateTeamOptions struct {
+	Description string
+	Authorize   AccessMode
+}
+
+func (db *teams) Create(ctx context.Context, orgID int64, name string, opts CreateTeamOptions) (*Team, error) {
+	if name == "" {
+		return nil, errors.New("empty team name")
+	}
+
+	err := IsUsableTeamName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	team := &Team{
+		OrgID:       orgID,
+		LowerName:   strings.ToLower(name),
+		Name:        name,
+		Description: opts.Description,
+		Authorize:   opts.Authorize,
+	}
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("id = ? AND type = ?", orgID, UserTypeOrganization).First(&User{}).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrOrgNotExist{args: errutil.Args{"orgID": orgID}}
+			}
+			return errors.Wrap(err, "get organization")
+		}
+
+		// NOTE: The unique index on (org_id, lower_name) is the last line of defense
+		// against concurrent creations, check beforehand to return a friendly error.
+		existing := new(Team)
+		err = tx.Where("org_id = ? AND lower_name = ?", orgID, team.LowerName).First(existing).Error
+		if err == nil {
+			return ErrTeamAlreadyExist{ID: existing.ID, OrgID: orgID, Name: team.LowerName}
+		} else if err != gorm.ErrRecordNotFound {
+			return errors.Wrap(err, "check name collision")
+		}
+
+		err = tx.Create(team).Error
+		if err != nil {
+			return errors.Wrap(err, "create team")
+		}
+
+		err = createAuditLog(ctx, tx, &AuditLog{OrgID: orgID, Action: AuditActionCreateTeam, TeamID: team.ID})
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&User{}).
+			Where("id = ?", orgID).
+			Update("num_teams", gorm.Expr("num_teams + 1")).
+			Error
+		if err != nil {
+			return errors.Wrap(err, `update "user.num_teams"`)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+func (db *teams) IsTeamMember(ctx context.Context, teamID, userID int64) bool {
+	err := db.WithContext(ctx).Where("team_id = ? AND uid = ?", teamID, userID).First(&TeamUser{}).Error
+	return err == nil
+}
+
+func (db *teams) ListMembers(ctx context.Context, teamID int64) ([]*User, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT "user".* FROM "user"
+		JOIN team_user ON team_user.uid = "user".id
+		WHERE team_user.team_id = @teamID
+		ORDER BY "user".id
+	*/
+	var users []*User
+	return users, db.WithContext(ctx).
+		Joins(dbutil.Quote("JOIN team_user ON team_user.uid = %s.id", "user")).
+		Where("team_user.team_id = ?", teamID).
+		Order(dbutil.Quote("%s.id", "user")).
+		Find(&users).
+		Error
+}
+
+func (db *teams) RepoAccessMode(ctx context.Context, teamID, repoID int64) (AccessMode, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT team.id, team.authorize, team_repo.id AS team_repo_id FROM team
+		LEFT JOIN team_repo ON team_repo.team_id = team.id AND team_repo.repo_id = @repoID
+		WHERE team.id = @teamID
+	*/
+	var row struct {
+		ID         int64
+		Authorize  AccessMode
+		TeamRepoID int64
+	}
+	err := db.WithContext(ctx).
+		Model(&Team{}).
+		Select("team.id, team.authorize, team_repo.id AS team_repo_id").
+		Joins("LEFT JOIN team_repo ON team_repo.team_id = team.id AND team_repo.repo_id = ?", repoID).
+		Where("team.id = ?", teamID).
+		Scan(&row).
+		Error
+	if err != nil {
+		return AccessModeNone, errors.Wrap(err, "get team")
+	} else if row.ID == 0 {
+		return AccessModeNone, ErrTeamNotExist{args: errutil.Args{"teamID": teamID}}
+	} else if row.TeamRepoID == 0 {
+		return AccessModeNone, nil
+	}
+	return row.Authorize, nil
+}
+
+func (db *teams) ListRepos(ctx context.Context, teamID int64, page, pageSize int) ([]*Repository, int64, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT repository.* FROM repository
+		JOIN team_repo ON team_repo.repo_id = repository.id
+		WHERE team_repo.team_id = @teamID AND repository.deleted_unix = 0
+		ORDER BY repository.updated_unix DESC
+		LIMIT @limit OFFSET @offset
+	*/
+	conn := db.WithContext(ctx)
+	query := func() *gorm.DB {
+		return conn.Model(&Repository{}).
+			Joins("JOIN team_repo ON team_repo.repo_id = repository.id").
+			Where("team_repo.team_id = ? AND repository.deleted_unix = 0", teamID)
+	}
+
+	var count int64
+	err := query().Count(&count).Error
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "count")
+	}
+
+	if page <= 0 {
+		page = 1
+	}
+	var repos []*Repository
+	err = query().
+		Order("repository.updated_unix DESC").
+		Limit(pageSize).Offset((page - 1) * pageSize).
+		Find(&repos).
+		Error
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "list")
+	}
+	return repos, count, nil
+}
+
+// TeamWithCounts is a team of an organization along with its numbers of
+// members and repositories.
+type TeamWithCounts struct {
+	Team *Team
+	// The number of members of the team.
+	NumMembers int
+	// The number of repositories the team has access to, computed from
+	// "team_repo".
+	NumRepos int
+}
+
+func (db *teams) ListByOrg(ctx context.Context, orgID int64) ([]*TeamWithCounts, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT
+			team.*,
+			(SELECT COUNT(*) FROM team_repo WHERE team_repo.team_id = team.id) AS team_num_repos
+		FROM team
+		WHERE org_id = @orgID
+		ORDER BY CASE WHEN lower_name = @ownersTeam THEN 0 ELSE 1 END, id ASC
+	*/
+	var rows []*struct {
+		Team         `gorm:"embedded"`
+		TeamNumRepos int
+	}
+	err := db.WithContext(ctx).
+		Model(&Team{}).
+		Select("team.*, (SELECT COUNT(*) FROM team_repo WHERE team_repo.team_id = team.id) AS team_num_repos").
+		Where("org_id = ?", orgID).
+		Order(gorm.Expr("CASE WHEN lower_name = ? THEN 0 ELSE 1 END, id ASC", strings.ToLower(OWNER_TEAM))).
+		Find(&rows).
+		Error
+	if err != nil {
+		return nil, errors.Wrap(err, "list teams")
+	}
+
+	teams := make([]*TeamWithCounts, 0, len(rows))
+	for _, row := range rows {
+		t := row.Team
+		teams = append(teams, &TeamWithCounts{
+			Team:       &t,
+			NumMembers: t.NumMembers,
+			NumRepos:   row.TeamNumRepos,
+		})
+	}
+	return teams, nil
+}
+
+// recountMembers recounts the number of members of the given team.
+func (*teams) recountMembers(tx *gorm.DB, teamID int64) error {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		UPDATE team
+		SET num_members = (
+			SELECT COUNT(*) FROM team_user WHERE team_id = @teamID
+		)
+		WHERE id = @teamID
+	*/
+	err := tx.Model(&Team{}).
+		Where("id = ?", teamID).
+		Update(
+			"num_members",
+			tx.Model(&TeamUser{}).Select("COUNT(*)").Where("team_id = ?", teamID),
+		).
+		Error
+	if err != nil {
+		return errors.Wrap(err, `update "team.num_members"`)
+	}
+	return nil
+}
+
+// recountOrgUserTeams recounts the number of teams the user is a member of in
+// the given organization, and updates whether the user is an owner of the
+// organization.
+func (*teams) recountOrgUserTeams(tx *gorm.DB, orgID, userID int64) error {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT COUNT(*) FROM team_user WHERE org_id = @orgID AND uid = @userID
+	*/
+	var numTeams int64
+	err := tx.Model(&TeamUser{}).Where("org_id = ? AND uid = ?", orgID, userID).Count(&numTeams).Error
+	if err != nil {
+		return errors.Wrap(err, "count teams")
+	}
+
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT COUNT(*) FROM team_user
+		JOIN team ON team.id = team_user.team_id
+		WHERE team_user.org_id = @orgID AND team_user.uid = @userID AND team.lower_name = 'owners'
+	*/
+	var numOwnersTeams int64
+	err = tx.Model(&TeamUser{}).
+		Joins("JOIN team ON team.id = team_user.team_id").
+		Where("team_user.org_id = ? AND team_user.uid = ? AND team.lower_name = ?", orgID, userID, strings.ToLower(OWNER_TEAM)).
+		Count(&numOwnersTeams).
+		Error
+	if err != nil {
+		return errors.Wrap(err, "count owners teams")
+	}
+
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		UPDATE org_user
+		SET num_teams = @numTeams, is_owner = @numOwnersTeams > 0
+		WHERE org_id = @orgID AND uid = @userID
+	*/
+	err = tx.Model(&OrgUser{}).
+		Where("org_id = ? AND uid = ?", orgID, userID).
+		Updates(map[string]any{
+			"num_teams": numTeams,
+			"is_owner":  numOwnersTeams > 0,
+		}).
+		Error
+	if err != nil {
+		return errors.Wrap(err, `update "org_user"`)
+	}
+	return nil
+}
+
+// recalculateTeamAccesses recalculates accesses of all repositories of the
+// team, see computeAccesses for how access modes are computed. Unlike
+// recalculateAccesses, accesses of all repositories are computed in one pass.
+func (*teams) recalculateTeamAccesses(tx *gorm.DB, teamID int64) error {
+	team := new(Team)
+	err := tx.Select("id", "org_id", "name").Where("id = ?", teamID).First(team).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrTeamNotExist{args: errutil.Args{"teamID": teamID}}
+		}
+		return errors.Wrap(err, "get team")
+	}
+
+	// Members of the Owners team have access to all repositories of the
+	// organization.
+	newDB := tx.Session(&gorm.Session{NewDB: true})
+	repoIDs := newDB.Model(&TeamRepo{}).Select("repo_id").Where("team_id = ?", teamID)
+	if team.IsOwnerTeam() {
+		repoIDs = newDB.Model(&Repository{}).Select("id").Where("owner_id = ?", team.OrgID)
+	}
+
+	err = tx.Where("repo_id IN (?)", repoIDs).Delete(new(Access)).Error
+	if err != nil {
+		return errors.Wrap(err, "delete old accesses")
+	}
+
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		INSERT INTO access (user_id, repo_id, mode)
+		SELECT user_id, repo_id, MAX(mode) FROM (
+			SELECT user_id, repo_id, mode FROM collaboration
+			WHERE repo_id IN (@repoIDs) AND (expires_unix = 0 OR expires_unix > @now)
+
+			UNION ALL
+
+			SELECT org_user.uid, repository.id, "user".default_repo_permission
+			FROM repository
+			JOIN "user" ON "user".id = repository.owner_id
+			JOIN org_user ON org_user.org_id = repository.owner_id
+			WHERE
+				repository.id IN (@repoIDs)
+			AND repository.is_private = FALSE
+			AND "user".type = @orgType
+			AND "user".default_repo_permission > @noneMode
+
+			UNION ALL
+
+			SELECT
+				team_user.uid,
+				repository.id,
+				CASE WHEN team.lower_name = @ownersTeam THEN @ownerMode ELSE team.authorize END
+			FROM repository
+			JOIN team ON team.org_id = repository.owner_id
+			JOIN team_user ON team_user.team_id = team.id
+			WHERE
+				repository.id IN (@repoIDs)
+			AND (
+					team.lower_name = @ownersTeam
+				OR  (
+						team.authorize > @noneMode
+					AND team.id IN (SELECT team_id FROM team_repo WHERE team_repo.repo_id = repository.id)
+				)
+			)
+		) AS accesses
+		GROUP BY user_id, repo_id
+	*/
+	ownersTeam := strings.ToLower(OWNER_TEAM)
+	err = tx.Exec(dbutil.Quote(`
+INSERT INTO access (user_id, repo_id, mode)
+SELECT user_id, repo_id, MAX(mode) FROM (
+	SELECT user_id, repo_id, mode FROM collaboration
+	WHERE repo_id IN (?) AND (expires_unix = 0 OR expires_unix > ?)
+
+	UNION ALL
+
+	SELECT org_user.uid AS user_id, repository.id AS repo_id, %[1]s.default_repo_permission AS mode
+	FROM repository
+	JOIN %[1]s ON %[1]s.id = repository.owner_id
+	JOIN org_user ON org_user.org_id = repository.owner_id
+	WHERE
+		repository.id IN (?)
+	AND repository.is_private = ?
+	AND %[1]s.type = ?
+	AND %[1]s.default_repo_permission > ?
+
+	UNION ALL
+
+	SELECT
+		team_user.uid AS user_id,
+		repository.id AS repo_id,
+		CASE WHEN team.lower_name = ? THEN ? ELSE team.authorize END AS mode
+	FROM repository
+	JOIN team ON team.org_id = repository.owner_id
+	JOIN team_user ON team_user.team_id = team.id
+	WHERE
+		repository.id IN (?)
+	AND (
+			team.lower_name = ?
+		OR  (
+				team.authorize > ?
+			AND team.id IN (SELECT team_id FROM team_repo WHERE team_repo.repo_id = repository.id)
+		)
+	)
+) AS accesses
+GROUP BY user_id, repo_id`, "user"),
+		repoIDs, tx.NowFunc().Unix(),
+		repoIDs, false, UserTypeOrganization, AccessModeNone,
+		ownersTeam, AccessModeOwner,
+		repoIDs, ownersTeam, AccessModeNone,
+	).Error
+	if err != nil {
+		return errors.Wrap(err, "insert new accesses")
+	}
+	return nil
+}
+
+func (db *teams) RecomputeAccess(ctx context.Context, teamID int64) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return db.recalculateTeamAccesses(tx, teamID)
+	})
+}
+
+func (db *teams) AddTeamMember(ctx context.Context, teamID, userID int64) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		team := new(Team)
+		err := tx.Where("id = ?", teamID).First(team).Error
+		if err != nil {
+			return errors.Wrap(err, "get team")
+		}
+
+		tu := &TeamUser{
+			OrgID:  team.OrgID,
+			TeamID: teamID,
+			UID:    userID,
+		}
+		result := tx.FirstOrCreate(tu, tu)
+		if result.Error != nil {
+			return errors.Wrap(result.Error, "upsert team member")
+		} else if result.RowsAffected <= 0 {
+			return nil // Relation already exists
+		}
+
+		result = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&OrgUser{
+			Uid:   userID,
+			OrgID: team.OrgID,
+		})
+		if result.Error != nil {
+			return errors.Wrap(result.Error, "upsert organization member")
+		} else if result.RowsAffected > 0 {
+			err = createAuditLog(ctx, tx, &AuditLog{OrgID: team.OrgID, Action: AuditActionAddMember, TargetUserID: userID})
+			if err != nil {
+				return err
+			}
+
+			err = (&orgs{DB: tx}).recountMembers(tx, team.OrgID)
+			if err != nil {
+				return errors.Wrap(err, "recount organization members")
+			}
+		}
+
+		err = createAuditLog(ctx, tx, &AuditLog{OrgID: team.OrgID, Action: AuditActionAddTeamMember, TargetUserID: userID, TeamID: teamID})
+		if err != nil {
+			return err
+		}
+
+		err = db.recountMembers(tx, teamID)
+		if err != nil {
+			return errors.Wrap(err, "recount team members")
+		}
+
+		err = db.recountOrgUserTeams(tx, team.OrgID, userID)
+		if err != nil {
+			return errors.Wrap(err, "recount organization member teams")
+		}
+
+		return db.recalculateTeamAccesses(tx, teamID)
+	})
+}
+
+func (db *teams) RemoveTeamMember(ctx context.Context, teamID, userID int64) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		team := new(Team)
+		err := tx.Where("id = ?", teamID).First(team).Error
+		if err != nil {
+			return errors.Wrap(err, "get team")
+		}
+
+		tu := new(TeamUser)
+		err = tx.Where("team_id = ? AND uid = ?", teamID, userID).First(tu).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil
+			}
+			return errors.Wrap(err, "get team member")
+		}
+
+		if team.IsOwnerTeam() {
+			var count int64
+			err = tx.Model(&TeamUser{}).Where("team_id = ?", teamID).Count(&count).Error
+			if err != nil {
+				return errors.Wrap(err, "count team members")
+			} else if count <= 1 {
+				return ErrLastOrgOwner{UID: userID}
+			}
+		}
+
+		err = tx.Delete(&TeamUser{}, tu.ID).Error
+		if err != nil {
+			return errors.Wrap(err, "delete team member")
+		}
+
+		err = createAuditLog(ctx, tx, &AuditLog{OrgID: team.OrgID, Action: AuditActionRemoveTeamMember, TargetUserID: userID, TeamID: teamID})
+		if err != nil {
+			return err
+		}
+
+		err = db.recountMembers(tx, teamID)
+		if err != nil {
+			return errors.Wrap(err, "recount team members")
+		}
+
+		err = db.recountOrgUserTeams(tx, team.OrgID, userID)
+		if err != nil {
+			return errors.Wrap(err, "recount organization member teams")
+		}
+
+		return db.recalculateTeamAccesses(tx, teamID)
+	})
+}
+
+// maxTeamDescriptionLength is the maximum length of a team description.
+const maxTeamDescriptionLength = 255
+
+type UpdateTeamOptions struct {
+	Name        *string
+	Description *string
+	// The new access level of the team, nil means no change. The access level of
+	// the Owners team cannot be changed.
+	Authorize *AccessMode
+	// The new custom avatar image of the team, nil means no change.
+	Avatar []byte
+	// Whether to delete the custom avatar of the team, it takes precedence over
+	// Avatar.
+	DeleteAvatar bool
+}
+
+type ErrOwnersTeamRename struct {
+	args errutil.Args
+}
+
+// IsErrOwnersTeamRename returns true if the underlying error has the type
+// ErrOwnersTeamRename.
+func IsErrOwnersTeamRename(err error) bool {
+	_, ok := errors.Cause(err).(ErrOwnersTeamRename)
+	return ok
+}
+
+func (err ErrOwnersTeamRename) Error() string {
+	return fmt.Sprintf("the Owners team cannot be renamed: %v", err.args)
+}
+
+type ErrTeamDescriptionTooLong struct {
+	args errutil.Args
+}
+
+// IsErrTeamDescriptionTooLong returns true if the underlying error has the type
+// ErrTeamDescriptionTooLong.
+func IsErrTeamDescriptionTooLong(err error) bool {
+	_, ok := errors.Cause(err).(ErrTeamDescriptionTooLong)
+	return ok
+}
+
+func (err ErrTeamDescriptionTooLong) Error() string {
+	return fmt.Sprintf("team description is too long: %v", err.args)
+}
+
+func (db *teams) Update(ctx context.Context, teamID int64, opts UpdateTeamOptions) error {
+	if opts.Description != nil && utf8.RuneCountInString(*opts.Description) > maxTeamDescriptionLength {
+		return ErrTeamDescriptionTooLong{args: errutil.Args{"teamID": teamID, "max": maxTeamDescriptionLength}}
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		team := new(Team)
+		err := tx.Where("id = ?", teamID).First(team).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrTeamNotExist{args: errutil.Args{"teamID": teamID}}
+			}
+			return errors.Wrap(err, "get team")
+		}
+
+		updates := make(map[string]any)
+		if opts.Name != nil && *opts.Name != team.Name {
+			if team.IsOwnerTeam() {
+				return ErrOwnersTeamRename{args: errutil.Args{"teamID": teamID}}
+			} else if *opts.Name == "" {
+				return errors.New("empty team name")
+			}
+
+			lowerName := strings.ToLower(*opts.Name)
+			existing := new(Team)
+			err = tx.Where("org_id = ? AND lower_name = ? AND id != ?", team.OrgID, lowerName, teamID).First(existing).Error
+			if err == nil {
+				return ErrTeamAlreadyExist{ID: existing.ID, OrgID: team.OrgID, Name: lowerName}
+			} else if err != gorm.ErrRecordNotFound {
+				return errors.Wrap(err, "check name collision")
+			}
+
+			updates["name"] = *opts.Name
+			updates["lower_name"] = lowerName
+		}
+		if opts.Description != nil {
+			updates["description"] = *opts.Description
+		}
+		authChanged := opts.Authorize != nil && *opts.Authorize != team.Authorize
+		if authChanged {
+			if team.IsOwnerTeam() {
+				return errors.New("the access level of the Owners team cannot be changed")
+			}
+			updates["authorize"] = *opts.Authorize
+		}
+
+		switch {
+		case opts.DeleteAvatar:
+			_ = os.Remove(userutil.CustomTeamAvatarPath(teamID))
+			updates["use_custom_avatar"] = false
+		case opts.Avatar != nil:
+			err = userutil.SaveTeamAvatar(teamID, opts.Avatar)
+			if err != nil {
+				return errors.Wrap(err, "save avatar")
+			}
+			updates["use_custom_avatar"] = true
+		}
+
+		if len(updates) == 0 {
+			return nil
+		}
+		err = tx.Model(&Team{}).Where("id = ?", teamID).Updates(updates).Error
+		if err != nil {
+			return errors.Wrap(err, "update")
+		}
+
+		if authChanged {
+			err = db.recalculateTeamAccesses(tx, teamID)
+			if err != nil {
+				return errors.Wrap(err, "recalculate team accesses")
+			}
+		}
+		return createAuditLog(ctx, tx, &AuditLog{OrgID: team.OrgID, Action: AuditActionUpdateTeam, TeamID: teamID})
+	})
+}
+
+type ErrTeamOrgMismatch struct {
+	args errutil.Args
+}
+
+// IsErrTeamOrgMismatch returns true if the underlying error has the type
+// ErrTeamOrgMismatch.
+func IsErrTeamOrgMismatch(err error) bool {
+	_, ok := errors.Cause(err).(ErrTeamOrgMismatch)
+	return ok
+}
+
+func (err ErrTeamOrgMismatch) Error() string {
+	return fmt.Sprintf("teams belong to different organizations: %v", err.args)
+}
+
+var _ errutil.NotFound = (*ErrTeamRepoNotExist)(nil)
+
+type ErrTeamRepoNotExist struct {
+	args errutil.Args
+}
+
+// IsErrTeamRepoNotExist returns true if the underlying error has the type
+// ErrTeamRepoNotExist.
+func IsErrTeamRepoNotExist(err error) bool {
+	_, ok := errors.Cause(err).(ErrTeamRepoNotExist)
+	return ok
+}
+
+func (err ErrTeamRepoNotExist) Error() string {
+	return fmt.Sprintf("repository is not associated with the team: %v", err.args)
+}
+
+func (ErrTeamRepoNotExist) NotFound() bool {
+	return true
+}
+
+func (db *teams) MoveRepos(ctx context.Context, fromTeamID, toTeamID int64, repoIDs []int64) error {
+	seen := make(map[int64]bool, len(repoIDs))
+	moves := make([]int64, 0, len(repoIDs))
+	for _, id := range repoIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		moves = append(moves, id)
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var teams []*Team
+		err := tx.Where("id IN (?)", []int64{fromTeamID, toTeamID}).Find(&teams).Error
+		if err != nil {
+			return errors.Wrap(err, "get teams")
+		}
+		var fromTeam, toTeam *Team
+		for _, t := range teams {
+			if t.ID == fromTeamID {
+				fromTeam = t
+			}
+			if t.ID == toTeamID {
+				toTeam = t
+			}
+		}
+		if fromTeam == nil {
+			return ErrTeamNotExist{args: errutil.Args{"teamID": fromTeamID}}
+		} else if toTeam == nil {
+			return ErrTeamNotExist{args: errutil.Args{"teamID": toTeamID}}
+		} else if fromTeam.OrgID != toTeam.OrgID {
+			return ErrTeamOrgMismatch{args: errutil.Args{"fromTeamID": fromTeamID, "toTeamID": toTeamID}}
+		}
+
+		if len(moves) == 0 {
+			return nil
+		}
+
+		var associated []int64
+		err = tx.Model(&TeamRepo{}).
+			Where("team_id = ? AND repo_id IN (?)", fromTeamID, moves).
+			Pluck("repo_id", &associated).
+			Error
+		if err != nil {
+			return errors.Wrap(err, "list repositories of the source team")
+		}
+		isAssociated := make(map[int64]bool, len(associated))
+		for _, id := range associated {
+			isAssociated[id] = true
+		}
+		for _, id := range moves {
+			if !isAssociated[id] {
+				return ErrTeamRepoNotExist{args: errutil.Args{"teamID": fromTeamID, "repoID": id}}
+			}
+		}
+
+		if fromTeamID == toTeamID {
+			return nil
+		}
+
+		var existing []int64
+		err = tx.Model(&TeamRepo{}).
+			Where("team_id = ? AND repo_id IN (?)", toTeamID, moves).
+			Pluck("repo_id", &existing).
+			Error
+		if err != nil {
+			return errors.Wrap(err, "list repositories of the destination team")
+		}
+
+		// Repositories that are already associated with the destination team only need
+		// to be detached from the source team.
+		if len(existing) > 0 {
+			err = tx.Where("team_id = ? AND repo_id IN (?)", fromTeamID, existing).Delete(&TeamRepo{}).Error
+			if err != nil {
+				return errors.Wrap(err, "delete duplicated team repositories")
+			}
+		}
+
+		/*
+			Equivalent SQL for PostgreSQL:
+
+			UPDATE team_repo
+			SET team_id = @toTeamID
+			WHERE team_id = @fromTeamID AND repo_id IN (@repoIDs)
+		*/
+		err = tx.Model(&TeamRepo{}).
+			Where("team_id = ? AND repo_id IN (?)", fromTeamID, moves).
+			Update("team_id", toTeamID).
+			Error
+		if err != nil {
+			return errors.Wrap(err, "move team repositories")
+		}
+
+		err = (&orgs{DB: tx}).recountTeamRepos(tx, fromTeam.OrgID)
+		if err != nil {
+			return errors.Wrap(err, "recount team repositories")
+		}
+
+		for _, repoID := range moves {
+			err = recalculateAccesses(tx, repoID)
+			if err != nil {
+				return errors.Wrapf(err, "recalculate accesses for repository %d", repoID)
+			}
+
+			err = createAuditLog(ctx, tx, &AuditLog{OrgID: fromTeam.OrgID, Action: AuditActionRemoveTeamRepo, TeamID: fromTeamID, RepoID: repoID})
+			if err != nil {
+				return err
+			}
+			err = createAuditLog(ctx, tx, &AuditLog{OrgID: toTeam.OrgID, Action: AuditActionAddTeamRepo, TeamID: toTeamID, RepoID: repoID})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (db *teams) DeleteByID(ctx context.Context, teamID int64) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		team := new(Team)
+		err := tx.Where("id = ?", teamID).First(team).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrTeamNotExist{args: errutil.Args{"teamID": teamID}}
+			}
+			return errors.Wrap(err, "get team")
+		} else if team.IsOwnerTeam() {
+			return errors.New("the Owners team cannot be deleted")
+		}
+
+		var memberIDs []int64
+		err = tx.Model(&TeamUser{}).Where("team_id = ?", teamID).Pluck("uid", &memberIDs).Error
+		if err != nil {
+			return errors.Wrap(err, "list members")
+		}
+		var repoIDs []int64
+		err = tx.Model(&TeamRepo{}).Where("team_id = ?", teamID).Pluck("repo_id", &repoIDs).Error
+		if err != nil {
+			return errors.Wrap(err, "list repositories")
+		}
+
+		for _, table := range []any{&TeamUser{}, &TeamRepo{}} {
+			err = tx.Where("team_id = ?", teamID).Delete(table).Error
+			if err != nil {
+				return errors.Wrapf(err, "delete from %T", table)
+			}
+		}
+		err = tx.Model(&OrgInvitation{}).Where("team_id = ?", teamID).Update("team_id", 0).Error
+		if err != nil {
+			return errors.Wrap(err, "detach invitations")
+		}
+		err = tx.Delete(&Team{}, teamID).Error
+		if err != nil {
+			return errors.Wrap(err, "delete team")
+		}
+
+		err = tx.Model(&User{}).
+			Where("id = ?", team.OrgID).
+			Update("num_teams", gorm.Expr("num_teams - 1")).
+			Error
+		if err != nil {
+			return errors.Wrap(err, `update "user.num_teams"`)
+		}
+
+		for _, memberID := range memberIDs {
+			err = db.recountOrgUserTeams(tx, team.OrgID, memberID)
+			if err != nil {
+				return errors.Wrapf(err, "recount organization member teams for user %d", memberID)
+			}
+		}
+		for _, repoID := range repoIDs {
+			err = recalculateAccesses(tx, repoID)
+			if err != nil {
+				return errors.Wrapf(err, "recalculate accesses for repository %d", repoID)
+			}
+		}
+		return createAuditLog(ctx, tx, &AuditLog{OrgID: team.OrgID, Action: AuditActionDeleteTeam, TeamID: teamID})
+	})
+}
@@ -0,0 +1,810 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"gogs.io/gogs/internal/conf"
+	"gogs.io/gogs/internal/dbtest"
+	"gogs.io/gogs/internal/dbutil"
+	"gogs.io/gogs/internal/errutil"
+	"gogs.io/gogs/internal/osutil"
+	"gogs.io/gogs/internal/userutil"
+	"gogs.io/gogs/public"
+)
+
+func TestTeams(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	tables := []any{
+		new(User), new(EmailAddress), new(OrgUser), new(Team), new(TeamUser), new(TeamRepo),
+		new(Repository), new(Watch), new(Access), new(Collaboration), new(AuditLog),
+		new(OrgInvitation),
+	}
+	db := &teams{
+		DB: dbtest.NewDB(t, "teams", tables...),
+	}
+
+	for _, tc := range []struct {
+		name string
+		test func(t *testing.T, db *teams)
+	}{
+		{"Create", teamsCreate},
+		{"ListByOrg", teamsListByOrg},
+		{"RepoAccessMode", teamsRepoAccessMode},
+		{"ListRepos", teamsListRepos},
+		{"ListMembers", teamsListMembers},
+		{"AddTeamMember", teamsAddTeamMember},
+		{"RemoveTeamMember", teamsRemoveTeamMember},
+		{"Update", teamsUpdate},
+		{"MoveRepos", teamsMoveRepos},
+		{"RecomputeAccess", teamsRecomputeAccess},
+		{"DeleteByID", teamsDeleteByID},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Cleanup(func() {
+				err := clearTables(t, db.DB, tables...)
+				require.NoError(t, err)
+			})
+			tc.test(t, db)
+		})
+		if t.Failed() {
+			break
+		}
+	}
+}
+
+// createTeamsTestOrg creates an organization with the given name along with its
+// Owners team, without touching the file system.
+func createTeamsTestOrg(t *testing.T, db *teams, name string) (*User, *Team) {
+	org := createTestOrg(t, db.DB, name, CreateUserOptions{})
+	ownersTeam := createTestTeam(t, db.DB, &Team{
+		OrgID:     org.ID,
+		LowerName: "owners",
+		Name:      OWNER_TEAM,
+		Authorize: AccessModeOwner,
+	}, nil, nil)
+	return org, ownersTeam
+}
+
+func teamsCreate(t *testing.T, db *teams) {
+	ctx := context.Background()
+
+	org1, _ := createTeamsTestOrg(t, db, "org1")
+	org2, _ := createTeamsTestOrg(t, db, "org2")
+
+	t.Run("name not allowed", func(t *testing.T) {
+		_, err := db.Create(ctx, org1.ID, "new", CreateTeamOptions{})
+		wantErr := ErrNameNotAllowed{args: errutil.Args{"reason": "reserved", "name": "new"}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	t.Run("organization does not exist", func(t *testing.T) {
+		_, err := db.Create(ctx, 404, "DevOps", CreateTeamOptions{})
+		wantErr := ErrOrgNotExist{args: errutil.Args{"orgID": int64(404)}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	team, err := db.Create(ctx, org1.ID, "DevOps", CreateTeamOptions{Description: "Ops", Authorize: AccessModeWrite})
+	require.NoError(t, err)
+	assert.Equal(t, "devops", team.LowerName)
+	assert.Equal(t, "DevOps", team.Name)
+	assert.Equal(t, "Ops", team.Description)
+	assert.Equal(t, AccessModeWrite, team.Authorize)
+
+	t.Run("case-variant name already exists", func(t *testing.T) {
+		_, err := db.Create(ctx, org1.ID, "devops", CreateTeamOptions{})
+		wantErr := ErrTeamAlreadyExist{ID: team.ID, OrgID: org1.ID, Name: "devops"}
+		assert.Equal(t, wantErr, err)
+	})
+
+	// Same name in a different organization is fine
+	_, err = db.Create(ctx, org2.ID, "devops", CreateTeamOptions{})
+	require.NoError(t, err)
+
+	org1, err = NewUsersStore(db.DB).GetByID(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, org1.NumTeams)
+
+	// The unique index rejects case-variant names bypassing the check
+	err = db.DB.Create(&Team{OrgID: org1.ID, LowerName: "devops", Name: "DEVOPS"}).Error
+	assert.Error(t, err)
+}
+
+func teamsListByOrg(t *testing.T, db *teams) {
+	ctx := context.Background()
+
+	org1, ownersTeam := createTeamsTestOrg(t, db, "org1")
+	org2, _ := createTeamsTestOrg(t, db, "org2")
+
+	// Make the Owners team sort after other teams by ID
+	err := db.Exec(`UPDATE team SET id = ? WHERE id = ?`, 100, ownersTeam.ID).Error
+	require.NoError(t, err)
+	ownersTeam.ID = 100
+
+	team1 := createTestTeam(t, db.DB, &Team{OrgID: org1.ID, Name: "team1", Authorize: AccessModeWrite, NumMembers: 2}, nil, []int64{1})
+	team2 := createTestTeam(t, db.DB, &Team{OrgID: org1.ID, Name: "team2", Authorize: AccessModeRead}, nil, nil)
+	createTestTeam(t, db.DB, &Team{OrgID: org2.ID, Name: "team3"}, nil, nil)
+
+	for _, repoID := range []int64{1, 2, 3} {
+		err = db.DB.Create(&TeamRepo{OrgID: org1.ID, TeamID: ownersTeam.ID, RepoID: repoID}).Error
+		require.NoError(t, err)
+	}
+
+	got, err := db.ListByOrg(ctx, org1.ID)
+	require.NoError(t, err)
+
+	type teamCounts struct {
+		ID         int64
+		NumMembers int
+		NumRepos   int
+	}
+	var gotCounts []teamCounts
+	for _, team := range got {
+		gotCounts = append(gotCounts, teamCounts{ID: team.Team.ID, NumMembers: team.NumMembers, NumRepos: team.NumRepos})
+	}
+	want := []teamCounts{
+		{ID: ownersTeam.ID, NumMembers: 0, NumRepos: 3},
+		{ID: team1.ID, NumMembers: 2, NumRepos: 1},
+		{ID: team2.ID, NumMembers: 0, NumRepos: 0},
+	}
+	assert.Equal(t, want, gotCounts)
+
+	got, err = db.ListByOrg(ctx, 404)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func teamsRepoAccessMode(t *testing.T, db *teams) {
+	ctx := context.Background()
+
+	t.Run("team does not exist", func(t *testing.T) {
+		_, err := db.RepoAccessMode(ctx, 404, 1)
+		wantErr := ErrTeamNotExist{args: errutil.Args{"teamID": int64(404)}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	org1, _ := createTeamsTestOrg(t, db, "org1")
+	team1, err := db.Create(ctx, org1.ID, "team1", CreateTeamOptions{Authorize: AccessModeWrite})
+	require.NoError(t, err)
+	err = db.DB.Create(&TeamRepo{OrgID: org1.ID, TeamID: team1.ID, RepoID: 1}).Error
+	require.NoError(t, err)
+
+	mode, err := db.RepoAccessMode(ctx, team1.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, AccessModeWrite, mode)
+
+	mode, err = db.RepoAccessMode(ctx, team1.ID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, AccessModeNone, mode)
+}
+
+func teamsListRepos(t *testing.T, db *teams) {
+	ctx := context.Background()
+
+	org1, _ := createTeamsTestOrg(t, db, "org1")
+	team1, err := db.Create(ctx, org1.ID, "team1", CreateTeamOptions{Authorize: AccessModeRead})
+	require.NoError(t, err)
+	team2, err := db.Create(ctx, org1.ID, "team2", CreateTeamOptions{Authorize: AccessModeRead})
+	require.NoError(t, err)
+
+	reposStore := NewReposStore(db.DB)
+	var repos []*Repository
+	for i, name := range []string{"repo1", "repo2", "repo3", "repo4"} {
+		repo, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: name})
+		require.NoError(t, err)
+		err = db.Model(&Repository{}).Where("id = ?", repo.ID).Update("updated_unix", i+1).Error
+		require.NoError(t, err)
+		repos = append(repos, repo)
+	}
+	for _, repo := range repos[:3] {
+		err = db.DB.Create(&TeamRepo{OrgID: org1.ID, TeamID: team1.ID, RepoID: repo.ID}).Error
+		require.NoError(t, err)
+	}
+	err = db.DB.Create(&TeamRepo{OrgID: org1.ID, TeamID: team2.ID, RepoID: repos[3].ID}).Error
+	require.NoError(t, err)
+
+	// Soft-deleted repositories should not be included
+	err = reposStore.SoftDelete(ctx, repos[0].ID)
+	require.NoError(t, err)
+
+	names := func(repos []*Repository) []string {
+		got := make([]string, 0, len(repos))
+		for _, repo := range repos {
+			got = append(got, repo.Name)
+		}
+		return got
+	}
+
+	got, count, err := db.ListRepos(ctx, team1.ID, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.Equal(t, []string{"repo3", "repo2"}, names(got))
+
+	got, count, err = db.ListRepos(ctx, team1.ID, 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.Equal(t, []string{"repo2"}, names(got))
+
+	got, count, err = db.ListRepos(ctx, 404, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+	assert.Empty(t, got)
+}
+
+func teamsListMembers(t *testing.T, db *teams) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	cindy, err := usersStore.Create(ctx, "cindy", "cindy@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1, ownersTeam := createTeamsTestOrg(t, db, "org1")
+	team1, err := db.Create(ctx, org1.ID, "team1", CreateTeamOptions{Authorize: AccessModeRead})
+	require.NoError(t, err)
+
+	for _, userID := range []int64{cindy.ID, alice.ID} {
+		err = db.AddTeamMember(ctx, team1.ID, userID)
+		require.NoError(t, err)
+	}
+	err = db.AddTeamMember(ctx, ownersTeam.ID, bob.ID)
+	require.NoError(t, err)
+
+	got, err := db.ListMembers(ctx, team1.ID)
+	require.NoError(t, err)
+	gotNames := make([]string, 0, len(got))
+	for _, u := range got {
+		gotNames = append(gotNames, u.Name)
+	}
+	assert.Equal(t, []string{"alice", "cindy"}, gotNames)
+
+	got, err = db.ListMembers(ctx, 404)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func teamsAddTeamMember(t *testing.T, db *teams) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1, ownersTeam := createTeamsTestOrg(t, db, "org1")
+	team1 := createTestTeam(t, db.DB, &Team{
+		OrgID:     org1.ID,
+		LowerName: "team1",
+		Name:      "team1",
+		Authorize: AccessModeWrite,
+	}, nil, nil)
+
+	reposStore := NewReposStore(db.DB)
+	repo1, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo1", Private: true})
+	require.NoError(t, err)
+	err = db.Exec(`INSERT INTO team_repo (org_id, team_id, repo_id) VALUES (?, ?, ?)`, org1.ID, team1.ID, repo1.ID).Error
+	require.NoError(t, err)
+
+	assert.False(t, db.IsTeamMember(ctx, team1.ID, alice.ID))
+
+	err = db.AddTeamMember(ctx, team1.ID, alice.ID)
+	require.NoError(t, err)
+	assert.True(t, db.IsTeamMember(ctx, team1.ID, alice.ID))
+
+	// Adding the same member again should be a no-op
+	err = db.AddTeamMember(ctx, team1.ID, alice.ID)
+	require.NoError(t, err)
+
+	err = db.First(team1, team1.ID).Error
+	require.NoError(t, err)
+	assert.Equal(t, 1, team1.NumMembers)
+
+	// The user should become a member of the organization automatically
+	org1, err = usersStore.GetByID(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, org1.NumMembers)
+
+	orgUser := new(OrgUser)
+	err = db.Where("org_id = ? AND uid = ?", org1.ID, alice.ID).First(orgUser).Error
+	require.NoError(t, err)
+	assert.Equal(t, 1, orgUser.NumTeams)
+	assert.False(t, orgUser.IsOwner)
+
+	// The user should get access to team repositories
+	mode := NewPermsStore(db.DB).AccessMode(ctx, alice.ID, repo1.ID, AccessModeOptions{OwnerID: org1.ID, Private: true})
+	assert.Equal(t, AccessModeWrite, mode)
+
+	err = db.AddTeamMember(ctx, ownersTeam.ID, alice.ID)
+	require.NoError(t, err)
+
+	err = db.Where("org_id = ? AND uid = ?", org1.ID, alice.ID).First(orgUser).Error
+	require.NoError(t, err)
+	assert.Equal(t, 2, orgUser.NumTeams)
+	assert.True(t, orgUser.IsOwner)
+}
+
+func teamsRemoveTeamMember(t *testing.T, db *teams) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1, ownersTeam := createTeamsTestOrg(t, db, "org1")
+	team1 := createTestTeam(t, db.DB, &Team{
+		OrgID:     org1.ID,
+		LowerName: "team1",
+		Name:      "team1",
+		Authorize: AccessModeWrite,
+	}, nil, nil)
+
+	err = db.AddTeamMember(ctx, ownersTeam.ID, alice.ID)
+	require.NoError(t, err)
+	err = db.AddTeamMember(ctx, team1.ID, bob.ID)
+	require.NoError(t, err)
+
+	t.Run("last owner", func(t *testing.T) {
+		err := db.RemoveTeamMember(ctx, ownersTeam.ID, alice.ID)
+		assert.Equal(t, ErrLastOrgOwner{UID: alice.ID}, err)
+	})
+
+	// Removing a non-member should be a no-op
+	err = db.RemoveTeamMember(ctx, team1.ID, alice.ID)
+	require.NoError(t, err)
+
+	err = db.RemoveTeamMember(ctx, team1.ID, bob.ID)
+	require.NoError(t, err)
+	assert.False(t, db.IsTeamMember(ctx, team1.ID, bob.ID))
+
+	err = db.First(team1, team1.ID).Error
+	require.NoError(t, err)
+	assert.Equal(t, 0, team1.NumMembers)
+
+	orgUser := new(OrgUser)
+	err = db.Where("org_id = ? AND uid = ?", org1.ID, bob.ID).First(orgUser).Error
+	require.NoError(t, err)
+	assert.Equal(t, 0, orgUser.NumTeams)
+}
+
+func teamsUpdate(t *testing.T, db *teams) {
+	ctx := context.Background()
+
+	conf.SetMockPicture(t,
+		conf.PictureOpts{
+			AvatarUploadPath: t.TempDir(),
+		},
+	)
+
+	org1, ownersTeam := createTeamsTestOrg(t, db, "org1")
+	team1 := createTestTeam(t, db.DB, &Team{
+		OrgID:     org1.ID,
+		LowerName: "team1",
+		Name:      "team1",
+		Authorize: AccessModeWrite,
+	}, nil, nil)
+
+	t.Run("team does not exist", func(t *testing.T) {
+		err := db.Update(ctx, 404, UpdateTeamOptions{})
+		wantErr := ErrTeamNotExist{args: errutil.Args{"teamID": int64(404)}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	t.Run("rename the Owners team", func(t *testing.T) {
+		name := "Admins"
+		err := db.Update(ctx, ownersTeam.ID, UpdateTeamOptions{Name: &name})
+		wantErr := ErrOwnersTeamRename{args: errutil.Args{"teamID": ownersTeam.ID}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	t.Run("name collision", func(t *testing.T) {
+		name := "OWNERS"
+		err := db.Update(ctx, team1.ID, UpdateTeamOptions{Name: &name})
+		wantErr := ErrTeamAlreadyExist{ID: ownersTeam.ID, OrgID: org1.ID, Name: "owners"}
+		assert.Equal(t, wantErr, err)
+	})
+
+	t.Run("description too long", func(t *testing.T) {
+		description := strings.Repeat("a", 256)
+		err := db.Update(ctx, team1.ID, UpdateTeamOptions{Description: &description})
+		wantErr := ErrTeamDescriptionTooLong{args: errutil.Args{"teamID": team1.ID, "max": 255}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	avatar, err := public.Files.ReadFile("img/avatar_default.png")
+	require.NoError(t, err)
+
+	name := "Developers"
+	description := "The developers"
+	err = db.Update(ctx,
+		team1.ID,
+		UpdateTeamOptions{
+			Name:        &name,
+			Description: &description,
+			Avatar:      avatar,
+		},
+	)
+	require.NoError(t, err)
+
+	got := new(Team)
+	err = db.First(got, team1.ID).Error
+	require.NoError(t, err)
+	assert.Equal(t, "Developers", got.Name)
+	assert.Equal(t, "developers", got.LowerName)
+	assert.Equal(t, "The developers", got.Description)
+	assert.True(t, got.UseCustomAvatar)
+	assert.True(t, osutil.IsFile(userutil.CustomTeamAvatarPath(team1.ID)))
+
+	// The Owners team can still update its description
+	name = OWNER_TEAM
+	description = "The owners"
+	err = db.Update(ctx, ownersTeam.ID, UpdateTeamOptions{Name: &name, Description: &description})
+	require.NoError(t, err)
+
+	err = db.Update(ctx, team1.ID, UpdateTeamOptions{DeleteAvatar: true})
+	require.NoError(t, err)
+
+	err = db.First(got, team1.ID).Error
+	require.NoError(t, err)
+	assert.False(t, got.UseCustomAvatar)
+	assert.False(t, osutil.IsFile(userutil.CustomTeamAvatarPath(team1.ID)))
+
+	t.Run("change access level of the Owners team", func(t *testing.T) {
+		authorize := AccessModeRead
+		err := db.Update(ctx, ownersTeam.ID, UpdateTeamOptions{Authorize: &authorize})
+		assert.Error(t, err)
+	})
+
+	t.Run("change access level", func(t *testing.T) {
+		alice, err := NewUsersStore(db.DB).Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+		require.NoError(t, err)
+		repo1, err := NewReposStore(db.DB).Create(ctx, org1.ID, CreateRepoOptions{Name: "repo1", Private: true})
+		require.NoError(t, err)
+		err = db.AddTeamMember(ctx, team1.ID, alice.ID)
+		require.NoError(t, err)
+		err = db.DB.Create(&TeamRepo{OrgID: org1.ID, TeamID: team1.ID, RepoID: repo1.ID}).Error
+		require.NoError(t, err)
+		err = db.RecomputeAccess(ctx, team1.ID)
+		require.NoError(t, err)
+
+		authorize := AccessModeAdmin
+		err = db.Update(WithAuditActor(ctx, alice.ID), team1.ID, UpdateTeamOptions{Authorize: &authorize})
+		require.NoError(t, err)
+
+		log := new(AuditLog)
+		err = db.Where("action = ?", AuditActionUpdateTeam).Order("id DESC").First(log).Error
+		require.NoError(t, err)
+		assert.Equal(t, alice.ID, log.ActorID)
+		assert.Equal(t, team1.ID, log.TeamID)
+
+		err = db.First(got, team1.ID).Error
+		require.NoError(t, err)
+		assert.Equal(t, AccessModeAdmin, got.Authorize)
+
+		access := new(Access)
+		err = db.Where("user_id = ? AND repo_id = ?", alice.ID, repo1.ID).First(access).Error
+		require.NoError(t, err)
+		assert.Equal(t, AccessModeAdmin, access.Mode)
+	})
+}
+
+func teamsMoveRepos(t *testing.T, db *teams) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1, _ := createTeamsTestOrg(t, db, "org1")
+	org2, _ := createTeamsTestOrg(t, db, "org2")
+	team1, err := db.Create(ctx, org1.ID, "team1", CreateTeamOptions{Authorize: AccessModeRead})
+	require.NoError(t, err)
+	team2, err := db.Create(ctx, org1.ID, "team2", CreateTeamOptions{Authorize: AccessModeWrite})
+	require.NoError(t, err)
+	team3, err := db.Create(ctx, org2.ID, "team3", CreateTeamOptions{})
+	require.NoError(t, err)
+
+	err = db.AddTeamMember(ctx, team1.ID, alice.ID)
+	require.NoError(t, err)
+	err = db.AddTeamMember(ctx, team2.ID, bob.ID)
+	require.NoError(t, err)
+
+	reposStore := NewReposStore(db.DB)
+	repo1, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo1", Private: true})
+	require.NoError(t, err)
+	repo2, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo2", Private: true})
+	require.NoError(t, err)
+	repo3, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo3", Private: true})
+	require.NoError(t, err)
+	err = db.DB.Create(
+		[]*TeamRepo{
+			{OrgID: org1.ID, TeamID: team1.ID, RepoID: repo1.ID},
+			{OrgID: org1.ID, TeamID: team1.ID, RepoID: repo2.ID},
+			{OrgID: org1.ID, TeamID: team2.ID, RepoID: repo2.ID},
+		},
+	).Error
+	require.NoError(t, err)
+	err = (&orgs{DB: db.DB}).recountTeamRepos(db.DB, org1.ID)
+	require.NoError(t, err)
+	for _, repoID := range []int64{repo1.ID, repo2.ID} {
+		err = recalculateAccesses(db.DB, repoID)
+		require.NoError(t, err)
+	}
+
+	t.Run("team does not exist", func(t *testing.T) {
+		err := db.MoveRepos(ctx, team1.ID, 404, []int64{repo1.ID})
+		wantErr := ErrTeamNotExist{args: errutil.Args{"teamID": int64(404)}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	t.Run("teams of different organizations", func(t *testing.T) {
+		err := db.MoveRepos(ctx, team1.ID, team3.ID, []int64{repo1.ID})
+		assert.True(t, IsErrTeamOrgMismatch(err))
+	})
+
+	t.Run("repository not associated with the source team", func(t *testing.T) {
+		err := db.MoveRepos(ctx, team1.ID, team2.ID, []int64{repo1.ID, repo3.ID})
+		wantErr := ErrTeamRepoNotExist{args: errutil.Args{"teamID": team1.ID, "repoID": repo3.ID}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	err = db.MoveRepos(WithAuditActor(ctx, alice.ID), team1.ID, team2.ID, []int64{repo1.ID, repo2.ID, repo1.ID})
+	require.NoError(t, err)
+
+	var repoIDs []int64
+	err = db.Model(&TeamRepo{}).Where("team_id = ?", team1.ID).Pluck("repo_id", &repoIDs).Error
+	require.NoError(t, err)
+	assert.Empty(t, repoIDs)
+	err = db.Model(&TeamRepo{}).Where("team_id = ?", team2.ID).Order("repo_id").Pluck("repo_id", &repoIDs).Error
+	require.NoError(t, err)
+	assert.Equal(t, []int64{repo1.ID, repo2.ID}, repoIDs)
+
+	err = db.First(team1, team1.ID).Error
+	require.NoError(t, err)
+	assert.Equal(t, 0, team1.NumRepos)
+	err = db.First(team2, team2.ID).Error
+	require.NoError(t, err)
+	assert.Equal(t, 2, team2.NumRepos)
+
+	// Members of the source team lose access, and members of the destination team
+	// gain access.
+	permsStore := NewPermsStore(db.DB)
+	opts := AccessModeOptions{OwnerID: org1.ID, Private: true}
+	assert.Equal(t, AccessModeNone, permsStore.AccessMode(ctx, alice.ID, repo1.ID, opts))
+	assert.Equal(t, AccessModeNone, permsStore.AccessMode(ctx, alice.ID, repo2.ID, opts))
+	assert.Equal(t, AccessModeWrite, permsStore.AccessMode(ctx, bob.ID, repo1.ID, opts))
+	assert.Equal(t, AccessModeWrite, permsStore.AccessMode(ctx, bob.ID, repo2.ID, opts))
+
+	var logs []*AuditLog
+	err = db.Where("action IN (?)", []AuditAction{AuditActionRemoveTeamRepo, AuditActionAddTeamRepo}).Order("id").Find(&logs).Error
+	require.NoError(t, err)
+	require.Len(t, logs, 4)
+	for _, log := range logs {
+		assert.Equal(t, alice.ID, log.ActorID)
+		assert.Equal(t, org1.ID, log.OrgID)
+	}
+	assert.Equal(t,
+		[]int64{team1.ID, repo1.ID, team2.ID, repo1.ID},
+		[]int64{logs[0].TeamID, logs[0].RepoID, logs[1].TeamID, logs[1].RepoID},
+	)
+	assert.Equal(t, AuditActionRemoveTeamRepo, logs[0].Action)
+	assert.Equal(t, AuditActionAddTeamRepo, logs[1].Action)
+}
+
+func teamsRecomputeAccess(t *testing.T, db *teams) {
+	ctx := context.Background()
+
+	t.Run("team does not exist", func(t *testing.T) {
+		err := db.RecomputeAccess(ctx, 404)
+		wantErr := ErrTeamNotExist{args: errutil.Args{"teamID": int64(404)}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	cindy, err := usersStore.Create(ctx, "cindy", "cindy@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1, ownersTeam := createTeamsTestOrg(t, db, "org1")
+	err = db.Exec(
+		dbutil.Quote("UPDATE %s SET default_repo_permission = ? WHERE id = ?", "user"),
+		AccessModeRead, org1.ID,
+	).Error
+	require.NoError(t, err)
+	team1, err := db.Create(ctx, org1.ID, "team1", CreateTeamOptions{Authorize: AccessModeRead})
+	require.NoError(t, err)
+
+	err = db.AddTeamMember(ctx, ownersTeam.ID, alice.ID)
+	require.NoError(t, err)
+	err = db.AddTeamMember(ctx, team1.ID, bob.ID)
+	require.NoError(t, err)
+	err = db.AddTeamMember(ctx, team1.ID, cindy.ID)
+	require.NoError(t, err)
+
+	reposStore := NewReposStore(db.DB)
+	repo1, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo1", Private: true})
+	require.NoError(t, err)
+	repo2, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo2"})
+	require.NoError(t, err)
+	repo3, err := reposStore.Create(ctx, org1.ID, CreateRepoOptions{Name: "repo3", Private: true})
+	require.NoError(t, err)
+	err = db.DB.Create(
+		[]*TeamRepo{
+			{OrgID: org1.ID, TeamID: team1.ID, RepoID: repo1.ID},
+			{OrgID: org1.ID, TeamID: team1.ID, RepoID: repo2.ID},
+		},
+	).Error
+	require.NoError(t, err)
+	err = db.DB.Create(
+		[]*Collaboration{
+			{UserID: cindy.ID, RepoID: repo1.ID, Mode: AccessModeAdmin},
+			{UserID: cindy.ID, RepoID: repo2.ID, Mode: AccessModeAdmin, ExpiresUnix: 1},
+		},
+	).Error
+	require.NoError(t, err)
+
+	accesses := func(t *testing.T) map[[2]int64]AccessMode {
+		t.Helper()
+
+		var records []*Access
+		err := db.Order("id").Find(&records).Error
+		require.NoError(t, err)
+
+		got := make(map[[2]int64]AccessMode, len(records))
+		for _, r := range records {
+			got[[2]int64{r.UserID, r.RepoID}] = r.Mode
+		}
+		return got
+	}
+
+	// Changing the access level of the team does not update accesses by itself.
+	err = db.Model(&Team{}).Where("id = ?", team1.ID).Update("authorize", AccessModeWrite).Error
+	require.NoError(t, err)
+	err = db.DB.Create(&Access{UserID: bob.ID, RepoID: repo3.ID, Mode: AccessModeAdmin}).Error
+	require.NoError(t, err)
+
+	err = db.RecomputeAccess(ctx, team1.ID)
+	require.NoError(t, err)
+	want := map[[2]int64]AccessMode{
+		{bob.ID, repo1.ID}:   AccessModeWrite,
+		{cindy.ID, repo1.ID}: AccessModeAdmin,
+		{alice.ID, repo1.ID}: AccessModeOwner,
+		{bob.ID, repo2.ID}:   AccessModeWrite,
+		{cindy.ID, repo2.ID}: AccessModeWrite,
+		{alice.ID, repo2.ID}: AccessModeOwner,
+		// Repositories that are not associated with the team are left untouched.
+		{bob.ID, repo3.ID}: AccessModeAdmin,
+	}
+	assert.Equal(t, want, accesses(t))
+
+	// It should be idempotent and agree with computing accesses per repository.
+	err = db.RecomputeAccess(ctx, team1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, want, accesses(t))
+	for _, repoID := range []int64{repo1.ID, repo2.ID} {
+		err = recalculateAccesses(db.DB, repoID)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, want, accesses(t))
+
+	// The Owners team covers all repositories of the organization.
+	err = db.RecomputeAccess(ctx, ownersTeam.ID)
+	require.NoError(t, err)
+	delete(want, [2]int64{bob.ID, repo3.ID})
+	want[[2]int64{alice.ID, repo3.ID}] = AccessModeOwner
+	assert.Equal(t, want, accesses(t))
+}
+
+func teamsDeleteByID(t *testing.T, db *teams) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := usersStore.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	org1, ownersTeam := createTeamsTestOrg(t, db, "org1")
+	team1, err := db.Create(ctx, org1.ID, "team1", CreateTeamOptions{Authorize: AccessModeWrite})
+	require.NoError(t, err)
+	team2, err := db.Create(ctx, org1.ID, "team2", CreateTeamOptions{Authorize: AccessModeRead})
+	require.NoError(t, err)
+
+	t.Run("team does not exist", func(t *testing.T) {
+		err := db.DeleteByID(ctx, 404)
+		wantErr := ErrTeamNotExist{args: errutil.Args{"teamID": int64(404)}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	t.Run("delete the Owners team", func(t *testing.T) {
+		err := db.DeleteByID(ctx, ownersTeam.ID)
+		assert.Error(t, err)
+	})
+
+	err = db.AddTeamMember(ctx, ownersTeam.ID, alice.ID)
+	require.NoError(t, err)
+	err = db.AddTeamMember(ctx, team1.ID, bob.ID)
+	require.NoError(t, err)
+	err = db.AddTeamMember(ctx, team2.ID, bob.ID)
+	require.NoError(t, err)
+
+	repo1, err := NewReposStore(db.DB).Create(ctx, org1.ID, CreateRepoOptions{Name: "repo1", Private: true})
+	require.NoError(t, err)
+	err = db.DB.Create(
+		[]*TeamRepo{
+			{OrgID: org1.ID, TeamID: team1.ID, RepoID: repo1.ID},
+			{OrgID: org1.ID, TeamID: team2.ID, RepoID: repo1.ID},
+		},
+	).Error
+	require.NoError(t, err)
+	err = db.RecomputeAccess(ctx, team1.ID)
+	require.NoError(t, err)
+	err = db.DB.Create(
+		&OrgInvitation{
+			OrgID:       org1.ID,
+			Email:       "cindy@example.com",
+			TeamID:      team1.ID,
+			Token:       "token",
+			CreatedUnix: 1,
+			ExpiresUnix: 2,
+		},
+	).Error
+	require.NoError(t, err)
+
+	err = db.DeleteByID(WithAuditActor(ctx, alice.ID), team1.ID)
+	require.NoError(t, err)
+
+	err = db.Where("id = ?", team1.ID).First(&Team{}).Error
+	assert.Equal(t, gorm.ErrRecordNotFound, err)
+
+	for _, table := range []any{&TeamUser{}, &TeamRepo{}, &OrgInvitation{}} {
+		var count int64
+		err = db.Model(table).Where("team_id = ?", team1.ID).Count(&count).Error
+		require.NoError(t, err)
+		assert.Zero(t, count, "%T", table)
+	}
+
+	// The Owners team was created without bumping the number of teams.
+	gotOrg, err := usersStore.GetByID(ctx, org1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, gotOrg.NumTeams)
+
+	orgUser := new(OrgUser)
+	err = db.Where("org_id = ? AND uid = ?", org1.ID, bob.ID).First(orgUser).Error
+	require.NoError(t, err)
+	assert.Equal(t, 1, orgUser.NumTeams)
+
+	// Bob falls back to the access level of the remaining team.
+	access := new(Access)
+	err = db.Where("user_id = ? AND repo_id = ?", bob.ID, repo1.ID).First(access).Error
+	require.NoError(t, err)
+	assert.Equal(t, AccessModeRead, access.Mode)
+
+	log := new(AuditLog)
+	err = db.Where("action = ?", AuditActionDeleteTeam).First(log).Error
+	require.NoError(t, err)
+	assert.Equal(t, alice.ID, log.ActorID)
+	assert.Equal(t, org1.ID, log.OrgID)
+	assert.Equal(t, team1.ID, log.TeamID)
+}
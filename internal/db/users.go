@@ -5,10 +5,12 @@
 package db
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"time"
 	"unicode/utf8"
@@ -16,6 +18,8 @@ import (
 	"github.com/go-macaron/binding"
 	api "github.com/gogs/go-gogs-client"
 	"github.com/pkg/errors"
+	"golang.org/x/crypto/openpgp"
+	pgperrors "golang.org/x/crypto/openpgp/errors"
 	"gorm.io/gorm"
 	log "unknwon.dev/clog/v2"
 
@@ -49,21 +53,29 @@ type UsersStore interface {
 	// Create creates a new user and persists to database. It returns
 	// ErrNameNotAllowed if the given name or pattern of the name is not allowed as
 	// a username, or ErrUserAlreadyExist when a user with same name already exists,
-	// or ErrEmailAlreadyUsed if the email has been verified by another user.
+	// or ErrEmailAlreadyUsed if the email has been verified by another user, or
+	// ErrPasswordPolicy when the password of a local user does not comply with the
+	// password policy.
 	Create(ctx context.Context, username, email string, opts CreateUserOptions) (*User, error)
 
 	// GetByEmail returns the user (not organization) with given email. It ignores
 	// records with unverified emails and returns ErrUserNotExist when not found.
 	GetByEmail(ctx context.Context, email string) (*User, error)
+	// GetByEmails returns users (not organizations) with given emails, keyed by
+	// the lowercased email. Both primary and secondary emails are matched, but
+	// records with unverified emails are ignored. Emails that do not belong to any
+	// user are absent from the returned map.
+	GetByEmails(ctx context.Context, emails []string) (map[string]*User, error)
 	// GetByID returns the user with given ID. It returns ErrUserNotExist when not
 	// found.
 	GetByID(ctx context.Context, id int64) (*User, error)
 	// GetByUsername returns the user with given username. It returns
 	// ErrUserNotExist when not found.
 	GetByUsername(ctx context.Context, username string) (*User, error)
-	// GetByKeyID returns the owner of given public key ID. It returns
-	// ErrUserNotExist when not found.
-	GetByKeyID(ctx context.Context, keyID int64) (*User, error)
+	// GetByPublicKeyID returns the owner of given public key ID. It returns
+	// ErrUserNotExist when not found or the key is a deploy key, which belongs to
+	// a repository rather than a user.
+	GetByPublicKeyID(ctx context.Context, keyID int64) (*User, error)
 	// GetMailableEmailsByUsernames returns a list of verified primary email
 	// addresses (where email notifications are sent to) of users with given list of
 	// usernames. Non-existing usernames are ignored.
@@ -74,20 +86,38 @@ type UsersStore interface {
 	// all results is also returned. If the order is not given, it's up to the
 	// database to decide.
 	SearchByName(ctx context.Context, keyword string, page, pageSize int, orderBy string) ([]*User, int64, error)
-
-	// IsUsernameUsed returns true if the given username has been used other than
-	// the excluded user (a non-positive ID effectively meaning check against all
-	// users).
-	IsUsernameUsed(ctx context.Context, username string, excludeUserId int64) bool
+	// Search returns a list of users filtered by options. Results are paginated
+	// by given page and page size, and sorted by opts.OrderBy which must be one of
+	// the allowed orders in UserSearchOrders. A total count of all results is
+	// also returned.
+	Search(ctx context.Context, page, pageSize int, opts SearchUsersOptions) ([]*User, int64, error)
+	// ListWithoutTwoFactor returns the subset of given user IDs whose users have
+	// not enabled two-factor authentication, in the same order as given.
+	ListWithoutTwoFactor(ctx context.Context, userIDs []int64) ([]int64, error)
+
+	// IsUsernameUsed returns true if the given username has been used by a user
+	// or an organization other than the excluded one (a non-positive ID
+	// effectively meaning check against all users), or is reserved.
+	IsUsernameUsed(ctx context.Context, username string, excludeUserId int64) (bool, error)
 	// ChangeUsername changes the username of the given user and updates all
 	// references to the old username. It returns ErrNameNotAllowed if the given
 	// name or pattern of the name is not allowed as a username, or
 	// ErrUserAlreadyExist when another user with same name already exists.
 	ChangeUsername(ctx context.Context, userID int64, newUsername string) error
-	// Update updates fields for the given user.
+	// Update updates fields for the given user. It returns ErrPasswordPolicy when
+	// the new password does not comply with the password policy.
 	Update(ctx context.Context, userID int64, opts UpdateUserOptions) error
 	// UseCustomAvatar uses the given avatar as the user custom avatar.
 	UseCustomAvatar(ctx context.Context, userID int64, avatar []byte) error
+	// AvatarURL returns the full URL to the avatar of the user with the given
+	// size in pixels, which is determined by the custom avatar of the user and
+	// conf.Picture.AvatarSource. The size is omitted when it is not positive.
+	AvatarURL(user *User, size int) string
+	// InvalidateSessions invalidates all existing sessions of the user by bumping
+	// the session epoch, which is checked against the one recorded in the session
+	// on each authenticated request. Remember-me cookies of the user are
+	// invalidated as well. It returns ErrUserNotExist when not found.
+	InvalidateSessions(ctx context.Context, userID int64) error
 
 	// DeleteCustomAvatar deletes the current user custom avatar and falls back to
 	// use look up avatar by email.
@@ -122,6 +152,22 @@ type UsersStore interface {
 	// DeleteEmail deletes the email address of the given user.
 	DeleteEmail(ctx context.Context, userID int64, email string) error
 
+	// AddGPGKey adds the armored GPG public key to given user. It returns
+	// ErrGPGKeyInvalid if the key cannot be parsed, ErrGPGKeyEmailNotVerified when
+	// none of the emails of the key has been verified by the user, or
+	// ErrGPGKeyAlreadyExist when a key with the same key ID already exists.
+	AddGPGKey(ctx context.Context, userID int64, content string) (*GPGKey, error)
+	// ListGPGKeys returns all GPG keys of the given user, sorted by the time of
+	// addition.
+	ListGPGKeys(ctx context.Context, userID int64) ([]*GPGKey, error)
+	// DeleteGPGKey deletes the GPG key with given ID of the user. It returns
+	// ErrGPGKeyNotExist when not found.
+	DeleteGPGKey(ctx context.Context, userID, id int64) error
+	// VerifyCommitSignature verifies the signature of the raw commit object (i.e.
+	// the output of "git cat-file commit") against GPG keys of the user who has
+	// verified the author email.
+	VerifyCommitSignature(ctx context.Context, commit []byte) (*CommitVerification, error)
+
 	// Follow marks the user to follow the other user.
 	Follow(ctx context.Context, userID, followID int64) error
 	// Unfollow removes the mark the user to follow the other user.
@@ -137,11 +183,29 @@ type UsersStore interface {
 	// follow in descending order.
 	ListFollowings(ctx context.Context, userID int64, page, pageSize int) ([]*User, error)
 
+	// RecordFailedLogin records a failed login attempt of the username from the IP
+	// address. Attempts are counted within the lockout window that starts at the
+	// first attempt, a new window starts once the previous one has ended.
+	RecordFailedLogin(ctx context.Context, username, ip string) error
+	// IsLockedOut returns true if the number of failed login attempts of the
+	// username from the IP address has reached the threshold within the current
+	// lockout window. The lockout expires by itself when the window ends.
+	IsLockedOut(ctx context.Context, username, ip string) bool
+	// ClearFailedLogins clears failed login attempts of the username from the IP
+	// address.
+	ClearFailedLogins(ctx context.Context, username, ip string) error
+
 	// List returns a list of users. Results are paginated by given page and page
 	// size, and sorted by primary key (id) in ascending order.
 	List(ctx context.Context, page, pageSize int) ([]*User, error)
 	// Count returns the total number of users.
 	Count(ctx context.Context) int64
+
+	// CheckStorageQuota checks whether the total size of repositories owned by
+	// the given user or organization, excluding soft-deleted ones, is still
+	// within the limit of conf.Repository.MaxOwnerStorageSize. It returns
+	// ErrStorageQuotaExceeded when the limit has been reached.
+	CheckStorageQuota(ctx context.Context, userID int64) error
 }
 
 var Users UsersStore
@@ -203,6 +267,7 @@ func (db *users) Authenticate(ctx context.Context, login, password string, login
 		// Validate password hash fetched from database for local accounts.
 		if user.IsLocal() {
 			if userutil.ValidatePassword(user.Password, user.Salt, password) {
+				db.recordLogin(ctx, user)
 				return user, nil
 			}
 
@@ -236,6 +301,8 @@ func (db *users) Authenticate(ctx context.Context, login, password string, login
 	}
 
 	if !createNewUser {
+		db.syncExternalTeams(ctx, user.ID, extAccount.Teams)
+		db.recordLogin(ctx, user)
 		return user, nil
 	}
 
@@ -244,7 +311,7 @@ func (db *users) Authenticate(ctx context.Context, login, password string, login
 		return nil, fmt.Errorf("invalid pattern for attribute 'username' [%s]: must be valid alpha or numeric or dash(-_) or dot characters", extAccount.Name)
 	}
 
-	return db.Create(ctx, extAccount.Name, extAccount.Email,
+	user, err = db.Create(ctx, extAccount.Name, extAccount.Email,
 		CreateUserOptions{
 			FullName:    extAccount.FullName,
 			LoginSource: authSourceID,
@@ -255,6 +322,57 @@ func (db *users) Authenticate(ctx context.Context, login, password string, login
 			Admin:       extAccount.Admin,
 		},
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	db.syncExternalTeams(ctx, user.ID, extAccount.Teams)
+	db.recordLogin(ctx, user)
+	return user, nil
+}
+
+// recordLogin sets the time of last successful authentication of the user.
+// Failures are only logged because they should not prevent the user from
+// signing in.
+func (db *users) recordLogin(ctx context.Context, user *User) {
+	user.LastLoginUnix = db.NowFunc().Unix()
+	err := db.WithContext(ctx).
+		Model(&User{}).
+		Where("id = ?", user.ID).
+		UpdateColumn("last_login_unix", user.LastLoginUnix).
+		Error
+	if err != nil {
+		log.Error("Failed to record login of user %d: %v", user.ID, err)
+	}
+}
+
+// syncExternalTeams reconciles team memberships of the user with the given
+// state reported by the login source, keys are team IDs and values indicate
+// whether the user should be a member of the team. Teams not present in the map
+// are left untouched. Failures are logged without failing the authentication.
+func (db *users) syncExternalTeams(ctx context.Context, userID int64, teams map[int64]bool) {
+	teamsStore := NewTeamsStore(db.DB)
+	for teamID, member := range teams {
+		if member == teamsStore.IsTeamMember(ctx, teamID, userID) {
+			continue
+		}
+
+		if member {
+			err := teamsStore.AddTeamMember(ctx, teamID, userID)
+			if err != nil {
+				log.Warn("Failed to add user to team from login source [user_id: %d, team_id: %d]: %v", userID, teamID, err)
+				continue
+			}
+			log.Info("Added user to team from login source [user_id: %d, team_id: %d]", userID, teamID)
+		} else {
+			err := teamsStore.RemoveTeamMember(ctx, teamID, userID)
+			if err != nil {
+				log.Warn("Failed to remove user from team from login source [user_id: %d, team_id: %d]: %v", userID, teamID, err)
+				continue
+			}
+			log.Info("Removed user from team from login source [user_id: %d, team_id: %d]", userID, teamID)
+		}
+	}
 }
 
 func (db *users) ChangeUsername(ctx context.Context, userID int64, newUsername string) error {
@@ -263,7 +381,10 @@ func (db *users) ChangeUsername(ctx context.Context, userID int64, newUsername s
 		return err
 	}
 
-	if db.IsUsernameUsed(ctx, newUsername, userID) {
+	used, err := db.IsUsernameUsed(ctx, newUsername, userID)
+	if err != nil {
+		return errors.Wrap(err, "check username")
+	} else if used {
 		return ErrUserAlreadyExist{
 			args: errutil.Args{
 				"name": newUsername,
@@ -344,6 +465,50 @@ func (db *users) Count(ctx context.Context) int64 {
 	return count
 }
 
+type ErrStorageQuotaExceeded struct {
+	args errutil.Args
+}
+
+// IsErrStorageQuotaExceeded returns true if the underlying error has the type
+// ErrStorageQuotaExceeded.
+func IsErrStorageQuotaExceeded(err error) bool {
+	_, ok := errors.Cause(err).(ErrStorageQuotaExceeded)
+	return ok
+}
+
+func (err ErrStorageQuotaExceeded) Error() string {
+	return fmt.Sprintf("storage quota exceeded: %v", err.args)
+}
+
+func (db *users) CheckStorageQuota(ctx context.Context, userID int64) error {
+	if conf.Repository.MaxOwnerStorageSize <= -1 {
+		return nil
+	}
+
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT COALESCE(SUM(size), 0) FROM repository
+		WHERE owner_id = @userID AND deleted_unix = 0
+	*/
+	var size int64
+	err := db.WithContext(ctx).
+		Model(&Repository{}).
+		Select("COALESCE(SUM(size), 0)").
+		Where("owner_id = ? AND deleted_unix = 0", userID).
+		Scan(&size).
+		Error
+	if err != nil {
+		return errors.Wrap(err, "sum repository sizes")
+	}
+
+	limit := conf.Repository.MaxOwnerStorageSize * 1024 * 1024
+	if size >= limit {
+		return ErrStorageQuotaExceeded{args: errutil.Args{"userID": userID, "size": size, "limit": limit}}
+	}
+	return nil
+}
+
 type CreateUserOptions struct {
 	FullName    string
 	Password    string
@@ -393,13 +558,64 @@ func (err ErrEmailAlreadyUsed) Error() string {
 	return fmt.Sprintf("email has been used: %v", err.args)
 }
 
+type ErrPasswordPolicy struct {
+	args errutil.Args
+}
+
+// IsErrPasswordPolicy returns true if the underlying error has the type
+// ErrPasswordPolicy.
+func IsErrPasswordPolicy(err error) bool {
+	_, ok := errors.Cause(err).(ErrPasswordPolicy)
+	return ok
+}
+
+func (err ErrPasswordPolicy) Error() string {
+	return fmt.Sprintf("password does not comply with the policy: %v", err.args)
+}
+
+// Violations returns the rules of the password policy that the password
+// violates, see userutil.PasswordPolicy.Violations for possible values.
+func (err ErrPasswordPolicy) Violations() []string {
+	violations, _ := err.args["violations"].([]string)
+	return violations
+}
+
+// checkPasswordPolicy returns ErrPasswordPolicy when the password does not
+// comply with the password policy of the site.
+func checkPasswordPolicy(password string) error {
+	policy := userutil.PasswordPolicy{
+		MinLength:       conf.Auth.PasswordMinLength,
+		RequiredClasses: conf.Auth.PasswordRequiredClasses,
+		RejectCommon:    conf.Auth.PasswordRejectCommon,
+		Banned:          conf.Auth.PasswordBannedList,
+	}
+	violations := policy.Violations(password)
+	if len(violations) > 0 {
+		return ErrPasswordPolicy{args: errutil.Args{"violations": violations}}
+	}
+	return nil
+}
+
 func (db *users) Create(ctx context.Context, username, email string, opts CreateUserOptions) (*User, error) {
 	err := isUsernameAllowed(username)
 	if err != nil {
 		return nil, err
 	}
 
-	if db.IsUsernameUsed(ctx, username, 0) {
+	// NOTE: Passwords of users from external login sources are not used, and
+	// users without a password, e.g. those created by reverse proxy
+	// authentication, cannot sign in with one until it is set.
+	if opts.Password != "" && opts.LoginSource == 0 {
+		err = checkPasswordPolicy(opts.Password)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	used, err := db.IsUsernameUsed(ctx, username, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "check username")
+	} else if used {
 		return nil, ErrUserAlreadyExist{
 			args: errutil.Args{
 				"name": username,
@@ -519,123 +735,8 @@ func (db *users) DeleteByID(ctx context.Context, userID int64, skipRewriteAuthor
 
 	needsRewriteAuthorizedKeys := false
 	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		/*
-			Equivalent SQL for PostgreSQL:
-
-			UPDATE repository
-			SET num_watches = num_watches - 1
-			WHERE id IN (
-				SELECT repo_id FROM watch WHERE user_id = @userID
-			)
-		*/
-		err = tx.Table("repository").
-			Where("id IN (?)", tx.
-				Select("repo_id").
-				Table("watch").
-				Where("user_id = ?", userID),
-			).
-			UpdateColumn("num_watches", gorm.Expr("num_watches - 1")).
-			Error
-		if err != nil {
-			return errors.Wrap(err, `decrease "repository.num_watches"`)
-		}
-
-		/*
-			Equivalent SQL for PostgreSQL:
-
-			UPDATE repository
-			SET num_stars = num_stars - 1
-			WHERE id IN (
-				SELECT repo_id FROM star WHERE uid = @userID
-			)
-		*/
-		err = tx.Table("repository").
-			Where("id IN (?)", tx.
-				Select("repo_id").
-				Table("star").
-				Where("uid = ?", userID),
-			).
-			UpdateColumn("num_stars", gorm.Expr("num_stars - 1")).
-			Error
-		if err != nil {
-			return errors.Wrap(err, `decrease "repository.num_stars"`)
-		}
-
-		/*
-			Equivalent SQL for PostgreSQL:
-
-			UPDATE user
-			SET num_followers = num_followers - 1
-			WHERE id IN (
-				SELECT follow_id FROM follow WHERE user_id = @userID
-			)
-		*/
-		err = tx.Table("user").
-			Where("id IN (?)", tx.
-				Select("follow_id").
-				Table("follow").
-				Where("user_id = ?", userID),
-			).
-			UpdateColumn("num_followers", gorm.Expr("num_followers - 1")).
-			Error
-		if err != nil {
-			return errors.Wrap(err, `decrease "user.num_followers"`)
-		}
-
-		/*
-			Equivalent SQL for PostgreSQL:
-
-			UPDATE user
-			SET num_following = num_following - 1
-			WHERE id IN (
-				SELECT user_id FROM follow WHERE follow_id = @userID
-			)
-		*/
-		err = tx.Table("user").
-			Where("id IN (?)", tx.
-				Select("user_id").
-				Table("follow").
-				Where("follow_id = ?", userID),
-			).
-			UpdateColumn("num_following", gorm.Expr("num_following - 1")).
-			Error
-		if err != nil {
-			return errors.Wrap(err, `decrease "user.num_following"`)
-		}
-
-		if !skipRewriteAuthorizedKeys {
-			// We need to rewrite "authorized_keys" file if the user owns any public keys.
-			needsRewriteAuthorizedKeys = tx.Where("owner_id = ?", userID).First(&PublicKey{}).Error != gorm.ErrRecordNotFound
-		}
-
-		err = tx.Model(&Issue{}).Where("assignee_id = ?", userID).Update("assignee_id", 0).Error
-		if err != nil {
-			return errors.Wrap(err, "clear assignees")
-		}
-
-		for _, t := range []struct {
-			table any
-			where string
-		}{
-			{&Watch{}, "user_id = @userID"},
-			{&Star{}, "uid = @userID"},
-			{&Follow{}, "user_id = @userID OR follow_id = @userID"},
-			{&PublicKey{}, "owner_id = @userID"},
-
-			{&AccessToken{}, "uid = @userID"},
-			{&Collaboration{}, "user_id = @userID"},
-			{&Access{}, "user_id = @userID"},
-			{&Action{}, "user_id = @userID"},
-			{&IssueUser{}, "uid = @userID"},
-			{&EmailAddress{}, "uid = @userID"},
-			{&User{}, "id = @userID"},
-		} {
-			err = tx.Where(t.where, sql.Named("userID", userID)).Delete(t.table).Error
-			if err != nil {
-				return errors.Wrapf(err, "clean up table %T", t.table)
-			}
-		}
-		return nil
+		needsRewriteAuthorizedKeys, err = db.deleteByID(tx, userID, skipRewriteAuthorizedKeys)
+		return err
 	})
 	if err != nil {
 		return err
@@ -653,6 +754,130 @@ func (db *users) DeleteByID(ctx context.Context, userID int64, skipRewriteAuthor
 	return nil
 }
 
+// deleteByID deletes the user and cleans up related data in the given
+// transaction. It returns whether the "authorized_keys" file needs to be
+// rewritten because the user owned public keys.
+func (*users) deleteByID(tx *gorm.DB, userID int64, skipRewriteAuthorizedKeys bool) (needsRewriteAuthorizedKeys bool, err error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		UPDATE repository
+		SET num_watches = num_watches - 1
+		WHERE id IN (
+			SELECT repo_id FROM watch WHERE user_id = @userID
+		)
+	*/
+	err = tx.Table("repository").
+		Where("id IN (?)", tx.
+			Select("repo_id").
+			Table("watch").
+			Where("user_id = ?", userID),
+		).
+		UpdateColumn("num_watches", gorm.Expr("num_watches - 1")).
+		Error
+	if err != nil {
+		return false, errors.Wrap(err, `decrease "repository.num_watches"`)
+	}
+
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		UPDATE repository
+		SET num_stars = num_stars - 1
+		WHERE id IN (
+			SELECT repo_id FROM star WHERE uid = @userID
+		)
+	*/
+	err = tx.Table("repository").
+		Where("id IN (?)", tx.
+			Select("repo_id").
+			Table("star").
+			Where("uid = ?", userID),
+		).
+		UpdateColumn("num_stars", gorm.Expr("num_stars - 1")).
+		Error
+	if err != nil {
+		return false, errors.Wrap(err, `decrease "repository.num_stars"`)
+	}
+
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		UPDATE user
+		SET num_followers = num_followers - 1
+		WHERE id IN (
+			SELECT follow_id FROM follow WHERE user_id = @userID
+		)
+	*/
+	err = tx.Table("user").
+		Where("id IN (?)", tx.
+			Select("follow_id").
+			Table("follow").
+			Where("user_id = ?", userID),
+		).
+		UpdateColumn("num_followers", gorm.Expr("num_followers - 1")).
+		Error
+	if err != nil {
+		return false, errors.Wrap(err, `decrease "user.num_followers"`)
+	}
+
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		UPDATE user
+		SET num_following = num_following - 1
+		WHERE id IN (
+			SELECT user_id FROM follow WHERE follow_id = @userID
+		)
+	*/
+	err = tx.Table("user").
+		Where("id IN (?)", tx.
+			Select("user_id").
+			Table("follow").
+			Where("follow_id = ?", userID),
+		).
+		UpdateColumn("num_following", gorm.Expr("num_following - 1")).
+		Error
+	if err != nil {
+		return false, errors.Wrap(err, `decrease "user.num_following"`)
+	}
+
+	if !skipRewriteAuthorizedKeys {
+		// We need to rewrite "authorized_keys" file if the user owns any public keys.
+		needsRewriteAuthorizedKeys = tx.Where("owner_id = ?", userID).First(&PublicKey{}).Error != gorm.ErrRecordNotFound
+	}
+
+	err = tx.Model(&Issue{}).Where("assignee_id = ?", userID).Update("assignee_id", 0).Error
+	if err != nil {
+		return false, errors.Wrap(err, "clear assignees")
+	}
+
+	for _, t := range []struct {
+		table any
+		where string
+	}{
+		{&Watch{}, "user_id = @userID"},
+		{&Star{}, "uid = @userID"},
+		{&Follow{}, "user_id = @userID OR follow_id = @userID"},
+		{&PublicKey{}, "owner_id = @userID"},
+		{&GPGKey{}, "owner_id = @userID"},
+
+		{&AccessToken{}, "uid = @userID"},
+		{&Collaboration{}, "user_id = @userID"},
+		{&Access{}, "user_id = @userID"},
+		{&Action{}, "user_id = @userID"},
+		{&IssueUser{}, "uid = @userID"},
+		{&EmailAddress{}, "uid = @userID"},
+		{&User{}, "id = @userID"},
+	} {
+		err = tx.Where(t.where, sql.Named("userID", userID)).Delete(t.table).Error
+		if err != nil {
+			return false, errors.Wrapf(err, "clean up table %T", t.table)
+		}
+	}
+	return needsRewriteAuthorizedKeys, nil
+}
+
 // NOTE: We do not take context.Context here because this operation in practice
 // could much longer than the general request timeout (e.g. one minute).
 func (db *users) DeleteInactivated() error {
@@ -819,6 +1044,81 @@ func (db *users) GetByEmail(ctx context.Context, email string) (*User, error) {
 	return user, nil
 }
 
+func (db *users) GetByEmails(ctx context.Context, emails []string) (map[string]*User, error) {
+	wanted := make(map[string]struct{}, len(emails))
+	for _, email := range emails {
+		email = strings.ToLower(strings.TrimSpace(email))
+		if email != "" {
+			wanted[email] = struct{}{}
+		}
+	}
+	if len(wanted) == 0 {
+		return map[string]*User{}, nil
+	}
+
+	lowerEmails := make([]string, 0, len(wanted))
+	for email := range wanted {
+		lowerEmails = append(lowerEmails, email)
+	}
+
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT "user".*, email_address.email AS secondary_email, email_address.is_activated AS secondary_email_activated
+		FROM "user"
+		LEFT JOIN email_address ON email_address.uid = "user".id
+		WHERE
+			"user".type = @userType
+		AND (
+				LOWER("user".email) IN @emails AND "user".is_active = TRUE
+			OR  LOWER(email_address.email) IN @emails AND email_address.is_activated = TRUE
+		)
+	*/
+	var rows []*struct {
+		User                    `gorm:"embedded"`
+		SecondaryEmail          *string
+		SecondaryEmailActivated *bool
+	}
+	err := db.WithContext(ctx).
+		Model(&User{}).
+		Select(dbutil.Quote("%s.*, email_address.email AS secondary_email, email_address.is_activated AS secondary_email_activated", "user")).
+		Joins(dbutil.Quote("LEFT JOIN email_address ON email_address.uid = %s.id", "user"), true).
+		Where(dbutil.Quote("%s.type = ?", "user"), UserTypeIndividual).
+		Where(db.
+			Where(dbutil.Quote("LOWER(%[1]s.email) IN (?) AND %[1]s.is_active = ?", "user"), lowerEmails, true).
+			Or("LOWER(email_address.email) IN (?) AND email_address.is_activated = ?", lowerEmails, true),
+		).
+		Find(&rows).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	users := make(map[int64]*User, len(rows))
+	matched := make(map[string]*User, len(wanted))
+	for _, row := range rows {
+		user, ok := users[row.ID]
+		if !ok {
+			u := row.User
+			user = &u
+			users[user.ID] = user
+		}
+
+		if email := strings.ToLower(user.Email); user.IsActive {
+			if _, ok := wanted[email]; ok {
+				matched[email] = user
+			}
+		}
+		if row.SecondaryEmail != nil && row.SecondaryEmailActivated != nil && *row.SecondaryEmailActivated {
+			email := strings.ToLower(*row.SecondaryEmail)
+			if _, ok := wanted[email]; ok {
+				matched[email] = user
+			}
+		}
+	}
+	return matched, nil
+}
+
 func (db *users) GetByID(ctx context.Context, id int64) (*User, error) {
 	user := new(User)
 	err := db.WithContext(ctx).Where("id = ?", id).First(user).Error
@@ -843,11 +1143,19 @@ func (db *users) GetByUsername(ctx context.Context, username string) (*User, err
 	return user, nil
 }
 
-func (db *users) GetByKeyID(ctx context.Context, keyID int64) (*User, error) {
+func (db *users) GetByPublicKeyID(ctx context.Context, keyID int64) (*User, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT "user".* FROM "user"
+		JOIN public_key ON public_key.owner_id = "user".id
+		WHERE public_key.id = @keyID AND public_key.type = @keyTypeUser
+		LIMIT 1
+	*/
 	user := new(User)
 	err := db.WithContext(ctx).
 		Joins(dbutil.Quote("JOIN public_key ON public_key.owner_id = %s.id", "user")).
-		Where("public_key.id = ?", keyID).
+		Where("public_key.id = ? AND public_key.type = ?", keyID, KEY_TYPE_USER).
 		First(user).
 		Error
 	if err != nil {
@@ -868,15 +1176,25 @@ func (db *users) GetMailableEmailsByUsernames(ctx context.Context, usernames []s
 		Find(&emails).Error
 }
 
-func (db *users) IsUsernameUsed(ctx context.Context, username string, excludeUserId int64) bool {
+func (db *users) IsUsernameUsed(ctx context.Context, username string, excludeUserId int64) (bool, error) {
 	if username == "" {
-		return false
+		return false, nil
+	} else if IsErrNameNotAllowed(isUsernameAllowed(username)) {
+		return true, nil
 	}
-	return db.WithContext(ctx).
+
+	err := db.WithContext(ctx).
 		Select("id").
 		Where("lower_name = ? AND id != ?", strings.ToLower(username), excludeUserId).
 		First(&User{}).
-		Error != gorm.ErrRecordNotFound
+		Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "get user")
+	}
+	return true, nil
 }
 
 func (db *users) List(ctx context.Context, page, pageSize int) ([]*User, error) {
@@ -929,14 +1247,25 @@ func (db *users) ListFollowings(ctx context.Context, userID int64, page, pageSiz
 		Error
 }
 
-func searchUserByName(ctx context.Context, db *gorm.DB, userType UserType, keyword string, page, pageSize int, orderBy string) ([]*User, int64, error) {
+func searchUserByName(ctx context.Context, db *gorm.DB, userType UserType, keyword string, page, pageSize int, orderBy string, scopes ...func(*gorm.DB) *gorm.DB) ([]*User, int64, error) {
 	if keyword == "" {
 		return []*User{}, 0, nil
 	}
 	keyword = "%" + strings.ToLower(keyword) + "%"
 
+	matchKeyword := func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("lower_name LIKE ? OR LOWER(full_name) LIKE ?", keyword, keyword)
+	}
+	return listUsersByType(ctx, db, userType, page, pageSize, orderBy, append([]func(*gorm.DB) *gorm.DB{matchKeyword}, scopes...)...)
+}
+
+// listUsersByType returns users of the given type that match all given scopes.
+// Results are paginated by given page and page size, and sorted by the given
+// order. A total count of all results is also returned.
+func listUsersByType(ctx context.Context, db *gorm.DB, userType UserType, page, pageSize int, orderBy string, scopes ...func(*gorm.DB) *gorm.DB) ([]*User, int64, error) {
 	tx := db.WithContext(ctx).
-		Where("type = ? AND (lower_name LIKE ? OR LOWER(full_name) LIKE ?)", userType, keyword, keyword)
+		Where("type = ?", userType).
+		Scopes(scopes...)
 
 	var count int64
 	err := tx.Model(&User{}).Count(&count).Error
@@ -952,6 +1281,151 @@ func (db *users) SearchByName(ctx context.Context, keyword string, page, pageSiz
 	return searchUserByName(ctx, db.DB, UserTypeIndividual, keyword, page, pageSize, orderBy)
 }
 
+// UserSearchOrders is the list of orders that are allowed to sort results of
+// searching users.
+var UserSearchOrders = []string{
+	"id ASC",
+	"id DESC",
+	"lower_name ASC",
+	"lower_name DESC",
+	"created_unix ASC",
+	"created_unix DESC",
+	"updated_unix ASC",
+	"updated_unix DESC",
+}
+
+type SearchUsersOptions struct {
+	// The keyword to match usernames or full names case-insensitively, empty
+	// means no filtering.
+	Keyword string
+	// Whether to only include activated or inactivated users, nil means both.
+	IsActive *bool
+	// Whether to only include site admins or non-admins, nil means both.
+	IsAdmin *bool
+	// The ID of the login source that users authenticate through, 0 means local
+	// accounts and nil means any.
+	LoginSource *int64
+	// The type of login source that users authenticate through, auth.Plain means
+	// local accounts and auth.None means any.
+	LoginType auth.Type
+	// The order of results, defaults to "id ASC".
+	OrderBy string
+}
+
+func (db *users) Search(ctx context.Context, page, pageSize int, opts SearchUsersOptions) ([]*User, int64, error) {
+	if opts.OrderBy == "" {
+		opts.OrderBy = "id ASC"
+	}
+	allowed := false
+	for _, orderBy := range UserSearchOrders {
+		if opts.OrderBy == orderBy {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, 0, errors.Errorf("order %q is not allowed", opts.OrderBy)
+	}
+
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT * FROM "user"
+		WHERE
+			type = @userType
+		[AND (lower_name LIKE @keyword OR LOWER(full_name) LIKE @keyword)]
+		[AND is_active = @isActive]
+		[AND is_admin = @isAdmin]
+		[AND login_source = @loginSource]
+		[AND login_source IN (@loginSourceIDsOfType)]
+		ORDER BY @orderBy
+		LIMIT @limit OFFSET @offset
+	*/
+	tx := db.WithContext(ctx).Model(&User{}).Where("type = ?", UserTypeIndividual)
+	if opts.Keyword != "" {
+		keyword := "%" + strings.ToLower(opts.Keyword) + "%"
+		tx = tx.Where("lower_name LIKE ? OR LOWER(full_name) LIKE ?", keyword, keyword)
+	}
+	if opts.IsActive != nil {
+		tx = tx.Where("is_active = ?", *opts.IsActive)
+	}
+	if opts.IsAdmin != nil {
+		tx = tx.Where("is_admin = ?", *opts.IsAdmin)
+	}
+	if opts.LoginSource != nil {
+		tx = tx.Where("login_source = ?", *opts.LoginSource)
+	}
+	switch opts.LoginType {
+	case auth.None:
+	case auth.Plain:
+		tx = tx.Where("login_source = 0")
+	default:
+		// Login sources may come from local files, thus filter by IDs instead of a
+		// subquery.
+		sources, err := LoginSources.List(ctx, ListLoginSourceOptions{})
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "list login sources")
+		}
+
+		sourceIDs := make([]int64, 0, len(sources))
+		for _, source := range sources {
+			if source.Type == opts.LoginType {
+				sourceIDs = append(sourceIDs, source.ID)
+			}
+		}
+		if len(sourceIDs) == 0 {
+			return []*User{}, 0, nil
+		}
+		tx = tx.Where("login_source IN (?)", sourceIDs)
+	}
+
+	var count int64
+	err := tx.Count(&count).Error
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "count")
+	}
+
+	users := make([]*User, 0, pageSize)
+	return users, count, tx.Order(opts.OrderBy).Limit(pageSize).Offset((page - 1) * pageSize).Find(&users).Error
+}
+
+func (db *users) ListWithoutTwoFactor(ctx context.Context, userIDs []int64) ([]int64, error) {
+	if len(userIDs) == 0 {
+		return []int64{}, nil
+	}
+
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT user_id FROM two_factor
+		WHERE user_id IN @userIDs
+	*/
+	var enrolledIDs []int64
+	err := db.WithContext(ctx).
+		Model(&TwoFactor{}).
+		Where("user_id IN (?)", userIDs).
+		Pluck("user_id", &enrolledIDs).
+		Error
+	if err != nil {
+		return nil, errors.Wrap(err, "list users with two-factor authentication")
+	}
+
+	skip := make(map[int64]bool, len(enrolledIDs))
+	for _, id := range enrolledIDs {
+		skip[id] = true
+	}
+
+	withoutIDs := make([]int64, 0, len(userIDs)-len(enrolledIDs))
+	for _, id := range userIDs {
+		if skip[id] {
+			continue
+		}
+		skip[id] = true // Deduplicate
+		withoutIDs = append(withoutIDs, id)
+	}
+	return withoutIDs, nil
+}
+
 type UpdateUserOptions struct {
 	LoginSource *int64
 	LoginName   *string
@@ -992,6 +1466,11 @@ func (db *users) Update(ctx context.Context, userID int64, opts UpdateUserOption
 	}
 
 	if opts.Password != nil {
+		err := checkPasswordPolicy(*opts.Password)
+		if err != nil {
+			return err
+		}
+
 		salt, err := userutil.RandomSalt()
 		if err != nil {
 			return errors.Wrap(err, "generate salt")
@@ -1066,6 +1545,45 @@ func (db *users) Update(ctx context.Context, userID int64, opts UpdateUserOption
 	return db.WithContext(ctx).Model(&User{}).Where("id = ?", userID).Updates(updates).Error
 }
 
+func (db *users) AvatarURL(user *User, size int) string {
+	url := user.AvatarURL()
+	if size <= 0 {
+		return url
+	}
+	return tool.AppendAvatarSize(url, size)
+}
+
+func (db *users) InvalidateSessions(ctx context.Context, userID int64) error {
+	rands, err := userutil.RandomSalt()
+	if err != nil {
+		return errors.Wrap(err, "generate rands")
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(&User{}).Where("id = ?", userID).Count(&count).Error
+		if err != nil {
+			return errors.Wrap(err, "count user")
+		} else if count == 0 {
+			return ErrUserNotExist{args: errutil.Args{"userID": userID}}
+		}
+
+		err = tx.Model(&User{}).
+			Where("id = ?", userID).
+			Updates(map[string]any{
+				"session_epoch": gorm.Expr("session_epoch + 1"),
+				// Remember-me cookies are signed with the rands.
+				"rands":        rands,
+				"updated_unix": tx.NowFunc().Unix(),
+			}).
+			Error
+		if err != nil {
+			return errors.Wrap(err, "update")
+		}
+		return nil
+	})
+}
+
 func (db *users) UseCustomAvatar(ctx context.Context, userID int64, avatar []byte) error {
 	err := userutil.SaveAvatar(userID, avatar)
 	if err != nil {
@@ -1259,6 +1777,309 @@ func (db *users) DeleteEmail(ctx context.Context, userID int64, email string) er
 	return db.WithContext(ctx).Where("uid = ? AND email = ?", userID, email).Delete(&EmailAddress{}).Error
 }
 
+type ErrGPGKeyInvalid struct {
+	args errutil.Args
+}
+
+// IsErrGPGKeyInvalid returns true if the underlying error has the type
+// ErrGPGKeyInvalid.
+func IsErrGPGKeyInvalid(err error) bool {
+	_, ok := errors.Cause(err).(ErrGPGKeyInvalid)
+	return ok
+}
+
+func (err ErrGPGKeyInvalid) Error() string {
+	return fmt.Sprintf("GPG key is invalid: %v", err.args)
+}
+
+type ErrGPGKeyEmailNotVerified struct {
+	args errutil.Args
+}
+
+// IsErrGPGKeyEmailNotVerified returns true if the underlying error has the type
+// ErrGPGKeyEmailNotVerified.
+func IsErrGPGKeyEmailNotVerified(err error) bool {
+	_, ok := errors.Cause(err).(ErrGPGKeyEmailNotVerified)
+	return ok
+}
+
+func (err ErrGPGKeyEmailNotVerified) Error() string {
+	return fmt.Sprintf("none of the GPG key emails has been verified: %v", err.args)
+}
+
+type ErrGPGKeyAlreadyExist struct {
+	args errutil.Args
+}
+
+// IsErrGPGKeyAlreadyExist returns true if the underlying error has the type
+// ErrGPGKeyAlreadyExist.
+func IsErrGPGKeyAlreadyExist(err error) bool {
+	_, ok := errors.Cause(err).(ErrGPGKeyAlreadyExist)
+	return ok
+}
+
+func (err ErrGPGKeyAlreadyExist) Error() string {
+	return fmt.Sprintf("GPG key already exists: %v", err.args)
+}
+
+func (db *users) AddGPGKey(ctx context.Context, userID int64, content string) (*GPGKey, error) {
+	content = strings.TrimSpace(content)
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(content))
+	if err != nil {
+		return nil, ErrGPGKeyInvalid{args: errutil.Args{"userID": userID, "reason": err.Error()}}
+	} else if len(entities) != 1 {
+		return nil, ErrGPGKeyInvalid{args: errutil.Args{"userID": userID, "reason": fmt.Sprintf("want exactly one key but got %d", len(entities))}}
+	}
+
+	entity := entities[0]
+	if entity.PrivateKey != nil {
+		return nil, ErrGPGKeyInvalid{args: errutil.Args{"userID": userID, "reason": "not a public key"}}
+	}
+	keyID := entity.PrimaryKey.KeyIdString()
+
+	// Only keep emails that have been verified by the user, so that signatures
+	// can never be attributed to someone else's email.
+	var emails []string
+	for _, identity := range entity.Identities {
+		email := strings.ToLower(identity.UserId.Email)
+		if email == "" {
+			continue
+		}
+
+		user, err := db.GetByEmail(ctx, email)
+		if err != nil {
+			if IsErrUserNotExist(err) {
+				continue
+			}
+			return nil, errors.Wrap(err, "get user by email")
+		} else if user.ID == userID {
+			emails = append(emails, email)
+		}
+	}
+	if len(emails) == 0 {
+		return nil, ErrGPGKeyEmailNotVerified{args: errutil.Args{"userID": userID, "keyID": keyID}}
+	}
+	sort.Strings(emails)
+
+	key := &GPGKey{
+		OwnerID: userID,
+		KeyID:   keyID,
+		Emails:  strings.Join(emails, ","),
+		Content: content,
+	}
+	return key, db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("key_id = ?", keyID).First(&GPGKey{}).Error
+		if err == nil {
+			return ErrGPGKeyAlreadyExist{args: errutil.Args{"keyID": keyID}}
+		} else if err != gorm.ErrRecordNotFound {
+			return errors.Wrap(err, "check existing key")
+		}
+		return tx.Create(key).Error
+	})
+}
+
+func (db *users) ListGPGKeys(ctx context.Context, userID int64) ([]*GPGKey, error) {
+	var keys []*GPGKey
+	return keys, db.WithContext(ctx).Where("owner_id = ?", userID).Order("id").Find(&keys).Error
+}
+
+var _ errutil.NotFound = (*ErrGPGKeyNotExist)(nil)
+
+type ErrGPGKeyNotExist struct {
+	args errutil.Args
+}
+
+// IsErrGPGKeyNotExist returns true if the underlying error has the type
+// ErrGPGKeyNotExist.
+func IsErrGPGKeyNotExist(err error) bool {
+	_, ok := errors.Cause(err).(ErrGPGKeyNotExist)
+	return ok
+}
+
+func (err ErrGPGKeyNotExist) Error() string {
+	return fmt.Sprintf("GPG key does not exist: %v", err.args)
+}
+
+func (ErrGPGKeyNotExist) NotFound() bool {
+	return true
+}
+
+func (db *users) DeleteGPGKey(ctx context.Context, userID, id int64) error {
+	result := db.WithContext(ctx).Where("id = ? AND owner_id = ?", id, userID).Delete(&GPGKey{})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return ErrGPGKeyNotExist{args: errutil.Args{"userID": userID, "id": id}}
+	}
+	return nil
+}
+
+// CommitVerificationState is the state of verifying the signature of a commit.
+type CommitVerificationState string
+
+const (
+	// CommitVerificationUnsigned indicates the commit is not signed.
+	CommitVerificationUnsigned CommitVerificationState = "unsigned"
+	// CommitVerificationUnknownKey indicates the commit is signed by a key that
+	// does not belong to the user who has verified the author email.
+	CommitVerificationUnknownKey CommitVerificationState = "unknown_key"
+	// CommitVerificationBadSignature indicates the signature does not match the
+	// content of the commit.
+	CommitVerificationBadSignature CommitVerificationState = "bad_signature"
+	// CommitVerificationVerified indicates the commit is signed by a key of the
+	// user who has verified the author email.
+	CommitVerificationVerified CommitVerificationState = "verified"
+)
+
+// CommitVerification is the result of verifying the signature of a commit.
+type CommitVerification struct {
+	State CommitVerificationState
+	// The user and the key that signed the commit, only set when the state is
+	// CommitVerificationVerified.
+	Signer *User
+	Key    *GPGKey
+}
+
+// parseCommitSignature splits the raw commit object into the signed payload and
+// the armored signature, and returns the author email along the way. The
+// signature is empty if the commit is not signed.
+func parseCommitSignature(commit []byte) (payload, signature []byte, authorEmail string) {
+	header, message, _ := bytes.Cut(commit, []byte("\n\n"))
+
+	var payloadBuf, signatureBuf bytes.Buffer
+	inSignature := false
+	for _, line := range bytes.Split(header, []byte("\n")) {
+		// Continuation lines of a multi-line header start with a space.
+		if inSignature && bytes.HasPrefix(line, []byte(" ")) {
+			signatureBuf.Write(line[1:])
+			signatureBuf.WriteByte('\n')
+			continue
+		}
+		inSignature = false
+
+		if bytes.HasPrefix(line, []byte("gpgsig ")) {
+			inSignature = true
+			signatureBuf.Write(bytes.TrimPrefix(line, []byte("gpgsig ")))
+			signatureBuf.WriteByte('\n')
+			continue
+		} else if bytes.HasPrefix(line, []byte("author ")) {
+			start := bytes.IndexByte(line, '<')
+			end := bytes.LastIndexByte(line, '>')
+			if start >= 0 && end > start {
+				authorEmail = string(line[start+1 : end])
+			}
+		}
+		payloadBuf.Write(line)
+		payloadBuf.WriteByte('\n')
+	}
+	payloadBuf.WriteByte('\n')
+	payloadBuf.Write(message)
+	return payloadBuf.Bytes(), signatureBuf.Bytes(), authorEmail
+}
+
+func (db *users) VerifyCommitSignature(ctx context.Context, commit []byte) (*CommitVerification, error) {
+	payload, signature, authorEmail := parseCommitSignature(commit)
+	if len(signature) == 0 {
+		return &CommitVerification{State: CommitVerificationUnsigned}, nil
+	}
+	authorEmail = strings.ToLower(authorEmail)
+
+	author, err := db.GetByEmail(ctx, authorEmail)
+	if err != nil {
+		if IsErrUserNotExist(err) {
+			return &CommitVerification{State: CommitVerificationUnknownKey}, nil
+		}
+		return nil, errors.Wrap(err, "get author by email")
+	}
+
+	keys, err := db.ListGPGKeys(ctx, author.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "list GPG keys")
+	}
+
+	var keyring openpgp.EntityList
+	keysByID := make(map[uint64]*GPGKey, len(keys))
+	for _, key := range keys {
+		// Keys are only trusted for the emails they have been verified with.
+		if !key.hasEmail(authorEmail) {
+			continue
+		}
+
+		entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key.Content))
+		if err != nil {
+			log.Error("Failed to read GPG key %d: %v", key.ID, err)
+			continue
+		}
+		for _, entity := range entities {
+			keysByID[entity.PrimaryKey.KeyId] = key
+		}
+		keyring = append(keyring, entities...)
+	}
+
+	signer, err := openpgp.CheckArmoredDetachedSignature(keyring, bytes.NewReader(payload), bytes.NewReader(signature))
+	if err != nil {
+		if err == pgperrors.ErrUnknownIssuer {
+			return &CommitVerification{State: CommitVerificationUnknownKey}, nil
+		}
+		return &CommitVerification{State: CommitVerificationBadSignature}, nil
+	}
+	return &CommitVerification{
+		State:  CommitVerificationVerified,
+		Signer: author,
+		Key:    keysByID[signer.PrimaryKey.KeyId],
+	}, nil
+}
+
+func (db *users) RecordFailedLogin(ctx context.Context, username, ip string) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := tx.NowFunc().Unix()
+		attempt := new(FailedLogin)
+		err := tx.Where("lower_name = ? AND ip = ?", strings.ToLower(username), ip).First(attempt).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return tx.Create(
+					&FailedLogin{
+						LowerName:       strings.ToLower(username),
+						IP:              ip,
+						NumAttempts:     1,
+						WindowStartUnix: now,
+					},
+				).Error
+			}
+			return errors.Wrap(err, "get failed login")
+		}
+
+		updates := map[string]any{
+			"num_attempts": gorm.Expr("num_attempts + 1"),
+		}
+		if now >= attempt.WindowStartUnix+int64(conf.Security.LoginLockoutWindow.Seconds()) {
+			updates = map[string]any{
+				"num_attempts":      1,
+				"window_start_unix": now,
+			}
+		}
+		return tx.Model(attempt).Updates(updates).Error
+	})
+}
+
+func (db *users) IsLockedOut(ctx context.Context, username, ip string) bool {
+	if conf.Security.LoginLockoutThreshold <= 0 {
+		return false
+	}
+
+	attempt := new(FailedLogin)
+	err := db.WithContext(ctx).Where("lower_name = ? AND ip = ?", strings.ToLower(username), ip).First(attempt).Error
+	if err != nil {
+		return false
+	}
+	return attempt.NumAttempts >= conf.Security.LoginLockoutThreshold &&
+		db.NowFunc().Unix() < attempt.WindowStartUnix+int64(conf.Security.LoginLockoutWindow.Seconds())
+}
+
+func (db *users) ClearFailedLogins(ctx context.Context, username, ip string) error {
+	return db.WithContext(ctx).Where("lower_name = ? AND ip = ?", strings.ToLower(username), ip).Delete(&FailedLogin{}).Error
+}
+
 // UserType indicates the type of the user account.
 type UserType int
 
@@ -1267,6 +2088,18 @@ const (
 	UserTypeOrganization
 )
 
+// RepoCreatePermission indicates who are allowed to create repositories in an
+// organization.
+type RepoCreatePermission int
+
+const (
+	// RepoCreatePermissionMembers allows owners and members of teams with the
+	// write access mode or higher to create repositories.
+	RepoCreatePermissionMembers RepoCreatePermission = iota
+	// RepoCreatePermissionOwners only allows owners to create repositories.
+	RepoCreatePermissionOwners
+)
+
 // User represents the object of an individual or an organization.
 type User struct {
 	ID        int64  `gorm:"primaryKey"`
@@ -1288,6 +2121,8 @@ type User struct {
 	CreatedUnix int64
 	Updated     time.Time `xorm:"-" gorm:"-" json:"-"`
 	UpdatedUnix int64
+	// The time in Unix seconds of the last successful authentication.
+	LastLoginUnix int64 `xorm:"NOT NULL DEFAULT 0" gorm:"not null;default:0"`
 
 	// Remember visibility choice for convenience, true for private
 	LastRepoVisibility bool
@@ -1300,6 +2135,9 @@ type User struct {
 	AllowGitHook     bool
 	AllowImportLocal bool // Allow migrate repository by local path
 	ProhibitLogin    bool
+	// The epoch of sessions, sessions recorded with a different epoch are no
+	// longer valid.
+	SessionEpoch int64 `xorm:"NOT NULL DEFAULT 0" gorm:"not null;default:0"`
 
 	// Avatar
 	Avatar          string `xorm:"VARCHAR(2048) NOT NULL" gorm:"type:VARCHAR(2048);not null"`
@@ -1318,6 +2156,19 @@ type User struct {
 	NumMembers  int
 	Teams       []*Team `xorm:"-" gorm:"-" json:"-"`
 	Members     []*User `xorm:"-" gorm:"-" json:"-"`
+
+	// The access mode that members get on public repositories by default.
+	DefaultRepoPermission AccessMode `xorm:"NOT NULL DEFAULT 0" gorm:"not null;default:0"`
+	// Whether members must enable two-factor authentication to access resources
+	// of the organization.
+	RequireTwoFactor bool `xorm:"NOT NULL DEFAULT false" gorm:"not null;default:FALSE"`
+	// Whether the organization is verified as official by site admins.
+	IsVerified bool `xorm:"NOT NULL DEFAULT false" gorm:"not null;default:FALSE"`
+	// Whether the organization is archived, which hides it from listings and
+	// makes its repositories read-only.
+	IsArchived bool `xorm:"NOT NULL DEFAULT false" gorm:"not null;default:FALSE"`
+	// Who are allowed to create repositories in the organization.
+	RepoCreatePermission RepoCreatePermission `xorm:"NOT NULL DEFAULT 0" gorm:"not null;default:0"`
 }
 
 // BeforeCreate implements the GORM create hook.
@@ -1415,8 +2266,9 @@ func (u *User) HTMLURL() string {
 	return conf.Server.ExternalURL + u.Name
 }
 
-// AvatarURLPath returns the URL path to the user or organization avatar. If the
-// user enables Gravatar-like service, then an external URL will be returned.
+// AvatarURLPath returns the URL path to the user or organization avatar
+// according to conf.Picture.AvatarSource. If a Gravatar-like service is used,
+// then an external URL will be returned.
 //
 // TODO(unknwon): This is also used in templates, which should be fixed by
 // having a dedicated type `template.User` and move this to the "userutil"
@@ -1434,7 +2286,9 @@ func (u *User) AvatarURLPath() string {
 			return defaultURLPath
 		}
 		return fmt.Sprintf("%s/%s/%d", conf.Server.Subpath, conf.UsersAvatarPathPrefix, u.ID)
-	case conf.Picture.DisableGravatar:
+	case conf.Picture.AvatarSource == conf.AvatarSourceInternal:
+		return defaultURLPath
+	case conf.Picture.AvatarSource == conf.AvatarSourceDisabled:
 		if !hasCustomAvatar {
 			if err := userutil.GenerateRandomAvatar(u.ID, u.Name, u.Email); err != nil {
 				log.Error("Failed to generate random avatar [user_id: %d]: %v", u.ID, err)
@@ -1467,15 +2321,6 @@ func (u *User) IsFollowing(followID int64) bool {
 	return Users.IsFollowing(context.TODO(), u.ID, followID)
 }
 
-// IsUserOrgOwner returns true if the user is in the owner team of the given
-// organization.
-//
-// TODO(unknwon): This is also used in templates, which should be fixed by
-// having a dedicated type `template.User`.
-func (u *User) IsUserOrgOwner(orgId int64) bool {
-	return IsOrganizationOwner(orgId, u.ID)
-}
-
 // IsPublicMember returns true if the user has public membership of the given
 // organization.
 //
@@ -1629,9 +2474,52 @@ type EmailAddress struct {
 	IsPrimary   bool   `xorm:"-" gorm:"-" json:"-"`
 }
 
+// FailedLogin represents failed login attempts of a username from an IP address
+// within the current lockout window.
+type FailedLogin struct {
+	ID              int64  `gorm:"primaryKey"`
+	LowerName       string `gorm:"type:VARCHAR(255);uniqueIndex:failed_login_name_ip_unique;not null"`
+	IP              string `gorm:"type:VARCHAR(64);uniqueIndex:failed_login_name_ip_unique;not null"`
+	NumAttempts     int    `gorm:"not null;default:0"`
+	WindowStartUnix int64  `gorm:"not null"`
+}
+
 // Follow represents relations of users and their followers.
 type Follow struct {
 	ID       int64 `gorm:"primaryKey"`
 	UserID   int64 `xorm:"UNIQUE(follow)" gorm:"uniqueIndex:follow_user_follow_unique;not null"`
 	FollowID int64 `xorm:"UNIQUE(follow)" gorm:"uniqueIndex:follow_user_follow_unique;not null"`
 }
+
+// GPGKey is a GPG public key of a user for verifying commit signatures.
+type GPGKey struct {
+	ID      int64 `gorm:"primaryKey"`
+	OwnerID int64 `gorm:"index;not null"`
+	// The long key ID of the primary key in hexadecimal form.
+	KeyID string `gorm:"type:VARCHAR(16);unique;not null"`
+	// The comma-separated list of emails of the key that have been verified by
+	// the owner.
+	Emails string `gorm:"type:TEXT;not null"`
+	// The armored public key.
+	Content     string `gorm:"type:TEXT;not null"`
+	CreatedUnix int64  `gorm:"not null"`
+}
+
+// hasEmail returns true if the given email is one of the verified emails of the
+// key.
+func (k *GPGKey) hasEmail(email string) bool {
+	for _, e := range strings.Split(k.Emails, ",") {
+		if e == email {
+			return true
+		}
+	}
+	return false
+}
+
+// BeforeCreate implements the GORM create hook.
+func (k *GPGKey) BeforeCreate(tx *gorm.DB) error {
+	if k.CreatedUnix == 0 {
+		k.CreatedUnix = tx.NowFunc().Unix()
+	}
+	return nil
+}
@@ -5,6 +5,7 @@
 package db
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
@@ -15,15 +16,18 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	"golang.org/x/crypto/openpgp/packet"
 	"gorm.io/gorm"
 
 	"gogs.io/gogs/internal/auth"
 	"gogs.io/gogs/internal/conf"
 	"gogs.io/gogs/internal/dbtest"
-	"gogs.io/gogs/internal/dbutil"
 	"gogs.io/gogs/internal/errutil"
 	"gogs.io/gogs/internal/osutil"
 	"gogs.io/gogs/internal/repoutil"
+	"gogs.io/gogs/internal/tool"
 	"gogs.io/gogs/internal/userutil"
 	"gogs.io/gogs/public"
 )
@@ -87,7 +91,8 @@ func TestUsers(t *testing.T) {
 	tables := []any{
 		new(User), new(EmailAddress), new(Repository), new(Follow), new(PullRequest), new(PublicKey), new(OrgUser),
 		new(Watch), new(Star), new(Issue), new(AccessToken), new(Collaboration), new(Action), new(IssueUser),
-		new(Access),
+		new(Access), new(Team), new(TeamUser), new(TeamRepo), new(FailedLogin), new(TwoFactor), new(AuditLog),
+		new(GPGKey),
 	}
 	db := &users{
 		DB: dbtest.NewDB(t, "users", tables...),
@@ -100,31 +105,42 @@ func TestUsers(t *testing.T) {
 		{"Authenticate", usersAuthenticate},
 		{"ChangeUsername", usersChangeUsername},
 		{"Count", usersCount},
+		{"CheckStorageQuota", usersCheckStorageQuota},
 		{"Create", usersCreate},
 		{"DeleteCustomAvatar", usersDeleteCustomAvatar},
 		{"DeleteByID", usersDeleteByID},
 		{"DeleteInactivated", usersDeleteInactivated},
 		{"GetByEmail", usersGetByEmail},
+		{"GetByEmails", usersGetByEmails},
 		{"GetByID", usersGetByID},
 		{"GetByUsername", usersGetByUsername},
-		{"GetByKeyID", usersGetByKeyID},
+		{"GetByPublicKeyID", usersGetByPublicKeyID},
 		{"GetMailableEmailsByUsernames", usersGetMailableEmailsByUsernames},
 		{"IsUsernameUsed", usersIsUsernameUsed},
 		{"List", usersList},
 		{"ListFollowers", usersListFollowers},
 		{"ListFollowings", usersListFollowings},
 		{"SearchByName", usersSearchByName},
+		{"Search", usersSearch},
+		{"ListWithoutTwoFactor", usersListWithoutTwoFactor},
 		{"Update", usersUpdate},
 		{"UseCustomAvatar", usersUseCustomAvatar},
+		{"AvatarURL", usersAvatarURL},
+		{"InvalidateSessions", usersInvalidateSessions},
 		{"AddEmail", usersAddEmail},
 		{"GetEmail", usersGetEmail},
 		{"ListEmails", usersListEmails},
 		{"MarkEmailActivated", usersMarkEmailActivated},
 		{"MarkEmailPrimary", usersMarkEmailPrimary},
 		{"DeleteEmail", usersDeleteEmail},
+		{"AddGPGKey", usersAddGPGKey},
+		{"ListGPGKeys", usersListGPGKeys},
+		{"DeleteGPGKey", usersDeleteGPGKey},
+		{"VerifyCommitSignature", usersVerifyCommitSignature},
 		{"Follow", usersFollow},
 		{"IsFollowing", usersIsFollowing},
 		{"Unfollow", usersUnfollow},
+		{"RecordFailedLogin", usersRecordFailedLogin},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Cleanup(func() {
@@ -172,6 +188,11 @@ func usersAuthenticate(t *testing.T, db *users) {
 		user, err := db.Authenticate(ctx, alice.Name, password, -1)
 		require.NoError(t, err)
 		assert.Equal(t, alice.Name, user.Name)
+
+		// The time of last login is recorded
+		got, err := db.GetByID(ctx, alice.ID)
+		require.NoError(t, err)
+		assert.Equal(t, db.NowFunc().Unix(), got.LastLoginUnix)
 	})
 
 	t.Run("login source mismatch", func(t *testing.T) {
@@ -234,6 +255,54 @@ func usersAuthenticate(t *testing.T, db *users) {
 		require.NoError(t, err)
 		assert.Equal(t, "cindy@example.com", user.Email)
 	})
+
+	t.Run("sync teams via login source", func(t *testing.T) {
+		teamsStore := &teams{DB: db.DB}
+		org1, _ := createTeamsTestOrg(t, teamsStore, "org1")
+		team1 := createTestTeam(t, db.DB, &Team{OrgID: org1.ID, Name: "team1", Authorize: AccessModeRead}, nil, nil)
+		team2 := createTestTeam(t, db.DB, &Team{OrgID: org1.ID, Name: "team2", Authorize: AccessModeRead}, nil, nil)
+		team3 := createTestTeam(t, db.DB, &Team{OrgID: org1.ID, Name: "team3", Authorize: AccessModeRead}, nil, nil)
+
+		dave, err := db.Create(ctx, "dave", "dave@example.com",
+			CreateUserOptions{
+				Password:    password,
+				LoginSource: 1,
+			},
+		)
+		require.NoError(t, err)
+		err = teamsStore.AddTeamMember(ctx, team2.ID, dave.ID)
+		require.NoError(t, err)
+		err = teamsStore.AddTeamMember(ctx, team3.ID, dave.ID)
+		require.NoError(t, err)
+
+		mockLoginSources := NewMockLoginSourcesStore()
+		mockLoginSources.GetByIDFunc.SetDefaultHook(func(ctx context.Context, id int64) (*LoginSource, error) {
+			mockProvider := NewMockProvider()
+			mockProvider.AuthenticateFunc.SetDefaultReturn(
+				&auth.ExternalAccount{
+					Teams: map[int64]bool{
+						team1.ID: true,
+						team2.ID: false,
+						404:      true, // Non-existent team should be skipped
+					},
+				},
+				nil,
+			)
+			s := &LoginSource{
+				IsActived: true,
+				Provider:  mockProvider,
+			}
+			return s, nil
+		})
+		setMockLoginSourcesStore(t, mockLoginSources)
+
+		_, err = db.Authenticate(ctx, dave.Email, password, 1)
+		require.NoError(t, err)
+		assert.True(t, teamsStore.IsTeamMember(ctx, team1.ID, dave.ID))
+		assert.False(t, teamsStore.IsTeamMember(ctx, team2.ID, dave.ID))
+		// Teams that are not mapped should be left untouched
+		assert.True(t, teamsStore.IsTeamMember(ctx, team3.ID, dave.ID))
+	})
 }
 
 func usersChangeUsername(t *testing.T, db *users) {
@@ -381,18 +450,65 @@ func usersCount(t *testing.T, db *users) {
 	assert.Equal(t, int64(1), got)
 
 	// Create an organization shouldn't count
-	// TODO: Use Orgs.Create to replace SQL hack when the method is available.
-	org1, err := db.Create(ctx, "org1", "org1@example.com", CreateUserOptions{})
-	require.NoError(t, err)
-	err = db.Exec(
-		dbutil.Quote("UPDATE %s SET type = ? WHERE id = ?", "user"),
-		UserTypeOrganization, org1.ID,
-	).Error
-	require.NoError(t, err)
+	createTestOrg(t, db.DB, "org1", CreateUserOptions{})
 	got = db.Count(ctx)
 	assert.Equal(t, int64(1), got)
 }
 
+func usersCheckStorageQuota(t *testing.T, db *users) {
+	ctx := context.Background()
+
+	alice, err := db.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := db.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	reposStore := NewReposStore(db.DB)
+	for _, name := range []string{"repo1", "repo2"} {
+		repo, err := reposStore.Create(ctx, alice.ID, CreateRepoOptions{Name: name})
+		require.NoError(t, err)
+		err = reposStore.UpdateSize(ctx, repo.ID, 1024*1024)
+		require.NoError(t, err)
+	}
+
+	// Soft-deleted repositories do not count towards the quota
+	repo3, err := reposStore.Create(ctx, alice.ID, CreateRepoOptions{Name: "repo3"})
+	require.NoError(t, err)
+	err = reposStore.UpdateSize(ctx, repo3.ID, 1024*1024)
+	require.NoError(t, err)
+	err = reposStore.SoftDelete(ctx, repo3.ID)
+	require.NoError(t, err)
+
+	t.Run("no limit", func(t *testing.T) {
+		conf.SetMockRepository(t, conf.RepositoryOpts{MaxOwnerStorageSize: -1})
+		err := db.CheckStorageQuota(ctx, alice.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("within limit", func(t *testing.T) {
+		conf.SetMockRepository(t, conf.RepositoryOpts{MaxOwnerStorageSize: 3})
+		err := db.CheckStorageQuota(ctx, alice.ID)
+		require.NoError(t, err)
+
+		// Users without any repository are always within the limit
+		err = db.CheckStorageQuota(ctx, bob.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("limit reached", func(t *testing.T) {
+		conf.SetMockRepository(t, conf.RepositoryOpts{MaxOwnerStorageSize: 2})
+		err := db.CheckStorageQuota(ctx, alice.ID)
+		wantErr := ErrStorageQuotaExceeded{
+			args: errutil.Args{
+				"userID": alice.ID,
+				"size":   int64(2 * 1024 * 1024),
+				"limit":  int64(2 * 1024 * 1024),
+			},
+		}
+		assert.Equal(t, wantErr, err)
+	})
+}
+
 func usersCreate(t *testing.T, db *users) {
 	ctx := context.Background()
 
@@ -437,6 +553,20 @@ func usersCreate(t *testing.T, db *users) {
 		assert.Equal(t, wantErr, err)
 	})
 
+	t.Run("password policy", func(t *testing.T) {
+		conf.SetMockAuth(t, conf.AuthOpts{PasswordMinLength: 8, PasswordRejectCommon: true})
+
+		_, err := db.Create(ctx, "bob", "bob@example.com", CreateUserOptions{Password: "password"})
+		wantErr := ErrPasswordPolicy{args: errutil.Args{"violations": []string{userutil.PasswordRuleCommon}}}
+		assert.Equal(t, wantErr, err)
+
+		// Passwords of users from external login sources are not checked
+		bob, err := db.Create(ctx, "bob", "bob@example.com", CreateUserOptions{Password: "short", LoginSource: 1})
+		require.NoError(t, err)
+		err = db.DeleteByID(ctx, bob.ID, true)
+		require.NoError(t, err)
+	})
+
 	user, err := db.GetByUsername(ctx, alice.Name)
 	require.NoError(t, err)
 	assert.Equal(t, db.NowFunc().Format(time.RFC3339), user.Created.UTC().Format(time.RFC3339))
@@ -499,14 +629,7 @@ func usersDeleteByID(t *testing.T, db *users) {
 		bob, err := db.Create(ctx, "bob", "bob@exmaple.com", CreateUserOptions{})
 		require.NoError(t, err)
 
-		// TODO: Use Orgs.Create to replace SQL hack when the method is available.
-		org1, err := db.Create(ctx, "org1", "org1@example.com", CreateUserOptions{})
-		require.NoError(t, err)
-		err = db.Exec(
-			dbutil.Quote("UPDATE %s SET type = ? WHERE id IN (?)", "user"),
-			UserTypeOrganization, org1.ID,
-		).Error
-		require.NoError(t, err)
+		org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
 
 		// TODO: Use Orgs.Join to replace SQL hack when the method is available.
 		err = db.Exec(`INSERT INTO org_user (uid, org_id) VALUES (?, ?)`, bob.ID, org1.ID).Error
@@ -703,14 +826,7 @@ func usersDeleteInactivated(t *testing.T, db *users) {
 	// User with organization membership should be skipped
 	bob, err := db.Create(ctx, "bob", "bob@exmaple.com", CreateUserOptions{})
 	require.NoError(t, err)
-	// TODO: Use Orgs.Create to replace SQL hack when the method is available.
-	org1, err := db.Create(ctx, "org1", "org1@example.com", CreateUserOptions{})
-	require.NoError(t, err)
-	err = db.Exec(
-		dbutil.Quote("UPDATE %s SET type = ? WHERE id IN (?)", "user"),
-		UserTypeOrganization, org1.ID,
-	).Error
-	require.NoError(t, err)
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
 	// TODO: Use Orgs.Join to replace SQL hack when the method is available.
 	err = db.Exec(`INSERT INTO org_user (uid, org_id) VALUES (?, ?)`, bob.ID, org1.ID).Error
 	require.NoError(t, err)
@@ -748,14 +864,9 @@ func usersGetByEmail(t *testing.T, db *users) {
 	})
 
 	t.Run("ignore organization", func(t *testing.T) {
-		// TODO: Use Orgs.Create to replace SQL hack when the method is available.
-		org, err := db.Create(ctx, "gogs", "gogs@exmaple.com", CreateUserOptions{})
-		require.NoError(t, err)
+		org := createTestOrg(t, db.DB, "gogs", CreateUserOptions{})
 
-		err = db.Model(&User{}).Where("id", org.ID).UpdateColumn("type", UserTypeOrganization).Error
-		require.NoError(t, err)
-
-		_, err = db.GetByEmail(ctx, org.Email)
+		_, err := db.GetByEmail(ctx, org.Email)
 		wantErr := ErrUserNotExist{args: errutil.Args{"email": org.Email}}
 		assert.Equal(t, wantErr, err)
 	})
@@ -801,6 +912,55 @@ func usersGetByEmail(t *testing.T, db *users) {
 	})
 }
 
+func usersGetByEmails(t *testing.T, db *users) {
+	ctx := context.Background()
+
+	got, err := db.GetByEmails(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	alice, err := db.Create(ctx, "alice", "alice@example.com", CreateUserOptions{Activated: true})
+	require.NoError(t, err)
+	bob, err := db.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	org, err := db.Create(ctx, "gogs", "gogs@example.com", CreateUserOptions{Activated: true})
+	require.NoError(t, err)
+	err = db.Model(&User{}).Where("id", org.ID).UpdateColumn("type", UserTypeOrganization).Error
+	require.NoError(t, err)
+
+	err = db.AddEmail(ctx, alice.ID, "alice2@example.com", true)
+	require.NoError(t, err)
+	err = db.AddEmail(ctx, alice.ID, "alice3@example.com", false)
+	require.NoError(t, err)
+	err = db.AddEmail(ctx, bob.ID, "bob2@example.com", true)
+	require.NoError(t, err)
+
+	got, err = db.GetByEmails(ctx,
+		[]string{
+			"Alice@Example.com",
+			"alice2@example.com",
+			"alice3@example.com", // Unverified secondary email
+			"bob@example.com",    // Unverified primary email
+			"BOB2@example.com",
+			"gogs@example.com", // Organization
+			"404@example.com",
+		},
+	)
+	require.NoError(t, err)
+
+	gotIDs := make(map[string]int64, len(got))
+	for email, user := range got {
+		gotIDs[email] = user.ID
+	}
+	wantIDs := map[string]int64{
+		"alice@example.com":  alice.ID,
+		"alice2@example.com": alice.ID,
+		"bob2@example.com":   bob.ID,
+	}
+	assert.Equal(t, wantIDs, gotIDs)
+	assert.Equal(t, "alice", got["alice@example.com"].Name)
+}
+
 func usersGetByID(t *testing.T, db *users) {
 	ctx := context.Background()
 
@@ -831,7 +991,7 @@ func usersGetByUsername(t *testing.T, db *users) {
 	assert.Equal(t, wantErr, err)
 }
 
-func usersGetByKeyID(t *testing.T, db *users) {
+func usersGetByPublicKeyID(t *testing.T, db *users) {
 	ctx := context.Background()
 
 	alice, err := db.Create(ctx, "alice", "alice@exmaple.com", CreateUserOptions{})
@@ -849,13 +1009,31 @@ func usersGetByKeyID(t *testing.T, db *users) {
 	err = db.WithContext(ctx).Create(publicKey).Error
 	require.NoError(t, err)
 
-	user, err := db.GetByKeyID(ctx, publicKey.ID)
+	user, err := db.GetByPublicKeyID(ctx, publicKey.ID)
 	require.NoError(t, err)
 	assert.Equal(t, alice.Name, user.Name)
 
-	_, err = db.GetByKeyID(ctx, publicKey.ID+1)
-	wantErr := ErrUserNotExist{args: errutil.Args{"keyID": publicKey.ID + 1}}
+	_, err = db.GetByPublicKeyID(ctx, publicKey.ID+100)
+	wantErr := ErrUserNotExist{args: errutil.Args{"keyID": publicKey.ID + 100}}
 	assert.Equal(t, wantErr, err)
+
+	t.Run("deploy key", func(t *testing.T) {
+		deployKey := &PublicKey{
+			OwnerID:     alice.ID,
+			Name:        "deploy-key",
+			Fingerprint: "8d:3a:1c:47:0e:b5:62:f9:71:aa:20:6c:d4:19:e3:5b",
+			Content:     "deploy-key-content",
+			Type:        KEY_TYPE_DEPLOY,
+			CreatedUnix: db.NowFunc().Unix(),
+			UpdatedUnix: db.NowFunc().Unix(),
+		}
+		err = db.WithContext(ctx).Create(deployKey).Error
+		require.NoError(t, err)
+
+		_, err = db.GetByPublicKeyID(ctx, deployKey.ID)
+		wantErr := ErrUserNotExist{args: errutil.Args{"keyID": deployKey.ID}}
+		assert.Equal(t, wantErr, err)
+	})
 }
 
 func usersGetMailableEmailsByUsernames(t *testing.T, db *users) {
@@ -917,10 +1095,17 @@ func usersIsUsernameUsed(t *testing.T, db *users) {
 			excludeUserID: 0,
 			want:          true,
 		},
+		{
+			name:          "reserved",
+			username:      "Admin",
+			excludeUserID: 0,
+			want:          true,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			got := db.IsUsernameUsed(ctx, test.username, test.excludeUserID)
+			got, err := db.IsUsernameUsed(ctx, test.username, test.excludeUserID)
+			require.NoError(t, err)
 			assert.Equal(t, test.want, got)
 		})
 	}
@@ -935,14 +1120,7 @@ func usersList(t *testing.T, db *users) {
 	require.NoError(t, err)
 
 	// Create an organization shouldn't count
-	// TODO: Use Orgs.Create to replace SQL hack when the method is available.
-	org1, err := db.Create(ctx, "org1", "org1@example.com", CreateUserOptions{})
-	require.NoError(t, err)
-	err = db.Exec(
-		dbutil.Quote("UPDATE %s SET type = ? WHERE id = ?", "user"),
-		UserTypeOrganization, org1.ID,
-	).Error
-	require.NoError(t, err)
+	createTestOrg(t, db.DB, "org1", CreateUserOptions{})
 
 	got, err := db.List(ctx, 1, 1)
 	require.NoError(t, err)
@@ -1067,6 +1245,138 @@ func usersSearchByName(t *testing.T, db *users) {
 	})
 }
 
+func usersSearch(t *testing.T, db *users) {
+	ctx := context.Background()
+
+	alice, err := db.Create(ctx, "alice", "alice@example.com", CreateUserOptions{FullName: "Alice Jordan", Activated: true, Admin: true})
+	require.NoError(t, err)
+	bob, err := db.Create(ctx, "bob", "bob@example.com", CreateUserOptions{FullName: "Bob Jordan", LoginSource: 1})
+	require.NoError(t, err)
+	cindy, err := db.Create(ctx, "cindy", "cindy@example.com", CreateUserOptions{Activated: true, LoginSource: 2})
+	require.NoError(t, err)
+
+	// Create an organization which should never be included in results
+	createTestOrg(t, db.DB, "org1", CreateUserOptions{FullName: "Org Jordan"})
+
+	mockLoginSources := NewMockLoginSourcesStore()
+	mockLoginSources.ListFunc.SetDefaultReturn(
+		[]*LoginSource{
+			{ID: 1, Type: auth.LDAP},
+			{ID: 2, Type: auth.GitHub},
+		},
+		nil,
+	)
+	setMockLoginSourcesStore(t, mockLoginSources)
+
+	userIDs := func(users []*User) []int64 {
+		ids := make([]int64, 0, len(users))
+		for _, u := range users {
+			ids = append(ids, u.ID)
+		}
+		return ids
+	}
+	isTrue := true
+	isFalse := false
+	localSource := int64(0)
+
+	tests := []struct {
+		name      string
+		opts      SearchUsersOptions
+		wantIDs   []int64
+		wantCount int64
+	}{
+		{
+			name:      "no filters",
+			wantIDs:   []int64{alice.ID, bob.ID, cindy.ID},
+			wantCount: 3,
+		},
+		{
+			name:      "keyword",
+			opts:      SearchUsersOptions{Keyword: "Jo"},
+			wantIDs:   []int64{alice.ID, bob.ID},
+			wantCount: 2,
+		},
+		{
+			name:      "activated",
+			opts:      SearchUsersOptions{IsActive: &isTrue},
+			wantIDs:   []int64{alice.ID, cindy.ID},
+			wantCount: 2,
+		},
+		{
+			name:      "non-admins",
+			opts:      SearchUsersOptions{IsAdmin: &isFalse},
+			wantIDs:   []int64{bob.ID, cindy.ID},
+			wantCount: 2,
+		},
+		{
+			name:      "local accounts",
+			opts:      SearchUsersOptions{LoginSource: &localSource},
+			wantIDs:   []int64{alice.ID},
+			wantCount: 1,
+		},
+		{
+			name:      "login type",
+			opts:      SearchUsersOptions{LoginType: auth.GitHub},
+			wantIDs:   []int64{cindy.ID},
+			wantCount: 1,
+		},
+		{
+			name:      "login type without sources",
+			opts:      SearchUsersOptions{LoginType: auth.PAM},
+			wantIDs:   []int64{},
+			wantCount: 0,
+		},
+		{
+			name:      "order by lower_name DESC",
+			opts:      SearchUsersOptions{OrderBy: "lower_name DESC"},
+			wantIDs:   []int64{cindy.ID, bob.ID, alice.ID},
+			wantCount: 3,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			users, count, err := db.Search(ctx, 1, 10, test.opts)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantIDs, userIDs(users))
+			assert.Equal(t, test.wantCount, count)
+		})
+	}
+
+	t.Run("paginate", func(t *testing.T) {
+		users, count, err := db.Search(ctx, 2, 2, SearchUsersOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{cindy.ID}, userIDs(users))
+		assert.Equal(t, int64(3), count)
+	})
+
+	t.Run("order not allowed", func(t *testing.T) {
+		_, _, err := db.Search(ctx, 1, 10, SearchUsersOptions{OrderBy: "passwd ASC"})
+		assert.Error(t, err)
+	})
+}
+
+func usersListWithoutTwoFactor(t *testing.T, db *users) {
+	ctx := context.Background()
+
+	alice, err := db.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	bob, err := db.Create(ctx, "bob", "bob@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	cindy, err := db.Create(ctx, "cindy", "cindy@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	err = db.DB.Create(&TwoFactor{UserID: bob.ID, Secret: "secret"}).Error
+	require.NoError(t, err)
+
+	got, err := db.ListWithoutTwoFactor(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	got, err = db.ListWithoutTwoFactor(ctx, []int64{cindy.ID, bob.ID, alice.ID, cindy.ID})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{cindy.ID, alice.ID}, got)
+}
+
 func usersUpdate(t *testing.T, db *users) {
 	ctx := context.Background()
 
@@ -1105,6 +1415,15 @@ func usersUpdate(t *testing.T, db *users) {
 		assert.True(t, got, "New password should work")
 	})
 
+	t.Run("update password not complying with the policy", func(t *testing.T) {
+		conf.SetMockAuth(t, conf.AuthOpts{PasswordMinLength: 8})
+
+		newPassword := "short"
+		err := db.Update(ctx, alice.ID, UpdateUserOptions{Password: &newPassword})
+		assert.True(t, IsErrPasswordPolicy(err))
+		assert.Equal(t, []string{userutil.PasswordRuleMinLength}, err.(ErrPasswordPolicy).Violations())
+	})
+
 	t.Run("update email but already used", func(t *testing.T) {
 		bob, err := db.Create(
 			ctx,
@@ -1207,6 +1526,79 @@ func usersUseCustomAvatar(t *testing.T, db *users) {
 	assert.True(t, alice.UseCustomAvatar)
 }
 
+func usersAvatarURL(t *testing.T, db *users) {
+	ctx := context.Background()
+	conf.SetMockServer(t, conf.ServerOpts{ExternalURL: "https://gogs.example.com/"})
+
+	alice, err := db.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	hash := tool.HashEmail(alice.AvatarEmail)
+
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{
+			source: conf.AvatarSourceGravatar,
+			want:   "https://secure.gravatar.com/avatar/" + hash + "?d=identicon&s=48",
+		},
+		{
+			// Falls back to the Gravatar-like service without federated lookup.
+			source: conf.AvatarSourceLibravatar,
+			want:   "https://secure.gravatar.com/avatar/" + hash + "?d=identicon&s=48",
+		},
+		{
+			source: conf.AvatarSourceInternal,
+			want:   "https://gogs.example.com/img/avatar_default.png?s=48",
+		},
+		{
+			source: conf.AvatarSourceDisabled,
+			want:   fmt.Sprintf("https://gogs.example.com/%s/%d?s=48", conf.UsersAvatarPathPrefix, alice.ID),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			conf.SetMockPicture(t,
+				conf.PictureOpts{
+					AvatarUploadPath: t.TempDir(),
+					GravatarSource:   "https://secure.gravatar.com/avatar/",
+					AvatarSource:     test.source,
+				},
+			)
+			assert.Equal(t, test.want, db.AvatarURL(alice, 48))
+		})
+	}
+
+	t.Run("identicon is generated when disabled", func(t *testing.T) {
+		conf.SetMockPicture(t,
+			conf.PictureOpts{
+				AvatarUploadPath: t.TempDir(),
+				AvatarSource:     conf.AvatarSourceDisabled,
+			},
+		)
+		_ = db.AvatarURL(alice, 0)
+		assert.True(t, osutil.IsFile(userutil.CustomAvatarPath(alice.ID)))
+	})
+
+	t.Run("custom avatar", func(t *testing.T) {
+		conf.SetMockPicture(t,
+			conf.PictureOpts{
+				AvatarUploadPath: t.TempDir(),
+				AvatarSource:     conf.AvatarSourceGravatar,
+			},
+		)
+		avatar, err := public.Files.ReadFile("img/avatar_default.png")
+		require.NoError(t, err)
+		err = db.UseCustomAvatar(ctx, alice.ID, avatar)
+		require.NoError(t, err)
+		alice, err := db.GetByID(ctx, alice.ID)
+		require.NoError(t, err)
+
+		want := fmt.Sprintf("https://gogs.example.com/%s/%d", conf.UsersAvatarPathPrefix, alice.ID)
+		assert.Equal(t, want, db.AvatarURL(alice, 0))
+	})
+}
+
 func TestIsUsernameAllowed(t *testing.T) {
 	for name := range reservedUsernames {
 		t.Run(name, func(t *testing.T) {
@@ -1377,6 +1769,198 @@ func usersDeleteEmail(t *testing.T, db *users) {
 	require.Equal(t, want, got)
 }
 
+func newTestGPGEntity(t *testing.T, name, email string) *openpgp.Entity {
+	t.Helper()
+	entity, err := openpgp.NewEntity(name, "", email, &packet.Config{RSABits: 1024})
+	require.NoError(t, err)
+	return entity
+}
+
+func armoredGPGPublicKey(t *testing.T, entity *openpgp.Entity) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+	return buf.String()
+}
+
+// signedCommit returns a raw commit object authored by the given email and
+// signed by the entity.
+func signedCommit(t *testing.T, entity *openpgp.Entity, email string) []byte {
+	t.Helper()
+	header := fmt.Sprintf(`tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904
+author Alice <%[1]s> 1588568886 +0000
+committer Alice <%[1]s> 1588568886 +0000
+`, email)
+	message := "Initial commit\n"
+
+	var signature bytes.Buffer
+	err := openpgp.ArmoredDetachSign(&signature, entity, strings.NewReader(header+"\n"+message), nil)
+	require.NoError(t, err)
+	gpgsig := "gpgsig " + strings.ReplaceAll(strings.TrimSpace(signature.String()), "\n", "\n ") + "\n"
+	return []byte(header + gpgsig + "\n" + message)
+}
+
+func usersAddGPGKey(t *testing.T, db *users) {
+	ctx := context.Background()
+	alice, err := db.Create(ctx, "alice", "alice@example.com", CreateUserOptions{Activated: true})
+	require.NoError(t, err)
+	_, err = db.Create(ctx, "bob", "bob@example.com", CreateUserOptions{Activated: true})
+	require.NoError(t, err)
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := db.AddGPGKey(ctx, alice.ID, "not a key")
+		assert.True(t, IsErrGPGKeyInvalid(err))
+	})
+
+	t.Run("private key", func(t *testing.T) {
+		entity := newTestGPGEntity(t, "Alice", "alice@example.com")
+		var buf bytes.Buffer
+		w, err := armor.Encode(&buf, openpgp.PrivateKeyType, nil)
+		require.NoError(t, err)
+		require.NoError(t, entity.SerializePrivate(w, nil))
+		require.NoError(t, w.Close())
+
+		_, err = db.AddGPGKey(ctx, alice.ID, buf.String())
+		assert.True(t, IsErrGPGKeyInvalid(err))
+	})
+
+	t.Run("email not verified", func(t *testing.T) {
+		err := db.AddEmail(ctx, alice.ID, "alice2@example.com", false)
+		require.NoError(t, err)
+
+		for _, email := range []string{"alice2@example.com", "bob@example.com", "cindy@example.com"} {
+			entity := newTestGPGEntity(t, "Alice", email)
+			_, err := db.AddGPGKey(ctx, alice.ID, armoredGPGPublicKey(t, entity))
+			assert.True(t, IsErrGPGKeyEmailNotVerified(err), email)
+		}
+	})
+
+	entity := newTestGPGEntity(t, "Alice", "Alice@example.com")
+	key, err := db.AddGPGKey(ctx, alice.ID, armoredGPGPublicKey(t, entity))
+	require.NoError(t, err)
+	assert.Equal(t, alice.ID, key.OwnerID)
+	assert.Equal(t, entity.PrimaryKey.KeyIdString(), key.KeyID)
+	assert.Equal(t, "alice@example.com", key.Emails)
+	assert.NotZero(t, key.CreatedUnix)
+
+	// Should fail for adding the same key again
+	_, err = db.AddGPGKey(ctx, alice.ID, key.Content)
+	assert.True(t, IsErrGPGKeyAlreadyExist(err))
+}
+
+func usersListGPGKeys(t *testing.T, db *users) {
+	ctx := context.Background()
+	alice, err := db.Create(ctx, "alice", "alice@example.com", CreateUserOptions{Activated: true})
+	require.NoError(t, err)
+	bob, err := db.Create(ctx, "bob", "bob@example.com", CreateUserOptions{Activated: true})
+	require.NoError(t, err)
+
+	key1, err := db.AddGPGKey(ctx, alice.ID, armoredGPGPublicKey(t, newTestGPGEntity(t, "Alice", "alice@example.com")))
+	require.NoError(t, err)
+	key2, err := db.AddGPGKey(ctx, alice.ID, armoredGPGPublicKey(t, newTestGPGEntity(t, "Alice", "alice@example.com")))
+	require.NoError(t, err)
+	_, err = db.AddGPGKey(ctx, bob.ID, armoredGPGPublicKey(t, newTestGPGEntity(t, "Bob", "bob@example.com")))
+	require.NoError(t, err)
+
+	keys, err := db.ListGPGKeys(ctx, alice.ID)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, key1.ID, keys[0].ID)
+	assert.Equal(t, key2.ID, keys[1].ID)
+}
+
+func usersDeleteGPGKey(t *testing.T, db *users) {
+	ctx := context.Background()
+	alice, err := db.Create(ctx, "alice", "alice@example.com", CreateUserOptions{Activated: true})
+	require.NoError(t, err)
+	bob, err := db.Create(ctx, "bob", "bob@example.com", CreateUserOptions{Activated: true})
+	require.NoError(t, err)
+
+	key, err := db.AddGPGKey(ctx, alice.ID, armoredGPGPublicKey(t, newTestGPGEntity(t, "Alice", "alice@example.com")))
+	require.NoError(t, err)
+
+	// Should not be able to delete the key of another user
+	err = db.DeleteGPGKey(ctx, bob.ID, key.ID)
+	assert.True(t, IsErrGPGKeyNotExist(err))
+
+	err = db.DeleteGPGKey(ctx, alice.ID, key.ID)
+	require.NoError(t, err)
+	keys, err := db.ListGPGKeys(ctx, alice.ID)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func usersVerifyCommitSignature(t *testing.T, db *users) {
+	ctx := context.Background()
+	alice, err := db.Create(ctx, "alice", "alice@example.com", CreateUserOptions{Activated: true})
+	require.NoError(t, err)
+	_, err = db.Create(ctx, "bob", "bob@example.com", CreateUserOptions{Activated: true})
+	require.NoError(t, err)
+	err = db.AddEmail(ctx, alice.ID, "alice2@example.com", true)
+	require.NoError(t, err)
+
+	entity := newTestGPGEntity(t, "Alice", "alice@example.com")
+	key, err := db.AddGPGKey(ctx, alice.ID, armoredGPGPublicKey(t, entity))
+	require.NoError(t, err)
+
+	t.Run("unsigned", func(t *testing.T) {
+		commit := []byte("tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\nauthor Alice <alice@example.com> 1588568886 +0000\n\nInitial commit\n")
+		got, err := db.VerifyCommitSignature(ctx, commit)
+		require.NoError(t, err)
+		assert.Equal(t, CommitVerificationUnsigned, got.State)
+	})
+
+	t.Run("verified", func(t *testing.T) {
+		got, err := db.VerifyCommitSignature(ctx, signedCommit(t, entity, "alice@example.com"))
+		require.NoError(t, err)
+		assert.Equal(t, CommitVerificationVerified, got.State)
+		assert.Equal(t, alice.ID, got.Signer.ID)
+		assert.Equal(t, key.ID, got.Key.ID)
+	})
+
+	t.Run("bad signature", func(t *testing.T) {
+		commit := signedCommit(t, entity, "alice@example.com")
+		commit = bytes.Replace(commit, []byte("Initial commit"), []byte("Tampered commit"), 1)
+		got, err := db.VerifyCommitSignature(ctx, commit)
+		require.NoError(t, err)
+		assert.Equal(t, CommitVerificationBadSignature, got.State)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			commit []byte
+		}{
+			{
+				name:   "key of another user",
+				commit: signedCommit(t, entity, "bob@example.com"),
+			},
+			{
+				name:   "email not of the key",
+				commit: signedCommit(t, entity, "alice2@example.com"),
+			},
+			{
+				name:   "key not added",
+				commit: signedCommit(t, newTestGPGEntity(t, "Alice", "alice@example.com"), "alice@example.com"),
+			},
+			{
+				name:   "author not exist",
+				commit: signedCommit(t, entity, "cindy@example.com"),
+			},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				got, err := db.VerifyCommitSignature(ctx, test.commit)
+				require.NoError(t, err)
+				assert.Equal(t, CommitVerificationUnknownKey, got.State)
+			})
+		}
+	})
+}
+
 func usersFollow(t *testing.T, db *users) {
 	ctx := context.Background()
 
@@ -1451,3 +2035,72 @@ func usersUnfollow(t *testing.T, db *users) {
 	require.NoError(t, err)
 	assert.Equal(t, 0, bob.NumFollowers)
 }
+
+func usersRecordFailedLogin(t *testing.T, db *users) {
+	ctx := context.Background()
+
+	conf.SetMockSecurity(t,
+		conf.SecurityOpts{
+			LoginLockoutThreshold: 2,
+			LoginLockoutWindow:    time.Hour,
+		},
+	)
+
+	const ip = "127.0.0.1"
+	assert.False(t, db.IsLockedOut(ctx, "alice", ip))
+
+	err := db.RecordFailedLogin(ctx, "alice", ip)
+	require.NoError(t, err)
+	assert.False(t, db.IsLockedOut(ctx, "alice", ip))
+
+	err = db.RecordFailedLogin(ctx, "Alice", ip)
+	require.NoError(t, err)
+	assert.True(t, db.IsLockedOut(ctx, "alice", ip))
+
+	// Other IP addresses should not be affected
+	assert.False(t, db.IsLockedOut(ctx, "alice", "127.0.0.2"))
+
+	t.Run("lockout expires with the window", func(t *testing.T) {
+		err := db.Model(&FailedLogin{}).
+			Where("lower_name = ? AND ip = ?", "alice", ip).
+			Update("window_start_unix", db.NowFunc().Add(-time.Hour).Unix()).
+			Error
+		require.NoError(t, err)
+		assert.False(t, db.IsLockedOut(ctx, "alice", ip))
+
+		// A new window starts with the next attempt
+		err = db.RecordFailedLogin(ctx, "alice", ip)
+		require.NoError(t, err)
+		assert.False(t, db.IsLockedOut(ctx, "alice", ip))
+	})
+
+	t.Run("clear failed logins", func(t *testing.T) {
+		err := db.RecordFailedLogin(ctx, "alice", ip)
+		require.NoError(t, err)
+		assert.True(t, db.IsLockedOut(ctx, "alice", ip))
+
+		err = db.ClearFailedLogins(ctx, "alice", ip)
+		require.NoError(t, err)
+		assert.False(t, db.IsLockedOut(ctx, "alice", ip))
+	})
+}
+
+func usersInvalidateSessions(t *testing.T, db *users) {
+	ctx := context.Background()
+
+	err := db.InvalidateSessions(ctx, 404)
+	wantErr := ErrUserNotExist{args: errutil.Args{"userID": int64(404)}}
+	assert.Equal(t, wantErr, err)
+
+	alice, err := db.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	assert.Zero(t, alice.SessionEpoch)
+
+	err = db.InvalidateSessions(ctx, alice.ID)
+	require.NoError(t, err)
+
+	got, err := db.GetByID(ctx, alice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), got.SessionEpoch)
+	assert.NotEqual(t, alice.Rands, got.Rands)
+}
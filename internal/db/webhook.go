@@ -12,14 +12,18 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"path"
 	"strings"
 	"time"
 
 	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
 	gouuid "github.com/satori/go.uuid"
+	"gorm.io/gorm"
 	log "unknwon.dev/clog/v2"
 	"xorm.io/xorm"
 
+	"github.com/gogs/git-module"
 	api "github.com/gogs/go-gogs-client"
 
 	"gogs.io/gogs/internal/conf"
@@ -95,23 +99,26 @@ const (
 
 // Webhook represents a web hook object.
 type Webhook struct {
-	ID           int64
-	RepoID       int64
-	OrgID        int64
-	URL          string `xorm:"url TEXT"`
-	ContentType  HookContentType
-	Secret       string     `xorm:"TEXT"`
-	Events       string     `xorm:"TEXT"`
-	*HookEvent   `xorm:"-"` // LEGACY [1.0]: Cannot ignore JSON (i.e. json:"-") here, it breaks old backup archive
-	IsSSL        bool       `xorm:"is_ssl"`
-	IsActive     bool
-	HookTaskType HookTaskType
-	Meta         string     `xorm:"TEXT"` // store hook-specific attributes
-	LastStatus   HookStatus // Last delivery status
-
-	Created     time.Time `xorm:"-" json:"-"`
+	ID             int64
+	RepoID         int64
+	OrgID          int64
+	URL            string `xorm:"url TEXT"`
+	ContentType    HookContentType
+	Secret         string              `xorm:"TEXT"`
+	PreviousSecret string              `xorm:"TEXT"` // The secret before the last rotation
+	SecretKeyID    int64               // The ID of the current secret, increased on every rotation
+	Events         string              `xorm:"TEXT"`
+	*HookEvent     `xorm:"-" gorm:"-"` // LEGACY [1.0]: Cannot ignore JSON (i.e. json:"-") here, it breaks old backup archive
+	IsSSL          bool                `xorm:"is_ssl"`
+	IsActive       bool
+	HookTaskType   HookTaskType
+	Meta           string     `xorm:"TEXT"` // store hook-specific attributes
+	BranchFilter   string     `xorm:"TEXT"` // Glob pattern of branches for push and create/delete events, empty means all branches
+	LastStatus     HookStatus // Last delivery status
+
+	Created     time.Time `xorm:"-" json:"-" gorm:"-"`
 	CreatedUnix int64
-	Updated     time.Time `xorm:"-" json:"-"`
+	Updated     time.Time `xorm:"-" json:"-" gorm:"-"`
 	UpdatedUnix int64
 }
 
@@ -124,6 +131,28 @@ func (w *Webhook) BeforeUpdate() {
 	w.UpdatedUnix = time.Now().Unix()
 }
 
+// BeforeCreate implements the GORM create hook.
+func (w *Webhook) BeforeCreate(tx *gorm.DB) error {
+	if w.CreatedUnix == 0 {
+		w.CreatedUnix = tx.NowFunc().Unix()
+		w.UpdatedUnix = w.CreatedUnix
+	}
+	return nil
+}
+
+// AfterFind implements the GORM query hook.
+func (w *Webhook) AfterFind(_ *gorm.DB) error {
+	w.HookEvent = &HookEvent{}
+	if w.Events != "" {
+		if err := jsoniter.Unmarshal([]byte(w.Events), w.HookEvent); err != nil {
+			return errors.Wrap(err, "unmarshal events")
+		}
+	}
+	w.Created = time.Unix(w.CreatedUnix, 0).Local()
+	w.Updated = time.Unix(w.UpdatedUnix, 0).Local()
+	return nil
+}
+
 func (w *Webhook) AfterSet(colName string, _ xorm.Cell) {
 	var err error
 	switch colName {
@@ -147,9 +176,24 @@ func (w *Webhook) SlackMeta() *SlackMeta {
 	return s
 }
 
-// History returns history of webhook by given conditions.
-func (w *Webhook) History(page int) ([]*HookTask, error) {
-	return HookTasks(w.ID, page)
+// Signature returns the value of the "X-Gogs-Signature" header for the given
+// payload, which is the SHA256 HMAC hex digest of the payload signed with the
+// current secret. Once the secret has been rotated, the ID of the secret is
+// included as well (i.e. "keyid=<id>,sha256=<digest>") so receivers know which
+// secret signed the payload. It returns an empty string if the webhook has no
+// secret.
+func (w *Webhook) Signature(payload []byte) string {
+	if w.Secret == "" {
+		return ""
+	}
+
+	sig := hmac.New(sha256.New, []byte(w.Secret))
+	_, _ = sig.Write(payload)
+	digest := hex.EncodeToString(sig.Sum(nil))
+	if w.SecretKeyID <= 0 {
+		return digest
+	}
+	return fmt.Sprintf("keyid=%d,sha256=%s", w.SecretKeyID, digest)
 }
 
 // UpdateEvent handles conversion from HookEvent to Events.
@@ -207,6 +251,45 @@ func (w *Webhook) HasReleaseEvent() bool {
 		(w.ChooseEvents && w.HookEvents.Release)
 }
 
+// MatchesBranch returns true if the given branch matches the branch filter of
+// the webhook. An empty filter matches all branches.
+func (w *Webhook) MatchesBranch(branch string) bool {
+	if w.BranchFilter == "" {
+		return true
+	}
+
+	matched, err := path.Match(w.BranchFilter, branch)
+	if err != nil {
+		log.Error("Failed to match branch filter [webhook_id: %d, filter: %q]: %v", w.ID, w.BranchFilter, err)
+		return false
+	}
+	return matched
+}
+
+// payloadBranch returns the branch that the payload of push and create/delete
+// events refers to. It returns false if the payload is not about a branch, e.g.
+// an issue event or a tag is pushed.
+func payloadBranch(p api.Payloader) (string, bool) {
+	switch p := p.(type) {
+	case *api.PushPayload:
+		if !strings.HasPrefix(p.Ref, git.RefsHeads) {
+			return "", false
+		}
+		return strings.TrimPrefix(p.Ref, git.RefsHeads), true
+	case *api.CreatePayload:
+		if p.RefType != "branch" {
+			return "", false
+		}
+		return p.Ref, true
+	case *api.DeletePayload:
+		if p.RefType != "branch" {
+			return "", false
+		}
+		return p.Ref, true
+	}
+	return "", false
+}
+
 type eventChecker struct {
 	checker func() bool
 	typ     HookEventType
@@ -346,16 +429,10 @@ func DeleteWebhookOfOrgByID(orgID, id int64) error {
 	})
 }
 
-// GetWebhooksByOrgID returns all webhooks for an organization.
-func GetWebhooksByOrgID(orgID int64) (ws []*Webhook, err error) {
-	err = x.Find(&ws, &Webhook{OrgID: orgID})
-	return ws, err
-}
-
 // getActiveWebhooksByOrgID returns all active webhooks for an organization.
 func getActiveWebhooksByOrgID(e Engine, orgID int64) ([]*Webhook, error) {
 	ws := make([]*Webhook, 0, 3)
-	return ws, e.Where("org_id=?", orgID).And("is_active=?", true).Find(&ws)
+	return ws, e.Where("org_id = ? AND repo_id = 0", orgID).And("is_active = ?", true).Find(&ws)
 }
 
 //   ___ ___                __   ___________              __
@@ -417,8 +494,20 @@ const (
 	HOOK_EVENT_PULL_REQUEST  HookEventType = "pull_request"
 	HOOK_EVENT_ISSUE_COMMENT HookEventType = "issue_comment"
 	HOOK_EVENT_RELEASE       HookEventType = "release"
+	HOOK_EVENT_PING          HookEventType = "ping"
 )
 
+// PingPayload is the payload of the ping event, which is sent on demand to
+// verify the payload URL and secret of a webhook.
+type PingPayload struct {
+	HookID     int64           `json:"hook_id"`
+	Repository *api.Repository `json:"repository,omitempty"`
+}
+
+func (p *PingPayload) JSONPayload() ([]byte, error) {
+	return jsoniter.MarshalIndent(p, "", "  ")
+}
+
 // HookRequest represents hook task request information.
 type HookRequest struct {
 	Headers map[string]string `json:"headers"`
@@ -440,21 +529,25 @@ type HookTask struct {
 	Type            HookTaskType
 	URL             string `xorm:"TEXT"`
 	Signature       string `xorm:"TEXT"`
-	api.Payloader   `xorm:"-" json:"-"`
+	api.Payloader   `xorm:"-" json:"-" gorm:"-"`
 	PayloadContent  string `xorm:"TEXT"`
 	ContentType     HookContentType
 	EventType       HookEventType
 	IsSSL           bool
 	IsDelivered     bool
 	Delivered       int64
-	DeliveredString string `xorm:"-" json:"-"`
+	DeliveredString string `xorm:"-" json:"-" gorm:"-"`
+
+	// Retry info.
+	RetryCount   int   `xorm:"NOT NULL DEFAULT 0"`
+	DeliverAfter int64 `xorm:"NOT NULL DEFAULT 0"` // The Unix timestamp after which the task can be delivered.
 
 	// History info.
 	IsSucceed       bool
 	RequestContent  string        `xorm:"TEXT"`
-	RequestInfo     *HookRequest  `xorm:"-" json:"-"`
+	RequestInfo     *HookRequest  `xorm:"-" json:"-" gorm:"-"`
 	ResponseContent string        `xorm:"TEXT"`
-	ResponseInfo    *HookResponse `xorm:"-" json:"-"`
+	ResponseInfo    *HookResponse `xorm:"-" json:"-" gorm:"-"`
 }
 
 func (t *HookTask) BeforeUpdate() {
@@ -494,6 +587,25 @@ func (t *HookTask) AfterSet(colName string, _ xorm.Cell) {
 	}
 }
 
+// AfterFind implements the GORM query hook.
+func (t *HookTask) AfterFind(_ *gorm.DB) error {
+	t.DeliveredString = time.Unix(0, t.Delivered).Format("2006-01-02 15:04:05 MST")
+
+	if t.RequestContent != "" {
+		t.RequestInfo = &HookRequest{}
+		if err := jsoniter.Unmarshal([]byte(t.RequestContent), t.RequestInfo); err != nil {
+			log.Error("Unmarshal [%d]: %v", t.ID, err)
+		}
+	}
+	if t.ResponseContent != "" {
+		t.ResponseInfo = &HookResponse{}
+		if err := jsoniter.Unmarshal([]byte(t.ResponseContent), t.ResponseInfo); err != nil {
+			log.Error("Unmarshal [%d]: %v", t.ID, err)
+		}
+	}
+	return nil
+}
+
 func (t *HookTask) ToJSON(v any) string {
 	p, err := jsoniter.Marshal(v)
 	if err != nil {
@@ -502,23 +614,51 @@ func (t *HookTask) ToJSON(v any) string {
 	return string(p)
 }
 
-// HookTasks returns a list of hook tasks by given conditions.
-func HookTasks(hookID int64, page int) ([]*HookTask, error) {
-	tasks := make([]*HookTask, 0, conf.Webhook.PagingNum)
-	return tasks, x.Limit(conf.Webhook.PagingNum, (page-1)*conf.Webhook.PagingNum).Where("hook_id=?", hookID).Desc("id").Find(&tasks)
-}
+// newHookTask returns a new hook task of the webhook for the given event and
+// payload. The payload is converted to the format of the webhook type and signed
+// with the secret of the webhook.
+func newHookTask(repoID int64, w *Webhook, event HookEventType, p api.Payloader) (*HookTask, error) {
+	// Use separate objects so modifications won't be made on payload on non-Gogs type hooks.
+	var payloader api.Payloader
+	var err error
+	switch w.HookTaskType {
+	case SLACK:
+		payloader, err = GetSlackPayload(p, event, w.Meta)
+		if err != nil {
+			return nil, fmt.Errorf("GetSlackPayload: %v", err)
+		}
+	case DISCORD:
+		payloader, err = GetDiscordPayload(p, event, w.Meta)
+		if err != nil {
+			return nil, fmt.Errorf("GetDiscordPayload: %v", err)
+		}
+	case DINGTALK:
+		payloader, err = GetDingtalkPayload(p, event)
+		if err != nil {
+			return nil, fmt.Errorf("GetDingtalkPayload: %v", err)
+		}
+	default:
+		payloader = p
+	}
 
-// createHookTask creates a new hook task,
-// it handles conversion from Payload to PayloadContent.
-func createHookTask(e Engine, t *HookTask) error {
-	data, err := t.Payloader.JSONPayload()
+	data, err := payloader.JSONPayload()
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("JSONPayload: %v", err)
 	}
-	t.UUID = gouuid.NewV4().String()
-	t.PayloadContent = string(data)
-	_, err = e.Insert(t)
-	return err
+
+	return &HookTask{
+		RepoID:         repoID,
+		HookID:         w.ID,
+		UUID:           gouuid.NewV4().String(),
+		Type:           w.HookTaskType,
+		URL:            w.URL,
+		Signature:      w.Signature(data),
+		Payloader:      payloader,
+		PayloadContent: string(data),
+		ContentType:    w.ContentType,
+		EventType:      event,
+		IsSSL:          w.IsSSL,
+	}, nil
 }
 
 var _ errutil.NotFound = (*ErrHookTaskNotExist)(nil)
@@ -562,12 +702,11 @@ func UpdateHookTask(t *HookTask) error {
 }
 
 // prepareHookTasks adds list of webhooks to task queue.
-func prepareHookTasks(e Engine, repo *Repository, event HookEventType, p api.Payloader, webhooks []*Webhook) (err error) {
+func prepareHookTasks(e Engine, repo *Repository, event HookEventType, p api.Payloader, webhooks []*Webhook) error {
 	if len(webhooks) == 0 {
 		return nil
 	}
 
-	var payloader api.Payloader
 	for _, w := range webhooks {
 		switch event {
 		case HOOK_EVENT_CREATE:
@@ -604,50 +743,15 @@ func prepareHookTasks(e Engine, repo *Repository, event HookEventType, p api.Pay
 			}
 		}
 
-		// Use separate objects so modifications won't be made on payload on non-Gogs type hooks.
-		switch w.HookTaskType {
-		case SLACK:
-			payloader, err = GetSlackPayload(p, event, w.Meta)
-			if err != nil {
-				return fmt.Errorf("GetSlackPayload: %v", err)
-			}
-		case DISCORD:
-			payloader, err = GetDiscordPayload(p, event, w.Meta)
-			if err != nil {
-				return fmt.Errorf("GetDiscordPayload: %v", err)
-			}
-		case DINGTALK:
-			payloader, err = GetDingtalkPayload(p, event)
-			if err != nil {
-				return fmt.Errorf("GetDingtalkPayload: %v", err)
-			}
-		default:
-			payloader = p
-		}
-
-		var signature string
-		if len(w.Secret) > 0 {
-			data, err := payloader.JSONPayload()
-			if err != nil {
-				log.Error("prepareWebhooks.JSONPayload: %v", err)
-			}
-			sig := hmac.New(sha256.New, []byte(w.Secret))
-			_, _ = sig.Write(data)
-			signature = hex.EncodeToString(sig.Sum(nil))
+		if branch, ok := payloadBranch(p); ok && !w.MatchesBranch(branch) {
+			continue
 		}
 
-		if err = createHookTask(e, &HookTask{
-			RepoID:      repo.ID,
-			HookID:      w.ID,
-			Type:        w.HookTaskType,
-			URL:         w.URL,
-			Signature:   signature,
-			Payloader:   payloader,
-			ContentType: w.ContentType,
-			EventType:   event,
-			IsSSL:       w.IsSSL,
-		}); err != nil {
-			return fmt.Errorf("createHookTask: %v", err)
+		t, err := newHookTask(repo.ID, w, event, p)
+		if err != nil {
+			return fmt.Errorf("newHookTask: %v", err)
+		} else if _, err = e.Insert(t); err != nil {
+			return fmt.Errorf("insert hook task: %v", err)
 		}
 	}
 
@@ -695,7 +799,56 @@ func TestWebhook(repo *Repository, event HookEventType, p api.Payloader, webhook
 	return prepareHookTasks(x, repo, event, p, []*Webhook{webhook})
 }
 
+// hookTaskRetryDelays is the list of delays before retrying failed deliveries,
+// delays of further retries keep doubling the last one.
+var hookTaskRetryDelays = []time.Duration{
+	time.Minute,
+	5 * time.Minute,
+	15 * time.Minute,
+}
+
+// hookTaskRetryDelay returns the delay before the given n-th (starting from 1)
+// retry of a failed delivery, it never exceeds 24 hours.
+func hookTaskRetryDelay(n int) time.Duration {
+	if n <= 0 {
+		return 0
+	} else if n <= len(hookTaskRetryDelays) {
+		return hookTaskRetryDelays[n-1]
+	}
+
+	const maxDelay = 24 * time.Hour
+	delay := hookTaskRetryDelays[len(hookTaskRetryDelays)-1]
+	for i := len(hookTaskRetryDelays); i < n && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+// scheduleRetry schedules the next delivery of the task when the current one
+// has failed, or marks the task as delivered (and failed) when it has exhausted
+// all attempts.
+func (t *HookTask) scheduleRetry() {
+	if t.IsSucceed || t.RetryCount+1 >= conf.Webhook.MaxAttempts {
+		t.IsDelivered = true
+		return
+	}
+
+	t.IsDelivered = false
+	t.RetryCount++
+	t.DeliverAfter = time.Now().Add(hookTaskRetryDelay(t.RetryCount)).Unix()
+	log.Trace("Hook delivery will be retried after %s: %s", time.Unix(t.DeliverAfter, 0), t.UUID)
+}
+
+// hookResponseBodyMaxSize is the maximum size in bytes of the response body to
+// be kept in the delivery history, the rest is truncated.
+const hookResponseBodyMaxSize = 64 << 10
+
 func (t *HookTask) deliver() {
+	defer t.scheduleRetry()
+
 	payloadURL, err := url.Parse(t.URL)
 	if err != nil {
 		t.ResponseContent = fmt.Sprintf(`{"body": "Cannot parse payload URL: %v"}`, err)
@@ -775,19 +928,22 @@ func (t *HookTask) deliver() {
 		t.ResponseInfo.Headers[k] = strings.Join(vals, ",")
 	}
 
-	p, err := io.ReadAll(resp.Body)
+	p, err := io.ReadAll(io.LimitReader(resp.Body, hookResponseBodyMaxSize+1))
 	if err != nil {
 		t.ResponseInfo.Body = fmt.Sprintf("read body: %s", err)
 		return
 	}
+	if len(p) > hookResponseBodyMaxSize {
+		t.ResponseInfo.Body = string(p[:hookResponseBodyMaxSize]) + "\n... (truncated)"
+		return
+	}
 	t.ResponseInfo.Body = string(p)
 }
 
-// DeliverHooks checks and delivers undelivered hooks.
-// TODO: shoot more hooks at same time.
-func DeliverHooks() {
+// deliverPendingHooks delivers all undelivered hooks that are due.
+func deliverPendingHooks() {
 	tasks := make([]*HookTask, 0, 10)
-	_ = x.Where("is_delivered = ?", false).Iterate(new(HookTask),
+	_ = x.Where("is_delivered = ?", false).And("deliver_after <= ?", time.Now().Unix()).Iterate(new(HookTask),
 		func(idx int, bean any) error {
 			t := bean.(*HookTask)
 			t.deliver()
@@ -801,23 +957,39 @@ func DeliverHooks() {
 			log.Error("UpdateHookTask [%d]: %v", t.ID, err)
 		}
 	}
+}
 
-	// Start listening on new hook requests.
-	for repoID := range HookQueue.Queue() {
-		log.Trace("DeliverHooks [repo_id: %v]", repoID)
-		HookQueue.Remove(repoID)
+// DeliverHooks checks and delivers undelivered hooks, failed deliveries are
+// retried periodically until they succeed or exhaust all attempts.
+// TODO: shoot more hooks at same time.
+func DeliverHooks() {
+	deliverPendingHooks()
 
-		tasks = make([]*HookTask, 0, 5)
-		if err := x.Where("repo_id = ?", repoID).And("is_delivered = ?", false).Find(&tasks); err != nil {
-			log.Error("Get repository [%s] hook tasks: %v", repoID, err)
-			continue
-		}
-		for _, t := range tasks {
-			t.deliver()
-			if err := UpdateHookTask(t); err != nil {
-				log.Error("UpdateHookTask [%d]: %v", t.ID, err)
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	// Start listening on new hook requests.
+	for {
+		select {
+		case <-ticker.C:
+			deliverPendingHooks()
+
+		case repoID := <-HookQueue.Queue():
+			log.Trace("DeliverHooks [repo_id: %v]", repoID)
+			HookQueue.Remove(repoID)
+
+			tasks := make([]*HookTask, 0, 5)
+			if err := x.Where("repo_id = ?", repoID).And("is_delivered = ?", false).And("deliver_after <= ?", time.Now().Unix()).Find(&tasks); err != nil {
+				log.Error("Get repository [%s] hook tasks: %v", repoID, err)
 				continue
 			}
+			for _, t := range tasks {
+				t.deliver()
+				if err := UpdateHookTask(t); err != nil {
+					log.Error("UpdateHookTask [%d]: %v", t.ID, err)
+					continue
+				}
+			}
 		}
 	}
 }
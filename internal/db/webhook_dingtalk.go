@@ -13,6 +13,8 @@ import (
 
 	"github.com/gogs/git-module"
 	api "github.com/gogs/go-gogs-client"
+
+	"gogs.io/gogs/internal/conf"
 )
 
 const (
@@ -77,6 +79,8 @@ func GetDingtalkPayload(p api.Payloader, event HookEventType) (payload *Dingtalk
 		payload = getDingtalkPullRequestPayload(p.(*api.PullRequestPayload))
 	case HOOK_EVENT_RELEASE:
 		payload = getDingtalkReleasePayload(p.(*api.ReleasePayload))
+	case HOOK_EVENT_PING:
+		payload = getDingtalkPingPayload(p.(*PingPayload))
 	default:
 		return nil, errors.Errorf("unexpected event %q", event)
 	}
@@ -274,3 +278,22 @@ func getDingtalkReleasePayload(p *api.ReleasePayload) *DingtalkPayload {
 func MarkdownLinkFormatter(link, text string) string {
 	return "[" + text + "](" + link + ")"
 }
+
+func getDingtalkPingPayload(p *PingPayload) *DingtalkPayload {
+	link := conf.Server.ExternalURL
+	if p.Repository != nil {
+		link = p.Repository.HTMLURL
+	}
+
+	actionCard := NewDingtalkActionCard("View", link)
+	actionCard.Text += "# Webhook Ping Event"
+	actionCard.Text += fmt.Sprintf("\n- Webhook: **%d**", p.HookID)
+	if p.Repository != nil {
+		actionCard.Text += "\n- Repo: **" + MarkdownLinkFormatter(p.Repository.HTMLURL, p.Repository.Name) + "**"
+	}
+
+	return &DingtalkPayload{
+		MsgType:    "actionCard",
+		ActionCard: actionCard,
+	}
+}
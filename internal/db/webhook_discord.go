@@ -371,6 +371,19 @@ func getDiscordReleasePayload(p *api.ReleasePayload) *DiscordPayload {
 	}
 }
 
+func getDiscordPingPayload(p *PingPayload) *DiscordPayload {
+	embed := &DiscordEmbedObject{
+		Description: fmt.Sprintf("Ping from webhook %d", p.HookID),
+	}
+	if p.Repository != nil {
+		embed.Title = p.Repository.FullName
+		embed.URL = p.Repository.HTMLURL
+	}
+	return &DiscordPayload{
+		Embeds: []*DiscordEmbedObject{embed},
+	}
+}
+
 func GetDiscordPayload(p api.Payloader, event HookEventType, meta string) (payload *DiscordPayload, err error) {
 	slack := &SlackMeta{}
 	if err := jsoniter.Unmarshal([]byte(meta), &slack); err != nil {
@@ -394,6 +407,8 @@ func GetDiscordPayload(p api.Payloader, event HookEventType, meta string) (paylo
 		payload = getDiscordPullRequestPayload(p.(*api.PullRequestPayload), slack)
 	case HOOK_EVENT_RELEASE:
 		payload = getDiscordReleasePayload(p.(*api.ReleasePayload))
+	case HOOK_EVENT_PING:
+		payload = getDiscordPingPayload(p.(*PingPayload))
 	default:
 		return nil, errors.Errorf("unexpected event %q", event)
 	}
@@ -288,6 +288,16 @@ func getSlackReleasePayload(p *api.ReleasePayload) *SlackPayload {
 	}
 }
 
+func getSlackPingPayload(p *PingPayload) *SlackPayload {
+	text := fmt.Sprintf("Ping from webhook %d", p.HookID)
+	if p.Repository != nil {
+		text = fmt.Sprintf("[%s] %s", SlackLinkFormatter(p.Repository.HTMLURL, p.Repository.FullName), text)
+	}
+	return &SlackPayload{
+		Text: text,
+	}
+}
+
 func GetSlackPayload(p api.Payloader, event HookEventType, meta string) (payload *SlackPayload, err error) {
 	slack := &SlackMeta{}
 	if err := jsoniter.Unmarshal([]byte(meta), &slack); err != nil {
@@ -311,6 +321,8 @@ func GetSlackPayload(p api.Payloader, event HookEventType, meta string) (payload
 		payload = getSlackPullRequestPayload(p.(*api.PullRequestPayload), slack)
 	case HOOK_EVENT_RELEASE:
 		payload = getSlackReleasePayload(p.(*api.ReleasePayload))
+	case HOOK_EVENT_PING:
+		payload = getSlackPingPayload(p.(*PingPayload))
 	default:
 		return nil, errors.Errorf("unexpected event %q", event)
 	}
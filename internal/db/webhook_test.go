@@ -0,0 +1,128 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package db
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/gogs/go-gogs-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_hookTaskRetryDelay(t *testing.T) {
+	tests := []struct {
+		n    int
+		want time.Duration
+	}{
+		{n: 0, want: 0},
+		{n: 1, want: time.Minute},
+		{n: 2, want: 5 * time.Minute},
+		{n: 3, want: 15 * time.Minute},
+		{n: 4, want: 30 * time.Minute},
+		{n: 5, want: time.Hour},
+		{n: 100, want: 24 * time.Hour},
+	}
+	for _, test := range tests {
+		t.Run("", func(t *testing.T) {
+			assert.Equal(t, test.want, hookTaskRetryDelay(test.n))
+		})
+	}
+}
+
+func TestWebhook_Signature(t *testing.T) {
+	payload := []byte(`{"ref":"refs/heads/main"}`)
+	const digest = "d8f89f0618acd61fe621aa4e64078c0e2bca15d0b578b7f3eb734f55883c5320"
+
+	tests := []struct {
+		name    string
+		webhook *Webhook
+		want    string
+	}{
+		{
+			name:    "no secret",
+			webhook: &Webhook{},
+			want:    "",
+		},
+		{
+			name:    "never rotated",
+			webhook: &Webhook{Secret: "secret"},
+			want:    digest,
+		},
+		{
+			name:    "rotated",
+			webhook: &Webhook{Secret: "secret", PreviousSecret: "old", SecretKeyID: 2},
+			want:    "keyid=2,sha256=" + digest,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, test.webhook.Signature(payload))
+		})
+	}
+}
+
+func TestWebhook_MatchesBranch(t *testing.T) {
+	tests := []struct {
+		filter string
+		branch string
+		want   bool
+	}{
+		{filter: "", branch: "feature", want: true},
+		{filter: "main", branch: "main", want: true},
+		{filter: "main", branch: "master", want: false},
+		{filter: "release/*", branch: "release/v1.0", want: true},
+		{filter: "release/*", branch: "release", want: false},
+		{filter: "{main,release/*}", branch: "main", want: false}, // Alternation is not supported
+		{filter: "[", branch: "main", want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.filter+"|"+test.branch, func(t *testing.T) {
+			w := &Webhook{BranchFilter: test.filter}
+			assert.Equal(t, test.want, w.MatchesBranch(test.branch))
+		})
+	}
+}
+
+func Test_payloadBranch(t *testing.T) {
+	tests := []struct {
+		name       string
+		payload    api.Payloader
+		wantBranch string
+		wantOK     bool
+	}{
+		{
+			name:       "push to branch",
+			payload:    &api.PushPayload{Ref: "refs/heads/release/v1.0"},
+			wantBranch: "release/v1.0",
+			wantOK:     true,
+		},
+		{
+			name:    "push tag",
+			payload: &api.PushPayload{Ref: "refs/tags/v1.0"},
+		},
+		{
+			name:       "create branch",
+			payload:    &api.CreatePayload{Ref: "main", RefType: "branch"},
+			wantBranch: "main",
+			wantOK:     true,
+		},
+		{
+			name:    "delete tag",
+			payload: &api.DeletePayload{Ref: "v1.0", RefType: "tag"},
+		},
+		{
+			name:    "issues",
+			payload: &api.IssuesPayload{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			branch, ok := payloadBranch(test.payload)
+			assert.Equal(t, test.wantBranch, branch)
+			assert.Equal(t, test.wantOK, ok)
+		})
+	}
+}
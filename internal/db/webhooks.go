@@ -0,0 +1,244 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	gouuid "github.com/satori/go.uuid"
+	"gorm.io/gorm"
+
+	"gogs.io/gogs/internal/errutil"
+	"gogs.io/gogs/internal/strutil"
+)
+
+// WebhooksStore is the persistent interface for webhooks.
+type WebhooksStore interface {
+	// CreatePingTask creates a hook task with a ping payload for the given
+	// webhook and adds it to the delivery queue. The task goes through the same
+	// delivery pipeline as other events, including signing with the secret of
+	// the webhook, and the returned task can be used to poll the delivery status.
+	// It returns ErrWebhookNotExist when not found.
+	CreatePingTask(ctx context.Context, hookID int64) (*HookTask, error)
+	// ListByOrg returns all webhooks of the organization, which are delivered for
+	// events in every repository owned by the organization.
+	ListByOrg(ctx context.Context, orgID int64) ([]*Webhook, error)
+	// CreateForOrg creates a new webhook owned by the organization. Fields of the
+	// webhook that identify the owner are overwritten. It returns ErrOrgNotExist
+	// when the organization does not exist.
+	CreateForOrg(ctx context.Context, orgID int64, w *Webhook) error
+	// ListTasks returns a list of delivery history of the webhook, including
+	// request and response details. Results are paginated by given page and page
+	// size, and sorted by task ID in descending order. A total count of all
+	// results is also returned.
+	ListTasks(ctx context.Context, hookID int64, page, pageSize int) ([]*HookTask, int64, error)
+	// ReplayTask creates a new hook task with the same payload as the given task
+	// and adds it to the delivery queue, leaving the history of the given task
+	// intact. The payload is delivered to the current payload URL of the webhook
+	// and signed with its current secret. It returns ErrHookTaskNotExist when the
+	// task does not exist, or ErrWebhookNotExist when the webhook of the task has
+	// been deleted.
+	ReplayTask(ctx context.Context, taskID int64) (*HookTask, error)
+	// RotateSecret moves the current secret of the webhook to be the previous one,
+	// generates a new current secret and increases the ID of the secret. Receivers
+	// should accept signatures of both secrets during a grace window until they
+	// are updated with the new one. It returns the updated webhook, or
+	// ErrWebhookNotExist when not found.
+	RotateSecret(ctx context.Context, hookID int64) (*Webhook, error)
+}
+
+var Webhooks WebhooksStore
+
+var _ WebhooksStore = (*webhooks)(nil)
+
+type webhooks struct {
+	*gorm.DB
+}
+
+// NewWebhooksStore returns a persistent interface for webhooks with given
+// database connection.
+func NewWebhooksStore(db *gorm.DB) WebhooksStore {
+	return &webhooks{DB: db}
+}
+
+func (db *webhooks) CreatePingTask(ctx context.Context, hookID int64) (*HookTask, error) {
+	w := new(Webhook)
+	err := db.WithContext(ctx).Where("id = ?", hookID).First(w).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookNotExist{args: map[string]any{"webhookID": hookID}}
+		}
+		return nil, errors.Wrap(err, "get webhook")
+	}
+
+	p := &PingPayload{HookID: w.ID}
+	if w.RepoID > 0 {
+		repo, err := NewReposStore(db.DB).GetByID(ctx, w.RepoID)
+		if err != nil {
+			return nil, errors.Wrap(err, "get repository")
+		}
+		owner, err := NewUsersStore(db.DB).GetByID(ctx, repo.OwnerID)
+		if err != nil {
+			return nil, errors.Wrap(err, "get repository owner")
+		}
+		p.Repository = repo.APIFormat(owner)
+	}
+
+	t, err := newHookTask(w.RepoID, w, HOOK_EVENT_PING, p)
+	if err != nil {
+		return nil, errors.Wrap(err, "new hook task")
+	}
+
+	err = db.WithContext(ctx).Create(t).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "create hook task")
+	}
+
+	go HookQueue.Add(t.RepoID)
+	return t, nil
+}
+
+func (db *webhooks) ListByOrg(ctx context.Context, orgID int64) ([]*Webhook, error) {
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT * FROM webhook
+		WHERE org_id = @orgID AND repo_id = 0
+		ORDER BY id
+	*/
+	var hooks []*Webhook
+	return hooks, db.WithContext(ctx).
+		Where("org_id = ? AND repo_id = 0", orgID).
+		Order("id").
+		Find(&hooks).
+		Error
+}
+
+func (db *webhooks) CreateForOrg(ctx context.Context, orgID int64, w *Webhook) error {
+	var count int64
+	err := db.WithContext(ctx).
+		Model(&User{}).
+		Where("id = ? AND type = ?", orgID, UserTypeOrganization).
+		Count(&count).
+		Error
+	if err != nil {
+		return errors.Wrap(err, "count organization")
+	} else if count == 0 {
+		return ErrOrgNotExist{args: errutil.Args{"orgID": orgID}}
+	}
+
+	// Org webhooks are told apart from repository webhooks by having no
+	// repository, so that they are never delivered twice for the same event.
+	w.OrgID = orgID
+	w.RepoID = 0
+	err = w.UpdateEvent()
+	if err != nil {
+		return errors.Wrap(err, "update event")
+	}
+	return db.WithContext(ctx).Create(w).Error
+}
+
+func (db *webhooks) ListTasks(ctx context.Context, hookID int64, page, pageSize int) ([]*HookTask, int64, error) {
+	tx := db.WithContext(ctx).Model(&HookTask{}).Where("hook_id = ?", hookID)
+
+	var count int64
+	err := tx.Count(&count).Error
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "count")
+	}
+
+	/*
+		Equivalent SQL for PostgreSQL:
+
+		SELECT * FROM hook_task
+		WHERE hook_id = @hookID
+		ORDER BY id DESC
+		LIMIT @limit OFFSET @offset
+	*/
+	tasks := make([]*HookTask, 0, pageSize)
+	return tasks, count, tx.
+		Order("id DESC").
+		Limit(pageSize).
+		Offset((page - 1) * pageSize).
+		Find(&tasks).
+		Error
+}
+
+func (db *webhooks) ReplayTask(ctx context.Context, taskID int64) (*HookTask, error) {
+	t := new(HookTask)
+	err := db.WithContext(ctx).Where("id = ?", taskID).First(t).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrHookTaskNotExist{args: map[string]any{"taskID": taskID}}
+		}
+		return nil, errors.Wrap(err, "get hook task")
+	}
+
+	w := new(Webhook)
+	err = db.WithContext(ctx).Where("id = ?", t.HookID).First(w).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookNotExist{args: map[string]any{"webhookID": t.HookID}}
+		}
+		return nil, errors.Wrap(err, "get webhook")
+	}
+
+	replay := &HookTask{
+		RepoID:         t.RepoID,
+		HookID:         w.ID,
+		UUID:           gouuid.NewV4().String(),
+		Type:           t.Type,
+		URL:            w.URL,
+		Signature:      w.Signature([]byte(t.PayloadContent)),
+		PayloadContent: t.PayloadContent,
+		ContentType:    w.ContentType,
+		EventType:      t.EventType,
+		IsSSL:          w.IsSSL,
+	}
+	err = db.WithContext(ctx).Create(replay).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "create hook task")
+	}
+
+	go HookQueue.Add(replay.RepoID)
+	return replay, nil
+}
+
+func (db *webhooks) RotateSecret(ctx context.Context, hookID int64) (*Webhook, error) {
+	secret, err := strutil.RandomChars(32)
+	if err != nil {
+		return nil, errors.Wrap(err, "generate secret")
+	}
+
+	w := new(Webhook)
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("id = ?", hookID).First(w).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrWebhookNotExist{args: map[string]any{"webhookID": hookID}}
+			}
+			return errors.Wrap(err, "get webhook")
+		}
+
+		w.PreviousSecret = w.Secret
+		w.Secret = secret
+		w.SecretKeyID++
+		w.UpdatedUnix = tx.NowFunc().Unix()
+		return tx.Model(&Webhook{}).
+			Where("id = ?", hookID).
+			Updates(map[string]any{
+				"previous_secret": w.PreviousSecret,
+				"secret":          w.Secret,
+				"secret_key_id":   w.SecretKeyID,
+				"updated_unix":    w.UpdatedUnix,
+			}).
+			Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
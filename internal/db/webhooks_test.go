@@ -0,0 +1,305 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gogs.io/gogs/internal/dbtest"
+)
+
+func TestWebhooks(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	tables := []any{new(Webhook), new(HookTask), new(Repository), new(Watch), new(User), new(EmailAddress)}
+	db := &webhooks{
+		DB: dbtest.NewDB(t, "webhooks", tables...),
+	}
+
+	for _, tc := range []struct {
+		name string
+		test func(t *testing.T, db *webhooks)
+	}{
+		{"CreatePingTask", webhooksCreatePingTask},
+		{"ListByOrg", webhooksListByOrg},
+		{"CreateForOrg", webhooksCreateForOrg},
+		{"ListTasks", webhooksListTasks},
+		{"ReplayTask", webhooksReplayTask},
+		{"RotateSecret", webhooksRotateSecret},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Cleanup(func() {
+				err := clearTables(t, db.DB, tables...)
+				require.NoError(t, err)
+			})
+			tc.test(t, db)
+		})
+		if t.Failed() {
+			break
+		}
+	}
+}
+
+func webhooksCreatePingTask(t *testing.T, db *webhooks) {
+	ctx := context.Background()
+
+	t.Run("webhook does not exist", func(t *testing.T) {
+		_, err := db.CreatePingTask(ctx, 404)
+		wantErr := ErrWebhookNotExist{args: map[string]any{"webhookID": int64(404)}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	alice, err := NewUsersStore(db.DB).Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+	repo, err := NewReposStore(db.DB).Create(ctx, alice.ID, CreateRepoOptions{Name: "repo1"})
+	require.NoError(t, err)
+
+	w := &Webhook{
+		RepoID:       repo.ID,
+		URL:          "https://example.com/hook",
+		ContentType:  JSON,
+		Secret:       "secret",
+		SecretKeyID:  1,
+		IsActive:     true,
+		HookTaskType: GOGS,
+	}
+	err = db.DB.Create(w).Error
+	require.NoError(t, err)
+
+	task, err := db.CreatePingTask(ctx, w.ID)
+	require.NoError(t, err)
+	assert.NotZero(t, task.ID)
+	assert.NotEmpty(t, task.UUID)
+	assert.Equal(t, repo.ID, task.RepoID)
+	assert.Equal(t, w.ID, task.HookID)
+	assert.Equal(t, HOOK_EVENT_PING, task.EventType)
+	assert.False(t, task.IsDelivered)
+	assert.Equal(t, w.Signature([]byte(task.PayloadContent)), task.Signature)
+
+	var payload PingPayload
+	err = jsoniter.Unmarshal([]byte(task.PayloadContent), &payload)
+	require.NoError(t, err)
+	assert.Equal(t, w.ID, payload.HookID)
+	require.NotNil(t, payload.Repository)
+	assert.Equal(t, "alice/repo1", payload.Repository.FullName)
+
+	got := new(HookTask)
+	err = db.Where("uuid = ?", task.UUID).First(got).Error
+	require.NoError(t, err)
+	assert.Equal(t, task.ID, got.ID)
+	assert.Equal(t, task.Signature, got.Signature)
+}
+
+func webhooksListByOrg(t *testing.T, db *webhooks) {
+	ctx := context.Background()
+
+	hooks := []*Webhook{
+		{OrgID: 1, URL: "https://example.com/org1"},
+		{OrgID: 2, URL: "https://example.com/org2"},
+		{RepoID: 1, URL: "https://example.com/repo1"},
+		{OrgID: 1, URL: "https://example.com/org1-2", IsActive: true},
+	}
+	err := db.DB.Create(hooks).Error
+	require.NoError(t, err)
+
+	got, err := db.ListByOrg(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, hooks[0].ID, got[0].ID)
+	assert.Equal(t, hooks[3].ID, got[1].ID)
+
+	got, err = db.ListByOrg(ctx, 404)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func webhooksCreateForOrg(t *testing.T, db *webhooks) {
+	ctx := context.Background()
+
+	usersStore := NewUsersStore(db.DB)
+	alice, err := usersStore.Create(ctx, "alice", "alice@example.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	t.Run("organization does not exist", func(t *testing.T) {
+		err := db.CreateForOrg(ctx, alice.ID, &Webhook{URL: "https://example.com/hook"})
+		wantErr := ErrOrgNotExist{args: map[string]any{"orgID": alice.ID}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	org1 := createTestOrg(t, db.DB, "org1", CreateUserOptions{})
+
+	w := &Webhook{
+		RepoID:   1,
+		URL:      "https://example.com/hook",
+		IsActive: true,
+		HookEvent: &HookEvent{
+			ChooseEvents: true,
+			HookEvents:   HookEvents{Push: true},
+		},
+	}
+	err = db.CreateForOrg(ctx, org1.ID, w)
+	require.NoError(t, err)
+	assert.Equal(t, org1.ID, w.OrgID)
+	assert.Zero(t, w.RepoID)
+	assert.NotZero(t, w.CreatedUnix)
+
+	got, err := db.ListByOrg(ctx, org1.ID)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, w.ID, got[0].ID)
+	assert.True(t, got[0].HasPushEvent())
+	assert.False(t, got[0].HasIssuesEvent())
+}
+
+func webhooksListTasks(t *testing.T, db *webhooks) {
+	ctx := context.Background()
+
+	tasks := []*HookTask{
+		{HookID: 1, UUID: "uuid1", ResponseContent: `{"status": 200, "body": "ok"}`, IsSucceed: true},
+		{HookID: 2, UUID: "uuid2"},
+		{HookID: 1, UUID: "uuid3", ResponseContent: `{"status": 500, "headers": {"Content-Type": "text/plain"}, "body": "oops"}`},
+		{HookID: 1, UUID: "uuid4"},
+	}
+	err := db.DB.Create(tasks).Error
+	require.NoError(t, err)
+
+	got, count, err := db.ListTasks(ctx, 1, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	require.Len(t, got, 2)
+	assert.Equal(t, "uuid4", got[0].UUID)
+	assert.Nil(t, got[0].ResponseInfo)
+	assert.Equal(t, "uuid3", got[1].UUID)
+	require.NotNil(t, got[1].ResponseInfo)
+	assert.Equal(t, 500, got[1].ResponseInfo.Status)
+	assert.Equal(t, "text/plain", got[1].ResponseInfo.Headers["Content-Type"])
+	assert.Equal(t, "oops", got[1].ResponseInfo.Body)
+
+	got, count, err = db.ListTasks(ctx, 1, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	require.Len(t, got, 1)
+	assert.Equal(t, "uuid1", got[0].UUID)
+
+	got, count, err = db.ListTasks(ctx, 404, 1, 2)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	assert.Empty(t, got)
+}
+
+func webhooksReplayTask(t *testing.T, db *webhooks) {
+	ctx := context.Background()
+
+	t.Run("task does not exist", func(t *testing.T) {
+		_, err := db.ReplayTask(ctx, 404)
+		wantErr := ErrHookTaskNotExist{args: map[string]any{"taskID": int64(404)}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	w := &Webhook{
+		RepoID:       1,
+		URL:          "https://example.com/hook",
+		ContentType:  JSON,
+		Secret:       "secret",
+		SecretKeyID:  1,
+		IsActive:     true,
+		HookTaskType: GOGS,
+	}
+	err := db.DB.Create(w).Error
+	require.NoError(t, err)
+
+	task := &HookTask{
+		RepoID:          1,
+		HookID:          w.ID,
+		UUID:            "uuid1",
+		Type:            GOGS,
+		URL:             "https://example.com/old",
+		PayloadContent:  `{"ref": "refs/heads/main"}`,
+		ContentType:     JSON,
+		EventType:       HOOK_EVENT_PUSH,
+		IsDelivered:     true,
+		ResponseContent: `{"status": 500}`,
+	}
+	err = db.DB.Create(task).Error
+	require.NoError(t, err)
+
+	replay, err := db.ReplayTask(ctx, task.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, task.ID, replay.ID)
+	assert.NotEqual(t, task.UUID, replay.UUID)
+	assert.Equal(t, task.PayloadContent, replay.PayloadContent)
+	assert.Equal(t, HOOK_EVENT_PUSH, replay.EventType)
+	assert.Equal(t, w.URL, replay.URL)
+	assert.Equal(t, w.Signature([]byte(task.PayloadContent)), replay.Signature)
+	assert.False(t, replay.IsDelivered)
+
+	// The history of the original task should be intact
+	got := new(HookTask)
+	err = db.Where("id = ?", task.ID).First(got).Error
+	require.NoError(t, err)
+	assert.True(t, got.IsDelivered)
+	require.NotNil(t, got.ResponseInfo)
+	assert.Equal(t, 500, got.ResponseInfo.Status)
+
+	t.Run("webhook does not exist", func(t *testing.T) {
+		err := db.DB.Delete(w).Error
+		require.NoError(t, err)
+
+		_, err = db.ReplayTask(ctx, task.ID)
+		wantErr := ErrWebhookNotExist{args: map[string]any{"webhookID": w.ID}}
+		assert.Equal(t, wantErr, err)
+	})
+}
+
+func webhooksRotateSecret(t *testing.T, db *webhooks) {
+	ctx := context.Background()
+
+	t.Run("webhook does not exist", func(t *testing.T) {
+		_, err := db.RotateSecret(ctx, 404)
+		wantErr := ErrWebhookNotExist{args: map[string]any{"webhookID": int64(404)}}
+		assert.Equal(t, wantErr, err)
+	})
+
+	w := &Webhook{
+		RepoID:       1,
+		URL:          "https://example.com/hook",
+		ContentType:  JSON,
+		Secret:       "secret",
+		IsActive:     true,
+		HookTaskType: GOGS,
+	}
+	err := db.DB.Create(w).Error
+	require.NoError(t, err)
+
+	got, err := db.RotateSecret(ctx, w.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", got.PreviousSecret)
+	assert.Len(t, got.Secret, 32)
+	assert.NotEqual(t, "secret", got.Secret)
+	assert.Equal(t, int64(1), got.SecretKeyID)
+
+	// Rotating again retires the secret generated by the last rotation.
+	again, err := db.RotateSecret(ctx, w.ID)
+	require.NoError(t, err)
+	assert.Equal(t, got.Secret, again.PreviousSecret)
+	assert.NotEqual(t, got.Secret, again.Secret)
+	assert.Equal(t, int64(2), again.SecretKeyID)
+
+	stored := new(Webhook)
+	err = db.Where("id = ?", w.ID).First(stored).Error
+	require.NoError(t, err)
+	assert.Equal(t, again.Secret, stored.Secret)
+	assert.Equal(t, again.PreviousSecret, stored.PreviousSecret)
+	assert.Equal(t, int64(2), stored.SecretKeyID)
+	assert.Equal(t, "keyid=2,", stored.Signature([]byte("payload"))[:8])
+}
@@ -5,8 +5,15 @@
 package dbutil
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"gorm.io/gorm/logger"
 )
 
 // Logger is a wrapper of io.Writer for the GORM's logger.Writer.
@@ -17,3 +24,65 @@ type Logger struct {
 func (l *Logger) Printf(format string, args ...any) {
 	_, _ = fmt.Fprintf(l.Writer, format, args...)
 }
+
+// SlowQueryLogger is a wrapper of the GORM's logger.Interface that additionally
+// logs queries taking longer than the threshold, along with the calling
+// function and the elapsed time. Parameters of all queries going through the
+// logger are redacted to not leak sensitive data.
+type SlowQueryLogger struct {
+	logger.Interface
+	// The writer to log slow queries to.
+	Writer logger.Writer
+	// The minimum duration of a query to be considered slow.
+	Threshold time.Duration
+	// The import path of the package whose functions are reported as the callers
+	// of slow queries, e.g. "gogs.io/gogs/internal/db".
+	CallerPackage string
+}
+
+func (l *SlowQueryLogger) LogMode(level logger.LogLevel) logger.Interface {
+	newLogger := *l
+	newLogger.Interface = l.Interface.LogMode(level)
+	return &newLogger
+}
+
+// ParamsFilter implements the GORM's ParamsFilter to redact parameters of
+// queries.
+func (*SlowQueryLogger) ParamsFilter(_ context.Context, sql string, _ ...any) (string, []any) {
+	return sql, nil
+}
+
+func (l *SlowQueryLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	l.Interface.Trace(ctx, begin, fc, err)
+
+	elapsed := time.Since(begin)
+	if l.Threshold <= 0 || elapsed < l.Threshold {
+		return
+	}
+
+	sql, _ := fc()
+	l.Writer.Printf("[SLOW QUERY] %s took %v: %s", callerFunc(l.CallerPackage), elapsed, sql)
+}
+
+var funcLiteralSuffix = regexp.MustCompile(`(\.func\d+)+$`)
+
+// callerFunc returns the name of the innermost function in the call stack that
+// belongs to the given package, with the package path and suffixes of function
+// literals trimmed, e.g. "(*orgs).AccessibleRepositoriesByUser". It returns
+// "unknown" if no such function is found.
+func callerFunc(pkg string) string {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if strings.HasPrefix(frame.Function, pkg+".") {
+			name := strings.TrimPrefix(frame.Function, pkg+".")
+			return funcLiteralSuffix.ReplaceAllString(name, "")
+		}
+		if !more {
+			break
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,41 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package dbutil
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm/logger"
+)
+
+func TestSlowQueryLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := &SlowQueryLogger{
+		Interface:     logger.Discard,
+		Writer:        &Logger{Writer: &buf},
+		Threshold:     time.Second,
+		CallerPackage: "gogs.io/gogs/internal/dbutil",
+	}
+
+	sql, params := l.ParamsFilter(context.Background(), "SELECT * FROM user WHERE id = ?", 1)
+	assert.Equal(t, "SELECT * FROM user WHERE id = ?", sql)
+	assert.Empty(t, params)
+
+	fc := func() (string, int64) {
+		return "SELECT * FROM user WHERE id = ?", 1
+	}
+	l.Trace(context.Background(), time.Now(), fc, nil)
+	assert.Empty(t, buf.String())
+
+	func() {
+		l.Trace(context.Background(), time.Now().Add(-2*time.Second), fc, nil)
+	}()
+	assert.Contains(t, buf.String(), "[SLOW QUERY] TestSlowQueryLogger took ")
+	assert.Contains(t, buf.String(), ": SELECT * FROM user WHERE id = ?")
+}
@@ -6,6 +6,7 @@ package dbutil
 
 import (
 	"fmt"
+	"strings"
 
 	"gogs.io/gogs/internal/conf"
 )
@@ -23,3 +24,16 @@ func Quote(format string, args ...string) string {
 	}
 	return fmt.Sprintf(format, anys...)
 }
+
+// EscapeLike escapes wildcard characters ("%" and "_") of the given string to be
+// used as a pattern in a LIKE condition, using "!" as the escape character, which
+// requires the condition to be declared with `ESCAPE '!'`.
+func EscapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+var likeEscaper = strings.NewReplacer(
+	"!", "!!",
+	"%", "!%",
+	"_", "!_",
+)
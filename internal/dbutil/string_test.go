@@ -23,3 +23,20 @@ func TestQuote(t *testing.T) {
 	want = `SELECT * FROM user`
 	assert.Equal(t, want, got)
 }
+
+func TestEscapeLike(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "gogs", want: "gogs"},
+		{input: "100%", want: "100!%"},
+		{input: "go_gs", want: "go!_gs"},
+		{input: "wow!", want: "wow!!"},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			assert.Equal(t, test.want, EscapeLike(test.input))
+		})
+	}
+}
@@ -26,10 +26,13 @@ const (
 	MAIL_AUTH_RESET_PASSWORD  = "auth/reset_passwd"
 	MAIL_AUTH_REGISTER_NOTIFY = "auth/register_notify"
 
-	MAIL_ISSUE_COMMENT = "issue/comment"
-	MAIL_ISSUE_MENTION = "issue/mention"
+	MAIL_ISSUE_COMMENT  = "issue/comment"
+	MAIL_ISSUE_MENTION  = "issue/mention"
+	MAIL_ISSUE_ASSIGNED = "issue/assigned"
 
 	MAIL_NOTIFY_COLLABORATOR = "notify/collaborator"
+
+	MAIL_ORG_INVITATION = "org/invitation"
 )
 
 var (
@@ -186,6 +189,29 @@ func SendCollaboratorMail(u, doer User, repo Repository) {
 	Send(msg)
 }
 
+// SendOrgInvitationMail sends an invitation to join the organization to the
+// email address, the link is used to accept the invitation.
+func SendOrgInvitationMail(c *macaron.Context, doer User, orgName, to, link string) {
+	subject := c.Tr("mail.org_invitation", doer.DisplayName(), orgName)
+
+	data := map[string]any{
+		"Subject": subject,
+		"Doer":    doer.DisplayName(),
+		"OrgName": orgName,
+		"Link":    link,
+	}
+	body, err := render(MAIL_ORG_INVITATION, data)
+	if err != nil {
+		log.Error("HTMLString: %v", err)
+		return
+	}
+
+	msg := NewMessage([]string{to}, subject, body)
+	msg.Info = fmt.Sprintf("UID: %d, organization invitation", doer.ID())
+
+	Send(msg)
+}
+
 func composeTplData(subject, body, link string) map[string]any {
 	data := make(map[string]any, 10)
 	data["Subject"] = subject
@@ -225,3 +251,11 @@ func SendIssueMentionMail(issue Issue, repo Repository, doer User, tos []string)
 	}
 	Send(composeIssueMessage(issue, repo, doer, MAIL_ISSUE_MENTION, tos, "issue mention"))
 }
+
+// SendIssueAssignedMail composes and sends issue assignment emails to target receivers.
+func SendIssueAssignedMail(issue Issue, repo Repository, doer User, tos []string) {
+	if len(tos) == 0 {
+		return
+	}
+	Send(composeIssueMessage(issue, repo, doer, MAIL_ISSUE_ASSIGNED, tos, "issue assigned"))
+}
@@ -31,6 +31,7 @@ type Authentication struct {
 	GroupFilter       string
 	GroupMemberUID    string
 	UserUID           string
+	GroupTeamMap      string
 	IsActive          bool
 	IsDefault         bool
 	SMTPAuth          string
@@ -40,6 +40,19 @@ func (f *CreateRepo) Validate(ctx *macaron.Context, errs binding.Errors) binding
 	return validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+type CreateRepoFromTemplate struct {
+	UserID        int64  `binding:"Required"`
+	RepoName      string `binding:"Required;AlphaDashDot;MaxSize(100)"`
+	Private       bool
+	Description   string `binding:"MaxSize(512)"`
+	IncludeLabels bool
+	IncludeIssues bool
+}
+
+func (f *CreateRepoFromTemplate) Validate(ctx *macaron.Context, errs binding.Errors) binding.Errors {
+	return validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 type MigrateRepo struct {
 	CloneAddr    string `json:"clone_addr" binding:"Required"`
 	AuthUsername string `json:"auth_username"`
@@ -97,11 +110,13 @@ type RepoSetting struct {
 	RepoName      string `binding:"Required;AlphaDashDot;MaxSize(100)"`
 	Description   string `binding:"MaxSize(512)"`
 	Website       string `binding:"Url;MaxSize(100)"`
+	Topics        string `binding:"MaxSize(1024)"`
 	Branch        string
 	Interval      int
 	MirrorAddress string
 	Private       bool
 	Unlisted      bool
+	Template      bool
 	EnablePrune   bool
 
 	// Advanced settings
@@ -124,6 +139,16 @@ func (f *RepoSetting) Validate(ctx *macaron.Context, errs binding.Errors) bindin
 	return validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+type AddDeployKey struct {
+	Title      string `binding:"Required;MaxSize(50)"`
+	Content    string `binding:"Required"`
+	AllowWrite bool
+}
+
+func (f *AddDeployKey) Validate(ctx *macaron.Context, errs binding.Errors) binding.Errors {
+	return validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // __________                             .__
 // \______   \____________    ____   ____ |  |__
 //  |    |  _/\_  __ \__  \  /    \_/ ___\|  |  \
@@ -160,6 +185,7 @@ type Webhook struct {
 	IssueComment bool
 	PullRequest  bool
 	Release      bool
+	BranchFilter string
 	Active       bool
 }
 
@@ -7,6 +7,7 @@ package netutil
 import (
 	"fmt"
 	"net"
+	"strings"
 )
 
 var localCIDRs []*net.IPNet
@@ -70,3 +71,53 @@ func IsBlockedLocalHostname(hostname string, allowlist []string) bool {
 	}
 	return false
 }
+
+// ClientIP returns the IP address of the client from the peer address of the
+// connection and the value of the "X-Forwarded-For" header. The header is only
+// honored when the peer is one of the trusted proxies, which are either IP
+// addresses or CIDRs. Addresses in the header are taken from right to left and
+// trusted proxies are skipped, so a client cannot spoof its address by
+// prepending entries to the header.
+func ClientIP(remoteAddr, forwardedFor string, trustedProxies []string) string {
+	ip := remoteAddr
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		ip = host
+	}
+	if forwardedFor == "" || !isTrustedProxy(ip, trustedProxies) {
+		return ip
+	}
+
+	hops := strings.Split(forwardedFor, ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			break
+		}
+
+		ip = hop
+		if !isTrustedProxy(hop, trustedProxies) {
+			break
+		}
+	}
+	return ip
+}
+
+func isTrustedProxy(ip string, trustedProxies []string) bool {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+
+	for _, proxy := range trustedProxies {
+		proxy = strings.TrimSpace(proxy)
+		if strings.Contains(proxy, "/") {
+			_, cidr, err := net.ParseCIDR(proxy)
+			if err == nil && cidr.Contains(addr) {
+				return true
+			}
+		} else if addr.Equal(net.ParseIP(proxy)) {
+			return true
+		}
+	}
+	return false
+}
@@ -39,3 +39,60 @@ func TestIsLocalHostname(t *testing.T) {
 		})
 	}
 }
+
+func TestClientIP(t *testing.T) {
+	trustedProxies := []string{"10.0.0.1", "192.168.0.0/16"}
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		want         string
+	}{
+		{
+			name:       "no header",
+			remoteAddr: "10.0.0.1:2222",
+			want:       "10.0.0.1",
+		},
+		{
+			name:         "untrusted peer",
+			remoteAddr:   "1.2.3.4:2222",
+			forwardedFor: "5.6.7.8",
+			want:         "1.2.3.4",
+		},
+		{
+			name:         "trusted peer",
+			remoteAddr:   "10.0.0.1:2222",
+			forwardedFor: "5.6.7.8",
+			want:         "5.6.7.8",
+		},
+		{
+			name:         "skip trusted proxies in the chain",
+			remoteAddr:   "10.0.0.1:2222",
+			forwardedFor: "5.6.7.8, 192.168.1.1",
+			want:         "5.6.7.8",
+		},
+		{
+			name:         "spoofed entries are ignored",
+			remoteAddr:   "10.0.0.1:2222",
+			forwardedFor: "9.9.9.9, 5.6.7.8",
+			want:         "5.6.7.8",
+		},
+		{
+			name:         "malformed entry",
+			remoteAddr:   "10.0.0.1:2222",
+			forwardedFor: "5.6.7.8, unknown",
+			want:         "10.0.0.1",
+		},
+		{
+			name:         "all trusted",
+			remoteAddr:   "10.0.0.1:2222",
+			forwardedFor: "192.168.1.2, 192.168.1.1",
+			want:         "192.168.1.2",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, ClientIP(test.remoteAddr, test.forwardedFor, trustedProxies))
+		})
+	}
+}
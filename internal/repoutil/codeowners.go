@@ -0,0 +1,156 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repoutil
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// CodeOwnersPaths is the list of paths that a CODEOWNERS file is looked up from
+// in a repository, in the order of precedence.
+var CodeOwnersPaths = []string{
+	"CODEOWNERS",
+	".gogs/CODEOWNERS",
+	"docs/CODEOWNERS",
+}
+
+// CodeOwnersRule is a rule of a CODEOWNERS file that designates owners for
+// paths matching the pattern.
+type CodeOwnersRule struct {
+	// The pattern as it appears in the file.
+	Pattern string
+	// The owners in the form of "@username" or "@orgname/teamname". A rule with
+	// no owners unsets ownership of matched paths.
+	Owners []string
+
+	re *regexp.Regexp
+}
+
+// Match returns true if the rule matches the given path relative to the
+// repository root.
+func (r *CodeOwnersRule) Match(path string) bool {
+	return r.re.MatchString(strings.TrimPrefix(path, "/"))
+}
+
+// CodeOwners is a parsed CODEOWNERS file.
+type CodeOwners struct {
+	Rules []*CodeOwnersRule
+}
+
+// Match returns the rule that determines owners of the given path, which is
+// the last matching rule in the file. It returns nil if no rule matches.
+func (o *CodeOwners) Match(path string) *CodeOwnersRule {
+	for i := len(o.Rules) - 1; i >= 0; i-- {
+		if o.Rules[i].Match(path) {
+			return o.Rules[i]
+		}
+	}
+	return nil
+}
+
+// ParseCodeOwnersOwner splits the owner of a CODEOWNERS rule into the
+// organization name and team name for a team owner, or returns the username as
+// the name for a user owner.
+func ParseCodeOwnersOwner(owner string) (orgName, name string) {
+	owner = strings.TrimPrefix(owner, "@")
+	if i := strings.Index(owner, "/"); i >= 0 {
+		return owner[:i], owner[i+1:]
+	}
+	return "", owner
+}
+
+// ParseCodeOwners parses the CODEOWNERS file from given reader. Each non-empty
+// line that is not a comment consists of a path pattern followed by owners,
+// where patterns follow the syntax of gitignore.
+func ParseCodeOwners(r io.Reader) (*CodeOwners, error) {
+	owners := new(CodeOwners)
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		for _, owner := range fields[1:] {
+			orgName, name := ParseCodeOwnersOwner(owner)
+			if !strings.HasPrefix(owner, "@") || name == "" || strings.Contains(name, "/") ||
+				(strings.Contains(owner, "/") && orgName == "") {
+				return nil, fmt.Errorf("line %d: invalid owner %q", lineNum, owner)
+			}
+		}
+
+		re, err := compileCodeOwnersPattern(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid pattern %q: %v", lineNum, fields[0], err)
+		}
+		owners.Rules = append(owners.Rules,
+			&CodeOwnersRule{
+				Pattern: fields[0],
+				Owners:  fields[1:],
+				re:      re,
+			},
+		)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return owners, nil
+}
+
+// compileCodeOwnersPattern compiles the gitignore-style pattern to a regular
+// expression that matches file paths relative to the repository root.
+func compileCodeOwnersPattern(pattern string) (*regexp.Regexp, error) {
+	// Patterns with a leading or middle slash are relative to the repository root,
+	// otherwise they match at any level.
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	if pattern == "" {
+		return nil, fmt.Errorf("empty pattern")
+	}
+
+	var buf strings.Builder
+	buf.WriteString("^")
+	if !anchored {
+		buf.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					buf.WriteString("(?:.*/)?")
+				} else {
+					buf.WriteString(".*")
+				}
+			} else {
+				buf.WriteString("[^/]*")
+			}
+		case '?':
+			buf.WriteString("[^/]")
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	// A pattern that matches a directory also matches everything inside it.
+	if dirOnly {
+		buf.WriteString("/.*$")
+	} else {
+		buf.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(buf.String())
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repoutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCodeOwners(t *testing.T) {
+	t.Run("invalid owner", func(t *testing.T) {
+		for _, owner := range []string{"alice", "@", "@org/", "@/team", "@org/team/sub"} {
+			_, err := ParseCodeOwners(strings.NewReader("*.go " + owner))
+			assert.Error(t, err, owner)
+		}
+	})
+
+	owners, err := ParseCodeOwners(strings.NewReader(`
+# Default owners
+*            @alice
+
+*.go         @bob @org1/backend # Go code
+/docs/       @cindy
+build/       @org1/ops
+/vendor/**/LICENSE
+`))
+	require.NoError(t, err)
+	require.Len(t, owners.Rules, 5)
+	assert.Equal(t, "*.go", owners.Rules[1].Pattern)
+	assert.Equal(t, []string{"@bob", "@org1/backend"}, owners.Rules[1].Owners)
+	assert.Empty(t, owners.Rules[4].Owners)
+
+	tests := []struct {
+		path        string
+		wantPattern string
+	}{
+		{path: "README.md", wantPattern: "*"},
+		{path: "main.go", wantPattern: "*.go"},
+		{path: "internal/db/db.go", wantPattern: "*.go"},
+		{path: "docs/index.md", wantPattern: "/docs/"},
+		// The last matching rule wins over earlier ones.
+		{path: "docs/example.go", wantPattern: "/docs/"},
+		{path: "internal/docs/index.md", wantPattern: "*"},
+		{path: "build/Makefile", wantPattern: "build/"},
+		{path: "scripts/build/run.sh", wantPattern: "build/"},
+		{path: "vendor/a/b/LICENSE", wantPattern: "/vendor/**/LICENSE"},
+		{path: "vendor/LICENSE", wantPattern: "/vendor/**/LICENSE"},
+		{path: "vendor/a/main.go", wantPattern: "*.go"},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			got := owners.Match(test.path)
+			require.NotNil(t, got)
+			assert.Equal(t, test.wantPattern, got.Pattern)
+		})
+	}
+
+	owners, err = ParseCodeOwners(strings.NewReader("/docs/*.md @alice"))
+	require.NoError(t, err)
+	assert.NotNil(t, owners.Match("docs/index.md"))
+	assert.Nil(t, owners.Match("docs/api/index.md"))
+	assert.Nil(t, owners.Match("README.md"))
+}
+
+func TestParseCodeOwnersOwner(t *testing.T) {
+	orgName, name := ParseCodeOwnersOwner("@alice")
+	assert.Empty(t, orgName)
+	assert.Equal(t, "alice", name)
+
+	orgName, name = ParseCodeOwnersOwner("@org1/backend")
+	assert.Equal(t, "org1", orgName)
+	assert.Equal(t, "backend", name)
+}
@@ -137,6 +137,9 @@ const (
 	SyncSSHAuthorizedKey
 	SyncRepositoryHooks
 	ReinitMissingRepository
+	RecountOrganizations
+	CleanupOrphanedAccess
+	DeduplicateOrgMembers
 )
 
 func Operation(c *context.Context) {
@@ -164,6 +167,17 @@ func Operation(c *context.Context) {
 	case ReinitMissingRepository:
 		success = c.Tr("admin.dashboard.reinit_missing_repos_success")
 		err = db.ReinitMissingRepositories()
+	case RecountOrganizations:
+		success = c.Tr("admin.dashboard.recount_orgs_success")
+		err = db.RecountOrganizations()
+	case CleanupOrphanedAccess:
+		var deleted int64
+		deleted, err = db.Repos.CleanupOrphanedAccess(c.Req.Context())
+		success = c.Tr("admin.dashboard.cleanup_orphaned_access_success", deleted)
+	case DeduplicateOrgMembers:
+		var deleted int64
+		deleted, err = db.DeduplicateOrganizationMembers()
+		success = c.Tr("admin.dashboard.dedup_org_members_success", deleted)
 	}
 
 	if err != nil {
@@ -104,6 +104,7 @@ func parseLDAPConfig(f form.Authentication) *ldap.Config {
 		GroupMemberUID:    f.GroupMemberUID,
 		UserUID:           f.UserUID,
 		AdminFilter:       f.AdminFilter,
+		GroupTeamMap:      f.GroupTeamMap,
 	}
 }
 
@@ -7,6 +7,8 @@ package admin
 import (
 	gocontext "context"
 
+	log "unknwon.dev/clog/v2"
+
 	"gogs.io/gogs/internal/conf"
 	"gogs.io/gogs/internal/context"
 	"gogs.io/gogs/internal/db"
@@ -14,7 +16,8 @@ import (
 )
 
 const (
-	ORGS = "admin/org/list"
+	ORGS          = "admin/org/list"
+	ORGS_ORPHANED = "admin/org/orphaned"
 )
 
 func Organizations(c *context.Context) {
@@ -35,3 +38,84 @@ func Organizations(c *context.Context) {
 		TplName:  ORGS,
 	})
 }
+
+func VerifyOrganization(c *context.Context) {
+	org, err := db.Users.GetByID(c.Req.Context(), c.ParamsInt64(":orgid"))
+	if err != nil {
+		c.NotFoundOrError(err, "get organization by ID")
+		return
+	} else if !org.IsOrganization() {
+		c.NotFound()
+		return
+	}
+
+	verified := c.QueryBool("verified")
+	if err = db.Orgs.SetVerified(c.Req.Context(), org.ID, verified); err != nil {
+		c.Error(err, "set verified")
+		return
+	}
+	log.Trace("Organization verification changed by admin (%s): %s -> %v", c.User.Name, org.Name, verified)
+
+	if verified {
+		c.Flash.Success(c.Tr("admin.orgs.verify_success", org.Name))
+	} else {
+		c.Flash.Success(c.Tr("admin.orgs.unverify_success", org.Name))
+	}
+	c.Redirect(conf.Server.Subpath + "/admin/orgs")
+}
+
+func OrphanedOrganizations(c *context.Context) {
+	c.Data["Title"] = c.Tr("admin.orgs.orphaned")
+	c.Data["PageIsAdmin"] = true
+	c.Data["PageIsAdminOrganizations"] = true
+
+	orgs, err := db.Orgs.ListOrphaned(c.Req.Context())
+	if err != nil {
+		c.Error(err, "list orphaned organizations")
+		return
+	}
+	c.Data["Orgs"] = orgs
+
+	c.Success(ORGS_ORPHANED)
+}
+
+func AssignOrganizationOwner(c *context.Context) {
+	org, err := db.Users.GetByID(c.Req.Context(), c.ParamsInt64(":orgid"))
+	if err != nil {
+		c.NotFoundOrError(err, "get organization by ID")
+		return
+	} else if !org.IsOrganization() {
+		c.NotFound()
+		return
+	}
+
+	redirectTo := conf.Server.Subpath + "/admin/orgs/orphaned"
+	user, err := db.Users.GetByUsername(c.Req.Context(), c.Query("user_name"))
+	if err != nil {
+		if db.IsErrUserNotExist(err) {
+			c.Flash.Error(c.Tr("form.user_not_exist"))
+			c.Redirect(redirectTo)
+		} else {
+			c.Error(err, "get user by name")
+		}
+		return
+	} else if user.IsOrganization() {
+		c.Flash.Error(c.Tr("form.user_not_exist"))
+		c.Redirect(redirectTo)
+		return
+	}
+
+	ctx := db.WithAuditActor(c.Req.Context(), c.User.ID)
+	if err = db.Orgs.AddMember(ctx, org.ID, user.ID); err != nil {
+		c.Error(err, "add member")
+		return
+	}
+	if err = db.Orgs.SetOwner(ctx, org.ID, user.ID, true); err != nil {
+		c.Error(err, "set owner")
+		return
+	}
+	log.Trace("Organization owner assigned by admin (%s): %s -> %s", c.User.Name, org.Name, user.Name)
+
+	c.Flash.Success(c.Tr("admin.orgs.assign_owner_success", user.Name, org.Name))
+	c.Redirect(redirectTo)
+}
@@ -5,9 +5,12 @@
 package admin
 
 import (
+	"html/template"
+	"net/url"
 	"strconv"
 	"strings"
 
+	"github.com/unknwon/paginater"
 	log "unknwon.dev/clog/v2"
 
 	"gogs.io/gogs/internal/conf"
@@ -15,7 +18,6 @@ import (
 	"gogs.io/gogs/internal/db"
 	"gogs.io/gogs/internal/email"
 	"gogs.io/gogs/internal/form"
-	"gogs.io/gogs/internal/route"
 )
 
 const (
@@ -29,14 +31,76 @@ func Users(c *context.Context) {
 	c.Data["PageIsAdmin"] = true
 	c.Data["PageIsAdminUsers"] = true
 
-	route.RenderUserSearch(c, &route.UserSearchOptions{
-		Type:     db.UserTypeIndividual,
-		Counter:  db.Users.Count,
-		Ranger:   db.Users.List,
-		PageSize: conf.UI.Admin.UserPagingNum,
-		OrderBy:  "id ASC",
-		TplName:  USERS,
-	})
+	page := c.QueryInt("page")
+	if page <= 1 {
+		page = 1
+	}
+
+	opts := db.SearchUsersOptions{
+		Keyword: c.Query("q"),
+		OrderBy: userSortOrders[c.Query("sort")],
+	}
+	filters := make(url.Values)
+	switch status := c.Query("status"); status {
+	case "active", "inactive":
+		isActive := status == "active"
+		opts.IsActive = &isActive
+		filters.Set("status", status)
+	}
+	switch role := c.Query("role"); role {
+	case "admin", "user":
+		isAdmin := role == "admin"
+		opts.IsAdmin = &isAdmin
+		filters.Set("role", role)
+	}
+	if source := c.Query("source"); source != "" {
+		sourceID, err := strconv.ParseInt(source, 10, 64)
+		if err == nil && sourceID >= 0 {
+			opts.LoginSource = &sourceID
+			filters.Set("source", source)
+		}
+	}
+	if _, ok := userSortOrders[c.Query("sort")]; ok {
+		filters.Set("sort", c.Query("sort"))
+	}
+
+	users, count, err := db.Users.Search(c.Req.Context(), page, conf.UI.Admin.UserPagingNum, opts)
+	if err != nil {
+		c.Error(err, "search users")
+		return
+	}
+
+	sources, err := db.LoginSources.List(c.Req.Context(), db.ListLoginSourceOptions{})
+	if err != nil {
+		c.Error(err, "list login sources")
+		return
+	}
+	c.Data["Sources"] = sources
+
+	c.Data["Keyword"] = opts.Keyword
+	c.Data["Status"] = filters.Get("status")
+	c.Data["Role"] = filters.Get("role")
+	c.Data["Source"] = filters.Get("source")
+	c.Data["SortType"] = filters.Get("sort")
+	if len(filters) > 0 {
+		c.Data["FilterQuery"] = template.URL("&" + filters.Encode())
+	}
+	c.Data["Total"] = count
+	c.Data["Page"] = paginater.New(int(count), conf.UI.Admin.UserPagingNum, page, 5)
+	c.Data["Users"] = users
+
+	c.Success(USERS)
+}
+
+// userSortOrders maps sort types of the users listing to orders accepted by
+// db.UsersStore.Search.
+var userSortOrders = map[string]string{
+	"oldest":                "id ASC",
+	"newest":                "id DESC",
+	"alphabetically":        "lower_name ASC",
+	"reversealphabetically": "lower_name DESC",
+	"recentupdate":          "updated_unix DESC",
+	"leastupdate":           "updated_unix ASC",
 }
 
 func NewUser(c *context.Context) {
@@ -100,6 +164,9 @@ func NewUserPost(c *context.Context, f form.AdminCrateUser) {
 		case db.IsErrNameNotAllowed(err):
 			c.Data["Err_UserName"] = true
 			c.RenderWithErr(c.Tr("user.form.name_not_allowed", err.(db.ErrNameNotAllowed).Value()), USER_NEW, &f)
+		case db.IsErrPasswordPolicy(err):
+			c.Data["Err_Password"] = true
+			c.RenderWithErr(c.TrPasswordPolicy(err.(db.ErrPasswordPolicy)), USER_NEW, &f)
 		default:
 			c.Error(err, "create user")
 		}
@@ -205,16 +272,30 @@ func EditUserPost(c *context.Context, f form.AdminEditUser) {
 
 	err := db.Users.Update(c.Req.Context(), u.ID, opts)
 	if err != nil {
-		if db.IsErrEmailAlreadyUsed(err) {
+		switch {
+		case db.IsErrEmailAlreadyUsed(err):
 			c.Data["Err_Email"] = true
 			c.RenderWithErr(c.Tr("form.email_been_used"), USER_EDIT, &f)
-		} else {
+		case db.IsErrPasswordPolicy(err):
+			c.Data["Err_Password"] = true
+			c.RenderWithErr(c.TrPasswordPolicy(err.(db.ErrPasswordPolicy)), USER_EDIT, &f)
+		default:
 			c.Error(err, "update user")
 		}
 		return
 	}
 	log.Trace("Account updated by admin %q: %s", c.User.Name, u.Name)
 
+	// Log the user out everywhere when the account is disabled or the password is
+	// changed by the admin.
+	if (f.ProhibitLogin && !u.ProhibitLogin) || f.Password != "" {
+		err = db.Users.InvalidateSessions(c.Req.Context(), u.ID)
+		if err != nil {
+			c.Error(err, "invalidate sessions")
+			return
+		}
+	}
+
 	c.Flash.Success(c.Tr("admin.users.update_profile_success"))
 	c.Redirect(conf.Server.Subpath + "/admin/users/" + c.Params(":userid"))
 }
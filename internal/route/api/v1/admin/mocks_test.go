@@ -0,0 +1,4456 @@
+// Code generated by go-mockgen 1.3.7; DO NOT EDIT.
+//
+// This file was generated by running `go-mockgen` at the root of this repository.
+// To add additional mocks to this or another package, add a new entry to the
+// mockgen.yaml file in the root of this repository.
+
+package admin
+
+import (
+	"context"
+	"sync"
+
+	db "gogs.io/gogs/internal/db"
+)
+
+// MockReposStore is a mock implementation of the ReposStore interface (from
+// the package gogs.io/gogs/internal/db) used for unit testing.
+type MockReposStore struct {
+	// AddDeployKeyFunc is an instance of a mock function object controlling
+	// the behavior of the method AddDeployKey.
+	AddDeployKeyFunc *ReposStoreAddDeployKeyFunc
+	// AddProtectedTagFunc is an instance of a mock function object
+	// controlling the behavior of the method AddProtectedTag.
+	AddProtectedTagFunc *ReposStoreAddProtectedTagFunc
+	// ApplyOrgLabelsFunc is an instance of a mock function object
+	// controlling the behavior of the method ApplyOrgLabels.
+	ApplyOrgLabelsFunc *ReposStoreApplyOrgLabelsFunc
+	// CleanupOrphanedAccessFunc is an instance of a mock function object
+	// controlling the behavior of the method CleanupOrphanedAccess.
+	CleanupOrphanedAccessFunc *ReposStoreCleanupOrphanedAccessFunc
+	// CreateFunc is an instance of a mock function object controlling the
+	// behavior of the method Create.
+	CreateFunc *ReposStoreCreateFunc
+	// CreateFromTemplateFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateFromTemplate.
+	CreateFromTemplateFunc *ReposStoreCreateFromTemplateFunc
+	// DeleteExpiredCollaboratorsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// DeleteExpiredCollaborators.
+	DeleteExpiredCollaboratorsFunc *ReposStoreDeleteExpiredCollaboratorsFunc
+	// ForkFunc is an instance of a mock function object controlling the
+	// behavior of the method Fork.
+	ForkFunc *ReposStoreForkFunc
+	// GetByCollaboratorIDFunc is an instance of a mock function object
+	// controlling the behavior of the method GetByCollaboratorID.
+	GetByCollaboratorIDFunc *ReposStoreGetByCollaboratorIDFunc
+	// GetByCollaboratorIDWithAccessModeFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// GetByCollaboratorIDWithAccessMode.
+	GetByCollaboratorIDWithAccessModeFunc *ReposStoreGetByCollaboratorIDWithAccessModeFunc
+	// GetByIDFunc is an instance of a mock function object controlling the
+	// behavior of the method GetByID.
+	GetByIDFunc *ReposStoreGetByIDFunc
+	// GetByNameFunc is an instance of a mock function object controlling
+	// the behavior of the method GetByName.
+	GetByNameFunc *ReposStoreGetByNameFunc
+	// GetByRedirectFunc is an instance of a mock function object
+	// controlling the behavior of the method GetByRedirect.
+	GetByRedirectFunc *ReposStoreGetByRedirectFunc
+	// HasForkedByFunc is an instance of a mock function object controlling
+	// the behavior of the method HasForkedBy.
+	HasForkedByFunc *ReposStoreHasForkedByFunc
+	// IsNameAvailableFunc is an instance of a mock function object
+	// controlling the behavior of the method IsNameAvailable.
+	IsNameAvailableFunc *ReposStoreIsNameAvailableFunc
+	// ListMirrorsToSyncFunc is an instance of a mock function object
+	// controlling the behavior of the method ListMirrorsToSync.
+	ListMirrorsToSyncFunc *ReposStoreListMirrorsToSyncFunc
+	// ListProtectedTagsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListProtectedTags.
+	ListProtectedTagsFunc *ReposStoreListProtectedTagsFunc
+	// ListTopicsFunc is an instance of a mock function object controlling
+	// the behavior of the method ListTopics.
+	ListTopicsFunc *ReposStoreListTopicsFunc
+	// ListWatchedByUserInOrgFunc is an instance of a mock function object
+	// controlling the behavior of the method ListWatchedByUserInOrg.
+	ListWatchedByUserInOrgFunc *ReposStoreListWatchedByUserInOrgFunc
+	// ListWatchesFunc is an instance of a mock function object controlling
+	// the behavior of the method ListWatches.
+	ListWatchesFunc *ReposStoreListWatchesFunc
+	// RenameFunc is an instance of a mock function object controlling the
+	// behavior of the method Rename.
+	RenameFunc *ReposStoreRenameFunc
+	// RestoreFunc is an instance of a mock function object controlling the
+	// behavior of the method Restore.
+	RestoreFunc *ReposStoreRestoreFunc
+	// SearchByTopicFunc is an instance of a mock function object
+	// controlling the behavior of the method SearchByTopic.
+	SearchByTopicFunc *ReposStoreSearchByTopicFunc
+	// SetArchivedFunc is an instance of a mock function object controlling
+	// the behavior of the method SetArchived.
+	SetArchivedFunc *ReposStoreSetArchivedFunc
+	// SetCollaboratorExpiryFunc is an instance of a mock function object
+	// controlling the behavior of the method SetCollaboratorExpiry.
+	SetCollaboratorExpiryFunc *ReposStoreSetCollaboratorExpiryFunc
+	// SetExternalTrackerFunc is an instance of a mock function object
+	// controlling the behavior of the method SetExternalTracker.
+	SetExternalTrackerFunc *ReposStoreSetExternalTrackerFunc
+	// SetMirrorSyncResultFunc is an instance of a mock function object
+	// controlling the behavior of the method SetMirrorSyncResult.
+	SetMirrorSyncResultFunc *ReposStoreSetMirrorSyncResultFunc
+	// SetPrivateFunc is an instance of a mock function object controlling
+	// the behavior of the method SetPrivate.
+	SetPrivateFunc *ReposStoreSetPrivateFunc
+	// SetTopicsFunc is an instance of a mock function object controlling
+	// the behavior of the method SetTopics.
+	SetTopicsFunc *ReposStoreSetTopicsFunc
+	// SoftDeleteFunc is an instance of a mock function object controlling
+	// the behavior of the method SoftDelete.
+	SoftDeleteFunc *ReposStoreSoftDeleteFunc
+	// StarFunc is an instance of a mock function object controlling the
+	// behavior of the method Star.
+	StarFunc *ReposStoreStarFunc
+	// TouchFunc is an instance of a mock function object controlling the
+	// behavior of the method Touch.
+	TouchFunc *ReposStoreTouchFunc
+	// TransferAllByOwnerFunc is an instance of a mock function object
+	// controlling the behavior of the method TransferAllByOwner.
+	TransferAllByOwnerFunc *ReposStoreTransferAllByOwnerFunc
+	// UpdateSizeFunc is an instance of a mock function object controlling
+	// the behavior of the method UpdateSize.
+	UpdateSizeFunc *ReposStoreUpdateSizeFunc
+	// WatchFunc is an instance of a mock function object controlling the
+	// behavior of the method Watch.
+	WatchFunc *ReposStoreWatchFunc
+}
+
+// NewMockReposStore creates a new mock of the ReposStore interface. All
+// methods return zero values for all results, unless overwritten.
+func NewMockReposStore() *MockReposStore {
+	return &MockReposStore{
+		AddDeployKeyFunc: &ReposStoreAddDeployKeyFunc{
+			defaultHook: func(context.Context, int64, string, string, bool) (r0 error) {
+				return
+			},
+		},
+		AddProtectedTagFunc: &ReposStoreAddProtectedTagFunc{
+			defaultHook: func(context.Context, int64, string, db.AccessMode) (r0 *db.ProtectedTag, r1 error) {
+				return
+			},
+		},
+		ApplyOrgLabelsFunc: &ReposStoreApplyOrgLabelsFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 error) {
+				return
+			},
+		},
+		CleanupOrphanedAccessFunc: &ReposStoreCleanupOrphanedAccessFunc{
+			defaultHook: func(context.Context) (r0 int64, r1 error) {
+				return
+			},
+		},
+		CreateFunc: &ReposStoreCreateFunc{
+			defaultHook: func(context.Context, int64, db.CreateRepoOptions) (r0 *db.Repository, r1 error) {
+				return
+			},
+		},
+		CreateFromTemplateFunc: &ReposStoreCreateFromTemplateFunc{
+			defaultHook: func(context.Context, int64, int64, db.CreateFromTemplateOptions) (r0 *db.Repository, r1 error) {
+				return
+			},
+		},
+		DeleteExpiredCollaboratorsFunc: &ReposStoreDeleteExpiredCollaboratorsFunc{
+			defaultHook: func(context.Context) (r0 error) {
+				return
+			},
+		},
+		ForkFunc: &ReposStoreForkFunc{
+			defaultHook: func(context.Context, int64, int64, db.ForkOptions) (r0 *db.Repository, r1 error) {
+				return
+			},
+		},
+		GetByCollaboratorIDFunc: &ReposStoreGetByCollaboratorIDFunc{
+			defaultHook: func(context.Context, int64, int, string) (r0 []*db.Repository, r1 error) {
+				return
+			},
+		},
+		GetByCollaboratorIDWithAccessModeFunc: &ReposStoreGetByCollaboratorIDWithAccessModeFunc{
+			defaultHook: func(context.Context, int64) (r0 map[*db.Repository]db.AccessMode, r1 error) {
+				return
+			},
+		},
+		GetByIDFunc: &ReposStoreGetByIDFunc{
+			defaultHook: func(context.Context, int64) (r0 *db.Repository, r1 error) {
+				return
+			},
+		},
+		GetByNameFunc: &ReposStoreGetByNameFunc{
+			defaultHook: func(context.Context, int64, string) (r0 *db.Repository, r1 error) {
+				return
+			},
+		},
+		GetByRedirectFunc: &ReposStoreGetByRedirectFunc{
+			defaultHook: func(context.Context, int64, string) (r0 *db.Repository, r1 error) {
+				return
+			},
+		},
+		HasForkedByFunc: &ReposStoreHasForkedByFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 bool) {
+				return
+			},
+		},
+		IsNameAvailableFunc: &ReposStoreIsNameAvailableFunc{
+			defaultHook: func(context.Context, int64, string) (r0 bool, r1 error) {
+				return
+			},
+		},
+		ListMirrorsToSyncFunc: &ReposStoreListMirrorsToSyncFunc{
+			defaultHook: func(context.Context, int64, int, int) (r0 []*db.Mirror, r1 error) {
+				return
+			},
+		},
+		ListProtectedTagsFunc: &ReposStoreListProtectedTagsFunc{
+			defaultHook: func(context.Context, int64) (r0 []*db.ProtectedTag, r1 error) {
+				return
+			},
+		},
+		ListTopicsFunc: &ReposStoreListTopicsFunc{
+			defaultHook: func(context.Context, int64) (r0 []*db.Topic, r1 error) {
+				return
+			},
+		},
+		ListWatchedByUserInOrgFunc: &ReposStoreListWatchedByUserInOrgFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 []*db.Repository, r1 error) {
+				return
+			},
+		},
+		ListWatchesFunc: &ReposStoreListWatchesFunc{
+			defaultHook: func(context.Context, int64) (r0 []*db.Watch, r1 error) {
+				return
+			},
+		},
+		RenameFunc: &ReposStoreRenameFunc{
+			defaultHook: func(context.Context, int64, string) (r0 error) {
+				return
+			},
+		},
+		RestoreFunc: &ReposStoreRestoreFunc{
+			defaultHook: func(context.Context, int64) (r0 error) {
+				return
+			},
+		},
+		SearchByTopicFunc: &ReposStoreSearchByTopicFunc{
+			defaultHook: func(context.Context, string, int, int) (r0 []*db.Repository, r1 int64, r2 error) {
+				return
+			},
+		},
+		SetArchivedFunc: &ReposStoreSetArchivedFunc{
+			defaultHook: func(context.Context, int64, bool) (r0 error) {
+				return
+			},
+		},
+		SetCollaboratorExpiryFunc: &ReposStoreSetCollaboratorExpiryFunc{
+			defaultHook: func(context.Context, int64, int64, int64) (r0 error) {
+				return
+			},
+		},
+		SetExternalTrackerFunc: &ReposStoreSetExternalTrackerFunc{
+			defaultHook: func(context.Context, int64, db.SetExternalTrackerOptions) (r0 error) {
+				return
+			},
+		},
+		SetMirrorSyncResultFunc: &ReposStoreSetMirrorSyncResultFunc{
+			defaultHook: func(context.Context, int64, bool, string) (r0 error) {
+				return
+			},
+		},
+		SetPrivateFunc: &ReposStoreSetPrivateFunc{
+			defaultHook: func(context.Context, int64, bool) (r0 error) {
+				return
+			},
+		},
+		SetTopicsFunc: &ReposStoreSetTopicsFunc{
+			defaultHook: func(context.Context, int64, []string) (r0 error) {
+				return
+			},
+		},
+		SoftDeleteFunc: &ReposStoreSoftDeleteFunc{
+			defaultHook: func(context.Context, int64) (r0 error) {
+				return
+			},
+		},
+		StarFunc: &ReposStoreStarFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 error) {
+				return
+			},
+		},
+		TouchFunc: &ReposStoreTouchFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 error) {
+				return
+			},
+		},
+		TransferAllByOwnerFunc: &ReposStoreTransferAllByOwnerFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 []*db.Repository, r1 error) {
+				return
+			},
+		},
+		UpdateSizeFunc: &ReposStoreUpdateSizeFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 error) {
+				return
+			},
+		},
+		WatchFunc: &ReposStoreWatchFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockReposStore creates a new mock of the ReposStore interface.
+// All methods panic on invocation, unless overwritten.
+func NewStrictMockReposStore() *MockReposStore {
+	return &MockReposStore{
+		AddDeployKeyFunc: &ReposStoreAddDeployKeyFunc{
+			defaultHook: func(context.Context, int64, string, string, bool) error {
+				panic("unexpected invocation of MockReposStore.AddDeployKey")
+			},
+		},
+		AddProtectedTagFunc: &ReposStoreAddProtectedTagFunc{
+			defaultHook: func(context.Context, int64, string, db.AccessMode) (*db.ProtectedTag, error) {
+				panic("unexpected invocation of MockReposStore.AddProtectedTag")
+			},
+		},
+		ApplyOrgLabelsFunc: &ReposStoreApplyOrgLabelsFunc{
+			defaultHook: func(context.Context, int64, int64) error {
+				panic("unexpected invocation of MockReposStore.ApplyOrgLabels")
+			},
+		},
+		CleanupOrphanedAccessFunc: &ReposStoreCleanupOrphanedAccessFunc{
+			defaultHook: func(context.Context) (int64, error) {
+				panic("unexpected invocation of MockReposStore.CleanupOrphanedAccess")
+			},
+		},
+		CreateFunc: &ReposStoreCreateFunc{
+			defaultHook: func(context.Context, int64, db.CreateRepoOptions) (*db.Repository, error) {
+				panic("unexpected invocation of MockReposStore.Create")
+			},
+		},
+		CreateFromTemplateFunc: &ReposStoreCreateFromTemplateFunc{
+			defaultHook: func(context.Context, int64, int64, db.CreateFromTemplateOptions) (*db.Repository, error) {
+				panic("unexpected invocation of MockReposStore.CreateFromTemplate")
+			},
+		},
+		DeleteExpiredCollaboratorsFunc: &ReposStoreDeleteExpiredCollaboratorsFunc{
+			defaultHook: func(context.Context) error {
+				panic("unexpected invocation of MockReposStore.DeleteExpiredCollaborators")
+			},
+		},
+		ForkFunc: &ReposStoreForkFunc{
+			defaultHook: func(context.Context, int64, int64, db.ForkOptions) (*db.Repository, error) {
+				panic("unexpected invocation of MockReposStore.Fork")
+			},
+		},
+		GetByCollaboratorIDFunc: &ReposStoreGetByCollaboratorIDFunc{
+			defaultHook: func(context.Context, int64, int, string) ([]*db.Repository, error) {
+				panic("unexpected invocation of MockReposStore.GetByCollaboratorID")
+			},
+		},
+		GetByCollaboratorIDWithAccessModeFunc: &ReposStoreGetByCollaboratorIDWithAccessModeFunc{
+			defaultHook: func(context.Context, int64) (map[*db.Repository]db.AccessMode, error) {
+				panic("unexpected invocation of MockReposStore.GetByCollaboratorIDWithAccessMode")
+			},
+		},
+		GetByIDFunc: &ReposStoreGetByIDFunc{
+			defaultHook: func(context.Context, int64) (*db.Repository, error) {
+				panic("unexpected invocation of MockReposStore.GetByID")
+			},
+		},
+		GetByNameFunc: &ReposStoreGetByNameFunc{
+			defaultHook: func(context.Context, int64, string) (*db.Repository, error) {
+				panic("unexpected invocation of MockReposStore.GetByName")
+			},
+		},
+		GetByRedirectFunc: &ReposStoreGetByRedirectFunc{
+			defaultHook: func(context.Context, int64, string) (*db.Repository, error) {
+				panic("unexpected invocation of MockReposStore.GetByRedirect")
+			},
+		},
+		HasForkedByFunc: &ReposStoreHasForkedByFunc{
+			defaultHook: func(context.Context, int64, int64) bool {
+				panic("unexpected invocation of MockReposStore.HasForkedBy")
+			},
+		},
+		IsNameAvailableFunc: &ReposStoreIsNameAvailableFunc{
+			defaultHook: func(context.Context, int64, string) (bool, error) {
+				panic("unexpected invocation of MockReposStore.IsNameAvailable")
+			},
+		},
+		ListMirrorsToSyncFunc: &ReposStoreListMirrorsToSyncFunc{
+			defaultHook: func(context.Context, int64, int, int) ([]*db.Mirror, error) {
+				panic("unexpected invocation of MockReposStore.ListMirrorsToSync")
+			},
+		},
+		ListProtectedTagsFunc: &ReposStoreListProtectedTagsFunc{
+			defaultHook: func(context.Context, int64) ([]*db.ProtectedTag, error) {
+				panic("unexpected invocation of MockReposStore.ListProtectedTags")
+			},
+		},
+		ListTopicsFunc: &ReposStoreListTopicsFunc{
+			defaultHook: func(context.Context, int64) ([]*db.Topic, error) {
+				panic("unexpected invocation of MockReposStore.ListTopics")
+			},
+		},
+		ListWatchedByUserInOrgFunc: &ReposStoreListWatchedByUserInOrgFunc{
+			defaultHook: func(context.Context, int64, int64) ([]*db.Repository, error) {
+				panic("unexpected invocation of MockReposStore.ListWatchedByUserInOrg")
+			},
+		},
+		ListWatchesFunc: &ReposStoreListWatchesFunc{
+			defaultHook: func(context.Context, int64) ([]*db.Watch, error) {
+				panic("unexpected invocation of MockReposStore.ListWatches")
+			},
+		},
+		RenameFunc: &ReposStoreRenameFunc{
+			defaultHook: func(context.Context, int64, string) error {
+				panic("unexpected invocation of MockReposStore.Rename")
+			},
+		},
+		RestoreFunc: &ReposStoreRestoreFunc{
+			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockReposStore.Restore")
+			},
+		},
+		SearchByTopicFunc: &ReposStoreSearchByTopicFunc{
+			defaultHook: func(context.Context, string, int, int) ([]*db.Repository, int64, error) {
+				panic("unexpected invocation of MockReposStore.SearchByTopic")
+			},
+		},
+		SetArchivedFunc: &ReposStoreSetArchivedFunc{
+			defaultHook: func(context.Context, int64, bool) error {
+				panic("unexpected invocation of MockReposStore.SetArchived")
+			},
+		},
+		SetCollaboratorExpiryFunc: &ReposStoreSetCollaboratorExpiryFunc{
+			defaultHook: func(context.Context, int64, int64, int64) error {
+				panic("unexpected invocation of MockReposStore.SetCollaboratorExpiry")
+			},
+		},
+		SetExternalTrackerFunc: &ReposStoreSetExternalTrackerFunc{
+			defaultHook: func(context.Context, int64, db.SetExternalTrackerOptions) error {
+				panic("unexpected invocation of MockReposStore.SetExternalTracker")
+			},
+		},
+		SetMirrorSyncResultFunc: &ReposStoreSetMirrorSyncResultFunc{
+			defaultHook: func(context.Context, int64, bool, string) error {
+				panic("unexpected invocation of MockReposStore.SetMirrorSyncResult")
+			},
+		},
+		SetPrivateFunc: &ReposStoreSetPrivateFunc{
+			defaultHook: func(context.Context, int64, bool) error {
+				panic("unexpected invocation of MockReposStore.SetPrivate")
+			},
+		},
+		SetTopicsFunc: &ReposStoreSetTopicsFunc{
+			defaultHook: func(context.Context, int64, []string) error {
+				panic("unexpected invocation of MockReposStore.SetTopics")
+			},
+		},
+		SoftDeleteFunc: &ReposStoreSoftDeleteFunc{
+			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockReposStore.SoftDelete")
+			},
+		},
+		StarFunc: &ReposStoreStarFunc{
+			defaultHook: func(context.Context, int64, int64) error {
+				panic("unexpected invocation of MockReposStore.Star")
+			},
+		},
+		TouchFunc: &ReposStoreTouchFunc{
+			defaultHook: func(context.Context, int64, int64) error {
+				panic("unexpected invocation of MockReposStore.Touch")
+			},
+		},
+		TransferAllByOwnerFunc: &ReposStoreTransferAllByOwnerFunc{
+			defaultHook: func(context.Context, int64, int64) ([]*db.Repository, error) {
+				panic("unexpected invocation of MockReposStore.TransferAllByOwner")
+			},
+		},
+		UpdateSizeFunc: &ReposStoreUpdateSizeFunc{
+			defaultHook: func(context.Context, int64, int64) error {
+				panic("unexpected invocation of MockReposStore.UpdateSize")
+			},
+		},
+		WatchFunc: &ReposStoreWatchFunc{
+			defaultHook: func(context.Context, int64, int64) error {
+				panic("unexpected invocation of MockReposStore.Watch")
+			},
+		},
+	}
+}
+
+// NewMockReposStoreFrom creates a new mock of the MockReposStore interface.
+// All methods delegate to the given implementation, unless overwritten.
+func NewMockReposStoreFrom(i db.ReposStore) *MockReposStore {
+	return &MockReposStore{
+		AddDeployKeyFunc: &ReposStoreAddDeployKeyFunc{
+			defaultHook: i.AddDeployKey,
+		},
+		AddProtectedTagFunc: &ReposStoreAddProtectedTagFunc{
+			defaultHook: i.AddProtectedTag,
+		},
+		ApplyOrgLabelsFunc: &ReposStoreApplyOrgLabelsFunc{
+			defaultHook: i.ApplyOrgLabels,
+		},
+		CleanupOrphanedAccessFunc: &ReposStoreCleanupOrphanedAccessFunc{
+			defaultHook: i.CleanupOrphanedAccess,
+		},
+		CreateFunc: &ReposStoreCreateFunc{
+			defaultHook: i.Create,
+		},
+		CreateFromTemplateFunc: &ReposStoreCreateFromTemplateFunc{
+			defaultHook: i.CreateFromTemplate,
+		},
+		DeleteExpiredCollaboratorsFunc: &ReposStoreDeleteExpiredCollaboratorsFunc{
+			defaultHook: i.DeleteExpiredCollaborators,
+		},
+		ForkFunc: &ReposStoreForkFunc{
+			defaultHook: i.Fork,
+		},
+		GetByCollaboratorIDFunc: &ReposStoreGetByCollaboratorIDFunc{
+			defaultHook: i.GetByCollaboratorID,
+		},
+		GetByCollaboratorIDWithAccessModeFunc: &ReposStoreGetByCollaboratorIDWithAccessModeFunc{
+			defaultHook: i.GetByCollaboratorIDWithAccessMode,
+		},
+		GetByIDFunc: &ReposStoreGetByIDFunc{
+			defaultHook: i.GetByID,
+		},
+		GetByNameFunc: &ReposStoreGetByNameFunc{
+			defaultHook: i.GetByName,
+		},
+		GetByRedirectFunc: &ReposStoreGetByRedirectFunc{
+			defaultHook: i.GetByRedirect,
+		},
+		HasForkedByFunc: &ReposStoreHasForkedByFunc{
+			defaultHook: i.HasForkedBy,
+		},
+		IsNameAvailableFunc: &ReposStoreIsNameAvailableFunc{
+			defaultHook: i.IsNameAvailable,
+		},
+		ListMirrorsToSyncFunc: &ReposStoreListMirrorsToSyncFunc{
+			defaultHook: i.ListMirrorsToSync,
+		},
+		ListProtectedTagsFunc: &ReposStoreListProtectedTagsFunc{
+			defaultHook: i.ListProtectedTags,
+		},
+		ListTopicsFunc: &ReposStoreListTopicsFunc{
+			defaultHook: i.ListTopics,
+		},
+		ListWatchedByUserInOrgFunc: &ReposStoreListWatchedByUserInOrgFunc{
+			defaultHook: i.ListWatchedByUserInOrg,
+		},
+		ListWatchesFunc: &ReposStoreListWatchesFunc{
+			defaultHook: i.ListWatches,
+		},
+		RenameFunc: &ReposStoreRenameFunc{
+			defaultHook: i.Rename,
+		},
+		RestoreFunc: &ReposStoreRestoreFunc{
+			defaultHook: i.Restore,
+		},
+		SearchByTopicFunc: &ReposStoreSearchByTopicFunc{
+			defaultHook: i.SearchByTopic,
+		},
+		SetArchivedFunc: &ReposStoreSetArchivedFunc{
+			defaultHook: i.SetArchived,
+		},
+		SetCollaboratorExpiryFunc: &ReposStoreSetCollaboratorExpiryFunc{
+			defaultHook: i.SetCollaboratorExpiry,
+		},
+		SetExternalTrackerFunc: &ReposStoreSetExternalTrackerFunc{
+			defaultHook: i.SetExternalTracker,
+		},
+		SetMirrorSyncResultFunc: &ReposStoreSetMirrorSyncResultFunc{
+			defaultHook: i.SetMirrorSyncResult,
+		},
+		SetPrivateFunc: &ReposStoreSetPrivateFunc{
+			defaultHook: i.SetPrivate,
+		},
+		SetTopicsFunc: &ReposStoreSetTopicsFunc{
+			defaultHook: i.SetTopics,
+		},
+		SoftDeleteFunc: &ReposStoreSoftDeleteFunc{
+			defaultHook: i.SoftDelete,
+		},
+		StarFunc: &ReposStoreStarFunc{
+			defaultHook: i.Star,
+		},
+		TouchFunc: &ReposStoreTouchFunc{
+			defaultHook: i.Touch,
+		},
+		TransferAllByOwnerFunc: &ReposStoreTransferAllByOwnerFunc{
+			defaultHook: i.TransferAllByOwner,
+		},
+		UpdateSizeFunc: &ReposStoreUpdateSizeFunc{
+			defaultHook: i.UpdateSize,
+		},
+		WatchFunc: &ReposStoreWatchFunc{
+			defaultHook: i.Watch,
+		},
+	}
+}
+
+// ReposStoreAddDeployKeyFunc describes the behavior when the AddDeployKey
+// method of the parent MockReposStore instance is invoked.
+type ReposStoreAddDeployKeyFunc struct {
+	defaultHook func(context.Context, int64, string, string, bool) error
+	hooks       []func(context.Context, int64, string, string, bool) error
+	history     []ReposStoreAddDeployKeyFuncCall
+	mutex       sync.Mutex
+}
+
+// AddDeployKey delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockReposStore) AddDeployKey(v0 context.Context, v1 int64, v2 string, v3 string, v4 bool) error {
+	r0 := m.AddDeployKeyFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.AddDeployKeyFunc.appendCall(ReposStoreAddDeployKeyFuncCall{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the AddDeployKey method
+// of the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreAddDeployKeyFunc) SetDefaultHook(hook func(context.Context, int64, string, string, bool) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AddDeployKey method of the parent MockReposStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ReposStoreAddDeployKeyFunc) PushHook(hook func(context.Context, int64, string, string, bool) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreAddDeployKeyFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, string, string, bool) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreAddDeployKeyFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, string, string, bool) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreAddDeployKeyFunc) nextHook() func(context.Context, int64, string, string, bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreAddDeployKeyFunc) appendCall(r0 ReposStoreAddDeployKeyFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreAddDeployKeyFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreAddDeployKeyFunc) History() []ReposStoreAddDeployKeyFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreAddDeployKeyFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreAddDeployKeyFuncCall is an object that describes an invocation
+// of method AddDeployKey on an instance of MockReposStore.
+type ReposStoreAddDeployKeyFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreAddDeployKeyFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreAddDeployKeyFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreAddProtectedTagFunc describes the behavior when the
+// AddProtectedTag method of the parent MockReposStore instance is invoked.
+type ReposStoreAddProtectedTagFunc struct {
+	defaultHook func(context.Context, int64, string, db.AccessMode) (*db.ProtectedTag, error)
+	hooks       []func(context.Context, int64, string, db.AccessMode) (*db.ProtectedTag, error)
+	history     []ReposStoreAddProtectedTagFuncCall
+	mutex       sync.Mutex
+}
+
+// AddProtectedTag delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockReposStore) AddProtectedTag(v0 context.Context, v1 int64, v2 string, v3 db.AccessMode) (*db.ProtectedTag, error) {
+	r0, r1 := m.AddProtectedTagFunc.nextHook()(v0, v1, v2, v3)
+	m.AddProtectedTagFunc.appendCall(ReposStoreAddProtectedTagFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the AddProtectedTag
+// method of the parent MockReposStore instance is invoked and the hook
+// queue is empty.
+func (f *ReposStoreAddProtectedTagFunc) SetDefaultHook(hook func(context.Context, int64, string, db.AccessMode) (*db.ProtectedTag, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AddProtectedTag method of the parent MockReposStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ReposStoreAddProtectedTagFunc) PushHook(hook func(context.Context, int64, string, db.AccessMode) (*db.ProtectedTag, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreAddProtectedTagFunc) SetDefaultReturn(r0 *db.ProtectedTag, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, string, db.AccessMode) (*db.ProtectedTag, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreAddProtectedTagFunc) PushReturn(r0 *db.ProtectedTag, r1 error) {
+	f.PushHook(func(context.Context, int64, string, db.AccessMode) (*db.ProtectedTag, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreAddProtectedTagFunc) nextHook() func(context.Context, int64, string, db.AccessMode) (*db.ProtectedTag, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreAddProtectedTagFunc) appendCall(r0 ReposStoreAddProtectedTagFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreAddProtectedTagFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreAddProtectedTagFunc) History() []ReposStoreAddProtectedTagFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreAddProtectedTagFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreAddProtectedTagFuncCall is an object that describes an
+// invocation of method AddProtectedTag on an instance of MockReposStore.
+type ReposStoreAddProtectedTagFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 db.AccessMode
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *db.ProtectedTag
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreAddProtectedTagFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreAddProtectedTagFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreApplyOrgLabelsFunc describes the behavior when the
+// ApplyOrgLabels method of the parent MockReposStore instance is invoked.
+type ReposStoreApplyOrgLabelsFunc struct {
+	defaultHook func(context.Context, int64, int64) error
+	hooks       []func(context.Context, int64, int64) error
+	history     []ReposStoreApplyOrgLabelsFuncCall
+	mutex       sync.Mutex
+}
+
+// ApplyOrgLabels delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockReposStore) ApplyOrgLabels(v0 context.Context, v1 int64, v2 int64) error {
+	r0 := m.ApplyOrgLabelsFunc.nextHook()(v0, v1, v2)
+	m.ApplyOrgLabelsFunc.appendCall(ReposStoreApplyOrgLabelsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ApplyOrgLabels
+// method of the parent MockReposStore instance is invoked and the hook
+// queue is empty.
+func (f *ReposStoreApplyOrgLabelsFunc) SetDefaultHook(hook func(context.Context, int64, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ApplyOrgLabels method of the parent MockReposStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ReposStoreApplyOrgLabelsFunc) PushHook(hook func(context.Context, int64, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreApplyOrgLabelsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreApplyOrgLabelsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreApplyOrgLabelsFunc) nextHook() func(context.Context, int64, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreApplyOrgLabelsFunc) appendCall(r0 ReposStoreApplyOrgLabelsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreApplyOrgLabelsFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreApplyOrgLabelsFunc) History() []ReposStoreApplyOrgLabelsFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreApplyOrgLabelsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreApplyOrgLabelsFuncCall is an object that describes an
+// invocation of method ApplyOrgLabels on an instance of MockReposStore.
+type ReposStoreApplyOrgLabelsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreApplyOrgLabelsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreApplyOrgLabelsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreCleanupOrphanedAccessFunc describes the behavior when the
+// CleanupOrphanedAccess method of the parent MockReposStore instance is
+// invoked.
+type ReposStoreCleanupOrphanedAccessFunc struct {
+	defaultHook func(context.Context) (int64, error)
+	hooks       []func(context.Context) (int64, error)
+	history     []ReposStoreCleanupOrphanedAccessFuncCall
+	mutex       sync.Mutex
+}
+
+// CleanupOrphanedAccess delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockReposStore) CleanupOrphanedAccess(v0 context.Context) (int64, error) {
+	r0, r1 := m.CleanupOrphanedAccessFunc.nextHook()(v0)
+	m.CleanupOrphanedAccessFunc.appendCall(ReposStoreCleanupOrphanedAccessFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CleanupOrphanedAccess method of the parent MockReposStore instance is
+// invoked and the hook queue is empty.
+func (f *ReposStoreCleanupOrphanedAccessFunc) SetDefaultHook(hook func(context.Context) (int64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CleanupOrphanedAccess method of the parent MockReposStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ReposStoreCleanupOrphanedAccessFunc) PushHook(hook func(context.Context) (int64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreCleanupOrphanedAccessFunc) SetDefaultReturn(r0 int64, r1 error) {
+	f.SetDefaultHook(func(context.Context) (int64, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreCleanupOrphanedAccessFunc) PushReturn(r0 int64, r1 error) {
+	f.PushHook(func(context.Context) (int64, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreCleanupOrphanedAccessFunc) nextHook() func(context.Context) (int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreCleanupOrphanedAccessFunc) appendCall(r0 ReposStoreCleanupOrphanedAccessFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreCleanupOrphanedAccessFuncCall
+// objects describing the invocations of this function.
+func (f *ReposStoreCleanupOrphanedAccessFunc) History() []ReposStoreCleanupOrphanedAccessFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreCleanupOrphanedAccessFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreCleanupOrphanedAccessFuncCall is an object that describes an
+// invocation of method CleanupOrphanedAccess on an instance of
+// MockReposStore.
+type ReposStoreCleanupOrphanedAccessFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int64
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreCleanupOrphanedAccessFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreCleanupOrphanedAccessFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreCreateFunc describes the behavior when the Create method of the
+// parent MockReposStore instance is invoked.
+type ReposStoreCreateFunc struct {
+	defaultHook func(context.Context, int64, db.CreateRepoOptions) (*db.Repository, error)
+	hooks       []func(context.Context, int64, db.CreateRepoOptions) (*db.Repository, error)
+	history     []ReposStoreCreateFuncCall
+	mutex       sync.Mutex
+}
+
+// Create delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockReposStore) Create(v0 context.Context, v1 int64, v2 db.CreateRepoOptions) (*db.Repository, error) {
+	r0, r1 := m.CreateFunc.nextHook()(v0, v1, v2)
+	m.CreateFunc.appendCall(ReposStoreCreateFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Create method of the
+// parent MockReposStore instance is invoked and the hook queue is empty.
+func (f *ReposStoreCreateFunc) SetDefaultHook(hook func(context.Context, int64, db.CreateRepoOptions) (*db.Repository, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Create method of the parent MockReposStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreCreateFunc) PushHook(hook func(context.Context, int64, db.CreateRepoOptions) (*db.Repository, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreCreateFunc) SetDefaultReturn(r0 *db.Repository, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, db.CreateRepoOptions) (*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreCreateFunc) PushReturn(r0 *db.Repository, r1 error) {
+	f.PushHook(func(context.Context, int64, db.CreateRepoOptions) (*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreCreateFunc) nextHook() func(context.Context, int64, db.CreateRepoOptions) (*db.Repository, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreCreateFunc) appendCall(r0 ReposStoreCreateFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreCreateFuncCall objects describing
+// the invocations of this function.
+func (f *ReposStoreCreateFunc) History() []ReposStoreCreateFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreCreateFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreCreateFuncCall is an object that describes an invocation of
+// method Create on an instance of MockReposStore.
+type ReposStoreCreateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 db.CreateRepoOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *db.Repository
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreCreateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreCreateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreCreateFromTemplateFunc describes the behavior when the
+// CreateFromTemplate method of the parent MockReposStore instance is
+// invoked.
+type ReposStoreCreateFromTemplateFunc struct {
+	defaultHook func(context.Context, int64, int64, db.CreateFromTemplateOptions) (*db.Repository, error)
+	hooks       []func(context.Context, int64, int64, db.CreateFromTemplateOptions) (*db.Repository, error)
+	history     []ReposStoreCreateFromTemplateFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateFromTemplate delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockReposStore) CreateFromTemplate(v0 context.Context, v1 int64, v2 int64, v3 db.CreateFromTemplateOptions) (*db.Repository, error) {
+	r0, r1 := m.CreateFromTemplateFunc.nextHook()(v0, v1, v2, v3)
+	m.CreateFromTemplateFunc.appendCall(ReposStoreCreateFromTemplateFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CreateFromTemplate
+// method of the parent MockReposStore instance is invoked and the hook
+// queue is empty.
+func (f *ReposStoreCreateFromTemplateFunc) SetDefaultHook(hook func(context.Context, int64, int64, db.CreateFromTemplateOptions) (*db.Repository, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateFromTemplate method of the parent MockReposStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ReposStoreCreateFromTemplateFunc) PushHook(hook func(context.Context, int64, int64, db.CreateFromTemplateOptions) (*db.Repository, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreCreateFromTemplateFunc) SetDefaultReturn(r0 *db.Repository, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64, db.CreateFromTemplateOptions) (*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreCreateFromTemplateFunc) PushReturn(r0 *db.Repository, r1 error) {
+	f.PushHook(func(context.Context, int64, int64, db.CreateFromTemplateOptions) (*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreCreateFromTemplateFunc) nextHook() func(context.Context, int64, int64, db.CreateFromTemplateOptions) (*db.Repository, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreCreateFromTemplateFunc) appendCall(r0 ReposStoreCreateFromTemplateFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreCreateFromTemplateFuncCall
+// objects describing the invocations of this function.
+func (f *ReposStoreCreateFromTemplateFunc) History() []ReposStoreCreateFromTemplateFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreCreateFromTemplateFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreCreateFromTemplateFuncCall is an object that describes an
+// invocation of method CreateFromTemplate on an instance of MockReposStore.
+type ReposStoreCreateFromTemplateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 db.CreateFromTemplateOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *db.Repository
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreCreateFromTemplateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreCreateFromTemplateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreDeleteExpiredCollaboratorsFunc describes the behavior when the
+// DeleteExpiredCollaborators method of the parent MockReposStore instance
+// is invoked.
+type ReposStoreDeleteExpiredCollaboratorsFunc struct {
+	defaultHook func(context.Context) error
+	hooks       []func(context.Context) error
+	history     []ReposStoreDeleteExpiredCollaboratorsFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteExpiredCollaborators delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockReposStore) DeleteExpiredCollaborators(v0 context.Context) error {
+	r0 := m.DeleteExpiredCollaboratorsFunc.nextHook()(v0)
+	m.DeleteExpiredCollaboratorsFunc.appendCall(ReposStoreDeleteExpiredCollaboratorsFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteExpiredCollaborators method of the parent MockReposStore instance
+// is invoked and the hook queue is empty.
+func (f *ReposStoreDeleteExpiredCollaboratorsFunc) SetDefaultHook(hook func(context.Context) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteExpiredCollaborators method of the parent MockReposStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ReposStoreDeleteExpiredCollaboratorsFunc) PushHook(hook func(context.Context) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreDeleteExpiredCollaboratorsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreDeleteExpiredCollaboratorsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreDeleteExpiredCollaboratorsFunc) nextHook() func(context.Context) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreDeleteExpiredCollaboratorsFunc) appendCall(r0 ReposStoreDeleteExpiredCollaboratorsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// ReposStoreDeleteExpiredCollaboratorsFuncCall objects describing the
+// invocations of this function.
+func (f *ReposStoreDeleteExpiredCollaboratorsFunc) History() []ReposStoreDeleteExpiredCollaboratorsFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreDeleteExpiredCollaboratorsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreDeleteExpiredCollaboratorsFuncCall is an object that describes
+// an invocation of method DeleteExpiredCollaborators on an instance of
+// MockReposStore.
+type ReposStoreDeleteExpiredCollaboratorsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreDeleteExpiredCollaboratorsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreDeleteExpiredCollaboratorsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreForkFunc describes the behavior when the Fork method of the
+// parent MockReposStore instance is invoked.
+type ReposStoreForkFunc struct {
+	defaultHook func(context.Context, int64, int64, db.ForkOptions) (*db.Repository, error)
+	hooks       []func(context.Context, int64, int64, db.ForkOptions) (*db.Repository, error)
+	history     []ReposStoreForkFuncCall
+	mutex       sync.Mutex
+}
+
+// Fork delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockReposStore) Fork(v0 context.Context, v1 int64, v2 int64, v3 db.ForkOptions) (*db.Repository, error) {
+	r0, r1 := m.ForkFunc.nextHook()(v0, v1, v2, v3)
+	m.ForkFunc.appendCall(ReposStoreForkFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Fork method of the
+// parent MockReposStore instance is invoked and the hook queue is empty.
+func (f *ReposStoreForkFunc) SetDefaultHook(hook func(context.Context, int64, int64, db.ForkOptions) (*db.Repository, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Fork method of the parent MockReposStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *ReposStoreForkFunc) PushHook(hook func(context.Context, int64, int64, db.ForkOptions) (*db.Repository, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreForkFunc) SetDefaultReturn(r0 *db.Repository, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64, db.ForkOptions) (*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreForkFunc) PushReturn(r0 *db.Repository, r1 error) {
+	f.PushHook(func(context.Context, int64, int64, db.ForkOptions) (*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreForkFunc) nextHook() func(context.Context, int64, int64, db.ForkOptions) (*db.Repository, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreForkFunc) appendCall(r0 ReposStoreForkFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreForkFuncCall objects describing
+// the invocations of this function.
+func (f *ReposStoreForkFunc) History() []ReposStoreForkFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreForkFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreForkFuncCall is an object that describes an invocation of
+// method Fork on an instance of MockReposStore.
+type ReposStoreForkFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 db.ForkOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *db.Repository
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreForkFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreForkFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreGetByCollaboratorIDFunc describes the behavior when the
+// GetByCollaboratorID method of the parent MockReposStore instance is
+// invoked.
+type ReposStoreGetByCollaboratorIDFunc struct {
+	defaultHook func(context.Context, int64, int, string) ([]*db.Repository, error)
+	hooks       []func(context.Context, int64, int, string) ([]*db.Repository, error)
+	history     []ReposStoreGetByCollaboratorIDFuncCall
+	mutex       sync.Mutex
+}
+
+// GetByCollaboratorID delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockReposStore) GetByCollaboratorID(v0 context.Context, v1 int64, v2 int, v3 string) ([]*db.Repository, error) {
+	r0, r1 := m.GetByCollaboratorIDFunc.nextHook()(v0, v1, v2, v3)
+	m.GetByCollaboratorIDFunc.appendCall(ReposStoreGetByCollaboratorIDFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetByCollaboratorID
+// method of the parent MockReposStore instance is invoked and the hook
+// queue is empty.
+func (f *ReposStoreGetByCollaboratorIDFunc) SetDefaultHook(hook func(context.Context, int64, int, string) ([]*db.Repository, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetByCollaboratorID method of the parent MockReposStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ReposStoreGetByCollaboratorIDFunc) PushHook(hook func(context.Context, int64, int, string) ([]*db.Repository, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreGetByCollaboratorIDFunc) SetDefaultReturn(r0 []*db.Repository, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int, string) ([]*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreGetByCollaboratorIDFunc) PushReturn(r0 []*db.Repository, r1 error) {
+	f.PushHook(func(context.Context, int64, int, string) ([]*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreGetByCollaboratorIDFunc) nextHook() func(context.Context, int64, int, string) ([]*db.Repository, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreGetByCollaboratorIDFunc) appendCall(r0 ReposStoreGetByCollaboratorIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreGetByCollaboratorIDFuncCall
+// objects describing the invocations of this function.
+func (f *ReposStoreGetByCollaboratorIDFunc) History() []ReposStoreGetByCollaboratorIDFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreGetByCollaboratorIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreGetByCollaboratorIDFuncCall is an object that describes an
+// invocation of method GetByCollaboratorID on an instance of
+// MockReposStore.
+type ReposStoreGetByCollaboratorIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.Repository
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreGetByCollaboratorIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreGetByCollaboratorIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreGetByCollaboratorIDWithAccessModeFunc describes the behavior
+// when the GetByCollaboratorIDWithAccessMode method of the parent
+// MockReposStore instance is invoked.
+type ReposStoreGetByCollaboratorIDWithAccessModeFunc struct {
+	defaultHook func(context.Context, int64) (map[*db.Repository]db.AccessMode, error)
+	hooks       []func(context.Context, int64) (map[*db.Repository]db.AccessMode, error)
+	history     []ReposStoreGetByCollaboratorIDWithAccessModeFuncCall
+	mutex       sync.Mutex
+}
+
+// GetByCollaboratorIDWithAccessMode delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockReposStore) GetByCollaboratorIDWithAccessMode(v0 context.Context, v1 int64) (map[*db.Repository]db.AccessMode, error) {
+	r0, r1 := m.GetByCollaboratorIDWithAccessModeFunc.nextHook()(v0, v1)
+	m.GetByCollaboratorIDWithAccessModeFunc.appendCall(ReposStoreGetByCollaboratorIDWithAccessModeFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetByCollaboratorIDWithAccessMode method of the parent MockReposStore
+// instance is invoked and the hook queue is empty.
+func (f *ReposStoreGetByCollaboratorIDWithAccessModeFunc) SetDefaultHook(hook func(context.Context, int64) (map[*db.Repository]db.AccessMode, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetByCollaboratorIDWithAccessMode method of the parent MockReposStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *ReposStoreGetByCollaboratorIDWithAccessModeFunc) PushHook(hook func(context.Context, int64) (map[*db.Repository]db.AccessMode, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreGetByCollaboratorIDWithAccessModeFunc) SetDefaultReturn(r0 map[*db.Repository]db.AccessMode, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (map[*db.Repository]db.AccessMode, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreGetByCollaboratorIDWithAccessModeFunc) PushReturn(r0 map[*db.Repository]db.AccessMode, r1 error) {
+	f.PushHook(func(context.Context, int64) (map[*db.Repository]db.AccessMode, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreGetByCollaboratorIDWithAccessModeFunc) nextHook() func(context.Context, int64) (map[*db.Repository]db.AccessMode, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreGetByCollaboratorIDWithAccessModeFunc) appendCall(r0 ReposStoreGetByCollaboratorIDWithAccessModeFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// ReposStoreGetByCollaboratorIDWithAccessModeFuncCall objects describing
+// the invocations of this function.
+func (f *ReposStoreGetByCollaboratorIDWithAccessModeFunc) History() []ReposStoreGetByCollaboratorIDWithAccessModeFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreGetByCollaboratorIDWithAccessModeFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreGetByCollaboratorIDWithAccessModeFuncCall is an object that
+// describes an invocation of method GetByCollaboratorIDWithAccessMode on an
+// instance of MockReposStore.
+type ReposStoreGetByCollaboratorIDWithAccessModeFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[*db.Repository]db.AccessMode
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreGetByCollaboratorIDWithAccessModeFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreGetByCollaboratorIDWithAccessModeFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreGetByIDFunc describes the behavior when the GetByID method of
+// the parent MockReposStore instance is invoked.
+type ReposStoreGetByIDFunc struct {
+	defaultHook func(context.Context, int64) (*db.Repository, error)
+	hooks       []func(context.Context, int64) (*db.Repository, error)
+	history     []ReposStoreGetByIDFuncCall
+	mutex       sync.Mutex
+}
+
+// GetByID delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockReposStore) GetByID(v0 context.Context, v1 int64) (*db.Repository, error) {
+	r0, r1 := m.GetByIDFunc.nextHook()(v0, v1)
+	m.GetByIDFunc.appendCall(ReposStoreGetByIDFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetByID method of
+// the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreGetByIDFunc) SetDefaultHook(hook func(context.Context, int64) (*db.Repository, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetByID method of the parent MockReposStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreGetByIDFunc) PushHook(hook func(context.Context, int64) (*db.Repository, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreGetByIDFunc) SetDefaultReturn(r0 *db.Repository, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreGetByIDFunc) PushReturn(r0 *db.Repository, r1 error) {
+	f.PushHook(func(context.Context, int64) (*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreGetByIDFunc) nextHook() func(context.Context, int64) (*db.Repository, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreGetByIDFunc) appendCall(r0 ReposStoreGetByIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreGetByIDFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreGetByIDFunc) History() []ReposStoreGetByIDFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreGetByIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreGetByIDFuncCall is an object that describes an invocation of
+// method GetByID on an instance of MockReposStore.
+type ReposStoreGetByIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *db.Repository
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreGetByIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreGetByIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreGetByNameFunc describes the behavior when the GetByName method
+// of the parent MockReposStore instance is invoked.
+type ReposStoreGetByNameFunc struct {
+	defaultHook func(context.Context, int64, string) (*db.Repository, error)
+	hooks       []func(context.Context, int64, string) (*db.Repository, error)
+	history     []ReposStoreGetByNameFuncCall
+	mutex       sync.Mutex
+}
+
+// GetByName delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockReposStore) GetByName(v0 context.Context, v1 int64, v2 string) (*db.Repository, error) {
+	r0, r1 := m.GetByNameFunc.nextHook()(v0, v1, v2)
+	m.GetByNameFunc.appendCall(ReposStoreGetByNameFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetByName method of
+// the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreGetByNameFunc) SetDefaultHook(hook func(context.Context, int64, string) (*db.Repository, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetByName method of the parent MockReposStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreGetByNameFunc) PushHook(hook func(context.Context, int64, string) (*db.Repository, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreGetByNameFunc) SetDefaultReturn(r0 *db.Repository, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, string) (*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreGetByNameFunc) PushReturn(r0 *db.Repository, r1 error) {
+	f.PushHook(func(context.Context, int64, string) (*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreGetByNameFunc) nextHook() func(context.Context, int64, string) (*db.Repository, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreGetByNameFunc) appendCall(r0 ReposStoreGetByNameFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreGetByNameFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreGetByNameFunc) History() []ReposStoreGetByNameFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreGetByNameFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreGetByNameFuncCall is an object that describes an invocation of
+// method GetByName on an instance of MockReposStore.
+type ReposStoreGetByNameFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *db.Repository
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreGetByNameFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreGetByNameFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreGetByRedirectFunc describes the behavior when the GetByRedirect
+// method of the parent MockReposStore instance is invoked.
+type ReposStoreGetByRedirectFunc struct {
+	defaultHook func(context.Context, int64, string) (*db.Repository, error)
+	hooks       []func(context.Context, int64, string) (*db.Repository, error)
+	history     []ReposStoreGetByRedirectFuncCall
+	mutex       sync.Mutex
+}
+
+// GetByRedirect delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockReposStore) GetByRedirect(v0 context.Context, v1 int64, v2 string) (*db.Repository, error) {
+	r0, r1 := m.GetByRedirectFunc.nextHook()(v0, v1, v2)
+	m.GetByRedirectFunc.appendCall(ReposStoreGetByRedirectFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetByRedirect method
+// of the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreGetByRedirectFunc) SetDefaultHook(hook func(context.Context, int64, string) (*db.Repository, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetByRedirect method of the parent MockReposStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ReposStoreGetByRedirectFunc) PushHook(hook func(context.Context, int64, string) (*db.Repository, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreGetByRedirectFunc) SetDefaultReturn(r0 *db.Repository, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, string) (*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreGetByRedirectFunc) PushReturn(r0 *db.Repository, r1 error) {
+	f.PushHook(func(context.Context, int64, string) (*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreGetByRedirectFunc) nextHook() func(context.Context, int64, string) (*db.Repository, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreGetByRedirectFunc) appendCall(r0 ReposStoreGetByRedirectFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreGetByRedirectFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreGetByRedirectFunc) History() []ReposStoreGetByRedirectFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreGetByRedirectFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreGetByRedirectFuncCall is an object that describes an invocation
+// of method GetByRedirect on an instance of MockReposStore.
+type ReposStoreGetByRedirectFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *db.Repository
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreGetByRedirectFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreGetByRedirectFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreHasForkedByFunc describes the behavior when the HasForkedBy
+// method of the parent MockReposStore instance is invoked.
+type ReposStoreHasForkedByFunc struct {
+	defaultHook func(context.Context, int64, int64) bool
+	hooks       []func(context.Context, int64, int64) bool
+	history     []ReposStoreHasForkedByFuncCall
+	mutex       sync.Mutex
+}
+
+// HasForkedBy delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockReposStore) HasForkedBy(v0 context.Context, v1 int64, v2 int64) bool {
+	r0 := m.HasForkedByFunc.nextHook()(v0, v1, v2)
+	m.HasForkedByFunc.appendCall(ReposStoreHasForkedByFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the HasForkedBy method
+// of the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreHasForkedByFunc) SetDefaultHook(hook func(context.Context, int64, int64) bool) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// HasForkedBy method of the parent MockReposStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreHasForkedByFunc) PushHook(hook func(context.Context, int64, int64) bool) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreHasForkedByFunc) SetDefaultReturn(r0 bool) {
+	f.SetDefaultHook(func(context.Context, int64, int64) bool {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreHasForkedByFunc) PushReturn(r0 bool) {
+	f.PushHook(func(context.Context, int64, int64) bool {
+		return r0
+	})
+}
+
+func (f *ReposStoreHasForkedByFunc) nextHook() func(context.Context, int64, int64) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreHasForkedByFunc) appendCall(r0 ReposStoreHasForkedByFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreHasForkedByFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreHasForkedByFunc) History() []ReposStoreHasForkedByFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreHasForkedByFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreHasForkedByFuncCall is an object that describes an invocation
+// of method HasForkedBy on an instance of MockReposStore.
+type ReposStoreHasForkedByFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreHasForkedByFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreHasForkedByFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreIsNameAvailableFunc describes the behavior when the
+// IsNameAvailable method of the parent MockReposStore instance is invoked.
+type ReposStoreIsNameAvailableFunc struct {
+	defaultHook func(context.Context, int64, string) (bool, error)
+	hooks       []func(context.Context, int64, string) (bool, error)
+	history     []ReposStoreIsNameAvailableFuncCall
+	mutex       sync.Mutex
+}
+
+// IsNameAvailable delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockReposStore) IsNameAvailable(v0 context.Context, v1 int64, v2 string) (bool, error) {
+	r0, r1 := m.IsNameAvailableFunc.nextHook()(v0, v1, v2)
+	m.IsNameAvailableFunc.appendCall(ReposStoreIsNameAvailableFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the IsNameAvailable
+// method of the parent MockReposStore instance is invoked and the hook
+// queue is empty.
+func (f *ReposStoreIsNameAvailableFunc) SetDefaultHook(hook func(context.Context, int64, string) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// IsNameAvailable method of the parent MockReposStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ReposStoreIsNameAvailableFunc) PushHook(hook func(context.Context, int64, string) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreIsNameAvailableFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, string) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreIsNameAvailableFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, int64, string) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreIsNameAvailableFunc) nextHook() func(context.Context, int64, string) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreIsNameAvailableFunc) appendCall(r0 ReposStoreIsNameAvailableFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreIsNameAvailableFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreIsNameAvailableFunc) History() []ReposStoreIsNameAvailableFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreIsNameAvailableFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreIsNameAvailableFuncCall is an object that describes an
+// invocation of method IsNameAvailable on an instance of MockReposStore.
+type ReposStoreIsNameAvailableFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreIsNameAvailableFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreIsNameAvailableFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreListMirrorsToSyncFunc describes the behavior when the
+// ListMirrorsToSync method of the parent MockReposStore instance is
+// invoked.
+type ReposStoreListMirrorsToSyncFunc struct {
+	defaultHook func(context.Context, int64, int, int) ([]*db.Mirror, error)
+	hooks       []func(context.Context, int64, int, int) ([]*db.Mirror, error)
+	history     []ReposStoreListMirrorsToSyncFuncCall
+	mutex       sync.Mutex
+}
+
+// ListMirrorsToSync delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockReposStore) ListMirrorsToSync(v0 context.Context, v1 int64, v2 int, v3 int) ([]*db.Mirror, error) {
+	r0, r1 := m.ListMirrorsToSyncFunc.nextHook()(v0, v1, v2, v3)
+	m.ListMirrorsToSyncFunc.appendCall(ReposStoreListMirrorsToSyncFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListMirrorsToSync
+// method of the parent MockReposStore instance is invoked and the hook
+// queue is empty.
+func (f *ReposStoreListMirrorsToSyncFunc) SetDefaultHook(hook func(context.Context, int64, int, int) ([]*db.Mirror, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListMirrorsToSync method of the parent MockReposStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ReposStoreListMirrorsToSyncFunc) PushHook(hook func(context.Context, int64, int, int) ([]*db.Mirror, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreListMirrorsToSyncFunc) SetDefaultReturn(r0 []*db.Mirror, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int, int) ([]*db.Mirror, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreListMirrorsToSyncFunc) PushReturn(r0 []*db.Mirror, r1 error) {
+	f.PushHook(func(context.Context, int64, int, int) ([]*db.Mirror, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreListMirrorsToSyncFunc) nextHook() func(context.Context, int64, int, int) ([]*db.Mirror, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreListMirrorsToSyncFunc) appendCall(r0 ReposStoreListMirrorsToSyncFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreListMirrorsToSyncFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreListMirrorsToSyncFunc) History() []ReposStoreListMirrorsToSyncFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreListMirrorsToSyncFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreListMirrorsToSyncFuncCall is an object that describes an
+// invocation of method ListMirrorsToSync on an instance of MockReposStore.
+type ReposStoreListMirrorsToSyncFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.Mirror
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreListMirrorsToSyncFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreListMirrorsToSyncFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreListProtectedTagsFunc describes the behavior when the
+// ListProtectedTags method of the parent MockReposStore instance is
+// invoked.
+type ReposStoreListProtectedTagsFunc struct {
+	defaultHook func(context.Context, int64) ([]*db.ProtectedTag, error)
+	hooks       []func(context.Context, int64) ([]*db.ProtectedTag, error)
+	history     []ReposStoreListProtectedTagsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListProtectedTags delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockReposStore) ListProtectedTags(v0 context.Context, v1 int64) ([]*db.ProtectedTag, error) {
+	r0, r1 := m.ListProtectedTagsFunc.nextHook()(v0, v1)
+	m.ListProtectedTagsFunc.appendCall(ReposStoreListProtectedTagsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListProtectedTags
+// method of the parent MockReposStore instance is invoked and the hook
+// queue is empty.
+func (f *ReposStoreListProtectedTagsFunc) SetDefaultHook(hook func(context.Context, int64) ([]*db.ProtectedTag, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListProtectedTags method of the parent MockReposStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ReposStoreListProtectedTagsFunc) PushHook(hook func(context.Context, int64) ([]*db.ProtectedTag, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreListProtectedTagsFunc) SetDefaultReturn(r0 []*db.ProtectedTag, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) ([]*db.ProtectedTag, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreListProtectedTagsFunc) PushReturn(r0 []*db.ProtectedTag, r1 error) {
+	f.PushHook(func(context.Context, int64) ([]*db.ProtectedTag, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreListProtectedTagsFunc) nextHook() func(context.Context, int64) ([]*db.ProtectedTag, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreListProtectedTagsFunc) appendCall(r0 ReposStoreListProtectedTagsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreListProtectedTagsFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreListProtectedTagsFunc) History() []ReposStoreListProtectedTagsFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreListProtectedTagsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreListProtectedTagsFuncCall is an object that describes an
+// invocation of method ListProtectedTags on an instance of MockReposStore.
+type ReposStoreListProtectedTagsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.ProtectedTag
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreListProtectedTagsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreListProtectedTagsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreListTopicsFunc describes the behavior when the ListTopics
+// method of the parent MockReposStore instance is invoked.
+type ReposStoreListTopicsFunc struct {
+	defaultHook func(context.Context, int64) ([]*db.Topic, error)
+	hooks       []func(context.Context, int64) ([]*db.Topic, error)
+	history     []ReposStoreListTopicsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListTopics delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockReposStore) ListTopics(v0 context.Context, v1 int64) ([]*db.Topic, error) {
+	r0, r1 := m.ListTopicsFunc.nextHook()(v0, v1)
+	m.ListTopicsFunc.appendCall(ReposStoreListTopicsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListTopics method of
+// the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreListTopicsFunc) SetDefaultHook(hook func(context.Context, int64) ([]*db.Topic, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListTopics method of the parent MockReposStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreListTopicsFunc) PushHook(hook func(context.Context, int64) ([]*db.Topic, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreListTopicsFunc) SetDefaultReturn(r0 []*db.Topic, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) ([]*db.Topic, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreListTopicsFunc) PushReturn(r0 []*db.Topic, r1 error) {
+	f.PushHook(func(context.Context, int64) ([]*db.Topic, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreListTopicsFunc) nextHook() func(context.Context, int64) ([]*db.Topic, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreListTopicsFunc) appendCall(r0 ReposStoreListTopicsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreListTopicsFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreListTopicsFunc) History() []ReposStoreListTopicsFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreListTopicsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreListTopicsFuncCall is an object that describes an invocation of
+// method ListTopics on an instance of MockReposStore.
+type ReposStoreListTopicsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.Topic
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreListTopicsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreListTopicsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreListWatchedByUserInOrgFunc describes the behavior when the
+// ListWatchedByUserInOrg method of the parent MockReposStore instance is
+// invoked.
+type ReposStoreListWatchedByUserInOrgFunc struct {
+	defaultHook func(context.Context, int64, int64) ([]*db.Repository, error)
+	hooks       []func(context.Context, int64, int64) ([]*db.Repository, error)
+	history     []ReposStoreListWatchedByUserInOrgFuncCall
+	mutex       sync.Mutex
+}
+
+// ListWatchedByUserInOrg delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockReposStore) ListWatchedByUserInOrg(v0 context.Context, v1 int64, v2 int64) ([]*db.Repository, error) {
+	r0, r1 := m.ListWatchedByUserInOrgFunc.nextHook()(v0, v1, v2)
+	m.ListWatchedByUserInOrgFunc.appendCall(ReposStoreListWatchedByUserInOrgFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListWatchedByUserInOrg method of the parent MockReposStore instance is
+// invoked and the hook queue is empty.
+func (f *ReposStoreListWatchedByUserInOrgFunc) SetDefaultHook(hook func(context.Context, int64, int64) ([]*db.Repository, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListWatchedByUserInOrg method of the parent MockReposStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ReposStoreListWatchedByUserInOrgFunc) PushHook(hook func(context.Context, int64, int64) ([]*db.Repository, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreListWatchedByUserInOrgFunc) SetDefaultReturn(r0 []*db.Repository, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) ([]*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreListWatchedByUserInOrgFunc) PushReturn(r0 []*db.Repository, r1 error) {
+	f.PushHook(func(context.Context, int64, int64) ([]*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreListWatchedByUserInOrgFunc) nextHook() func(context.Context, int64, int64) ([]*db.Repository, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreListWatchedByUserInOrgFunc) appendCall(r0 ReposStoreListWatchedByUserInOrgFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreListWatchedByUserInOrgFuncCall
+// objects describing the invocations of this function.
+func (f *ReposStoreListWatchedByUserInOrgFunc) History() []ReposStoreListWatchedByUserInOrgFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreListWatchedByUserInOrgFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreListWatchedByUserInOrgFuncCall is an object that describes an
+// invocation of method ListWatchedByUserInOrg on an instance of
+// MockReposStore.
+type ReposStoreListWatchedByUserInOrgFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.Repository
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreListWatchedByUserInOrgFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreListWatchedByUserInOrgFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreListWatchesFunc describes the behavior when the ListWatches
+// method of the parent MockReposStore instance is invoked.
+type ReposStoreListWatchesFunc struct {
+	defaultHook func(context.Context, int64) ([]*db.Watch, error)
+	hooks       []func(context.Context, int64) ([]*db.Watch, error)
+	history     []ReposStoreListWatchesFuncCall
+	mutex       sync.Mutex
+}
+
+// ListWatches delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockReposStore) ListWatches(v0 context.Context, v1 int64) ([]*db.Watch, error) {
+	r0, r1 := m.ListWatchesFunc.nextHook()(v0, v1)
+	m.ListWatchesFunc.appendCall(ReposStoreListWatchesFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListWatches method
+// of the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreListWatchesFunc) SetDefaultHook(hook func(context.Context, int64) ([]*db.Watch, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListWatches method of the parent MockReposStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreListWatchesFunc) PushHook(hook func(context.Context, int64) ([]*db.Watch, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreListWatchesFunc) SetDefaultReturn(r0 []*db.Watch, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) ([]*db.Watch, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreListWatchesFunc) PushReturn(r0 []*db.Watch, r1 error) {
+	f.PushHook(func(context.Context, int64) ([]*db.Watch, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreListWatchesFunc) nextHook() func(context.Context, int64) ([]*db.Watch, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreListWatchesFunc) appendCall(r0 ReposStoreListWatchesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreListWatchesFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreListWatchesFunc) History() []ReposStoreListWatchesFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreListWatchesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreListWatchesFuncCall is an object that describes an invocation
+// of method ListWatches on an instance of MockReposStore.
+type ReposStoreListWatchesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.Watch
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreListWatchesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreListWatchesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreRenameFunc describes the behavior when the Rename method of the
+// parent MockReposStore instance is invoked.
+type ReposStoreRenameFunc struct {
+	defaultHook func(context.Context, int64, string) error
+	hooks       []func(context.Context, int64, string) error
+	history     []ReposStoreRenameFuncCall
+	mutex       sync.Mutex
+}
+
+// Rename delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockReposStore) Rename(v0 context.Context, v1 int64, v2 string) error {
+	r0 := m.RenameFunc.nextHook()(v0, v1, v2)
+	m.RenameFunc.appendCall(ReposStoreRenameFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Rename method of the
+// parent MockReposStore instance is invoked and the hook queue is empty.
+func (f *ReposStoreRenameFunc) SetDefaultHook(hook func(context.Context, int64, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Rename method of the parent MockReposStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreRenameFunc) PushHook(hook func(context.Context, int64, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreRenameFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreRenameFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, string) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreRenameFunc) nextHook() func(context.Context, int64, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreRenameFunc) appendCall(r0 ReposStoreRenameFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreRenameFuncCall objects describing
+// the invocations of this function.
+func (f *ReposStoreRenameFunc) History() []ReposStoreRenameFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreRenameFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreRenameFuncCall is an object that describes an invocation of
+// method Rename on an instance of MockReposStore.
+type ReposStoreRenameFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreRenameFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreRenameFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreRestoreFunc describes the behavior when the Restore method of
+// the parent MockReposStore instance is invoked.
+type ReposStoreRestoreFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []ReposStoreRestoreFuncCall
+	mutex       sync.Mutex
+}
+
+// Restore delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockReposStore) Restore(v0 context.Context, v1 int64) error {
+	r0 := m.RestoreFunc.nextHook()(v0, v1)
+	m.RestoreFunc.appendCall(ReposStoreRestoreFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Restore method of
+// the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreRestoreFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Restore method of the parent MockReposStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreRestoreFunc) PushHook(hook func(context.Context, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreRestoreFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreRestoreFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreRestoreFunc) nextHook() func(context.Context, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreRestoreFunc) appendCall(r0 ReposStoreRestoreFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreRestoreFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreRestoreFunc) History() []ReposStoreRestoreFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreRestoreFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreRestoreFuncCall is an object that describes an invocation of
+// method Restore on an instance of MockReposStore.
+type ReposStoreRestoreFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreRestoreFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreRestoreFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreSearchByTopicFunc describes the behavior when the SearchByTopic
+// method of the parent MockReposStore instance is invoked.
+type ReposStoreSearchByTopicFunc struct {
+	defaultHook func(context.Context, string, int, int) ([]*db.Repository, int64, error)
+	hooks       []func(context.Context, string, int, int) ([]*db.Repository, int64, error)
+	history     []ReposStoreSearchByTopicFuncCall
+	mutex       sync.Mutex
+}
+
+// SearchByTopic delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockReposStore) SearchByTopic(v0 context.Context, v1 string, v2 int, v3 int) ([]*db.Repository, int64, error) {
+	r0, r1, r2 := m.SearchByTopicFunc.nextHook()(v0, v1, v2, v3)
+	m.SearchByTopicFunc.appendCall(ReposStoreSearchByTopicFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the SearchByTopic method
+// of the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreSearchByTopicFunc) SetDefaultHook(hook func(context.Context, string, int, int) ([]*db.Repository, int64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SearchByTopic method of the parent MockReposStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ReposStoreSearchByTopicFunc) PushHook(hook func(context.Context, string, int, int) ([]*db.Repository, int64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreSearchByTopicFunc) SetDefaultReturn(r0 []*db.Repository, r1 int64, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, int, int) ([]*db.Repository, int64, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreSearchByTopicFunc) PushReturn(r0 []*db.Repository, r1 int64, r2 error) {
+	f.PushHook(func(context.Context, string, int, int) ([]*db.Repository, int64, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *ReposStoreSearchByTopicFunc) nextHook() func(context.Context, string, int, int) ([]*db.Repository, int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreSearchByTopicFunc) appendCall(r0 ReposStoreSearchByTopicFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreSearchByTopicFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreSearchByTopicFunc) History() []ReposStoreSearchByTopicFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreSearchByTopicFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreSearchByTopicFuncCall is an object that describes an invocation
+// of method SearchByTopic on an instance of MockReposStore.
+type ReposStoreSearchByTopicFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.Repository
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int64
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreSearchByTopicFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreSearchByTopicFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// ReposStoreSetArchivedFunc describes the behavior when the SetArchived
+// method of the parent MockReposStore instance is invoked.
+type ReposStoreSetArchivedFunc struct {
+	defaultHook func(context.Context, int64, bool) error
+	hooks       []func(context.Context, int64, bool) error
+	history     []ReposStoreSetArchivedFuncCall
+	mutex       sync.Mutex
+}
+
+// SetArchived delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockReposStore) SetArchived(v0 context.Context, v1 int64, v2 bool) error {
+	r0 := m.SetArchivedFunc.nextHook()(v0, v1, v2)
+	m.SetArchivedFunc.appendCall(ReposStoreSetArchivedFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetArchived method
+// of the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreSetArchivedFunc) SetDefaultHook(hook func(context.Context, int64, bool) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetArchived method of the parent MockReposStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreSetArchivedFunc) PushHook(hook func(context.Context, int64, bool) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreSetArchivedFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreSetArchivedFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, bool) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreSetArchivedFunc) nextHook() func(context.Context, int64, bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreSetArchivedFunc) appendCall(r0 ReposStoreSetArchivedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreSetArchivedFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreSetArchivedFunc) History() []ReposStoreSetArchivedFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreSetArchivedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreSetArchivedFuncCall is an object that describes an invocation
+// of method SetArchived on an instance of MockReposStore.
+type ReposStoreSetArchivedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreSetArchivedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreSetArchivedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreSetCollaboratorExpiryFunc describes the behavior when the
+// SetCollaboratorExpiry method of the parent MockReposStore instance is
+// invoked.
+type ReposStoreSetCollaboratorExpiryFunc struct {
+	defaultHook func(context.Context, int64, int64, int64) error
+	hooks       []func(context.Context, int64, int64, int64) error
+	history     []ReposStoreSetCollaboratorExpiryFuncCall
+	mutex       sync.Mutex
+}
+
+// SetCollaboratorExpiry delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockReposStore) SetCollaboratorExpiry(v0 context.Context, v1 int64, v2 int64, v3 int64) error {
+	r0 := m.SetCollaboratorExpiryFunc.nextHook()(v0, v1, v2, v3)
+	m.SetCollaboratorExpiryFunc.appendCall(ReposStoreSetCollaboratorExpiryFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// SetCollaboratorExpiry method of the parent MockReposStore instance is
+// invoked and the hook queue is empty.
+func (f *ReposStoreSetCollaboratorExpiryFunc) SetDefaultHook(hook func(context.Context, int64, int64, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetCollaboratorExpiry method of the parent MockReposStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ReposStoreSetCollaboratorExpiryFunc) PushHook(hook func(context.Context, int64, int64, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreSetCollaboratorExpiryFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreSetCollaboratorExpiryFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64, int64) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreSetCollaboratorExpiryFunc) nextHook() func(context.Context, int64, int64, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreSetCollaboratorExpiryFunc) appendCall(r0 ReposStoreSetCollaboratorExpiryFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreSetCollaboratorExpiryFuncCall
+// objects describing the invocations of this function.
+func (f *ReposStoreSetCollaboratorExpiryFunc) History() []ReposStoreSetCollaboratorExpiryFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreSetCollaboratorExpiryFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreSetCollaboratorExpiryFuncCall is an object that describes an
+// invocation of method SetCollaboratorExpiry on an instance of
+// MockReposStore.
+type ReposStoreSetCollaboratorExpiryFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreSetCollaboratorExpiryFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreSetCollaboratorExpiryFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreSetExternalTrackerFunc describes the behavior when the
+// SetExternalTracker method of the parent MockReposStore instance is
+// invoked.
+type ReposStoreSetExternalTrackerFunc struct {
+	defaultHook func(context.Context, int64, db.SetExternalTrackerOptions) error
+	hooks       []func(context.Context, int64, db.SetExternalTrackerOptions) error
+	history     []ReposStoreSetExternalTrackerFuncCall
+	mutex       sync.Mutex
+}
+
+// SetExternalTracker delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockReposStore) SetExternalTracker(v0 context.Context, v1 int64, v2 db.SetExternalTrackerOptions) error {
+	r0 := m.SetExternalTrackerFunc.nextHook()(v0, v1, v2)
+	m.SetExternalTrackerFunc.appendCall(ReposStoreSetExternalTrackerFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetExternalTracker
+// method of the parent MockReposStore instance is invoked and the hook
+// queue is empty.
+func (f *ReposStoreSetExternalTrackerFunc) SetDefaultHook(hook func(context.Context, int64, db.SetExternalTrackerOptions) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetExternalTracker method of the parent MockReposStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ReposStoreSetExternalTrackerFunc) PushHook(hook func(context.Context, int64, db.SetExternalTrackerOptions) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreSetExternalTrackerFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, db.SetExternalTrackerOptions) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreSetExternalTrackerFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, db.SetExternalTrackerOptions) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreSetExternalTrackerFunc) nextHook() func(context.Context, int64, db.SetExternalTrackerOptions) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreSetExternalTrackerFunc) appendCall(r0 ReposStoreSetExternalTrackerFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreSetExternalTrackerFuncCall
+// objects describing the invocations of this function.
+func (f *ReposStoreSetExternalTrackerFunc) History() []ReposStoreSetExternalTrackerFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreSetExternalTrackerFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreSetExternalTrackerFuncCall is an object that describes an
+// invocation of method SetExternalTracker on an instance of MockReposStore.
+type ReposStoreSetExternalTrackerFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 db.SetExternalTrackerOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreSetExternalTrackerFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreSetExternalTrackerFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreSetMirrorSyncResultFunc describes the behavior when the
+// SetMirrorSyncResult method of the parent MockReposStore instance is
+// invoked.
+type ReposStoreSetMirrorSyncResultFunc struct {
+	defaultHook func(context.Context, int64, bool, string) error
+	hooks       []func(context.Context, int64, bool, string) error
+	history     []ReposStoreSetMirrorSyncResultFuncCall
+	mutex       sync.Mutex
+}
+
+// SetMirrorSyncResult delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockReposStore) SetMirrorSyncResult(v0 context.Context, v1 int64, v2 bool, v3 string) error {
+	r0 := m.SetMirrorSyncResultFunc.nextHook()(v0, v1, v2, v3)
+	m.SetMirrorSyncResultFunc.appendCall(ReposStoreSetMirrorSyncResultFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetMirrorSyncResult
+// method of the parent MockReposStore instance is invoked and the hook
+// queue is empty.
+func (f *ReposStoreSetMirrorSyncResultFunc) SetDefaultHook(hook func(context.Context, int64, bool, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetMirrorSyncResult method of the parent MockReposStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ReposStoreSetMirrorSyncResultFunc) PushHook(hook func(context.Context, int64, bool, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreSetMirrorSyncResultFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreSetMirrorSyncResultFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, bool, string) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreSetMirrorSyncResultFunc) nextHook() func(context.Context, int64, bool, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreSetMirrorSyncResultFunc) appendCall(r0 ReposStoreSetMirrorSyncResultFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreSetMirrorSyncResultFuncCall
+// objects describing the invocations of this function.
+func (f *ReposStoreSetMirrorSyncResultFunc) History() []ReposStoreSetMirrorSyncResultFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreSetMirrorSyncResultFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreSetMirrorSyncResultFuncCall is an object that describes an
+// invocation of method SetMirrorSyncResult on an instance of
+// MockReposStore.
+type ReposStoreSetMirrorSyncResultFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreSetMirrorSyncResultFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreSetMirrorSyncResultFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreSetPrivateFunc describes the behavior when the SetPrivate
+// method of the parent MockReposStore instance is invoked.
+type ReposStoreSetPrivateFunc struct {
+	defaultHook func(context.Context, int64, bool) error
+	hooks       []func(context.Context, int64, bool) error
+	history     []ReposStoreSetPrivateFuncCall
+	mutex       sync.Mutex
+}
+
+// SetPrivate delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockReposStore) SetPrivate(v0 context.Context, v1 int64, v2 bool) error {
+	r0 := m.SetPrivateFunc.nextHook()(v0, v1, v2)
+	m.SetPrivateFunc.appendCall(ReposStoreSetPrivateFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetPrivate method of
+// the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreSetPrivateFunc) SetDefaultHook(hook func(context.Context, int64, bool) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetPrivate method of the parent MockReposStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreSetPrivateFunc) PushHook(hook func(context.Context, int64, bool) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreSetPrivateFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreSetPrivateFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, bool) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreSetPrivateFunc) nextHook() func(context.Context, int64, bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreSetPrivateFunc) appendCall(r0 ReposStoreSetPrivateFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreSetPrivateFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreSetPrivateFunc) History() []ReposStoreSetPrivateFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreSetPrivateFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreSetPrivateFuncCall is an object that describes an invocation of
+// method SetPrivate on an instance of MockReposStore.
+type ReposStoreSetPrivateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreSetPrivateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreSetPrivateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreSetTopicsFunc describes the behavior when the SetTopics method
+// of the parent MockReposStore instance is invoked.
+type ReposStoreSetTopicsFunc struct {
+	defaultHook func(context.Context, int64, []string) error
+	hooks       []func(context.Context, int64, []string) error
+	history     []ReposStoreSetTopicsFuncCall
+	mutex       sync.Mutex
+}
+
+// SetTopics delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockReposStore) SetTopics(v0 context.Context, v1 int64, v2 []string) error {
+	r0 := m.SetTopicsFunc.nextHook()(v0, v1, v2)
+	m.SetTopicsFunc.appendCall(ReposStoreSetTopicsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetTopics method of
+// the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreSetTopicsFunc) SetDefaultHook(hook func(context.Context, int64, []string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetTopics method of the parent MockReposStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreSetTopicsFunc) PushHook(hook func(context.Context, int64, []string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreSetTopicsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, []string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreSetTopicsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, []string) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreSetTopicsFunc) nextHook() func(context.Context, int64, []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreSetTopicsFunc) appendCall(r0 ReposStoreSetTopicsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreSetTopicsFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreSetTopicsFunc) History() []ReposStoreSetTopicsFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreSetTopicsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreSetTopicsFuncCall is an object that describes an invocation of
+// method SetTopics on an instance of MockReposStore.
+type ReposStoreSetTopicsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreSetTopicsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreSetTopicsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreSoftDeleteFunc describes the behavior when the SoftDelete
+// method of the parent MockReposStore instance is invoked.
+type ReposStoreSoftDeleteFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []ReposStoreSoftDeleteFuncCall
+	mutex       sync.Mutex
+}
+
+// SoftDelete delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockReposStore) SoftDelete(v0 context.Context, v1 int64) error {
+	r0 := m.SoftDeleteFunc.nextHook()(v0, v1)
+	m.SoftDeleteFunc.appendCall(ReposStoreSoftDeleteFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SoftDelete method of
+// the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreSoftDeleteFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SoftDelete method of the parent MockReposStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreSoftDeleteFunc) PushHook(hook func(context.Context, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreSoftDeleteFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreSoftDeleteFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreSoftDeleteFunc) nextHook() func(context.Context, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreSoftDeleteFunc) appendCall(r0 ReposStoreSoftDeleteFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreSoftDeleteFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreSoftDeleteFunc) History() []ReposStoreSoftDeleteFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreSoftDeleteFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreSoftDeleteFuncCall is an object that describes an invocation of
+// method SoftDelete on an instance of MockReposStore.
+type ReposStoreSoftDeleteFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreSoftDeleteFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreSoftDeleteFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreStarFunc describes the behavior when the Star method of the
+// parent MockReposStore instance is invoked.
+type ReposStoreStarFunc struct {
+	defaultHook func(context.Context, int64, int64) error
+	hooks       []func(context.Context, int64, int64) error
+	history     []ReposStoreStarFuncCall
+	mutex       sync.Mutex
+}
+
+// Star delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockReposStore) Star(v0 context.Context, v1 int64, v2 int64) error {
+	r0 := m.StarFunc.nextHook()(v0, v1, v2)
+	m.StarFunc.appendCall(ReposStoreStarFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Star method of the
+// parent MockReposStore instance is invoked and the hook queue is empty.
+func (f *ReposStoreStarFunc) SetDefaultHook(hook func(context.Context, int64, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Star method of the parent MockReposStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *ReposStoreStarFunc) PushHook(hook func(context.Context, int64, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreStarFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreStarFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreStarFunc) nextHook() func(context.Context, int64, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreStarFunc) appendCall(r0 ReposStoreStarFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreStarFuncCall objects describing
+// the invocations of this function.
+func (f *ReposStoreStarFunc) History() []ReposStoreStarFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreStarFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreStarFuncCall is an object that describes an invocation of
+// method Star on an instance of MockReposStore.
+type ReposStoreStarFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreStarFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreStarFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreTouchFunc describes the behavior when the Touch method of the
+// parent MockReposStore instance is invoked.
+type ReposStoreTouchFunc struct {
+	defaultHook func(context.Context, int64, int64) error
+	hooks       []func(context.Context, int64, int64) error
+	history     []ReposStoreTouchFuncCall
+	mutex       sync.Mutex
+}
+
+// Touch delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockReposStore) Touch(v0 context.Context, v1 int64, v2 int64) error {
+	r0 := m.TouchFunc.nextHook()(v0, v1, v2)
+	m.TouchFunc.appendCall(ReposStoreTouchFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Touch method of the
+// parent MockReposStore instance is invoked and the hook queue is empty.
+func (f *ReposStoreTouchFunc) SetDefaultHook(hook func(context.Context, int64, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Touch method of the parent MockReposStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreTouchFunc) PushHook(hook func(context.Context, int64, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreTouchFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreTouchFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreTouchFunc) nextHook() func(context.Context, int64, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreTouchFunc) appendCall(r0 ReposStoreTouchFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreTouchFuncCall objects describing
+// the invocations of this function.
+func (f *ReposStoreTouchFunc) History() []ReposStoreTouchFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreTouchFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreTouchFuncCall is an object that describes an invocation of
+// method Touch on an instance of MockReposStore.
+type ReposStoreTouchFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreTouchFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreTouchFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreTransferAllByOwnerFunc describes the behavior when the
+// TransferAllByOwner method of the parent MockReposStore instance is
+// invoked.
+type ReposStoreTransferAllByOwnerFunc struct {
+	defaultHook func(context.Context, int64, int64) ([]*db.Repository, error)
+	hooks       []func(context.Context, int64, int64) ([]*db.Repository, error)
+	history     []ReposStoreTransferAllByOwnerFuncCall
+	mutex       sync.Mutex
+}
+
+// TransferAllByOwner delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockReposStore) TransferAllByOwner(v0 context.Context, v1 int64, v2 int64) ([]*db.Repository, error) {
+	r0, r1 := m.TransferAllByOwnerFunc.nextHook()(v0, v1, v2)
+	m.TransferAllByOwnerFunc.appendCall(ReposStoreTransferAllByOwnerFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the TransferAllByOwner
+// method of the parent MockReposStore instance is invoked and the hook
+// queue is empty.
+func (f *ReposStoreTransferAllByOwnerFunc) SetDefaultHook(hook func(context.Context, int64, int64) ([]*db.Repository, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// TransferAllByOwner method of the parent MockReposStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ReposStoreTransferAllByOwnerFunc) PushHook(hook func(context.Context, int64, int64) ([]*db.Repository, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreTransferAllByOwnerFunc) SetDefaultReturn(r0 []*db.Repository, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) ([]*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreTransferAllByOwnerFunc) PushReturn(r0 []*db.Repository, r1 error) {
+	f.PushHook(func(context.Context, int64, int64) ([]*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreTransferAllByOwnerFunc) nextHook() func(context.Context, int64, int64) ([]*db.Repository, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreTransferAllByOwnerFunc) appendCall(r0 ReposStoreTransferAllByOwnerFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreTransferAllByOwnerFuncCall
+// objects describing the invocations of this function.
+func (f *ReposStoreTransferAllByOwnerFunc) History() []ReposStoreTransferAllByOwnerFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreTransferAllByOwnerFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreTransferAllByOwnerFuncCall is an object that describes an
+// invocation of method TransferAllByOwner on an instance of MockReposStore.
+type ReposStoreTransferAllByOwnerFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.Repository
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreTransferAllByOwnerFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreTransferAllByOwnerFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreUpdateSizeFunc describes the behavior when the UpdateSize
+// method of the parent MockReposStore instance is invoked.
+type ReposStoreUpdateSizeFunc struct {
+	defaultHook func(context.Context, int64, int64) error
+	hooks       []func(context.Context, int64, int64) error
+	history     []ReposStoreUpdateSizeFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateSize delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockReposStore) UpdateSize(v0 context.Context, v1 int64, v2 int64) error {
+	r0 := m.UpdateSizeFunc.nextHook()(v0, v1, v2)
+	m.UpdateSizeFunc.appendCall(ReposStoreUpdateSizeFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the UpdateSize method of
+// the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreUpdateSizeFunc) SetDefaultHook(hook func(context.Context, int64, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateSize method of the parent MockReposStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreUpdateSizeFunc) PushHook(hook func(context.Context, int64, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreUpdateSizeFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreUpdateSizeFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreUpdateSizeFunc) nextHook() func(context.Context, int64, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreUpdateSizeFunc) appendCall(r0 ReposStoreUpdateSizeFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreUpdateSizeFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreUpdateSizeFunc) History() []ReposStoreUpdateSizeFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreUpdateSizeFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreUpdateSizeFuncCall is an object that describes an invocation of
+// method UpdateSize on an instance of MockReposStore.
+type ReposStoreUpdateSizeFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreUpdateSizeFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreUpdateSizeFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreWatchFunc describes the behavior when the Watch method of the
+// parent MockReposStore instance is invoked.
+type ReposStoreWatchFunc struct {
+	defaultHook func(context.Context, int64, int64) error
+	hooks       []func(context.Context, int64, int64) error
+	history     []ReposStoreWatchFuncCall
+	mutex       sync.Mutex
+}
+
+// Watch delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockReposStore) Watch(v0 context.Context, v1 int64, v2 int64) error {
+	r0 := m.WatchFunc.nextHook()(v0, v1, v2)
+	m.WatchFunc.appendCall(ReposStoreWatchFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Watch method of the
+// parent MockReposStore instance is invoked and the hook queue is empty.
+func (f *ReposStoreWatchFunc) SetDefaultHook(hook func(context.Context, int64, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Watch method of the parent MockReposStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreWatchFunc) PushHook(hook func(context.Context, int64, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreWatchFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreWatchFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreWatchFunc) nextHook() func(context.Context, int64, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreWatchFunc) appendCall(r0 ReposStoreWatchFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreWatchFuncCall objects describing
+// the invocations of this function.
+func (f *ReposStoreWatchFunc) History() []ReposStoreWatchFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreWatchFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreWatchFuncCall is an object that describes an invocation of
+// method Watch on an instance of MockReposStore.
+type ReposStoreWatchFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreWatchFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreWatchFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
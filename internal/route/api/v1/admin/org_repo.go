@@ -5,8 +5,11 @@
 package admin
 
 import (
+	api "github.com/gogs/go-gogs-client"
+
 	"gogs.io/gogs/internal/context"
 	"gogs.io/gogs/internal/db"
+	"gogs.io/gogs/internal/route/api/v1/convert"
 )
 
 func GetRepositoryByParams(c *context.APIContext) *db.Repository {
@@ -23,7 +26,7 @@ func AddTeamRepository(c *context.APIContext) {
 	if c.Written() {
 		return
 	}
-	if err := c.Org.Team.AddRepository(repo); err != nil {
+	if err := c.Org.Team.AddRepository(db.WithAuditActor(c.Req.Context(), c.User.ID), repo); err != nil {
 		c.Error(err, "add repository")
 		return
 	}
@@ -36,10 +39,49 @@ func RemoveTeamRepository(c *context.APIContext) {
 	if c.Written() {
 		return
 	}
-	if err := c.Org.Team.RemoveRepository(repo.ID); err != nil {
+	if err := c.Org.Team.RemoveRepository(db.WithAuditActor(c.Req.Context(), c.User.ID), repo.ID); err != nil {
 		c.Error(err, "remove repository")
 		return
 	}
 
 	c.NoContent()
 }
+
+// ListOrgRepositories lists all repositories of the organization including
+// private ones, regardless of team memberships of the site admin.
+func ListOrgRepositories(c *context.APIContext) {
+	org := c.Org.Organization
+	if !org.IsOrganization() {
+		c.NotFound()
+		return
+	}
+
+	pageSize := convert.ToCorrectPageSize(c.QueryInt("limit"))
+	repos, count, err := db.Orgs.ListAllRepos(
+		c.Req.Context(),
+		org.ID,
+		c.QueryInt("page"),
+		pageSize,
+		db.ListAllReposOptions{
+			Keyword:         c.Query("q"),
+			IncludeArchived: c.Query("archived") == "true",
+			MirrorsOnly:     c.Query("mirrors") == "true",
+		},
+	)
+	if err != nil {
+		c.Error(err, "list all repositories")
+		return
+	}
+
+	if err = db.RepositoryList(repos).LoadAttributes(); err != nil {
+		c.Error(err, "load attributes")
+		return
+	}
+
+	apiRepos := make([]*api.Repository, len(repos))
+	for i := range repos {
+		apiRepos[i] = repos[i].APIFormatLegacy(&api.Permission{Admin: true, Push: true, Pull: true})
+	}
+	c.SetLinkHeader(int(count), pageSize)
+	c.JSONSuccess(&apiRepos)
+}
@@ -16,13 +16,14 @@ import (
 )
 
 func CreateTeam(c *context.APIContext, form api.CreateTeamOption) {
-	team := &db.Team{
-		OrgID:       c.Org.Organization.ID,
-		Name:        form.Name,
-		Description: form.Description,
-		Authorize:   db.ParseAccessMode(form.Permission),
-	}
-	if err := db.NewTeam(team); err != nil {
+	ctx := db.WithAuditActor(c.Req.Context(), c.User.ID)
+	team, err := db.Teams.Create(ctx, c.Org.Organization.ID, form.Name,
+		db.CreateTeamOptions{
+			Description: form.Description,
+			Authorize:   db.ParseAccessMode(form.Permission),
+		},
+	)
+	if err != nil {
 		if db.IsErrTeamAlreadyExist(err) {
 			c.ErrorStatus(http.StatusUnprocessableEntity, err)
 		} else {
@@ -39,7 +40,8 @@ func AddTeamMember(c *context.APIContext) {
 	if c.Written() {
 		return
 	}
-	if err := c.Org.Team.AddMember(u.ID); err != nil {
+	err := db.Teams.AddTeamMember(db.WithAuditActor(c.Req.Context(), c.User.ID), c.Org.Team.ID, u.ID)
+	if err != nil {
 		c.Error(err, "add member")
 		return
 	}
@@ -53,7 +55,8 @@ func RemoveTeamMember(c *context.APIContext) {
 		return
 	}
 
-	if err := c.Org.Team.RemoveMember(u.ID); err != nil {
+	err := db.Teams.RemoveTeamMember(db.WithAuditActor(c.Req.Context(), c.User.ID), c.Org.Team.ID, u.ID)
+	if err != nil {
 		c.Error(err, "remove member")
 		return
 	}
@@ -8,6 +8,7 @@ import (
 	api "github.com/gogs/go-gogs-client"
 
 	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/db"
 	"gogs.io/gogs/internal/route/api/v1/repo"
 	"gogs.io/gogs/internal/route/api/v1/user"
 )
@@ -20,3 +21,14 @@ func CreateRepo(c *context.APIContext, form api.CreateRepoOption) {
 
 	repo.CreateUserRepo(c, owner, form)
 }
+
+// RestoreRepo restores the soft-deleted repository with given ID, it only
+// works within the retention window before the repository is purged.
+func RestoreRepo(c *context.APIContext) {
+	err := db.Repos.Restore(c.Req.Context(), c.ParamsInt64(":id"))
+	if err != nil {
+		c.NotFoundOrError(err, "restore repository")
+		return
+	}
+	c.NoContent()
+}
@@ -0,0 +1,80 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/macaron.v1"
+
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/db"
+)
+
+func TestRestoreRepo(t *testing.T) {
+	tests := []struct {
+		name           string
+		mockReposStore func() db.ReposStore
+		expStatusCode  int
+	}{
+		{
+			name: "repository not soft-deleted",
+			mockReposStore: func() db.ReposStore {
+				mock := NewMockReposStore()
+				mock.RestoreFunc.SetDefaultReturn(db.ErrRepoNotExist{})
+				return mock
+			},
+			expStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			mockReposStore: func() db.ReposStore {
+				mock := NewMockReposStore()
+				mock.RestoreFunc.SetDefaultReturn(errors.New("unexpected"))
+				return mock
+			},
+			expStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "restored",
+			mockReposStore: func() db.ReposStore {
+				mock := NewMockReposStore()
+				mock.RestoreFunc.SetDefaultReturn(nil)
+				return mock
+			},
+			expStatusCode: http.StatusNoContent,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock := test.mockReposStore()
+			db.SetMockReposStore(t, mock)
+
+			m := macaron.New()
+			m.Use(macaron.Renderer())
+			m.Post("/admin/repos/:id/restore", func(c *macaron.Context) {
+				RestoreRepo(&context.APIContext{Context: &context.Context{Context: c}})
+			})
+
+			r, err := http.NewRequest("POST", "/admin/repos/1/restore", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			m.ServeHTTP(rr, r)
+			assert.Equal(t, test.expStatusCode, rr.Result().StatusCode)
+
+			calls := mock.(*MockReposStore).RestoreFunc.History()
+			if assert.Len(t, calls, 1) {
+				assert.Equal(t, int64(1), calls[0].Arg1)
+			}
+		})
+	}
+}
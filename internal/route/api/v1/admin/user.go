@@ -54,7 +54,8 @@ func CreateUser(c *context.APIContext, form api.CreateUserOption) {
 	if err != nil {
 		if db.IsErrUserAlreadyExist(err) ||
 			db.IsErrEmailAlreadyUsed(err) ||
-			db.IsErrNameNotAllowed(err) {
+			db.IsErrNameNotAllowed(err) ||
+			db.IsErrPasswordPolicy(err) {
 			c.ErrorStatus(http.StatusUnprocessableEntity, err)
 		} else {
 			c.Error(err, "create user")
@@ -106,7 +107,7 @@ func EditUser(c *context.APIContext, form api.EditUserOption) {
 
 	err := db.Users.Update(c.Req.Context(), u.ID, opts)
 	if err != nil {
-		if db.IsErrEmailAlreadyUsed(err) {
+		if db.IsErrEmailAlreadyUsed(err) || db.IsErrPasswordPolicy(err) {
 			c.ErrorStatus(http.StatusUnprocessableEntity, err)
 		} else {
 			c.Error(err, "update user")
@@ -115,6 +116,14 @@ func EditUser(c *context.APIContext, form api.EditUserOption) {
 	}
 	log.Trace("Account updated by admin %q: %s", c.User.Name, u.Name)
 
+	if form.Password != "" {
+		err = db.Users.InvalidateSessions(c.Req.Context(), u.ID)
+		if err != nil {
+			c.Error(err, "invalidate sessions")
+			return
+		}
+	}
+
 	u, err = db.Users.GetByID(c.Req.Context(), u.ID)
 	if err != nil {
 		c.Error(err, "get user")
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/go-macaron/binding"
+	"github.com/pkg/errors"
 	"gopkg.in/macaron.v1"
 
 	api "github.com/gogs/go-gogs-client"
@@ -65,6 +66,11 @@ func repoAssignment() macaron.Handler {
 			)
 		}
 
+		// Repositories of an archived organization are read-only to everyone.
+		if owner.IsArchived && c.Repo.AccessMode > db.AccessModeRead {
+			c.Repo.AccessMode = db.AccessModeRead
+		}
+
 		if !c.Repo.HasAccess() {
 			c.NotFound()
 			return
@@ -159,10 +165,15 @@ func reqRepoAdmin() macaron.Handler {
 }
 
 func mustEnableIssues(c *context.APIContext) {
-	if !c.Repo.Repository.EnableIssues || c.Repo.Repository.EnableExternalTracker {
+	if !c.Repo.Repository.EnableIssues {
 		c.NotFound()
 		return
 	}
+
+	if c.Repo.Repository.EnableExternalTracker {
+		c.ErrorStatus(http.StatusForbidden, errors.New("issues are disabled in favor of the external issue tracker"))
+		return
+	}
 }
 
 // RegisterRoutes registers all route in API v1 to the web application.
@@ -329,6 +340,10 @@ func RegisterRoutes(m *macaron.Macaron) {
 								Delete(repo.DeleteIssueComment)
 						})
 
+						m.Combo("/assignees").
+							Get(repo.ListIssueAssignees).
+							Put(reqRepoWriter(), bind(repo.SetIssueAssigneesRequest{}), repo.SetIssueAssignees)
+
 						m.Get("/labels", repo.ListIssueLabels)
 						m.Group("/labels", func() {
 							m.Combo("").
@@ -364,6 +379,7 @@ func RegisterRoutes(m *macaron.Macaron) {
 
 				m.Patch("/issue-tracker", reqRepoWriter(), bind(api.EditIssueTrackerOption{}), repo.IssueTracker)
 				m.Patch("/wiki", reqRepoWriter(), bind(api.EditWikiOption{}), repo.Wiki)
+				m.Patch("/visibility", reqRepoAdmin(), bind(repo.SetVisibilityRequest{}), repo.SetVisibility)
 				m.Post("/mirror-sync", reqRepoWriter(), repo.MirrorSync)
 				m.Get("/editorconfig/:filename", context.RepoRef(), repo.GetEditorconfig)
 			}, repoAssignment())
@@ -382,6 +398,13 @@ func RegisterRoutes(m *macaron.Macaron) {
 				Get(org.Get).
 				Patch(bind(api.EditOrgOption{}), org.Edit)
 			m.Get("/teams", org.ListTeams)
+			m.Combo("/pinned").
+				Get(org.ListPinnedRepos).
+				Put(reqToken(), bind(org.SetPinnedReposRequest{}), org.SetPinnedRepos)
+			m.Combo("/labels").
+				Get(org.ListDefaultLabels).
+				Put(reqToken(), bind(org.SetDefaultLabelsRequest{}), org.SetDefaultLabels)
+			m.Post("/labels/apply", reqToken(), org.ApplyDefaultLabels)
 		}, orgAssignment(true))
 
 		m.Group("/admin", func() {
@@ -398,10 +421,13 @@ func RegisterRoutes(m *macaron.Macaron) {
 				})
 			})
 
+			m.Post("/repos/:id/restore", admin.RestoreRepo)
+
 			m.Group("/orgs/:orgname", func() {
 				m.Group("/teams", func() {
 					m.Post("", orgAssignment(true), bind(api.CreateTeamOption{}), admin.CreateTeam)
 				})
+				m.Get("/repos", orgAssignment(true), admin.ListOrgRepositories)
 			})
 
 			m.Group("/teams", func() {
@@ -87,6 +87,9 @@ func ToHook(repoLink string, w *db.Webhook) *api.Hook {
 		"url":          w.URL,
 		"content_type": w.ContentType.Name(),
 	}
+	if w.BranchFilter != "" {
+		config["branch_filter"] = w.BranchFilter
+	}
 	if w.HookTaskType == db.SLACK {
 		s := w.SlackMeta()
 		config["channel"] = s.Channel
@@ -114,19 +117,45 @@ func ToDeployKey(apiLink string, key *db.DeployKey) *api.DeployKey {
 		URL:      apiLink + com.ToStr(key.ID),
 		Title:    key.Name,
 		Created:  key.Created,
-		ReadOnly: true, // All deploy keys are read-only.
+		ReadOnly: key.ReadOnly,
 	}
 }
 
-func ToOrganization(org *db.User) *api.Organization {
-	return &api.Organization{
-		ID:          org.ID,
-		AvatarUrl:   org.AvatarURL(),
-		UserName:    org.Name,
-		FullName:    org.FullName,
-		Description: org.Description,
-		Website:     org.Website,
-		Location:    org.Location,
+// Organization is an organization along with the flags that are not part of
+// the API client yet.
+type Organization struct {
+	*api.Organization
+	IsVerified bool `json:"is_verified"`
+}
+
+func ToOrganization(org *db.User) *Organization {
+	return &Organization{
+		Organization: &api.Organization{
+			ID:          org.ID,
+			AvatarUrl:   org.AvatarURL(),
+			UserName:    org.Name,
+			FullName:    org.FullName,
+			Description: org.Description,
+			Website:     org.Website,
+			Location:    org.Location,
+		},
+		IsVerified: org.IsVerified,
+	}
+}
+
+// OrganizationWithRole is an organization along with the membership flags of
+// a member.
+type OrganizationWithRole struct {
+	*Organization
+	IsOwner  bool `json:"is_owner"`
+	IsPublic bool `json:"is_public"`
+}
+
+func ToOrganizationWithRole(org *db.OrgWithRole) *OrganizationWithRole {
+	return &OrganizationWithRole{
+		Organization: ToOrganization(org.Organization),
+		IsOwner:      org.IsOwner,
+		IsPublic:     org.IsPublic,
 	}
 }
 
@@ -0,0 +1,7775 @@
+// Code generated by go-mockgen 1.3.7; DO NOT EDIT.
+//
+// This file was generated by running `go-mockgen` at the root of this repository.
+// To add additional mocks to this or another package, add a new entry to the
+// mockgen.yaml file in the root of this repository.
+
+package org
+
+import (
+	"context"
+	"sync"
+
+	db "gogs.io/gogs/internal/db"
+)
+
+// MockOrgsStore is a mock implementation of the OrgsStore interface (from
+// the package gogs.io/gogs/internal/db) used for unit testing.
+type MockOrgsStore struct {
+	// AcceptInvitationFunc is an instance of a mock function object
+	// controlling the behavior of the method AcceptInvitation.
+	AcceptInvitationFunc *OrgsStoreAcceptInvitationFunc
+	// AccessibleRepositoriesByUserFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// AccessibleRepositoriesByUser.
+	AccessibleRepositoriesByUserFunc *OrgsStoreAccessibleRepositoriesByUserFunc
+	// AccessibleRepositoryIDsByUserFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// AccessibleRepositoryIDsByUser.
+	AccessibleRepositoryIDsByUserFunc *OrgsStoreAccessibleRepositoryIDsByUserFunc
+	// AddMemberFunc is an instance of a mock function object controlling
+	// the behavior of the method AddMember.
+	AddMemberFunc *OrgsStoreAddMemberFunc
+	// AddMemberToTeamFunc is an instance of a mock function object
+	// controlling the behavior of the method AddMemberToTeam.
+	AddMemberToTeamFunc *OrgsStoreAddMemberToTeamFunc
+	// AddMembersFunc is an instance of a mock function object controlling
+	// the behavior of the method AddMembers.
+	AddMembersFunc *OrgsStoreAddMembersFunc
+	// ApplyDefaultLabelsToAllFunc is an instance of a mock function object
+	// controlling the behavior of the method ApplyDefaultLabelsToAll.
+	ApplyDefaultLabelsToAllFunc *OrgsStoreApplyDefaultLabelsToAllFunc
+	// ApplyDefaultProtectionFunc is an instance of a mock function object
+	// controlling the behavior of the method ApplyDefaultProtection.
+	ApplyDefaultProtectionFunc *OrgsStoreApplyDefaultProtectionFunc
+	// ApplyDefaultProtectionToAllFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// ApplyDefaultProtectionToAll.
+	ApplyDefaultProtectionToAllFunc *OrgsStoreApplyDefaultProtectionToAllFunc
+	// ConsumeInvitationsFunc is an instance of a mock function object
+	// controlling the behavior of the method ConsumeInvitations.
+	ConsumeInvitationsFunc *OrgsStoreConsumeInvitationsFunc
+	// CountAccessibleRepositoriesByUserFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// CountAccessibleRepositoriesByUser.
+	CountAccessibleRepositoriesByUserFunc *OrgsStoreCountAccessibleRepositoriesByUserFunc
+	// CountByUserFunc is an instance of a mock function object controlling
+	// the behavior of the method CountByUser.
+	CountByUserFunc *OrgsStoreCountByUserFunc
+	// CountMembersFunc is an instance of a mock function object controlling
+	// the behavior of the method CountMembers.
+	CountMembersFunc *OrgsStoreCountMembersFunc
+	// CountOwnedByUserFunc is an instance of a mock function object
+	// controlling the behavior of the method CountOwnedByUser.
+	CountOwnedByUserFunc *OrgsStoreCountOwnedByUserFunc
+	// CreateFunc is an instance of a mock function object controlling the
+	// behavior of the method Create.
+	CreateFunc *OrgsStoreCreateFunc
+	// DeduplicateMembersFunc is an instance of a mock function object
+	// controlling the behavior of the method DeduplicateMembers.
+	DeduplicateMembersFunc *OrgsStoreDeduplicateMembersFunc
+	// DeleteByIDFunc is an instance of a mock function object controlling
+	// the behavior of the method DeleteByID.
+	DeleteByIDFunc *OrgsStoreDeleteByIDFunc
+	// EnsureOwnersTeamFunc is an instance of a mock function object
+	// controlling the behavior of the method EnsureOwnersTeam.
+	EnsureOwnersTeamFunc *OrgsStoreEnsureOwnersTeamFunc
+	// FilterMembersFunc is an instance of a mock function object
+	// controlling the behavior of the method FilterMembers.
+	FilterMembersFunc *OrgsStoreFilterMembersFunc
+	// GetByNameFunc is an instance of a mock function object controlling
+	// the behavior of the method GetByName.
+	GetByNameFunc *OrgsStoreGetByNameFunc
+	// GetDefaultLabelsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetDefaultLabels.
+	GetDefaultLabelsFunc *OrgsStoreGetDefaultLabelsFunc
+	// GetDefaultProtectionFunc is an instance of a mock function object
+	// controlling the behavior of the method GetDefaultProtection.
+	GetDefaultProtectionFunc *OrgsStoreGetDefaultProtectionFunc
+	// GetMembershipFunc is an instance of a mock function object
+	// controlling the behavior of the method GetMembership.
+	GetMembershipFunc *OrgsStoreGetMembershipFunc
+	// GetTeamsByUserFunc is an instance of a mock function object
+	// controlling the behavior of the method GetTeamsByUser.
+	GetTeamsByUserFunc *OrgsStoreGetTeamsByUserFunc
+	// HasMemberFunc is an instance of a mock function object controlling
+	// the behavior of the method HasMember.
+	HasMemberFunc *OrgsStoreHasMemberFunc
+	// InviteByEmailFunc is an instance of a mock function object
+	// controlling the behavior of the method InviteByEmail.
+	InviteByEmailFunc *OrgsStoreInviteByEmailFunc
+	// IsOwnedByFunc is an instance of a mock function object controlling
+	// the behavior of the method IsOwnedBy.
+	IsOwnedByFunc *OrgsStoreIsOwnedByFunc
+	// LeaveOrgFunc is an instance of a mock function object controlling the
+	// behavior of the method LeaveOrg.
+	LeaveOrgFunc *OrgsStoreLeaveOrgFunc
+	// ListFunc is an instance of a mock function object controlling the
+	// behavior of the method List.
+	ListFunc *OrgsStoreListFunc
+	// ListActiveMembersFunc is an instance of a mock function object
+	// controlling the behavior of the method ListActiveMembers.
+	ListActiveMembersFunc *OrgsStoreListActiveMembersFunc
+	// ListAllReposFunc is an instance of a mock function object controlling
+	// the behavior of the method ListAllRepos.
+	ListAllReposFunc *OrgsStoreListAllReposFunc
+	// ListAuditLogFunc is an instance of a mock function object controlling
+	// the behavior of the method ListAuditLog.
+	ListAuditLogFunc *OrgsStoreListAuditLogFunc
+	// ListCommonFunc is an instance of a mock function object controlling
+	// the behavior of the method ListCommon.
+	ListCommonFunc *OrgsStoreListCommonFunc
+	// ListInvitationsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListInvitations.
+	ListInvitationsFunc *OrgsStoreListInvitationsFunc
+	// ListMemberNotifyLevelsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListMemberNotifyLevels.
+	ListMemberNotifyLevelsFunc *OrgsStoreListMemberNotifyLevelsFunc
+	// ListMembersFunc is an instance of a mock function object controlling
+	// the behavior of the method ListMembers.
+	ListMembersFunc *OrgsStoreListMembersFunc
+	// ListNotifyLevelsOfMemberFunc is an instance of a mock function object
+	// controlling the behavior of the method ListNotifyLevelsOfMember.
+	ListNotifyLevelsOfMemberFunc *OrgsStoreListNotifyLevelsOfMemberFunc
+	// ListOrgMembersWithRoleFunc is an instance of a mock function object
+	// controlling the behavior of the method ListOrgMembersWithRole.
+	ListOrgMembersWithRoleFunc *OrgsStoreListOrgMembersWithRoleFunc
+	// ListOrgsWithRepoCreatePermissionFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// ListOrgsWithRepoCreatePermission.
+	ListOrgsWithRepoCreatePermissionFunc *OrgsStoreListOrgsWithRepoCreatePermissionFunc
+	// ListOrgsWithRoleFunc is an instance of a mock function object
+	// controlling the behavior of the method ListOrgsWithRole.
+	ListOrgsWithRoleFunc *OrgsStoreListOrgsWithRoleFunc
+	// ListOrphanedFunc is an instance of a mock function object controlling
+	// the behavior of the method ListOrphaned.
+	ListOrphanedFunc *OrgsStoreListOrphanedFunc
+	// ListPinnedReposFunc is an instance of a mock function object
+	// controlling the behavior of the method ListPinnedRepos.
+	ListPinnedReposFunc *OrgsStoreListPinnedReposFunc
+	// RecountAllFunc is an instance of a mock function object controlling
+	// the behavior of the method RecountAll.
+	RecountAllFunc *OrgsStoreRecountAllFunc
+	// RemoveMemberFunc is an instance of a mock function object controlling
+	// the behavior of the method RemoveMember.
+	RemoveMemberFunc *OrgsStoreRemoveMemberFunc
+	// RevokeInvitationFunc is an instance of a mock function object
+	// controlling the behavior of the method RevokeInvitation.
+	RevokeInvitationFunc *OrgsStoreRevokeInvitationFunc
+	// SearchByNameFunc is an instance of a mock function object controlling
+	// the behavior of the method SearchByName.
+	SearchByNameFunc *OrgsStoreSearchByNameFunc
+	// SearchMembersFunc is an instance of a mock function object
+	// controlling the behavior of the method SearchMembers.
+	SearchMembersFunc *OrgsStoreSearchMembersFunc
+	// SearchVisibleByNameFunc is an instance of a mock function object
+	// controlling the behavior of the method SearchVisibleByName.
+	SearchVisibleByNameFunc *OrgsStoreSearchVisibleByNameFunc
+	// SetArchivedFunc is an instance of a mock function object controlling
+	// the behavior of the method SetArchived.
+	SetArchivedFunc *OrgsStoreSetArchivedFunc
+	// SetDefaultLabelsFunc is an instance of a mock function object
+	// controlling the behavior of the method SetDefaultLabels.
+	SetDefaultLabelsFunc *OrgsStoreSetDefaultLabelsFunc
+	// SetDefaultProtectionFunc is an instance of a mock function object
+	// controlling the behavior of the method SetDefaultProtection.
+	SetDefaultProtectionFunc *OrgsStoreSetDefaultProtectionFunc
+	// SetDefaultRepoPermissionFunc is an instance of a mock function object
+	// controlling the behavior of the method SetDefaultRepoPermission.
+	SetDefaultRepoPermissionFunc *OrgsStoreSetDefaultRepoPermissionFunc
+	// SetMemberNotifyLevelFunc is an instance of a mock function object
+	// controlling the behavior of the method SetMemberNotifyLevel.
+	SetMemberNotifyLevelFunc *OrgsStoreSetMemberNotifyLevelFunc
+	// SetMemberVisibilityFunc is an instance of a mock function object
+	// controlling the behavior of the method SetMemberVisibility.
+	SetMemberVisibilityFunc *OrgsStoreSetMemberVisibilityFunc
+	// SetOwnerFunc is an instance of a mock function object controlling the
+	// behavior of the method SetOwner.
+	SetOwnerFunc *OrgsStoreSetOwnerFunc
+	// SetPinnedReposFunc is an instance of a mock function object
+	// controlling the behavior of the method SetPinnedRepos.
+	SetPinnedReposFunc *OrgsStoreSetPinnedReposFunc
+	// SetRepoCreatePermissionFunc is an instance of a mock function object
+	// controlling the behavior of the method SetRepoCreatePermission.
+	SetRepoCreatePermissionFunc *OrgsStoreSetRepoCreatePermissionFunc
+	// SetRequireTwoFactorFunc is an instance of a mock function object
+	// controlling the behavior of the method SetRequireTwoFactor.
+	SetRequireTwoFactorFunc *OrgsStoreSetRequireTwoFactorFunc
+	// SetVerifiedFunc is an instance of a mock function object controlling
+	// the behavior of the method SetVerified.
+	SetVerifiedFunc *OrgsStoreSetVerifiedFunc
+	// StreamMembersFunc is an instance of a mock function object
+	// controlling the behavior of the method StreamMembers.
+	StreamMembersFunc *OrgsStoreStreamMembersFunc
+	// TransferOwnershipFunc is an instance of a mock function object
+	// controlling the behavior of the method TransferOwnership.
+	TransferOwnershipFunc *OrgsStoreTransferOwnershipFunc
+}
+
+// NewMockOrgsStore creates a new mock of the OrgsStore interface. All
+// methods return zero values for all results, unless overwritten.
+func NewMockOrgsStore() *MockOrgsStore {
+	return &MockOrgsStore{
+		AcceptInvitationFunc: &OrgsStoreAcceptInvitationFunc{
+			defaultHook: func(context.Context, int64, string) (r0 *db.OrgInvitation, r1 error) {
+				return
+			},
+		},
+		AccessibleRepositoriesByUserFunc: &OrgsStoreAccessibleRepositoriesByUserFunc{
+			defaultHook: func(context.Context, int64, int64, int, int, db.AccessibleRepositoriesByUserOptions) (r0 []*db.Repository, r1 int64, r2 bool, r3 error) {
+				return
+			},
+		},
+		AccessibleRepositoryIDsByUserFunc: &OrgsStoreAccessibleRepositoryIDsByUserFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 []int64, r1 error) {
+				return
+			},
+		},
+		AddMemberFunc: &OrgsStoreAddMemberFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 error) {
+				return
+			},
+		},
+		AddMemberToTeamFunc: &OrgsStoreAddMemberToTeamFunc{
+			defaultHook: func(context.Context, int64, int64, int64) (r0 error) {
+				return
+			},
+		},
+		AddMembersFunc: &OrgsStoreAddMembersFunc{
+			defaultHook: func(context.Context, int64, []int64) (r0 error) {
+				return
+			},
+		},
+		ApplyDefaultLabelsToAllFunc: &OrgsStoreApplyDefaultLabelsToAllFunc{
+			defaultHook: func(context.Context, int64) (r0 error) {
+				return
+			},
+		},
+		ApplyDefaultProtectionFunc: &OrgsStoreApplyDefaultProtectionFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 error) {
+				return
+			},
+		},
+		ApplyDefaultProtectionToAllFunc: &OrgsStoreApplyDefaultProtectionToAllFunc{
+			defaultHook: func(context.Context, int64) (r0 error) {
+				return
+			},
+		},
+		ConsumeInvitationsFunc: &OrgsStoreConsumeInvitationsFunc{
+			defaultHook: func(context.Context, int64) (r0 error) {
+				return
+			},
+		},
+		CountAccessibleRepositoriesByUserFunc: &OrgsStoreCountAccessibleRepositoriesByUserFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 int64, r1 error) {
+				return
+			},
+		},
+		CountByUserFunc: &OrgsStoreCountByUserFunc{
+			defaultHook: func(context.Context, int64) (r0 int64, r1 error) {
+				return
+			},
+		},
+		CountMembersFunc: &OrgsStoreCountMembersFunc{
+			defaultHook: func(context.Context, int64) (r0 int64, r1 error) {
+				return
+			},
+		},
+		CountOwnedByUserFunc: &OrgsStoreCountOwnedByUserFunc{
+			defaultHook: func(context.Context, int64) (r0 int64, r1 error) {
+				return
+			},
+		},
+		CreateFunc: &OrgsStoreCreateFunc{
+			defaultHook: func(context.Context, string, int64, db.CreateOrgOptions) (r0 *db.User, r1 error) {
+				return
+			},
+		},
+		DeduplicateMembersFunc: &OrgsStoreDeduplicateMembersFunc{
+			defaultHook: func(context.Context, int64) (r0 int64, r1 error) {
+				return
+			},
+		},
+		DeleteByIDFunc: &OrgsStoreDeleteByIDFunc{
+			defaultHook: func(context.Context, int64, db.DeleteOrgOptions) (r0 error) {
+				return
+			},
+		},
+		EnsureOwnersTeamFunc: &OrgsStoreEnsureOwnersTeamFunc{
+			defaultHook: func(context.Context, int64) (r0 *db.Team, r1 error) {
+				return
+			},
+		},
+		FilterMembersFunc: &OrgsStoreFilterMembersFunc{
+			defaultHook: func(context.Context, int64, []int64) (r0 map[int64]bool, r1 error) {
+				return
+			},
+		},
+		GetByNameFunc: &OrgsStoreGetByNameFunc{
+			defaultHook: func(context.Context, string) (r0 *db.User, r1 error) {
+				return
+			},
+		},
+		GetDefaultLabelsFunc: &OrgsStoreGetDefaultLabelsFunc{
+			defaultHook: func(context.Context, int64) (r0 []*db.OrgLabel, r1 error) {
+				return
+			},
+		},
+		GetDefaultProtectionFunc: &OrgsStoreGetDefaultProtectionFunc{
+			defaultHook: func(context.Context, int64) (r0 []*db.OrgProtectBranch, r1 error) {
+				return
+			},
+		},
+		GetMembershipFunc: &OrgsStoreGetMembershipFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 *db.OrgUser, r1 error) {
+				return
+			},
+		},
+		GetTeamsByUserFunc: &OrgsStoreGetTeamsByUserFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 []*db.Team, r1 error) {
+				return
+			},
+		},
+		HasMemberFunc: &OrgsStoreHasMemberFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 bool) {
+				return
+			},
+		},
+		InviteByEmailFunc: &OrgsStoreInviteByEmailFunc{
+			defaultHook: func(context.Context, int64, string, int64) (r0 *db.OrgInvitation, r1 error) {
+				return
+			},
+		},
+		IsOwnedByFunc: &OrgsStoreIsOwnedByFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 bool) {
+				return
+			},
+		},
+		LeaveOrgFunc: &OrgsStoreLeaveOrgFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 error) {
+				return
+			},
+		},
+		ListFunc: &OrgsStoreListFunc{
+			defaultHook: func(context.Context, db.ListOrgsOptions) (r0 []*db.User, r1 error) {
+				return
+			},
+		},
+		ListActiveMembersFunc: &OrgsStoreListActiveMembersFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 []*db.User, r1 error) {
+				return
+			},
+		},
+		ListAllReposFunc: &OrgsStoreListAllReposFunc{
+			defaultHook: func(context.Context, int64, int, int, db.ListAllReposOptions) (r0 []*db.Repository, r1 int64, r2 error) {
+				return
+			},
+		},
+		ListAuditLogFunc: &OrgsStoreListAuditLogFunc{
+			defaultHook: func(context.Context, int64, db.ListAuditLogOptions) (r0 []*db.AuditLog, r1 int64, r2 error) {
+				return
+			},
+		},
+		ListCommonFunc: &OrgsStoreListCommonFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 []*db.User, r1 error) {
+				return
+			},
+		},
+		ListInvitationsFunc: &OrgsStoreListInvitationsFunc{
+			defaultHook: func(context.Context, int64) (r0 []*db.OrgInvitation, r1 error) {
+				return
+			},
+		},
+		ListMemberNotifyLevelsFunc: &OrgsStoreListMemberNotifyLevelsFunc{
+			defaultHook: func(context.Context, int64) (r0 map[int64]int, r1 error) {
+				return
+			},
+		},
+		ListMembersFunc: &OrgsStoreListMembersFunc{
+			defaultHook: func(context.Context, int64, db.ListOrgMembersOptions) (r0 []*db.User, r1 int64, r2 error) {
+				return
+			},
+		},
+		ListNotifyLevelsOfMemberFunc: &OrgsStoreListNotifyLevelsOfMemberFunc{
+			defaultHook: func(context.Context, int64) (r0 map[int64]int, r1 error) {
+				return
+			},
+		},
+		ListOrgMembersWithRoleFunc: &OrgsStoreListOrgMembersWithRoleFunc{
+			defaultHook: func(context.Context, int64, db.ListOrgMembersOptions) (r0 []*db.OrgMemberWithRole, r1 error) {
+				return
+			},
+		},
+		ListOrgsWithRepoCreatePermissionFunc: &OrgsStoreListOrgsWithRepoCreatePermissionFunc{
+			defaultHook: func(context.Context, int64) (r0 []*db.User, r1 error) {
+				return
+			},
+		},
+		ListOrgsWithRoleFunc: &OrgsStoreListOrgsWithRoleFunc{
+			defaultHook: func(context.Context, db.ListOrgsOptions) (r0 []*db.OrgWithRole, r1 error) {
+				return
+			},
+		},
+		ListOrphanedFunc: &OrgsStoreListOrphanedFunc{
+			defaultHook: func(context.Context) (r0 []*db.User, r1 error) {
+				return
+			},
+		},
+		ListPinnedReposFunc: &OrgsStoreListPinnedReposFunc{
+			defaultHook: func(context.Context, int64) (r0 []*db.Repository, r1 error) {
+				return
+			},
+		},
+		RecountAllFunc: &OrgsStoreRecountAllFunc{
+			defaultHook: func(context.Context, int64) (r0 error) {
+				return
+			},
+		},
+		RemoveMemberFunc: &OrgsStoreRemoveMemberFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 error) {
+				return
+			},
+		},
+		RevokeInvitationFunc: &OrgsStoreRevokeInvitationFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 error) {
+				return
+			},
+		},
+		SearchByNameFunc: &OrgsStoreSearchByNameFunc{
+			defaultHook: func(context.Context, string, int, int, string, bool) (r0 []*db.User, r1 int64, r2 error) {
+				return
+			},
+		},
+		SearchMembersFunc: &OrgsStoreSearchMembersFunc{
+			defaultHook: func(context.Context, int64, string, int, int, db.SearchOrgMembersOptions) (r0 []*db.User, r1 int64, r2 error) {
+				return
+			},
+		},
+		SearchVisibleByNameFunc: &OrgsStoreSearchVisibleByNameFunc{
+			defaultHook: func(context.Context, string, int, int, string, int64) (r0 []*db.User, r1 int64, r2 error) {
+				return
+			},
+		},
+		SetArchivedFunc: &OrgsStoreSetArchivedFunc{
+			defaultHook: func(context.Context, int64, bool) (r0 error) {
+				return
+			},
+		},
+		SetDefaultLabelsFunc: &OrgsStoreSetDefaultLabelsFunc{
+			defaultHook: func(context.Context, int64, []*db.OrgLabel) (r0 error) {
+				return
+			},
+		},
+		SetDefaultProtectionFunc: &OrgsStoreSetDefaultProtectionFunc{
+			defaultHook: func(context.Context, int64, []*db.OrgProtectBranch) (r0 error) {
+				return
+			},
+		},
+		SetDefaultRepoPermissionFunc: &OrgsStoreSetDefaultRepoPermissionFunc{
+			defaultHook: func(context.Context, int64, db.AccessMode) (r0 error) {
+				return
+			},
+		},
+		SetMemberNotifyLevelFunc: &OrgsStoreSetMemberNotifyLevelFunc{
+			defaultHook: func(context.Context, int64, int64, int) (r0 error) {
+				return
+			},
+		},
+		SetMemberVisibilityFunc: &OrgsStoreSetMemberVisibilityFunc{
+			defaultHook: func(context.Context, int64, int64, bool) (r0 error) {
+				return
+			},
+		},
+		SetOwnerFunc: &OrgsStoreSetOwnerFunc{
+			defaultHook: func(context.Context, int64, int64, bool) (r0 error) {
+				return
+			},
+		},
+		SetPinnedReposFunc: &OrgsStoreSetPinnedReposFunc{
+			defaultHook: func(context.Context, int64, []int64) (r0 error) {
+				return
+			},
+		},
+		SetRepoCreatePermissionFunc: &OrgsStoreSetRepoCreatePermissionFunc{
+			defaultHook: func(context.Context, int64, db.RepoCreatePermission) (r0 error) {
+				return
+			},
+		},
+		SetRequireTwoFactorFunc: &OrgsStoreSetRequireTwoFactorFunc{
+			defaultHook: func(context.Context, int64, bool) (r0 error) {
+				return
+			},
+		},
+		SetVerifiedFunc: &OrgsStoreSetVerifiedFunc{
+			defaultHook: func(context.Context, int64, bool) (r0 error) {
+				return
+			},
+		},
+		StreamMembersFunc: &OrgsStoreStreamMembersFunc{
+			defaultHook: func(context.Context, int64, func(*db.OrgMemberWithRole) error) (r0 error) {
+				return
+			},
+		},
+		TransferOwnershipFunc: &OrgsStoreTransferOwnershipFunc{
+			defaultHook: func(context.Context, int64, int64, db.TransferOptions) (r0 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockOrgsStore creates a new mock of the OrgsStore interface. All
+// methods panic on invocation, unless overwritten.
+func NewStrictMockOrgsStore() *MockOrgsStore {
+	return &MockOrgsStore{
+		AcceptInvitationFunc: &OrgsStoreAcceptInvitationFunc{
+			defaultHook: func(context.Context, int64, string) (*db.OrgInvitation, error) {
+				panic("unexpected invocation of MockOrgsStore.AcceptInvitation")
+			},
+		},
+		AccessibleRepositoriesByUserFunc: &OrgsStoreAccessibleRepositoriesByUserFunc{
+			defaultHook: func(context.Context, int64, int64, int, int, db.AccessibleRepositoriesByUserOptions) ([]*db.Repository, int64, bool, error) {
+				panic("unexpected invocation of MockOrgsStore.AccessibleRepositoriesByUser")
+			},
+		},
+		AccessibleRepositoryIDsByUserFunc: &OrgsStoreAccessibleRepositoryIDsByUserFunc{
+			defaultHook: func(context.Context, int64, int64) ([]int64, error) {
+				panic("unexpected invocation of MockOrgsStore.AccessibleRepositoryIDsByUser")
+			},
+		},
+		AddMemberFunc: &OrgsStoreAddMemberFunc{
+			defaultHook: func(context.Context, int64, int64) error {
+				panic("unexpected invocation of MockOrgsStore.AddMember")
+			},
+		},
+		AddMemberToTeamFunc: &OrgsStoreAddMemberToTeamFunc{
+			defaultHook: func(context.Context, int64, int64, int64) error {
+				panic("unexpected invocation of MockOrgsStore.AddMemberToTeam")
+			},
+		},
+		AddMembersFunc: &OrgsStoreAddMembersFunc{
+			defaultHook: func(context.Context, int64, []int64) error {
+				panic("unexpected invocation of MockOrgsStore.AddMembers")
+			},
+		},
+		ApplyDefaultLabelsToAllFunc: &OrgsStoreApplyDefaultLabelsToAllFunc{
+			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockOrgsStore.ApplyDefaultLabelsToAll")
+			},
+		},
+		ApplyDefaultProtectionFunc: &OrgsStoreApplyDefaultProtectionFunc{
+			defaultHook: func(context.Context, int64, int64) error {
+				panic("unexpected invocation of MockOrgsStore.ApplyDefaultProtection")
+			},
+		},
+		ApplyDefaultProtectionToAllFunc: &OrgsStoreApplyDefaultProtectionToAllFunc{
+			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockOrgsStore.ApplyDefaultProtectionToAll")
+			},
+		},
+		ConsumeInvitationsFunc: &OrgsStoreConsumeInvitationsFunc{
+			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockOrgsStore.ConsumeInvitations")
+			},
+		},
+		CountAccessibleRepositoriesByUserFunc: &OrgsStoreCountAccessibleRepositoriesByUserFunc{
+			defaultHook: func(context.Context, int64, int64) (int64, error) {
+				panic("unexpected invocation of MockOrgsStore.CountAccessibleRepositoriesByUser")
+			},
+		},
+		CountByUserFunc: &OrgsStoreCountByUserFunc{
+			defaultHook: func(context.Context, int64) (int64, error) {
+				panic("unexpected invocation of MockOrgsStore.CountByUser")
+			},
+		},
+		CountMembersFunc: &OrgsStoreCountMembersFunc{
+			defaultHook: func(context.Context, int64) (int64, error) {
+				panic("unexpected invocation of MockOrgsStore.CountMembers")
+			},
+		},
+		CountOwnedByUserFunc: &OrgsStoreCountOwnedByUserFunc{
+			defaultHook: func(context.Context, int64) (int64, error) {
+				panic("unexpected invocation of MockOrgsStore.CountOwnedByUser")
+			},
+		},
+		CreateFunc: &OrgsStoreCreateFunc{
+			defaultHook: func(context.Context, string, int64, db.CreateOrgOptions) (*db.User, error) {
+				panic("unexpected invocation of MockOrgsStore.Create")
+			},
+		},
+		DeduplicateMembersFunc: &OrgsStoreDeduplicateMembersFunc{
+			defaultHook: func(context.Context, int64) (int64, error) {
+				panic("unexpected invocation of MockOrgsStore.DeduplicateMembers")
+			},
+		},
+		DeleteByIDFunc: &OrgsStoreDeleteByIDFunc{
+			defaultHook: func(context.Context, int64, db.DeleteOrgOptions) error {
+				panic("unexpected invocation of MockOrgsStore.DeleteByID")
+			},
+		},
+		EnsureOwnersTeamFunc: &OrgsStoreEnsureOwnersTeamFunc{
+			defaultHook: func(context.Context, int64) (*db.Team, error) {
+				panic("unexpected invocation of MockOrgsStore.EnsureOwnersTeam")
+			},
+		},
+		FilterMembersFunc: &OrgsStoreFilterMembersFunc{
+			defaultHook: func(context.Context, int64, []int64) (map[int64]bool, error) {
+				panic("unexpected invocation of MockOrgsStore.FilterMembers")
+			},
+		},
+		GetByNameFunc: &OrgsStoreGetByNameFunc{
+			defaultHook: func(context.Context, string) (*db.User, error) {
+				panic("unexpected invocation of MockOrgsStore.GetByName")
+			},
+		},
+		GetDefaultLabelsFunc: &OrgsStoreGetDefaultLabelsFunc{
+			defaultHook: func(context.Context, int64) ([]*db.OrgLabel, error) {
+				panic("unexpected invocation of MockOrgsStore.GetDefaultLabels")
+			},
+		},
+		GetDefaultProtectionFunc: &OrgsStoreGetDefaultProtectionFunc{
+			defaultHook: func(context.Context, int64) ([]*db.OrgProtectBranch, error) {
+				panic("unexpected invocation of MockOrgsStore.GetDefaultProtection")
+			},
+		},
+		GetMembershipFunc: &OrgsStoreGetMembershipFunc{
+			defaultHook: func(context.Context, int64, int64) (*db.OrgUser, error) {
+				panic("unexpected invocation of MockOrgsStore.GetMembership")
+			},
+		},
+		GetTeamsByUserFunc: &OrgsStoreGetTeamsByUserFunc{
+			defaultHook: func(context.Context, int64, int64) ([]*db.Team, error) {
+				panic("unexpected invocation of MockOrgsStore.GetTeamsByUser")
+			},
+		},
+		HasMemberFunc: &OrgsStoreHasMemberFunc{
+			defaultHook: func(context.Context, int64, int64) bool {
+				panic("unexpected invocation of MockOrgsStore.HasMember")
+			},
+		},
+		InviteByEmailFunc: &OrgsStoreInviteByEmailFunc{
+			defaultHook: func(context.Context, int64, string, int64) (*db.OrgInvitation, error) {
+				panic("unexpected invocation of MockOrgsStore.InviteByEmail")
+			},
+		},
+		IsOwnedByFunc: &OrgsStoreIsOwnedByFunc{
+			defaultHook: func(context.Context, int64, int64) bool {
+				panic("unexpected invocation of MockOrgsStore.IsOwnedBy")
+			},
+		},
+		LeaveOrgFunc: &OrgsStoreLeaveOrgFunc{
+			defaultHook: func(context.Context, int64, int64) error {
+				panic("unexpected invocation of MockOrgsStore.LeaveOrg")
+			},
+		},
+		ListFunc: &OrgsStoreListFunc{
+			defaultHook: func(context.Context, db.ListOrgsOptions) ([]*db.User, error) {
+				panic("unexpected invocation of MockOrgsStore.List")
+			},
+		},
+		ListActiveMembersFunc: &OrgsStoreListActiveMembersFunc{
+			defaultHook: func(context.Context, int64, int64) ([]*db.User, error) {
+				panic("unexpected invocation of MockOrgsStore.ListActiveMembers")
+			},
+		},
+		ListAllReposFunc: &OrgsStoreListAllReposFunc{
+			defaultHook: func(context.Context, int64, int, int, db.ListAllReposOptions) ([]*db.Repository, int64, error) {
+				panic("unexpected invocation of MockOrgsStore.ListAllRepos")
+			},
+		},
+		ListAuditLogFunc: &OrgsStoreListAuditLogFunc{
+			defaultHook: func(context.Context, int64, db.ListAuditLogOptions) ([]*db.AuditLog, int64, error) {
+				panic("unexpected invocation of MockOrgsStore.ListAuditLog")
+			},
+		},
+		ListCommonFunc: &OrgsStoreListCommonFunc{
+			defaultHook: func(context.Context, int64, int64) ([]*db.User, error) {
+				panic("unexpected invocation of MockOrgsStore.ListCommon")
+			},
+		},
+		ListInvitationsFunc: &OrgsStoreListInvitationsFunc{
+			defaultHook: func(context.Context, int64) ([]*db.OrgInvitation, error) {
+				panic("unexpected invocation of MockOrgsStore.ListInvitations")
+			},
+		},
+		ListMemberNotifyLevelsFunc: &OrgsStoreListMemberNotifyLevelsFunc{
+			defaultHook: func(context.Context, int64) (map[int64]int, error) {
+				panic("unexpected invocation of MockOrgsStore.ListMemberNotifyLevels")
+			},
+		},
+		ListMembersFunc: &OrgsStoreListMembersFunc{
+			defaultHook: func(context.Context, int64, db.ListOrgMembersOptions) ([]*db.User, int64, error) {
+				panic("unexpected invocation of MockOrgsStore.ListMembers")
+			},
+		},
+		ListNotifyLevelsOfMemberFunc: &OrgsStoreListNotifyLevelsOfMemberFunc{
+			defaultHook: func(context.Context, int64) (map[int64]int, error) {
+				panic("unexpected invocation of MockOrgsStore.ListNotifyLevelsOfMember")
+			},
+		},
+		ListOrgMembersWithRoleFunc: &OrgsStoreListOrgMembersWithRoleFunc{
+			defaultHook: func(context.Context, int64, db.ListOrgMembersOptions) ([]*db.OrgMemberWithRole, error) {
+				panic("unexpected invocation of MockOrgsStore.ListOrgMembersWithRole")
+			},
+		},
+		ListOrgsWithRepoCreatePermissionFunc: &OrgsStoreListOrgsWithRepoCreatePermissionFunc{
+			defaultHook: func(context.Context, int64) ([]*db.User, error) {
+				panic("unexpected invocation of MockOrgsStore.ListOrgsWithRepoCreatePermission")
+			},
+		},
+		ListOrgsWithRoleFunc: &OrgsStoreListOrgsWithRoleFunc{
+			defaultHook: func(context.Context, db.ListOrgsOptions) ([]*db.OrgWithRole, error) {
+				panic("unexpected invocation of MockOrgsStore.ListOrgsWithRole")
+			},
+		},
+		ListOrphanedFunc: &OrgsStoreListOrphanedFunc{
+			defaultHook: func(context.Context) ([]*db.User, error) {
+				panic("unexpected invocation of MockOrgsStore.ListOrphaned")
+			},
+		},
+		ListPinnedReposFunc: &OrgsStoreListPinnedReposFunc{
+			defaultHook: func(context.Context, int64) ([]*db.Repository, error) {
+				panic("unexpected invocation of MockOrgsStore.ListPinnedRepos")
+			},
+		},
+		RecountAllFunc: &OrgsStoreRecountAllFunc{
+			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockOrgsStore.RecountAll")
+			},
+		},
+		RemoveMemberFunc: &OrgsStoreRemoveMemberFunc{
+			defaultHook: func(context.Context, int64, int64) error {
+				panic("unexpected invocation of MockOrgsStore.RemoveMember")
+			},
+		},
+		RevokeInvitationFunc: &OrgsStoreRevokeInvitationFunc{
+			defaultHook: func(context.Context, int64, int64) error {
+				panic("unexpected invocation of MockOrgsStore.RevokeInvitation")
+			},
+		},
+		SearchByNameFunc: &OrgsStoreSearchByNameFunc{
+			defaultHook: func(context.Context, string, int, int, string, bool) ([]*db.User, int64, error) {
+				panic("unexpected invocation of MockOrgsStore.SearchByName")
+			},
+		},
+		SearchMembersFunc: &OrgsStoreSearchMembersFunc{
+			defaultHook: func(context.Context, int64, string, int, int, db.SearchOrgMembersOptions) ([]*db.User, int64, error) {
+				panic("unexpected invocation of MockOrgsStore.SearchMembers")
+			},
+		},
+		SearchVisibleByNameFunc: &OrgsStoreSearchVisibleByNameFunc{
+			defaultHook: func(context.Context, string, int, int, string, int64) ([]*db.User, int64, error) {
+				panic("unexpected invocation of MockOrgsStore.SearchVisibleByName")
+			},
+		},
+		SetArchivedFunc: &OrgsStoreSetArchivedFunc{
+			defaultHook: func(context.Context, int64, bool) error {
+				panic("unexpected invocation of MockOrgsStore.SetArchived")
+			},
+		},
+		SetDefaultLabelsFunc: &OrgsStoreSetDefaultLabelsFunc{
+			defaultHook: func(context.Context, int64, []*db.OrgLabel) error {
+				panic("unexpected invocation of MockOrgsStore.SetDefaultLabels")
+			},
+		},
+		SetDefaultProtectionFunc: &OrgsStoreSetDefaultProtectionFunc{
+			defaultHook: func(context.Context, int64, []*db.OrgProtectBranch) error {
+				panic("unexpected invocation of MockOrgsStore.SetDefaultProtection")
+			},
+		},
+		SetDefaultRepoPermissionFunc: &OrgsStoreSetDefaultRepoPermissionFunc{
+			defaultHook: func(context.Context, int64, db.AccessMode) error {
+				panic("unexpected invocation of MockOrgsStore.SetDefaultRepoPermission")
+			},
+		},
+		SetMemberNotifyLevelFunc: &OrgsStoreSetMemberNotifyLevelFunc{
+			defaultHook: func(context.Context, int64, int64, int) error {
+				panic("unexpected invocation of MockOrgsStore.SetMemberNotifyLevel")
+			},
+		},
+		SetMemberVisibilityFunc: &OrgsStoreSetMemberVisibilityFunc{
+			defaultHook: func(context.Context, int64, int64, bool) error {
+				panic("unexpected invocation of MockOrgsStore.SetMemberVisibility")
+			},
+		},
+		SetOwnerFunc: &OrgsStoreSetOwnerFunc{
+			defaultHook: func(context.Context, int64, int64, bool) error {
+				panic("unexpected invocation of MockOrgsStore.SetOwner")
+			},
+		},
+		SetPinnedReposFunc: &OrgsStoreSetPinnedReposFunc{
+			defaultHook: func(context.Context, int64, []int64) error {
+				panic("unexpected invocation of MockOrgsStore.SetPinnedRepos")
+			},
+		},
+		SetRepoCreatePermissionFunc: &OrgsStoreSetRepoCreatePermissionFunc{
+			defaultHook: func(context.Context, int64, db.RepoCreatePermission) error {
+				panic("unexpected invocation of MockOrgsStore.SetRepoCreatePermission")
+			},
+		},
+		SetRequireTwoFactorFunc: &OrgsStoreSetRequireTwoFactorFunc{
+			defaultHook: func(context.Context, int64, bool) error {
+				panic("unexpected invocation of MockOrgsStore.SetRequireTwoFactor")
+			},
+		},
+		SetVerifiedFunc: &OrgsStoreSetVerifiedFunc{
+			defaultHook: func(context.Context, int64, bool) error {
+				panic("unexpected invocation of MockOrgsStore.SetVerified")
+			},
+		},
+		StreamMembersFunc: &OrgsStoreStreamMembersFunc{
+			defaultHook: func(context.Context, int64, func(*db.OrgMemberWithRole) error) error {
+				panic("unexpected invocation of MockOrgsStore.StreamMembers")
+			},
+		},
+		TransferOwnershipFunc: &OrgsStoreTransferOwnershipFunc{
+			defaultHook: func(context.Context, int64, int64, db.TransferOptions) error {
+				panic("unexpected invocation of MockOrgsStore.TransferOwnership")
+			},
+		},
+	}
+}
+
+// NewMockOrgsStoreFrom creates a new mock of the MockOrgsStore interface.
+// All methods delegate to the given implementation, unless overwritten.
+func NewMockOrgsStoreFrom(i db.OrgsStore) *MockOrgsStore {
+	return &MockOrgsStore{
+		AcceptInvitationFunc: &OrgsStoreAcceptInvitationFunc{
+			defaultHook: i.AcceptInvitation,
+		},
+		AccessibleRepositoriesByUserFunc: &OrgsStoreAccessibleRepositoriesByUserFunc{
+			defaultHook: i.AccessibleRepositoriesByUser,
+		},
+		AccessibleRepositoryIDsByUserFunc: &OrgsStoreAccessibleRepositoryIDsByUserFunc{
+			defaultHook: i.AccessibleRepositoryIDsByUser,
+		},
+		AddMemberFunc: &OrgsStoreAddMemberFunc{
+			defaultHook: i.AddMember,
+		},
+		AddMemberToTeamFunc: &OrgsStoreAddMemberToTeamFunc{
+			defaultHook: i.AddMemberToTeam,
+		},
+		AddMembersFunc: &OrgsStoreAddMembersFunc{
+			defaultHook: i.AddMembers,
+		},
+		ApplyDefaultLabelsToAllFunc: &OrgsStoreApplyDefaultLabelsToAllFunc{
+			defaultHook: i.ApplyDefaultLabelsToAll,
+		},
+		ApplyDefaultProtectionFunc: &OrgsStoreApplyDefaultProtectionFunc{
+			defaultHook: i.ApplyDefaultProtection,
+		},
+		ApplyDefaultProtectionToAllFunc: &OrgsStoreApplyDefaultProtectionToAllFunc{
+			defaultHook: i.ApplyDefaultProtectionToAll,
+		},
+		ConsumeInvitationsFunc: &OrgsStoreConsumeInvitationsFunc{
+			defaultHook: i.ConsumeInvitations,
+		},
+		CountAccessibleRepositoriesByUserFunc: &OrgsStoreCountAccessibleRepositoriesByUserFunc{
+			defaultHook: i.CountAccessibleRepositoriesByUser,
+		},
+		CountByUserFunc: &OrgsStoreCountByUserFunc{
+			defaultHook: i.CountByUser,
+		},
+		CountMembersFunc: &OrgsStoreCountMembersFunc{
+			defaultHook: i.CountMembers,
+		},
+		CountOwnedByUserFunc: &OrgsStoreCountOwnedByUserFunc{
+			defaultHook: i.CountOwnedByUser,
+		},
+		CreateFunc: &OrgsStoreCreateFunc{
+			defaultHook: i.Create,
+		},
+		DeduplicateMembersFunc: &OrgsStoreDeduplicateMembersFunc{
+			defaultHook: i.DeduplicateMembers,
+		},
+		DeleteByIDFunc: &OrgsStoreDeleteByIDFunc{
+			defaultHook: i.DeleteByID,
+		},
+		EnsureOwnersTeamFunc: &OrgsStoreEnsureOwnersTeamFunc{
+			defaultHook: i.EnsureOwnersTeam,
+		},
+		FilterMembersFunc: &OrgsStoreFilterMembersFunc{
+			defaultHook: i.FilterMembers,
+		},
+		GetByNameFunc: &OrgsStoreGetByNameFunc{
+			defaultHook: i.GetByName,
+		},
+		GetDefaultLabelsFunc: &OrgsStoreGetDefaultLabelsFunc{
+			defaultHook: i.GetDefaultLabels,
+		},
+		GetDefaultProtectionFunc: &OrgsStoreGetDefaultProtectionFunc{
+			defaultHook: i.GetDefaultProtection,
+		},
+		GetMembershipFunc: &OrgsStoreGetMembershipFunc{
+			defaultHook: i.GetMembership,
+		},
+		GetTeamsByUserFunc: &OrgsStoreGetTeamsByUserFunc{
+			defaultHook: i.GetTeamsByUser,
+		},
+		HasMemberFunc: &OrgsStoreHasMemberFunc{
+			defaultHook: i.HasMember,
+		},
+		InviteByEmailFunc: &OrgsStoreInviteByEmailFunc{
+			defaultHook: i.InviteByEmail,
+		},
+		IsOwnedByFunc: &OrgsStoreIsOwnedByFunc{
+			defaultHook: i.IsOwnedBy,
+		},
+		LeaveOrgFunc: &OrgsStoreLeaveOrgFunc{
+			defaultHook: i.LeaveOrg,
+		},
+		ListFunc: &OrgsStoreListFunc{
+			defaultHook: i.List,
+		},
+		ListActiveMembersFunc: &OrgsStoreListActiveMembersFunc{
+			defaultHook: i.ListActiveMembers,
+		},
+		ListAllReposFunc: &OrgsStoreListAllReposFunc{
+			defaultHook: i.ListAllRepos,
+		},
+		ListAuditLogFunc: &OrgsStoreListAuditLogFunc{
+			defaultHook: i.ListAuditLog,
+		},
+		ListCommonFunc: &OrgsStoreListCommonFunc{
+			defaultHook: i.ListCommon,
+		},
+		ListInvitationsFunc: &OrgsStoreListInvitationsFunc{
+			defaultHook: i.ListInvitations,
+		},
+		ListMemberNotifyLevelsFunc: &OrgsStoreListMemberNotifyLevelsFunc{
+			defaultHook: i.ListMemberNotifyLevels,
+		},
+		ListMembersFunc: &OrgsStoreListMembersFunc{
+			defaultHook: i.ListMembers,
+		},
+		ListNotifyLevelsOfMemberFunc: &OrgsStoreListNotifyLevelsOfMemberFunc{
+			defaultHook: i.ListNotifyLevelsOfMember,
+		},
+		ListOrgMembersWithRoleFunc: &OrgsStoreListOrgMembersWithRoleFunc{
+			defaultHook: i.ListOrgMembersWithRole,
+		},
+		ListOrgsWithRepoCreatePermissionFunc: &OrgsStoreListOrgsWithRepoCreatePermissionFunc{
+			defaultHook: i.ListOrgsWithRepoCreatePermission,
+		},
+		ListOrgsWithRoleFunc: &OrgsStoreListOrgsWithRoleFunc{
+			defaultHook: i.ListOrgsWithRole,
+		},
+		ListOrphanedFunc: &OrgsStoreListOrphanedFunc{
+			defaultHook: i.ListOrphaned,
+		},
+		ListPinnedReposFunc: &OrgsStoreListPinnedReposFunc{
+			defaultHook: i.ListPinnedRepos,
+		},
+		RecountAllFunc: &OrgsStoreRecountAllFunc{
+			defaultHook: i.RecountAll,
+		},
+		RemoveMemberFunc: &OrgsStoreRemoveMemberFunc{
+			defaultHook: i.RemoveMember,
+		},
+		RevokeInvitationFunc: &OrgsStoreRevokeInvitationFunc{
+			defaultHook: i.RevokeInvitation,
+		},
+		SearchByNameFunc: &OrgsStoreSearchByNameFunc{
+			defaultHook: i.SearchByName,
+		},
+		SearchMembersFunc: &OrgsStoreSearchMembersFunc{
+			defaultHook: i.SearchMembers,
+		},
+		SearchVisibleByNameFunc: &OrgsStoreSearchVisibleByNameFunc{
+			defaultHook: i.SearchVisibleByName,
+		},
+		SetArchivedFunc: &OrgsStoreSetArchivedFunc{
+			defaultHook: i.SetArchived,
+		},
+		SetDefaultLabelsFunc: &OrgsStoreSetDefaultLabelsFunc{
+			defaultHook: i.SetDefaultLabels,
+		},
+		SetDefaultProtectionFunc: &OrgsStoreSetDefaultProtectionFunc{
+			defaultHook: i.SetDefaultProtection,
+		},
+		SetDefaultRepoPermissionFunc: &OrgsStoreSetDefaultRepoPermissionFunc{
+			defaultHook: i.SetDefaultRepoPermission,
+		},
+		SetMemberNotifyLevelFunc: &OrgsStoreSetMemberNotifyLevelFunc{
+			defaultHook: i.SetMemberNotifyLevel,
+		},
+		SetMemberVisibilityFunc: &OrgsStoreSetMemberVisibilityFunc{
+			defaultHook: i.SetMemberVisibility,
+		},
+		SetOwnerFunc: &OrgsStoreSetOwnerFunc{
+			defaultHook: i.SetOwner,
+		},
+		SetPinnedReposFunc: &OrgsStoreSetPinnedReposFunc{
+			defaultHook: i.SetPinnedRepos,
+		},
+		SetRepoCreatePermissionFunc: &OrgsStoreSetRepoCreatePermissionFunc{
+			defaultHook: i.SetRepoCreatePermission,
+		},
+		SetRequireTwoFactorFunc: &OrgsStoreSetRequireTwoFactorFunc{
+			defaultHook: i.SetRequireTwoFactor,
+		},
+		SetVerifiedFunc: &OrgsStoreSetVerifiedFunc{
+			defaultHook: i.SetVerified,
+		},
+		StreamMembersFunc: &OrgsStoreStreamMembersFunc{
+			defaultHook: i.StreamMembers,
+		},
+		TransferOwnershipFunc: &OrgsStoreTransferOwnershipFunc{
+			defaultHook: i.TransferOwnership,
+		},
+	}
+}
+
+// OrgsStoreAcceptInvitationFunc describes the behavior when the
+// AcceptInvitation method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreAcceptInvitationFunc struct {
+	defaultHook func(context.Context, int64, string) (*db.OrgInvitation, error)
+	hooks       []func(context.Context, int64, string) (*db.OrgInvitation, error)
+	history     []OrgsStoreAcceptInvitationFuncCall
+	mutex       sync.Mutex
+}
+
+// AcceptInvitation delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) AcceptInvitation(v0 context.Context, v1 int64, v2 string) (*db.OrgInvitation, error) {
+	r0, r1 := m.AcceptInvitationFunc.nextHook()(v0, v1, v2)
+	m.AcceptInvitationFunc.appendCall(OrgsStoreAcceptInvitationFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the AcceptInvitation
+// method of the parent MockOrgsStore instance is invoked and the hook queue
+// is empty.
+func (f *OrgsStoreAcceptInvitationFunc) SetDefaultHook(hook func(context.Context, int64, string) (*db.OrgInvitation, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AcceptInvitation method of the parent MockOrgsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *OrgsStoreAcceptInvitationFunc) PushHook(hook func(context.Context, int64, string) (*db.OrgInvitation, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreAcceptInvitationFunc) SetDefaultReturn(r0 *db.OrgInvitation, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, string) (*db.OrgInvitation, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreAcceptInvitationFunc) PushReturn(r0 *db.OrgInvitation, r1 error) {
+	f.PushHook(func(context.Context, int64, string) (*db.OrgInvitation, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreAcceptInvitationFunc) nextHook() func(context.Context, int64, string) (*db.OrgInvitation, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreAcceptInvitationFunc) appendCall(r0 OrgsStoreAcceptInvitationFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreAcceptInvitationFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreAcceptInvitationFunc) History() []OrgsStoreAcceptInvitationFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreAcceptInvitationFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreAcceptInvitationFuncCall is an object that describes an
+// invocation of method AcceptInvitation on an instance of MockOrgsStore.
+type OrgsStoreAcceptInvitationFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *db.OrgInvitation
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreAcceptInvitationFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreAcceptInvitationFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreAccessibleRepositoriesByUserFunc describes the behavior when the
+// AccessibleRepositoriesByUser method of the parent MockOrgsStore instance
+// is invoked.
+type OrgsStoreAccessibleRepositoriesByUserFunc struct {
+	defaultHook func(context.Context, int64, int64, int, int, db.AccessibleRepositoriesByUserOptions) ([]*db.Repository, int64, bool, error)
+	hooks       []func(context.Context, int64, int64, int, int, db.AccessibleRepositoriesByUserOptions) ([]*db.Repository, int64, bool, error)
+	history     []OrgsStoreAccessibleRepositoriesByUserFuncCall
+	mutex       sync.Mutex
+}
+
+// AccessibleRepositoriesByUser delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) AccessibleRepositoriesByUser(v0 context.Context, v1 int64, v2 int64, v3 int, v4 int, v5 db.AccessibleRepositoriesByUserOptions) ([]*db.Repository, int64, bool, error) {
+	r0, r1, r2, r3 := m.AccessibleRepositoriesByUserFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.AccessibleRepositoriesByUserFunc.appendCall(OrgsStoreAccessibleRepositoriesByUserFuncCall{v0, v1, v2, v3, v4, v5, r0, r1, r2, r3})
+	return r0, r1, r2, r3
+}
+
+// SetDefaultHook sets function that is called when the
+// AccessibleRepositoriesByUser method of the parent MockOrgsStore instance
+// is invoked and the hook queue is empty.
+func (f *OrgsStoreAccessibleRepositoriesByUserFunc) SetDefaultHook(hook func(context.Context, int64, int64, int, int, db.AccessibleRepositoriesByUserOptions) ([]*db.Repository, int64, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AccessibleRepositoriesByUser method of the parent MockOrgsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *OrgsStoreAccessibleRepositoriesByUserFunc) PushHook(hook func(context.Context, int64, int64, int, int, db.AccessibleRepositoriesByUserOptions) ([]*db.Repository, int64, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreAccessibleRepositoriesByUserFunc) SetDefaultReturn(r0 []*db.Repository, r1 int64, r2 bool, r3 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64, int, int, db.AccessibleRepositoriesByUserOptions) ([]*db.Repository, int64, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreAccessibleRepositoriesByUserFunc) PushReturn(r0 []*db.Repository, r1 int64, r2 bool, r3 error) {
+	f.PushHook(func(context.Context, int64, int64, int, int, db.AccessibleRepositoriesByUserOptions) ([]*db.Repository, int64, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+func (f *OrgsStoreAccessibleRepositoriesByUserFunc) nextHook() func(context.Context, int64, int64, int, int, db.AccessibleRepositoriesByUserOptions) ([]*db.Repository, int64, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreAccessibleRepositoriesByUserFunc) appendCall(r0 OrgsStoreAccessibleRepositoriesByUserFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// OrgsStoreAccessibleRepositoriesByUserFuncCall objects describing the
+// invocations of this function.
+func (f *OrgsStoreAccessibleRepositoriesByUserFunc) History() []OrgsStoreAccessibleRepositoriesByUserFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreAccessibleRepositoriesByUserFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreAccessibleRepositoriesByUserFuncCall is an object that describes
+// an invocation of method AccessibleRepositoriesByUser on an instance of
+// MockOrgsStore.
+type OrgsStoreAccessibleRepositoriesByUserFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 db.AccessibleRepositoriesByUserOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.Repository
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int64
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 bool
+	// Result3 is the value of the 4th result returned from this method
+	// invocation.
+	Result3 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreAccessibleRepositoriesByUserFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreAccessibleRepositoriesByUserFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
+}
+
+// OrgsStoreAccessibleRepositoryIDsByUserFunc describes the behavior when
+// the AccessibleRepositoryIDsByUser method of the parent MockOrgsStore
+// instance is invoked.
+type OrgsStoreAccessibleRepositoryIDsByUserFunc struct {
+	defaultHook func(context.Context, int64, int64) ([]int64, error)
+	hooks       []func(context.Context, int64, int64) ([]int64, error)
+	history     []OrgsStoreAccessibleRepositoryIDsByUserFuncCall
+	mutex       sync.Mutex
+}
+
+// AccessibleRepositoryIDsByUser delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) AccessibleRepositoryIDsByUser(v0 context.Context, v1 int64, v2 int64) ([]int64, error) {
+	r0, r1 := m.AccessibleRepositoryIDsByUserFunc.nextHook()(v0, v1, v2)
+	m.AccessibleRepositoryIDsByUserFunc.appendCall(OrgsStoreAccessibleRepositoryIDsByUserFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// AccessibleRepositoryIDsByUser method of the parent MockOrgsStore instance
+// is invoked and the hook queue is empty.
+func (f *OrgsStoreAccessibleRepositoryIDsByUserFunc) SetDefaultHook(hook func(context.Context, int64, int64) ([]int64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AccessibleRepositoryIDsByUser method of the parent MockOrgsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *OrgsStoreAccessibleRepositoryIDsByUserFunc) PushHook(hook func(context.Context, int64, int64) ([]int64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreAccessibleRepositoryIDsByUserFunc) SetDefaultReturn(r0 []int64, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) ([]int64, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreAccessibleRepositoryIDsByUserFunc) PushReturn(r0 []int64, r1 error) {
+	f.PushHook(func(context.Context, int64, int64) ([]int64, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreAccessibleRepositoryIDsByUserFunc) nextHook() func(context.Context, int64, int64) ([]int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreAccessibleRepositoryIDsByUserFunc) appendCall(r0 OrgsStoreAccessibleRepositoryIDsByUserFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// OrgsStoreAccessibleRepositoryIDsByUserFuncCall objects describing the
+// invocations of this function.
+func (f *OrgsStoreAccessibleRepositoryIDsByUserFunc) History() []OrgsStoreAccessibleRepositoryIDsByUserFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreAccessibleRepositoryIDsByUserFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreAccessibleRepositoryIDsByUserFuncCall is an object that
+// describes an invocation of method AccessibleRepositoryIDsByUser on an
+// instance of MockOrgsStore.
+type OrgsStoreAccessibleRepositoryIDsByUserFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []int64
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreAccessibleRepositoryIDsByUserFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreAccessibleRepositoryIDsByUserFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreAddMemberFunc describes the behavior when the AddMember method
+// of the parent MockOrgsStore instance is invoked.
+type OrgsStoreAddMemberFunc struct {
+	defaultHook func(context.Context, int64, int64) error
+	hooks       []func(context.Context, int64, int64) error
+	history     []OrgsStoreAddMemberFuncCall
+	mutex       sync.Mutex
+}
+
+// AddMember delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockOrgsStore) AddMember(v0 context.Context, v1 int64, v2 int64) error {
+	r0 := m.AddMemberFunc.nextHook()(v0, v1, v2)
+	m.AddMemberFunc.appendCall(OrgsStoreAddMemberFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the AddMember method of
+// the parent MockOrgsStore instance is invoked and the hook queue is empty.
+func (f *OrgsStoreAddMemberFunc) SetDefaultHook(hook func(context.Context, int64, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AddMember method of the parent MockOrgsStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *OrgsStoreAddMemberFunc) PushHook(hook func(context.Context, int64, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreAddMemberFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreAddMemberFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreAddMemberFunc) nextHook() func(context.Context, int64, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreAddMemberFunc) appendCall(r0 OrgsStoreAddMemberFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreAddMemberFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreAddMemberFunc) History() []OrgsStoreAddMemberFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreAddMemberFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreAddMemberFuncCall is an object that describes an invocation of
+// method AddMember on an instance of MockOrgsStore.
+type OrgsStoreAddMemberFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreAddMemberFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreAddMemberFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreAddMemberToTeamFunc describes the behavior when the
+// AddMemberToTeam method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreAddMemberToTeamFunc struct {
+	defaultHook func(context.Context, int64, int64, int64) error
+	hooks       []func(context.Context, int64, int64, int64) error
+	history     []OrgsStoreAddMemberToTeamFuncCall
+	mutex       sync.Mutex
+}
+
+// AddMemberToTeam delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) AddMemberToTeam(v0 context.Context, v1 int64, v2 int64, v3 int64) error {
+	r0 := m.AddMemberToTeamFunc.nextHook()(v0, v1, v2, v3)
+	m.AddMemberToTeamFunc.appendCall(OrgsStoreAddMemberToTeamFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the AddMemberToTeam
+// method of the parent MockOrgsStore instance is invoked and the hook queue
+// is empty.
+func (f *OrgsStoreAddMemberToTeamFunc) SetDefaultHook(hook func(context.Context, int64, int64, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AddMemberToTeam method of the parent MockOrgsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *OrgsStoreAddMemberToTeamFunc) PushHook(hook func(context.Context, int64, int64, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreAddMemberToTeamFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreAddMemberToTeamFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64, int64) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreAddMemberToTeamFunc) nextHook() func(context.Context, int64, int64, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreAddMemberToTeamFunc) appendCall(r0 OrgsStoreAddMemberToTeamFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreAddMemberToTeamFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreAddMemberToTeamFunc) History() []OrgsStoreAddMemberToTeamFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreAddMemberToTeamFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreAddMemberToTeamFuncCall is an object that describes an
+// invocation of method AddMemberToTeam on an instance of MockOrgsStore.
+type OrgsStoreAddMemberToTeamFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreAddMemberToTeamFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreAddMemberToTeamFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreAddMembersFunc describes the behavior when the AddMembers method
+// of the parent MockOrgsStore instance is invoked.
+type OrgsStoreAddMembersFunc struct {
+	defaultHook func(context.Context, int64, []int64) error
+	hooks       []func(context.Context, int64, []int64) error
+	history     []OrgsStoreAddMembersFuncCall
+	mutex       sync.Mutex
+}
+
+// AddMembers delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockOrgsStore) AddMembers(v0 context.Context, v1 int64, v2 []int64) error {
+	r0 := m.AddMembersFunc.nextHook()(v0, v1, v2)
+	m.AddMembersFunc.appendCall(OrgsStoreAddMembersFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the AddMembers method of
+// the parent MockOrgsStore instance is invoked and the hook queue is empty.
+func (f *OrgsStoreAddMembersFunc) SetDefaultHook(hook func(context.Context, int64, []int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AddMembers method of the parent MockOrgsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *OrgsStoreAddMembersFunc) PushHook(hook func(context.Context, int64, []int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreAddMembersFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, []int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreAddMembersFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, []int64) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreAddMembersFunc) nextHook() func(context.Context, int64, []int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreAddMembersFunc) appendCall(r0 OrgsStoreAddMembersFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreAddMembersFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreAddMembersFunc) History() []OrgsStoreAddMembersFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreAddMembersFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreAddMembersFuncCall is an object that describes an invocation of
+// method AddMembers on an instance of MockOrgsStore.
+type OrgsStoreAddMembersFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreAddMembersFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreAddMembersFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreApplyDefaultLabelsToAllFunc describes the behavior when the
+// ApplyDefaultLabelsToAll method of the parent MockOrgsStore instance is
+// invoked.
+type OrgsStoreApplyDefaultLabelsToAllFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []OrgsStoreApplyDefaultLabelsToAllFuncCall
+	mutex       sync.Mutex
+}
+
+// ApplyDefaultLabelsToAll delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) ApplyDefaultLabelsToAll(v0 context.Context, v1 int64) error {
+	r0 := m.ApplyDefaultLabelsToAllFunc.nextHook()(v0, v1)
+	m.ApplyDefaultLabelsToAllFunc.appendCall(OrgsStoreApplyDefaultLabelsToAllFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// ApplyDefaultLabelsToAll method of the parent MockOrgsStore instance is
+// invoked and the hook queue is empty.
+func (f *OrgsStoreApplyDefaultLabelsToAllFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ApplyDefaultLabelsToAll method of the parent MockOrgsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *OrgsStoreApplyDefaultLabelsToAllFunc) PushHook(hook func(context.Context, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreApplyDefaultLabelsToAllFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreApplyDefaultLabelsToAllFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreApplyDefaultLabelsToAllFunc) nextHook() func(context.Context, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreApplyDefaultLabelsToAllFunc) appendCall(r0 OrgsStoreApplyDefaultLabelsToAllFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreApplyDefaultLabelsToAllFuncCall
+// objects describing the invocations of this function.
+func (f *OrgsStoreApplyDefaultLabelsToAllFunc) History() []OrgsStoreApplyDefaultLabelsToAllFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreApplyDefaultLabelsToAllFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreApplyDefaultLabelsToAllFuncCall is an object that describes an
+// invocation of method ApplyDefaultLabelsToAll on an instance of
+// MockOrgsStore.
+type OrgsStoreApplyDefaultLabelsToAllFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreApplyDefaultLabelsToAllFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreApplyDefaultLabelsToAllFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreApplyDefaultProtectionFunc describes the behavior when the
+// ApplyDefaultProtection method of the parent MockOrgsStore instance is
+// invoked.
+type OrgsStoreApplyDefaultProtectionFunc struct {
+	defaultHook func(context.Context, int64, int64) error
+	hooks       []func(context.Context, int64, int64) error
+	history     []OrgsStoreApplyDefaultProtectionFuncCall
+	mutex       sync.Mutex
+}
+
+// ApplyDefaultProtection delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) ApplyDefaultProtection(v0 context.Context, v1 int64, v2 int64) error {
+	r0 := m.ApplyDefaultProtectionFunc.nextHook()(v0, v1, v2)
+	m.ApplyDefaultProtectionFunc.appendCall(OrgsStoreApplyDefaultProtectionFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// ApplyDefaultProtection method of the parent MockOrgsStore instance is
+// invoked and the hook queue is empty.
+func (f *OrgsStoreApplyDefaultProtectionFunc) SetDefaultHook(hook func(context.Context, int64, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ApplyDefaultProtection method of the parent MockOrgsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *OrgsStoreApplyDefaultProtectionFunc) PushHook(hook func(context.Context, int64, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreApplyDefaultProtectionFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreApplyDefaultProtectionFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreApplyDefaultProtectionFunc) nextHook() func(context.Context, int64, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreApplyDefaultProtectionFunc) appendCall(r0 OrgsStoreApplyDefaultProtectionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreApplyDefaultProtectionFuncCall
+// objects describing the invocations of this function.
+func (f *OrgsStoreApplyDefaultProtectionFunc) History() []OrgsStoreApplyDefaultProtectionFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreApplyDefaultProtectionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreApplyDefaultProtectionFuncCall is an object that describes an
+// invocation of method ApplyDefaultProtection on an instance of
+// MockOrgsStore.
+type OrgsStoreApplyDefaultProtectionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreApplyDefaultProtectionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreApplyDefaultProtectionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreApplyDefaultProtectionToAllFunc describes the behavior when the
+// ApplyDefaultProtectionToAll method of the parent MockOrgsStore instance
+// is invoked.
+type OrgsStoreApplyDefaultProtectionToAllFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []OrgsStoreApplyDefaultProtectionToAllFuncCall
+	mutex       sync.Mutex
+}
+
+// ApplyDefaultProtectionToAll delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) ApplyDefaultProtectionToAll(v0 context.Context, v1 int64) error {
+	r0 := m.ApplyDefaultProtectionToAllFunc.nextHook()(v0, v1)
+	m.ApplyDefaultProtectionToAllFunc.appendCall(OrgsStoreApplyDefaultProtectionToAllFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// ApplyDefaultProtectionToAll method of the parent MockOrgsStore instance
+// is invoked and the hook queue is empty.
+func (f *OrgsStoreApplyDefaultProtectionToAllFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ApplyDefaultProtectionToAll method of the parent MockOrgsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *OrgsStoreApplyDefaultProtectionToAllFunc) PushHook(hook func(context.Context, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreApplyDefaultProtectionToAllFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreApplyDefaultProtectionToAllFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreApplyDefaultProtectionToAllFunc) nextHook() func(context.Context, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreApplyDefaultProtectionToAllFunc) appendCall(r0 OrgsStoreApplyDefaultProtectionToAllFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// OrgsStoreApplyDefaultProtectionToAllFuncCall objects describing the
+// invocations of this function.
+func (f *OrgsStoreApplyDefaultProtectionToAllFunc) History() []OrgsStoreApplyDefaultProtectionToAllFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreApplyDefaultProtectionToAllFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreApplyDefaultProtectionToAllFuncCall is an object that describes
+// an invocation of method ApplyDefaultProtectionToAll on an instance of
+// MockOrgsStore.
+type OrgsStoreApplyDefaultProtectionToAllFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreApplyDefaultProtectionToAllFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreApplyDefaultProtectionToAllFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreConsumeInvitationsFunc describes the behavior when the
+// ConsumeInvitations method of the parent MockOrgsStore instance is
+// invoked.
+type OrgsStoreConsumeInvitationsFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []OrgsStoreConsumeInvitationsFuncCall
+	mutex       sync.Mutex
+}
+
+// ConsumeInvitations delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) ConsumeInvitations(v0 context.Context, v1 int64) error {
+	r0 := m.ConsumeInvitationsFunc.nextHook()(v0, v1)
+	m.ConsumeInvitationsFunc.appendCall(OrgsStoreConsumeInvitationsFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ConsumeInvitations
+// method of the parent MockOrgsStore instance is invoked and the hook queue
+// is empty.
+func (f *OrgsStoreConsumeInvitationsFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ConsumeInvitations method of the parent MockOrgsStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *OrgsStoreConsumeInvitationsFunc) PushHook(hook func(context.Context, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreConsumeInvitationsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreConsumeInvitationsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreConsumeInvitationsFunc) nextHook() func(context.Context, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreConsumeInvitationsFunc) appendCall(r0 OrgsStoreConsumeInvitationsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreConsumeInvitationsFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreConsumeInvitationsFunc) History() []OrgsStoreConsumeInvitationsFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreConsumeInvitationsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreConsumeInvitationsFuncCall is an object that describes an
+// invocation of method ConsumeInvitations on an instance of MockOrgsStore.
+type OrgsStoreConsumeInvitationsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreConsumeInvitationsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreConsumeInvitationsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreCountAccessibleRepositoriesByUserFunc describes the behavior
+// when the CountAccessibleRepositoriesByUser method of the parent
+// MockOrgsStore instance is invoked.
+type OrgsStoreCountAccessibleRepositoriesByUserFunc struct {
+	defaultHook func(context.Context, int64, int64) (int64, error)
+	hooks       []func(context.Context, int64, int64) (int64, error)
+	history     []OrgsStoreCountAccessibleRepositoriesByUserFuncCall
+	mutex       sync.Mutex
+}
+
+// CountAccessibleRepositoriesByUser delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) CountAccessibleRepositoriesByUser(v0 context.Context, v1 int64, v2 int64) (int64, error) {
+	r0, r1 := m.CountAccessibleRepositoriesByUserFunc.nextHook()(v0, v1, v2)
+	m.CountAccessibleRepositoriesByUserFunc.appendCall(OrgsStoreCountAccessibleRepositoriesByUserFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CountAccessibleRepositoriesByUser method of the parent MockOrgsStore
+// instance is invoked and the hook queue is empty.
+func (f *OrgsStoreCountAccessibleRepositoriesByUserFunc) SetDefaultHook(hook func(context.Context, int64, int64) (int64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CountAccessibleRepositoriesByUser method of the parent MockOrgsStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *OrgsStoreCountAccessibleRepositoriesByUserFunc) PushHook(hook func(context.Context, int64, int64) (int64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreCountAccessibleRepositoriesByUserFunc) SetDefaultReturn(r0 int64, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) (int64, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreCountAccessibleRepositoriesByUserFunc) PushReturn(r0 int64, r1 error) {
+	f.PushHook(func(context.Context, int64, int64) (int64, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreCountAccessibleRepositoriesByUserFunc) nextHook() func(context.Context, int64, int64) (int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreCountAccessibleRepositoriesByUserFunc) appendCall(r0 OrgsStoreCountAccessibleRepositoriesByUserFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// OrgsStoreCountAccessibleRepositoriesByUserFuncCall objects describing the
+// invocations of this function.
+func (f *OrgsStoreCountAccessibleRepositoriesByUserFunc) History() []OrgsStoreCountAccessibleRepositoriesByUserFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreCountAccessibleRepositoriesByUserFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreCountAccessibleRepositoriesByUserFuncCall is an object that
+// describes an invocation of method CountAccessibleRepositoriesByUser on an
+// instance of MockOrgsStore.
+type OrgsStoreCountAccessibleRepositoriesByUserFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int64
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreCountAccessibleRepositoriesByUserFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreCountAccessibleRepositoriesByUserFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreCountByUserFunc describes the behavior when the CountByUser
+// method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreCountByUserFunc struct {
+	defaultHook func(context.Context, int64) (int64, error)
+	hooks       []func(context.Context, int64) (int64, error)
+	history     []OrgsStoreCountByUserFuncCall
+	mutex       sync.Mutex
+}
+
+// CountByUser delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockOrgsStore) CountByUser(v0 context.Context, v1 int64) (int64, error) {
+	r0, r1 := m.CountByUserFunc.nextHook()(v0, v1)
+	m.CountByUserFunc.appendCall(OrgsStoreCountByUserFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CountByUser method
+// of the parent MockOrgsStore instance is invoked and the hook queue is
+// empty.
+func (f *OrgsStoreCountByUserFunc) SetDefaultHook(hook func(context.Context, int64) (int64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CountByUser method of the parent MockOrgsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *OrgsStoreCountByUserFunc) PushHook(hook func(context.Context, int64) (int64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreCountByUserFunc) SetDefaultReturn(r0 int64, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (int64, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreCountByUserFunc) PushReturn(r0 int64, r1 error) {
+	f.PushHook(func(context.Context, int64) (int64, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreCountByUserFunc) nextHook() func(context.Context, int64) (int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreCountByUserFunc) appendCall(r0 OrgsStoreCountByUserFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreCountByUserFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreCountByUserFunc) History() []OrgsStoreCountByUserFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreCountByUserFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreCountByUserFuncCall is an object that describes an invocation of
+// method CountByUser on an instance of MockOrgsStore.
+type OrgsStoreCountByUserFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int64
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreCountByUserFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreCountByUserFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreCountMembersFunc describes the behavior when the CountMembers
+// method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreCountMembersFunc struct {
+	defaultHook func(context.Context, int64) (int64, error)
+	hooks       []func(context.Context, int64) (int64, error)
+	history     []OrgsStoreCountMembersFuncCall
+	mutex       sync.Mutex
+}
+
+// CountMembers delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockOrgsStore) CountMembers(v0 context.Context, v1 int64) (int64, error) {
+	r0, r1 := m.CountMembersFunc.nextHook()(v0, v1)
+	m.CountMembersFunc.appendCall(OrgsStoreCountMembersFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CountMembers method
+// of the parent MockOrgsStore instance is invoked and the hook queue is
+// empty.
+func (f *OrgsStoreCountMembersFunc) SetDefaultHook(hook func(context.Context, int64) (int64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CountMembers method of the parent MockOrgsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *OrgsStoreCountMembersFunc) PushHook(hook func(context.Context, int64) (int64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreCountMembersFunc) SetDefaultReturn(r0 int64, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (int64, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreCountMembersFunc) PushReturn(r0 int64, r1 error) {
+	f.PushHook(func(context.Context, int64) (int64, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreCountMembersFunc) nextHook() func(context.Context, int64) (int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreCountMembersFunc) appendCall(r0 OrgsStoreCountMembersFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreCountMembersFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreCountMembersFunc) History() []OrgsStoreCountMembersFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreCountMembersFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreCountMembersFuncCall is an object that describes an invocation
+// of method CountMembers on an instance of MockOrgsStore.
+type OrgsStoreCountMembersFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int64
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreCountMembersFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreCountMembersFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreCountOwnedByUserFunc describes the behavior when the
+// CountOwnedByUser method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreCountOwnedByUserFunc struct {
+	defaultHook func(context.Context, int64) (int64, error)
+	hooks       []func(context.Context, int64) (int64, error)
+	history     []OrgsStoreCountOwnedByUserFuncCall
+	mutex       sync.Mutex
+}
+
+// CountOwnedByUser delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) CountOwnedByUser(v0 context.Context, v1 int64) (int64, error) {
+	r0, r1 := m.CountOwnedByUserFunc.nextHook()(v0, v1)
+	m.CountOwnedByUserFunc.appendCall(OrgsStoreCountOwnedByUserFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CountOwnedByUser
+// method of the parent MockOrgsStore instance is invoked and the hook queue
+// is empty.
+func (f *OrgsStoreCountOwnedByUserFunc) SetDefaultHook(hook func(context.Context, int64) (int64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CountOwnedByUser method of the parent MockOrgsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *OrgsStoreCountOwnedByUserFunc) PushHook(hook func(context.Context, int64) (int64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreCountOwnedByUserFunc) SetDefaultReturn(r0 int64, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (int64, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreCountOwnedByUserFunc) PushReturn(r0 int64, r1 error) {
+	f.PushHook(func(context.Context, int64) (int64, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreCountOwnedByUserFunc) nextHook() func(context.Context, int64) (int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreCountOwnedByUserFunc) appendCall(r0 OrgsStoreCountOwnedByUserFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreCountOwnedByUserFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreCountOwnedByUserFunc) History() []OrgsStoreCountOwnedByUserFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreCountOwnedByUserFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreCountOwnedByUserFuncCall is an object that describes an
+// invocation of method CountOwnedByUser on an instance of MockOrgsStore.
+type OrgsStoreCountOwnedByUserFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int64
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreCountOwnedByUserFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreCountOwnedByUserFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreCreateFunc describes the behavior when the Create method of the
+// parent MockOrgsStore instance is invoked.
+type OrgsStoreCreateFunc struct {
+	defaultHook func(context.Context, string, int64, db.CreateOrgOptions) (*db.User, error)
+	hooks       []func(context.Context, string, int64, db.CreateOrgOptions) (*db.User, error)
+	history     []OrgsStoreCreateFuncCall
+	mutex       sync.Mutex
+}
+
+// Create delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockOrgsStore) Create(v0 context.Context, v1 string, v2 int64, v3 db.CreateOrgOptions) (*db.User, error) {
+	r0, r1 := m.CreateFunc.nextHook()(v0, v1, v2, v3)
+	m.CreateFunc.appendCall(OrgsStoreCreateFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Create method of the
+// parent MockOrgsStore instance is invoked and the hook queue is empty.
+func (f *OrgsStoreCreateFunc) SetDefaultHook(hook func(context.Context, string, int64, db.CreateOrgOptions) (*db.User, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Create method of the parent MockOrgsStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *OrgsStoreCreateFunc) PushHook(hook func(context.Context, string, int64, db.CreateOrgOptions) (*db.User, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreCreateFunc) SetDefaultReturn(r0 *db.User, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int64, db.CreateOrgOptions) (*db.User, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreCreateFunc) PushReturn(r0 *db.User, r1 error) {
+	f.PushHook(func(context.Context, string, int64, db.CreateOrgOptions) (*db.User, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreCreateFunc) nextHook() func(context.Context, string, int64, db.CreateOrgOptions) (*db.User, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreCreateFunc) appendCall(r0 OrgsStoreCreateFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreCreateFuncCall objects describing
+// the invocations of this function.
+func (f *OrgsStoreCreateFunc) History() []OrgsStoreCreateFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreCreateFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreCreateFuncCall is an object that describes an invocation of
+// method Create on an instance of MockOrgsStore.
+type OrgsStoreCreateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 db.CreateOrgOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *db.User
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreCreateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreCreateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreDeduplicateMembersFunc describes the behavior when the
+// DeduplicateMembers method of the parent MockOrgsStore instance is
+// invoked.
+type OrgsStoreDeduplicateMembersFunc struct {
+	defaultHook func(context.Context, int64) (int64, error)
+	hooks       []func(context.Context, int64) (int64, error)
+	history     []OrgsStoreDeduplicateMembersFuncCall
+	mutex       sync.Mutex
+}
+
+// DeduplicateMembers delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) DeduplicateMembers(v0 context.Context, v1 int64) (int64, error) {
+	r0, r1 := m.DeduplicateMembersFunc.nextHook()(v0, v1)
+	m.DeduplicateMembersFunc.appendCall(OrgsStoreDeduplicateMembersFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DeduplicateMembers
+// method of the parent MockOrgsStore instance is invoked and the hook queue
+// is empty.
+func (f *OrgsStoreDeduplicateMembersFunc) SetDefaultHook(hook func(context.Context, int64) (int64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeduplicateMembers method of the parent MockOrgsStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *OrgsStoreDeduplicateMembersFunc) PushHook(hook func(context.Context, int64) (int64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreDeduplicateMembersFunc) SetDefaultReturn(r0 int64, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (int64, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreDeduplicateMembersFunc) PushReturn(r0 int64, r1 error) {
+	f.PushHook(func(context.Context, int64) (int64, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreDeduplicateMembersFunc) nextHook() func(context.Context, int64) (int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreDeduplicateMembersFunc) appendCall(r0 OrgsStoreDeduplicateMembersFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreDeduplicateMembersFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreDeduplicateMembersFunc) History() []OrgsStoreDeduplicateMembersFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreDeduplicateMembersFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreDeduplicateMembersFuncCall is an object that describes an
+// invocation of method DeduplicateMembers on an instance of MockOrgsStore.
+type OrgsStoreDeduplicateMembersFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int64
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreDeduplicateMembersFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreDeduplicateMembersFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreDeleteByIDFunc describes the behavior when the DeleteByID method
+// of the parent MockOrgsStore instance is invoked.
+type OrgsStoreDeleteByIDFunc struct {
+	defaultHook func(context.Context, int64, db.DeleteOrgOptions) error
+	hooks       []func(context.Context, int64, db.DeleteOrgOptions) error
+	history     []OrgsStoreDeleteByIDFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteByID delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockOrgsStore) DeleteByID(v0 context.Context, v1 int64, v2 db.DeleteOrgOptions) error {
+	r0 := m.DeleteByIDFunc.nextHook()(v0, v1, v2)
+	m.DeleteByIDFunc.appendCall(OrgsStoreDeleteByIDFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteByID method of
+// the parent MockOrgsStore instance is invoked and the hook queue is empty.
+func (f *OrgsStoreDeleteByIDFunc) SetDefaultHook(hook func(context.Context, int64, db.DeleteOrgOptions) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteByID method of the parent MockOrgsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *OrgsStoreDeleteByIDFunc) PushHook(hook func(context.Context, int64, db.DeleteOrgOptions) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreDeleteByIDFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, db.DeleteOrgOptions) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreDeleteByIDFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, db.DeleteOrgOptions) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreDeleteByIDFunc) nextHook() func(context.Context, int64, db.DeleteOrgOptions) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreDeleteByIDFunc) appendCall(r0 OrgsStoreDeleteByIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreDeleteByIDFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreDeleteByIDFunc) History() []OrgsStoreDeleteByIDFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreDeleteByIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreDeleteByIDFuncCall is an object that describes an invocation of
+// method DeleteByID on an instance of MockOrgsStore.
+type OrgsStoreDeleteByIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 db.DeleteOrgOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreDeleteByIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreDeleteByIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreEnsureOwnersTeamFunc describes the behavior when the
+// EnsureOwnersTeam method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreEnsureOwnersTeamFunc struct {
+	defaultHook func(context.Context, int64) (*db.Team, error)
+	hooks       []func(context.Context, int64) (*db.Team, error)
+	history     []OrgsStoreEnsureOwnersTeamFuncCall
+	mutex       sync.Mutex
+}
+
+// EnsureOwnersTeam delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) EnsureOwnersTeam(v0 context.Context, v1 int64) (*db.Team, error) {
+	r0, r1 := m.EnsureOwnersTeamFunc.nextHook()(v0, v1)
+	m.EnsureOwnersTeamFunc.appendCall(OrgsStoreEnsureOwnersTeamFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the EnsureOwnersTeam
+// method of the parent MockOrgsStore instance is invoked and the hook queue
+// is empty.
+func (f *OrgsStoreEnsureOwnersTeamFunc) SetDefaultHook(hook func(context.Context, int64) (*db.Team, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// EnsureOwnersTeam method of the parent MockOrgsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *OrgsStoreEnsureOwnersTeamFunc) PushHook(hook func(context.Context, int64) (*db.Team, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreEnsureOwnersTeamFunc) SetDefaultReturn(r0 *db.Team, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*db.Team, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreEnsureOwnersTeamFunc) PushReturn(r0 *db.Team, r1 error) {
+	f.PushHook(func(context.Context, int64) (*db.Team, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreEnsureOwnersTeamFunc) nextHook() func(context.Context, int64) (*db.Team, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreEnsureOwnersTeamFunc) appendCall(r0 OrgsStoreEnsureOwnersTeamFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreEnsureOwnersTeamFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreEnsureOwnersTeamFunc) History() []OrgsStoreEnsureOwnersTeamFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreEnsureOwnersTeamFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreEnsureOwnersTeamFuncCall is an object that describes an
+// invocation of method EnsureOwnersTeam on an instance of MockOrgsStore.
+type OrgsStoreEnsureOwnersTeamFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *db.Team
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreEnsureOwnersTeamFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreEnsureOwnersTeamFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreFilterMembersFunc describes the behavior when the FilterMembers
+// method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreFilterMembersFunc struct {
+	defaultHook func(context.Context, int64, []int64) (map[int64]bool, error)
+	hooks       []func(context.Context, int64, []int64) (map[int64]bool, error)
+	history     []OrgsStoreFilterMembersFuncCall
+	mutex       sync.Mutex
+}
+
+// FilterMembers delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockOrgsStore) FilterMembers(v0 context.Context, v1 int64, v2 []int64) (map[int64]bool, error) {
+	r0, r1 := m.FilterMembersFunc.nextHook()(v0, v1, v2)
+	m.FilterMembersFunc.appendCall(OrgsStoreFilterMembersFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the FilterMembers method
+// of the parent MockOrgsStore instance is invoked and the hook queue is
+// empty.
+func (f *OrgsStoreFilterMembersFunc) SetDefaultHook(hook func(context.Context, int64, []int64) (map[int64]bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// FilterMembers method of the parent MockOrgsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *OrgsStoreFilterMembersFunc) PushHook(hook func(context.Context, int64, []int64) (map[int64]bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreFilterMembersFunc) SetDefaultReturn(r0 map[int64]bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, []int64) (map[int64]bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreFilterMembersFunc) PushReturn(r0 map[int64]bool, r1 error) {
+	f.PushHook(func(context.Context, int64, []int64) (map[int64]bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreFilterMembersFunc) nextHook() func(context.Context, int64, []int64) (map[int64]bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreFilterMembersFunc) appendCall(r0 OrgsStoreFilterMembersFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreFilterMembersFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreFilterMembersFunc) History() []OrgsStoreFilterMembersFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreFilterMembersFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreFilterMembersFuncCall is an object that describes an invocation
+// of method FilterMembers on an instance of MockOrgsStore.
+type OrgsStoreFilterMembersFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[int64]bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreFilterMembersFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreFilterMembersFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreGetByNameFunc describes the behavior when the GetByName method
+// of the parent MockOrgsStore instance is invoked.
+type OrgsStoreGetByNameFunc struct {
+	defaultHook func(context.Context, string) (*db.User, error)
+	hooks       []func(context.Context, string) (*db.User, error)
+	history     []OrgsStoreGetByNameFuncCall
+	mutex       sync.Mutex
+}
+
+// GetByName delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockOrgsStore) GetByName(v0 context.Context, v1 string) (*db.User, error) {
+	r0, r1 := m.GetByNameFunc.nextHook()(v0, v1)
+	m.GetByNameFunc.appendCall(OrgsStoreGetByNameFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetByName method of
+// the parent MockOrgsStore instance is invoked and the hook queue is empty.
+func (f *OrgsStoreGetByNameFunc) SetDefaultHook(hook func(context.Context, string) (*db.User, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetByName method of the parent MockOrgsStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *OrgsStoreGetByNameFunc) PushHook(hook func(context.Context, string) (*db.User, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreGetByNameFunc) SetDefaultReturn(r0 *db.User, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) (*db.User, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreGetByNameFunc) PushReturn(r0 *db.User, r1 error) {
+	f.PushHook(func(context.Context, string) (*db.User, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreGetByNameFunc) nextHook() func(context.Context, string) (*db.User, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreGetByNameFunc) appendCall(r0 OrgsStoreGetByNameFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreGetByNameFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreGetByNameFunc) History() []OrgsStoreGetByNameFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreGetByNameFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreGetByNameFuncCall is an object that describes an invocation of
+// method GetByName on an instance of MockOrgsStore.
+type OrgsStoreGetByNameFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *db.User
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreGetByNameFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreGetByNameFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreGetDefaultLabelsFunc describes the behavior when the
+// GetDefaultLabels method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreGetDefaultLabelsFunc struct {
+	defaultHook func(context.Context, int64) ([]*db.OrgLabel, error)
+	hooks       []func(context.Context, int64) ([]*db.OrgLabel, error)
+	history     []OrgsStoreGetDefaultLabelsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDefaultLabels delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) GetDefaultLabels(v0 context.Context, v1 int64) ([]*db.OrgLabel, error) {
+	r0, r1 := m.GetDefaultLabelsFunc.nextHook()(v0, v1)
+	m.GetDefaultLabelsFunc.appendCall(OrgsStoreGetDefaultLabelsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetDefaultLabels
+// method of the parent MockOrgsStore instance is invoked and the hook queue
+// is empty.
+func (f *OrgsStoreGetDefaultLabelsFunc) SetDefaultHook(hook func(context.Context, int64) ([]*db.OrgLabel, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDefaultLabels method of the parent MockOrgsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *OrgsStoreGetDefaultLabelsFunc) PushHook(hook func(context.Context, int64) ([]*db.OrgLabel, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreGetDefaultLabelsFunc) SetDefaultReturn(r0 []*db.OrgLabel, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) ([]*db.OrgLabel, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreGetDefaultLabelsFunc) PushReturn(r0 []*db.OrgLabel, r1 error) {
+	f.PushHook(func(context.Context, int64) ([]*db.OrgLabel, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreGetDefaultLabelsFunc) nextHook() func(context.Context, int64) ([]*db.OrgLabel, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreGetDefaultLabelsFunc) appendCall(r0 OrgsStoreGetDefaultLabelsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreGetDefaultLabelsFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreGetDefaultLabelsFunc) History() []OrgsStoreGetDefaultLabelsFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreGetDefaultLabelsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreGetDefaultLabelsFuncCall is an object that describes an
+// invocation of method GetDefaultLabels on an instance of MockOrgsStore.
+type OrgsStoreGetDefaultLabelsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.OrgLabel
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreGetDefaultLabelsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreGetDefaultLabelsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreGetDefaultProtectionFunc describes the behavior when the
+// GetDefaultProtection method of the parent MockOrgsStore instance is
+// invoked.
+type OrgsStoreGetDefaultProtectionFunc struct {
+	defaultHook func(context.Context, int64) ([]*db.OrgProtectBranch, error)
+	hooks       []func(context.Context, int64) ([]*db.OrgProtectBranch, error)
+	history     []OrgsStoreGetDefaultProtectionFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDefaultProtection delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) GetDefaultProtection(v0 context.Context, v1 int64) ([]*db.OrgProtectBranch, error) {
+	r0, r1 := m.GetDefaultProtectionFunc.nextHook()(v0, v1)
+	m.GetDefaultProtectionFunc.appendCall(OrgsStoreGetDefaultProtectionFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetDefaultProtection
+// method of the parent MockOrgsStore instance is invoked and the hook queue
+// is empty.
+func (f *OrgsStoreGetDefaultProtectionFunc) SetDefaultHook(hook func(context.Context, int64) ([]*db.OrgProtectBranch, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDefaultProtection method of the parent MockOrgsStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *OrgsStoreGetDefaultProtectionFunc) PushHook(hook func(context.Context, int64) ([]*db.OrgProtectBranch, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreGetDefaultProtectionFunc) SetDefaultReturn(r0 []*db.OrgProtectBranch, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) ([]*db.OrgProtectBranch, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreGetDefaultProtectionFunc) PushReturn(r0 []*db.OrgProtectBranch, r1 error) {
+	f.PushHook(func(context.Context, int64) ([]*db.OrgProtectBranch, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreGetDefaultProtectionFunc) nextHook() func(context.Context, int64) ([]*db.OrgProtectBranch, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreGetDefaultProtectionFunc) appendCall(r0 OrgsStoreGetDefaultProtectionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreGetDefaultProtectionFuncCall
+// objects describing the invocations of this function.
+func (f *OrgsStoreGetDefaultProtectionFunc) History() []OrgsStoreGetDefaultProtectionFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreGetDefaultProtectionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreGetDefaultProtectionFuncCall is an object that describes an
+// invocation of method GetDefaultProtection on an instance of
+// MockOrgsStore.
+type OrgsStoreGetDefaultProtectionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.OrgProtectBranch
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreGetDefaultProtectionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreGetDefaultProtectionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreGetMembershipFunc describes the behavior when the GetMembership
+// method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreGetMembershipFunc struct {
+	defaultHook func(context.Context, int64, int64) (*db.OrgUser, error)
+	hooks       []func(context.Context, int64, int64) (*db.OrgUser, error)
+	history     []OrgsStoreGetMembershipFuncCall
+	mutex       sync.Mutex
+}
+
+// GetMembership delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockOrgsStore) GetMembership(v0 context.Context, v1 int64, v2 int64) (*db.OrgUser, error) {
+	r0, r1 := m.GetMembershipFunc.nextHook()(v0, v1, v2)
+	m.GetMembershipFunc.appendCall(OrgsStoreGetMembershipFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetMembership method
+// of the parent MockOrgsStore instance is invoked and the hook queue is
+// empty.
+func (f *OrgsStoreGetMembershipFunc) SetDefaultHook(hook func(context.Context, int64, int64) (*db.OrgUser, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetMembership method of the parent MockOrgsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *OrgsStoreGetMembershipFunc) PushHook(hook func(context.Context, int64, int64) (*db.OrgUser, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreGetMembershipFunc) SetDefaultReturn(r0 *db.OrgUser, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) (*db.OrgUser, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreGetMembershipFunc) PushReturn(r0 *db.OrgUser, r1 error) {
+	f.PushHook(func(context.Context, int64, int64) (*db.OrgUser, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreGetMembershipFunc) nextHook() func(context.Context, int64, int64) (*db.OrgUser, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreGetMembershipFunc) appendCall(r0 OrgsStoreGetMembershipFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreGetMembershipFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreGetMembershipFunc) History() []OrgsStoreGetMembershipFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreGetMembershipFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreGetMembershipFuncCall is an object that describes an invocation
+// of method GetMembership on an instance of MockOrgsStore.
+type OrgsStoreGetMembershipFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *db.OrgUser
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreGetMembershipFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreGetMembershipFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreGetTeamsByUserFunc describes the behavior when the
+// GetTeamsByUser method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreGetTeamsByUserFunc struct {
+	defaultHook func(context.Context, int64, int64) ([]*db.Team, error)
+	hooks       []func(context.Context, int64, int64) ([]*db.Team, error)
+	history     []OrgsStoreGetTeamsByUserFuncCall
+	mutex       sync.Mutex
+}
+
+// GetTeamsByUser delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) GetTeamsByUser(v0 context.Context, v1 int64, v2 int64) ([]*db.Team, error) {
+	r0, r1 := m.GetTeamsByUserFunc.nextHook()(v0, v1, v2)
+	m.GetTeamsByUserFunc.appendCall(OrgsStoreGetTeamsByUserFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetTeamsByUser
+// method of the parent MockOrgsStore instance is invoked and the hook queue
+// is empty.
+func (f *OrgsStoreGetTeamsByUserFunc) SetDefaultHook(hook func(context.Context, int64, int64) ([]*db.Team, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetTeamsByUser method of the parent MockOrgsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *OrgsStoreGetTeamsByUserFunc) PushHook(hook func(context.Context, int64, int64) ([]*db.Team, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreGetTeamsByUserFunc) SetDefaultReturn(r0 []*db.Team, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) ([]*db.Team, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreGetTeamsByUserFunc) PushReturn(r0 []*db.Team, r1 error) {
+	f.PushHook(func(context.Context, int64, int64) ([]*db.Team, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreGetTeamsByUserFunc) nextHook() func(context.Context, int64, int64) ([]*db.Team, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreGetTeamsByUserFunc) appendCall(r0 OrgsStoreGetTeamsByUserFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreGetTeamsByUserFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreGetTeamsByUserFunc) History() []OrgsStoreGetTeamsByUserFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreGetTeamsByUserFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreGetTeamsByUserFuncCall is an object that describes an invocation
+// of method GetTeamsByUser on an instance of MockOrgsStore.
+type OrgsStoreGetTeamsByUserFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.Team
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreGetTeamsByUserFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreGetTeamsByUserFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreHasMemberFunc describes the behavior when the HasMember method
+// of the parent MockOrgsStore instance is invoked.
+type OrgsStoreHasMemberFunc struct {
+	defaultHook func(context.Context, int64, int64) bool
+	hooks       []func(context.Context, int64, int64) bool
+	history     []OrgsStoreHasMemberFuncCall
+	mutex       sync.Mutex
+}
+
+// HasMember delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockOrgsStore) HasMember(v0 context.Context, v1 int64, v2 int64) bool {
+	r0 := m.HasMemberFunc.nextHook()(v0, v1, v2)
+	m.HasMemberFunc.appendCall(OrgsStoreHasMemberFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the HasMember method of
+// the parent MockOrgsStore instance is invoked and the hook queue is empty.
+func (f *OrgsStoreHasMemberFunc) SetDefaultHook(hook func(context.Context, int64, int64) bool) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// HasMember method of the parent MockOrgsStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *OrgsStoreHasMemberFunc) PushHook(hook func(context.Context, int64, int64) bool) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreHasMemberFunc) SetDefaultReturn(r0 bool) {
+	f.SetDefaultHook(func(context.Context, int64, int64) bool {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreHasMemberFunc) PushReturn(r0 bool) {
+	f.PushHook(func(context.Context, int64, int64) bool {
+		return r0
+	})
+}
+
+func (f *OrgsStoreHasMemberFunc) nextHook() func(context.Context, int64, int64) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreHasMemberFunc) appendCall(r0 OrgsStoreHasMemberFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreHasMemberFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreHasMemberFunc) History() []OrgsStoreHasMemberFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreHasMemberFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreHasMemberFuncCall is an object that describes an invocation of
+// method HasMember on an instance of MockOrgsStore.
+type OrgsStoreHasMemberFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreHasMemberFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreHasMemberFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreInviteByEmailFunc describes the behavior when the InviteByEmail
+// method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreInviteByEmailFunc struct {
+	defaultHook func(context.Context, int64, string, int64) (*db.OrgInvitation, error)
+	hooks       []func(context.Context, int64, string, int64) (*db.OrgInvitation, error)
+	history     []OrgsStoreInviteByEmailFuncCall
+	mutex       sync.Mutex
+}
+
+// InviteByEmail delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockOrgsStore) InviteByEmail(v0 context.Context, v1 int64, v2 string, v3 int64) (*db.OrgInvitation, error) {
+	r0, r1 := m.InviteByEmailFunc.nextHook()(v0, v1, v2, v3)
+	m.InviteByEmailFunc.appendCall(OrgsStoreInviteByEmailFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the InviteByEmail method
+// of the parent MockOrgsStore instance is invoked and the hook queue is
+// empty.
+func (f *OrgsStoreInviteByEmailFunc) SetDefaultHook(hook func(context.Context, int64, string, int64) (*db.OrgInvitation, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InviteByEmail method of the parent MockOrgsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *OrgsStoreInviteByEmailFunc) PushHook(hook func(context.Context, int64, string, int64) (*db.OrgInvitation, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreInviteByEmailFunc) SetDefaultReturn(r0 *db.OrgInvitation, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, string, int64) (*db.OrgInvitation, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreInviteByEmailFunc) PushReturn(r0 *db.OrgInvitation, r1 error) {
+	f.PushHook(func(context.Context, int64, string, int64) (*db.OrgInvitation, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreInviteByEmailFunc) nextHook() func(context.Context, int64, string, int64) (*db.OrgInvitation, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreInviteByEmailFunc) appendCall(r0 OrgsStoreInviteByEmailFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreInviteByEmailFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreInviteByEmailFunc) History() []OrgsStoreInviteByEmailFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreInviteByEmailFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreInviteByEmailFuncCall is an object that describes an invocation
+// of method InviteByEmail on an instance of MockOrgsStore.
+type OrgsStoreInviteByEmailFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *db.OrgInvitation
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreInviteByEmailFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreInviteByEmailFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreIsOwnedByFunc describes the behavior when the IsOwnedBy method
+// of the parent MockOrgsStore instance is invoked.
+type OrgsStoreIsOwnedByFunc struct {
+	defaultHook func(context.Context, int64, int64) bool
+	hooks       []func(context.Context, int64, int64) bool
+	history     []OrgsStoreIsOwnedByFuncCall
+	mutex       sync.Mutex
+}
+
+// IsOwnedBy delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockOrgsStore) IsOwnedBy(v0 context.Context, v1 int64, v2 int64) bool {
+	r0 := m.IsOwnedByFunc.nextHook()(v0, v1, v2)
+	m.IsOwnedByFunc.appendCall(OrgsStoreIsOwnedByFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the IsOwnedBy method of
+// the parent MockOrgsStore instance is invoked and the hook queue is empty.
+func (f *OrgsStoreIsOwnedByFunc) SetDefaultHook(hook func(context.Context, int64, int64) bool) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// IsOwnedBy method of the parent MockOrgsStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *OrgsStoreIsOwnedByFunc) PushHook(hook func(context.Context, int64, int64) bool) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreIsOwnedByFunc) SetDefaultReturn(r0 bool) {
+	f.SetDefaultHook(func(context.Context, int64, int64) bool {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreIsOwnedByFunc) PushReturn(r0 bool) {
+	f.PushHook(func(context.Context, int64, int64) bool {
+		return r0
+	})
+}
+
+func (f *OrgsStoreIsOwnedByFunc) nextHook() func(context.Context, int64, int64) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreIsOwnedByFunc) appendCall(r0 OrgsStoreIsOwnedByFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreIsOwnedByFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreIsOwnedByFunc) History() []OrgsStoreIsOwnedByFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreIsOwnedByFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreIsOwnedByFuncCall is an object that describes an invocation of
+// method IsOwnedBy on an instance of MockOrgsStore.
+type OrgsStoreIsOwnedByFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreIsOwnedByFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreIsOwnedByFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreLeaveOrgFunc describes the behavior when the LeaveOrg method of
+// the parent MockOrgsStore instance is invoked.
+type OrgsStoreLeaveOrgFunc struct {
+	defaultHook func(context.Context, int64, int64) error
+	hooks       []func(context.Context, int64, int64) error
+	history     []OrgsStoreLeaveOrgFuncCall
+	mutex       sync.Mutex
+}
+
+// LeaveOrg delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockOrgsStore) LeaveOrg(v0 context.Context, v1 int64, v2 int64) error {
+	r0 := m.LeaveOrgFunc.nextHook()(v0, v1, v2)
+	m.LeaveOrgFunc.appendCall(OrgsStoreLeaveOrgFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the LeaveOrg method of
+// the parent MockOrgsStore instance is invoked and the hook queue is empty.
+func (f *OrgsStoreLeaveOrgFunc) SetDefaultHook(hook func(context.Context, int64, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// LeaveOrg method of the parent MockOrgsStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *OrgsStoreLeaveOrgFunc) PushHook(hook func(context.Context, int64, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreLeaveOrgFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreLeaveOrgFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreLeaveOrgFunc) nextHook() func(context.Context, int64, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreLeaveOrgFunc) appendCall(r0 OrgsStoreLeaveOrgFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreLeaveOrgFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreLeaveOrgFunc) History() []OrgsStoreLeaveOrgFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreLeaveOrgFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreLeaveOrgFuncCall is an object that describes an invocation of
+// method LeaveOrg on an instance of MockOrgsStore.
+type OrgsStoreLeaveOrgFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreLeaveOrgFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreLeaveOrgFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreListFunc describes the behavior when the List method of the
+// parent MockOrgsStore instance is invoked.
+type OrgsStoreListFunc struct {
+	defaultHook func(context.Context, db.ListOrgsOptions) ([]*db.User, error)
+	hooks       []func(context.Context, db.ListOrgsOptions) ([]*db.User, error)
+	history     []OrgsStoreListFuncCall
+	mutex       sync.Mutex
+}
+
+// List delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockOrgsStore) List(v0 context.Context, v1 db.ListOrgsOptions) ([]*db.User, error) {
+	r0, r1 := m.ListFunc.nextHook()(v0, v1)
+	m.ListFunc.appendCall(OrgsStoreListFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the List method of the
+// parent MockOrgsStore instance is invoked and the hook queue is empty.
+func (f *OrgsStoreListFunc) SetDefaultHook(hook func(context.Context, db.ListOrgsOptions) ([]*db.User, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// List method of the parent MockOrgsStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *OrgsStoreListFunc) PushHook(hook func(context.Context, db.ListOrgsOptions) ([]*db.User, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreListFunc) SetDefaultReturn(r0 []*db.User, r1 error) {
+	f.SetDefaultHook(func(context.Context, db.ListOrgsOptions) ([]*db.User, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreListFunc) PushReturn(r0 []*db.User, r1 error) {
+	f.PushHook(func(context.Context, db.ListOrgsOptions) ([]*db.User, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreListFunc) nextHook() func(context.Context, db.ListOrgsOptions) ([]*db.User, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreListFunc) appendCall(r0 OrgsStoreListFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreListFuncCall objects describing
+// the invocations of this function.
+func (f *OrgsStoreListFunc) History() []OrgsStoreListFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreListFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreListFuncCall is an object that describes an invocation of method
+// List on an instance of MockOrgsStore.
+type OrgsStoreListFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 db.ListOrgsOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.User
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreListFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreListFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreListActiveMembersFunc describes the behavior when the
+// ListActiveMembers method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreListActiveMembersFunc struct {
+	defaultHook func(context.Context, int64, int64) ([]*db.User, error)
+	hooks       []func(context.Context, int64, int64) ([]*db.User, error)
+	history     []OrgsStoreListActiveMembersFuncCall
+	mutex       sync.Mutex
+}
+
+// ListActiveMembers delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) ListActiveMembers(v0 context.Context, v1 int64, v2 int64) ([]*db.User, error) {
+	r0, r1 := m.ListActiveMembersFunc.nextHook()(v0, v1, v2)
+	m.ListActiveMembersFunc.appendCall(OrgsStoreListActiveMembersFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListActiveMembers
+// method of the parent MockOrgsStore instance is invoked and the hook queue
+// is empty.
+func (f *OrgsStoreListActiveMembersFunc) SetDefaultHook(hook func(context.Context, int64, int64) ([]*db.User, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListActiveMembers method of the parent MockOrgsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *OrgsStoreListActiveMembersFunc) PushHook(hook func(context.Context, int64, int64) ([]*db.User, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreListActiveMembersFunc) SetDefaultReturn(r0 []*db.User, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) ([]*db.User, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreListActiveMembersFunc) PushReturn(r0 []*db.User, r1 error) {
+	f.PushHook(func(context.Context, int64, int64) ([]*db.User, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreListActiveMembersFunc) nextHook() func(context.Context, int64, int64) ([]*db.User, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreListActiveMembersFunc) appendCall(r0 OrgsStoreListActiveMembersFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreListActiveMembersFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreListActiveMembersFunc) History() []OrgsStoreListActiveMembersFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreListActiveMembersFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreListActiveMembersFuncCall is an object that describes an
+// invocation of method ListActiveMembers on an instance of MockOrgsStore.
+type OrgsStoreListActiveMembersFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.User
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreListActiveMembersFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreListActiveMembersFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreListAllReposFunc describes the behavior when the ListAllRepos
+// method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreListAllReposFunc struct {
+	defaultHook func(context.Context, int64, int, int, db.ListAllReposOptions) ([]*db.Repository, int64, error)
+	hooks       []func(context.Context, int64, int, int, db.ListAllReposOptions) ([]*db.Repository, int64, error)
+	history     []OrgsStoreListAllReposFuncCall
+	mutex       sync.Mutex
+}
+
+// ListAllRepos delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockOrgsStore) ListAllRepos(v0 context.Context, v1 int64, v2 int, v3 int, v4 db.ListAllReposOptions) ([]*db.Repository, int64, error) {
+	r0, r1, r2 := m.ListAllReposFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.ListAllReposFunc.appendCall(OrgsStoreListAllReposFuncCall{v0, v1, v2, v3, v4, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the ListAllRepos method
+// of the parent MockOrgsStore instance is invoked and the hook queue is
+// empty.
+func (f *OrgsStoreListAllReposFunc) SetDefaultHook(hook func(context.Context, int64, int, int, db.ListAllReposOptions) ([]*db.Repository, int64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListAllRepos method of the parent MockOrgsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *OrgsStoreListAllReposFunc) PushHook(hook func(context.Context, int64, int, int, db.ListAllReposOptions) ([]*db.Repository, int64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreListAllReposFunc) SetDefaultReturn(r0 []*db.Repository, r1 int64, r2 error) {
+	f.SetDefaultHook(func(context.Context, int64, int, int, db.ListAllReposOptions) ([]*db.Repository, int64, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreListAllReposFunc) PushReturn(r0 []*db.Repository, r1 int64, r2 error) {
+	f.PushHook(func(context.Context, int64, int, int, db.ListAllReposOptions) ([]*db.Repository, int64, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *OrgsStoreListAllReposFunc) nextHook() func(context.Context, int64, int, int, db.ListAllReposOptions) ([]*db.Repository, int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreListAllReposFunc) appendCall(r0 OrgsStoreListAllReposFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreListAllReposFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreListAllReposFunc) History() []OrgsStoreListAllReposFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreListAllReposFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreListAllReposFuncCall is an object that describes an invocation
+// of method ListAllRepos on an instance of MockOrgsStore.
+type OrgsStoreListAllReposFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 db.ListAllReposOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.Repository
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int64
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreListAllReposFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreListAllReposFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// OrgsStoreListAuditLogFunc describes the behavior when the ListAuditLog
+// method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreListAuditLogFunc struct {
+	defaultHook func(context.Context, int64, db.ListAuditLogOptions) ([]*db.AuditLog, int64, error)
+	hooks       []func(context.Context, int64, db.ListAuditLogOptions) ([]*db.AuditLog, int64, error)
+	history     []OrgsStoreListAuditLogFuncCall
+	mutex       sync.Mutex
+}
+
+// ListAuditLog delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockOrgsStore) ListAuditLog(v0 context.Context, v1 int64, v2 db.ListAuditLogOptions) ([]*db.AuditLog, int64, error) {
+	r0, r1, r2 := m.ListAuditLogFunc.nextHook()(v0, v1, v2)
+	m.ListAuditLogFunc.appendCall(OrgsStoreListAuditLogFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the ListAuditLog method
+// of the parent MockOrgsStore instance is invoked and the hook queue is
+// empty.
+func (f *OrgsStoreListAuditLogFunc) SetDefaultHook(hook func(context.Context, int64, db.ListAuditLogOptions) ([]*db.AuditLog, int64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListAuditLog method of the parent MockOrgsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *OrgsStoreListAuditLogFunc) PushHook(hook func(context.Context, int64, db.ListAuditLogOptions) ([]*db.AuditLog, int64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreListAuditLogFunc) SetDefaultReturn(r0 []*db.AuditLog, r1 int64, r2 error) {
+	f.SetDefaultHook(func(context.Context, int64, db.ListAuditLogOptions) ([]*db.AuditLog, int64, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreListAuditLogFunc) PushReturn(r0 []*db.AuditLog, r1 int64, r2 error) {
+	f.PushHook(func(context.Context, int64, db.ListAuditLogOptions) ([]*db.AuditLog, int64, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *OrgsStoreListAuditLogFunc) nextHook() func(context.Context, int64, db.ListAuditLogOptions) ([]*db.AuditLog, int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreListAuditLogFunc) appendCall(r0 OrgsStoreListAuditLogFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreListAuditLogFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreListAuditLogFunc) History() []OrgsStoreListAuditLogFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreListAuditLogFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreListAuditLogFuncCall is an object that describes an invocation
+// of method ListAuditLog on an instance of MockOrgsStore.
+type OrgsStoreListAuditLogFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 db.ListAuditLogOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.AuditLog
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int64
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreListAuditLogFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreListAuditLogFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// OrgsStoreListCommonFunc describes the behavior when the ListCommon method
+// of the parent MockOrgsStore instance is invoked.
+type OrgsStoreListCommonFunc struct {
+	defaultHook func(context.Context, int64, int64) ([]*db.User, error)
+	hooks       []func(context.Context, int64, int64) ([]*db.User, error)
+	history     []OrgsStoreListCommonFuncCall
+	mutex       sync.Mutex
+}
+
+// ListCommon delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockOrgsStore) ListCommon(v0 context.Context, v1 int64, v2 int64) ([]*db.User, error) {
+	r0, r1 := m.ListCommonFunc.nextHook()(v0, v1, v2)
+	m.ListCommonFunc.appendCall(OrgsStoreListCommonFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListCommon method of
+// the parent MockOrgsStore instance is invoked and the hook queue is empty.
+func (f *OrgsStoreListCommonFunc) SetDefaultHook(hook func(context.Context, int64, int64) ([]*db.User, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListCommon method of the parent MockOrgsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *OrgsStoreListCommonFunc) PushHook(hook func(context.Context, int64, int64) ([]*db.User, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreListCommonFunc) SetDefaultReturn(r0 []*db.User, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) ([]*db.User, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreListCommonFunc) PushReturn(r0 []*db.User, r1 error) {
+	f.PushHook(func(context.Context, int64, int64) ([]*db.User, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreListCommonFunc) nextHook() func(context.Context, int64, int64) ([]*db.User, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreListCommonFunc) appendCall(r0 OrgsStoreListCommonFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreListCommonFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreListCommonFunc) History() []OrgsStoreListCommonFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreListCommonFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreListCommonFuncCall is an object that describes an invocation of
+// method ListCommon on an instance of MockOrgsStore.
+type OrgsStoreListCommonFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.User
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreListCommonFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreListCommonFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreListInvitationsFunc describes the behavior when the
+// ListInvitations method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreListInvitationsFunc struct {
+	defaultHook func(context.Context, int64) ([]*db.OrgInvitation, error)
+	hooks       []func(context.Context, int64) ([]*db.OrgInvitation, error)
+	history     []OrgsStoreListInvitationsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListInvitations delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) ListInvitations(v0 context.Context, v1 int64) ([]*db.OrgInvitation, error) {
+	r0, r1 := m.ListInvitationsFunc.nextHook()(v0, v1)
+	m.ListInvitationsFunc.appendCall(OrgsStoreListInvitationsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListInvitations
+// method of the parent MockOrgsStore instance is invoked and the hook queue
+// is empty.
+func (f *OrgsStoreListInvitationsFunc) SetDefaultHook(hook func(context.Context, int64) ([]*db.OrgInvitation, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListInvitations method of the parent MockOrgsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *OrgsStoreListInvitationsFunc) PushHook(hook func(context.Context, int64) ([]*db.OrgInvitation, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreListInvitationsFunc) SetDefaultReturn(r0 []*db.OrgInvitation, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) ([]*db.OrgInvitation, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreListInvitationsFunc) PushReturn(r0 []*db.OrgInvitation, r1 error) {
+	f.PushHook(func(context.Context, int64) ([]*db.OrgInvitation, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreListInvitationsFunc) nextHook() func(context.Context, int64) ([]*db.OrgInvitation, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreListInvitationsFunc) appendCall(r0 OrgsStoreListInvitationsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreListInvitationsFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreListInvitationsFunc) History() []OrgsStoreListInvitationsFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreListInvitationsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreListInvitationsFuncCall is an object that describes an
+// invocation of method ListInvitations on an instance of MockOrgsStore.
+type OrgsStoreListInvitationsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.OrgInvitation
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreListInvitationsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreListInvitationsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreListMemberNotifyLevelsFunc describes the behavior when the
+// ListMemberNotifyLevels method of the parent MockOrgsStore instance is
+// invoked.
+type OrgsStoreListMemberNotifyLevelsFunc struct {
+	defaultHook func(context.Context, int64) (map[int64]int, error)
+	hooks       []func(context.Context, int64) (map[int64]int, error)
+	history     []OrgsStoreListMemberNotifyLevelsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListMemberNotifyLevels delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) ListMemberNotifyLevels(v0 context.Context, v1 int64) (map[int64]int, error) {
+	r0, r1 := m.ListMemberNotifyLevelsFunc.nextHook()(v0, v1)
+	m.ListMemberNotifyLevelsFunc.appendCall(OrgsStoreListMemberNotifyLevelsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListMemberNotifyLevels method of the parent MockOrgsStore instance is
+// invoked and the hook queue is empty.
+func (f *OrgsStoreListMemberNotifyLevelsFunc) SetDefaultHook(hook func(context.Context, int64) (map[int64]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListMemberNotifyLevels method of the parent MockOrgsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *OrgsStoreListMemberNotifyLevelsFunc) PushHook(hook func(context.Context, int64) (map[int64]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreListMemberNotifyLevelsFunc) SetDefaultReturn(r0 map[int64]int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (map[int64]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreListMemberNotifyLevelsFunc) PushReturn(r0 map[int64]int, r1 error) {
+	f.PushHook(func(context.Context, int64) (map[int64]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreListMemberNotifyLevelsFunc) nextHook() func(context.Context, int64) (map[int64]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreListMemberNotifyLevelsFunc) appendCall(r0 OrgsStoreListMemberNotifyLevelsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreListMemberNotifyLevelsFuncCall
+// objects describing the invocations of this function.
+func (f *OrgsStoreListMemberNotifyLevelsFunc) History() []OrgsStoreListMemberNotifyLevelsFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreListMemberNotifyLevelsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreListMemberNotifyLevelsFuncCall is an object that describes an
+// invocation of method ListMemberNotifyLevels on an instance of
+// MockOrgsStore.
+type OrgsStoreListMemberNotifyLevelsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[int64]int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreListMemberNotifyLevelsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreListMemberNotifyLevelsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreListMembersFunc describes the behavior when the ListMembers
+// method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreListMembersFunc struct {
+	defaultHook func(context.Context, int64, db.ListOrgMembersOptions) ([]*db.User, int64, error)
+	hooks       []func(context.Context, int64, db.ListOrgMembersOptions) ([]*db.User, int64, error)
+	history     []OrgsStoreListMembersFuncCall
+	mutex       sync.Mutex
+}
+
+// ListMembers delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockOrgsStore) ListMembers(v0 context.Context, v1 int64, v2 db.ListOrgMembersOptions) ([]*db.User, int64, error) {
+	r0, r1, r2 := m.ListMembersFunc.nextHook()(v0, v1, v2)
+	m.ListMembersFunc.appendCall(OrgsStoreListMembersFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the ListMembers method
+// of the parent MockOrgsStore instance is invoked and the hook queue is
+// empty.
+func (f *OrgsStoreListMembersFunc) SetDefaultHook(hook func(context.Context, int64, db.ListOrgMembersOptions) ([]*db.User, int64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListMembers method of the parent MockOrgsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *OrgsStoreListMembersFunc) PushHook(hook func(context.Context, int64, db.ListOrgMembersOptions) ([]*db.User, int64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreListMembersFunc) SetDefaultReturn(r0 []*db.User, r1 int64, r2 error) {
+	f.SetDefaultHook(func(context.Context, int64, db.ListOrgMembersOptions) ([]*db.User, int64, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreListMembersFunc) PushReturn(r0 []*db.User, r1 int64, r2 error) {
+	f.PushHook(func(context.Context, int64, db.ListOrgMembersOptions) ([]*db.User, int64, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *OrgsStoreListMembersFunc) nextHook() func(context.Context, int64, db.ListOrgMembersOptions) ([]*db.User, int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreListMembersFunc) appendCall(r0 OrgsStoreListMembersFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreListMembersFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreListMembersFunc) History() []OrgsStoreListMembersFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreListMembersFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreListMembersFuncCall is an object that describes an invocation of
+// method ListMembers on an instance of MockOrgsStore.
+type OrgsStoreListMembersFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 db.ListOrgMembersOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.User
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int64
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreListMembersFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreListMembersFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// OrgsStoreListNotifyLevelsOfMemberFunc describes the behavior when the
+// ListNotifyLevelsOfMember method of the parent MockOrgsStore instance is
+// invoked.
+type OrgsStoreListNotifyLevelsOfMemberFunc struct {
+	defaultHook func(context.Context, int64) (map[int64]int, error)
+	hooks       []func(context.Context, int64) (map[int64]int, error)
+	history     []OrgsStoreListNotifyLevelsOfMemberFuncCall
+	mutex       sync.Mutex
+}
+
+// ListNotifyLevelsOfMember delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) ListNotifyLevelsOfMember(v0 context.Context, v1 int64) (map[int64]int, error) {
+	r0, r1 := m.ListNotifyLevelsOfMemberFunc.nextHook()(v0, v1)
+	m.ListNotifyLevelsOfMemberFunc.appendCall(OrgsStoreListNotifyLevelsOfMemberFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListNotifyLevelsOfMember method of the parent MockOrgsStore instance is
+// invoked and the hook queue is empty.
+func (f *OrgsStoreListNotifyLevelsOfMemberFunc) SetDefaultHook(hook func(context.Context, int64) (map[int64]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListNotifyLevelsOfMember method of the parent MockOrgsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *OrgsStoreListNotifyLevelsOfMemberFunc) PushHook(hook func(context.Context, int64) (map[int64]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreListNotifyLevelsOfMemberFunc) SetDefaultReturn(r0 map[int64]int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (map[int64]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreListNotifyLevelsOfMemberFunc) PushReturn(r0 map[int64]int, r1 error) {
+	f.PushHook(func(context.Context, int64) (map[int64]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreListNotifyLevelsOfMemberFunc) nextHook() func(context.Context, int64) (map[int64]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreListNotifyLevelsOfMemberFunc) appendCall(r0 OrgsStoreListNotifyLevelsOfMemberFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreListNotifyLevelsOfMemberFuncCall
+// objects describing the invocations of this function.
+func (f *OrgsStoreListNotifyLevelsOfMemberFunc) History() []OrgsStoreListNotifyLevelsOfMemberFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreListNotifyLevelsOfMemberFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreListNotifyLevelsOfMemberFuncCall is an object that describes an
+// invocation of method ListNotifyLevelsOfMember on an instance of
+// MockOrgsStore.
+type OrgsStoreListNotifyLevelsOfMemberFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[int64]int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreListNotifyLevelsOfMemberFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreListNotifyLevelsOfMemberFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreListOrgMembersWithRoleFunc describes the behavior when the
+// ListOrgMembersWithRole method of the parent MockOrgsStore instance is
+// invoked.
+type OrgsStoreListOrgMembersWithRoleFunc struct {
+	defaultHook func(context.Context, int64, db.ListOrgMembersOptions) ([]*db.OrgMemberWithRole, error)
+	hooks       []func(context.Context, int64, db.ListOrgMembersOptions) ([]*db.OrgMemberWithRole, error)
+	history     []OrgsStoreListOrgMembersWithRoleFuncCall
+	mutex       sync.Mutex
+}
+
+// ListOrgMembersWithRole delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) ListOrgMembersWithRole(v0 context.Context, v1 int64, v2 db.ListOrgMembersOptions) ([]*db.OrgMemberWithRole, error) {
+	r0, r1 := m.ListOrgMembersWithRoleFunc.nextHook()(v0, v1, v2)
+	m.ListOrgMembersWithRoleFunc.appendCall(OrgsStoreListOrgMembersWithRoleFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListOrgMembersWithRole method of the parent MockOrgsStore instance is
+// invoked and the hook queue is empty.
+func (f *OrgsStoreListOrgMembersWithRoleFunc) SetDefaultHook(hook func(context.Context, int64, db.ListOrgMembersOptions) ([]*db.OrgMemberWithRole, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListOrgMembersWithRole method of the parent MockOrgsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *OrgsStoreListOrgMembersWithRoleFunc) PushHook(hook func(context.Context, int64, db.ListOrgMembersOptions) ([]*db.OrgMemberWithRole, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreListOrgMembersWithRoleFunc) SetDefaultReturn(r0 []*db.OrgMemberWithRole, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, db.ListOrgMembersOptions) ([]*db.OrgMemberWithRole, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreListOrgMembersWithRoleFunc) PushReturn(r0 []*db.OrgMemberWithRole, r1 error) {
+	f.PushHook(func(context.Context, int64, db.ListOrgMembersOptions) ([]*db.OrgMemberWithRole, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreListOrgMembersWithRoleFunc) nextHook() func(context.Context, int64, db.ListOrgMembersOptions) ([]*db.OrgMemberWithRole, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreListOrgMembersWithRoleFunc) appendCall(r0 OrgsStoreListOrgMembersWithRoleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreListOrgMembersWithRoleFuncCall
+// objects describing the invocations of this function.
+func (f *OrgsStoreListOrgMembersWithRoleFunc) History() []OrgsStoreListOrgMembersWithRoleFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreListOrgMembersWithRoleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreListOrgMembersWithRoleFuncCall is an object that describes an
+// invocation of method ListOrgMembersWithRole on an instance of
+// MockOrgsStore.
+type OrgsStoreListOrgMembersWithRoleFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 db.ListOrgMembersOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.OrgMemberWithRole
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreListOrgMembersWithRoleFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreListOrgMembersWithRoleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreListOrgsWithRepoCreatePermissionFunc describes the behavior when
+// the ListOrgsWithRepoCreatePermission method of the parent MockOrgsStore
+// instance is invoked.
+type OrgsStoreListOrgsWithRepoCreatePermissionFunc struct {
+	defaultHook func(context.Context, int64) ([]*db.User, error)
+	hooks       []func(context.Context, int64) ([]*db.User, error)
+	history     []OrgsStoreListOrgsWithRepoCreatePermissionFuncCall
+	mutex       sync.Mutex
+}
+
+// ListOrgsWithRepoCreatePermission delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) ListOrgsWithRepoCreatePermission(v0 context.Context, v1 int64) ([]*db.User, error) {
+	r0, r1 := m.ListOrgsWithRepoCreatePermissionFunc.nextHook()(v0, v1)
+	m.ListOrgsWithRepoCreatePermissionFunc.appendCall(OrgsStoreListOrgsWithRepoCreatePermissionFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListOrgsWithRepoCreatePermission method of the parent MockOrgsStore
+// instance is invoked and the hook queue is empty.
+func (f *OrgsStoreListOrgsWithRepoCreatePermissionFunc) SetDefaultHook(hook func(context.Context, int64) ([]*db.User, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListOrgsWithRepoCreatePermission method of the parent MockOrgsStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *OrgsStoreListOrgsWithRepoCreatePermissionFunc) PushHook(hook func(context.Context, int64) ([]*db.User, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreListOrgsWithRepoCreatePermissionFunc) SetDefaultReturn(r0 []*db.User, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) ([]*db.User, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreListOrgsWithRepoCreatePermissionFunc) PushReturn(r0 []*db.User, r1 error) {
+	f.PushHook(func(context.Context, int64) ([]*db.User, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreListOrgsWithRepoCreatePermissionFunc) nextHook() func(context.Context, int64) ([]*db.User, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreListOrgsWithRepoCreatePermissionFunc) appendCall(r0 OrgsStoreListOrgsWithRepoCreatePermissionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// OrgsStoreListOrgsWithRepoCreatePermissionFuncCall objects describing the
+// invocations of this function.
+func (f *OrgsStoreListOrgsWithRepoCreatePermissionFunc) History() []OrgsStoreListOrgsWithRepoCreatePermissionFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreListOrgsWithRepoCreatePermissionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreListOrgsWithRepoCreatePermissionFuncCall is an object that
+// describes an invocation of method ListOrgsWithRepoCreatePermission on an
+// instance of MockOrgsStore.
+type OrgsStoreListOrgsWithRepoCreatePermissionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.User
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreListOrgsWithRepoCreatePermissionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreListOrgsWithRepoCreatePermissionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreListOrgsWithRoleFunc describes the behavior when the
+// ListOrgsWithRole method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreListOrgsWithRoleFunc struct {
+	defaultHook func(context.Context, db.ListOrgsOptions) ([]*db.OrgWithRole, error)
+	hooks       []func(context.Context, db.ListOrgsOptions) ([]*db.OrgWithRole, error)
+	history     []OrgsStoreListOrgsWithRoleFuncCall
+	mutex       sync.Mutex
+}
+
+// ListOrgsWithRole delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) ListOrgsWithRole(v0 context.Context, v1 db.ListOrgsOptions) ([]*db.OrgWithRole, error) {
+	r0, r1 := m.ListOrgsWithRoleFunc.nextHook()(v0, v1)
+	m.ListOrgsWithRoleFunc.appendCall(OrgsStoreListOrgsWithRoleFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListOrgsWithRole
+// method of the parent MockOrgsStore instance is invoked and the hook queue
+// is empty.
+func (f *OrgsStoreListOrgsWithRoleFunc) SetDefaultHook(hook func(context.Context, db.ListOrgsOptions) ([]*db.OrgWithRole, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListOrgsWithRole method of the parent MockOrgsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *OrgsStoreListOrgsWithRoleFunc) PushHook(hook func(context.Context, db.ListOrgsOptions) ([]*db.OrgWithRole, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreListOrgsWithRoleFunc) SetDefaultReturn(r0 []*db.OrgWithRole, r1 error) {
+	f.SetDefaultHook(func(context.Context, db.ListOrgsOptions) ([]*db.OrgWithRole, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreListOrgsWithRoleFunc) PushReturn(r0 []*db.OrgWithRole, r1 error) {
+	f.PushHook(func(context.Context, db.ListOrgsOptions) ([]*db.OrgWithRole, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreListOrgsWithRoleFunc) nextHook() func(context.Context, db.ListOrgsOptions) ([]*db.OrgWithRole, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreListOrgsWithRoleFunc) appendCall(r0 OrgsStoreListOrgsWithRoleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreListOrgsWithRoleFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreListOrgsWithRoleFunc) History() []OrgsStoreListOrgsWithRoleFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreListOrgsWithRoleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreListOrgsWithRoleFuncCall is an object that describes an
+// invocation of method ListOrgsWithRole on an instance of MockOrgsStore.
+type OrgsStoreListOrgsWithRoleFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 db.ListOrgsOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.OrgWithRole
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreListOrgsWithRoleFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreListOrgsWithRoleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreListOrphanedFunc describes the behavior when the ListOrphaned
+// method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreListOrphanedFunc struct {
+	defaultHook func(context.Context) ([]*db.User, error)
+	hooks       []func(context.Context) ([]*db.User, error)
+	history     []OrgsStoreListOrphanedFuncCall
+	mutex       sync.Mutex
+}
+
+// ListOrphaned delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockOrgsStore) ListOrphaned(v0 context.Context) ([]*db.User, error) {
+	r0, r1 := m.ListOrphanedFunc.nextHook()(v0)
+	m.ListOrphanedFunc.appendCall(OrgsStoreListOrphanedFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListOrphaned method
+// of the parent MockOrgsStore instance is invoked and the hook queue is
+// empty.
+func (f *OrgsStoreListOrphanedFunc) SetDefaultHook(hook func(context.Context) ([]*db.User, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListOrphaned method of the parent MockOrgsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *OrgsStoreListOrphanedFunc) PushHook(hook func(context.Context) ([]*db.User, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreListOrphanedFunc) SetDefaultReturn(r0 []*db.User, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]*db.User, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreListOrphanedFunc) PushReturn(r0 []*db.User, r1 error) {
+	f.PushHook(func(context.Context) ([]*db.User, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreListOrphanedFunc) nextHook() func(context.Context) ([]*db.User, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreListOrphanedFunc) appendCall(r0 OrgsStoreListOrphanedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreListOrphanedFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreListOrphanedFunc) History() []OrgsStoreListOrphanedFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreListOrphanedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreListOrphanedFuncCall is an object that describes an invocation
+// of method ListOrphaned on an instance of MockOrgsStore.
+type OrgsStoreListOrphanedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.User
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreListOrphanedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreListOrphanedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreListPinnedReposFunc describes the behavior when the
+// ListPinnedRepos method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreListPinnedReposFunc struct {
+	defaultHook func(context.Context, int64) ([]*db.Repository, error)
+	hooks       []func(context.Context, int64) ([]*db.Repository, error)
+	history     []OrgsStoreListPinnedReposFuncCall
+	mutex       sync.Mutex
+}
+
+// ListPinnedRepos delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) ListPinnedRepos(v0 context.Context, v1 int64) ([]*db.Repository, error) {
+	r0, r1 := m.ListPinnedReposFunc.nextHook()(v0, v1)
+	m.ListPinnedReposFunc.appendCall(OrgsStoreListPinnedReposFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListPinnedRepos
+// method of the parent MockOrgsStore instance is invoked and the hook queue
+// is empty.
+func (f *OrgsStoreListPinnedReposFunc) SetDefaultHook(hook func(context.Context, int64) ([]*db.Repository, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListPinnedRepos method of the parent MockOrgsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *OrgsStoreListPinnedReposFunc) PushHook(hook func(context.Context, int64) ([]*db.Repository, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreListPinnedReposFunc) SetDefaultReturn(r0 []*db.Repository, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) ([]*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreListPinnedReposFunc) PushReturn(r0 []*db.Repository, r1 error) {
+	f.PushHook(func(context.Context, int64) ([]*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgsStoreListPinnedReposFunc) nextHook() func(context.Context, int64) ([]*db.Repository, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreListPinnedReposFunc) appendCall(r0 OrgsStoreListPinnedReposFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreListPinnedReposFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreListPinnedReposFunc) History() []OrgsStoreListPinnedReposFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreListPinnedReposFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreListPinnedReposFuncCall is an object that describes an
+// invocation of method ListPinnedRepos on an instance of MockOrgsStore.
+type OrgsStoreListPinnedReposFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.Repository
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreListPinnedReposFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreListPinnedReposFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OrgsStoreRecountAllFunc describes the behavior when the RecountAll method
+// of the parent MockOrgsStore instance is invoked.
+type OrgsStoreRecountAllFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []OrgsStoreRecountAllFuncCall
+	mutex       sync.Mutex
+}
+
+// RecountAll delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockOrgsStore) RecountAll(v0 context.Context, v1 int64) error {
+	r0 := m.RecountAllFunc.nextHook()(v0, v1)
+	m.RecountAllFunc.appendCall(OrgsStoreRecountAllFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the RecountAll method of
+// the parent MockOrgsStore instance is invoked and the hook queue is empty.
+func (f *OrgsStoreRecountAllFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RecountAll method of the parent MockOrgsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *OrgsStoreRecountAllFunc) PushHook(hook func(context.Context, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreRecountAllFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreRecountAllFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreRecountAllFunc) nextHook() func(context.Context, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreRecountAllFunc) appendCall(r0 OrgsStoreRecountAllFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreRecountAllFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreRecountAllFunc) History() []OrgsStoreRecountAllFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreRecountAllFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreRecountAllFuncCall is an object that describes an invocation of
+// method RecountAll on an instance of MockOrgsStore.
+type OrgsStoreRecountAllFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreRecountAllFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreRecountAllFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreRemoveMemberFunc describes the behavior when the RemoveMember
+// method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreRemoveMemberFunc struct {
+	defaultHook func(context.Context, int64, int64) error
+	hooks       []func(context.Context, int64, int64) error
+	history     []OrgsStoreRemoveMemberFuncCall
+	mutex       sync.Mutex
+}
+
+// RemoveMember delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockOrgsStore) RemoveMember(v0 context.Context, v1 int64, v2 int64) error {
+	r0 := m.RemoveMemberFunc.nextHook()(v0, v1, v2)
+	m.RemoveMemberFunc.appendCall(OrgsStoreRemoveMemberFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the RemoveMember method
+// of the parent MockOrgsStore instance is invoked and the hook queue is
+// empty.
+func (f *OrgsStoreRemoveMemberFunc) SetDefaultHook(hook func(context.Context, int64, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RemoveMember method of the parent MockOrgsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *OrgsStoreRemoveMemberFunc) PushHook(hook func(context.Context, int64, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreRemoveMemberFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreRemoveMemberFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreRemoveMemberFunc) nextHook() func(context.Context, int64, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreRemoveMemberFunc) appendCall(r0 OrgsStoreRemoveMemberFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreRemoveMemberFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreRemoveMemberFunc) History() []OrgsStoreRemoveMemberFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreRemoveMemberFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreRemoveMemberFuncCall is an object that describes an invocation
+// of method RemoveMember on an instance of MockOrgsStore.
+type OrgsStoreRemoveMemberFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreRemoveMemberFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreRemoveMemberFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreRevokeInvitationFunc describes the behavior when the
+// RevokeInvitation method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreRevokeInvitationFunc struct {
+	defaultHook func(context.Context, int64, int64) error
+	hooks       []func(context.Context, int64, int64) error
+	history     []OrgsStoreRevokeInvitationFuncCall
+	mutex       sync.Mutex
+}
+
+// RevokeInvitation delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) RevokeInvitation(v0 context.Context, v1 int64, v2 int64) error {
+	r0 := m.RevokeInvitationFunc.nextHook()(v0, v1, v2)
+	m.RevokeInvitationFunc.appendCall(OrgsStoreRevokeInvitationFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the RevokeInvitation
+// method of the parent MockOrgsStore instance is invoked and the hook queue
+// is empty.
+func (f *OrgsStoreRevokeInvitationFunc) SetDefaultHook(hook func(context.Context, int64, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RevokeInvitation method of the parent MockOrgsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *OrgsStoreRevokeInvitationFunc) PushHook(hook func(context.Context, int64, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreRevokeInvitationFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreRevokeInvitationFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreRevokeInvitationFunc) nextHook() func(context.Context, int64, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreRevokeInvitationFunc) appendCall(r0 OrgsStoreRevokeInvitationFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreRevokeInvitationFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreRevokeInvitationFunc) History() []OrgsStoreRevokeInvitationFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreRevokeInvitationFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreRevokeInvitationFuncCall is an object that describes an
+// invocation of method RevokeInvitation on an instance of MockOrgsStore.
+type OrgsStoreRevokeInvitationFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreRevokeInvitationFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreRevokeInvitationFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreSearchByNameFunc describes the behavior when the SearchByName
+// method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreSearchByNameFunc struct {
+	defaultHook func(context.Context, string, int, int, string, bool) ([]*db.User, int64, error)
+	hooks       []func(context.Context, string, int, int, string, bool) ([]*db.User, int64, error)
+	history     []OrgsStoreSearchByNameFuncCall
+	mutex       sync.Mutex
+}
+
+// SearchByName delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockOrgsStore) SearchByName(v0 context.Context, v1 string, v2 int, v3 int, v4 string, v5 bool) ([]*db.User, int64, error) {
+	r0, r1, r2 := m.SearchByNameFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.SearchByNameFunc.appendCall(OrgsStoreSearchByNameFuncCall{v0, v1, v2, v3, v4, v5, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the SearchByName method
+// of the parent MockOrgsStore instance is invoked and the hook queue is
+// empty.
+func (f *OrgsStoreSearchByNameFunc) SetDefaultHook(hook func(context.Context, string, int, int, string, bool) ([]*db.User, int64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SearchByName method of the parent MockOrgsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *OrgsStoreSearchByNameFunc) PushHook(hook func(context.Context, string, int, int, string, bool) ([]*db.User, int64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreSearchByNameFunc) SetDefaultReturn(r0 []*db.User, r1 int64, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, int, int, string, bool) ([]*db.User, int64, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreSearchByNameFunc) PushReturn(r0 []*db.User, r1 int64, r2 error) {
+	f.PushHook(func(context.Context, string, int, int, string, bool) ([]*db.User, int64, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *OrgsStoreSearchByNameFunc) nextHook() func(context.Context, string, int, int, string, bool) ([]*db.User, int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreSearchByNameFunc) appendCall(r0 OrgsStoreSearchByNameFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreSearchByNameFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreSearchByNameFunc) History() []OrgsStoreSearchByNameFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreSearchByNameFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreSearchByNameFuncCall is an object that describes an invocation
+// of method SearchByName on an instance of MockOrgsStore.
+type OrgsStoreSearchByNameFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.User
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int64
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreSearchByNameFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreSearchByNameFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// OrgsStoreSearchMembersFunc describes the behavior when the SearchMembers
+// method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreSearchMembersFunc struct {
+	defaultHook func(context.Context, int64, string, int, int, db.SearchOrgMembersOptions) ([]*db.User, int64, error)
+	hooks       []func(context.Context, int64, string, int, int, db.SearchOrgMembersOptions) ([]*db.User, int64, error)
+	history     []OrgsStoreSearchMembersFuncCall
+	mutex       sync.Mutex
+}
+
+// SearchMembers delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockOrgsStore) SearchMembers(v0 context.Context, v1 int64, v2 string, v3 int, v4 int, v5 db.SearchOrgMembersOptions) ([]*db.User, int64, error) {
+	r0, r1, r2 := m.SearchMembersFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.SearchMembersFunc.appendCall(OrgsStoreSearchMembersFuncCall{v0, v1, v2, v3, v4, v5, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the SearchMembers method
+// of the parent MockOrgsStore instance is invoked and the hook queue is
+// empty.
+func (f *OrgsStoreSearchMembersFunc) SetDefaultHook(hook func(context.Context, int64, string, int, int, db.SearchOrgMembersOptions) ([]*db.User, int64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SearchMembers method of the parent MockOrgsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *OrgsStoreSearchMembersFunc) PushHook(hook func(context.Context, int64, string, int, int, db.SearchOrgMembersOptions) ([]*db.User, int64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreSearchMembersFunc) SetDefaultReturn(r0 []*db.User, r1 int64, r2 error) {
+	f.SetDefaultHook(func(context.Context, int64, string, int, int, db.SearchOrgMembersOptions) ([]*db.User, int64, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreSearchMembersFunc) PushReturn(r0 []*db.User, r1 int64, r2 error) {
+	f.PushHook(func(context.Context, int64, string, int, int, db.SearchOrgMembersOptions) ([]*db.User, int64, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *OrgsStoreSearchMembersFunc) nextHook() func(context.Context, int64, string, int, int, db.SearchOrgMembersOptions) ([]*db.User, int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreSearchMembersFunc) appendCall(r0 OrgsStoreSearchMembersFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreSearchMembersFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreSearchMembersFunc) History() []OrgsStoreSearchMembersFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreSearchMembersFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreSearchMembersFuncCall is an object that describes an invocation
+// of method SearchMembers on an instance of MockOrgsStore.
+type OrgsStoreSearchMembersFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 db.SearchOrgMembersOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.User
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int64
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreSearchMembersFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreSearchMembersFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// OrgsStoreSearchVisibleByNameFunc describes the behavior when the
+// SearchVisibleByName method of the parent MockOrgsStore instance is
+// invoked.
+type OrgsStoreSearchVisibleByNameFunc struct {
+	defaultHook func(context.Context, string, int, int, string, int64) ([]*db.User, int64, error)
+	hooks       []func(context.Context, string, int, int, string, int64) ([]*db.User, int64, error)
+	history     []OrgsStoreSearchVisibleByNameFuncCall
+	mutex       sync.Mutex
+}
+
+// SearchVisibleByName delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) SearchVisibleByName(v0 context.Context, v1 string, v2 int, v3 int, v4 string, v5 int64) ([]*db.User, int64, error) {
+	r0, r1, r2 := m.SearchVisibleByNameFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.SearchVisibleByNameFunc.appendCall(OrgsStoreSearchVisibleByNameFuncCall{v0, v1, v2, v3, v4, v5, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the SearchVisibleByName
+// method of the parent MockOrgsStore instance is invoked and the hook queue
+// is empty.
+func (f *OrgsStoreSearchVisibleByNameFunc) SetDefaultHook(hook func(context.Context, string, int, int, string, int64) ([]*db.User, int64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SearchVisibleByName method of the parent MockOrgsStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *OrgsStoreSearchVisibleByNameFunc) PushHook(hook func(context.Context, string, int, int, string, int64) ([]*db.User, int64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreSearchVisibleByNameFunc) SetDefaultReturn(r0 []*db.User, r1 int64, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, int, int, string, int64) ([]*db.User, int64, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreSearchVisibleByNameFunc) PushReturn(r0 []*db.User, r1 int64, r2 error) {
+	f.PushHook(func(context.Context, string, int, int, string, int64) ([]*db.User, int64, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *OrgsStoreSearchVisibleByNameFunc) nextHook() func(context.Context, string, int, int, string, int64) ([]*db.User, int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreSearchVisibleByNameFunc) appendCall(r0 OrgsStoreSearchVisibleByNameFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreSearchVisibleByNameFuncCall
+// objects describing the invocations of this function.
+func (f *OrgsStoreSearchVisibleByNameFunc) History() []OrgsStoreSearchVisibleByNameFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreSearchVisibleByNameFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreSearchVisibleByNameFuncCall is an object that describes an
+// invocation of method SearchVisibleByName on an instance of MockOrgsStore.
+type OrgsStoreSearchVisibleByNameFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.User
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int64
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreSearchVisibleByNameFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreSearchVisibleByNameFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// OrgsStoreSetArchivedFunc describes the behavior when the SetArchived
+// method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreSetArchivedFunc struct {
+	defaultHook func(context.Context, int64, bool) error
+	hooks       []func(context.Context, int64, bool) error
+	history     []OrgsStoreSetArchivedFuncCall
+	mutex       sync.Mutex
+}
+
+// SetArchived delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockOrgsStore) SetArchived(v0 context.Context, v1 int64, v2 bool) error {
+	r0 := m.SetArchivedFunc.nextHook()(v0, v1, v2)
+	m.SetArchivedFunc.appendCall(OrgsStoreSetArchivedFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetArchived method
+// of the parent MockOrgsStore instance is invoked and the hook queue is
+// empty.
+func (f *OrgsStoreSetArchivedFunc) SetDefaultHook(hook func(context.Context, int64, bool) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetArchived method of the parent MockOrgsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *OrgsStoreSetArchivedFunc) PushHook(hook func(context.Context, int64, bool) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreSetArchivedFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreSetArchivedFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, bool) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreSetArchivedFunc) nextHook() func(context.Context, int64, bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreSetArchivedFunc) appendCall(r0 OrgsStoreSetArchivedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreSetArchivedFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreSetArchivedFunc) History() []OrgsStoreSetArchivedFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreSetArchivedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreSetArchivedFuncCall is an object that describes an invocation of
+// method SetArchived on an instance of MockOrgsStore.
+type OrgsStoreSetArchivedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreSetArchivedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreSetArchivedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreSetDefaultLabelsFunc describes the behavior when the
+// SetDefaultLabels method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreSetDefaultLabelsFunc struct {
+	defaultHook func(context.Context, int64, []*db.OrgLabel) error
+	hooks       []func(context.Context, int64, []*db.OrgLabel) error
+	history     []OrgsStoreSetDefaultLabelsFuncCall
+	mutex       sync.Mutex
+}
+
+// SetDefaultLabels delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) SetDefaultLabels(v0 context.Context, v1 int64, v2 []*db.OrgLabel) error {
+	r0 := m.SetDefaultLabelsFunc.nextHook()(v0, v1, v2)
+	m.SetDefaultLabelsFunc.appendCall(OrgsStoreSetDefaultLabelsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetDefaultLabels
+// method of the parent MockOrgsStore instance is invoked and the hook queue
+// is empty.
+func (f *OrgsStoreSetDefaultLabelsFunc) SetDefaultHook(hook func(context.Context, int64, []*db.OrgLabel) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetDefaultLabels method of the parent MockOrgsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *OrgsStoreSetDefaultLabelsFunc) PushHook(hook func(context.Context, int64, []*db.OrgLabel) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreSetDefaultLabelsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, []*db.OrgLabel) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreSetDefaultLabelsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, []*db.OrgLabel) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreSetDefaultLabelsFunc) nextHook() func(context.Context, int64, []*db.OrgLabel) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreSetDefaultLabelsFunc) appendCall(r0 OrgsStoreSetDefaultLabelsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreSetDefaultLabelsFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreSetDefaultLabelsFunc) History() []OrgsStoreSetDefaultLabelsFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreSetDefaultLabelsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreSetDefaultLabelsFuncCall is an object that describes an
+// invocation of method SetDefaultLabels on an instance of MockOrgsStore.
+type OrgsStoreSetDefaultLabelsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []*db.OrgLabel
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreSetDefaultLabelsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreSetDefaultLabelsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreSetDefaultProtectionFunc describes the behavior when the
+// SetDefaultProtection method of the parent MockOrgsStore instance is
+// invoked.
+type OrgsStoreSetDefaultProtectionFunc struct {
+	defaultHook func(context.Context, int64, []*db.OrgProtectBranch) error
+	hooks       []func(context.Context, int64, []*db.OrgProtectBranch) error
+	history     []OrgsStoreSetDefaultProtectionFuncCall
+	mutex       sync.Mutex
+}
+
+// SetDefaultProtection delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) SetDefaultProtection(v0 context.Context, v1 int64, v2 []*db.OrgProtectBranch) error {
+	r0 := m.SetDefaultProtectionFunc.nextHook()(v0, v1, v2)
+	m.SetDefaultProtectionFunc.appendCall(OrgsStoreSetDefaultProtectionFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetDefaultProtection
+// method of the parent MockOrgsStore instance is invoked and the hook queue
+// is empty.
+func (f *OrgsStoreSetDefaultProtectionFunc) SetDefaultHook(hook func(context.Context, int64, []*db.OrgProtectBranch) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetDefaultProtection method of the parent MockOrgsStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *OrgsStoreSetDefaultProtectionFunc) PushHook(hook func(context.Context, int64, []*db.OrgProtectBranch) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreSetDefaultProtectionFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, []*db.OrgProtectBranch) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreSetDefaultProtectionFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, []*db.OrgProtectBranch) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreSetDefaultProtectionFunc) nextHook() func(context.Context, int64, []*db.OrgProtectBranch) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreSetDefaultProtectionFunc) appendCall(r0 OrgsStoreSetDefaultProtectionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreSetDefaultProtectionFuncCall
+// objects describing the invocations of this function.
+func (f *OrgsStoreSetDefaultProtectionFunc) History() []OrgsStoreSetDefaultProtectionFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreSetDefaultProtectionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreSetDefaultProtectionFuncCall is an object that describes an
+// invocation of method SetDefaultProtection on an instance of
+// MockOrgsStore.
+type OrgsStoreSetDefaultProtectionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []*db.OrgProtectBranch
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreSetDefaultProtectionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreSetDefaultProtectionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreSetDefaultRepoPermissionFunc describes the behavior when the
+// SetDefaultRepoPermission method of the parent MockOrgsStore instance is
+// invoked.
+type OrgsStoreSetDefaultRepoPermissionFunc struct {
+	defaultHook func(context.Context, int64, db.AccessMode) error
+	hooks       []func(context.Context, int64, db.AccessMode) error
+	history     []OrgsStoreSetDefaultRepoPermissionFuncCall
+	mutex       sync.Mutex
+}
+
+// SetDefaultRepoPermission delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) SetDefaultRepoPermission(v0 context.Context, v1 int64, v2 db.AccessMode) error {
+	r0 := m.SetDefaultRepoPermissionFunc.nextHook()(v0, v1, v2)
+	m.SetDefaultRepoPermissionFunc.appendCall(OrgsStoreSetDefaultRepoPermissionFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// SetDefaultRepoPermission method of the parent MockOrgsStore instance is
+// invoked and the hook queue is empty.
+func (f *OrgsStoreSetDefaultRepoPermissionFunc) SetDefaultHook(hook func(context.Context, int64, db.AccessMode) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetDefaultRepoPermission method of the parent MockOrgsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *OrgsStoreSetDefaultRepoPermissionFunc) PushHook(hook func(context.Context, int64, db.AccessMode) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreSetDefaultRepoPermissionFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, db.AccessMode) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreSetDefaultRepoPermissionFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, db.AccessMode) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreSetDefaultRepoPermissionFunc) nextHook() func(context.Context, int64, db.AccessMode) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreSetDefaultRepoPermissionFunc) appendCall(r0 OrgsStoreSetDefaultRepoPermissionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreSetDefaultRepoPermissionFuncCall
+// objects describing the invocations of this function.
+func (f *OrgsStoreSetDefaultRepoPermissionFunc) History() []OrgsStoreSetDefaultRepoPermissionFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreSetDefaultRepoPermissionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreSetDefaultRepoPermissionFuncCall is an object that describes an
+// invocation of method SetDefaultRepoPermission on an instance of
+// MockOrgsStore.
+type OrgsStoreSetDefaultRepoPermissionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 db.AccessMode
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreSetDefaultRepoPermissionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreSetDefaultRepoPermissionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreSetMemberNotifyLevelFunc describes the behavior when the
+// SetMemberNotifyLevel method of the parent MockOrgsStore instance is
+// invoked.
+type OrgsStoreSetMemberNotifyLevelFunc struct {
+	defaultHook func(context.Context, int64, int64, int) error
+	hooks       []func(context.Context, int64, int64, int) error
+	history     []OrgsStoreSetMemberNotifyLevelFuncCall
+	mutex       sync.Mutex
+}
+
+// SetMemberNotifyLevel delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) SetMemberNotifyLevel(v0 context.Context, v1 int64, v2 int64, v3 int) error {
+	r0 := m.SetMemberNotifyLevelFunc.nextHook()(v0, v1, v2, v3)
+	m.SetMemberNotifyLevelFunc.appendCall(OrgsStoreSetMemberNotifyLevelFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetMemberNotifyLevel
+// method of the parent MockOrgsStore instance is invoked and the hook queue
+// is empty.
+func (f *OrgsStoreSetMemberNotifyLevelFunc) SetDefaultHook(hook func(context.Context, int64, int64, int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetMemberNotifyLevel method of the parent MockOrgsStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *OrgsStoreSetMemberNotifyLevelFunc) PushHook(hook func(context.Context, int64, int64, int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreSetMemberNotifyLevelFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64, int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreSetMemberNotifyLevelFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64, int) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreSetMemberNotifyLevelFunc) nextHook() func(context.Context, int64, int64, int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreSetMemberNotifyLevelFunc) appendCall(r0 OrgsStoreSetMemberNotifyLevelFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreSetMemberNotifyLevelFuncCall
+// objects describing the invocations of this function.
+func (f *OrgsStoreSetMemberNotifyLevelFunc) History() []OrgsStoreSetMemberNotifyLevelFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreSetMemberNotifyLevelFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreSetMemberNotifyLevelFuncCall is an object that describes an
+// invocation of method SetMemberNotifyLevel on an instance of
+// MockOrgsStore.
+type OrgsStoreSetMemberNotifyLevelFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreSetMemberNotifyLevelFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreSetMemberNotifyLevelFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreSetMemberVisibilityFunc describes the behavior when the
+// SetMemberVisibility method of the parent MockOrgsStore instance is
+// invoked.
+type OrgsStoreSetMemberVisibilityFunc struct {
+	defaultHook func(context.Context, int64, int64, bool) error
+	hooks       []func(context.Context, int64, int64, bool) error
+	history     []OrgsStoreSetMemberVisibilityFuncCall
+	mutex       sync.Mutex
+}
+
+// SetMemberVisibility delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) SetMemberVisibility(v0 context.Context, v1 int64, v2 int64, v3 bool) error {
+	r0 := m.SetMemberVisibilityFunc.nextHook()(v0, v1, v2, v3)
+	m.SetMemberVisibilityFunc.appendCall(OrgsStoreSetMemberVisibilityFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetMemberVisibility
+// method of the parent MockOrgsStore instance is invoked and the hook queue
+// is empty.
+func (f *OrgsStoreSetMemberVisibilityFunc) SetDefaultHook(hook func(context.Context, int64, int64, bool) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetMemberVisibility method of the parent MockOrgsStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *OrgsStoreSetMemberVisibilityFunc) PushHook(hook func(context.Context, int64, int64, bool) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreSetMemberVisibilityFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64, bool) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreSetMemberVisibilityFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64, bool) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreSetMemberVisibilityFunc) nextHook() func(context.Context, int64, int64, bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreSetMemberVisibilityFunc) appendCall(r0 OrgsStoreSetMemberVisibilityFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreSetMemberVisibilityFuncCall
+// objects describing the invocations of this function.
+func (f *OrgsStoreSetMemberVisibilityFunc) History() []OrgsStoreSetMemberVisibilityFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreSetMemberVisibilityFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreSetMemberVisibilityFuncCall is an object that describes an
+// invocation of method SetMemberVisibility on an instance of MockOrgsStore.
+type OrgsStoreSetMemberVisibilityFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreSetMemberVisibilityFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreSetMemberVisibilityFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreSetOwnerFunc describes the behavior when the SetOwner method of
+// the parent MockOrgsStore instance is invoked.
+type OrgsStoreSetOwnerFunc struct {
+	defaultHook func(context.Context, int64, int64, bool) error
+	hooks       []func(context.Context, int64, int64, bool) error
+	history     []OrgsStoreSetOwnerFuncCall
+	mutex       sync.Mutex
+}
+
+// SetOwner delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockOrgsStore) SetOwner(v0 context.Context, v1 int64, v2 int64, v3 bool) error {
+	r0 := m.SetOwnerFunc.nextHook()(v0, v1, v2, v3)
+	m.SetOwnerFunc.appendCall(OrgsStoreSetOwnerFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetOwner method of
+// the parent MockOrgsStore instance is invoked and the hook queue is empty.
+func (f *OrgsStoreSetOwnerFunc) SetDefaultHook(hook func(context.Context, int64, int64, bool) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetOwner method of the parent MockOrgsStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *OrgsStoreSetOwnerFunc) PushHook(hook func(context.Context, int64, int64, bool) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreSetOwnerFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64, bool) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreSetOwnerFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64, bool) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreSetOwnerFunc) nextHook() func(context.Context, int64, int64, bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreSetOwnerFunc) appendCall(r0 OrgsStoreSetOwnerFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreSetOwnerFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreSetOwnerFunc) History() []OrgsStoreSetOwnerFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreSetOwnerFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreSetOwnerFuncCall is an object that describes an invocation of
+// method SetOwner on an instance of MockOrgsStore.
+type OrgsStoreSetOwnerFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreSetOwnerFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreSetOwnerFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreSetPinnedReposFunc describes the behavior when the
+// SetPinnedRepos method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreSetPinnedReposFunc struct {
+	defaultHook func(context.Context, int64, []int64) error
+	hooks       []func(context.Context, int64, []int64) error
+	history     []OrgsStoreSetPinnedReposFuncCall
+	mutex       sync.Mutex
+}
+
+// SetPinnedRepos delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) SetPinnedRepos(v0 context.Context, v1 int64, v2 []int64) error {
+	r0 := m.SetPinnedReposFunc.nextHook()(v0, v1, v2)
+	m.SetPinnedReposFunc.appendCall(OrgsStoreSetPinnedReposFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetPinnedRepos
+// method of the parent MockOrgsStore instance is invoked and the hook queue
+// is empty.
+func (f *OrgsStoreSetPinnedReposFunc) SetDefaultHook(hook func(context.Context, int64, []int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetPinnedRepos method of the parent MockOrgsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *OrgsStoreSetPinnedReposFunc) PushHook(hook func(context.Context, int64, []int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreSetPinnedReposFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, []int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreSetPinnedReposFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, []int64) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreSetPinnedReposFunc) nextHook() func(context.Context, int64, []int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreSetPinnedReposFunc) appendCall(r0 OrgsStoreSetPinnedReposFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreSetPinnedReposFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreSetPinnedReposFunc) History() []OrgsStoreSetPinnedReposFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreSetPinnedReposFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreSetPinnedReposFuncCall is an object that describes an invocation
+// of method SetPinnedRepos on an instance of MockOrgsStore.
+type OrgsStoreSetPinnedReposFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreSetPinnedReposFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreSetPinnedReposFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreSetRepoCreatePermissionFunc describes the behavior when the
+// SetRepoCreatePermission method of the parent MockOrgsStore instance is
+// invoked.
+type OrgsStoreSetRepoCreatePermissionFunc struct {
+	defaultHook func(context.Context, int64, db.RepoCreatePermission) error
+	hooks       []func(context.Context, int64, db.RepoCreatePermission) error
+	history     []OrgsStoreSetRepoCreatePermissionFuncCall
+	mutex       sync.Mutex
+}
+
+// SetRepoCreatePermission delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) SetRepoCreatePermission(v0 context.Context, v1 int64, v2 db.RepoCreatePermission) error {
+	r0 := m.SetRepoCreatePermissionFunc.nextHook()(v0, v1, v2)
+	m.SetRepoCreatePermissionFunc.appendCall(OrgsStoreSetRepoCreatePermissionFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// SetRepoCreatePermission method of the parent MockOrgsStore instance is
+// invoked and the hook queue is empty.
+func (f *OrgsStoreSetRepoCreatePermissionFunc) SetDefaultHook(hook func(context.Context, int64, db.RepoCreatePermission) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetRepoCreatePermission method of the parent MockOrgsStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *OrgsStoreSetRepoCreatePermissionFunc) PushHook(hook func(context.Context, int64, db.RepoCreatePermission) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreSetRepoCreatePermissionFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, db.RepoCreatePermission) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreSetRepoCreatePermissionFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, db.RepoCreatePermission) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreSetRepoCreatePermissionFunc) nextHook() func(context.Context, int64, db.RepoCreatePermission) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreSetRepoCreatePermissionFunc) appendCall(r0 OrgsStoreSetRepoCreatePermissionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreSetRepoCreatePermissionFuncCall
+// objects describing the invocations of this function.
+func (f *OrgsStoreSetRepoCreatePermissionFunc) History() []OrgsStoreSetRepoCreatePermissionFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreSetRepoCreatePermissionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreSetRepoCreatePermissionFuncCall is an object that describes an
+// invocation of method SetRepoCreatePermission on an instance of
+// MockOrgsStore.
+type OrgsStoreSetRepoCreatePermissionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 db.RepoCreatePermission
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreSetRepoCreatePermissionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreSetRepoCreatePermissionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreSetRequireTwoFactorFunc describes the behavior when the
+// SetRequireTwoFactor method of the parent MockOrgsStore instance is
+// invoked.
+type OrgsStoreSetRequireTwoFactorFunc struct {
+	defaultHook func(context.Context, int64, bool) error
+	hooks       []func(context.Context, int64, bool) error
+	history     []OrgsStoreSetRequireTwoFactorFuncCall
+	mutex       sync.Mutex
+}
+
+// SetRequireTwoFactor delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) SetRequireTwoFactor(v0 context.Context, v1 int64, v2 bool) error {
+	r0 := m.SetRequireTwoFactorFunc.nextHook()(v0, v1, v2)
+	m.SetRequireTwoFactorFunc.appendCall(OrgsStoreSetRequireTwoFactorFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetRequireTwoFactor
+// method of the parent MockOrgsStore instance is invoked and the hook queue
+// is empty.
+func (f *OrgsStoreSetRequireTwoFactorFunc) SetDefaultHook(hook func(context.Context, int64, bool) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetRequireTwoFactor method of the parent MockOrgsStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *OrgsStoreSetRequireTwoFactorFunc) PushHook(hook func(context.Context, int64, bool) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreSetRequireTwoFactorFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreSetRequireTwoFactorFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, bool) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreSetRequireTwoFactorFunc) nextHook() func(context.Context, int64, bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreSetRequireTwoFactorFunc) appendCall(r0 OrgsStoreSetRequireTwoFactorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreSetRequireTwoFactorFuncCall
+// objects describing the invocations of this function.
+func (f *OrgsStoreSetRequireTwoFactorFunc) History() []OrgsStoreSetRequireTwoFactorFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreSetRequireTwoFactorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreSetRequireTwoFactorFuncCall is an object that describes an
+// invocation of method SetRequireTwoFactor on an instance of MockOrgsStore.
+type OrgsStoreSetRequireTwoFactorFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreSetRequireTwoFactorFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreSetRequireTwoFactorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreSetVerifiedFunc describes the behavior when the SetVerified
+// method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreSetVerifiedFunc struct {
+	defaultHook func(context.Context, int64, bool) error
+	hooks       []func(context.Context, int64, bool) error
+	history     []OrgsStoreSetVerifiedFuncCall
+	mutex       sync.Mutex
+}
+
+// SetVerified delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockOrgsStore) SetVerified(v0 context.Context, v1 int64, v2 bool) error {
+	r0 := m.SetVerifiedFunc.nextHook()(v0, v1, v2)
+	m.SetVerifiedFunc.appendCall(OrgsStoreSetVerifiedFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetVerified method
+// of the parent MockOrgsStore instance is invoked and the hook queue is
+// empty.
+func (f *OrgsStoreSetVerifiedFunc) SetDefaultHook(hook func(context.Context, int64, bool) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetVerified method of the parent MockOrgsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *OrgsStoreSetVerifiedFunc) PushHook(hook func(context.Context, int64, bool) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreSetVerifiedFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreSetVerifiedFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, bool) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreSetVerifiedFunc) nextHook() func(context.Context, int64, bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreSetVerifiedFunc) appendCall(r0 OrgsStoreSetVerifiedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreSetVerifiedFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreSetVerifiedFunc) History() []OrgsStoreSetVerifiedFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreSetVerifiedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreSetVerifiedFuncCall is an object that describes an invocation of
+// method SetVerified on an instance of MockOrgsStore.
+type OrgsStoreSetVerifiedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreSetVerifiedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreSetVerifiedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreStreamMembersFunc describes the behavior when the StreamMembers
+// method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreStreamMembersFunc struct {
+	defaultHook func(context.Context, int64, func(*db.OrgMemberWithRole) error) error
+	hooks       []func(context.Context, int64, func(*db.OrgMemberWithRole) error) error
+	history     []OrgsStoreStreamMembersFuncCall
+	mutex       sync.Mutex
+}
+
+// StreamMembers delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockOrgsStore) StreamMembers(v0 context.Context, v1 int64, v2 func(*db.OrgMemberWithRole) error) error {
+	r0 := m.StreamMembersFunc.nextHook()(v0, v1, v2)
+	m.StreamMembersFunc.appendCall(OrgsStoreStreamMembersFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the StreamMembers method
+// of the parent MockOrgsStore instance is invoked and the hook queue is
+// empty.
+func (f *OrgsStoreStreamMembersFunc) SetDefaultHook(hook func(context.Context, int64, func(*db.OrgMemberWithRole) error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// StreamMembers method of the parent MockOrgsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *OrgsStoreStreamMembersFunc) PushHook(hook func(context.Context, int64, func(*db.OrgMemberWithRole) error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreStreamMembersFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, func(*db.OrgMemberWithRole) error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreStreamMembersFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, func(*db.OrgMemberWithRole) error) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreStreamMembersFunc) nextHook() func(context.Context, int64, func(*db.OrgMemberWithRole) error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreStreamMembersFunc) appendCall(r0 OrgsStoreStreamMembersFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreStreamMembersFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreStreamMembersFunc) History() []OrgsStoreStreamMembersFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreStreamMembersFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreStreamMembersFuncCall is an object that describes an invocation
+// of method StreamMembers on an instance of MockOrgsStore.
+type OrgsStoreStreamMembersFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 func(*db.OrgMemberWithRole) error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreStreamMembersFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreStreamMembersFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// OrgsStoreTransferOwnershipFunc describes the behavior when the
+// TransferOwnership method of the parent MockOrgsStore instance is invoked.
+type OrgsStoreTransferOwnershipFunc struct {
+	defaultHook func(context.Context, int64, int64, db.TransferOptions) error
+	hooks       []func(context.Context, int64, int64, db.TransferOptions) error
+	history     []OrgsStoreTransferOwnershipFuncCall
+	mutex       sync.Mutex
+}
+
+// TransferOwnership delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockOrgsStore) TransferOwnership(v0 context.Context, v1 int64, v2 int64, v3 db.TransferOptions) error {
+	r0 := m.TransferOwnershipFunc.nextHook()(v0, v1, v2, v3)
+	m.TransferOwnershipFunc.appendCall(OrgsStoreTransferOwnershipFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the TransferOwnership
+// method of the parent MockOrgsStore instance is invoked and the hook queue
+// is empty.
+func (f *OrgsStoreTransferOwnershipFunc) SetDefaultHook(hook func(context.Context, int64, int64, db.TransferOptions) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// TransferOwnership method of the parent MockOrgsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *OrgsStoreTransferOwnershipFunc) PushHook(hook func(context.Context, int64, int64, db.TransferOptions) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgsStoreTransferOwnershipFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64, db.TransferOptions) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgsStoreTransferOwnershipFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64, db.TransferOptions) error {
+		return r0
+	})
+}
+
+func (f *OrgsStoreTransferOwnershipFunc) nextHook() func(context.Context, int64, int64, db.TransferOptions) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgsStoreTransferOwnershipFunc) appendCall(r0 OrgsStoreTransferOwnershipFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgsStoreTransferOwnershipFuncCall objects
+// describing the invocations of this function.
+func (f *OrgsStoreTransferOwnershipFunc) History() []OrgsStoreTransferOwnershipFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgsStoreTransferOwnershipFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgsStoreTransferOwnershipFuncCall is an object that describes an
+// invocation of method TransferOwnership on an instance of MockOrgsStore.
+type OrgsStoreTransferOwnershipFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 db.TransferOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgsStoreTransferOwnershipFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgsStoreTransferOwnershipFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
@@ -6,6 +6,7 @@ package org
 
 import (
 	"net/http"
+	"strings"
 
 	api "github.com/gogs/go-gogs-client"
 
@@ -20,17 +21,19 @@ func CreateOrgForUser(c *context.APIContext, apiForm api.CreateOrgOption, user *
 		return
 	}
 
-	org := &db.User{
-		Name:        apiForm.UserName,
-		FullName:    apiForm.FullName,
-		Description: apiForm.Description,
-		Website:     apiForm.Website,
-		Location:    apiForm.Location,
-		IsActive:    true,
-		Type:        db.UserTypeOrganization,
-	}
-	if err := db.CreateOrganization(org, user); err != nil {
-		if db.IsErrUserAlreadyExist(err) ||
+	org, err := db.Orgs.Create(
+		c.Req.Context(),
+		apiForm.UserName,
+		user.ID,
+		db.CreateOrgOptions{
+			FullName:    apiForm.FullName,
+			Description: apiForm.Description,
+			Website:     apiForm.Website,
+			Location:    apiForm.Location,
+		},
+	)
+	if err != nil {
+		if db.IsErrOrgAlreadyExist(err) ||
 			db.IsErrNameNotAllowed(err) {
 			c.ErrorStatus(http.StatusUnprocessableEntity, err)
 		} else {
@@ -43,7 +46,7 @@ func CreateOrgForUser(c *context.APIContext, apiForm api.CreateOrgOption, user *
 }
 
 func listUserOrgs(c *context.APIContext, u *db.User, all bool) {
-	orgs, err := db.Orgs.List(
+	orgs, err := db.Orgs.ListOrgsWithRole(
 		c.Req.Context(),
 		db.ListOrgsOptions{
 			MemberID:              u.ID,
@@ -55,9 +58,19 @@ func listUserOrgs(c *context.APIContext, u *db.User, all bool) {
 		return
 	}
 
-	apiOrgs := make([]*api.Organization, len(orgs))
+	// Membership flags are only revealed to the user themselves and site admins.
+	if c.IsLogged && (c.User.ID == u.ID || c.User.IsAdmin) {
+		apiOrgs := make([]*convert.OrganizationWithRole, len(orgs))
+		for i := range orgs {
+			apiOrgs[i] = convert.ToOrganizationWithRole(orgs[i])
+		}
+		c.JSONSuccess(&apiOrgs)
+		return
+	}
+
+	apiOrgs := make([]*convert.Organization, len(orgs))
 	for i := range orgs {
-		apiOrgs[i] = convert.ToOrganization(orgs[i])
+		apiOrgs[i] = convert.ToOrganization(orgs[i].Organization)
 	}
 	c.JSONSuccess(&apiOrgs)
 }
@@ -84,7 +97,7 @@ func Get(c *context.APIContext) {
 
 func Edit(c *context.APIContext, form api.EditOrgOption) {
 	org := c.Org.Organization
-	if !org.IsOwnedBy(c.User.ID) {
+	if !db.Orgs.IsOwnedBy(c.Req.Context(), org.ID, c.User.ID) {
 		c.Status(http.StatusForbidden)
 		return
 	}
@@ -104,10 +117,125 @@ func Edit(c *context.APIContext, form api.EditOrgOption) {
 		return
 	}
 
-	org, err = db.GetOrgByName(org.Name)
+	org, err = db.Orgs.GetByName(c.Req.Context(), org.Name)
 	if err != nil {
 		c.Error(err, "get organization")
 		return
 	}
 	c.JSONSuccess(convert.ToOrganization(org))
 }
+
+// GET /orgs/:orgname/pinned
+func ListPinnedRepos(c *context.APIContext) {
+	org := c.Org.Organization
+	repos, err := db.Orgs.ListPinnedRepos(c.Req.Context(), org.ID)
+	if err != nil {
+		c.Error(err, "list pinned repositories")
+		return
+	}
+
+	apiRepos := make([]*api.Repository, 0, len(repos))
+	for _, repo := range repos {
+		if !db.Perms.Authorize(c.Req.Context(), c.UserID(), repo.ID, db.AccessModeRead,
+			db.AccessModeOptions{
+				OwnerID: repo.OwnerID,
+				Private: repo.IsPrivate,
+			},
+		) {
+			continue
+		}
+		repo.Owner = org
+		apiRepos = append(apiRepos, repo.APIFormatLegacy(nil))
+	}
+	c.JSONSuccess(&apiRepos)
+}
+
+// SetPinnedReposRequest is the API message for setting pinned repositories of
+// an organization.
+type SetPinnedReposRequest struct {
+	RepoIDs []int64 `json:"repo_ids"`
+}
+
+// PUT /orgs/:orgname/pinned
+func SetPinnedRepos(c *context.APIContext, r SetPinnedReposRequest) {
+	org := c.Org.Organization
+	if !db.Orgs.IsOwnedBy(c.Req.Context(), org.ID, c.User.ID) {
+		c.Status(http.StatusForbidden)
+		return
+	}
+
+	err := db.Orgs.SetPinnedRepos(c.Req.Context(), org.ID, r.RepoIDs)
+	if err != nil {
+		if db.IsErrInvalidPinnedRepo(err) {
+			c.ErrorStatus(http.StatusUnprocessableEntity, err)
+		} else {
+			c.Error(err, "set pinned repositories")
+		}
+		return
+	}
+	ListPinnedRepos(c)
+}
+
+// GET /orgs/:orgname/labels
+func ListDefaultLabels(c *context.APIContext) {
+	labels, err := db.Orgs.GetDefaultLabels(c.Req.Context(), c.Org.Organization.ID)
+	if err != nil {
+		c.Error(err, "get default labels")
+		return
+	}
+
+	apiLabels := make([]*api.Label, len(labels))
+	for i := range labels {
+		apiLabels[i] = &api.Label{
+			ID:    labels[i].ID,
+			Name:  labels[i].Name,
+			Color: strings.TrimLeft(labels[i].Color, "#"),
+		}
+	}
+	c.JSONSuccess(&apiLabels)
+}
+
+// SetDefaultLabelsRequest is the API message for setting issue label templates
+// of an organization.
+type SetDefaultLabelsRequest struct {
+	Labels []api.CreateLabelOption `json:"labels"`
+}
+
+// PUT /orgs/:orgname/labels
+func SetDefaultLabels(c *context.APIContext, r SetDefaultLabelsRequest) {
+	org := c.Org.Organization
+	if !db.Orgs.IsOwnedBy(c.Req.Context(), org.ID, c.User.ID) {
+		c.Status(http.StatusForbidden)
+		return
+	}
+
+	labels := make([]*db.OrgLabel, len(r.Labels))
+	for i := range r.Labels {
+		labels[i] = &db.OrgLabel{
+			Name:  r.Labels[i].Name,
+			Color: r.Labels[i].Color,
+		}
+	}
+	err := db.Orgs.SetDefaultLabels(c.Req.Context(), org.ID, labels)
+	if err != nil {
+		c.Error(err, "set default labels")
+		return
+	}
+	ListDefaultLabels(c)
+}
+
+// POST /orgs/:orgname/labels/apply
+func ApplyDefaultLabels(c *context.APIContext) {
+	org := c.Org.Organization
+	if !db.Orgs.IsOwnedBy(c.Req.Context(), org.ID, c.User.ID) {
+		c.Status(http.StatusForbidden)
+		return
+	}
+
+	err := db.Orgs.ApplyDefaultLabelsToAll(c.Req.Context(), org.ID)
+	if err != nil {
+		c.Error(err, "apply default labels to all repositories")
+		return
+	}
+	c.NoContent()
+}
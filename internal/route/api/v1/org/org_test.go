@@ -0,0 +1,96 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package org
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/macaron.v1"
+
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/db"
+)
+
+func Test_listUserOrgs(t *testing.T) {
+	mockOrgsStore := NewMockOrgsStore()
+	mockOrgsStore.ListOrgsWithRoleFunc.SetDefaultReturn(
+		[]*db.OrgWithRole{
+			{
+				Organization: &db.Organization{ID: 2, Name: "org1"},
+				IsOwner:      true,
+				IsPublic:     true,
+			},
+		},
+		nil,
+	)
+	db.SetMockOrgsStore(t, mockOrgsStore)
+
+	alice := &db.User{ID: 1, Name: "alice"}
+	tests := []struct {
+		name        string
+		viewer      *db.User
+		expWithRole bool
+	}{
+		{
+			name: "anonymous",
+		},
+		{
+			name:   "another user",
+			viewer: &db.User{ID: 3, Name: "bob"},
+		},
+		{
+			name:        "the user themselves",
+			viewer:      alice,
+			expWithRole: true,
+		},
+		{
+			name:        "site admin",
+			viewer:      &db.User{ID: 4, Name: "cindy", IsAdmin: true},
+			expWithRole: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := macaron.New()
+			m.Use(macaron.Renderer())
+			m.Get("/", func(c *macaron.Context) {
+				listUserOrgs(
+					&context.APIContext{
+						Context: &context.Context{
+							Context:  c,
+							User:     test.viewer,
+							IsLogged: test.viewer != nil,
+						},
+					},
+					alice,
+					false,
+				)
+			})
+
+			r, err := http.NewRequest("GET", "/", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			m.ServeHTTP(rr, r)
+			assert.Equal(t, http.StatusOK, rr.Code)
+
+			body, err := io.ReadAll(rr.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(body), `"username":"org1"`)
+			if test.expWithRole {
+				assert.Contains(t, string(body), `"is_owner":true`)
+				assert.Contains(t, string(body), `"is_public":true`)
+			} else {
+				assert.NotContains(t, string(body), "is_owner")
+				assert.NotContains(t, string(body), "is_public")
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ package repo
 
 import (
 	"net/http"
+	"path"
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
@@ -49,15 +50,20 @@ func CreateHook(c *context.APIContext, form api.CreateHookOption) {
 		c.ErrorStatus(http.StatusUnprocessableEntity, errors.New("Invalid content type."))
 		return
 	}
+	if _, err := path.Match(form.Config["branch_filter"], ""); err != nil {
+		c.ErrorStatus(http.StatusUnprocessableEntity, errors.New("Invalid branch filter."))
+		return
+	}
 
 	if len(form.Events) == 0 {
 		form.Events = []string{"push"}
 	}
 	w := &db.Webhook{
-		RepoID:      c.Repo.Repository.ID,
-		URL:         form.Config["url"],
-		ContentType: db.ToHookContentType(form.Config["content_type"]),
-		Secret:      form.Config["secret"],
+		RepoID:       c.Repo.Repository.ID,
+		URL:          form.Config["url"],
+		ContentType:  db.ToHookContentType(form.Config["content_type"]),
+		Secret:       form.Config["secret"],
+		BranchFilter: form.Config["branch_filter"],
 		HookEvent: &db.HookEvent{
 			ChooseEvents: true,
 			HookEvents: db.HookEvents{
@@ -123,6 +129,13 @@ func EditHook(c *context.APIContext, form api.EditHookOption) {
 			}
 			w.ContentType = db.ToHookContentType(ct)
 		}
+		if filter, ok := form.Config["branch_filter"]; ok {
+			if _, err := path.Match(filter, ""); err != nil {
+				c.ErrorStatus(http.StatusUnprocessableEntity, errors.New("Invalid branch filter."))
+				return
+			}
+			w.BranchFilter = filter
+		}
 
 		if w.HookTaskType == db.SLACK {
 			if channel, ok := form.Config["channel"]; ok {
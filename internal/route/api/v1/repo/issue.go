@@ -17,7 +17,7 @@ import (
 )
 
 func listIssues(c *context.APIContext, opts *db.IssuesOptions) {
-	issues, err := db.Issues(opts)
+	issues, err := db.ListIssues(opts)
 	if err != nil {
 		c.Error(err, "list issues")
 		return
@@ -100,7 +100,11 @@ func CreateIssue(c *context.APIContext, form api.CreateIssueOption) {
 	}
 
 	if err := db.NewIssue(c.Repo.Repository, issue, form.Labels, nil); err != nil {
-		c.Error(err, "new issue")
+		if db.IsErrIssuesDisabled(err) {
+			c.ErrorStatus(http.StatusForbidden, err)
+		} else {
+			c.Error(err, "new issue")
+		}
 		return
 	}
 
@@ -142,9 +146,8 @@ func EditIssue(c *context.APIContext, form api.EditIssueOption) {
 
 	if c.Repo.IsWriter() && form.Assignee != nil &&
 		(issue.Assignee == nil || issue.Assignee.LowerName != strings.ToLower(*form.Assignee)) {
-		if *form.Assignee == "" {
-			issue.AssigneeID = 0
-		} else {
+		var assigneeID int64
+		if *form.Assignee != "" {
 			assignee, err := db.Users.GetByUsername(c.Req.Context(), *form.Assignee)
 			if err != nil {
 				if db.IsErrUserNotExist(err) {
@@ -154,11 +157,11 @@ func EditIssue(c *context.APIContext, form api.EditIssueOption) {
 				}
 				return
 			}
-			issue.AssigneeID = assignee.ID
+			assigneeID = assignee.ID
 		}
 
-		if err = db.UpdateIssueUserByAssignee(issue); err != nil {
-			c.Error(err, "update issue user by assignee")
+		if err = issue.ChangeAssignee(c.User, assigneeID); err != nil {
+			c.Error(err, "change assignee")
 			return
 		}
 	}
@@ -0,0 +1,87 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	api "github.com/gogs/go-gogs-client"
+
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/db"
+)
+
+func toAPIUsers(users []*db.User) []*api.User {
+	apiUsers := make([]*api.User, len(users))
+	for i := range users {
+		apiUsers[i] = users[i].APIFormat()
+	}
+	return apiUsers
+}
+
+// GET /repos/:owner/:reponame/issues/:index/assignees
+func ListIssueAssignees(c *context.APIContext) {
+	issue, err := db.GetIssueByIndex(c.Repo.Repository.ID, c.ParamsInt64(":index"))
+	if err != nil {
+		c.NotFoundOrError(err, "get issue by index")
+		return
+	}
+
+	assignees, err := db.Issues.ListAssignees(c.Req.Context(), issue.ID)
+	if err != nil {
+		c.Error(err, "list assignees")
+		return
+	}
+	c.JSONSuccess(toAPIUsers(assignees))
+}
+
+// SetIssueAssigneesRequest is the API message for replacing assignees of an
+// issue.
+type SetIssueAssigneesRequest struct {
+	Assignees []string `json:"assignees"`
+}
+
+// PUT /repos/:owner/:reponame/issues/:index/assignees
+func SetIssueAssignees(c *context.APIContext, r SetIssueAssigneesRequest) {
+	issue, err := db.GetIssueByIndex(c.Repo.Repository.ID, c.ParamsInt64(":index"))
+	if err != nil {
+		c.NotFoundOrError(err, "get issue by index")
+		return
+	}
+
+	candidates, err := c.Repo.Repository.GetAssignees()
+	if err != nil {
+		c.Error(err, "get assignees")
+		return
+	}
+	candidateIDs := make(map[string]int64, len(candidates))
+	for _, u := range candidates {
+		candidateIDs[u.LowerName] = u.ID
+	}
+
+	assigneeIDs := make([]int64, 0, len(r.Assignees))
+	for _, name := range r.Assignees {
+		id, ok := candidateIDs[strings.ToLower(name)]
+		if !ok {
+			c.ErrorStatus(http.StatusUnprocessableEntity, fmt.Errorf("assignee does not exist or cannot be assigned: [name: %s]", name))
+			return
+		}
+		assigneeIDs = append(assigneeIDs, id)
+	}
+
+	if err = issue.ChangeAssignees(c.User, assigneeIDs); err != nil {
+		c.Error(err, "change assignees")
+		return
+	}
+
+	assignees, err := db.Issues.ListAssignees(c.Req.Context(), issue.ID)
+	if err != nil {
+		c.Error(err, "list assignees")
+		return
+	}
+	c.JSONSuccess(toAPIUsers(assignees))
+}
@@ -40,7 +40,7 @@ func Search(c *context.APIContext) {
 				})
 				return
 			}
-			if u.IsOrganization() && u.IsOwnedBy(c.User.ID) {
+			if u.IsOrganization() && db.Orgs.IsOwnedBy(c.Req.Context(), u.ID, c.User.ID) {
 				opts.Private = true
 			}
 			// FIXME: how about collaborators?
@@ -191,14 +191,27 @@ func Create(c *context.APIContext, opt api.CreateRepoOption) {
 }
 
 func CreateOrgRepo(c *context.APIContext, opt api.CreateRepoOption) {
-	org, err := db.GetOrgByName(c.Params(":org"))
+	org, err := db.Orgs.GetByName(c.Req.Context(), c.Params(":org"))
 	if err != nil {
 		c.NotFoundOrError(err, "get organization by name")
 		return
 	}
 
-	if !org.IsOwnedBy(c.User.ID) {
-		c.ErrorStatus(http.StatusForbidden, errors.New("Given user is not owner of organization."))
+	orgs, err := db.Orgs.ListOrgsWithRepoCreatePermission(c.Req.Context(), c.User.ID)
+	if err != nil {
+		c.Error(err, "list organizations with repository creation permission")
+		return
+	}
+
+	canCreate := c.User.IsAdmin
+	for _, o := range orgs {
+		if o.ID == org.ID {
+			canCreate = true
+			break
+		}
+	}
+	if !canCreate {
+		c.ErrorStatus(http.StatusForbidden, errors.New("Given user is not allowed to create repositories in the organization."))
 		return
 	}
 	CreateUserRepo(c, org, opt)
@@ -231,7 +244,7 @@ func Migrate(c *context.APIContext, f form.MigrateRepo) {
 
 	if ctxUser.IsOrganization() && !c.User.IsAdmin {
 		// Check ownership of organization.
-		if !ctxUser.IsOwnedBy(c.User.ID) {
+		if !db.Orgs.IsOwnedBy(c.Req.Context(), ctxUser.ID, c.User.ID) {
 			c.ErrorStatus(http.StatusForbidden, errors.New("Given user is not owner of organization."))
 			return
 		}
@@ -325,12 +338,12 @@ func Delete(c *context.APIContext) {
 		return
 	}
 
-	if owner.IsOrganization() && !owner.IsOwnedBy(c.User.ID) {
+	if owner.IsOrganization() && !db.Orgs.IsOwnedBy(c.Req.Context(), owner.ID, c.User.ID) {
 		c.ErrorStatus(http.StatusForbidden, errors.New("Given user is not owner of organization."))
 		return
 	}
 
-	if err := db.DeleteRepository(owner.ID, repo.ID); err != nil {
+	if err := db.DeleteRepositoryWithRetention(owner.ID, repo.ID); err != nil {
 		c.Error(err, "delete repository")
 		return
 	}
@@ -375,6 +388,27 @@ func ListForks(c *context.APIContext) {
 	c.JSONSuccess(&apiForks)
 }
 
+// SetVisibilityRequest is the API message for changing visibility of a
+// repository.
+type SetVisibilityRequest struct {
+	Private bool `json:"private"`
+}
+
+// PATCH /repos/:owner/:reponame/visibility
+func SetVisibility(c *context.APIContext, r SetVisibilityRequest) {
+	repo := c.Repo.Repository
+	if repo.IsFork {
+		c.ErrorStatus(http.StatusUnprocessableEntity, errors.New("visibility of a fork always follows its base repository"))
+		return
+	}
+
+	if err := db.Repos.SetPrivate(c.Req.Context(), repo.ID, r.Private); err != nil {
+		c.Error(err, "set private")
+		return
+	}
+	c.NoContent()
+}
+
 func IssueTracker(c *context.APIContext, form api.EditIssueTrackerOption) {
 	_, repo := parseOwnerAndRepo(c)
 	if c.Written() {
@@ -105,7 +105,15 @@ func RenderUserSearch(c *context.Context, opts *UserSearchOptions) {
 	)
 
 	keyword := c.Query("q")
-	if keyword == "" {
+	if opts.Type == db.UserTypeOrganization && (!c.IsLogged || !c.User.IsAdmin) {
+		// Organizations that are effectively private to the viewer must not be
+		// listed, with or without a keyword.
+		users, count, err = db.OrgsReplica.SearchVisibleByName(c.Req.Context(), keyword, page, opts.PageSize, opts.OrderBy, c.UserID())
+		if err != nil {
+			c.Error(err, "search visible by name")
+			return
+		}
+	} else if keyword == "" {
 		users, err = opts.Ranger(c.Req.Context(), page, opts.PageSize)
 		if err != nil {
 			c.Error(err, "ranger")
@@ -115,7 +123,9 @@ func RenderUserSearch(c *context.Context, opts *UserSearchOptions) {
 	} else {
 		search := db.Users.SearchByName
 		if opts.Type == db.UserTypeOrganization {
-			search = db.Orgs.SearchByName
+			search = func(ctx gocontext.Context, keyword string, page, pageSize int, orderBy string) ([]*db.User, int64, error) {
+				return db.OrgsReplica.SearchByName(ctx, keyword, page, pageSize, orderBy, true)
+			}
 		}
 		users, count, err = search(c.Req.Context(), keyword, page, opts.PageSize, opts.OrderBy)
 		if err != nil {
@@ -419,6 +419,7 @@ func InstallPost(c *context.Context, f form.Install) {
 		// Auto-login for admin
 		_ = c.Session.Set("uid", user.ID)
 		_ = c.Session.Set("uname", user.Name)
+		_ = c.Session.Set("sessionEpoch", user.SessionEpoch)
 	}
 
 	log.Info("First-time run install finished!")
@@ -1091,6 +1091,12 @@ type MockPermsStore struct {
 	// AuthorizeFunc is an instance of a mock function object controlling
 	// the behavior of the method Authorize.
 	AuthorizeFunc *PermsStoreAuthorizeFunc
+	// HighestAccessModeFunc is an instance of a mock function object
+	// controlling the behavior of the method HighestAccessMode.
+	HighestAccessModeFunc *PermsStoreHighestAccessModeFunc
+	// RecalculateAccessesFunc is an instance of a mock function object
+	// controlling the behavior of the method RecalculateAccesses.
+	RecalculateAccessesFunc *PermsStoreRecalculateAccessesFunc
 	// SetRepoPermsFunc is an instance of a mock function object controlling
 	// the behavior of the method SetRepoPerms.
 	SetRepoPermsFunc *PermsStoreSetRepoPermsFunc
@@ -1110,6 +1116,16 @@ func NewMockPermsStore() *MockPermsStore {
 				return
 			},
 		},
+		HighestAccessModeFunc: &PermsStoreHighestAccessModeFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 db.AccessMode, r1 error) {
+				return
+			},
+		},
+		RecalculateAccessesFunc: &PermsStoreRecalculateAccessesFunc{
+			defaultHook: func(context.Context, int64) (r0 error) {
+				return
+			},
+		},
 		SetRepoPermsFunc: &PermsStoreSetRepoPermsFunc{
 			defaultHook: func(context.Context, int64, map[int64]db.AccessMode) (r0 error) {
 				return
@@ -1132,6 +1148,16 @@ func NewStrictMockPermsStore() *MockPermsStore {
 				panic("unexpected invocation of MockPermsStore.Authorize")
 			},
 		},
+		HighestAccessModeFunc: &PermsStoreHighestAccessModeFunc{
+			defaultHook: func(context.Context, int64, int64) (db.AccessMode, error) {
+				panic("unexpected invocation of MockPermsStore.HighestAccessMode")
+			},
+		},
+		RecalculateAccessesFunc: &PermsStoreRecalculateAccessesFunc{
+			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockPermsStore.RecalculateAccesses")
+			},
+		},
 		SetRepoPermsFunc: &PermsStoreSetRepoPermsFunc{
 			defaultHook: func(context.Context, int64, map[int64]db.AccessMode) error {
 				panic("unexpected invocation of MockPermsStore.SetRepoPerms")
@@ -1150,6 +1176,12 @@ func NewMockPermsStoreFrom(i db.PermsStore) *MockPermsStore {
 		AuthorizeFunc: &PermsStoreAuthorizeFunc{
 			defaultHook: i.Authorize,
 		},
+		HighestAccessModeFunc: &PermsStoreHighestAccessModeFunc{
+			defaultHook: i.HighestAccessMode,
+		},
+		RecalculateAccessesFunc: &PermsStoreRecalculateAccessesFunc{
+			defaultHook: i.RecalculateAccesses,
+		},
 		SetRepoPermsFunc: &PermsStoreSetRepoPermsFunc{
 			defaultHook: i.SetRepoPerms,
 		},
@@ -1381,6 +1413,225 @@ func (c PermsStoreAuthorizeFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// PermsStoreHighestAccessModeFunc describes the behavior when the
+// HighestAccessMode method of the parent MockPermsStore instance is
+// invoked.
+type PermsStoreHighestAccessModeFunc struct {
+	defaultHook func(context.Context, int64, int64) (db.AccessMode, error)
+	hooks       []func(context.Context, int64, int64) (db.AccessMode, error)
+	history     []PermsStoreHighestAccessModeFuncCall
+	mutex       sync.Mutex
+}
+
+// HighestAccessMode delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockPermsStore) HighestAccessMode(v0 context.Context, v1 int64, v2 int64) (db.AccessMode, error) {
+	r0, r1 := m.HighestAccessModeFunc.nextHook()(v0, v1, v2)
+	m.HighestAccessModeFunc.appendCall(PermsStoreHighestAccessModeFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the HighestAccessMode
+// method of the parent MockPermsStore instance is invoked and the hook
+// queue is empty.
+func (f *PermsStoreHighestAccessModeFunc) SetDefaultHook(hook func(context.Context, int64, int64) (db.AccessMode, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// HighestAccessMode method of the parent MockPermsStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *PermsStoreHighestAccessModeFunc) PushHook(hook func(context.Context, int64, int64) (db.AccessMode, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *PermsStoreHighestAccessModeFunc) SetDefaultReturn(r0 db.AccessMode, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) (db.AccessMode, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *PermsStoreHighestAccessModeFunc) PushReturn(r0 db.AccessMode, r1 error) {
+	f.PushHook(func(context.Context, int64, int64) (db.AccessMode, error) {
+		return r0, r1
+	})
+}
+
+func (f *PermsStoreHighestAccessModeFunc) nextHook() func(context.Context, int64, int64) (db.AccessMode, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *PermsStoreHighestAccessModeFunc) appendCall(r0 PermsStoreHighestAccessModeFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of PermsStoreHighestAccessModeFuncCall objects
+// describing the invocations of this function.
+func (f *PermsStoreHighestAccessModeFunc) History() []PermsStoreHighestAccessModeFuncCall {
+	f.mutex.Lock()
+	history := make([]PermsStoreHighestAccessModeFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// PermsStoreHighestAccessModeFuncCall is an object that describes an
+// invocation of method HighestAccessMode on an instance of MockPermsStore.
+type PermsStoreHighestAccessModeFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 db.AccessMode
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c PermsStoreHighestAccessModeFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c PermsStoreHighestAccessModeFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// PermsStoreRecalculateAccessesFunc describes the behavior when the
+// RecalculateAccesses method of the parent MockPermsStore instance is
+// invoked.
+type PermsStoreRecalculateAccessesFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []PermsStoreRecalculateAccessesFuncCall
+	mutex       sync.Mutex
+}
+
+// RecalculateAccesses delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockPermsStore) RecalculateAccesses(v0 context.Context, v1 int64) error {
+	r0 := m.RecalculateAccessesFunc.nextHook()(v0, v1)
+	m.RecalculateAccessesFunc.appendCall(PermsStoreRecalculateAccessesFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the RecalculateAccesses
+// method of the parent MockPermsStore instance is invoked and the hook
+// queue is empty.
+func (f *PermsStoreRecalculateAccessesFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RecalculateAccesses method of the parent MockPermsStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *PermsStoreRecalculateAccessesFunc) PushHook(hook func(context.Context, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *PermsStoreRecalculateAccessesFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *PermsStoreRecalculateAccessesFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+func (f *PermsStoreRecalculateAccessesFunc) nextHook() func(context.Context, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *PermsStoreRecalculateAccessesFunc) appendCall(r0 PermsStoreRecalculateAccessesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of PermsStoreRecalculateAccessesFuncCall
+// objects describing the invocations of this function.
+func (f *PermsStoreRecalculateAccessesFunc) History() []PermsStoreRecalculateAccessesFuncCall {
+	f.mutex.Lock()
+	history := make([]PermsStoreRecalculateAccessesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// PermsStoreRecalculateAccessesFuncCall is an object that describes an
+// invocation of method RecalculateAccesses on an instance of
+// MockPermsStore.
+type PermsStoreRecalculateAccessesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c PermsStoreRecalculateAccessesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c PermsStoreRecalculateAccessesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // PermsStoreSetRepoPermsFunc describes the behavior when the SetRepoPerms
 // method of the parent MockPermsStore instance is invoked.
 type PermsStoreSetRepoPermsFunc struct {
@@ -1492,9 +1743,31 @@ func (c PermsStoreSetRepoPermsFuncCall) Results() []interface{} {
 // MockReposStore is a mock implementation of the ReposStore interface (from
 // the package gogs.io/gogs/internal/db) used for unit testing.
 type MockReposStore struct {
+	// AddDeployKeyFunc is an instance of a mock function object controlling
+	// the behavior of the method AddDeployKey.
+	AddDeployKeyFunc *ReposStoreAddDeployKeyFunc
+	// AddProtectedTagFunc is an instance of a mock function object
+	// controlling the behavior of the method AddProtectedTag.
+	AddProtectedTagFunc *ReposStoreAddProtectedTagFunc
+	// ApplyOrgLabelsFunc is an instance of a mock function object
+	// controlling the behavior of the method ApplyOrgLabels.
+	ApplyOrgLabelsFunc *ReposStoreApplyOrgLabelsFunc
+	// CleanupOrphanedAccessFunc is an instance of a mock function object
+	// controlling the behavior of the method CleanupOrphanedAccess.
+	CleanupOrphanedAccessFunc *ReposStoreCleanupOrphanedAccessFunc
 	// CreateFunc is an instance of a mock function object controlling the
 	// behavior of the method Create.
 	CreateFunc *ReposStoreCreateFunc
+	// CreateFromTemplateFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateFromTemplate.
+	CreateFromTemplateFunc *ReposStoreCreateFromTemplateFunc
+	// DeleteExpiredCollaboratorsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// DeleteExpiredCollaborators.
+	DeleteExpiredCollaboratorsFunc *ReposStoreDeleteExpiredCollaboratorsFunc
+	// ForkFunc is an instance of a mock function object controlling the
+	// behavior of the method Fork.
+	ForkFunc *ReposStoreForkFunc
 	// GetByCollaboratorIDFunc is an instance of a mock function object
 	// controlling the behavior of the method GetByCollaboratorID.
 	GetByCollaboratorIDFunc *ReposStoreGetByCollaboratorIDFunc
@@ -1508,18 +1781,72 @@ type MockReposStore struct {
 	// GetByNameFunc is an instance of a mock function object controlling
 	// the behavior of the method GetByName.
 	GetByNameFunc *ReposStoreGetByNameFunc
+	// GetByRedirectFunc is an instance of a mock function object
+	// controlling the behavior of the method GetByRedirect.
+	GetByRedirectFunc *ReposStoreGetByRedirectFunc
 	// HasForkedByFunc is an instance of a mock function object controlling
 	// the behavior of the method HasForkedBy.
 	HasForkedByFunc *ReposStoreHasForkedByFunc
+	// IsNameAvailableFunc is an instance of a mock function object
+	// controlling the behavior of the method IsNameAvailable.
+	IsNameAvailableFunc *ReposStoreIsNameAvailableFunc
+	// ListMirrorsToSyncFunc is an instance of a mock function object
+	// controlling the behavior of the method ListMirrorsToSync.
+	ListMirrorsToSyncFunc *ReposStoreListMirrorsToSyncFunc
+	// ListProtectedTagsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListProtectedTags.
+	ListProtectedTagsFunc *ReposStoreListProtectedTagsFunc
+	// ListTopicsFunc is an instance of a mock function object controlling
+	// the behavior of the method ListTopics.
+	ListTopicsFunc *ReposStoreListTopicsFunc
+	// ListWatchedByUserInOrgFunc is an instance of a mock function object
+	// controlling the behavior of the method ListWatchedByUserInOrg.
+	ListWatchedByUserInOrgFunc *ReposStoreListWatchedByUserInOrgFunc
 	// ListWatchesFunc is an instance of a mock function object controlling
 	// the behavior of the method ListWatches.
 	ListWatchesFunc *ReposStoreListWatchesFunc
+	// RenameFunc is an instance of a mock function object controlling the
+	// behavior of the method Rename.
+	RenameFunc *ReposStoreRenameFunc
+	// RestoreFunc is an instance of a mock function object controlling the
+	// behavior of the method Restore.
+	RestoreFunc *ReposStoreRestoreFunc
+	// SearchByTopicFunc is an instance of a mock function object
+	// controlling the behavior of the method SearchByTopic.
+	SearchByTopicFunc *ReposStoreSearchByTopicFunc
+	// SetArchivedFunc is an instance of a mock function object controlling
+	// the behavior of the method SetArchived.
+	SetArchivedFunc *ReposStoreSetArchivedFunc
+	// SetCollaboratorExpiryFunc is an instance of a mock function object
+	// controlling the behavior of the method SetCollaboratorExpiry.
+	SetCollaboratorExpiryFunc *ReposStoreSetCollaboratorExpiryFunc
+	// SetExternalTrackerFunc is an instance of a mock function object
+	// controlling the behavior of the method SetExternalTracker.
+	SetExternalTrackerFunc *ReposStoreSetExternalTrackerFunc
+	// SetMirrorSyncResultFunc is an instance of a mock function object
+	// controlling the behavior of the method SetMirrorSyncResult.
+	SetMirrorSyncResultFunc *ReposStoreSetMirrorSyncResultFunc
+	// SetPrivateFunc is an instance of a mock function object controlling
+	// the behavior of the method SetPrivate.
+	SetPrivateFunc *ReposStoreSetPrivateFunc
+	// SetTopicsFunc is an instance of a mock function object controlling
+	// the behavior of the method SetTopics.
+	SetTopicsFunc *ReposStoreSetTopicsFunc
+	// SoftDeleteFunc is an instance of a mock function object controlling
+	// the behavior of the method SoftDelete.
+	SoftDeleteFunc *ReposStoreSoftDeleteFunc
 	// StarFunc is an instance of a mock function object controlling the
 	// behavior of the method Star.
 	StarFunc *ReposStoreStarFunc
 	// TouchFunc is an instance of a mock function object controlling the
 	// behavior of the method Touch.
 	TouchFunc *ReposStoreTouchFunc
+	// TransferAllByOwnerFunc is an instance of a mock function object
+	// controlling the behavior of the method TransferAllByOwner.
+	TransferAllByOwnerFunc *ReposStoreTransferAllByOwnerFunc
+	// UpdateSizeFunc is an instance of a mock function object controlling
+	// the behavior of the method UpdateSize.
+	UpdateSizeFunc *ReposStoreUpdateSizeFunc
 	// WatchFunc is an instance of a mock function object controlling the
 	// behavior of the method Watch.
 	WatchFunc *ReposStoreWatchFunc
@@ -1529,11 +1856,46 @@ type MockReposStore struct {
 // methods return zero values for all results, unless overwritten.
 func NewMockReposStore() *MockReposStore {
 	return &MockReposStore{
+		AddDeployKeyFunc: &ReposStoreAddDeployKeyFunc{
+			defaultHook: func(context.Context, int64, string, string, bool) (r0 error) {
+				return
+			},
+		},
+		AddProtectedTagFunc: &ReposStoreAddProtectedTagFunc{
+			defaultHook: func(context.Context, int64, string, db.AccessMode) (r0 *db.ProtectedTag, r1 error) {
+				return
+			},
+		},
+		ApplyOrgLabelsFunc: &ReposStoreApplyOrgLabelsFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 error) {
+				return
+			},
+		},
+		CleanupOrphanedAccessFunc: &ReposStoreCleanupOrphanedAccessFunc{
+			defaultHook: func(context.Context) (r0 int64, r1 error) {
+				return
+			},
+		},
 		CreateFunc: &ReposStoreCreateFunc{
 			defaultHook: func(context.Context, int64, db.CreateRepoOptions) (r0 *db.Repository, r1 error) {
 				return
 			},
 		},
+		CreateFromTemplateFunc: &ReposStoreCreateFromTemplateFunc{
+			defaultHook: func(context.Context, int64, int64, db.CreateFromTemplateOptions) (r0 *db.Repository, r1 error) {
+				return
+			},
+		},
+		DeleteExpiredCollaboratorsFunc: &ReposStoreDeleteExpiredCollaboratorsFunc{
+			defaultHook: func(context.Context) (r0 error) {
+				return
+			},
+		},
+		ForkFunc: &ReposStoreForkFunc{
+			defaultHook: func(context.Context, int64, int64, db.ForkOptions) (r0 *db.Repository, r1 error) {
+				return
+			},
+		},
 		GetByCollaboratorIDFunc: &ReposStoreGetByCollaboratorIDFunc{
 			defaultHook: func(context.Context, int64, int, string) (r0 []*db.Repository, r1 error) {
 				return
@@ -1554,83 +1916,298 @@ func NewMockReposStore() *MockReposStore {
 				return
 			},
 		},
+		GetByRedirectFunc: &ReposStoreGetByRedirectFunc{
+			defaultHook: func(context.Context, int64, string) (r0 *db.Repository, r1 error) {
+				return
+			},
+		},
 		HasForkedByFunc: &ReposStoreHasForkedByFunc{
 			defaultHook: func(context.Context, int64, int64) (r0 bool) {
 				return
 			},
 		},
-		ListWatchesFunc: &ReposStoreListWatchesFunc{
-			defaultHook: func(context.Context, int64) (r0 []*db.Watch, r1 error) {
+		IsNameAvailableFunc: &ReposStoreIsNameAvailableFunc{
+			defaultHook: func(context.Context, int64, string) (r0 bool, r1 error) {
 				return
 			},
 		},
-		StarFunc: &ReposStoreStarFunc{
-			defaultHook: func(context.Context, int64, int64) (r0 error) {
+		ListMirrorsToSyncFunc: &ReposStoreListMirrorsToSyncFunc{
+			defaultHook: func(context.Context, int64, int, int) (r0 []*db.Mirror, r1 error) {
 				return
 			},
 		},
-		TouchFunc: &ReposStoreTouchFunc{
-			defaultHook: func(context.Context, int64) (r0 error) {
+		ListProtectedTagsFunc: &ReposStoreListProtectedTagsFunc{
+			defaultHook: func(context.Context, int64) (r0 []*db.ProtectedTag, r1 error) {
 				return
 			},
 		},
-		WatchFunc: &ReposStoreWatchFunc{
-			defaultHook: func(context.Context, int64, int64) (r0 error) {
+		ListTopicsFunc: &ReposStoreListTopicsFunc{
+			defaultHook: func(context.Context, int64) (r0 []*db.Topic, r1 error) {
 				return
 			},
 		},
-	}
-}
-
-// NewStrictMockReposStore creates a new mock of the ReposStore interface.
-// All methods panic on invocation, unless overwritten.
-func NewStrictMockReposStore() *MockReposStore {
-	return &MockReposStore{
-		CreateFunc: &ReposStoreCreateFunc{
-			defaultHook: func(context.Context, int64, db.CreateRepoOptions) (*db.Repository, error) {
-				panic("unexpected invocation of MockReposStore.Create")
+		ListWatchedByUserInOrgFunc: &ReposStoreListWatchedByUserInOrgFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 []*db.Repository, r1 error) {
+				return
 			},
 		},
-		GetByCollaboratorIDFunc: &ReposStoreGetByCollaboratorIDFunc{
-			defaultHook: func(context.Context, int64, int, string) ([]*db.Repository, error) {
-				panic("unexpected invocation of MockReposStore.GetByCollaboratorID")
+		ListWatchesFunc: &ReposStoreListWatchesFunc{
+			defaultHook: func(context.Context, int64) (r0 []*db.Watch, r1 error) {
+				return
 			},
 		},
-		GetByCollaboratorIDWithAccessModeFunc: &ReposStoreGetByCollaboratorIDWithAccessModeFunc{
-			defaultHook: func(context.Context, int64) (map[*db.Repository]db.AccessMode, error) {
-				panic("unexpected invocation of MockReposStore.GetByCollaboratorIDWithAccessMode")
+		RenameFunc: &ReposStoreRenameFunc{
+			defaultHook: func(context.Context, int64, string) (r0 error) {
+				return
 			},
 		},
-		GetByIDFunc: &ReposStoreGetByIDFunc{
-			defaultHook: func(context.Context, int64) (*db.Repository, error) {
-				panic("unexpected invocation of MockReposStore.GetByID")
+		RestoreFunc: &ReposStoreRestoreFunc{
+			defaultHook: func(context.Context, int64) (r0 error) {
+				return
 			},
 		},
-		GetByNameFunc: &ReposStoreGetByNameFunc{
-			defaultHook: func(context.Context, int64, string) (*db.Repository, error) {
-				panic("unexpected invocation of MockReposStore.GetByName")
+		SearchByTopicFunc: &ReposStoreSearchByTopicFunc{
+			defaultHook: func(context.Context, string, int, int) (r0 []*db.Repository, r1 int64, r2 error) {
+				return
 			},
 		},
-		HasForkedByFunc: &ReposStoreHasForkedByFunc{
-			defaultHook: func(context.Context, int64, int64) bool {
-				panic("unexpected invocation of MockReposStore.HasForkedBy")
+		SetArchivedFunc: &ReposStoreSetArchivedFunc{
+			defaultHook: func(context.Context, int64, bool) (r0 error) {
+				return
 			},
 		},
-		ListWatchesFunc: &ReposStoreListWatchesFunc{
-			defaultHook: func(context.Context, int64) ([]*db.Watch, error) {
-				panic("unexpected invocation of MockReposStore.ListWatches")
+		SetCollaboratorExpiryFunc: &ReposStoreSetCollaboratorExpiryFunc{
+			defaultHook: func(context.Context, int64, int64, int64) (r0 error) {
+				return
 			},
 		},
-		StarFunc: &ReposStoreStarFunc{
-			defaultHook: func(context.Context, int64, int64) error {
-				panic("unexpected invocation of MockReposStore.Star")
+		SetExternalTrackerFunc: &ReposStoreSetExternalTrackerFunc{
+			defaultHook: func(context.Context, int64, db.SetExternalTrackerOptions) (r0 error) {
+				return
+			},
+		},
+		SetMirrorSyncResultFunc: &ReposStoreSetMirrorSyncResultFunc{
+			defaultHook: func(context.Context, int64, bool, string) (r0 error) {
+				return
+			},
+		},
+		SetPrivateFunc: &ReposStoreSetPrivateFunc{
+			defaultHook: func(context.Context, int64, bool) (r0 error) {
+				return
+			},
+		},
+		SetTopicsFunc: &ReposStoreSetTopicsFunc{
+			defaultHook: func(context.Context, int64, []string) (r0 error) {
+				return
+			},
+		},
+		SoftDeleteFunc: &ReposStoreSoftDeleteFunc{
+			defaultHook: func(context.Context, int64) (r0 error) {
+				return
+			},
+		},
+		StarFunc: &ReposStoreStarFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 error) {
+				return
 			},
 		},
 		TouchFunc: &ReposStoreTouchFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 error) {
+				return
+			},
+		},
+		TransferAllByOwnerFunc: &ReposStoreTransferAllByOwnerFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 []*db.Repository, r1 error) {
+				return
+			},
+		},
+		UpdateSizeFunc: &ReposStoreUpdateSizeFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 error) {
+				return
+			},
+		},
+		WatchFunc: &ReposStoreWatchFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockReposStore creates a new mock of the ReposStore interface.
+// All methods panic on invocation, unless overwritten.
+func NewStrictMockReposStore() *MockReposStore {
+	return &MockReposStore{
+		AddDeployKeyFunc: &ReposStoreAddDeployKeyFunc{
+			defaultHook: func(context.Context, int64, string, string, bool) error {
+				panic("unexpected invocation of MockReposStore.AddDeployKey")
+			},
+		},
+		AddProtectedTagFunc: &ReposStoreAddProtectedTagFunc{
+			defaultHook: func(context.Context, int64, string, db.AccessMode) (*db.ProtectedTag, error) {
+				panic("unexpected invocation of MockReposStore.AddProtectedTag")
+			},
+		},
+		ApplyOrgLabelsFunc: &ReposStoreApplyOrgLabelsFunc{
+			defaultHook: func(context.Context, int64, int64) error {
+				panic("unexpected invocation of MockReposStore.ApplyOrgLabels")
+			},
+		},
+		CleanupOrphanedAccessFunc: &ReposStoreCleanupOrphanedAccessFunc{
+			defaultHook: func(context.Context) (int64, error) {
+				panic("unexpected invocation of MockReposStore.CleanupOrphanedAccess")
+			},
+		},
+		CreateFunc: &ReposStoreCreateFunc{
+			defaultHook: func(context.Context, int64, db.CreateRepoOptions) (*db.Repository, error) {
+				panic("unexpected invocation of MockReposStore.Create")
+			},
+		},
+		CreateFromTemplateFunc: &ReposStoreCreateFromTemplateFunc{
+			defaultHook: func(context.Context, int64, int64, db.CreateFromTemplateOptions) (*db.Repository, error) {
+				panic("unexpected invocation of MockReposStore.CreateFromTemplate")
+			},
+		},
+		DeleteExpiredCollaboratorsFunc: &ReposStoreDeleteExpiredCollaboratorsFunc{
+			defaultHook: func(context.Context) error {
+				panic("unexpected invocation of MockReposStore.DeleteExpiredCollaborators")
+			},
+		},
+		ForkFunc: &ReposStoreForkFunc{
+			defaultHook: func(context.Context, int64, int64, db.ForkOptions) (*db.Repository, error) {
+				panic("unexpected invocation of MockReposStore.Fork")
+			},
+		},
+		GetByCollaboratorIDFunc: &ReposStoreGetByCollaboratorIDFunc{
+			defaultHook: func(context.Context, int64, int, string) ([]*db.Repository, error) {
+				panic("unexpected invocation of MockReposStore.GetByCollaboratorID")
+			},
+		},
+		GetByCollaboratorIDWithAccessModeFunc: &ReposStoreGetByCollaboratorIDWithAccessModeFunc{
+			defaultHook: func(context.Context, int64) (map[*db.Repository]db.AccessMode, error) {
+				panic("unexpected invocation of MockReposStore.GetByCollaboratorIDWithAccessMode")
+			},
+		},
+		GetByIDFunc: &ReposStoreGetByIDFunc{
+			defaultHook: func(context.Context, int64) (*db.Repository, error) {
+				panic("unexpected invocation of MockReposStore.GetByID")
+			},
+		},
+		GetByNameFunc: &ReposStoreGetByNameFunc{
+			defaultHook: func(context.Context, int64, string) (*db.Repository, error) {
+				panic("unexpected invocation of MockReposStore.GetByName")
+			},
+		},
+		GetByRedirectFunc: &ReposStoreGetByRedirectFunc{
+			defaultHook: func(context.Context, int64, string) (*db.Repository, error) {
+				panic("unexpected invocation of MockReposStore.GetByRedirect")
+			},
+		},
+		HasForkedByFunc: &ReposStoreHasForkedByFunc{
+			defaultHook: func(context.Context, int64, int64) bool {
+				panic("unexpected invocation of MockReposStore.HasForkedBy")
+			},
+		},
+		IsNameAvailableFunc: &ReposStoreIsNameAvailableFunc{
+			defaultHook: func(context.Context, int64, string) (bool, error) {
+				panic("unexpected invocation of MockReposStore.IsNameAvailable")
+			},
+		},
+		ListMirrorsToSyncFunc: &ReposStoreListMirrorsToSyncFunc{
+			defaultHook: func(context.Context, int64, int, int) ([]*db.Mirror, error) {
+				panic("unexpected invocation of MockReposStore.ListMirrorsToSync")
+			},
+		},
+		ListProtectedTagsFunc: &ReposStoreListProtectedTagsFunc{
+			defaultHook: func(context.Context, int64) ([]*db.ProtectedTag, error) {
+				panic("unexpected invocation of MockReposStore.ListProtectedTags")
+			},
+		},
+		ListTopicsFunc: &ReposStoreListTopicsFunc{
+			defaultHook: func(context.Context, int64) ([]*db.Topic, error) {
+				panic("unexpected invocation of MockReposStore.ListTopics")
+			},
+		},
+		ListWatchedByUserInOrgFunc: &ReposStoreListWatchedByUserInOrgFunc{
+			defaultHook: func(context.Context, int64, int64) ([]*db.Repository, error) {
+				panic("unexpected invocation of MockReposStore.ListWatchedByUserInOrg")
+			},
+		},
+		ListWatchesFunc: &ReposStoreListWatchesFunc{
+			defaultHook: func(context.Context, int64) ([]*db.Watch, error) {
+				panic("unexpected invocation of MockReposStore.ListWatches")
+			},
+		},
+		RenameFunc: &ReposStoreRenameFunc{
+			defaultHook: func(context.Context, int64, string) error {
+				panic("unexpected invocation of MockReposStore.Rename")
+			},
+		},
+		RestoreFunc: &ReposStoreRestoreFunc{
+			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockReposStore.Restore")
+			},
+		},
+		SearchByTopicFunc: &ReposStoreSearchByTopicFunc{
+			defaultHook: func(context.Context, string, int, int) ([]*db.Repository, int64, error) {
+				panic("unexpected invocation of MockReposStore.SearchByTopic")
+			},
+		},
+		SetArchivedFunc: &ReposStoreSetArchivedFunc{
+			defaultHook: func(context.Context, int64, bool) error {
+				panic("unexpected invocation of MockReposStore.SetArchived")
+			},
+		},
+		SetCollaboratorExpiryFunc: &ReposStoreSetCollaboratorExpiryFunc{
+			defaultHook: func(context.Context, int64, int64, int64) error {
+				panic("unexpected invocation of MockReposStore.SetCollaboratorExpiry")
+			},
+		},
+		SetExternalTrackerFunc: &ReposStoreSetExternalTrackerFunc{
+			defaultHook: func(context.Context, int64, db.SetExternalTrackerOptions) error {
+				panic("unexpected invocation of MockReposStore.SetExternalTracker")
+			},
+		},
+		SetMirrorSyncResultFunc: &ReposStoreSetMirrorSyncResultFunc{
+			defaultHook: func(context.Context, int64, bool, string) error {
+				panic("unexpected invocation of MockReposStore.SetMirrorSyncResult")
+			},
+		},
+		SetPrivateFunc: &ReposStoreSetPrivateFunc{
+			defaultHook: func(context.Context, int64, bool) error {
+				panic("unexpected invocation of MockReposStore.SetPrivate")
+			},
+		},
+		SetTopicsFunc: &ReposStoreSetTopicsFunc{
+			defaultHook: func(context.Context, int64, []string) error {
+				panic("unexpected invocation of MockReposStore.SetTopics")
+			},
+		},
+		SoftDeleteFunc: &ReposStoreSoftDeleteFunc{
 			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockReposStore.SoftDelete")
+			},
+		},
+		StarFunc: &ReposStoreStarFunc{
+			defaultHook: func(context.Context, int64, int64) error {
+				panic("unexpected invocation of MockReposStore.Star")
+			},
+		},
+		TouchFunc: &ReposStoreTouchFunc{
+			defaultHook: func(context.Context, int64, int64) error {
 				panic("unexpected invocation of MockReposStore.Touch")
 			},
 		},
+		TransferAllByOwnerFunc: &ReposStoreTransferAllByOwnerFunc{
+			defaultHook: func(context.Context, int64, int64) ([]*db.Repository, error) {
+				panic("unexpected invocation of MockReposStore.TransferAllByOwner")
+			},
+		},
+		UpdateSizeFunc: &ReposStoreUpdateSizeFunc{
+			defaultHook: func(context.Context, int64, int64) error {
+				panic("unexpected invocation of MockReposStore.UpdateSize")
+			},
+		},
 		WatchFunc: &ReposStoreWatchFunc{
 			defaultHook: func(context.Context, int64, int64) error {
 				panic("unexpected invocation of MockReposStore.Watch")
@@ -1643,9 +2220,30 @@ func NewStrictMockReposStore() *MockReposStore {
 // All methods delegate to the given implementation, unless overwritten.
 func NewMockReposStoreFrom(i db.ReposStore) *MockReposStore {
 	return &MockReposStore{
+		AddDeployKeyFunc: &ReposStoreAddDeployKeyFunc{
+			defaultHook: i.AddDeployKey,
+		},
+		AddProtectedTagFunc: &ReposStoreAddProtectedTagFunc{
+			defaultHook: i.AddProtectedTag,
+		},
+		ApplyOrgLabelsFunc: &ReposStoreApplyOrgLabelsFunc{
+			defaultHook: i.ApplyOrgLabels,
+		},
+		CleanupOrphanedAccessFunc: &ReposStoreCleanupOrphanedAccessFunc{
+			defaultHook: i.CleanupOrphanedAccess,
+		},
 		CreateFunc: &ReposStoreCreateFunc{
 			defaultHook: i.Create,
 		},
+		CreateFromTemplateFunc: &ReposStoreCreateFromTemplateFunc{
+			defaultHook: i.CreateFromTemplate,
+		},
+		DeleteExpiredCollaboratorsFunc: &ReposStoreDeleteExpiredCollaboratorsFunc{
+			defaultHook: i.DeleteExpiredCollaborators,
+		},
+		ForkFunc: &ReposStoreForkFunc{
+			defaultHook: i.Fork,
+		},
 		GetByCollaboratorIDFunc: &ReposStoreGetByCollaboratorIDFunc{
 			defaultHook: i.GetByCollaboratorID,
 		},
@@ -1658,52 +2256,107 @@ func NewMockReposStoreFrom(i db.ReposStore) *MockReposStore {
 		GetByNameFunc: &ReposStoreGetByNameFunc{
 			defaultHook: i.GetByName,
 		},
+		GetByRedirectFunc: &ReposStoreGetByRedirectFunc{
+			defaultHook: i.GetByRedirect,
+		},
 		HasForkedByFunc: &ReposStoreHasForkedByFunc{
 			defaultHook: i.HasForkedBy,
 		},
+		IsNameAvailableFunc: &ReposStoreIsNameAvailableFunc{
+			defaultHook: i.IsNameAvailable,
+		},
+		ListMirrorsToSyncFunc: &ReposStoreListMirrorsToSyncFunc{
+			defaultHook: i.ListMirrorsToSync,
+		},
+		ListProtectedTagsFunc: &ReposStoreListProtectedTagsFunc{
+			defaultHook: i.ListProtectedTags,
+		},
+		ListTopicsFunc: &ReposStoreListTopicsFunc{
+			defaultHook: i.ListTopics,
+		},
+		ListWatchedByUserInOrgFunc: &ReposStoreListWatchedByUserInOrgFunc{
+			defaultHook: i.ListWatchedByUserInOrg,
+		},
 		ListWatchesFunc: &ReposStoreListWatchesFunc{
 			defaultHook: i.ListWatches,
 		},
+		RenameFunc: &ReposStoreRenameFunc{
+			defaultHook: i.Rename,
+		},
+		RestoreFunc: &ReposStoreRestoreFunc{
+			defaultHook: i.Restore,
+		},
+		SearchByTopicFunc: &ReposStoreSearchByTopicFunc{
+			defaultHook: i.SearchByTopic,
+		},
+		SetArchivedFunc: &ReposStoreSetArchivedFunc{
+			defaultHook: i.SetArchived,
+		},
+		SetCollaboratorExpiryFunc: &ReposStoreSetCollaboratorExpiryFunc{
+			defaultHook: i.SetCollaboratorExpiry,
+		},
+		SetExternalTrackerFunc: &ReposStoreSetExternalTrackerFunc{
+			defaultHook: i.SetExternalTracker,
+		},
+		SetMirrorSyncResultFunc: &ReposStoreSetMirrorSyncResultFunc{
+			defaultHook: i.SetMirrorSyncResult,
+		},
+		SetPrivateFunc: &ReposStoreSetPrivateFunc{
+			defaultHook: i.SetPrivate,
+		},
+		SetTopicsFunc: &ReposStoreSetTopicsFunc{
+			defaultHook: i.SetTopics,
+		},
+		SoftDeleteFunc: &ReposStoreSoftDeleteFunc{
+			defaultHook: i.SoftDelete,
+		},
 		StarFunc: &ReposStoreStarFunc{
 			defaultHook: i.Star,
 		},
 		TouchFunc: &ReposStoreTouchFunc{
 			defaultHook: i.Touch,
 		},
+		TransferAllByOwnerFunc: &ReposStoreTransferAllByOwnerFunc{
+			defaultHook: i.TransferAllByOwner,
+		},
+		UpdateSizeFunc: &ReposStoreUpdateSizeFunc{
+			defaultHook: i.UpdateSize,
+		},
 		WatchFunc: &ReposStoreWatchFunc{
 			defaultHook: i.Watch,
 		},
 	}
 }
 
-// ReposStoreCreateFunc describes the behavior when the Create method of the
-// parent MockReposStore instance is invoked.
-type ReposStoreCreateFunc struct {
-	defaultHook func(context.Context, int64, db.CreateRepoOptions) (*db.Repository, error)
-	hooks       []func(context.Context, int64, db.CreateRepoOptions) (*db.Repository, error)
-	history     []ReposStoreCreateFuncCall
+// ReposStoreAddDeployKeyFunc describes the behavior when the AddDeployKey
+// method of the parent MockReposStore instance is invoked.
+type ReposStoreAddDeployKeyFunc struct {
+	defaultHook func(context.Context, int64, string, string, bool) error
+	hooks       []func(context.Context, int64, string, string, bool) error
+	history     []ReposStoreAddDeployKeyFuncCall
 	mutex       sync.Mutex
 }
 
-// Create delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockReposStore) Create(v0 context.Context, v1 int64, v2 db.CreateRepoOptions) (*db.Repository, error) {
-	r0, r1 := m.CreateFunc.nextHook()(v0, v1, v2)
-	m.CreateFunc.appendCall(ReposStoreCreateFuncCall{v0, v1, v2, r0, r1})
-	return r0, r1
+// AddDeployKey delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockReposStore) AddDeployKey(v0 context.Context, v1 int64, v2 string, v3 string, v4 bool) error {
+	r0 := m.AddDeployKeyFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.AddDeployKeyFunc.appendCall(ReposStoreAddDeployKeyFuncCall{v0, v1, v2, v3, v4, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the Create method of the
-// parent MockReposStore instance is invoked and the hook queue is empty.
-func (f *ReposStoreCreateFunc) SetDefaultHook(hook func(context.Context, int64, db.CreateRepoOptions) (*db.Repository, error)) {
+// SetDefaultHook sets function that is called when the AddDeployKey method
+// of the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreAddDeployKeyFunc) SetDefaultHook(hook func(context.Context, int64, string, string, bool) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Create method of the parent MockReposStore instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *ReposStoreCreateFunc) PushHook(hook func(context.Context, int64, db.CreateRepoOptions) (*db.Repository, error)) {
+// AddDeployKey method of the parent MockReposStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ReposStoreAddDeployKeyFunc) PushHook(hook func(context.Context, int64, string, string, bool) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1711,20 +2364,20 @@ func (f *ReposStoreCreateFunc) PushHook(hook func(context.Context, int64, db.Cre
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ReposStoreCreateFunc) SetDefaultReturn(r0 *db.Repository, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64, db.CreateRepoOptions) (*db.Repository, error) {
-		return r0, r1
+func (f *ReposStoreAddDeployKeyFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, string, string, bool) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ReposStoreCreateFunc) PushReturn(r0 *db.Repository, r1 error) {
-	f.PushHook(func(context.Context, int64, db.CreateRepoOptions) (*db.Repository, error) {
-		return r0, r1
+func (f *ReposStoreAddDeployKeyFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, string, string, bool) error {
+		return r0
 	})
 }
 
-func (f *ReposStoreCreateFunc) nextHook() func(context.Context, int64, db.CreateRepoOptions) (*db.Repository, error) {
+func (f *ReposStoreAddDeployKeyFunc) nextHook() func(context.Context, int64, string, string, bool) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1737,26 +2390,26 @@ func (f *ReposStoreCreateFunc) nextHook() func(context.Context, int64, db.Create
 	return hook
 }
 
-func (f *ReposStoreCreateFunc) appendCall(r0 ReposStoreCreateFuncCall) {
+func (f *ReposStoreAddDeployKeyFunc) appendCall(r0 ReposStoreAddDeployKeyFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ReposStoreCreateFuncCall objects describing
-// the invocations of this function.
-func (f *ReposStoreCreateFunc) History() []ReposStoreCreateFuncCall {
+// History returns a sequence of ReposStoreAddDeployKeyFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreAddDeployKeyFunc) History() []ReposStoreAddDeployKeyFuncCall {
 	f.mutex.Lock()
-	history := make([]ReposStoreCreateFuncCall, len(f.history))
+	history := make([]ReposStoreAddDeployKeyFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ReposStoreCreateFuncCall is an object that describes an invocation of
-// method Create on an instance of MockReposStore.
-type ReposStoreCreateFuncCall struct {
+// ReposStoreAddDeployKeyFuncCall is an object that describes an invocation
+// of method AddDeployKey on an instance of MockReposStore.
+type ReposStoreAddDeployKeyFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -1765,57 +2418,59 @@ type ReposStoreCreateFuncCall struct {
 	Arg1 int64
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 db.CreateRepoOptions
-	// Result0 is the value of the 1st result returned from this method
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
-	Result0 *db.Repository
-	// Result1 is the value of the 2nd result returned from this method
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
-	Result1 error
+	Arg4 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ReposStoreCreateFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c ReposStoreAddDeployKeyFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ReposStoreCreateFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c ReposStoreAddDeployKeyFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// ReposStoreGetByCollaboratorIDFunc describes the behavior when the
-// GetByCollaboratorID method of the parent MockReposStore instance is
-// invoked.
-type ReposStoreGetByCollaboratorIDFunc struct {
-	defaultHook func(context.Context, int64, int, string) ([]*db.Repository, error)
-	hooks       []func(context.Context, int64, int, string) ([]*db.Repository, error)
-	history     []ReposStoreGetByCollaboratorIDFuncCall
+// ReposStoreAddProtectedTagFunc describes the behavior when the
+// AddProtectedTag method of the parent MockReposStore instance is invoked.
+type ReposStoreAddProtectedTagFunc struct {
+	defaultHook func(context.Context, int64, string, db.AccessMode) (*db.ProtectedTag, error)
+	hooks       []func(context.Context, int64, string, db.AccessMode) (*db.ProtectedTag, error)
+	history     []ReposStoreAddProtectedTagFuncCall
 	mutex       sync.Mutex
 }
 
-// GetByCollaboratorID delegates to the next hook function in the queue and
+// AddProtectedTag delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockReposStore) GetByCollaboratorID(v0 context.Context, v1 int64, v2 int, v3 string) ([]*db.Repository, error) {
-	r0, r1 := m.GetByCollaboratorIDFunc.nextHook()(v0, v1, v2, v3)
-	m.GetByCollaboratorIDFunc.appendCall(ReposStoreGetByCollaboratorIDFuncCall{v0, v1, v2, v3, r0, r1})
+func (m *MockReposStore) AddProtectedTag(v0 context.Context, v1 int64, v2 string, v3 db.AccessMode) (*db.ProtectedTag, error) {
+	r0, r1 := m.AddProtectedTagFunc.nextHook()(v0, v1, v2, v3)
+	m.AddProtectedTagFunc.appendCall(ReposStoreAddProtectedTagFuncCall{v0, v1, v2, v3, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetByCollaboratorID
+// SetDefaultHook sets function that is called when the AddProtectedTag
 // method of the parent MockReposStore instance is invoked and the hook
 // queue is empty.
-func (f *ReposStoreGetByCollaboratorIDFunc) SetDefaultHook(hook func(context.Context, int64, int, string) ([]*db.Repository, error)) {
+func (f *ReposStoreAddProtectedTagFunc) SetDefaultHook(hook func(context.Context, int64, string, db.AccessMode) (*db.ProtectedTag, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetByCollaboratorID method of the parent MockReposStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *ReposStoreGetByCollaboratorIDFunc) PushHook(hook func(context.Context, int64, int, string) ([]*db.Repository, error)) {
+// AddProtectedTag method of the parent MockReposStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ReposStoreAddProtectedTagFunc) PushHook(hook func(context.Context, int64, string, db.AccessMode) (*db.ProtectedTag, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1823,20 +2478,20 @@ func (f *ReposStoreGetByCollaboratorIDFunc) PushHook(hook func(context.Context,
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ReposStoreGetByCollaboratorIDFunc) SetDefaultReturn(r0 []*db.Repository, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64, int, string) ([]*db.Repository, error) {
+func (f *ReposStoreAddProtectedTagFunc) SetDefaultReturn(r0 *db.ProtectedTag, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, string, db.AccessMode) (*db.ProtectedTag, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ReposStoreGetByCollaboratorIDFunc) PushReturn(r0 []*db.Repository, r1 error) {
-	f.PushHook(func(context.Context, int64, int, string) ([]*db.Repository, error) {
+func (f *ReposStoreAddProtectedTagFunc) PushReturn(r0 *db.ProtectedTag, r1 error) {
+	f.PushHook(func(context.Context, int64, string, db.AccessMode) (*db.ProtectedTag, error) {
 		return r0, r1
 	})
 }
 
-func (f *ReposStoreGetByCollaboratorIDFunc) nextHook() func(context.Context, int64, int, string) ([]*db.Repository, error) {
+func (f *ReposStoreAddProtectedTagFunc) nextHook() func(context.Context, int64, string, db.AccessMode) (*db.ProtectedTag, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1849,27 +2504,26 @@ func (f *ReposStoreGetByCollaboratorIDFunc) nextHook() func(context.Context, int
 	return hook
 }
 
-func (f *ReposStoreGetByCollaboratorIDFunc) appendCall(r0 ReposStoreGetByCollaboratorIDFuncCall) {
+func (f *ReposStoreAddProtectedTagFunc) appendCall(r0 ReposStoreAddProtectedTagFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ReposStoreGetByCollaboratorIDFuncCall
-// objects describing the invocations of this function.
-func (f *ReposStoreGetByCollaboratorIDFunc) History() []ReposStoreGetByCollaboratorIDFuncCall {
+// History returns a sequence of ReposStoreAddProtectedTagFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreAddProtectedTagFunc) History() []ReposStoreAddProtectedTagFuncCall {
 	f.mutex.Lock()
-	history := make([]ReposStoreGetByCollaboratorIDFuncCall, len(f.history))
+	history := make([]ReposStoreAddProtectedTagFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ReposStoreGetByCollaboratorIDFuncCall is an object that describes an
-// invocation of method GetByCollaboratorID on an instance of
-// MockReposStore.
-type ReposStoreGetByCollaboratorIDFuncCall struct {
+// ReposStoreAddProtectedTagFuncCall is an object that describes an
+// invocation of method AddProtectedTag on an instance of MockReposStore.
+type ReposStoreAddProtectedTagFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -1878,13 +2532,13 @@ type ReposStoreGetByCollaboratorIDFuncCall struct {
 	Arg1 int64
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 int
+	Arg2 string
 	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
-	Arg3 string
+	Arg3 db.AccessMode
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*db.Repository
+	Result0 *db.ProtectedTag
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -1892,47 +2546,45 @@ type ReposStoreGetByCollaboratorIDFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ReposStoreGetByCollaboratorIDFuncCall) Args() []interface{} {
+func (c ReposStoreAddProtectedTagFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ReposStoreGetByCollaboratorIDFuncCall) Results() []interface{} {
+func (c ReposStoreAddProtectedTagFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// ReposStoreGetByCollaboratorIDWithAccessModeFunc describes the behavior
-// when the GetByCollaboratorIDWithAccessMode method of the parent
-// MockReposStore instance is invoked.
-type ReposStoreGetByCollaboratorIDWithAccessModeFunc struct {
-	defaultHook func(context.Context, int64) (map[*db.Repository]db.AccessMode, error)
-	hooks       []func(context.Context, int64) (map[*db.Repository]db.AccessMode, error)
-	history     []ReposStoreGetByCollaboratorIDWithAccessModeFuncCall
+// ReposStoreApplyOrgLabelsFunc describes the behavior when the
+// ApplyOrgLabels method of the parent MockReposStore instance is invoked.
+type ReposStoreApplyOrgLabelsFunc struct {
+	defaultHook func(context.Context, int64, int64) error
+	hooks       []func(context.Context, int64, int64) error
+	history     []ReposStoreApplyOrgLabelsFuncCall
 	mutex       sync.Mutex
 }
 
-// GetByCollaboratorIDWithAccessMode delegates to the next hook function in
-// the queue and stores the parameter and result values of this invocation.
-func (m *MockReposStore) GetByCollaboratorIDWithAccessMode(v0 context.Context, v1 int64) (map[*db.Repository]db.AccessMode, error) {
-	r0, r1 := m.GetByCollaboratorIDWithAccessModeFunc.nextHook()(v0, v1)
-	m.GetByCollaboratorIDWithAccessModeFunc.appendCall(ReposStoreGetByCollaboratorIDWithAccessModeFuncCall{v0, v1, r0, r1})
-	return r0, r1
+// ApplyOrgLabels delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockReposStore) ApplyOrgLabels(v0 context.Context, v1 int64, v2 int64) error {
+	r0 := m.ApplyOrgLabelsFunc.nextHook()(v0, v1, v2)
+	m.ApplyOrgLabelsFunc.appendCall(ReposStoreApplyOrgLabelsFuncCall{v0, v1, v2, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the
-// GetByCollaboratorIDWithAccessMode method of the parent MockReposStore
-// instance is invoked and the hook queue is empty.
-func (f *ReposStoreGetByCollaboratorIDWithAccessModeFunc) SetDefaultHook(hook func(context.Context, int64) (map[*db.Repository]db.AccessMode, error)) {
+// SetDefaultHook sets function that is called when the ApplyOrgLabels
+// method of the parent MockReposStore instance is invoked and the hook
+// queue is empty.
+func (f *ReposStoreApplyOrgLabelsFunc) SetDefaultHook(hook func(context.Context, int64, int64) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetByCollaboratorIDWithAccessMode method of the parent MockReposStore
-// instance invokes the hook at the front of the queue and discards it.
-// After the queue is empty, the default hook function is invoked for any
-// future action.
-func (f *ReposStoreGetByCollaboratorIDWithAccessModeFunc) PushHook(hook func(context.Context, int64) (map[*db.Repository]db.AccessMode, error)) {
+// ApplyOrgLabels method of the parent MockReposStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ReposStoreApplyOrgLabelsFunc) PushHook(hook func(context.Context, int64, int64) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1940,20 +2592,20 @@ func (f *ReposStoreGetByCollaboratorIDWithAccessModeFunc) PushHook(hook func(con
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ReposStoreGetByCollaboratorIDWithAccessModeFunc) SetDefaultReturn(r0 map[*db.Repository]db.AccessMode, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64) (map[*db.Repository]db.AccessMode, error) {
-		return r0, r1
+func (f *ReposStoreApplyOrgLabelsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ReposStoreGetByCollaboratorIDWithAccessModeFunc) PushReturn(r0 map[*db.Repository]db.AccessMode, r1 error) {
-	f.PushHook(func(context.Context, int64) (map[*db.Repository]db.AccessMode, error) {
-		return r0, r1
+func (f *ReposStoreApplyOrgLabelsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64) error {
+		return r0
 	})
 }
 
-func (f *ReposStoreGetByCollaboratorIDWithAccessModeFunc) nextHook() func(context.Context, int64) (map[*db.Repository]db.AccessMode, error) {
+func (f *ReposStoreApplyOrgLabelsFunc) nextHook() func(context.Context, int64, int64) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1966,83 +2618,83 @@ func (f *ReposStoreGetByCollaboratorIDWithAccessModeFunc) nextHook() func(contex
 	return hook
 }
 
-func (f *ReposStoreGetByCollaboratorIDWithAccessModeFunc) appendCall(r0 ReposStoreGetByCollaboratorIDWithAccessModeFuncCall) {
+func (f *ReposStoreApplyOrgLabelsFunc) appendCall(r0 ReposStoreApplyOrgLabelsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// ReposStoreGetByCollaboratorIDWithAccessModeFuncCall objects describing
-// the invocations of this function.
-func (f *ReposStoreGetByCollaboratorIDWithAccessModeFunc) History() []ReposStoreGetByCollaboratorIDWithAccessModeFuncCall {
+// History returns a sequence of ReposStoreApplyOrgLabelsFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreApplyOrgLabelsFunc) History() []ReposStoreApplyOrgLabelsFuncCall {
 	f.mutex.Lock()
-	history := make([]ReposStoreGetByCollaboratorIDWithAccessModeFuncCall, len(f.history))
+	history := make([]ReposStoreApplyOrgLabelsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ReposStoreGetByCollaboratorIDWithAccessModeFuncCall is an object that
-// describes an invocation of method GetByCollaboratorIDWithAccessMode on an
-// instance of MockReposStore.
-type ReposStoreGetByCollaboratorIDWithAccessModeFuncCall struct {
+// ReposStoreApplyOrgLabelsFuncCall is an object that describes an
+// invocation of method ApplyOrgLabels on an instance of MockReposStore.
+type ReposStoreApplyOrgLabelsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int64
-	// Result0 is the value of the 1st result returned from this method
+	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Result0 map[*db.Repository]db.AccessMode
-	// Result1 is the value of the 2nd result returned from this method
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ReposStoreGetByCollaboratorIDWithAccessModeFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c ReposStoreApplyOrgLabelsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ReposStoreGetByCollaboratorIDWithAccessModeFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c ReposStoreApplyOrgLabelsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// ReposStoreGetByIDFunc describes the behavior when the GetByID method of
-// the parent MockReposStore instance is invoked.
-type ReposStoreGetByIDFunc struct {
-	defaultHook func(context.Context, int64) (*db.Repository, error)
-	hooks       []func(context.Context, int64) (*db.Repository, error)
-	history     []ReposStoreGetByIDFuncCall
+// ReposStoreCleanupOrphanedAccessFunc describes the behavior when the
+// CleanupOrphanedAccess method of the parent MockReposStore instance is
+// invoked.
+type ReposStoreCleanupOrphanedAccessFunc struct {
+	defaultHook func(context.Context) (int64, error)
+	hooks       []func(context.Context) (int64, error)
+	history     []ReposStoreCleanupOrphanedAccessFuncCall
 	mutex       sync.Mutex
 }
 
-// GetByID delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockReposStore) GetByID(v0 context.Context, v1 int64) (*db.Repository, error) {
-	r0, r1 := m.GetByIDFunc.nextHook()(v0, v1)
-	m.GetByIDFunc.appendCall(ReposStoreGetByIDFuncCall{v0, v1, r0, r1})
+// CleanupOrphanedAccess delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockReposStore) CleanupOrphanedAccess(v0 context.Context) (int64, error) {
+	r0, r1 := m.CleanupOrphanedAccessFunc.nextHook()(v0)
+	m.CleanupOrphanedAccessFunc.appendCall(ReposStoreCleanupOrphanedAccessFuncCall{v0, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetByID method of
-// the parent MockReposStore instance is invoked and the hook queue is
-// empty.
-func (f *ReposStoreGetByIDFunc) SetDefaultHook(hook func(context.Context, int64) (*db.Repository, error)) {
+// SetDefaultHook sets function that is called when the
+// CleanupOrphanedAccess method of the parent MockReposStore instance is
+// invoked and the hook queue is empty.
+func (f *ReposStoreCleanupOrphanedAccessFunc) SetDefaultHook(hook func(context.Context) (int64, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetByID method of the parent MockReposStore instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *ReposStoreGetByIDFunc) PushHook(hook func(context.Context, int64) (*db.Repository, error)) {
+// CleanupOrphanedAccess method of the parent MockReposStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ReposStoreCleanupOrphanedAccessFunc) PushHook(hook func(context.Context) (int64, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2050,20 +2702,20 @@ func (f *ReposStoreGetByIDFunc) PushHook(hook func(context.Context, int64) (*db.
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ReposStoreGetByIDFunc) SetDefaultReturn(r0 *db.Repository, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64) (*db.Repository, error) {
+func (f *ReposStoreCleanupOrphanedAccessFunc) SetDefaultReturn(r0 int64, r1 error) {
+	f.SetDefaultHook(func(context.Context) (int64, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ReposStoreGetByIDFunc) PushReturn(r0 *db.Repository, r1 error) {
-	f.PushHook(func(context.Context, int64) (*db.Repository, error) {
+func (f *ReposStoreCleanupOrphanedAccessFunc) PushReturn(r0 int64, r1 error) {
+	f.PushHook(func(context.Context) (int64, error) {
 		return r0, r1
 	})
 }
 
-func (f *ReposStoreGetByIDFunc) nextHook() func(context.Context, int64) (*db.Repository, error) {
+func (f *ReposStoreCleanupOrphanedAccessFunc) nextHook() func(context.Context) (int64, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2076,35 +2728,33 @@ func (f *ReposStoreGetByIDFunc) nextHook() func(context.Context, int64) (*db.Rep
 	return hook
 }
 
-func (f *ReposStoreGetByIDFunc) appendCall(r0 ReposStoreGetByIDFuncCall) {
+func (f *ReposStoreCleanupOrphanedAccessFunc) appendCall(r0 ReposStoreCleanupOrphanedAccessFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ReposStoreGetByIDFuncCall objects
-// describing the invocations of this function.
-func (f *ReposStoreGetByIDFunc) History() []ReposStoreGetByIDFuncCall {
+// History returns a sequence of ReposStoreCleanupOrphanedAccessFuncCall
+// objects describing the invocations of this function.
+func (f *ReposStoreCleanupOrphanedAccessFunc) History() []ReposStoreCleanupOrphanedAccessFuncCall {
 	f.mutex.Lock()
-	history := make([]ReposStoreGetByIDFuncCall, len(f.history))
+	history := make([]ReposStoreCleanupOrphanedAccessFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ReposStoreGetByIDFuncCall is an object that describes an invocation of
-// method GetByID on an instance of MockReposStore.
-type ReposStoreGetByIDFuncCall struct {
+// ReposStoreCleanupOrphanedAccessFuncCall is an object that describes an
+// invocation of method CleanupOrphanedAccess on an instance of
+// MockReposStore.
+type ReposStoreCleanupOrphanedAccessFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *db.Repository
+	Result0 int64
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -2112,45 +2762,44 @@ type ReposStoreGetByIDFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ReposStoreGetByIDFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c ReposStoreCleanupOrphanedAccessFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ReposStoreGetByIDFuncCall) Results() []interface{} {
+func (c ReposStoreCleanupOrphanedAccessFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// ReposStoreGetByNameFunc describes the behavior when the GetByName method
-// of the parent MockReposStore instance is invoked.
-type ReposStoreGetByNameFunc struct {
-	defaultHook func(context.Context, int64, string) (*db.Repository, error)
-	hooks       []func(context.Context, int64, string) (*db.Repository, error)
-	history     []ReposStoreGetByNameFuncCall
+// ReposStoreCreateFunc describes the behavior when the Create method of the
+// parent MockReposStore instance is invoked.
+type ReposStoreCreateFunc struct {
+	defaultHook func(context.Context, int64, db.CreateRepoOptions) (*db.Repository, error)
+	hooks       []func(context.Context, int64, db.CreateRepoOptions) (*db.Repository, error)
+	history     []ReposStoreCreateFuncCall
 	mutex       sync.Mutex
 }
 
-// GetByName delegates to the next hook function in the queue and stores the
+// Create delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockReposStore) GetByName(v0 context.Context, v1 int64, v2 string) (*db.Repository, error) {
-	r0, r1 := m.GetByNameFunc.nextHook()(v0, v1, v2)
-	m.GetByNameFunc.appendCall(ReposStoreGetByNameFuncCall{v0, v1, v2, r0, r1})
+func (m *MockReposStore) Create(v0 context.Context, v1 int64, v2 db.CreateRepoOptions) (*db.Repository, error) {
+	r0, r1 := m.CreateFunc.nextHook()(v0, v1, v2)
+	m.CreateFunc.appendCall(ReposStoreCreateFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetByName method of
-// the parent MockReposStore instance is invoked and the hook queue is
-// empty.
-func (f *ReposStoreGetByNameFunc) SetDefaultHook(hook func(context.Context, int64, string) (*db.Repository, error)) {
+// SetDefaultHook sets function that is called when the Create method of the
+// parent MockReposStore instance is invoked and the hook queue is empty.
+func (f *ReposStoreCreateFunc) SetDefaultHook(hook func(context.Context, int64, db.CreateRepoOptions) (*db.Repository, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetByName method of the parent MockReposStore instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
+// Create method of the parent MockReposStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
 // default hook function is invoked for any future action.
-func (f *ReposStoreGetByNameFunc) PushHook(hook func(context.Context, int64, string) (*db.Repository, error)) {
+func (f *ReposStoreCreateFunc) PushHook(hook func(context.Context, int64, db.CreateRepoOptions) (*db.Repository, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2158,20 +2807,20 @@ func (f *ReposStoreGetByNameFunc) PushHook(hook func(context.Context, int64, str
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ReposStoreGetByNameFunc) SetDefaultReturn(r0 *db.Repository, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64, string) (*db.Repository, error) {
+func (f *ReposStoreCreateFunc) SetDefaultReturn(r0 *db.Repository, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, db.CreateRepoOptions) (*db.Repository, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ReposStoreGetByNameFunc) PushReturn(r0 *db.Repository, r1 error) {
-	f.PushHook(func(context.Context, int64, string) (*db.Repository, error) {
+func (f *ReposStoreCreateFunc) PushReturn(r0 *db.Repository, r1 error) {
+	f.PushHook(func(context.Context, int64, db.CreateRepoOptions) (*db.Repository, error) {
 		return r0, r1
 	})
 }
 
-func (f *ReposStoreGetByNameFunc) nextHook() func(context.Context, int64, string) (*db.Repository, error) {
+func (f *ReposStoreCreateFunc) nextHook() func(context.Context, int64, db.CreateRepoOptions) (*db.Repository, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2184,26 +2833,26 @@ func (f *ReposStoreGetByNameFunc) nextHook() func(context.Context, int64, string
 	return hook
 }
 
-func (f *ReposStoreGetByNameFunc) appendCall(r0 ReposStoreGetByNameFuncCall) {
+func (f *ReposStoreCreateFunc) appendCall(r0 ReposStoreCreateFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ReposStoreGetByNameFuncCall objects
-// describing the invocations of this function.
-func (f *ReposStoreGetByNameFunc) History() []ReposStoreGetByNameFuncCall {
+// History returns a sequence of ReposStoreCreateFuncCall objects describing
+// the invocations of this function.
+func (f *ReposStoreCreateFunc) History() []ReposStoreCreateFuncCall {
 	f.mutex.Lock()
-	history := make([]ReposStoreGetByNameFuncCall, len(f.history))
+	history := make([]ReposStoreCreateFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ReposStoreGetByNameFuncCall is an object that describes an invocation of
-// method GetByName on an instance of MockReposStore.
-type ReposStoreGetByNameFuncCall struct {
+// ReposStoreCreateFuncCall is an object that describes an invocation of
+// method Create on an instance of MockReposStore.
+type ReposStoreCreateFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -2212,7 +2861,7 @@ type ReposStoreGetByNameFuncCall struct {
 	Arg1 int64
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 string
+	Arg2 db.CreateRepoOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 *db.Repository
@@ -2223,45 +2872,46 @@ type ReposStoreGetByNameFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ReposStoreGetByNameFuncCall) Args() []interface{} {
+func (c ReposStoreCreateFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ReposStoreGetByNameFuncCall) Results() []interface{} {
+func (c ReposStoreCreateFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// ReposStoreHasForkedByFunc describes the behavior when the HasForkedBy
-// method of the parent MockReposStore instance is invoked.
-type ReposStoreHasForkedByFunc struct {
-	defaultHook func(context.Context, int64, int64) bool
-	hooks       []func(context.Context, int64, int64) bool
-	history     []ReposStoreHasForkedByFuncCall
+// ReposStoreCreateFromTemplateFunc describes the behavior when the
+// CreateFromTemplate method of the parent MockReposStore instance is
+// invoked.
+type ReposStoreCreateFromTemplateFunc struct {
+	defaultHook func(context.Context, int64, int64, db.CreateFromTemplateOptions) (*db.Repository, error)
+	hooks       []func(context.Context, int64, int64, db.CreateFromTemplateOptions) (*db.Repository, error)
+	history     []ReposStoreCreateFromTemplateFuncCall
 	mutex       sync.Mutex
 }
 
-// HasForkedBy delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockReposStore) HasForkedBy(v0 context.Context, v1 int64, v2 int64) bool {
-	r0 := m.HasForkedByFunc.nextHook()(v0, v1, v2)
-	m.HasForkedByFunc.appendCall(ReposStoreHasForkedByFuncCall{v0, v1, v2, r0})
-	return r0
+// CreateFromTemplate delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockReposStore) CreateFromTemplate(v0 context.Context, v1 int64, v2 int64, v3 db.CreateFromTemplateOptions) (*db.Repository, error) {
+	r0, r1 := m.CreateFromTemplateFunc.nextHook()(v0, v1, v2, v3)
+	m.CreateFromTemplateFunc.appendCall(ReposStoreCreateFromTemplateFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the HasForkedBy method
-// of the parent MockReposStore instance is invoked and the hook queue is
-// empty.
-func (f *ReposStoreHasForkedByFunc) SetDefaultHook(hook func(context.Context, int64, int64) bool) {
+// SetDefaultHook sets function that is called when the CreateFromTemplate
+// method of the parent MockReposStore instance is invoked and the hook
+// queue is empty.
+func (f *ReposStoreCreateFromTemplateFunc) SetDefaultHook(hook func(context.Context, int64, int64, db.CreateFromTemplateOptions) (*db.Repository, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// HasForkedBy method of the parent MockReposStore instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *ReposStoreHasForkedByFunc) PushHook(hook func(context.Context, int64, int64) bool) {
+// CreateFromTemplate method of the parent MockReposStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ReposStoreCreateFromTemplateFunc) PushHook(hook func(context.Context, int64, int64, db.CreateFromTemplateOptions) (*db.Repository, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2269,20 +2919,20 @@ func (f *ReposStoreHasForkedByFunc) PushHook(hook func(context.Context, int64, i
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ReposStoreHasForkedByFunc) SetDefaultReturn(r0 bool) {
-	f.SetDefaultHook(func(context.Context, int64, int64) bool {
-		return r0
+func (f *ReposStoreCreateFromTemplateFunc) SetDefaultReturn(r0 *db.Repository, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64, db.CreateFromTemplateOptions) (*db.Repository, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ReposStoreHasForkedByFunc) PushReturn(r0 bool) {
-	f.PushHook(func(context.Context, int64, int64) bool {
-		return r0
+func (f *ReposStoreCreateFromTemplateFunc) PushReturn(r0 *db.Repository, r1 error) {
+	f.PushHook(func(context.Context, int64, int64, db.CreateFromTemplateOptions) (*db.Repository, error) {
+		return r0, r1
 	})
 }
 
-func (f *ReposStoreHasForkedByFunc) nextHook() func(context.Context, int64, int64) bool {
+func (f *ReposStoreCreateFromTemplateFunc) nextHook() func(context.Context, int64, int64, db.CreateFromTemplateOptions) (*db.Repository, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2295,26 +2945,26 @@ func (f *ReposStoreHasForkedByFunc) nextHook() func(context.Context, int64, int6
 	return hook
 }
 
-func (f *ReposStoreHasForkedByFunc) appendCall(r0 ReposStoreHasForkedByFuncCall) {
+func (f *ReposStoreCreateFromTemplateFunc) appendCall(r0 ReposStoreCreateFromTemplateFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ReposStoreHasForkedByFuncCall objects
-// describing the invocations of this function.
-func (f *ReposStoreHasForkedByFunc) History() []ReposStoreHasForkedByFuncCall {
+// History returns a sequence of ReposStoreCreateFromTemplateFuncCall
+// objects describing the invocations of this function.
+func (f *ReposStoreCreateFromTemplateFunc) History() []ReposStoreCreateFromTemplateFuncCall {
 	f.mutex.Lock()
-	history := make([]ReposStoreHasForkedByFuncCall, len(f.history))
+	history := make([]ReposStoreCreateFromTemplateFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ReposStoreHasForkedByFuncCall is an object that describes an invocation
-// of method HasForkedBy on an instance of MockReposStore.
-type ReposStoreHasForkedByFuncCall struct {
+// ReposStoreCreateFromTemplateFuncCall is an object that describes an
+// invocation of method CreateFromTemplate on an instance of MockReposStore.
+type ReposStoreCreateFromTemplateFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -2324,52 +2974,60 @@ type ReposStoreHasForkedByFuncCall struct {
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
 	Arg2 int64
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 db.CreateFromTemplateOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 bool
+	Result0 *db.Repository
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ReposStoreHasForkedByFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c ReposStoreCreateFromTemplateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ReposStoreHasForkedByFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c ReposStoreCreateFromTemplateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// ReposStoreListWatchesFunc describes the behavior when the ListWatches
-// method of the parent MockReposStore instance is invoked.
-type ReposStoreListWatchesFunc struct {
-	defaultHook func(context.Context, int64) ([]*db.Watch, error)
-	hooks       []func(context.Context, int64) ([]*db.Watch, error)
-	history     []ReposStoreListWatchesFuncCall
+// ReposStoreDeleteExpiredCollaboratorsFunc describes the behavior when the
+// DeleteExpiredCollaborators method of the parent MockReposStore instance
+// is invoked.
+type ReposStoreDeleteExpiredCollaboratorsFunc struct {
+	defaultHook func(context.Context) error
+	hooks       []func(context.Context) error
+	history     []ReposStoreDeleteExpiredCollaboratorsFuncCall
 	mutex       sync.Mutex
 }
 
-// ListWatches delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockReposStore) ListWatches(v0 context.Context, v1 int64) ([]*db.Watch, error) {
-	r0, r1 := m.ListWatchesFunc.nextHook()(v0, v1)
-	m.ListWatchesFunc.appendCall(ReposStoreListWatchesFuncCall{v0, v1, r0, r1})
-	return r0, r1
+// DeleteExpiredCollaborators delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockReposStore) DeleteExpiredCollaborators(v0 context.Context) error {
+	r0 := m.DeleteExpiredCollaboratorsFunc.nextHook()(v0)
+	m.DeleteExpiredCollaboratorsFunc.appendCall(ReposStoreDeleteExpiredCollaboratorsFuncCall{v0, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the ListWatches method
-// of the parent MockReposStore instance is invoked and the hook queue is
-// empty.
-func (f *ReposStoreListWatchesFunc) SetDefaultHook(hook func(context.Context, int64) ([]*db.Watch, error)) {
+// SetDefaultHook sets function that is called when the
+// DeleteExpiredCollaborators method of the parent MockReposStore instance
+// is invoked and the hook queue is empty.
+func (f *ReposStoreDeleteExpiredCollaboratorsFunc) SetDefaultHook(hook func(context.Context) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListWatches method of the parent MockReposStore instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *ReposStoreListWatchesFunc) PushHook(hook func(context.Context, int64) ([]*db.Watch, error)) {
+// DeleteExpiredCollaborators method of the parent MockReposStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ReposStoreDeleteExpiredCollaboratorsFunc) PushHook(hook func(context.Context) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2377,20 +3035,20 @@ func (f *ReposStoreListWatchesFunc) PushHook(hook func(context.Context, int64) (
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ReposStoreListWatchesFunc) SetDefaultReturn(r0 []*db.Watch, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64) ([]*db.Watch, error) {
-		return r0, r1
+func (f *ReposStoreDeleteExpiredCollaboratorsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ReposStoreListWatchesFunc) PushReturn(r0 []*db.Watch, r1 error) {
-	f.PushHook(func(context.Context, int64) ([]*db.Watch, error) {
-		return r0, r1
+func (f *ReposStoreDeleteExpiredCollaboratorsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context) error {
+		return r0
 	})
 }
 
-func (f *ReposStoreListWatchesFunc) nextHook() func(context.Context, int64) ([]*db.Watch, error) {
+func (f *ReposStoreDeleteExpiredCollaboratorsFunc) nextHook() func(context.Context) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2403,80 +3061,76 @@ func (f *ReposStoreListWatchesFunc) nextHook() func(context.Context, int64) ([]*
 	return hook
 }
 
-func (f *ReposStoreListWatchesFunc) appendCall(r0 ReposStoreListWatchesFuncCall) {
+func (f *ReposStoreDeleteExpiredCollaboratorsFunc) appendCall(r0 ReposStoreDeleteExpiredCollaboratorsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ReposStoreListWatchesFuncCall objects
-// describing the invocations of this function.
-func (f *ReposStoreListWatchesFunc) History() []ReposStoreListWatchesFuncCall {
+// History returns a sequence of
+// ReposStoreDeleteExpiredCollaboratorsFuncCall objects describing the
+// invocations of this function.
+func (f *ReposStoreDeleteExpiredCollaboratorsFunc) History() []ReposStoreDeleteExpiredCollaboratorsFuncCall {
 	f.mutex.Lock()
-	history := make([]ReposStoreListWatchesFuncCall, len(f.history))
+	history := make([]ReposStoreDeleteExpiredCollaboratorsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ReposStoreListWatchesFuncCall is an object that describes an invocation
-// of method ListWatches on an instance of MockReposStore.
-type ReposStoreListWatchesFuncCall struct {
+// ReposStoreDeleteExpiredCollaboratorsFuncCall is an object that describes
+// an invocation of method DeleteExpiredCollaborators on an instance of
+// MockReposStore.
+type ReposStoreDeleteExpiredCollaboratorsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*db.Watch
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ReposStoreListWatchesFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c ReposStoreDeleteExpiredCollaboratorsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ReposStoreListWatchesFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c ReposStoreDeleteExpiredCollaboratorsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// ReposStoreStarFunc describes the behavior when the Star method of the
+// ReposStoreForkFunc describes the behavior when the Fork method of the
 // parent MockReposStore instance is invoked.
-type ReposStoreStarFunc struct {
-	defaultHook func(context.Context, int64, int64) error
-	hooks       []func(context.Context, int64, int64) error
-	history     []ReposStoreStarFuncCall
+type ReposStoreForkFunc struct {
+	defaultHook func(context.Context, int64, int64, db.ForkOptions) (*db.Repository, error)
+	hooks       []func(context.Context, int64, int64, db.ForkOptions) (*db.Repository, error)
+	history     []ReposStoreForkFuncCall
 	mutex       sync.Mutex
 }
 
-// Star delegates to the next hook function in the queue and stores the
+// Fork delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockReposStore) Star(v0 context.Context, v1 int64, v2 int64) error {
-	r0 := m.StarFunc.nextHook()(v0, v1, v2)
-	m.StarFunc.appendCall(ReposStoreStarFuncCall{v0, v1, v2, r0})
-	return r0
+func (m *MockReposStore) Fork(v0 context.Context, v1 int64, v2 int64, v3 db.ForkOptions) (*db.Repository, error) {
+	r0, r1 := m.ForkFunc.nextHook()(v0, v1, v2, v3)
+	m.ForkFunc.appendCall(ReposStoreForkFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Star method of the
+// SetDefaultHook sets function that is called when the Fork method of the
 // parent MockReposStore instance is invoked and the hook queue is empty.
-func (f *ReposStoreStarFunc) SetDefaultHook(hook func(context.Context, int64, int64) error) {
+func (f *ReposStoreForkFunc) SetDefaultHook(hook func(context.Context, int64, int64, db.ForkOptions) (*db.Repository, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Star method of the parent MockReposStore instance invokes the hook at the
+// Fork method of the parent MockReposStore instance invokes the hook at the
 // front of the queue and discards it. After the queue is empty, the default
 // hook function is invoked for any future action.
-func (f *ReposStoreStarFunc) PushHook(hook func(context.Context, int64, int64) error) {
+func (f *ReposStoreForkFunc) PushHook(hook func(context.Context, int64, int64, db.ForkOptions) (*db.Repository, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2484,20 +3138,20 @@ func (f *ReposStoreStarFunc) PushHook(hook func(context.Context, int64, int64) e
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ReposStoreStarFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int64, int64) error {
-		return r0
+func (f *ReposStoreForkFunc) SetDefaultReturn(r0 *db.Repository, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64, db.ForkOptions) (*db.Repository, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ReposStoreStarFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int64, int64) error {
-		return r0
+func (f *ReposStoreForkFunc) PushReturn(r0 *db.Repository, r1 error) {
+	f.PushHook(func(context.Context, int64, int64, db.ForkOptions) (*db.Repository, error) {
+		return r0, r1
 	})
 }
 
-func (f *ReposStoreStarFunc) nextHook() func(context.Context, int64, int64) error {
+func (f *ReposStoreForkFunc) nextHook() func(context.Context, int64, int64, db.ForkOptions) (*db.Repository, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2510,26 +3164,26 @@ func (f *ReposStoreStarFunc) nextHook() func(context.Context, int64, int64) erro
 	return hook
 }
 
-func (f *ReposStoreStarFunc) appendCall(r0 ReposStoreStarFuncCall) {
+func (f *ReposStoreForkFunc) appendCall(r0 ReposStoreForkFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ReposStoreStarFuncCall objects describing
+// History returns a sequence of ReposStoreForkFuncCall objects describing
 // the invocations of this function.
-func (f *ReposStoreStarFunc) History() []ReposStoreStarFuncCall {
+func (f *ReposStoreForkFunc) History() []ReposStoreForkFuncCall {
 	f.mutex.Lock()
-	history := make([]ReposStoreStarFuncCall, len(f.history))
+	history := make([]ReposStoreForkFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ReposStoreStarFuncCall is an object that describes an invocation of
-// method Star on an instance of MockReposStore.
-type ReposStoreStarFuncCall struct {
+// ReposStoreForkFuncCall is an object that describes an invocation of
+// method Fork on an instance of MockReposStore.
+type ReposStoreForkFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -2539,51 +3193,59 @@ type ReposStoreStarFuncCall struct {
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
 	Arg2 int64
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 db.ForkOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 *db.Repository
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ReposStoreStarFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c ReposStoreForkFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ReposStoreStarFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c ReposStoreForkFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// ReposStoreTouchFunc describes the behavior when the Touch method of the
-// parent MockReposStore instance is invoked.
-type ReposStoreTouchFunc struct {
-	defaultHook func(context.Context, int64) error
-	hooks       []func(context.Context, int64) error
-	history     []ReposStoreTouchFuncCall
+// ReposStoreGetByCollaboratorIDFunc describes the behavior when the
+// GetByCollaboratorID method of the parent MockReposStore instance is
+// invoked.
+type ReposStoreGetByCollaboratorIDFunc struct {
+	defaultHook func(context.Context, int64, int, string) ([]*db.Repository, error)
+	hooks       []func(context.Context, int64, int, string) ([]*db.Repository, error)
+	history     []ReposStoreGetByCollaboratorIDFuncCall
 	mutex       sync.Mutex
 }
 
-// Touch delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockReposStore) Touch(v0 context.Context, v1 int64) error {
-	r0 := m.TouchFunc.nextHook()(v0, v1)
-	m.TouchFunc.appendCall(ReposStoreTouchFuncCall{v0, v1, r0})
-	return r0
+// GetByCollaboratorID delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockReposStore) GetByCollaboratorID(v0 context.Context, v1 int64, v2 int, v3 string) ([]*db.Repository, error) {
+	r0, r1 := m.GetByCollaboratorIDFunc.nextHook()(v0, v1, v2, v3)
+	m.GetByCollaboratorIDFunc.appendCall(ReposStoreGetByCollaboratorIDFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Touch method of the
-// parent MockReposStore instance is invoked and the hook queue is empty.
-func (f *ReposStoreTouchFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+// SetDefaultHook sets function that is called when the GetByCollaboratorID
+// method of the parent MockReposStore instance is invoked and the hook
+// queue is empty.
+func (f *ReposStoreGetByCollaboratorIDFunc) SetDefaultHook(hook func(context.Context, int64, int, string) ([]*db.Repository, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Touch method of the parent MockReposStore instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *ReposStoreTouchFunc) PushHook(hook func(context.Context, int64) error) {
+// GetByCollaboratorID method of the parent MockReposStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ReposStoreGetByCollaboratorIDFunc) PushHook(hook func(context.Context, int64, int, string) ([]*db.Repository, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2591,20 +3253,20 @@ func (f *ReposStoreTouchFunc) PushHook(hook func(context.Context, int64) error)
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ReposStoreTouchFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int64) error {
-		return r0
+func (f *ReposStoreGetByCollaboratorIDFunc) SetDefaultReturn(r0 []*db.Repository, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int, string) ([]*db.Repository, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ReposStoreTouchFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int64) error {
-		return r0
+func (f *ReposStoreGetByCollaboratorIDFunc) PushReturn(r0 []*db.Repository, r1 error) {
+	f.PushHook(func(context.Context, int64, int, string) ([]*db.Repository, error) {
+		return r0, r1
 	})
 }
 
-func (f *ReposStoreTouchFunc) nextHook() func(context.Context, int64) error {
+func (f *ReposStoreGetByCollaboratorIDFunc) nextHook() func(context.Context, int64, int, string) ([]*db.Repository, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2617,77 +3279,90 @@ func (f *ReposStoreTouchFunc) nextHook() func(context.Context, int64) error {
 	return hook
 }
 
-func (f *ReposStoreTouchFunc) appendCall(r0 ReposStoreTouchFuncCall) {
+func (f *ReposStoreGetByCollaboratorIDFunc) appendCall(r0 ReposStoreGetByCollaboratorIDFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ReposStoreTouchFuncCall objects describing
-// the invocations of this function.
-func (f *ReposStoreTouchFunc) History() []ReposStoreTouchFuncCall {
+// History returns a sequence of ReposStoreGetByCollaboratorIDFuncCall
+// objects describing the invocations of this function.
+func (f *ReposStoreGetByCollaboratorIDFunc) History() []ReposStoreGetByCollaboratorIDFuncCall {
 	f.mutex.Lock()
-	history := make([]ReposStoreTouchFuncCall, len(f.history))
+	history := make([]ReposStoreGetByCollaboratorIDFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ReposStoreTouchFuncCall is an object that describes an invocation of
-// method Touch on an instance of MockReposStore.
-type ReposStoreTouchFuncCall struct {
+// ReposStoreGetByCollaboratorIDFuncCall is an object that describes an
+// invocation of method GetByCollaboratorID on an instance of
+// MockReposStore.
+type ReposStoreGetByCollaboratorIDFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 []*db.Repository
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ReposStoreTouchFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c ReposStoreGetByCollaboratorIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ReposStoreTouchFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c ReposStoreGetByCollaboratorIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// ReposStoreWatchFunc describes the behavior when the Watch method of the
-// parent MockReposStore instance is invoked.
-type ReposStoreWatchFunc struct {
-	defaultHook func(context.Context, int64, int64) error
-	hooks       []func(context.Context, int64, int64) error
-	history     []ReposStoreWatchFuncCall
+// ReposStoreGetByCollaboratorIDWithAccessModeFunc describes the behavior
+// when the GetByCollaboratorIDWithAccessMode method of the parent
+// MockReposStore instance is invoked.
+type ReposStoreGetByCollaboratorIDWithAccessModeFunc struct {
+	defaultHook func(context.Context, int64) (map[*db.Repository]db.AccessMode, error)
+	hooks       []func(context.Context, int64) (map[*db.Repository]db.AccessMode, error)
+	history     []ReposStoreGetByCollaboratorIDWithAccessModeFuncCall
 	mutex       sync.Mutex
 }
 
-// Watch delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockReposStore) Watch(v0 context.Context, v1 int64, v2 int64) error {
-	r0 := m.WatchFunc.nextHook()(v0, v1, v2)
-	m.WatchFunc.appendCall(ReposStoreWatchFuncCall{v0, v1, v2, r0})
-	return r0
+// GetByCollaboratorIDWithAccessMode delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockReposStore) GetByCollaboratorIDWithAccessMode(v0 context.Context, v1 int64) (map[*db.Repository]db.AccessMode, error) {
+	r0, r1 := m.GetByCollaboratorIDWithAccessModeFunc.nextHook()(v0, v1)
+	m.GetByCollaboratorIDWithAccessModeFunc.appendCall(ReposStoreGetByCollaboratorIDWithAccessModeFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Watch method of the
-// parent MockReposStore instance is invoked and the hook queue is empty.
-func (f *ReposStoreWatchFunc) SetDefaultHook(hook func(context.Context, int64, int64) error) {
+// SetDefaultHook sets function that is called when the
+// GetByCollaboratorIDWithAccessMode method of the parent MockReposStore
+// instance is invoked and the hook queue is empty.
+func (f *ReposStoreGetByCollaboratorIDWithAccessModeFunc) SetDefaultHook(hook func(context.Context, int64) (map[*db.Repository]db.AccessMode, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Watch method of the parent MockReposStore instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *ReposStoreWatchFunc) PushHook(hook func(context.Context, int64, int64) error) {
+// GetByCollaboratorIDWithAccessMode method of the parent MockReposStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *ReposStoreGetByCollaboratorIDWithAccessModeFunc) PushHook(hook func(context.Context, int64) (map[*db.Repository]db.AccessMode, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2695,20 +3370,20 @@ func (f *ReposStoreWatchFunc) PushHook(hook func(context.Context, int64, int64)
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ReposStoreWatchFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int64, int64) error {
-		return r0
+func (f *ReposStoreGetByCollaboratorIDWithAccessModeFunc) SetDefaultReturn(r0 map[*db.Repository]db.AccessMode, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (map[*db.Repository]db.AccessMode, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ReposStoreWatchFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int64, int64) error {
-		return r0
+func (f *ReposStoreGetByCollaboratorIDWithAccessModeFunc) PushReturn(r0 map[*db.Repository]db.AccessMode, r1 error) {
+	f.PushHook(func(context.Context, int64) (map[*db.Repository]db.AccessMode, error) {
+		return r0, r1
 	})
 }
 
-func (f *ReposStoreWatchFunc) nextHook() func(context.Context, int64, int64) error {
+func (f *ReposStoreGetByCollaboratorIDWithAccessModeFunc) nextHook() func(context.Context, int64) (map[*db.Repository]db.AccessMode, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2721,158 +3396,83 @@ func (f *ReposStoreWatchFunc) nextHook() func(context.Context, int64, int64) err
 	return hook
 }
 
-func (f *ReposStoreWatchFunc) appendCall(r0 ReposStoreWatchFuncCall) {
+func (f *ReposStoreGetByCollaboratorIDWithAccessModeFunc) appendCall(r0 ReposStoreGetByCollaboratorIDWithAccessModeFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ReposStoreWatchFuncCall objects describing
+// History returns a sequence of
+// ReposStoreGetByCollaboratorIDWithAccessModeFuncCall objects describing
 // the invocations of this function.
-func (f *ReposStoreWatchFunc) History() []ReposStoreWatchFuncCall {
+func (f *ReposStoreGetByCollaboratorIDWithAccessModeFunc) History() []ReposStoreGetByCollaboratorIDWithAccessModeFuncCall {
 	f.mutex.Lock()
-	history := make([]ReposStoreWatchFuncCall, len(f.history))
+	history := make([]ReposStoreGetByCollaboratorIDWithAccessModeFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ReposStoreWatchFuncCall is an object that describes an invocation of
-// method Watch on an instance of MockReposStore.
-type ReposStoreWatchFuncCall struct {
+// ReposStoreGetByCollaboratorIDWithAccessModeFuncCall is an object that
+// describes an invocation of method GetByCollaboratorIDWithAccessMode on an
+// instance of MockReposStore.
+type ReposStoreGetByCollaboratorIDWithAccessModeFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int64
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 map[*db.Repository]db.AccessMode
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ReposStoreWatchFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c ReposStoreGetByCollaboratorIDWithAccessModeFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ReposStoreWatchFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
-}
-
-// MockTwoFactorsStore is a mock implementation of the TwoFactorsStore
-// interface (from the package gogs.io/gogs/internal/db) used for unit
-// testing.
-type MockTwoFactorsStore struct {
-	// CreateFunc is an instance of a mock function object controlling the
-	// behavior of the method Create.
-	CreateFunc *TwoFactorsStoreCreateFunc
-	// GetByUserIDFunc is an instance of a mock function object controlling
-	// the behavior of the method GetByUserID.
-	GetByUserIDFunc *TwoFactorsStoreGetByUserIDFunc
-	// IsEnabledFunc is an instance of a mock function object controlling
-	// the behavior of the method IsEnabled.
-	IsEnabledFunc *TwoFactorsStoreIsEnabledFunc
-}
-
-// NewMockTwoFactorsStore creates a new mock of the TwoFactorsStore
-// interface. All methods return zero values for all results, unless
-// overwritten.
-func NewMockTwoFactorsStore() *MockTwoFactorsStore {
-	return &MockTwoFactorsStore{
-		CreateFunc: &TwoFactorsStoreCreateFunc{
-			defaultHook: func(context.Context, int64, string, string) (r0 error) {
-				return
-			},
-		},
-		GetByUserIDFunc: &TwoFactorsStoreGetByUserIDFunc{
-			defaultHook: func(context.Context, int64) (r0 *db.TwoFactor, r1 error) {
-				return
-			},
-		},
-		IsEnabledFunc: &TwoFactorsStoreIsEnabledFunc{
-			defaultHook: func(context.Context, int64) (r0 bool) {
-				return
-			},
-		},
-	}
-}
-
-// NewStrictMockTwoFactorsStore creates a new mock of the TwoFactorsStore
-// interface. All methods panic on invocation, unless overwritten.
-func NewStrictMockTwoFactorsStore() *MockTwoFactorsStore {
-	return &MockTwoFactorsStore{
-		CreateFunc: &TwoFactorsStoreCreateFunc{
-			defaultHook: func(context.Context, int64, string, string) error {
-				panic("unexpected invocation of MockTwoFactorsStore.Create")
-			},
-		},
-		GetByUserIDFunc: &TwoFactorsStoreGetByUserIDFunc{
-			defaultHook: func(context.Context, int64) (*db.TwoFactor, error) {
-				panic("unexpected invocation of MockTwoFactorsStore.GetByUserID")
-			},
-		},
-		IsEnabledFunc: &TwoFactorsStoreIsEnabledFunc{
-			defaultHook: func(context.Context, int64) bool {
-				panic("unexpected invocation of MockTwoFactorsStore.IsEnabled")
-			},
-		},
-	}
-}
-
-// NewMockTwoFactorsStoreFrom creates a new mock of the MockTwoFactorsStore
-// interface. All methods delegate to the given implementation, unless
-// overwritten.
-func NewMockTwoFactorsStoreFrom(i db.TwoFactorsStore) *MockTwoFactorsStore {
-	return &MockTwoFactorsStore{
-		CreateFunc: &TwoFactorsStoreCreateFunc{
-			defaultHook: i.Create,
-		},
-		GetByUserIDFunc: &TwoFactorsStoreGetByUserIDFunc{
-			defaultHook: i.GetByUserID,
-		},
-		IsEnabledFunc: &TwoFactorsStoreIsEnabledFunc{
-			defaultHook: i.IsEnabled,
-		},
-	}
+func (c ReposStoreGetByCollaboratorIDWithAccessModeFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// TwoFactorsStoreCreateFunc describes the behavior when the Create method
-// of the parent MockTwoFactorsStore instance is invoked.
-type TwoFactorsStoreCreateFunc struct {
-	defaultHook func(context.Context, int64, string, string) error
-	hooks       []func(context.Context, int64, string, string) error
-	history     []TwoFactorsStoreCreateFuncCall
+// ReposStoreGetByIDFunc describes the behavior when the GetByID method of
+// the parent MockReposStore instance is invoked.
+type ReposStoreGetByIDFunc struct {
+	defaultHook func(context.Context, int64) (*db.Repository, error)
+	hooks       []func(context.Context, int64) (*db.Repository, error)
+	history     []ReposStoreGetByIDFuncCall
 	mutex       sync.Mutex
 }
 
-// Create delegates to the next hook function in the queue and stores the
+// GetByID delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockTwoFactorsStore) Create(v0 context.Context, v1 int64, v2 string, v3 string) error {
-	r0 := m.CreateFunc.nextHook()(v0, v1, v2, v3)
-	m.CreateFunc.appendCall(TwoFactorsStoreCreateFuncCall{v0, v1, v2, v3, r0})
-	return r0
+func (m *MockReposStore) GetByID(v0 context.Context, v1 int64) (*db.Repository, error) {
+	r0, r1 := m.GetByIDFunc.nextHook()(v0, v1)
+	m.GetByIDFunc.appendCall(ReposStoreGetByIDFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Create method of the
-// parent MockTwoFactorsStore instance is invoked and the hook queue is
+// SetDefaultHook sets function that is called when the GetByID method of
+// the parent MockReposStore instance is invoked and the hook queue is
 // empty.
-func (f *TwoFactorsStoreCreateFunc) SetDefaultHook(hook func(context.Context, int64, string, string) error) {
+func (f *ReposStoreGetByIDFunc) SetDefaultHook(hook func(context.Context, int64) (*db.Repository, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Create method of the parent MockTwoFactorsStore instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
+// GetByID method of the parent MockReposStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
 // default hook function is invoked for any future action.
-func (f *TwoFactorsStoreCreateFunc) PushHook(hook func(context.Context, int64, string, string) error) {
+func (f *ReposStoreGetByIDFunc) PushHook(hook func(context.Context, int64) (*db.Repository, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2880,20 +3480,20 @@ func (f *TwoFactorsStoreCreateFunc) PushHook(hook func(context.Context, int64, s
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *TwoFactorsStoreCreateFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int64, string, string) error {
-		return r0
+func (f *ReposStoreGetByIDFunc) SetDefaultReturn(r0 *db.Repository, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*db.Repository, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *TwoFactorsStoreCreateFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int64, string, string) error {
-		return r0
+func (f *ReposStoreGetByIDFunc) PushReturn(r0 *db.Repository, r1 error) {
+	f.PushHook(func(context.Context, int64) (*db.Repository, error) {
+		return r0, r1
 	})
 }
 
-func (f *TwoFactorsStoreCreateFunc) nextHook() func(context.Context, int64, string, string) error {
+func (f *ReposStoreGetByIDFunc) nextHook() func(context.Context, int64) (*db.Repository, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2906,84 +3506,81 @@ func (f *TwoFactorsStoreCreateFunc) nextHook() func(context.Context, int64, stri
 	return hook
 }
 
-func (f *TwoFactorsStoreCreateFunc) appendCall(r0 TwoFactorsStoreCreateFuncCall) {
+func (f *ReposStoreGetByIDFunc) appendCall(r0 ReposStoreGetByIDFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of TwoFactorsStoreCreateFuncCall objects
+// History returns a sequence of ReposStoreGetByIDFuncCall objects
 // describing the invocations of this function.
-func (f *TwoFactorsStoreCreateFunc) History() []TwoFactorsStoreCreateFuncCall {
+func (f *ReposStoreGetByIDFunc) History() []ReposStoreGetByIDFuncCall {
 	f.mutex.Lock()
-	history := make([]TwoFactorsStoreCreateFuncCall, len(f.history))
+	history := make([]ReposStoreGetByIDFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// TwoFactorsStoreCreateFuncCall is an object that describes an invocation
-// of method Create on an instance of MockTwoFactorsStore.
-type TwoFactorsStoreCreateFuncCall struct {
+// ReposStoreGetByIDFuncCall is an object that describes an invocation of
+// method GetByID on an instance of MockReposStore.
+type ReposStoreGetByIDFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int64
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 string
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 *db.Repository
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c TwoFactorsStoreCreateFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c ReposStoreGetByIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c TwoFactorsStoreCreateFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c ReposStoreGetByIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// TwoFactorsStoreGetByUserIDFunc describes the behavior when the
-// GetByUserID method of the parent MockTwoFactorsStore instance is invoked.
-type TwoFactorsStoreGetByUserIDFunc struct {
-	defaultHook func(context.Context, int64) (*db.TwoFactor, error)
-	hooks       []func(context.Context, int64) (*db.TwoFactor, error)
-	history     []TwoFactorsStoreGetByUserIDFuncCall
+// ReposStoreGetByNameFunc describes the behavior when the GetByName method
+// of the parent MockReposStore instance is invoked.
+type ReposStoreGetByNameFunc struct {
+	defaultHook func(context.Context, int64, string) (*db.Repository, error)
+	hooks       []func(context.Context, int64, string) (*db.Repository, error)
+	history     []ReposStoreGetByNameFuncCall
 	mutex       sync.Mutex
 }
 
-// GetByUserID delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockTwoFactorsStore) GetByUserID(v0 context.Context, v1 int64) (*db.TwoFactor, error) {
-	r0, r1 := m.GetByUserIDFunc.nextHook()(v0, v1)
-	m.GetByUserIDFunc.appendCall(TwoFactorsStoreGetByUserIDFuncCall{v0, v1, r0, r1})
+// GetByName delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockReposStore) GetByName(v0 context.Context, v1 int64, v2 string) (*db.Repository, error) {
+	r0, r1 := m.GetByNameFunc.nextHook()(v0, v1, v2)
+	m.GetByNameFunc.appendCall(ReposStoreGetByNameFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetByUserID method
-// of the parent MockTwoFactorsStore instance is invoked and the hook queue
-// is empty.
-func (f *TwoFactorsStoreGetByUserIDFunc) SetDefaultHook(hook func(context.Context, int64) (*db.TwoFactor, error)) {
+// SetDefaultHook sets function that is called when the GetByName method of
+// the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreGetByNameFunc) SetDefaultHook(hook func(context.Context, int64, string) (*db.Repository, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetByUserID method of the parent MockTwoFactorsStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *TwoFactorsStoreGetByUserIDFunc) PushHook(hook func(context.Context, int64) (*db.TwoFactor, error)) {
+// GetByName method of the parent MockReposStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreGetByNameFunc) PushHook(hook func(context.Context, int64, string) (*db.Repository, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2991,20 +3588,20 @@ func (f *TwoFactorsStoreGetByUserIDFunc) PushHook(hook func(context.Context, int
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *TwoFactorsStoreGetByUserIDFunc) SetDefaultReturn(r0 *db.TwoFactor, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64) (*db.TwoFactor, error) {
+func (f *ReposStoreGetByNameFunc) SetDefaultReturn(r0 *db.Repository, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, string) (*db.Repository, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *TwoFactorsStoreGetByUserIDFunc) PushReturn(r0 *db.TwoFactor, r1 error) {
-	f.PushHook(func(context.Context, int64) (*db.TwoFactor, error) {
+func (f *ReposStoreGetByNameFunc) PushReturn(r0 *db.Repository, r1 error) {
+	f.PushHook(func(context.Context, int64, string) (*db.Repository, error) {
 		return r0, r1
 	})
 }
 
-func (f *TwoFactorsStoreGetByUserIDFunc) nextHook() func(context.Context, int64) (*db.TwoFactor, error) {
+func (f *ReposStoreGetByNameFunc) nextHook() func(context.Context, int64, string) (*db.Repository, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -3017,35 +3614,38 @@ func (f *TwoFactorsStoreGetByUserIDFunc) nextHook() func(context.Context, int64)
 	return hook
 }
 
-func (f *TwoFactorsStoreGetByUserIDFunc) appendCall(r0 TwoFactorsStoreGetByUserIDFuncCall) {
+func (f *ReposStoreGetByNameFunc) appendCall(r0 ReposStoreGetByNameFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of TwoFactorsStoreGetByUserIDFuncCall objects
+// History returns a sequence of ReposStoreGetByNameFuncCall objects
 // describing the invocations of this function.
-func (f *TwoFactorsStoreGetByUserIDFunc) History() []TwoFactorsStoreGetByUserIDFuncCall {
+func (f *ReposStoreGetByNameFunc) History() []ReposStoreGetByNameFuncCall {
 	f.mutex.Lock()
-	history := make([]TwoFactorsStoreGetByUserIDFuncCall, len(f.history))
+	history := make([]ReposStoreGetByNameFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// TwoFactorsStoreGetByUserIDFuncCall is an object that describes an
-// invocation of method GetByUserID on an instance of MockTwoFactorsStore.
-type TwoFactorsStoreGetByUserIDFuncCall struct {
+// ReposStoreGetByNameFuncCall is an object that describes an invocation of
+// method GetByName on an instance of MockReposStore.
+type ReposStoreGetByNameFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *db.TwoFactor
+	Result0 *db.Repository
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -3053,22 +3653,2837 @@ type TwoFactorsStoreGetByUserIDFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c TwoFactorsStoreGetByUserIDFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c ReposStoreGetByNameFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c TwoFactorsStoreGetByUserIDFuncCall) Results() []interface{} {
+func (c ReposStoreGetByNameFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// TwoFactorsStoreIsEnabledFunc describes the behavior when the IsEnabled
-// method of the parent MockTwoFactorsStore instance is invoked.
-type TwoFactorsStoreIsEnabledFunc struct {
-	defaultHook func(context.Context, int64) bool
-	hooks       []func(context.Context, int64) bool
-	history     []TwoFactorsStoreIsEnabledFuncCall
+// ReposStoreGetByRedirectFunc describes the behavior when the GetByRedirect
+// method of the parent MockReposStore instance is invoked.
+type ReposStoreGetByRedirectFunc struct {
+	defaultHook func(context.Context, int64, string) (*db.Repository, error)
+	hooks       []func(context.Context, int64, string) (*db.Repository, error)
+	history     []ReposStoreGetByRedirectFuncCall
+	mutex       sync.Mutex
+}
+
+// GetByRedirect delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockReposStore) GetByRedirect(v0 context.Context, v1 int64, v2 string) (*db.Repository, error) {
+	r0, r1 := m.GetByRedirectFunc.nextHook()(v0, v1, v2)
+	m.GetByRedirectFunc.appendCall(ReposStoreGetByRedirectFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetByRedirect method
+// of the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreGetByRedirectFunc) SetDefaultHook(hook func(context.Context, int64, string) (*db.Repository, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetByRedirect method of the parent MockReposStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ReposStoreGetByRedirectFunc) PushHook(hook func(context.Context, int64, string) (*db.Repository, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreGetByRedirectFunc) SetDefaultReturn(r0 *db.Repository, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, string) (*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreGetByRedirectFunc) PushReturn(r0 *db.Repository, r1 error) {
+	f.PushHook(func(context.Context, int64, string) (*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreGetByRedirectFunc) nextHook() func(context.Context, int64, string) (*db.Repository, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreGetByRedirectFunc) appendCall(r0 ReposStoreGetByRedirectFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreGetByRedirectFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreGetByRedirectFunc) History() []ReposStoreGetByRedirectFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreGetByRedirectFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreGetByRedirectFuncCall is an object that describes an invocation
+// of method GetByRedirect on an instance of MockReposStore.
+type ReposStoreGetByRedirectFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *db.Repository
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreGetByRedirectFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreGetByRedirectFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreHasForkedByFunc describes the behavior when the HasForkedBy
+// method of the parent MockReposStore instance is invoked.
+type ReposStoreHasForkedByFunc struct {
+	defaultHook func(context.Context, int64, int64) bool
+	hooks       []func(context.Context, int64, int64) bool
+	history     []ReposStoreHasForkedByFuncCall
+	mutex       sync.Mutex
+}
+
+// HasForkedBy delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockReposStore) HasForkedBy(v0 context.Context, v1 int64, v2 int64) bool {
+	r0 := m.HasForkedByFunc.nextHook()(v0, v1, v2)
+	m.HasForkedByFunc.appendCall(ReposStoreHasForkedByFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the HasForkedBy method
+// of the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreHasForkedByFunc) SetDefaultHook(hook func(context.Context, int64, int64) bool) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// HasForkedBy method of the parent MockReposStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreHasForkedByFunc) PushHook(hook func(context.Context, int64, int64) bool) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreHasForkedByFunc) SetDefaultReturn(r0 bool) {
+	f.SetDefaultHook(func(context.Context, int64, int64) bool {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreHasForkedByFunc) PushReturn(r0 bool) {
+	f.PushHook(func(context.Context, int64, int64) bool {
+		return r0
+	})
+}
+
+func (f *ReposStoreHasForkedByFunc) nextHook() func(context.Context, int64, int64) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreHasForkedByFunc) appendCall(r0 ReposStoreHasForkedByFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreHasForkedByFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreHasForkedByFunc) History() []ReposStoreHasForkedByFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreHasForkedByFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreHasForkedByFuncCall is an object that describes an invocation
+// of method HasForkedBy on an instance of MockReposStore.
+type ReposStoreHasForkedByFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreHasForkedByFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreHasForkedByFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreIsNameAvailableFunc describes the behavior when the
+// IsNameAvailable method of the parent MockReposStore instance is invoked.
+type ReposStoreIsNameAvailableFunc struct {
+	defaultHook func(context.Context, int64, string) (bool, error)
+	hooks       []func(context.Context, int64, string) (bool, error)
+	history     []ReposStoreIsNameAvailableFuncCall
+	mutex       sync.Mutex
+}
+
+// IsNameAvailable delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockReposStore) IsNameAvailable(v0 context.Context, v1 int64, v2 string) (bool, error) {
+	r0, r1 := m.IsNameAvailableFunc.nextHook()(v0, v1, v2)
+	m.IsNameAvailableFunc.appendCall(ReposStoreIsNameAvailableFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the IsNameAvailable
+// method of the parent MockReposStore instance is invoked and the hook
+// queue is empty.
+func (f *ReposStoreIsNameAvailableFunc) SetDefaultHook(hook func(context.Context, int64, string) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// IsNameAvailable method of the parent MockReposStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ReposStoreIsNameAvailableFunc) PushHook(hook func(context.Context, int64, string) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreIsNameAvailableFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, string) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreIsNameAvailableFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, int64, string) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreIsNameAvailableFunc) nextHook() func(context.Context, int64, string) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreIsNameAvailableFunc) appendCall(r0 ReposStoreIsNameAvailableFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreIsNameAvailableFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreIsNameAvailableFunc) History() []ReposStoreIsNameAvailableFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreIsNameAvailableFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreIsNameAvailableFuncCall is an object that describes an
+// invocation of method IsNameAvailable on an instance of MockReposStore.
+type ReposStoreIsNameAvailableFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreIsNameAvailableFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreIsNameAvailableFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreListMirrorsToSyncFunc describes the behavior when the
+// ListMirrorsToSync method of the parent MockReposStore instance is
+// invoked.
+type ReposStoreListMirrorsToSyncFunc struct {
+	defaultHook func(context.Context, int64, int, int) ([]*db.Mirror, error)
+	hooks       []func(context.Context, int64, int, int) ([]*db.Mirror, error)
+	history     []ReposStoreListMirrorsToSyncFuncCall
+	mutex       sync.Mutex
+}
+
+// ListMirrorsToSync delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockReposStore) ListMirrorsToSync(v0 context.Context, v1 int64, v2 int, v3 int) ([]*db.Mirror, error) {
+	r0, r1 := m.ListMirrorsToSyncFunc.nextHook()(v0, v1, v2, v3)
+	m.ListMirrorsToSyncFunc.appendCall(ReposStoreListMirrorsToSyncFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListMirrorsToSync
+// method of the parent MockReposStore instance is invoked and the hook
+// queue is empty.
+func (f *ReposStoreListMirrorsToSyncFunc) SetDefaultHook(hook func(context.Context, int64, int, int) ([]*db.Mirror, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListMirrorsToSync method of the parent MockReposStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ReposStoreListMirrorsToSyncFunc) PushHook(hook func(context.Context, int64, int, int) ([]*db.Mirror, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreListMirrorsToSyncFunc) SetDefaultReturn(r0 []*db.Mirror, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int, int) ([]*db.Mirror, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreListMirrorsToSyncFunc) PushReturn(r0 []*db.Mirror, r1 error) {
+	f.PushHook(func(context.Context, int64, int, int) ([]*db.Mirror, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreListMirrorsToSyncFunc) nextHook() func(context.Context, int64, int, int) ([]*db.Mirror, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreListMirrorsToSyncFunc) appendCall(r0 ReposStoreListMirrorsToSyncFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreListMirrorsToSyncFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreListMirrorsToSyncFunc) History() []ReposStoreListMirrorsToSyncFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreListMirrorsToSyncFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreListMirrorsToSyncFuncCall is an object that describes an
+// invocation of method ListMirrorsToSync on an instance of MockReposStore.
+type ReposStoreListMirrorsToSyncFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.Mirror
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreListMirrorsToSyncFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreListMirrorsToSyncFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreListProtectedTagsFunc describes the behavior when the
+// ListProtectedTags method of the parent MockReposStore instance is
+// invoked.
+type ReposStoreListProtectedTagsFunc struct {
+	defaultHook func(context.Context, int64) ([]*db.ProtectedTag, error)
+	hooks       []func(context.Context, int64) ([]*db.ProtectedTag, error)
+	history     []ReposStoreListProtectedTagsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListProtectedTags delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockReposStore) ListProtectedTags(v0 context.Context, v1 int64) ([]*db.ProtectedTag, error) {
+	r0, r1 := m.ListProtectedTagsFunc.nextHook()(v0, v1)
+	m.ListProtectedTagsFunc.appendCall(ReposStoreListProtectedTagsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListProtectedTags
+// method of the parent MockReposStore instance is invoked and the hook
+// queue is empty.
+func (f *ReposStoreListProtectedTagsFunc) SetDefaultHook(hook func(context.Context, int64) ([]*db.ProtectedTag, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListProtectedTags method of the parent MockReposStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ReposStoreListProtectedTagsFunc) PushHook(hook func(context.Context, int64) ([]*db.ProtectedTag, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreListProtectedTagsFunc) SetDefaultReturn(r0 []*db.ProtectedTag, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) ([]*db.ProtectedTag, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreListProtectedTagsFunc) PushReturn(r0 []*db.ProtectedTag, r1 error) {
+	f.PushHook(func(context.Context, int64) ([]*db.ProtectedTag, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreListProtectedTagsFunc) nextHook() func(context.Context, int64) ([]*db.ProtectedTag, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreListProtectedTagsFunc) appendCall(r0 ReposStoreListProtectedTagsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreListProtectedTagsFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreListProtectedTagsFunc) History() []ReposStoreListProtectedTagsFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreListProtectedTagsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreListProtectedTagsFuncCall is an object that describes an
+// invocation of method ListProtectedTags on an instance of MockReposStore.
+type ReposStoreListProtectedTagsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.ProtectedTag
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreListProtectedTagsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreListProtectedTagsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreListTopicsFunc describes the behavior when the ListTopics
+// method of the parent MockReposStore instance is invoked.
+type ReposStoreListTopicsFunc struct {
+	defaultHook func(context.Context, int64) ([]*db.Topic, error)
+	hooks       []func(context.Context, int64) ([]*db.Topic, error)
+	history     []ReposStoreListTopicsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListTopics delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockReposStore) ListTopics(v0 context.Context, v1 int64) ([]*db.Topic, error) {
+	r0, r1 := m.ListTopicsFunc.nextHook()(v0, v1)
+	m.ListTopicsFunc.appendCall(ReposStoreListTopicsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListTopics method of
+// the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreListTopicsFunc) SetDefaultHook(hook func(context.Context, int64) ([]*db.Topic, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListTopics method of the parent MockReposStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreListTopicsFunc) PushHook(hook func(context.Context, int64) ([]*db.Topic, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreListTopicsFunc) SetDefaultReturn(r0 []*db.Topic, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) ([]*db.Topic, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreListTopicsFunc) PushReturn(r0 []*db.Topic, r1 error) {
+	f.PushHook(func(context.Context, int64) ([]*db.Topic, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreListTopicsFunc) nextHook() func(context.Context, int64) ([]*db.Topic, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreListTopicsFunc) appendCall(r0 ReposStoreListTopicsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreListTopicsFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreListTopicsFunc) History() []ReposStoreListTopicsFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreListTopicsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreListTopicsFuncCall is an object that describes an invocation of
+// method ListTopics on an instance of MockReposStore.
+type ReposStoreListTopicsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.Topic
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreListTopicsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreListTopicsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreListWatchedByUserInOrgFunc describes the behavior when the
+// ListWatchedByUserInOrg method of the parent MockReposStore instance is
+// invoked.
+type ReposStoreListWatchedByUserInOrgFunc struct {
+	defaultHook func(context.Context, int64, int64) ([]*db.Repository, error)
+	hooks       []func(context.Context, int64, int64) ([]*db.Repository, error)
+	history     []ReposStoreListWatchedByUserInOrgFuncCall
+	mutex       sync.Mutex
+}
+
+// ListWatchedByUserInOrg delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockReposStore) ListWatchedByUserInOrg(v0 context.Context, v1 int64, v2 int64) ([]*db.Repository, error) {
+	r0, r1 := m.ListWatchedByUserInOrgFunc.nextHook()(v0, v1, v2)
+	m.ListWatchedByUserInOrgFunc.appendCall(ReposStoreListWatchedByUserInOrgFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListWatchedByUserInOrg method of the parent MockReposStore instance is
+// invoked and the hook queue is empty.
+func (f *ReposStoreListWatchedByUserInOrgFunc) SetDefaultHook(hook func(context.Context, int64, int64) ([]*db.Repository, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListWatchedByUserInOrg method of the parent MockReposStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ReposStoreListWatchedByUserInOrgFunc) PushHook(hook func(context.Context, int64, int64) ([]*db.Repository, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreListWatchedByUserInOrgFunc) SetDefaultReturn(r0 []*db.Repository, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) ([]*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreListWatchedByUserInOrgFunc) PushReturn(r0 []*db.Repository, r1 error) {
+	f.PushHook(func(context.Context, int64, int64) ([]*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreListWatchedByUserInOrgFunc) nextHook() func(context.Context, int64, int64) ([]*db.Repository, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreListWatchedByUserInOrgFunc) appendCall(r0 ReposStoreListWatchedByUserInOrgFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreListWatchedByUserInOrgFuncCall
+// objects describing the invocations of this function.
+func (f *ReposStoreListWatchedByUserInOrgFunc) History() []ReposStoreListWatchedByUserInOrgFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreListWatchedByUserInOrgFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreListWatchedByUserInOrgFuncCall is an object that describes an
+// invocation of method ListWatchedByUserInOrg on an instance of
+// MockReposStore.
+type ReposStoreListWatchedByUserInOrgFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.Repository
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreListWatchedByUserInOrgFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreListWatchedByUserInOrgFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreListWatchesFunc describes the behavior when the ListWatches
+// method of the parent MockReposStore instance is invoked.
+type ReposStoreListWatchesFunc struct {
+	defaultHook func(context.Context, int64) ([]*db.Watch, error)
+	hooks       []func(context.Context, int64) ([]*db.Watch, error)
+	history     []ReposStoreListWatchesFuncCall
+	mutex       sync.Mutex
+}
+
+// ListWatches delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockReposStore) ListWatches(v0 context.Context, v1 int64) ([]*db.Watch, error) {
+	r0, r1 := m.ListWatchesFunc.nextHook()(v0, v1)
+	m.ListWatchesFunc.appendCall(ReposStoreListWatchesFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListWatches method
+// of the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreListWatchesFunc) SetDefaultHook(hook func(context.Context, int64) ([]*db.Watch, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListWatches method of the parent MockReposStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreListWatchesFunc) PushHook(hook func(context.Context, int64) ([]*db.Watch, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreListWatchesFunc) SetDefaultReturn(r0 []*db.Watch, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) ([]*db.Watch, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreListWatchesFunc) PushReturn(r0 []*db.Watch, r1 error) {
+	f.PushHook(func(context.Context, int64) ([]*db.Watch, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreListWatchesFunc) nextHook() func(context.Context, int64) ([]*db.Watch, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreListWatchesFunc) appendCall(r0 ReposStoreListWatchesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreListWatchesFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreListWatchesFunc) History() []ReposStoreListWatchesFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreListWatchesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreListWatchesFuncCall is an object that describes an invocation
+// of method ListWatches on an instance of MockReposStore.
+type ReposStoreListWatchesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.Watch
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreListWatchesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreListWatchesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreRenameFunc describes the behavior when the Rename method of the
+// parent MockReposStore instance is invoked.
+type ReposStoreRenameFunc struct {
+	defaultHook func(context.Context, int64, string) error
+	hooks       []func(context.Context, int64, string) error
+	history     []ReposStoreRenameFuncCall
+	mutex       sync.Mutex
+}
+
+// Rename delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockReposStore) Rename(v0 context.Context, v1 int64, v2 string) error {
+	r0 := m.RenameFunc.nextHook()(v0, v1, v2)
+	m.RenameFunc.appendCall(ReposStoreRenameFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Rename method of the
+// parent MockReposStore instance is invoked and the hook queue is empty.
+func (f *ReposStoreRenameFunc) SetDefaultHook(hook func(context.Context, int64, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Rename method of the parent MockReposStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreRenameFunc) PushHook(hook func(context.Context, int64, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreRenameFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreRenameFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, string) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreRenameFunc) nextHook() func(context.Context, int64, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreRenameFunc) appendCall(r0 ReposStoreRenameFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreRenameFuncCall objects describing
+// the invocations of this function.
+func (f *ReposStoreRenameFunc) History() []ReposStoreRenameFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreRenameFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreRenameFuncCall is an object that describes an invocation of
+// method Rename on an instance of MockReposStore.
+type ReposStoreRenameFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreRenameFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreRenameFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreRestoreFunc describes the behavior when the Restore method of
+// the parent MockReposStore instance is invoked.
+type ReposStoreRestoreFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []ReposStoreRestoreFuncCall
+	mutex       sync.Mutex
+}
+
+// Restore delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockReposStore) Restore(v0 context.Context, v1 int64) error {
+	r0 := m.RestoreFunc.nextHook()(v0, v1)
+	m.RestoreFunc.appendCall(ReposStoreRestoreFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Restore method of
+// the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreRestoreFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Restore method of the parent MockReposStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreRestoreFunc) PushHook(hook func(context.Context, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreRestoreFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreRestoreFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreRestoreFunc) nextHook() func(context.Context, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreRestoreFunc) appendCall(r0 ReposStoreRestoreFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreRestoreFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreRestoreFunc) History() []ReposStoreRestoreFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreRestoreFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreRestoreFuncCall is an object that describes an invocation of
+// method Restore on an instance of MockReposStore.
+type ReposStoreRestoreFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreRestoreFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreRestoreFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreSearchByTopicFunc describes the behavior when the SearchByTopic
+// method of the parent MockReposStore instance is invoked.
+type ReposStoreSearchByTopicFunc struct {
+	defaultHook func(context.Context, string, int, int) ([]*db.Repository, int64, error)
+	hooks       []func(context.Context, string, int, int) ([]*db.Repository, int64, error)
+	history     []ReposStoreSearchByTopicFuncCall
+	mutex       sync.Mutex
+}
+
+// SearchByTopic delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockReposStore) SearchByTopic(v0 context.Context, v1 string, v2 int, v3 int) ([]*db.Repository, int64, error) {
+	r0, r1, r2 := m.SearchByTopicFunc.nextHook()(v0, v1, v2, v3)
+	m.SearchByTopicFunc.appendCall(ReposStoreSearchByTopicFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the SearchByTopic method
+// of the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreSearchByTopicFunc) SetDefaultHook(hook func(context.Context, string, int, int) ([]*db.Repository, int64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SearchByTopic method of the parent MockReposStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ReposStoreSearchByTopicFunc) PushHook(hook func(context.Context, string, int, int) ([]*db.Repository, int64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreSearchByTopicFunc) SetDefaultReturn(r0 []*db.Repository, r1 int64, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, int, int) ([]*db.Repository, int64, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreSearchByTopicFunc) PushReturn(r0 []*db.Repository, r1 int64, r2 error) {
+	f.PushHook(func(context.Context, string, int, int) ([]*db.Repository, int64, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *ReposStoreSearchByTopicFunc) nextHook() func(context.Context, string, int, int) ([]*db.Repository, int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreSearchByTopicFunc) appendCall(r0 ReposStoreSearchByTopicFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreSearchByTopicFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreSearchByTopicFunc) History() []ReposStoreSearchByTopicFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreSearchByTopicFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreSearchByTopicFuncCall is an object that describes an invocation
+// of method SearchByTopic on an instance of MockReposStore.
+type ReposStoreSearchByTopicFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.Repository
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int64
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreSearchByTopicFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreSearchByTopicFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// ReposStoreSetArchivedFunc describes the behavior when the SetArchived
+// method of the parent MockReposStore instance is invoked.
+type ReposStoreSetArchivedFunc struct {
+	defaultHook func(context.Context, int64, bool) error
+	hooks       []func(context.Context, int64, bool) error
+	history     []ReposStoreSetArchivedFuncCall
+	mutex       sync.Mutex
+}
+
+// SetArchived delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockReposStore) SetArchived(v0 context.Context, v1 int64, v2 bool) error {
+	r0 := m.SetArchivedFunc.nextHook()(v0, v1, v2)
+	m.SetArchivedFunc.appendCall(ReposStoreSetArchivedFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetArchived method
+// of the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreSetArchivedFunc) SetDefaultHook(hook func(context.Context, int64, bool) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetArchived method of the parent MockReposStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreSetArchivedFunc) PushHook(hook func(context.Context, int64, bool) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreSetArchivedFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreSetArchivedFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, bool) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreSetArchivedFunc) nextHook() func(context.Context, int64, bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreSetArchivedFunc) appendCall(r0 ReposStoreSetArchivedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreSetArchivedFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreSetArchivedFunc) History() []ReposStoreSetArchivedFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreSetArchivedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreSetArchivedFuncCall is an object that describes an invocation
+// of method SetArchived on an instance of MockReposStore.
+type ReposStoreSetArchivedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreSetArchivedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreSetArchivedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreSetCollaboratorExpiryFunc describes the behavior when the
+// SetCollaboratorExpiry method of the parent MockReposStore instance is
+// invoked.
+type ReposStoreSetCollaboratorExpiryFunc struct {
+	defaultHook func(context.Context, int64, int64, int64) error
+	hooks       []func(context.Context, int64, int64, int64) error
+	history     []ReposStoreSetCollaboratorExpiryFuncCall
+	mutex       sync.Mutex
+}
+
+// SetCollaboratorExpiry delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockReposStore) SetCollaboratorExpiry(v0 context.Context, v1 int64, v2 int64, v3 int64) error {
+	r0 := m.SetCollaboratorExpiryFunc.nextHook()(v0, v1, v2, v3)
+	m.SetCollaboratorExpiryFunc.appendCall(ReposStoreSetCollaboratorExpiryFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// SetCollaboratorExpiry method of the parent MockReposStore instance is
+// invoked and the hook queue is empty.
+func (f *ReposStoreSetCollaboratorExpiryFunc) SetDefaultHook(hook func(context.Context, int64, int64, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetCollaboratorExpiry method of the parent MockReposStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ReposStoreSetCollaboratorExpiryFunc) PushHook(hook func(context.Context, int64, int64, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreSetCollaboratorExpiryFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreSetCollaboratorExpiryFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64, int64) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreSetCollaboratorExpiryFunc) nextHook() func(context.Context, int64, int64, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreSetCollaboratorExpiryFunc) appendCall(r0 ReposStoreSetCollaboratorExpiryFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreSetCollaboratorExpiryFuncCall
+// objects describing the invocations of this function.
+func (f *ReposStoreSetCollaboratorExpiryFunc) History() []ReposStoreSetCollaboratorExpiryFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreSetCollaboratorExpiryFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreSetCollaboratorExpiryFuncCall is an object that describes an
+// invocation of method SetCollaboratorExpiry on an instance of
+// MockReposStore.
+type ReposStoreSetCollaboratorExpiryFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreSetCollaboratorExpiryFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreSetCollaboratorExpiryFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreSetExternalTrackerFunc describes the behavior when the
+// SetExternalTracker method of the parent MockReposStore instance is
+// invoked.
+type ReposStoreSetExternalTrackerFunc struct {
+	defaultHook func(context.Context, int64, db.SetExternalTrackerOptions) error
+	hooks       []func(context.Context, int64, db.SetExternalTrackerOptions) error
+	history     []ReposStoreSetExternalTrackerFuncCall
+	mutex       sync.Mutex
+}
+
+// SetExternalTracker delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockReposStore) SetExternalTracker(v0 context.Context, v1 int64, v2 db.SetExternalTrackerOptions) error {
+	r0 := m.SetExternalTrackerFunc.nextHook()(v0, v1, v2)
+	m.SetExternalTrackerFunc.appendCall(ReposStoreSetExternalTrackerFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetExternalTracker
+// method of the parent MockReposStore instance is invoked and the hook
+// queue is empty.
+func (f *ReposStoreSetExternalTrackerFunc) SetDefaultHook(hook func(context.Context, int64, db.SetExternalTrackerOptions) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetExternalTracker method of the parent MockReposStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ReposStoreSetExternalTrackerFunc) PushHook(hook func(context.Context, int64, db.SetExternalTrackerOptions) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreSetExternalTrackerFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, db.SetExternalTrackerOptions) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreSetExternalTrackerFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, db.SetExternalTrackerOptions) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreSetExternalTrackerFunc) nextHook() func(context.Context, int64, db.SetExternalTrackerOptions) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreSetExternalTrackerFunc) appendCall(r0 ReposStoreSetExternalTrackerFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreSetExternalTrackerFuncCall
+// objects describing the invocations of this function.
+func (f *ReposStoreSetExternalTrackerFunc) History() []ReposStoreSetExternalTrackerFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreSetExternalTrackerFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreSetExternalTrackerFuncCall is an object that describes an
+// invocation of method SetExternalTracker on an instance of MockReposStore.
+type ReposStoreSetExternalTrackerFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 db.SetExternalTrackerOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreSetExternalTrackerFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreSetExternalTrackerFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreSetMirrorSyncResultFunc describes the behavior when the
+// SetMirrorSyncResult method of the parent MockReposStore instance is
+// invoked.
+type ReposStoreSetMirrorSyncResultFunc struct {
+	defaultHook func(context.Context, int64, bool, string) error
+	hooks       []func(context.Context, int64, bool, string) error
+	history     []ReposStoreSetMirrorSyncResultFuncCall
+	mutex       sync.Mutex
+}
+
+// SetMirrorSyncResult delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockReposStore) SetMirrorSyncResult(v0 context.Context, v1 int64, v2 bool, v3 string) error {
+	r0 := m.SetMirrorSyncResultFunc.nextHook()(v0, v1, v2, v3)
+	m.SetMirrorSyncResultFunc.appendCall(ReposStoreSetMirrorSyncResultFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetMirrorSyncResult
+// method of the parent MockReposStore instance is invoked and the hook
+// queue is empty.
+func (f *ReposStoreSetMirrorSyncResultFunc) SetDefaultHook(hook func(context.Context, int64, bool, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetMirrorSyncResult method of the parent MockReposStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ReposStoreSetMirrorSyncResultFunc) PushHook(hook func(context.Context, int64, bool, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreSetMirrorSyncResultFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreSetMirrorSyncResultFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, bool, string) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreSetMirrorSyncResultFunc) nextHook() func(context.Context, int64, bool, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreSetMirrorSyncResultFunc) appendCall(r0 ReposStoreSetMirrorSyncResultFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreSetMirrorSyncResultFuncCall
+// objects describing the invocations of this function.
+func (f *ReposStoreSetMirrorSyncResultFunc) History() []ReposStoreSetMirrorSyncResultFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreSetMirrorSyncResultFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreSetMirrorSyncResultFuncCall is an object that describes an
+// invocation of method SetMirrorSyncResult on an instance of
+// MockReposStore.
+type ReposStoreSetMirrorSyncResultFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreSetMirrorSyncResultFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreSetMirrorSyncResultFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreSetPrivateFunc describes the behavior when the SetPrivate
+// method of the parent MockReposStore instance is invoked.
+type ReposStoreSetPrivateFunc struct {
+	defaultHook func(context.Context, int64, bool) error
+	hooks       []func(context.Context, int64, bool) error
+	history     []ReposStoreSetPrivateFuncCall
+	mutex       sync.Mutex
+}
+
+// SetPrivate delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockReposStore) SetPrivate(v0 context.Context, v1 int64, v2 bool) error {
+	r0 := m.SetPrivateFunc.nextHook()(v0, v1, v2)
+	m.SetPrivateFunc.appendCall(ReposStoreSetPrivateFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetPrivate method of
+// the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreSetPrivateFunc) SetDefaultHook(hook func(context.Context, int64, bool) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetPrivate method of the parent MockReposStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreSetPrivateFunc) PushHook(hook func(context.Context, int64, bool) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreSetPrivateFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreSetPrivateFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, bool) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreSetPrivateFunc) nextHook() func(context.Context, int64, bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreSetPrivateFunc) appendCall(r0 ReposStoreSetPrivateFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreSetPrivateFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreSetPrivateFunc) History() []ReposStoreSetPrivateFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreSetPrivateFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreSetPrivateFuncCall is an object that describes an invocation of
+// method SetPrivate on an instance of MockReposStore.
+type ReposStoreSetPrivateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreSetPrivateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreSetPrivateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreSetTopicsFunc describes the behavior when the SetTopics method
+// of the parent MockReposStore instance is invoked.
+type ReposStoreSetTopicsFunc struct {
+	defaultHook func(context.Context, int64, []string) error
+	hooks       []func(context.Context, int64, []string) error
+	history     []ReposStoreSetTopicsFuncCall
+	mutex       sync.Mutex
+}
+
+// SetTopics delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockReposStore) SetTopics(v0 context.Context, v1 int64, v2 []string) error {
+	r0 := m.SetTopicsFunc.nextHook()(v0, v1, v2)
+	m.SetTopicsFunc.appendCall(ReposStoreSetTopicsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetTopics method of
+// the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreSetTopicsFunc) SetDefaultHook(hook func(context.Context, int64, []string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetTopics method of the parent MockReposStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreSetTopicsFunc) PushHook(hook func(context.Context, int64, []string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreSetTopicsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, []string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreSetTopicsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, []string) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreSetTopicsFunc) nextHook() func(context.Context, int64, []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreSetTopicsFunc) appendCall(r0 ReposStoreSetTopicsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreSetTopicsFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreSetTopicsFunc) History() []ReposStoreSetTopicsFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreSetTopicsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreSetTopicsFuncCall is an object that describes an invocation of
+// method SetTopics on an instance of MockReposStore.
+type ReposStoreSetTopicsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreSetTopicsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreSetTopicsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreSoftDeleteFunc describes the behavior when the SoftDelete
+// method of the parent MockReposStore instance is invoked.
+type ReposStoreSoftDeleteFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []ReposStoreSoftDeleteFuncCall
+	mutex       sync.Mutex
+}
+
+// SoftDelete delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockReposStore) SoftDelete(v0 context.Context, v1 int64) error {
+	r0 := m.SoftDeleteFunc.nextHook()(v0, v1)
+	m.SoftDeleteFunc.appendCall(ReposStoreSoftDeleteFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SoftDelete method of
+// the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreSoftDeleteFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SoftDelete method of the parent MockReposStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreSoftDeleteFunc) PushHook(hook func(context.Context, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreSoftDeleteFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreSoftDeleteFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreSoftDeleteFunc) nextHook() func(context.Context, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreSoftDeleteFunc) appendCall(r0 ReposStoreSoftDeleteFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreSoftDeleteFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreSoftDeleteFunc) History() []ReposStoreSoftDeleteFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreSoftDeleteFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreSoftDeleteFuncCall is an object that describes an invocation of
+// method SoftDelete on an instance of MockReposStore.
+type ReposStoreSoftDeleteFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreSoftDeleteFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreSoftDeleteFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreStarFunc describes the behavior when the Star method of the
+// parent MockReposStore instance is invoked.
+type ReposStoreStarFunc struct {
+	defaultHook func(context.Context, int64, int64) error
+	hooks       []func(context.Context, int64, int64) error
+	history     []ReposStoreStarFuncCall
+	mutex       sync.Mutex
+}
+
+// Star delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockReposStore) Star(v0 context.Context, v1 int64, v2 int64) error {
+	r0 := m.StarFunc.nextHook()(v0, v1, v2)
+	m.StarFunc.appendCall(ReposStoreStarFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Star method of the
+// parent MockReposStore instance is invoked and the hook queue is empty.
+func (f *ReposStoreStarFunc) SetDefaultHook(hook func(context.Context, int64, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Star method of the parent MockReposStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *ReposStoreStarFunc) PushHook(hook func(context.Context, int64, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreStarFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreStarFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreStarFunc) nextHook() func(context.Context, int64, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreStarFunc) appendCall(r0 ReposStoreStarFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreStarFuncCall objects describing
+// the invocations of this function.
+func (f *ReposStoreStarFunc) History() []ReposStoreStarFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreStarFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreStarFuncCall is an object that describes an invocation of
+// method Star on an instance of MockReposStore.
+type ReposStoreStarFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreStarFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreStarFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreTouchFunc describes the behavior when the Touch method of the
+// parent MockReposStore instance is invoked.
+type ReposStoreTouchFunc struct {
+	defaultHook func(context.Context, int64, int64) error
+	hooks       []func(context.Context, int64, int64) error
+	history     []ReposStoreTouchFuncCall
+	mutex       sync.Mutex
+}
+
+// Touch delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockReposStore) Touch(v0 context.Context, v1 int64, v2 int64) error {
+	r0 := m.TouchFunc.nextHook()(v0, v1, v2)
+	m.TouchFunc.appendCall(ReposStoreTouchFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Touch method of the
+// parent MockReposStore instance is invoked and the hook queue is empty.
+func (f *ReposStoreTouchFunc) SetDefaultHook(hook func(context.Context, int64, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Touch method of the parent MockReposStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreTouchFunc) PushHook(hook func(context.Context, int64, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreTouchFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreTouchFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreTouchFunc) nextHook() func(context.Context, int64, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreTouchFunc) appendCall(r0 ReposStoreTouchFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreTouchFuncCall objects describing
+// the invocations of this function.
+func (f *ReposStoreTouchFunc) History() []ReposStoreTouchFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreTouchFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreTouchFuncCall is an object that describes an invocation of
+// method Touch on an instance of MockReposStore.
+type ReposStoreTouchFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreTouchFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreTouchFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreTransferAllByOwnerFunc describes the behavior when the
+// TransferAllByOwner method of the parent MockReposStore instance is
+// invoked.
+type ReposStoreTransferAllByOwnerFunc struct {
+	defaultHook func(context.Context, int64, int64) ([]*db.Repository, error)
+	hooks       []func(context.Context, int64, int64) ([]*db.Repository, error)
+	history     []ReposStoreTransferAllByOwnerFuncCall
+	mutex       sync.Mutex
+}
+
+// TransferAllByOwner delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockReposStore) TransferAllByOwner(v0 context.Context, v1 int64, v2 int64) ([]*db.Repository, error) {
+	r0, r1 := m.TransferAllByOwnerFunc.nextHook()(v0, v1, v2)
+	m.TransferAllByOwnerFunc.appendCall(ReposStoreTransferAllByOwnerFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the TransferAllByOwner
+// method of the parent MockReposStore instance is invoked and the hook
+// queue is empty.
+func (f *ReposStoreTransferAllByOwnerFunc) SetDefaultHook(hook func(context.Context, int64, int64) ([]*db.Repository, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// TransferAllByOwner method of the parent MockReposStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ReposStoreTransferAllByOwnerFunc) PushHook(hook func(context.Context, int64, int64) ([]*db.Repository, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreTransferAllByOwnerFunc) SetDefaultReturn(r0 []*db.Repository, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) ([]*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreTransferAllByOwnerFunc) PushReturn(r0 []*db.Repository, r1 error) {
+	f.PushHook(func(context.Context, int64, int64) ([]*db.Repository, error) {
+		return r0, r1
+	})
+}
+
+func (f *ReposStoreTransferAllByOwnerFunc) nextHook() func(context.Context, int64, int64) ([]*db.Repository, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreTransferAllByOwnerFunc) appendCall(r0 ReposStoreTransferAllByOwnerFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreTransferAllByOwnerFuncCall
+// objects describing the invocations of this function.
+func (f *ReposStoreTransferAllByOwnerFunc) History() []ReposStoreTransferAllByOwnerFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreTransferAllByOwnerFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreTransferAllByOwnerFuncCall is an object that describes an
+// invocation of method TransferAllByOwner on an instance of MockReposStore.
+type ReposStoreTransferAllByOwnerFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.Repository
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreTransferAllByOwnerFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreTransferAllByOwnerFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ReposStoreUpdateSizeFunc describes the behavior when the UpdateSize
+// method of the parent MockReposStore instance is invoked.
+type ReposStoreUpdateSizeFunc struct {
+	defaultHook func(context.Context, int64, int64) error
+	hooks       []func(context.Context, int64, int64) error
+	history     []ReposStoreUpdateSizeFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateSize delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockReposStore) UpdateSize(v0 context.Context, v1 int64, v2 int64) error {
+	r0 := m.UpdateSizeFunc.nextHook()(v0, v1, v2)
+	m.UpdateSizeFunc.appendCall(ReposStoreUpdateSizeFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the UpdateSize method of
+// the parent MockReposStore instance is invoked and the hook queue is
+// empty.
+func (f *ReposStoreUpdateSizeFunc) SetDefaultHook(hook func(context.Context, int64, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateSize method of the parent MockReposStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreUpdateSizeFunc) PushHook(hook func(context.Context, int64, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreUpdateSizeFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreUpdateSizeFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreUpdateSizeFunc) nextHook() func(context.Context, int64, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreUpdateSizeFunc) appendCall(r0 ReposStoreUpdateSizeFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreUpdateSizeFuncCall objects
+// describing the invocations of this function.
+func (f *ReposStoreUpdateSizeFunc) History() []ReposStoreUpdateSizeFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreUpdateSizeFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreUpdateSizeFuncCall is an object that describes an invocation of
+// method UpdateSize on an instance of MockReposStore.
+type ReposStoreUpdateSizeFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreUpdateSizeFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreUpdateSizeFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ReposStoreWatchFunc describes the behavior when the Watch method of the
+// parent MockReposStore instance is invoked.
+type ReposStoreWatchFunc struct {
+	defaultHook func(context.Context, int64, int64) error
+	hooks       []func(context.Context, int64, int64) error
+	history     []ReposStoreWatchFuncCall
+	mutex       sync.Mutex
+}
+
+// Watch delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockReposStore) Watch(v0 context.Context, v1 int64, v2 int64) error {
+	r0 := m.WatchFunc.nextHook()(v0, v1, v2)
+	m.WatchFunc.appendCall(ReposStoreWatchFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Watch method of the
+// parent MockReposStore instance is invoked and the hook queue is empty.
+func (f *ReposStoreWatchFunc) SetDefaultHook(hook func(context.Context, int64, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Watch method of the parent MockReposStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ReposStoreWatchFunc) PushHook(hook func(context.Context, int64, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ReposStoreWatchFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ReposStoreWatchFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+func (f *ReposStoreWatchFunc) nextHook() func(context.Context, int64, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ReposStoreWatchFunc) appendCall(r0 ReposStoreWatchFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ReposStoreWatchFuncCall objects describing
+// the invocations of this function.
+func (f *ReposStoreWatchFunc) History() []ReposStoreWatchFuncCall {
+	f.mutex.Lock()
+	history := make([]ReposStoreWatchFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ReposStoreWatchFuncCall is an object that describes an invocation of
+// method Watch on an instance of MockReposStore.
+type ReposStoreWatchFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ReposStoreWatchFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ReposStoreWatchFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// MockTwoFactorsStore is a mock implementation of the TwoFactorsStore
+// interface (from the package gogs.io/gogs/internal/db) used for unit
+// testing.
+type MockTwoFactorsStore struct {
+	// CreateFunc is an instance of a mock function object controlling the
+	// behavior of the method Create.
+	CreateFunc *TwoFactorsStoreCreateFunc
+	// GetByUserIDFunc is an instance of a mock function object controlling
+	// the behavior of the method GetByUserID.
+	GetByUserIDFunc *TwoFactorsStoreGetByUserIDFunc
+	// IsEnabledFunc is an instance of a mock function object controlling
+	// the behavior of the method IsEnabled.
+	IsEnabledFunc *TwoFactorsStoreIsEnabledFunc
+}
+
+// NewMockTwoFactorsStore creates a new mock of the TwoFactorsStore
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockTwoFactorsStore() *MockTwoFactorsStore {
+	return &MockTwoFactorsStore{
+		CreateFunc: &TwoFactorsStoreCreateFunc{
+			defaultHook: func(context.Context, int64, string, string) (r0 error) {
+				return
+			},
+		},
+		GetByUserIDFunc: &TwoFactorsStoreGetByUserIDFunc{
+			defaultHook: func(context.Context, int64) (r0 *db.TwoFactor, r1 error) {
+				return
+			},
+		},
+		IsEnabledFunc: &TwoFactorsStoreIsEnabledFunc{
+			defaultHook: func(context.Context, int64) (r0 bool) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockTwoFactorsStore creates a new mock of the TwoFactorsStore
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockTwoFactorsStore() *MockTwoFactorsStore {
+	return &MockTwoFactorsStore{
+		CreateFunc: &TwoFactorsStoreCreateFunc{
+			defaultHook: func(context.Context, int64, string, string) error {
+				panic("unexpected invocation of MockTwoFactorsStore.Create")
+			},
+		},
+		GetByUserIDFunc: &TwoFactorsStoreGetByUserIDFunc{
+			defaultHook: func(context.Context, int64) (*db.TwoFactor, error) {
+				panic("unexpected invocation of MockTwoFactorsStore.GetByUserID")
+			},
+		},
+		IsEnabledFunc: &TwoFactorsStoreIsEnabledFunc{
+			defaultHook: func(context.Context, int64) bool {
+				panic("unexpected invocation of MockTwoFactorsStore.IsEnabled")
+			},
+		},
+	}
+}
+
+// NewMockTwoFactorsStoreFrom creates a new mock of the MockTwoFactorsStore
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockTwoFactorsStoreFrom(i db.TwoFactorsStore) *MockTwoFactorsStore {
+	return &MockTwoFactorsStore{
+		CreateFunc: &TwoFactorsStoreCreateFunc{
+			defaultHook: i.Create,
+		},
+		GetByUserIDFunc: &TwoFactorsStoreGetByUserIDFunc{
+			defaultHook: i.GetByUserID,
+		},
+		IsEnabledFunc: &TwoFactorsStoreIsEnabledFunc{
+			defaultHook: i.IsEnabled,
+		},
+	}
+}
+
+// TwoFactorsStoreCreateFunc describes the behavior when the Create method
+// of the parent MockTwoFactorsStore instance is invoked.
+type TwoFactorsStoreCreateFunc struct {
+	defaultHook func(context.Context, int64, string, string) error
+	hooks       []func(context.Context, int64, string, string) error
+	history     []TwoFactorsStoreCreateFuncCall
+	mutex       sync.Mutex
+}
+
+// Create delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockTwoFactorsStore) Create(v0 context.Context, v1 int64, v2 string, v3 string) error {
+	r0 := m.CreateFunc.nextHook()(v0, v1, v2, v3)
+	m.CreateFunc.appendCall(TwoFactorsStoreCreateFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Create method of the
+// parent MockTwoFactorsStore instance is invoked and the hook queue is
+// empty.
+func (f *TwoFactorsStoreCreateFunc) SetDefaultHook(hook func(context.Context, int64, string, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Create method of the parent MockTwoFactorsStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *TwoFactorsStoreCreateFunc) PushHook(hook func(context.Context, int64, string, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *TwoFactorsStoreCreateFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, string, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *TwoFactorsStoreCreateFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, string, string) error {
+		return r0
+	})
+}
+
+func (f *TwoFactorsStoreCreateFunc) nextHook() func(context.Context, int64, string, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *TwoFactorsStoreCreateFunc) appendCall(r0 TwoFactorsStoreCreateFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of TwoFactorsStoreCreateFuncCall objects
+// describing the invocations of this function.
+func (f *TwoFactorsStoreCreateFunc) History() []TwoFactorsStoreCreateFuncCall {
+	f.mutex.Lock()
+	history := make([]TwoFactorsStoreCreateFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// TwoFactorsStoreCreateFuncCall is an object that describes an invocation
+// of method Create on an instance of MockTwoFactorsStore.
+type TwoFactorsStoreCreateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c TwoFactorsStoreCreateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c TwoFactorsStoreCreateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// TwoFactorsStoreGetByUserIDFunc describes the behavior when the
+// GetByUserID method of the parent MockTwoFactorsStore instance is invoked.
+type TwoFactorsStoreGetByUserIDFunc struct {
+	defaultHook func(context.Context, int64) (*db.TwoFactor, error)
+	hooks       []func(context.Context, int64) (*db.TwoFactor, error)
+	history     []TwoFactorsStoreGetByUserIDFuncCall
+	mutex       sync.Mutex
+}
+
+// GetByUserID delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockTwoFactorsStore) GetByUserID(v0 context.Context, v1 int64) (*db.TwoFactor, error) {
+	r0, r1 := m.GetByUserIDFunc.nextHook()(v0, v1)
+	m.GetByUserIDFunc.appendCall(TwoFactorsStoreGetByUserIDFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetByUserID method
+// of the parent MockTwoFactorsStore instance is invoked and the hook queue
+// is empty.
+func (f *TwoFactorsStoreGetByUserIDFunc) SetDefaultHook(hook func(context.Context, int64) (*db.TwoFactor, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetByUserID method of the parent MockTwoFactorsStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *TwoFactorsStoreGetByUserIDFunc) PushHook(hook func(context.Context, int64) (*db.TwoFactor, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *TwoFactorsStoreGetByUserIDFunc) SetDefaultReturn(r0 *db.TwoFactor, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*db.TwoFactor, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *TwoFactorsStoreGetByUserIDFunc) PushReturn(r0 *db.TwoFactor, r1 error) {
+	f.PushHook(func(context.Context, int64) (*db.TwoFactor, error) {
+		return r0, r1
+	})
+}
+
+func (f *TwoFactorsStoreGetByUserIDFunc) nextHook() func(context.Context, int64) (*db.TwoFactor, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *TwoFactorsStoreGetByUserIDFunc) appendCall(r0 TwoFactorsStoreGetByUserIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of TwoFactorsStoreGetByUserIDFuncCall objects
+// describing the invocations of this function.
+func (f *TwoFactorsStoreGetByUserIDFunc) History() []TwoFactorsStoreGetByUserIDFuncCall {
+	f.mutex.Lock()
+	history := make([]TwoFactorsStoreGetByUserIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// TwoFactorsStoreGetByUserIDFuncCall is an object that describes an
+// invocation of method GetByUserID on an instance of MockTwoFactorsStore.
+type TwoFactorsStoreGetByUserIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *db.TwoFactor
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c TwoFactorsStoreGetByUserIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c TwoFactorsStoreGetByUserIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// TwoFactorsStoreIsEnabledFunc describes the behavior when the IsEnabled
+// method of the parent MockTwoFactorsStore instance is invoked.
+type TwoFactorsStoreIsEnabledFunc struct {
+	defaultHook func(context.Context, int64) bool
+	hooks       []func(context.Context, int64) bool
+	history     []TwoFactorsStoreIsEnabledFuncCall
 	mutex       sync.Mutex
 }
 
@@ -3174,12 +6589,24 @@ type MockUsersStore struct {
 	// AddEmailFunc is an instance of a mock function object controlling the
 	// behavior of the method AddEmail.
 	AddEmailFunc *UsersStoreAddEmailFunc
+	// AddGPGKeyFunc is an instance of a mock function object controlling
+	// the behavior of the method AddGPGKey.
+	AddGPGKeyFunc *UsersStoreAddGPGKeyFunc
 	// AuthenticateFunc is an instance of a mock function object controlling
 	// the behavior of the method Authenticate.
 	AuthenticateFunc *UsersStoreAuthenticateFunc
+	// AvatarURLFunc is an instance of a mock function object controlling
+	// the behavior of the method AvatarURL.
+	AvatarURLFunc *UsersStoreAvatarURLFunc
 	// ChangeUsernameFunc is an instance of a mock function object
 	// controlling the behavior of the method ChangeUsername.
 	ChangeUsernameFunc *UsersStoreChangeUsernameFunc
+	// CheckStorageQuotaFunc is an instance of a mock function object
+	// controlling the behavior of the method CheckStorageQuota.
+	CheckStorageQuotaFunc *UsersStoreCheckStorageQuotaFunc
+	// ClearFailedLoginsFunc is an instance of a mock function object
+	// controlling the behavior of the method ClearFailedLogins.
+	ClearFailedLoginsFunc *UsersStoreClearFailedLoginsFunc
 	// CountFunc is an instance of a mock function object controlling the
 	// behavior of the method Count.
 	CountFunc *UsersStoreCountFunc
@@ -3195,6 +6622,9 @@ type MockUsersStore struct {
 	// DeleteEmailFunc is an instance of a mock function object controlling
 	// the behavior of the method DeleteEmail.
 	DeleteEmailFunc *UsersStoreDeleteEmailFunc
+	// DeleteGPGKeyFunc is an instance of a mock function object controlling
+	// the behavior of the method DeleteGPGKey.
+	DeleteGPGKeyFunc *UsersStoreDeleteGPGKeyFunc
 	// DeleteInactivatedFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteInactivated.
 	DeleteInactivatedFunc *UsersStoreDeleteInactivatedFunc
@@ -3204,12 +6634,15 @@ type MockUsersStore struct {
 	// GetByEmailFunc is an instance of a mock function object controlling
 	// the behavior of the method GetByEmail.
 	GetByEmailFunc *UsersStoreGetByEmailFunc
+	// GetByEmailsFunc is an instance of a mock function object controlling
+	// the behavior of the method GetByEmails.
+	GetByEmailsFunc *UsersStoreGetByEmailsFunc
 	// GetByIDFunc is an instance of a mock function object controlling the
 	// behavior of the method GetByID.
 	GetByIDFunc *UsersStoreGetByIDFunc
-	// GetByKeyIDFunc is an instance of a mock function object controlling
-	// the behavior of the method GetByKeyID.
-	GetByKeyIDFunc *UsersStoreGetByKeyIDFunc
+	// GetByPublicKeyIDFunc is an instance of a mock function object
+	// controlling the behavior of the method GetByPublicKeyID.
+	GetByPublicKeyIDFunc *UsersStoreGetByPublicKeyIDFunc
 	// GetByUsernameFunc is an instance of a mock function object
 	// controlling the behavior of the method GetByUsername.
 	GetByUsernameFunc *UsersStoreGetByUsernameFunc
@@ -3220,9 +6653,15 @@ type MockUsersStore struct {
 	// object controlling the behavior of the method
 	// GetMailableEmailsByUsernames.
 	GetMailableEmailsByUsernamesFunc *UsersStoreGetMailableEmailsByUsernamesFunc
+	// InvalidateSessionsFunc is an instance of a mock function object
+	// controlling the behavior of the method InvalidateSessions.
+	InvalidateSessionsFunc *UsersStoreInvalidateSessionsFunc
 	// IsFollowingFunc is an instance of a mock function object controlling
 	// the behavior of the method IsFollowing.
 	IsFollowingFunc *UsersStoreIsFollowingFunc
+	// IsLockedOutFunc is an instance of a mock function object controlling
+	// the behavior of the method IsLockedOut.
+	IsLockedOutFunc *UsersStoreIsLockedOutFunc
 	// IsUsernameUsedFunc is an instance of a mock function object
 	// controlling the behavior of the method IsUsernameUsed.
 	IsUsernameUsedFunc *UsersStoreIsUsernameUsedFunc
@@ -3238,12 +6677,24 @@ type MockUsersStore struct {
 	// ListFollowingsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListFollowings.
 	ListFollowingsFunc *UsersStoreListFollowingsFunc
+	// ListGPGKeysFunc is an instance of a mock function object controlling
+	// the behavior of the method ListGPGKeys.
+	ListGPGKeysFunc *UsersStoreListGPGKeysFunc
+	// ListWithoutTwoFactorFunc is an instance of a mock function object
+	// controlling the behavior of the method ListWithoutTwoFactor.
+	ListWithoutTwoFactorFunc *UsersStoreListWithoutTwoFactorFunc
 	// MarkEmailActivatedFunc is an instance of a mock function object
 	// controlling the behavior of the method MarkEmailActivated.
 	MarkEmailActivatedFunc *UsersStoreMarkEmailActivatedFunc
 	// MarkEmailPrimaryFunc is an instance of a mock function object
 	// controlling the behavior of the method MarkEmailPrimary.
 	MarkEmailPrimaryFunc *UsersStoreMarkEmailPrimaryFunc
+	// RecordFailedLoginFunc is an instance of a mock function object
+	// controlling the behavior of the method RecordFailedLogin.
+	RecordFailedLoginFunc *UsersStoreRecordFailedLoginFunc
+	// SearchFunc is an instance of a mock function object controlling the
+	// behavior of the method Search.
+	SearchFunc *UsersStoreSearchFunc
 	// SearchByNameFunc is an instance of a mock function object controlling
 	// the behavior of the method SearchByName.
 	SearchByNameFunc *UsersStoreSearchByNameFunc
@@ -3256,6 +6707,9 @@ type MockUsersStore struct {
 	// UseCustomAvatarFunc is an instance of a mock function object
 	// controlling the behavior of the method UseCustomAvatar.
 	UseCustomAvatarFunc *UsersStoreUseCustomAvatarFunc
+	// VerifyCommitSignatureFunc is an instance of a mock function object
+	// controlling the behavior of the method VerifyCommitSignature.
+	VerifyCommitSignatureFunc *UsersStoreVerifyCommitSignatureFunc
 }
 
 // NewMockUsersStore creates a new mock of the UsersStore interface. All
@@ -3267,16 +6721,36 @@ func NewMockUsersStore() *MockUsersStore {
 				return
 			},
 		},
+		AddGPGKeyFunc: &UsersStoreAddGPGKeyFunc{
+			defaultHook: func(context.Context, int64, string) (r0 *db.GPGKey, r1 error) {
+				return
+			},
+		},
 		AuthenticateFunc: &UsersStoreAuthenticateFunc{
 			defaultHook: func(context.Context, string, string, int64) (r0 *db.User, r1 error) {
 				return
 			},
 		},
+		AvatarURLFunc: &UsersStoreAvatarURLFunc{
+			defaultHook: func(*db.User, int) (r0 string) {
+				return
+			},
+		},
 		ChangeUsernameFunc: &UsersStoreChangeUsernameFunc{
 			defaultHook: func(context.Context, int64, string) (r0 error) {
 				return
 			},
 		},
+		CheckStorageQuotaFunc: &UsersStoreCheckStorageQuotaFunc{
+			defaultHook: func(context.Context, int64) (r0 error) {
+				return
+			},
+		},
+		ClearFailedLoginsFunc: &UsersStoreClearFailedLoginsFunc{
+			defaultHook: func(context.Context, string, string) (r0 error) {
+				return
+			},
+		},
 		CountFunc: &UsersStoreCountFunc{
 			defaultHook: func(context.Context) (r0 int64) {
 				return
@@ -3302,6 +6776,11 @@ func NewMockUsersStore() *MockUsersStore {
 				return
 			},
 		},
+		DeleteGPGKeyFunc: &UsersStoreDeleteGPGKeyFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 error) {
+				return
+			},
+		},
 		DeleteInactivatedFunc: &UsersStoreDeleteInactivatedFunc{
 			defaultHook: func() (r0 error) {
 				return
@@ -3317,12 +6796,17 @@ func NewMockUsersStore() *MockUsersStore {
 				return
 			},
 		},
+		GetByEmailsFunc: &UsersStoreGetByEmailsFunc{
+			defaultHook: func(context.Context, []string) (r0 map[string]*db.User, r1 error) {
+				return
+			},
+		},
 		GetByIDFunc: &UsersStoreGetByIDFunc{
 			defaultHook: func(context.Context, int64) (r0 *db.User, r1 error) {
 				return
 			},
 		},
-		GetByKeyIDFunc: &UsersStoreGetByKeyIDFunc{
+		GetByPublicKeyIDFunc: &UsersStoreGetByPublicKeyIDFunc{
 			defaultHook: func(context.Context, int64) (r0 *db.User, r1 error) {
 				return
 			},
@@ -3342,13 +6826,23 @@ func NewMockUsersStore() *MockUsersStore {
 				return
 			},
 		},
+		InvalidateSessionsFunc: &UsersStoreInvalidateSessionsFunc{
+			defaultHook: func(context.Context, int64) (r0 error) {
+				return
+			},
+		},
 		IsFollowingFunc: &UsersStoreIsFollowingFunc{
 			defaultHook: func(context.Context, int64, int64) (r0 bool) {
 				return
 			},
 		},
+		IsLockedOutFunc: &UsersStoreIsLockedOutFunc{
+			defaultHook: func(context.Context, string, string) (r0 bool) {
+				return
+			},
+		},
 		IsUsernameUsedFunc: &UsersStoreIsUsernameUsedFunc{
-			defaultHook: func(context.Context, string, int64) (r0 bool) {
+			defaultHook: func(context.Context, string, int64) (r0 bool, r1 error) {
 				return
 			},
 		},
@@ -3372,6 +6866,16 @@ func NewMockUsersStore() *MockUsersStore {
 				return
 			},
 		},
+		ListGPGKeysFunc: &UsersStoreListGPGKeysFunc{
+			defaultHook: func(context.Context, int64) (r0 []*db.GPGKey, r1 error) {
+				return
+			},
+		},
+		ListWithoutTwoFactorFunc: &UsersStoreListWithoutTwoFactorFunc{
+			defaultHook: func(context.Context, []int64) (r0 []int64, r1 error) {
+				return
+			},
+		},
 		MarkEmailActivatedFunc: &UsersStoreMarkEmailActivatedFunc{
 			defaultHook: func(context.Context, int64, string) (r0 error) {
 				return
@@ -3382,6 +6886,16 @@ func NewMockUsersStore() *MockUsersStore {
 				return
 			},
 		},
+		RecordFailedLoginFunc: &UsersStoreRecordFailedLoginFunc{
+			defaultHook: func(context.Context, string, string) (r0 error) {
+				return
+			},
+		},
+		SearchFunc: &UsersStoreSearchFunc{
+			defaultHook: func(context.Context, int, int, db.SearchUsersOptions) (r0 []*db.User, r1 int64, r2 error) {
+				return
+			},
+		},
 		SearchByNameFunc: &UsersStoreSearchByNameFunc{
 			defaultHook: func(context.Context, string, int, int, string) (r0 []*db.User, r1 int64, r2 error) {
 				return
@@ -3402,6 +6916,11 @@ func NewMockUsersStore() *MockUsersStore {
 				return
 			},
 		},
+		VerifyCommitSignatureFunc: &UsersStoreVerifyCommitSignatureFunc{
+			defaultHook: func(context.Context, []byte) (r0 *db.CommitVerification, r1 error) {
+				return
+			},
+		},
 	}
 }
 
@@ -3414,16 +6933,36 @@ func NewStrictMockUsersStore() *MockUsersStore {
 				panic("unexpected invocation of MockUsersStore.AddEmail")
 			},
 		},
+		AddGPGKeyFunc: &UsersStoreAddGPGKeyFunc{
+			defaultHook: func(context.Context, int64, string) (*db.GPGKey, error) {
+				panic("unexpected invocation of MockUsersStore.AddGPGKey")
+			},
+		},
 		AuthenticateFunc: &UsersStoreAuthenticateFunc{
 			defaultHook: func(context.Context, string, string, int64) (*db.User, error) {
 				panic("unexpected invocation of MockUsersStore.Authenticate")
 			},
 		},
+		AvatarURLFunc: &UsersStoreAvatarURLFunc{
+			defaultHook: func(*db.User, int) string {
+				panic("unexpected invocation of MockUsersStore.AvatarURL")
+			},
+		},
 		ChangeUsernameFunc: &UsersStoreChangeUsernameFunc{
 			defaultHook: func(context.Context, int64, string) error {
 				panic("unexpected invocation of MockUsersStore.ChangeUsername")
 			},
 		},
+		CheckStorageQuotaFunc: &UsersStoreCheckStorageQuotaFunc{
+			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockUsersStore.CheckStorageQuota")
+			},
+		},
+		ClearFailedLoginsFunc: &UsersStoreClearFailedLoginsFunc{
+			defaultHook: func(context.Context, string, string) error {
+				panic("unexpected invocation of MockUsersStore.ClearFailedLogins")
+			},
+		},
 		CountFunc: &UsersStoreCountFunc{
 			defaultHook: func(context.Context) int64 {
 				panic("unexpected invocation of MockUsersStore.Count")
@@ -3449,6 +6988,11 @@ func NewStrictMockUsersStore() *MockUsersStore {
 				panic("unexpected invocation of MockUsersStore.DeleteEmail")
 			},
 		},
+		DeleteGPGKeyFunc: &UsersStoreDeleteGPGKeyFunc{
+			defaultHook: func(context.Context, int64, int64) error {
+				panic("unexpected invocation of MockUsersStore.DeleteGPGKey")
+			},
+		},
 		DeleteInactivatedFunc: &UsersStoreDeleteInactivatedFunc{
 			defaultHook: func() error {
 				panic("unexpected invocation of MockUsersStore.DeleteInactivated")
@@ -3464,14 +7008,19 @@ func NewStrictMockUsersStore() *MockUsersStore {
 				panic("unexpected invocation of MockUsersStore.GetByEmail")
 			},
 		},
+		GetByEmailsFunc: &UsersStoreGetByEmailsFunc{
+			defaultHook: func(context.Context, []string) (map[string]*db.User, error) {
+				panic("unexpected invocation of MockUsersStore.GetByEmails")
+			},
+		},
 		GetByIDFunc: &UsersStoreGetByIDFunc{
 			defaultHook: func(context.Context, int64) (*db.User, error) {
 				panic("unexpected invocation of MockUsersStore.GetByID")
 			},
 		},
-		GetByKeyIDFunc: &UsersStoreGetByKeyIDFunc{
+		GetByPublicKeyIDFunc: &UsersStoreGetByPublicKeyIDFunc{
 			defaultHook: func(context.Context, int64) (*db.User, error) {
-				panic("unexpected invocation of MockUsersStore.GetByKeyID")
+				panic("unexpected invocation of MockUsersStore.GetByPublicKeyID")
 			},
 		},
 		GetByUsernameFunc: &UsersStoreGetByUsernameFunc{
@@ -3489,13 +7038,23 @@ func NewStrictMockUsersStore() *MockUsersStore {
 				panic("unexpected invocation of MockUsersStore.GetMailableEmailsByUsernames")
 			},
 		},
+		InvalidateSessionsFunc: &UsersStoreInvalidateSessionsFunc{
+			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockUsersStore.InvalidateSessions")
+			},
+		},
 		IsFollowingFunc: &UsersStoreIsFollowingFunc{
 			defaultHook: func(context.Context, int64, int64) bool {
 				panic("unexpected invocation of MockUsersStore.IsFollowing")
 			},
 		},
+		IsLockedOutFunc: &UsersStoreIsLockedOutFunc{
+			defaultHook: func(context.Context, string, string) bool {
+				panic("unexpected invocation of MockUsersStore.IsLockedOut")
+			},
+		},
 		IsUsernameUsedFunc: &UsersStoreIsUsernameUsedFunc{
-			defaultHook: func(context.Context, string, int64) bool {
+			defaultHook: func(context.Context, string, int64) (bool, error) {
 				panic("unexpected invocation of MockUsersStore.IsUsernameUsed")
 			},
 		},
@@ -3519,6 +7078,16 @@ func NewStrictMockUsersStore() *MockUsersStore {
 				panic("unexpected invocation of MockUsersStore.ListFollowings")
 			},
 		},
+		ListGPGKeysFunc: &UsersStoreListGPGKeysFunc{
+			defaultHook: func(context.Context, int64) ([]*db.GPGKey, error) {
+				panic("unexpected invocation of MockUsersStore.ListGPGKeys")
+			},
+		},
+		ListWithoutTwoFactorFunc: &UsersStoreListWithoutTwoFactorFunc{
+			defaultHook: func(context.Context, []int64) ([]int64, error) {
+				panic("unexpected invocation of MockUsersStore.ListWithoutTwoFactor")
+			},
+		},
 		MarkEmailActivatedFunc: &UsersStoreMarkEmailActivatedFunc{
 			defaultHook: func(context.Context, int64, string) error {
 				panic("unexpected invocation of MockUsersStore.MarkEmailActivated")
@@ -3529,6 +7098,16 @@ func NewStrictMockUsersStore() *MockUsersStore {
 				panic("unexpected invocation of MockUsersStore.MarkEmailPrimary")
 			},
 		},
+		RecordFailedLoginFunc: &UsersStoreRecordFailedLoginFunc{
+			defaultHook: func(context.Context, string, string) error {
+				panic("unexpected invocation of MockUsersStore.RecordFailedLogin")
+			},
+		},
+		SearchFunc: &UsersStoreSearchFunc{
+			defaultHook: func(context.Context, int, int, db.SearchUsersOptions) ([]*db.User, int64, error) {
+				panic("unexpected invocation of MockUsersStore.Search")
+			},
+		},
 		SearchByNameFunc: &UsersStoreSearchByNameFunc{
 			defaultHook: func(context.Context, string, int, int, string) ([]*db.User, int64, error) {
 				panic("unexpected invocation of MockUsersStore.SearchByName")
@@ -3549,6 +7128,11 @@ func NewStrictMockUsersStore() *MockUsersStore {
 				panic("unexpected invocation of MockUsersStore.UseCustomAvatar")
 			},
 		},
+		VerifyCommitSignatureFunc: &UsersStoreVerifyCommitSignatureFunc{
+			defaultHook: func(context.Context, []byte) (*db.CommitVerification, error) {
+				panic("unexpected invocation of MockUsersStore.VerifyCommitSignature")
+			},
+		},
 	}
 }
 
@@ -3559,12 +7143,24 @@ func NewMockUsersStoreFrom(i db.UsersStore) *MockUsersStore {
 		AddEmailFunc: &UsersStoreAddEmailFunc{
 			defaultHook: i.AddEmail,
 		},
+		AddGPGKeyFunc: &UsersStoreAddGPGKeyFunc{
+			defaultHook: i.AddGPGKey,
+		},
 		AuthenticateFunc: &UsersStoreAuthenticateFunc{
 			defaultHook: i.Authenticate,
 		},
+		AvatarURLFunc: &UsersStoreAvatarURLFunc{
+			defaultHook: i.AvatarURL,
+		},
 		ChangeUsernameFunc: &UsersStoreChangeUsernameFunc{
 			defaultHook: i.ChangeUsername,
 		},
+		CheckStorageQuotaFunc: &UsersStoreCheckStorageQuotaFunc{
+			defaultHook: i.CheckStorageQuota,
+		},
+		ClearFailedLoginsFunc: &UsersStoreClearFailedLoginsFunc{
+			defaultHook: i.ClearFailedLogins,
+		},
 		CountFunc: &UsersStoreCountFunc{
 			defaultHook: i.Count,
 		},
@@ -3580,6 +7176,9 @@ func NewMockUsersStoreFrom(i db.UsersStore) *MockUsersStore {
 		DeleteEmailFunc: &UsersStoreDeleteEmailFunc{
 			defaultHook: i.DeleteEmail,
 		},
+		DeleteGPGKeyFunc: &UsersStoreDeleteGPGKeyFunc{
+			defaultHook: i.DeleteGPGKey,
+		},
 		DeleteInactivatedFunc: &UsersStoreDeleteInactivatedFunc{
 			defaultHook: i.DeleteInactivated,
 		},
@@ -3589,11 +7188,14 @@ func NewMockUsersStoreFrom(i db.UsersStore) *MockUsersStore {
 		GetByEmailFunc: &UsersStoreGetByEmailFunc{
 			defaultHook: i.GetByEmail,
 		},
+		GetByEmailsFunc: &UsersStoreGetByEmailsFunc{
+			defaultHook: i.GetByEmails,
+		},
 		GetByIDFunc: &UsersStoreGetByIDFunc{
 			defaultHook: i.GetByID,
 		},
-		GetByKeyIDFunc: &UsersStoreGetByKeyIDFunc{
-			defaultHook: i.GetByKeyID,
+		GetByPublicKeyIDFunc: &UsersStoreGetByPublicKeyIDFunc{
+			defaultHook: i.GetByPublicKeyID,
 		},
 		GetByUsernameFunc: &UsersStoreGetByUsernameFunc{
 			defaultHook: i.GetByUsername,
@@ -3604,9 +7206,15 @@ func NewMockUsersStoreFrom(i db.UsersStore) *MockUsersStore {
 		GetMailableEmailsByUsernamesFunc: &UsersStoreGetMailableEmailsByUsernamesFunc{
 			defaultHook: i.GetMailableEmailsByUsernames,
 		},
+		InvalidateSessionsFunc: &UsersStoreInvalidateSessionsFunc{
+			defaultHook: i.InvalidateSessions,
+		},
 		IsFollowingFunc: &UsersStoreIsFollowingFunc{
 			defaultHook: i.IsFollowing,
 		},
+		IsLockedOutFunc: &UsersStoreIsLockedOutFunc{
+			defaultHook: i.IsLockedOut,
+		},
 		IsUsernameUsedFunc: &UsersStoreIsUsernameUsedFunc{
 			defaultHook: i.IsUsernameUsed,
 		},
@@ -3622,12 +7230,24 @@ func NewMockUsersStoreFrom(i db.UsersStore) *MockUsersStore {
 		ListFollowingsFunc: &UsersStoreListFollowingsFunc{
 			defaultHook: i.ListFollowings,
 		},
+		ListGPGKeysFunc: &UsersStoreListGPGKeysFunc{
+			defaultHook: i.ListGPGKeys,
+		},
+		ListWithoutTwoFactorFunc: &UsersStoreListWithoutTwoFactorFunc{
+			defaultHook: i.ListWithoutTwoFactor,
+		},
 		MarkEmailActivatedFunc: &UsersStoreMarkEmailActivatedFunc{
 			defaultHook: i.MarkEmailActivated,
 		},
 		MarkEmailPrimaryFunc: &UsersStoreMarkEmailPrimaryFunc{
 			defaultHook: i.MarkEmailPrimary,
 		},
+		RecordFailedLoginFunc: &UsersStoreRecordFailedLoginFunc{
+			defaultHook: i.RecordFailedLogin,
+		},
+		SearchFunc: &UsersStoreSearchFunc{
+			defaultHook: i.Search,
+		},
 		SearchByNameFunc: &UsersStoreSearchByNameFunc{
 			defaultHook: i.SearchByName,
 		},
@@ -3640,38 +7260,377 @@ func NewMockUsersStoreFrom(i db.UsersStore) *MockUsersStore {
 		UseCustomAvatarFunc: &UsersStoreUseCustomAvatarFunc{
 			defaultHook: i.UseCustomAvatar,
 		},
+		VerifyCommitSignatureFunc: &UsersStoreVerifyCommitSignatureFunc{
+			defaultHook: i.VerifyCommitSignature,
+		},
+	}
+}
+
+// UsersStoreAddEmailFunc describes the behavior when the AddEmail method of
+// the parent MockUsersStore instance is invoked.
+type UsersStoreAddEmailFunc struct {
+	defaultHook func(context.Context, int64, string, bool) error
+	hooks       []func(context.Context, int64, string, bool) error
+	history     []UsersStoreAddEmailFuncCall
+	mutex       sync.Mutex
+}
+
+// AddEmail delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUsersStore) AddEmail(v0 context.Context, v1 int64, v2 string, v3 bool) error {
+	r0 := m.AddEmailFunc.nextHook()(v0, v1, v2, v3)
+	m.AddEmailFunc.appendCall(UsersStoreAddEmailFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the AddEmail method of
+// the parent MockUsersStore instance is invoked and the hook queue is
+// empty.
+func (f *UsersStoreAddEmailFunc) SetDefaultHook(hook func(context.Context, int64, string, bool) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AddEmail method of the parent MockUsersStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *UsersStoreAddEmailFunc) PushHook(hook func(context.Context, int64, string, bool) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UsersStoreAddEmailFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, string, bool) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UsersStoreAddEmailFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, string, bool) error {
+		return r0
+	})
+}
+
+func (f *UsersStoreAddEmailFunc) nextHook() func(context.Context, int64, string, bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UsersStoreAddEmailFunc) appendCall(r0 UsersStoreAddEmailFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UsersStoreAddEmailFuncCall objects
+// describing the invocations of this function.
+func (f *UsersStoreAddEmailFunc) History() []UsersStoreAddEmailFuncCall {
+	f.mutex.Lock()
+	history := make([]UsersStoreAddEmailFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UsersStoreAddEmailFuncCall is an object that describes an invocation of
+// method AddEmail on an instance of MockUsersStore.
+type UsersStoreAddEmailFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UsersStoreAddEmailFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UsersStoreAddEmailFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// UsersStoreAddGPGKeyFunc describes the behavior when the AddGPGKey method
+// of the parent MockUsersStore instance is invoked.
+type UsersStoreAddGPGKeyFunc struct {
+	defaultHook func(context.Context, int64, string) (*db.GPGKey, error)
+	hooks       []func(context.Context, int64, string) (*db.GPGKey, error)
+	history     []UsersStoreAddGPGKeyFuncCall
+	mutex       sync.Mutex
+}
+
+// AddGPGKey delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUsersStore) AddGPGKey(v0 context.Context, v1 int64, v2 string) (*db.GPGKey, error) {
+	r0, r1 := m.AddGPGKeyFunc.nextHook()(v0, v1, v2)
+	m.AddGPGKeyFunc.appendCall(UsersStoreAddGPGKeyFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the AddGPGKey method of
+// the parent MockUsersStore instance is invoked and the hook queue is
+// empty.
+func (f *UsersStoreAddGPGKeyFunc) SetDefaultHook(hook func(context.Context, int64, string) (*db.GPGKey, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AddGPGKey method of the parent MockUsersStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *UsersStoreAddGPGKeyFunc) PushHook(hook func(context.Context, int64, string) (*db.GPGKey, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UsersStoreAddGPGKeyFunc) SetDefaultReturn(r0 *db.GPGKey, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, string) (*db.GPGKey, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UsersStoreAddGPGKeyFunc) PushReturn(r0 *db.GPGKey, r1 error) {
+	f.PushHook(func(context.Context, int64, string) (*db.GPGKey, error) {
+		return r0, r1
+	})
+}
+
+func (f *UsersStoreAddGPGKeyFunc) nextHook() func(context.Context, int64, string) (*db.GPGKey, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UsersStoreAddGPGKeyFunc) appendCall(r0 UsersStoreAddGPGKeyFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UsersStoreAddGPGKeyFuncCall objects
+// describing the invocations of this function.
+func (f *UsersStoreAddGPGKeyFunc) History() []UsersStoreAddGPGKeyFuncCall {
+	f.mutex.Lock()
+	history := make([]UsersStoreAddGPGKeyFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UsersStoreAddGPGKeyFuncCall is an object that describes an invocation of
+// method AddGPGKey on an instance of MockUsersStore.
+type UsersStoreAddGPGKeyFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *db.GPGKey
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UsersStoreAddGPGKeyFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UsersStoreAddGPGKeyFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// UsersStoreAuthenticateFunc describes the behavior when the Authenticate
+// method of the parent MockUsersStore instance is invoked.
+type UsersStoreAuthenticateFunc struct {
+	defaultHook func(context.Context, string, string, int64) (*db.User, error)
+	hooks       []func(context.Context, string, string, int64) (*db.User, error)
+	history     []UsersStoreAuthenticateFuncCall
+	mutex       sync.Mutex
+}
+
+// Authenticate delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockUsersStore) Authenticate(v0 context.Context, v1 string, v2 string, v3 int64) (*db.User, error) {
+	r0, r1 := m.AuthenticateFunc.nextHook()(v0, v1, v2, v3)
+	m.AuthenticateFunc.appendCall(UsersStoreAuthenticateFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Authenticate method
+// of the parent MockUsersStore instance is invoked and the hook queue is
+// empty.
+func (f *UsersStoreAuthenticateFunc) SetDefaultHook(hook func(context.Context, string, string, int64) (*db.User, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Authenticate method of the parent MockUsersStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *UsersStoreAuthenticateFunc) PushHook(hook func(context.Context, string, string, int64) (*db.User, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UsersStoreAuthenticateFunc) SetDefaultReturn(r0 *db.User, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string, int64) (*db.User, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UsersStoreAuthenticateFunc) PushReturn(r0 *db.User, r1 error) {
+	f.PushHook(func(context.Context, string, string, int64) (*db.User, error) {
+		return r0, r1
+	})
+}
+
+func (f *UsersStoreAuthenticateFunc) nextHook() func(context.Context, string, string, int64) (*db.User, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
 	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
 }
 
-// UsersStoreAddEmailFunc describes the behavior when the AddEmail method of
-// the parent MockUsersStore instance is invoked.
-type UsersStoreAddEmailFunc struct {
-	defaultHook func(context.Context, int64, string, bool) error
-	hooks       []func(context.Context, int64, string, bool) error
-	history     []UsersStoreAddEmailFuncCall
+func (f *UsersStoreAuthenticateFunc) appendCall(r0 UsersStoreAuthenticateFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UsersStoreAuthenticateFuncCall objects
+// describing the invocations of this function.
+func (f *UsersStoreAuthenticateFunc) History() []UsersStoreAuthenticateFuncCall {
+	f.mutex.Lock()
+	history := make([]UsersStoreAuthenticateFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UsersStoreAuthenticateFuncCall is an object that describes an invocation
+// of method Authenticate on an instance of MockUsersStore.
+type UsersStoreAuthenticateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *db.User
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UsersStoreAuthenticateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UsersStoreAuthenticateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// UsersStoreAvatarURLFunc describes the behavior when the AvatarURL method
+// of the parent MockUsersStore instance is invoked.
+type UsersStoreAvatarURLFunc struct {
+	defaultHook func(*db.User, int) string
+	hooks       []func(*db.User, int) string
+	history     []UsersStoreAvatarURLFuncCall
 	mutex       sync.Mutex
 }
 
-// AddEmail delegates to the next hook function in the queue and stores the
+// AvatarURL delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockUsersStore) AddEmail(v0 context.Context, v1 int64, v2 string, v3 bool) error {
-	r0 := m.AddEmailFunc.nextHook()(v0, v1, v2, v3)
-	m.AddEmailFunc.appendCall(UsersStoreAddEmailFuncCall{v0, v1, v2, v3, r0})
+func (m *MockUsersStore) AvatarURL(v0 *db.User, v1 int) string {
+	r0 := m.AvatarURLFunc.nextHook()(v0, v1)
+	m.AvatarURLFunc.appendCall(UsersStoreAvatarURLFuncCall{v0, v1, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the AddEmail method of
+// SetDefaultHook sets function that is called when the AvatarURL method of
 // the parent MockUsersStore instance is invoked and the hook queue is
 // empty.
-func (f *UsersStoreAddEmailFunc) SetDefaultHook(hook func(context.Context, int64, string, bool) error) {
+func (f *UsersStoreAvatarURLFunc) SetDefaultHook(hook func(*db.User, int) string) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// AddEmail method of the parent MockUsersStore instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
+// AvatarURL method of the parent MockUsersStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
 // default hook function is invoked for any future action.
-func (f *UsersStoreAddEmailFunc) PushHook(hook func(context.Context, int64, string, bool) error) {
+func (f *UsersStoreAvatarURLFunc) PushHook(hook func(*db.User, int) string) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -3679,20 +7638,20 @@ func (f *UsersStoreAddEmailFunc) PushHook(hook func(context.Context, int64, stri
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *UsersStoreAddEmailFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int64, string, bool) error {
+func (f *UsersStoreAvatarURLFunc) SetDefaultReturn(r0 string) {
+	f.SetDefaultHook(func(*db.User, int) string {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *UsersStoreAddEmailFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int64, string, bool) error {
+func (f *UsersStoreAvatarURLFunc) PushReturn(r0 string) {
+	f.PushHook(func(*db.User, int) string {
 		return r0
 	})
 }
 
-func (f *UsersStoreAddEmailFunc) nextHook() func(context.Context, int64, string, bool) error {
+func (f *UsersStoreAvatarURLFunc) nextHook() func(*db.User, int) string {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -3705,26 +7664,131 @@ func (f *UsersStoreAddEmailFunc) nextHook() func(context.Context, int64, string,
 	return hook
 }
 
-func (f *UsersStoreAddEmailFunc) appendCall(r0 UsersStoreAddEmailFuncCall) {
+func (f *UsersStoreAvatarURLFunc) appendCall(r0 UsersStoreAvatarURLFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UsersStoreAddEmailFuncCall objects
+// History returns a sequence of UsersStoreAvatarURLFuncCall objects
 // describing the invocations of this function.
-func (f *UsersStoreAddEmailFunc) History() []UsersStoreAddEmailFuncCall {
+func (f *UsersStoreAvatarURLFunc) History() []UsersStoreAvatarURLFuncCall {
 	f.mutex.Lock()
-	history := make([]UsersStoreAddEmailFuncCall, len(f.history))
+	history := make([]UsersStoreAvatarURLFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UsersStoreAddEmailFuncCall is an object that describes an invocation of
-// method AddEmail on an instance of MockUsersStore.
-type UsersStoreAddEmailFuncCall struct {
+// UsersStoreAvatarURLFuncCall is an object that describes an invocation of
+// method AvatarURL on an instance of MockUsersStore.
+type UsersStoreAvatarURLFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 *db.User
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 string
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UsersStoreAvatarURLFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UsersStoreAvatarURLFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// UsersStoreChangeUsernameFunc describes the behavior when the
+// ChangeUsername method of the parent MockUsersStore instance is invoked.
+type UsersStoreChangeUsernameFunc struct {
+	defaultHook func(context.Context, int64, string) error
+	hooks       []func(context.Context, int64, string) error
+	history     []UsersStoreChangeUsernameFuncCall
+	mutex       sync.Mutex
+}
+
+// ChangeUsername delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUsersStore) ChangeUsername(v0 context.Context, v1 int64, v2 string) error {
+	r0 := m.ChangeUsernameFunc.nextHook()(v0, v1, v2)
+	m.ChangeUsernameFunc.appendCall(UsersStoreChangeUsernameFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ChangeUsername
+// method of the parent MockUsersStore instance is invoked and the hook
+// queue is empty.
+func (f *UsersStoreChangeUsernameFunc) SetDefaultHook(hook func(context.Context, int64, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ChangeUsername method of the parent MockUsersStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *UsersStoreChangeUsernameFunc) PushHook(hook func(context.Context, int64, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UsersStoreChangeUsernameFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UsersStoreChangeUsernameFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, string) error {
+		return r0
+	})
+}
+
+func (f *UsersStoreChangeUsernameFunc) nextHook() func(context.Context, int64, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UsersStoreChangeUsernameFunc) appendCall(r0 UsersStoreChangeUsernameFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UsersStoreChangeUsernameFuncCall objects
+// describing the invocations of this function.
+func (f *UsersStoreChangeUsernameFunc) History() []UsersStoreChangeUsernameFuncCall {
+	f.mutex.Lock()
+	history := make([]UsersStoreChangeUsernameFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UsersStoreChangeUsernameFuncCall is an object that describes an
+// invocation of method ChangeUsername on an instance of MockUsersStore.
+type UsersStoreChangeUsernameFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -3734,9 +7798,6 @@ type UsersStoreAddEmailFuncCall struct {
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
 	Arg2 string
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 bool
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -3744,45 +7805,46 @@ type UsersStoreAddEmailFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UsersStoreAddEmailFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c UsersStoreChangeUsernameFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UsersStoreAddEmailFuncCall) Results() []interface{} {
+func (c UsersStoreChangeUsernameFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// UsersStoreAuthenticateFunc describes the behavior when the Authenticate
-// method of the parent MockUsersStore instance is invoked.
-type UsersStoreAuthenticateFunc struct {
-	defaultHook func(context.Context, string, string, int64) (*db.User, error)
-	hooks       []func(context.Context, string, string, int64) (*db.User, error)
-	history     []UsersStoreAuthenticateFuncCall
+// UsersStoreCheckStorageQuotaFunc describes the behavior when the
+// CheckStorageQuota method of the parent MockUsersStore instance is
+// invoked.
+type UsersStoreCheckStorageQuotaFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []UsersStoreCheckStorageQuotaFuncCall
 	mutex       sync.Mutex
 }
 
-// Authenticate delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockUsersStore) Authenticate(v0 context.Context, v1 string, v2 string, v3 int64) (*db.User, error) {
-	r0, r1 := m.AuthenticateFunc.nextHook()(v0, v1, v2, v3)
-	m.AuthenticateFunc.appendCall(UsersStoreAuthenticateFuncCall{v0, v1, v2, v3, r0, r1})
-	return r0, r1
+// CheckStorageQuota delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUsersStore) CheckStorageQuota(v0 context.Context, v1 int64) error {
+	r0 := m.CheckStorageQuotaFunc.nextHook()(v0, v1)
+	m.CheckStorageQuotaFunc.appendCall(UsersStoreCheckStorageQuotaFuncCall{v0, v1, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the Authenticate method
-// of the parent MockUsersStore instance is invoked and the hook queue is
-// empty.
-func (f *UsersStoreAuthenticateFunc) SetDefaultHook(hook func(context.Context, string, string, int64) (*db.User, error)) {
+// SetDefaultHook sets function that is called when the CheckStorageQuota
+// method of the parent MockUsersStore instance is invoked and the hook
+// queue is empty.
+func (f *UsersStoreCheckStorageQuotaFunc) SetDefaultHook(hook func(context.Context, int64) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Authenticate method of the parent MockUsersStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *UsersStoreAuthenticateFunc) PushHook(hook func(context.Context, string, string, int64) (*db.User, error)) {
+// CheckStorageQuota method of the parent MockUsersStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *UsersStoreCheckStorageQuotaFunc) PushHook(hook func(context.Context, int64) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -3790,20 +7852,20 @@ func (f *UsersStoreAuthenticateFunc) PushHook(hook func(context.Context, string,
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *UsersStoreAuthenticateFunc) SetDefaultReturn(r0 *db.User, r1 error) {
-	f.SetDefaultHook(func(context.Context, string, string, int64) (*db.User, error) {
-		return r0, r1
+func (f *UsersStoreCheckStorageQuotaFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *UsersStoreAuthenticateFunc) PushReturn(r0 *db.User, r1 error) {
-	f.PushHook(func(context.Context, string, string, int64) (*db.User, error) {
-		return r0, r1
+func (f *UsersStoreCheckStorageQuotaFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
 	})
 }
 
-func (f *UsersStoreAuthenticateFunc) nextHook() func(context.Context, string, string, int64) (*db.User, error) {
+func (f *UsersStoreCheckStorageQuotaFunc) nextHook() func(context.Context, int64) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -3816,87 +7878,79 @@ func (f *UsersStoreAuthenticateFunc) nextHook() func(context.Context, string, st
 	return hook
 }
 
-func (f *UsersStoreAuthenticateFunc) appendCall(r0 UsersStoreAuthenticateFuncCall) {
+func (f *UsersStoreCheckStorageQuotaFunc) appendCall(r0 UsersStoreCheckStorageQuotaFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UsersStoreAuthenticateFuncCall objects
+// History returns a sequence of UsersStoreCheckStorageQuotaFuncCall objects
 // describing the invocations of this function.
-func (f *UsersStoreAuthenticateFunc) History() []UsersStoreAuthenticateFuncCall {
+func (f *UsersStoreCheckStorageQuotaFunc) History() []UsersStoreCheckStorageQuotaFuncCall {
 	f.mutex.Lock()
-	history := make([]UsersStoreAuthenticateFuncCall, len(f.history))
+	history := make([]UsersStoreCheckStorageQuotaFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UsersStoreAuthenticateFuncCall is an object that describes an invocation
-// of method Authenticate on an instance of MockUsersStore.
-type UsersStoreAuthenticateFuncCall struct {
+// UsersStoreCheckStorageQuotaFuncCall is an object that describes an
+// invocation of method CheckStorageQuota on an instance of MockUsersStore.
+type UsersStoreCheckStorageQuotaFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 string
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 string
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 int64
+	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *db.User
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UsersStoreAuthenticateFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c UsersStoreCheckStorageQuotaFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UsersStoreAuthenticateFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c UsersStoreCheckStorageQuotaFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// UsersStoreChangeUsernameFunc describes the behavior when the
-// ChangeUsername method of the parent MockUsersStore instance is invoked.
-type UsersStoreChangeUsernameFunc struct {
-	defaultHook func(context.Context, int64, string) error
-	hooks       []func(context.Context, int64, string) error
-	history     []UsersStoreChangeUsernameFuncCall
+// UsersStoreClearFailedLoginsFunc describes the behavior when the
+// ClearFailedLogins method of the parent MockUsersStore instance is
+// invoked.
+type UsersStoreClearFailedLoginsFunc struct {
+	defaultHook func(context.Context, string, string) error
+	hooks       []func(context.Context, string, string) error
+	history     []UsersStoreClearFailedLoginsFuncCall
 	mutex       sync.Mutex
 }
 
-// ChangeUsername delegates to the next hook function in the queue and
+// ClearFailedLogins delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockUsersStore) ChangeUsername(v0 context.Context, v1 int64, v2 string) error {
-	r0 := m.ChangeUsernameFunc.nextHook()(v0, v1, v2)
-	m.ChangeUsernameFunc.appendCall(UsersStoreChangeUsernameFuncCall{v0, v1, v2, r0})
+func (m *MockUsersStore) ClearFailedLogins(v0 context.Context, v1 string, v2 string) error {
+	r0 := m.ClearFailedLoginsFunc.nextHook()(v0, v1, v2)
+	m.ClearFailedLoginsFunc.appendCall(UsersStoreClearFailedLoginsFuncCall{v0, v1, v2, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the ChangeUsername
+// SetDefaultHook sets function that is called when the ClearFailedLogins
 // method of the parent MockUsersStore instance is invoked and the hook
 // queue is empty.
-func (f *UsersStoreChangeUsernameFunc) SetDefaultHook(hook func(context.Context, int64, string) error) {
+func (f *UsersStoreClearFailedLoginsFunc) SetDefaultHook(hook func(context.Context, string, string) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ChangeUsername method of the parent MockUsersStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *UsersStoreChangeUsernameFunc) PushHook(hook func(context.Context, int64, string) error) {
+// ClearFailedLogins method of the parent MockUsersStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *UsersStoreClearFailedLoginsFunc) PushHook(hook func(context.Context, string, string) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -3904,20 +7958,20 @@ func (f *UsersStoreChangeUsernameFunc) PushHook(hook func(context.Context, int64
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *UsersStoreChangeUsernameFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int64, string) error {
+func (f *UsersStoreClearFailedLoginsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string, string) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *UsersStoreChangeUsernameFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int64, string) error {
+func (f *UsersStoreClearFailedLoginsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string, string) error {
 		return r0
 	})
 }
 
-func (f *UsersStoreChangeUsernameFunc) nextHook() func(context.Context, int64, string) error {
+func (f *UsersStoreClearFailedLoginsFunc) nextHook() func(context.Context, string, string) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -3930,32 +7984,32 @@ func (f *UsersStoreChangeUsernameFunc) nextHook() func(context.Context, int64, s
 	return hook
 }
 
-func (f *UsersStoreChangeUsernameFunc) appendCall(r0 UsersStoreChangeUsernameFuncCall) {
+func (f *UsersStoreClearFailedLoginsFunc) appendCall(r0 UsersStoreClearFailedLoginsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UsersStoreChangeUsernameFuncCall objects
+// History returns a sequence of UsersStoreClearFailedLoginsFuncCall objects
 // describing the invocations of this function.
-func (f *UsersStoreChangeUsernameFunc) History() []UsersStoreChangeUsernameFuncCall {
+func (f *UsersStoreClearFailedLoginsFunc) History() []UsersStoreClearFailedLoginsFuncCall {
 	f.mutex.Lock()
-	history := make([]UsersStoreChangeUsernameFuncCall, len(f.history))
+	history := make([]UsersStoreClearFailedLoginsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UsersStoreChangeUsernameFuncCall is an object that describes an
-// invocation of method ChangeUsername on an instance of MockUsersStore.
-type UsersStoreChangeUsernameFuncCall struct {
+// UsersStoreClearFailedLoginsFuncCall is an object that describes an
+// invocation of method ClearFailedLogins on an instance of MockUsersStore.
+type UsersStoreClearFailedLoginsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int64
+	Arg1 string
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
 	Arg2 string
@@ -3966,13 +8020,13 @@ type UsersStoreChangeUsernameFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UsersStoreChangeUsernameFuncCall) Args() []interface{} {
+func (c UsersStoreClearFailedLoginsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UsersStoreChangeUsernameFuncCall) Results() []interface{} {
+func (c UsersStoreClearFailedLoginsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
@@ -4512,6 +8566,114 @@ func (c UsersStoreDeleteEmailFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// UsersStoreDeleteGPGKeyFunc describes the behavior when the DeleteGPGKey
+// method of the parent MockUsersStore instance is invoked.
+type UsersStoreDeleteGPGKeyFunc struct {
+	defaultHook func(context.Context, int64, int64) error
+	hooks       []func(context.Context, int64, int64) error
+	history     []UsersStoreDeleteGPGKeyFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteGPGKey delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockUsersStore) DeleteGPGKey(v0 context.Context, v1 int64, v2 int64) error {
+	r0 := m.DeleteGPGKeyFunc.nextHook()(v0, v1, v2)
+	m.DeleteGPGKeyFunc.appendCall(UsersStoreDeleteGPGKeyFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteGPGKey method
+// of the parent MockUsersStore instance is invoked and the hook queue is
+// empty.
+func (f *UsersStoreDeleteGPGKeyFunc) SetDefaultHook(hook func(context.Context, int64, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteGPGKey method of the parent MockUsersStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *UsersStoreDeleteGPGKeyFunc) PushHook(hook func(context.Context, int64, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UsersStoreDeleteGPGKeyFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UsersStoreDeleteGPGKeyFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int64) error {
+		return r0
+	})
+}
+
+func (f *UsersStoreDeleteGPGKeyFunc) nextHook() func(context.Context, int64, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UsersStoreDeleteGPGKeyFunc) appendCall(r0 UsersStoreDeleteGPGKeyFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UsersStoreDeleteGPGKeyFuncCall objects
+// describing the invocations of this function.
+func (f *UsersStoreDeleteGPGKeyFunc) History() []UsersStoreDeleteGPGKeyFuncCall {
+	f.mutex.Lock()
+	history := make([]UsersStoreDeleteGPGKeyFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UsersStoreDeleteGPGKeyFuncCall is an object that describes an invocation
+// of method DeleteGPGKey on an instance of MockUsersStore.
+type UsersStoreDeleteGPGKeyFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UsersStoreDeleteGPGKeyFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UsersStoreDeleteGPGKeyFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // UsersStoreDeleteInactivatedFunc describes the behavior when the
 // DeleteInactivated method of the parent MockUsersStore instance is
 // invoked.
@@ -4690,64 +8852,172 @@ func (f *UsersStoreFollowFunc) History() []UsersStoreFollowFuncCall {
 	return history
 }
 
-// UsersStoreFollowFuncCall is an object that describes an invocation of
-// method Follow on an instance of MockUsersStore.
-type UsersStoreFollowFuncCall struct {
+// UsersStoreFollowFuncCall is an object that describes an invocation of
+// method Follow on an instance of MockUsersStore.
+type UsersStoreFollowFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UsersStoreFollowFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UsersStoreFollowFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// UsersStoreGetByEmailFunc describes the behavior when the GetByEmail
+// method of the parent MockUsersStore instance is invoked.
+type UsersStoreGetByEmailFunc struct {
+	defaultHook func(context.Context, string) (*db.User, error)
+	hooks       []func(context.Context, string) (*db.User, error)
+	history     []UsersStoreGetByEmailFuncCall
+	mutex       sync.Mutex
+}
+
+// GetByEmail delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockUsersStore) GetByEmail(v0 context.Context, v1 string) (*db.User, error) {
+	r0, r1 := m.GetByEmailFunc.nextHook()(v0, v1)
+	m.GetByEmailFunc.appendCall(UsersStoreGetByEmailFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetByEmail method of
+// the parent MockUsersStore instance is invoked and the hook queue is
+// empty.
+func (f *UsersStoreGetByEmailFunc) SetDefaultHook(hook func(context.Context, string) (*db.User, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetByEmail method of the parent MockUsersStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *UsersStoreGetByEmailFunc) PushHook(hook func(context.Context, string) (*db.User, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UsersStoreGetByEmailFunc) SetDefaultReturn(r0 *db.User, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) (*db.User, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UsersStoreGetByEmailFunc) PushReturn(r0 *db.User, r1 error) {
+	f.PushHook(func(context.Context, string) (*db.User, error) {
+		return r0, r1
+	})
+}
+
+func (f *UsersStoreGetByEmailFunc) nextHook() func(context.Context, string) (*db.User, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UsersStoreGetByEmailFunc) appendCall(r0 UsersStoreGetByEmailFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UsersStoreGetByEmailFuncCall objects
+// describing the invocations of this function.
+func (f *UsersStoreGetByEmailFunc) History() []UsersStoreGetByEmailFuncCall {
+	f.mutex.Lock()
+	history := make([]UsersStoreGetByEmailFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UsersStoreGetByEmailFuncCall is an object that describes an invocation of
+// method GetByEmail on an instance of MockUsersStore.
+type UsersStoreGetByEmailFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int64
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 int64
+	Arg1 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 *db.User
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UsersStoreFollowFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c UsersStoreGetByEmailFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UsersStoreFollowFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c UsersStoreGetByEmailFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// UsersStoreGetByEmailFunc describes the behavior when the GetByEmail
+// UsersStoreGetByEmailsFunc describes the behavior when the GetByEmails
 // method of the parent MockUsersStore instance is invoked.
-type UsersStoreGetByEmailFunc struct {
-	defaultHook func(context.Context, string) (*db.User, error)
-	hooks       []func(context.Context, string) (*db.User, error)
-	history     []UsersStoreGetByEmailFuncCall
+type UsersStoreGetByEmailsFunc struct {
+	defaultHook func(context.Context, []string) (map[string]*db.User, error)
+	hooks       []func(context.Context, []string) (map[string]*db.User, error)
+	history     []UsersStoreGetByEmailsFuncCall
 	mutex       sync.Mutex
 }
 
-// GetByEmail delegates to the next hook function in the queue and stores
+// GetByEmails delegates to the next hook function in the queue and stores
 // the parameter and result values of this invocation.
-func (m *MockUsersStore) GetByEmail(v0 context.Context, v1 string) (*db.User, error) {
-	r0, r1 := m.GetByEmailFunc.nextHook()(v0, v1)
-	m.GetByEmailFunc.appendCall(UsersStoreGetByEmailFuncCall{v0, v1, r0, r1})
+func (m *MockUsersStore) GetByEmails(v0 context.Context, v1 []string) (map[string]*db.User, error) {
+	r0, r1 := m.GetByEmailsFunc.nextHook()(v0, v1)
+	m.GetByEmailsFunc.appendCall(UsersStoreGetByEmailsFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetByEmail method of
-// the parent MockUsersStore instance is invoked and the hook queue is
+// SetDefaultHook sets function that is called when the GetByEmails method
+// of the parent MockUsersStore instance is invoked and the hook queue is
 // empty.
-func (f *UsersStoreGetByEmailFunc) SetDefaultHook(hook func(context.Context, string) (*db.User, error)) {
+func (f *UsersStoreGetByEmailsFunc) SetDefaultHook(hook func(context.Context, []string) (map[string]*db.User, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetByEmail method of the parent MockUsersStore instance invokes the hook
+// GetByEmails method of the parent MockUsersStore instance invokes the hook
 // at the front of the queue and discards it. After the queue is empty, the
 // default hook function is invoked for any future action.
-func (f *UsersStoreGetByEmailFunc) PushHook(hook func(context.Context, string) (*db.User, error)) {
+func (f *UsersStoreGetByEmailsFunc) PushHook(hook func(context.Context, []string) (map[string]*db.User, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -4755,20 +9025,20 @@ func (f *UsersStoreGetByEmailFunc) PushHook(hook func(context.Context, string) (
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *UsersStoreGetByEmailFunc) SetDefaultReturn(r0 *db.User, r1 error) {
-	f.SetDefaultHook(func(context.Context, string) (*db.User, error) {
+func (f *UsersStoreGetByEmailsFunc) SetDefaultReturn(r0 map[string]*db.User, r1 error) {
+	f.SetDefaultHook(func(context.Context, []string) (map[string]*db.User, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *UsersStoreGetByEmailFunc) PushReturn(r0 *db.User, r1 error) {
-	f.PushHook(func(context.Context, string) (*db.User, error) {
+func (f *UsersStoreGetByEmailsFunc) PushReturn(r0 map[string]*db.User, r1 error) {
+	f.PushHook(func(context.Context, []string) (map[string]*db.User, error) {
 		return r0, r1
 	})
 }
 
-func (f *UsersStoreGetByEmailFunc) nextHook() func(context.Context, string) (*db.User, error) {
+func (f *UsersStoreGetByEmailsFunc) nextHook() func(context.Context, []string) (map[string]*db.User, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -4781,35 +9051,35 @@ func (f *UsersStoreGetByEmailFunc) nextHook() func(context.Context, string) (*db
 	return hook
 }
 
-func (f *UsersStoreGetByEmailFunc) appendCall(r0 UsersStoreGetByEmailFuncCall) {
+func (f *UsersStoreGetByEmailsFunc) appendCall(r0 UsersStoreGetByEmailsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UsersStoreGetByEmailFuncCall objects
+// History returns a sequence of UsersStoreGetByEmailsFuncCall objects
 // describing the invocations of this function.
-func (f *UsersStoreGetByEmailFunc) History() []UsersStoreGetByEmailFuncCall {
+func (f *UsersStoreGetByEmailsFunc) History() []UsersStoreGetByEmailsFuncCall {
 	f.mutex.Lock()
-	history := make([]UsersStoreGetByEmailFuncCall, len(f.history))
+	history := make([]UsersStoreGetByEmailsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UsersStoreGetByEmailFuncCall is an object that describes an invocation of
-// method GetByEmail on an instance of MockUsersStore.
-type UsersStoreGetByEmailFuncCall struct {
+// UsersStoreGetByEmailsFuncCall is an object that describes an invocation
+// of method GetByEmails on an instance of MockUsersStore.
+type UsersStoreGetByEmailsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 string
+	Arg1 []string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *db.User
+	Result0 map[string]*db.User
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -4817,13 +9087,13 @@ type UsersStoreGetByEmailFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UsersStoreGetByEmailFuncCall) Args() []interface{} {
+func (c UsersStoreGetByEmailsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UsersStoreGetByEmailFuncCall) Results() []interface{} {
+func (c UsersStoreGetByEmailsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -4935,35 +9205,35 @@ func (c UsersStoreGetByIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// UsersStoreGetByKeyIDFunc describes the behavior when the GetByKeyID
-// method of the parent MockUsersStore instance is invoked.
-type UsersStoreGetByKeyIDFunc struct {
+// UsersStoreGetByPublicKeyIDFunc describes the behavior when the
+// GetByPublicKeyID method of the parent MockUsersStore instance is invoked.
+type UsersStoreGetByPublicKeyIDFunc struct {
 	defaultHook func(context.Context, int64) (*db.User, error)
 	hooks       []func(context.Context, int64) (*db.User, error)
-	history     []UsersStoreGetByKeyIDFuncCall
+	history     []UsersStoreGetByPublicKeyIDFuncCall
 	mutex       sync.Mutex
 }
 
-// GetByKeyID delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockUsersStore) GetByKeyID(v0 context.Context, v1 int64) (*db.User, error) {
-	r0, r1 := m.GetByKeyIDFunc.nextHook()(v0, v1)
-	m.GetByKeyIDFunc.appendCall(UsersStoreGetByKeyIDFuncCall{v0, v1, r0, r1})
+// GetByPublicKeyID delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUsersStore) GetByPublicKeyID(v0 context.Context, v1 int64) (*db.User, error) {
+	r0, r1 := m.GetByPublicKeyIDFunc.nextHook()(v0, v1)
+	m.GetByPublicKeyIDFunc.appendCall(UsersStoreGetByPublicKeyIDFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetByKeyID method of
-// the parent MockUsersStore instance is invoked and the hook queue is
-// empty.
-func (f *UsersStoreGetByKeyIDFunc) SetDefaultHook(hook func(context.Context, int64) (*db.User, error)) {
+// SetDefaultHook sets function that is called when the GetByPublicKeyID
+// method of the parent MockUsersStore instance is invoked and the hook
+// queue is empty.
+func (f *UsersStoreGetByPublicKeyIDFunc) SetDefaultHook(hook func(context.Context, int64) (*db.User, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetByKeyID method of the parent MockUsersStore instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *UsersStoreGetByKeyIDFunc) PushHook(hook func(context.Context, int64) (*db.User, error)) {
+// GetByPublicKeyID method of the parent MockUsersStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *UsersStoreGetByPublicKeyIDFunc) PushHook(hook func(context.Context, int64) (*db.User, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -4971,20 +9241,20 @@ func (f *UsersStoreGetByKeyIDFunc) PushHook(hook func(context.Context, int64) (*
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *UsersStoreGetByKeyIDFunc) SetDefaultReturn(r0 *db.User, r1 error) {
+func (f *UsersStoreGetByPublicKeyIDFunc) SetDefaultReturn(r0 *db.User, r1 error) {
 	f.SetDefaultHook(func(context.Context, int64) (*db.User, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *UsersStoreGetByKeyIDFunc) PushReturn(r0 *db.User, r1 error) {
+func (f *UsersStoreGetByPublicKeyIDFunc) PushReturn(r0 *db.User, r1 error) {
 	f.PushHook(func(context.Context, int64) (*db.User, error) {
 		return r0, r1
 	})
 }
 
-func (f *UsersStoreGetByKeyIDFunc) nextHook() func(context.Context, int64) (*db.User, error) {
+func (f *UsersStoreGetByPublicKeyIDFunc) nextHook() func(context.Context, int64) (*db.User, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -4997,26 +9267,26 @@ func (f *UsersStoreGetByKeyIDFunc) nextHook() func(context.Context, int64) (*db.
 	return hook
 }
 
-func (f *UsersStoreGetByKeyIDFunc) appendCall(r0 UsersStoreGetByKeyIDFuncCall) {
+func (f *UsersStoreGetByPublicKeyIDFunc) appendCall(r0 UsersStoreGetByPublicKeyIDFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UsersStoreGetByKeyIDFuncCall objects
+// History returns a sequence of UsersStoreGetByPublicKeyIDFuncCall objects
 // describing the invocations of this function.
-func (f *UsersStoreGetByKeyIDFunc) History() []UsersStoreGetByKeyIDFuncCall {
+func (f *UsersStoreGetByPublicKeyIDFunc) History() []UsersStoreGetByPublicKeyIDFuncCall {
 	f.mutex.Lock()
-	history := make([]UsersStoreGetByKeyIDFuncCall, len(f.history))
+	history := make([]UsersStoreGetByPublicKeyIDFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UsersStoreGetByKeyIDFuncCall is an object that describes an invocation of
-// method GetByKeyID on an instance of MockUsersStore.
-type UsersStoreGetByKeyIDFuncCall struct {
+// UsersStoreGetByPublicKeyIDFuncCall is an object that describes an
+// invocation of method GetByPublicKeyID on an instance of MockUsersStore.
+type UsersStoreGetByPublicKeyIDFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -5033,13 +9303,13 @@ type UsersStoreGetByKeyIDFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UsersStoreGetByKeyIDFuncCall) Args() []interface{} {
+func (c UsersStoreGetByPublicKeyIDFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UsersStoreGetByKeyIDFuncCall) Results() []interface{} {
+func (c UsersStoreGetByPublicKeyIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -5303,20 +9573,236 @@ func (f *UsersStoreGetMailableEmailsByUsernamesFunc) PushHook(hook func(context.
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *UsersStoreGetMailableEmailsByUsernamesFunc) SetDefaultReturn(r0 []string, r1 error) {
-	f.SetDefaultHook(func(context.Context, []string) ([]string, error) {
-		return r0, r1
+func (f *UsersStoreGetMailableEmailsByUsernamesFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context, []string) ([]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UsersStoreGetMailableEmailsByUsernamesFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context, []string) ([]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *UsersStoreGetMailableEmailsByUsernamesFunc) nextHook() func(context.Context, []string) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UsersStoreGetMailableEmailsByUsernamesFunc) appendCall(r0 UsersStoreGetMailableEmailsByUsernamesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// UsersStoreGetMailableEmailsByUsernamesFuncCall objects describing the
+// invocations of this function.
+func (f *UsersStoreGetMailableEmailsByUsernamesFunc) History() []UsersStoreGetMailableEmailsByUsernamesFuncCall {
+	f.mutex.Lock()
+	history := make([]UsersStoreGetMailableEmailsByUsernamesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UsersStoreGetMailableEmailsByUsernamesFuncCall is an object that
+// describes an invocation of method GetMailableEmailsByUsernames on an
+// instance of MockUsersStore.
+type UsersStoreGetMailableEmailsByUsernamesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UsersStoreGetMailableEmailsByUsernamesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UsersStoreGetMailableEmailsByUsernamesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// UsersStoreInvalidateSessionsFunc describes the behavior when the
+// InvalidateSessions method of the parent MockUsersStore instance is
+// invoked.
+type UsersStoreInvalidateSessionsFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []UsersStoreInvalidateSessionsFuncCall
+	mutex       sync.Mutex
+}
+
+// InvalidateSessions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUsersStore) InvalidateSessions(v0 context.Context, v1 int64) error {
+	r0 := m.InvalidateSessionsFunc.nextHook()(v0, v1)
+	m.InvalidateSessionsFunc.appendCall(UsersStoreInvalidateSessionsFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the InvalidateSessions
+// method of the parent MockUsersStore instance is invoked and the hook
+// queue is empty.
+func (f *UsersStoreInvalidateSessionsFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InvalidateSessions method of the parent MockUsersStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *UsersStoreInvalidateSessionsFunc) PushHook(hook func(context.Context, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UsersStoreInvalidateSessionsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UsersStoreInvalidateSessionsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+func (f *UsersStoreInvalidateSessionsFunc) nextHook() func(context.Context, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UsersStoreInvalidateSessionsFunc) appendCall(r0 UsersStoreInvalidateSessionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UsersStoreInvalidateSessionsFuncCall
+// objects describing the invocations of this function.
+func (f *UsersStoreInvalidateSessionsFunc) History() []UsersStoreInvalidateSessionsFuncCall {
+	f.mutex.Lock()
+	history := make([]UsersStoreInvalidateSessionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UsersStoreInvalidateSessionsFuncCall is an object that describes an
+// invocation of method InvalidateSessions on an instance of MockUsersStore.
+type UsersStoreInvalidateSessionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UsersStoreInvalidateSessionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UsersStoreInvalidateSessionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// UsersStoreIsFollowingFunc describes the behavior when the IsFollowing
+// method of the parent MockUsersStore instance is invoked.
+type UsersStoreIsFollowingFunc struct {
+	defaultHook func(context.Context, int64, int64) bool
+	hooks       []func(context.Context, int64, int64) bool
+	history     []UsersStoreIsFollowingFuncCall
+	mutex       sync.Mutex
+}
+
+// IsFollowing delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockUsersStore) IsFollowing(v0 context.Context, v1 int64, v2 int64) bool {
+	r0 := m.IsFollowingFunc.nextHook()(v0, v1, v2)
+	m.IsFollowingFunc.appendCall(UsersStoreIsFollowingFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the IsFollowing method
+// of the parent MockUsersStore instance is invoked and the hook queue is
+// empty.
+func (f *UsersStoreIsFollowingFunc) SetDefaultHook(hook func(context.Context, int64, int64) bool) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// IsFollowing method of the parent MockUsersStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *UsersStoreIsFollowingFunc) PushHook(hook func(context.Context, int64, int64) bool) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UsersStoreIsFollowingFunc) SetDefaultReturn(r0 bool) {
+	f.SetDefaultHook(func(context.Context, int64, int64) bool {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *UsersStoreGetMailableEmailsByUsernamesFunc) PushReturn(r0 []string, r1 error) {
-	f.PushHook(func(context.Context, []string) ([]string, error) {
-		return r0, r1
+func (f *UsersStoreIsFollowingFunc) PushReturn(r0 bool) {
+	f.PushHook(func(context.Context, int64, int64) bool {
+		return r0
 	})
 }
 
-func (f *UsersStoreGetMailableEmailsByUsernamesFunc) nextHook() func(context.Context, []string) ([]string, error) {
+func (f *UsersStoreIsFollowingFunc) nextHook() func(context.Context, int64, int64) bool {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -5329,83 +9815,81 @@ func (f *UsersStoreGetMailableEmailsByUsernamesFunc) nextHook() func(context.Con
 	return hook
 }
 
-func (f *UsersStoreGetMailableEmailsByUsernamesFunc) appendCall(r0 UsersStoreGetMailableEmailsByUsernamesFuncCall) {
+func (f *UsersStoreIsFollowingFunc) appendCall(r0 UsersStoreIsFollowingFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// UsersStoreGetMailableEmailsByUsernamesFuncCall objects describing the
-// invocations of this function.
-func (f *UsersStoreGetMailableEmailsByUsernamesFunc) History() []UsersStoreGetMailableEmailsByUsernamesFuncCall {
+// History returns a sequence of UsersStoreIsFollowingFuncCall objects
+// describing the invocations of this function.
+func (f *UsersStoreIsFollowingFunc) History() []UsersStoreIsFollowingFuncCall {
 	f.mutex.Lock()
-	history := make([]UsersStoreGetMailableEmailsByUsernamesFuncCall, len(f.history))
+	history := make([]UsersStoreIsFollowingFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UsersStoreGetMailableEmailsByUsernamesFuncCall is an object that
-// describes an invocation of method GetMailableEmailsByUsernames on an
-// instance of MockUsersStore.
-type UsersStoreGetMailableEmailsByUsernamesFuncCall struct {
+// UsersStoreIsFollowingFuncCall is an object that describes an invocation
+// of method IsFollowing on an instance of MockUsersStore.
+type UsersStoreIsFollowingFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 []string
-	// Result0 is the value of the 1st result returned from this method
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Result0 []string
-	// Result1 is the value of the 2nd result returned from this method
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result1 error
+	Result0 bool
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UsersStoreGetMailableEmailsByUsernamesFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c UsersStoreIsFollowingFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UsersStoreGetMailableEmailsByUsernamesFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c UsersStoreIsFollowingFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// UsersStoreIsFollowingFunc describes the behavior when the IsFollowing
+// UsersStoreIsLockedOutFunc describes the behavior when the IsLockedOut
 // method of the parent MockUsersStore instance is invoked.
-type UsersStoreIsFollowingFunc struct {
-	defaultHook func(context.Context, int64, int64) bool
-	hooks       []func(context.Context, int64, int64) bool
-	history     []UsersStoreIsFollowingFuncCall
+type UsersStoreIsLockedOutFunc struct {
+	defaultHook func(context.Context, string, string) bool
+	hooks       []func(context.Context, string, string) bool
+	history     []UsersStoreIsLockedOutFuncCall
 	mutex       sync.Mutex
 }
 
-// IsFollowing delegates to the next hook function in the queue and stores
+// IsLockedOut delegates to the next hook function in the queue and stores
 // the parameter and result values of this invocation.
-func (m *MockUsersStore) IsFollowing(v0 context.Context, v1 int64, v2 int64) bool {
-	r0 := m.IsFollowingFunc.nextHook()(v0, v1, v2)
-	m.IsFollowingFunc.appendCall(UsersStoreIsFollowingFuncCall{v0, v1, v2, r0})
+func (m *MockUsersStore) IsLockedOut(v0 context.Context, v1 string, v2 string) bool {
+	r0 := m.IsLockedOutFunc.nextHook()(v0, v1, v2)
+	m.IsLockedOutFunc.appendCall(UsersStoreIsLockedOutFuncCall{v0, v1, v2, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the IsFollowing method
+// SetDefaultHook sets function that is called when the IsLockedOut method
 // of the parent MockUsersStore instance is invoked and the hook queue is
 // empty.
-func (f *UsersStoreIsFollowingFunc) SetDefaultHook(hook func(context.Context, int64, int64) bool) {
+func (f *UsersStoreIsLockedOutFunc) SetDefaultHook(hook func(context.Context, string, string) bool) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// IsFollowing method of the parent MockUsersStore instance invokes the hook
+// IsLockedOut method of the parent MockUsersStore instance invokes the hook
 // at the front of the queue and discards it. After the queue is empty, the
 // default hook function is invoked for any future action.
-func (f *UsersStoreIsFollowingFunc) PushHook(hook func(context.Context, int64, int64) bool) {
+func (f *UsersStoreIsLockedOutFunc) PushHook(hook func(context.Context, string, string) bool) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -5413,20 +9897,20 @@ func (f *UsersStoreIsFollowingFunc) PushHook(hook func(context.Context, int64, i
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *UsersStoreIsFollowingFunc) SetDefaultReturn(r0 bool) {
-	f.SetDefaultHook(func(context.Context, int64, int64) bool {
+func (f *UsersStoreIsLockedOutFunc) SetDefaultReturn(r0 bool) {
+	f.SetDefaultHook(func(context.Context, string, string) bool {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *UsersStoreIsFollowingFunc) PushReturn(r0 bool) {
-	f.PushHook(func(context.Context, int64, int64) bool {
+func (f *UsersStoreIsLockedOutFunc) PushReturn(r0 bool) {
+	f.PushHook(func(context.Context, string, string) bool {
 		return r0
 	})
 }
 
-func (f *UsersStoreIsFollowingFunc) nextHook() func(context.Context, int64, int64) bool {
+func (f *UsersStoreIsLockedOutFunc) nextHook() func(context.Context, string, string) bool {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -5439,35 +9923,35 @@ func (f *UsersStoreIsFollowingFunc) nextHook() func(context.Context, int64, int6
 	return hook
 }
 
-func (f *UsersStoreIsFollowingFunc) appendCall(r0 UsersStoreIsFollowingFuncCall) {
+func (f *UsersStoreIsLockedOutFunc) appendCall(r0 UsersStoreIsLockedOutFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of UsersStoreIsFollowingFuncCall objects
+// History returns a sequence of UsersStoreIsLockedOutFuncCall objects
 // describing the invocations of this function.
-func (f *UsersStoreIsFollowingFunc) History() []UsersStoreIsFollowingFuncCall {
+func (f *UsersStoreIsLockedOutFunc) History() []UsersStoreIsLockedOutFuncCall {
 	f.mutex.Lock()
-	history := make([]UsersStoreIsFollowingFuncCall, len(f.history))
+	history := make([]UsersStoreIsLockedOutFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// UsersStoreIsFollowingFuncCall is an object that describes an invocation
-// of method IsFollowing on an instance of MockUsersStore.
-type UsersStoreIsFollowingFuncCall struct {
+// UsersStoreIsLockedOutFuncCall is an object that describes an invocation
+// of method IsLockedOut on an instance of MockUsersStore.
+type UsersStoreIsLockedOutFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int64
+	Arg1 string
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 int64
+	Arg2 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 bool
@@ -5475,37 +9959,37 @@ type UsersStoreIsFollowingFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UsersStoreIsFollowingFuncCall) Args() []interface{} {
+func (c UsersStoreIsLockedOutFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UsersStoreIsFollowingFuncCall) Results() []interface{} {
+func (c UsersStoreIsLockedOutFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
 // UsersStoreIsUsernameUsedFunc describes the behavior when the
 // IsUsernameUsed method of the parent MockUsersStore instance is invoked.
 type UsersStoreIsUsernameUsedFunc struct {
-	defaultHook func(context.Context, string, int64) bool
-	hooks       []func(context.Context, string, int64) bool
+	defaultHook func(context.Context, string, int64) (bool, error)
+	hooks       []func(context.Context, string, int64) (bool, error)
 	history     []UsersStoreIsUsernameUsedFuncCall
 	mutex       sync.Mutex
 }
 
 // IsUsernameUsed delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockUsersStore) IsUsernameUsed(v0 context.Context, v1 string, v2 int64) bool {
-	r0 := m.IsUsernameUsedFunc.nextHook()(v0, v1, v2)
-	m.IsUsernameUsedFunc.appendCall(UsersStoreIsUsernameUsedFuncCall{v0, v1, v2, r0})
-	return r0
+func (m *MockUsersStore) IsUsernameUsed(v0 context.Context, v1 string, v2 int64) (bool, error) {
+	r0, r1 := m.IsUsernameUsedFunc.nextHook()(v0, v1, v2)
+	m.IsUsernameUsedFunc.appendCall(UsersStoreIsUsernameUsedFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the IsUsernameUsed
 // method of the parent MockUsersStore instance is invoked and the hook
 // queue is empty.
-func (f *UsersStoreIsUsernameUsedFunc) SetDefaultHook(hook func(context.Context, string, int64) bool) {
+func (f *UsersStoreIsUsernameUsedFunc) SetDefaultHook(hook func(context.Context, string, int64) (bool, error)) {
 	f.defaultHook = hook
 }
 
@@ -5513,7 +9997,7 @@ func (f *UsersStoreIsUsernameUsedFunc) SetDefaultHook(hook func(context.Context,
 // IsUsernameUsed method of the parent MockUsersStore instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *UsersStoreIsUsernameUsedFunc) PushHook(hook func(context.Context, string, int64) bool) {
+func (f *UsersStoreIsUsernameUsedFunc) PushHook(hook func(context.Context, string, int64) (bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -5521,20 +10005,20 @@ func (f *UsersStoreIsUsernameUsedFunc) PushHook(hook func(context.Context, strin
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *UsersStoreIsUsernameUsedFunc) SetDefaultReturn(r0 bool) {
-	f.SetDefaultHook(func(context.Context, string, int64) bool {
-		return r0
+func (f *UsersStoreIsUsernameUsedFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int64) (bool, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *UsersStoreIsUsernameUsedFunc) PushReturn(r0 bool) {
-	f.PushHook(func(context.Context, string, int64) bool {
-		return r0
+func (f *UsersStoreIsUsernameUsedFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, string, int64) (bool, error) {
+		return r0, r1
 	})
 }
 
-func (f *UsersStoreIsUsernameUsedFunc) nextHook() func(context.Context, string, int64) bool {
+func (f *UsersStoreIsUsernameUsedFunc) nextHook() func(context.Context, string, int64) (bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -5579,6 +10063,9 @@ type UsersStoreIsUsernameUsedFuncCall struct {
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
@@ -5590,7 +10077,7 @@ func (c UsersStoreIsUsernameUsedFuncCall) Args() []interface{} {
 // Results returns an interface slice containing the results of this
 // invocation.
 func (c UsersStoreIsUsernameUsedFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+	return []interface{}{c.Result0, c.Result1}
 }
 
 // UsersStoreListFunc describes the behavior when the List method of the
@@ -6018,10 +10505,228 @@ type UsersStoreListFollowingsFuncCall struct {
 	Arg2 int
 	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
-	Arg3 int
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.User
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UsersStoreListFollowingsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UsersStoreListFollowingsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// UsersStoreListGPGKeysFunc describes the behavior when the ListGPGKeys
+// method of the parent MockUsersStore instance is invoked.
+type UsersStoreListGPGKeysFunc struct {
+	defaultHook func(context.Context, int64) ([]*db.GPGKey, error)
+	hooks       []func(context.Context, int64) ([]*db.GPGKey, error)
+	history     []UsersStoreListGPGKeysFuncCall
+	mutex       sync.Mutex
+}
+
+// ListGPGKeys delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockUsersStore) ListGPGKeys(v0 context.Context, v1 int64) ([]*db.GPGKey, error) {
+	r0, r1 := m.ListGPGKeysFunc.nextHook()(v0, v1)
+	m.ListGPGKeysFunc.appendCall(UsersStoreListGPGKeysFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListGPGKeys method
+// of the parent MockUsersStore instance is invoked and the hook queue is
+// empty.
+func (f *UsersStoreListGPGKeysFunc) SetDefaultHook(hook func(context.Context, int64) ([]*db.GPGKey, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListGPGKeys method of the parent MockUsersStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *UsersStoreListGPGKeysFunc) PushHook(hook func(context.Context, int64) ([]*db.GPGKey, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UsersStoreListGPGKeysFunc) SetDefaultReturn(r0 []*db.GPGKey, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) ([]*db.GPGKey, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UsersStoreListGPGKeysFunc) PushReturn(r0 []*db.GPGKey, r1 error) {
+	f.PushHook(func(context.Context, int64) ([]*db.GPGKey, error) {
+		return r0, r1
+	})
+}
+
+func (f *UsersStoreListGPGKeysFunc) nextHook() func(context.Context, int64) ([]*db.GPGKey, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UsersStoreListGPGKeysFunc) appendCall(r0 UsersStoreListGPGKeysFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UsersStoreListGPGKeysFuncCall objects
+// describing the invocations of this function.
+func (f *UsersStoreListGPGKeysFunc) History() []UsersStoreListGPGKeysFuncCall {
+	f.mutex.Lock()
+	history := make([]UsersStoreListGPGKeysFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UsersStoreListGPGKeysFuncCall is an object that describes an invocation
+// of method ListGPGKeys on an instance of MockUsersStore.
+type UsersStoreListGPGKeysFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.GPGKey
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UsersStoreListGPGKeysFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UsersStoreListGPGKeysFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// UsersStoreListWithoutTwoFactorFunc describes the behavior when the
+// ListWithoutTwoFactor method of the parent MockUsersStore instance is
+// invoked.
+type UsersStoreListWithoutTwoFactorFunc struct {
+	defaultHook func(context.Context, []int64) ([]int64, error)
+	hooks       []func(context.Context, []int64) ([]int64, error)
+	history     []UsersStoreListWithoutTwoFactorFuncCall
+	mutex       sync.Mutex
+}
+
+// ListWithoutTwoFactor delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUsersStore) ListWithoutTwoFactor(v0 context.Context, v1 []int64) ([]int64, error) {
+	r0, r1 := m.ListWithoutTwoFactorFunc.nextHook()(v0, v1)
+	m.ListWithoutTwoFactorFunc.appendCall(UsersStoreListWithoutTwoFactorFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListWithoutTwoFactor
+// method of the parent MockUsersStore instance is invoked and the hook
+// queue is empty.
+func (f *UsersStoreListWithoutTwoFactorFunc) SetDefaultHook(hook func(context.Context, []int64) ([]int64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListWithoutTwoFactor method of the parent MockUsersStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *UsersStoreListWithoutTwoFactorFunc) PushHook(hook func(context.Context, []int64) ([]int64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UsersStoreListWithoutTwoFactorFunc) SetDefaultReturn(r0 []int64, r1 error) {
+	f.SetDefaultHook(func(context.Context, []int64) ([]int64, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UsersStoreListWithoutTwoFactorFunc) PushReturn(r0 []int64, r1 error) {
+	f.PushHook(func(context.Context, []int64) ([]int64, error) {
+		return r0, r1
+	})
+}
+
+func (f *UsersStoreListWithoutTwoFactorFunc) nextHook() func(context.Context, []int64) ([]int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UsersStoreListWithoutTwoFactorFunc) appendCall(r0 UsersStoreListWithoutTwoFactorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UsersStoreListWithoutTwoFactorFuncCall
+// objects describing the invocations of this function.
+func (f *UsersStoreListWithoutTwoFactorFunc) History() []UsersStoreListWithoutTwoFactorFuncCall {
+	f.mutex.Lock()
+	history := make([]UsersStoreListWithoutTwoFactorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UsersStoreListWithoutTwoFactorFuncCall is an object that describes an
+// invocation of method ListWithoutTwoFactor on an instance of
+// MockUsersStore.
+type UsersStoreListWithoutTwoFactorFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*db.User
+	Result0 []int64
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -6029,13 +10734,13 @@ type UsersStoreListFollowingsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c UsersStoreListFollowingsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c UsersStoreListWithoutTwoFactorFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c UsersStoreListFollowingsFuncCall) Results() []interface{} {
+func (c UsersStoreListWithoutTwoFactorFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -6256,6 +10961,231 @@ func (c UsersStoreMarkEmailPrimaryFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// UsersStoreRecordFailedLoginFunc describes the behavior when the
+// RecordFailedLogin method of the parent MockUsersStore instance is
+// invoked.
+type UsersStoreRecordFailedLoginFunc struct {
+	defaultHook func(context.Context, string, string) error
+	hooks       []func(context.Context, string, string) error
+	history     []UsersStoreRecordFailedLoginFuncCall
+	mutex       sync.Mutex
+}
+
+// RecordFailedLogin delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUsersStore) RecordFailedLogin(v0 context.Context, v1 string, v2 string) error {
+	r0 := m.RecordFailedLoginFunc.nextHook()(v0, v1, v2)
+	m.RecordFailedLoginFunc.appendCall(UsersStoreRecordFailedLoginFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the RecordFailedLogin
+// method of the parent MockUsersStore instance is invoked and the hook
+// queue is empty.
+func (f *UsersStoreRecordFailedLoginFunc) SetDefaultHook(hook func(context.Context, string, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RecordFailedLogin method of the parent MockUsersStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *UsersStoreRecordFailedLoginFunc) PushHook(hook func(context.Context, string, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UsersStoreRecordFailedLoginFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UsersStoreRecordFailedLoginFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string, string) error {
+		return r0
+	})
+}
+
+func (f *UsersStoreRecordFailedLoginFunc) nextHook() func(context.Context, string, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UsersStoreRecordFailedLoginFunc) appendCall(r0 UsersStoreRecordFailedLoginFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UsersStoreRecordFailedLoginFuncCall objects
+// describing the invocations of this function.
+func (f *UsersStoreRecordFailedLoginFunc) History() []UsersStoreRecordFailedLoginFuncCall {
+	f.mutex.Lock()
+	history := make([]UsersStoreRecordFailedLoginFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UsersStoreRecordFailedLoginFuncCall is an object that describes an
+// invocation of method RecordFailedLogin on an instance of MockUsersStore.
+type UsersStoreRecordFailedLoginFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UsersStoreRecordFailedLoginFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UsersStoreRecordFailedLoginFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// UsersStoreSearchFunc describes the behavior when the Search method of the
+// parent MockUsersStore instance is invoked.
+type UsersStoreSearchFunc struct {
+	defaultHook func(context.Context, int, int, db.SearchUsersOptions) ([]*db.User, int64, error)
+	hooks       []func(context.Context, int, int, db.SearchUsersOptions) ([]*db.User, int64, error)
+	history     []UsersStoreSearchFuncCall
+	mutex       sync.Mutex
+}
+
+// Search delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUsersStore) Search(v0 context.Context, v1 int, v2 int, v3 db.SearchUsersOptions) ([]*db.User, int64, error) {
+	r0, r1, r2 := m.SearchFunc.nextHook()(v0, v1, v2, v3)
+	m.SearchFunc.appendCall(UsersStoreSearchFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the Search method of the
+// parent MockUsersStore instance is invoked and the hook queue is empty.
+func (f *UsersStoreSearchFunc) SetDefaultHook(hook func(context.Context, int, int, db.SearchUsersOptions) ([]*db.User, int64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Search method of the parent MockUsersStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *UsersStoreSearchFunc) PushHook(hook func(context.Context, int, int, db.SearchUsersOptions) ([]*db.User, int64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UsersStoreSearchFunc) SetDefaultReturn(r0 []*db.User, r1 int64, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, int, db.SearchUsersOptions) ([]*db.User, int64, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UsersStoreSearchFunc) PushReturn(r0 []*db.User, r1 int64, r2 error) {
+	f.PushHook(func(context.Context, int, int, db.SearchUsersOptions) ([]*db.User, int64, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *UsersStoreSearchFunc) nextHook() func(context.Context, int, int, db.SearchUsersOptions) ([]*db.User, int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UsersStoreSearchFunc) appendCall(r0 UsersStoreSearchFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UsersStoreSearchFuncCall objects describing
+// the invocations of this function.
+func (f *UsersStoreSearchFunc) History() []UsersStoreSearchFuncCall {
+	f.mutex.Lock()
+	history := make([]UsersStoreSearchFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UsersStoreSearchFuncCall is an object that describes an invocation of
+// method Search on an instance of MockUsersStore.
+type UsersStoreSearchFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 db.SearchUsersOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*db.User
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int64
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UsersStoreSearchFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UsersStoreSearchFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // UsersStoreSearchByNameFunc describes the behavior when the SearchByName
 // method of the parent MockUsersStore instance is invoked.
 type UsersStoreSearchByNameFunc struct {
@@ -6698,3 +11628,114 @@ func (c UsersStoreUseCustomAvatarFuncCall) Args() []interface{} {
 func (c UsersStoreUseCustomAvatarFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
+
+// UsersStoreVerifyCommitSignatureFunc describes the behavior when the
+// VerifyCommitSignature method of the parent MockUsersStore instance is
+// invoked.
+type UsersStoreVerifyCommitSignatureFunc struct {
+	defaultHook func(context.Context, []byte) (*db.CommitVerification, error)
+	hooks       []func(context.Context, []byte) (*db.CommitVerification, error)
+	history     []UsersStoreVerifyCommitSignatureFuncCall
+	mutex       sync.Mutex
+}
+
+// VerifyCommitSignature delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockUsersStore) VerifyCommitSignature(v0 context.Context, v1 []byte) (*db.CommitVerification, error) {
+	r0, r1 := m.VerifyCommitSignatureFunc.nextHook()(v0, v1)
+	m.VerifyCommitSignatureFunc.appendCall(UsersStoreVerifyCommitSignatureFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// VerifyCommitSignature method of the parent MockUsersStore instance is
+// invoked and the hook queue is empty.
+func (f *UsersStoreVerifyCommitSignatureFunc) SetDefaultHook(hook func(context.Context, []byte) (*db.CommitVerification, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// VerifyCommitSignature method of the parent MockUsersStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UsersStoreVerifyCommitSignatureFunc) PushHook(hook func(context.Context, []byte) (*db.CommitVerification, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UsersStoreVerifyCommitSignatureFunc) SetDefaultReturn(r0 *db.CommitVerification, r1 error) {
+	f.SetDefaultHook(func(context.Context, []byte) (*db.CommitVerification, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UsersStoreVerifyCommitSignatureFunc) PushReturn(r0 *db.CommitVerification, r1 error) {
+	f.PushHook(func(context.Context, []byte) (*db.CommitVerification, error) {
+		return r0, r1
+	})
+}
+
+func (f *UsersStoreVerifyCommitSignatureFunc) nextHook() func(context.Context, []byte) (*db.CommitVerification, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UsersStoreVerifyCommitSignatureFunc) appendCall(r0 UsersStoreVerifyCommitSignatureFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UsersStoreVerifyCommitSignatureFuncCall
+// objects describing the invocations of this function.
+func (f *UsersStoreVerifyCommitSignatureFunc) History() []UsersStoreVerifyCommitSignatureFuncCall {
+	f.mutex.Lock()
+	history := make([]UsersStoreVerifyCommitSignatureFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UsersStoreVerifyCommitSignatureFuncCall is an object that describes an
+// invocation of method VerifyCommitSignature on an instance of
+// MockUsersStore.
+type UsersStoreVerifyCommitSignatureFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []byte
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *db.CommitVerification
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UsersStoreVerifyCommitSignatureFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UsersStoreVerifyCommitSignatureFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
@@ -59,7 +59,8 @@ func authenticate() macaron.Handler {
 			return
 		}
 
-		user, err := db.Users.Authenticate(c.Req.Context(), username, password, -1)
+		remoteIP := context.RemoteIP(c.Req.Request)
+		user, err := context.AuthenticateByPassword(c.Req.Context(), remoteIP, username, password)
 		if err != nil && !auth.IsErrBadCredentials(err) {
 			internalServerError(c.Resp)
 			log.Error("Failed to authenticate user [name: %s]: %v", username, err)
@@ -84,6 +85,7 @@ func authenticate() macaron.Handler {
 				user, err = context.AuthenticateByToken(c.Req.Context(), password)
 				if err != nil {
 					if db.IsErrAccessTokenNotExist(err) {
+						context.RecordFailedLogin(c.Req.Context(), remoteIP, username)
 						askCredentials(c.Resp)
 					} else {
 						c.Status(http.StatusInternalServerError)
@@ -138,6 +140,12 @@ func authorize(mode db.AccessMode) macaron.Handler {
 			return
 		}
 
+		// Repositories of an archived organization are read-only to everyone.
+		if mode > db.AccessModeRead && owner.IsArchived {
+			c.Status(http.StatusForbidden)
+			return
+		}
+
 		log.Trace("[LFS] Authorized user %q to %q", actor.Name, username+"/"+reponame)
 
 		c.Map(owner) // NOTE: Override actor
@@ -107,6 +107,29 @@ func Test_authenticate(t *testing.T) {
 			expHeader:     http.Header{},
 			expBody:       "ID: 1, Name: unknwon",
 		},
+		{
+			name: "username is locked out",
+			header: http.Header{
+				"Authorization": []string{"Basic dXNlcm5hbWU6cGFzc3dvcmQ="},
+			},
+			mockUsersStore: func() db.UsersStore {
+				mock := NewMockUsersStore()
+				mock.IsLockedOutFunc.SetDefaultReturn(true)
+				mock.AuthenticateFunc.SetDefaultReturn(&db.User{ID: 1, Name: "unknwon"}, nil)
+				return mock
+			},
+			mockAccessTokensStore: func() db.AccessTokensStore {
+				mock := NewMockAccessTokensStore()
+				mock.GetBySHA1Func.SetDefaultReturn(nil, db.ErrAccessTokenNotExist{})
+				return mock
+			},
+			expStatusCode: http.StatusUnauthorized,
+			expHeader: http.Header{
+				"Lfs-Authenticate": []string{`Basic realm="Git LFS"`},
+				"Content-Type":     []string{"application/vnd.git-lfs+json"},
+			},
+			expBody: `{"message":"Credentials needed"}` + "\n",
+		},
 		{
 			name: "authenticate by access token via username",
 			header: http.Header{
@@ -250,6 +273,30 @@ func Test_authorize(t *testing.T) {
 			},
 			expStatusCode: http.StatusNotFound,
 		},
+		{
+			name:      "owner is archived",
+			authroize: authorize(db.AccessModeWrite),
+			mockUsersStore: func() db.UsersStore {
+				mock := NewMockUsersStore()
+				mock.GetByUsernameFunc.SetDefaultHook(func(ctx context.Context, username string) (*db.User, error) {
+					return &db.User{Name: username, IsArchived: true}, nil
+				})
+				return mock
+			},
+			mockReposStore: func() db.ReposStore {
+				mock := NewMockReposStore()
+				mock.GetByNameFunc.SetDefaultHook(func(ctx context.Context, ownerID int64, name string) (*db.Repository, error) {
+					return &db.Repository{Name: name}, nil
+				})
+				return mock
+			},
+			mockPermsStore: func() db.PermsStore {
+				mock := NewMockPermsStore()
+				mock.AuthorizeFunc.SetDefaultReturn(true)
+				return mock
+			},
+			expStatusCode: http.StatusForbidden,
+		},
 
 		{
 			name:      "actor is authorized",
@@ -5,12 +5,17 @@
 package org
 
 import (
+	"encoding/csv"
+	"net/mail"
+	"strings"
+
 	"github.com/unknwon/com"
 	log "unknwon.dev/clog/v2"
 
 	"gogs.io/gogs/internal/conf"
 	"gogs.io/gogs/internal/context"
 	"gogs.io/gogs/internal/db"
+	"gogs.io/gogs/internal/email"
 )
 
 const (
@@ -23,15 +28,69 @@ func Members(c *context.Context) {
 	c.Data["Title"] = org.FullName
 	c.Data["PageIsOrgMembers"] = true
 
-	if err := org.GetMembers(0); err != nil {
-		c.Error(err, "get members")
+	members, err := db.Orgs.ListOrgMembersWithRole(c.Req.Context(), org.ID, db.ListOrgMembersOptions{})
+	if err != nil {
+		c.Error(err, "list members with role")
 		return
 	}
-	c.Data["Members"] = org.Members
+	c.Data["Members"] = members
+
+	if c.Org.IsOwner && org.RequireTwoFactor {
+		userIDs := make([]int64, 0, len(members))
+		for _, m := range members {
+			userIDs = append(userIDs, m.User.ID)
+		}
+		withoutIDs, err := db.Users.ListWithoutTwoFactor(c.Req.Context(), userIDs)
+		if err != nil {
+			c.Error(err, "list members without two-factor authentication")
+			return
+		}
+		c.Data["NumMembersWithoutTwoFactor"] = len(withoutIDs)
+	}
 
 	c.Success(MEMBERS)
 }
 
+// escapeCSVField prefixes the field with a single quote when it starts with a
+// character that spreadsheet applications treat as the start of a formula.
+func escapeCSVField(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// ExportMembers writes all members of the organization along with their role
+// and membership visibility as a CSV file.
+func ExportMembers(c *context.Context) {
+	org := c.Org.Organization
+	c.Resp.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	c.Resp.Header().Set("Content-Disposition", `attachment; filename="`+org.Name+`-members.csv"`)
+
+	w := csv.NewWriter(c.Resp)
+	_ = w.Write([]string{"username", "full_name", "role", "visibility"})
+	err := db.Orgs.StreamMembers(c.Req.Context(), org.ID, func(m *db.OrgMemberWithRole) error {
+		role := "member"
+		if m.IsOwner {
+			role = "owner"
+		}
+		visibility := "private"
+		if m.IsPublic {
+			visibility = "public"
+		}
+		return w.Write([]string{escapeCSVField(m.User.Name), escapeCSVField(m.User.FullName), role, visibility})
+	})
+	if err == nil {
+		w.Flush()
+		err = w.Error()
+	}
+	if err != nil {
+		// The response may have been partially written at this point, so only log
+		// the error.
+		log.Error("Failed to export members of organization %d: %v", org.ID, err)
+	}
+}
+
 func MembersAction(c *context.Context) {
 	uid := com.StrTo(c.Query("uid")).MustInt64()
 	if uid == 0 {
@@ -47,26 +106,26 @@ func MembersAction(c *context.Context) {
 			c.NotFound()
 			return
 		}
-		err = db.ChangeOrgUserStatus(org.ID, uid, false)
+		err = db.Orgs.SetMemberVisibility(db.WithAuditActor(c.Req.Context(), c.User.ID), org.ID, uid, false)
 	case "public":
 		if c.User.ID != uid && !c.Org.IsOwner {
 			c.NotFound()
 			return
 		}
-		err = db.ChangeOrgUserStatus(org.ID, uid, true)
+		err = db.Orgs.SetMemberVisibility(db.WithAuditActor(c.Req.Context(), c.User.ID), org.ID, uid, true)
 	case "remove":
 		if !c.Org.IsOwner {
 			c.NotFound()
 			return
 		}
-		err = org.RemoveMember(uid)
+		err = db.Orgs.RemoveMember(db.WithAuditActor(c.Req.Context(), c.User.ID), org.ID, uid)
 		if db.IsErrLastOrgOwner(err) {
 			c.Flash.Error(c.Tr("form.last_org_owner"))
 			c.Redirect(c.Org.OrgLink + "/members")
 			return
 		}
 	case "leave":
-		err = org.RemoveMember(c.User.ID)
+		err = db.Orgs.LeaveOrg(db.WithAuditActor(c.Req.Context(), c.User.ID), org.ID, c.User.ID)
 		if db.IsErrLastOrgOwner(err) {
 			c.Flash.Error(c.Tr("form.last_org_owner"))
 			c.Redirect(c.Org.OrgLink + "/members")
@@ -75,6 +134,10 @@ func MembersAction(c *context.Context) {
 	}
 
 	if err != nil {
+		if db.IsErrNotOrgMember(err) {
+			c.NotFound()
+			return
+		}
 		log.Error("Action(%s): %v", c.Params(":action"), err)
 		c.JSONSuccess(map[string]any{
 			"ok":  false,
@@ -97,6 +160,11 @@ func Invitation(c *context.Context) {
 
 	if c.Req.Method == "POST" {
 		uname := c.Query("uname")
+		if strings.Contains(uname, "@") {
+			invitePostByEmail(c, uname)
+			return
+		}
+
 		u, err := db.Users.GetByUsername(c.Req.Context(), uname)
 		if err != nil {
 			if db.IsErrUserNotExist(err) {
@@ -108,7 +176,8 @@ func Invitation(c *context.Context) {
 			return
 		}
 
-		if err = org.AddMember(u.ID); err != nil {
+		err = db.Orgs.AddMember(db.WithAuditActor(c.Req.Context(), c.User.ID), org.ID, u.ID)
+		if err != nil {
 			c.Error(err, "add member")
 			return
 		}
@@ -118,5 +187,73 @@ func Invitation(c *context.Context) {
 		return
 	}
 
+	invitations, err := db.Orgs.ListInvitations(c.Req.Context(), org.ID)
+	if err != nil {
+		c.Error(err, "list invitations")
+		return
+	}
+	c.Data["Invitations"] = invitations
+
 	c.Success(MEMBER_INVITE)
 }
+
+// invitePostByEmail invites the email address to join the organization and
+// sends the invitation email. The invitation is accepted by following the link
+// in the email, or once a user has verified the email address.
+func invitePostByEmail(c *context.Context, emailAddr string) {
+	org := c.Org.Organization
+	if _, err := mail.ParseAddress(emailAddr); err != nil {
+		c.Flash.Error(c.Tr("org.members.invalid_email", emailAddr))
+		c.Redirect(c.Org.OrgLink + "/invitations/new")
+		return
+	}
+
+	invitation, err := db.Orgs.InviteByEmail(c.Req.Context(), org.ID, emailAddr, 0)
+	if err != nil {
+		c.Error(err, "invite by email")
+		return
+	}
+
+	link := conf.Server.ExternalURL + "org/invitations/" + invitation.Token
+	email.SendOrgInvitationMail(c.Context, db.NewMailerUser(c.User), org.Name, invitation.Email, link)
+
+	log.Trace("New member invited(%s): %s", org.Name, invitation.Email)
+	c.Flash.Success(c.Tr("org.members.invite_email_success", invitation.Email))
+	c.Redirect(c.Org.OrgLink + "/invitations/new")
+}
+
+// AcceptInvitation accepts the invitation with the token on behalf of the
+// signed-in user.
+func AcceptInvitation(c *context.Context) {
+	invitation, err := db.Orgs.AcceptInvitation(c.Req.Context(), c.User.ID, c.Params(":token"))
+	if err != nil {
+		if db.IsErrOrgInvitationNotExist(err) {
+			c.Flash.Error(c.Tr("org.members.invitation_not_exist"))
+			c.Redirect(conf.Server.Subpath + "/")
+		} else {
+			c.Error(err, "accept invitation")
+		}
+		return
+	}
+
+	org, err := db.Users.GetByID(c.Req.Context(), invitation.OrgID)
+	if err != nil {
+		c.Error(err, "get organization by ID")
+		return
+	}
+
+	log.Trace("Invitation accepted(%s): %s", org.Name, c.User.Name)
+	c.Flash.Success(c.Tr("org.members.accept_invitation_success", org.Name))
+	c.Redirect(org.HomeURLPath())
+}
+
+func RevokeInvitation(c *context.Context) {
+	err := db.Orgs.RevokeInvitation(c.Req.Context(), c.Org.Organization.ID, c.ParamsInt64(":id"))
+	if err != nil && !db.IsErrOrgInvitationNotExist(err) {
+		c.Error(err, "revoke invitation")
+		return
+	}
+
+	c.Flash.Success(c.Tr("org.members.revoke_invitation_success"))
+	c.Redirect(c.Org.OrgLink + "/invitations/new")
+}
@@ -0,0 +1,97 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package org
+
+import (
+	gocontext "context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/macaron.v1"
+
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/db"
+)
+
+func TestExportMembers(t *testing.T) {
+	mockOrgsStore := NewMockOrgsStore()
+	mockOrgsStore.StreamMembersFunc.SetDefaultHook(func(_ gocontext.Context, _ int64, fn func(*db.OrgMemberWithRole) error) error {
+		members := []*db.OrgMemberWithRole{
+			{
+				User:    &db.User{ID: 2, Name: "alice", FullName: "Alice"},
+				IsOwner: true,
+			},
+			{
+				User:     &db.User{ID: 3, Name: "bob", FullName: "=HYPERLINK(\"http://example.com\")"},
+				IsPublic: true,
+			},
+			{
+				User: &db.User{ID: 4, Name: "cindy", FullName: "@SUM(1+1)"},
+			},
+		}
+		for _, m := range members {
+			if err := fn(m); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	db.SetMockOrgsStore(t, mockOrgsStore)
+
+	m := macaron.New()
+	m.Get("/org1/members/export", func(c *macaron.Context) {
+		ExportMembers(&context.Context{
+			Context: c,
+			Org: &context.Organization{
+				Organization: &db.User{ID: 1, Name: "org1"},
+			},
+		})
+	})
+
+	r, err := http.NewRequest("GET", "/org1/members/export", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	m.ServeHTTP(rr, r)
+
+	resp := rr.Result()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	want := `username,full_name,role,visibility
+alice,Alice,owner,private
+bob,"'=HYPERLINK(""http://example.com"")",member,public
+cindy,'@SUM(1+1),member,private
+`
+	assert.Equal(t, want, string(body))
+}
+
+func Test_escapeCSVField(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "", want: ""},
+		{input: "Alice", want: "Alice"},
+		{input: "a=b", want: "a=b"},
+		{input: "=1+1", want: "'=1+1"},
+		{input: "+1", want: "'+1"},
+		{input: "-1", want: "'-1"},
+		{input: "@SUM(A1)", want: "'@SUM(A1)"},
+		{input: "\t=1+1", want: "'\t=1+1"},
+		{input: "\r=1+1", want: "'\r=1+1"},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			assert.Equal(t, test.want, escapeCSVField(test.input))
+		})
+	}
+}
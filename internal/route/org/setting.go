@@ -37,6 +37,26 @@ func SettingsPost(c *context.Context, f form.UpdateOrgSetting) {
 
 	org := c.Org.Organization
 
+	if org.RequireTwoFactor != f.RequireTwoFactor {
+		err := db.Orgs.SetRequireTwoFactor(c.Req.Context(), org.ID, f.RequireTwoFactor)
+		if err != nil {
+			if db.IsErrOrgOwnersWithoutTwoFactor(err) {
+				c.RenderWithErr(c.Tr("org.settings.require_two_factor_owners_error"), SETTINGS_OPTIONS, &f)
+			} else {
+				c.Error(err, "set require two-factor")
+			}
+			return
+		}
+	}
+
+	if org.RepoCreatePermission != db.RepoCreatePermission(f.RepoCreatePermission) {
+		err := db.Orgs.SetRepoCreatePermission(c.Req.Context(), org.ID, db.RepoCreatePermission(f.RepoCreatePermission))
+		if err != nil {
+			c.Error(err, "set repository creation permission")
+			return
+		}
+	}
+
 	// Check if the organization username (including cases) had been changed
 	if org.Name != f.Name {
 		err := db.Users.ChangeUsername(c.Req.Context(), c.Org.Organization.ID, f.Name)
@@ -100,6 +120,23 @@ func SettingsDeleteAvatar(c *context.Context) {
 	c.Redirect(c.Org.OrgLink + "/settings")
 }
 
+func SettingsArchive(c *context.Context) {
+	org := c.Org.Organization
+	archived := c.Query("action") == "archive"
+	if err := db.Orgs.SetArchived(c.Req.Context(), org.ID, archived); err != nil {
+		c.Error(err, "set archived")
+		return
+	}
+	log.Trace("Organization archived status updated [archived: %t]: %s", archived, org.Name)
+
+	if archived {
+		c.Flash.Success(c.Tr("org.settings.archive_success"))
+	} else {
+		c.Flash.Success(c.Tr("org.settings.unarchive_success"))
+	}
+	c.Redirect(c.Org.OrgLink + "/settings")
+}
+
 func SettingsDelete(c *context.Context) {
 	c.Title("org.settings")
 	c.PageIs("SettingsDelete")
@@ -115,8 +152,8 @@ func SettingsDelete(c *context.Context) {
 			return
 		}
 
-		if err := db.DeleteOrganization(org); err != nil {
-			if db.IsErrUserOwnRepos(err) {
+		if err := db.Orgs.DeleteByID(c.Req.Context(), org.ID, db.DeleteOrgOptions{}); err != nil {
+			if db.IsErrOrgOwnsRepos(err) {
 				c.Flash.Error(c.Tr("form.org_still_own_repo"))
 				c.Redirect(c.Org.OrgLink + "/settings/delete")
 			} else {
@@ -7,10 +7,13 @@ package org
 import (
 	"net/http"
 	"path"
+	"strings"
 
 	"github.com/unknwon/com"
+	"github.com/unknwon/paginater"
 	log "unknwon.dev/clog/v2"
 
+	"gogs.io/gogs/internal/conf"
 	"gogs.io/gogs/internal/context"
 	"gogs.io/gogs/internal/db"
 	"gogs.io/gogs/internal/form"
@@ -46,6 +49,8 @@ func TeamsAction(c *context.Context) {
 		return
 	}
 
+	ctx := db.WithAuditActor(c.Req.Context(), c.User.ID)
+	teamID := c.Org.Team.ID
 	page := c.Query("page")
 	var err error
 	switch c.Params(":action") {
@@ -54,15 +59,15 @@ func TeamsAction(c *context.Context) {
 			c.NotFound()
 			return
 		}
-		err = c.Org.Team.AddMember(c.User.ID)
+		err = db.Teams.AddTeamMember(ctx, teamID, c.User.ID)
 	case "leave":
-		err = c.Org.Team.RemoveMember(c.User.ID)
+		err = db.Teams.RemoveTeamMember(ctx, teamID, c.User.ID)
 	case "remove":
 		if !c.Org.IsOwner {
 			c.NotFound()
 			return
 		}
-		err = c.Org.Team.RemoveMember(uid)
+		err = db.Teams.RemoveTeamMember(ctx, teamID, uid)
 		page = "team"
 	case "add":
 		if !c.Org.IsOwner {
@@ -82,7 +87,7 @@ func TeamsAction(c *context.Context) {
 			return
 		}
 
-		err = c.Org.Team.AddMember(u.ID)
+		err = db.Orgs.AddMemberToTeam(ctx, c.Org.Organization.ID, u.ID, teamID)
 		page = "team"
 	}
 
@@ -129,9 +134,9 @@ func TeamsRepoAction(c *context.Context) {
 			c.Error(err, "get repository by name")
 			return
 		}
-		err = c.Org.Team.AddRepository(repo)
+		err = c.Org.Team.AddRepository(db.WithAuditActor(c.Req.Context(), c.User.ID), repo)
 	case "remove":
-		err = c.Org.Team.RemoveRepository(com.StrTo(c.Query("repoid")).MustInt64())
+		err = c.Org.Team.RemoveRepository(db.WithAuditActor(c.Req.Context(), c.User.ID), com.StrTo(c.Query("repoid")).MustInt64())
 	}
 
 	if err != nil {
@@ -167,7 +172,14 @@ func NewTeamPost(c *context.Context, f form.CreateTeam) {
 		return
 	}
 
-	if err := db.NewTeam(t); err != nil {
+	ctx := db.WithAuditActor(c.Req.Context(), c.User.ID)
+	team, err := db.Teams.Create(ctx, t.OrgID, t.Name,
+		db.CreateTeamOptions{
+			Description: t.Description,
+			Authorize:   t.Authorize,
+		},
+	)
+	if err != nil {
 		c.Data["Err_TeamName"] = true
 		switch {
 		case db.IsErrTeamAlreadyExist(err):
@@ -179,27 +191,37 @@ func NewTeamPost(c *context.Context, f form.CreateTeam) {
 		}
 		return
 	}
-	log.Trace("Team created: %s/%s", c.Org.Organization.Name, t.Name)
-	c.Redirect(c.Org.OrgLink + "/teams/" + t.LowerName)
+	log.Trace("Team created: %s/%s", c.Org.Organization.Name, team.Name)
+	c.Redirect(c.Org.OrgLink + "/teams/" + team.LowerName)
 }
 
 func TeamMembers(c *context.Context) {
 	c.Data["Title"] = c.Org.Team.Name
 	c.Data["PageIsOrgTeams"] = true
-	if err := c.Org.Team.GetMembers(); err != nil {
-		c.Error(err, "get members")
+	members, err := db.Teams.ListMembers(c.Req.Context(), c.Org.Team.ID)
+	if err != nil {
+		c.Error(err, "list members")
 		return
 	}
+	c.Org.Team.Members = members
 	c.Success(TEAM_MEMBERS)
 }
 
 func TeamRepositories(c *context.Context) {
 	c.Data["Title"] = c.Org.Team.Name
 	c.Data["PageIsOrgTeams"] = true
-	if err := c.Org.Team.GetRepositories(); err != nil {
-		c.Error(err, "get repositories")
+
+	page := c.QueryInt("page")
+	if page <= 0 {
+		page = 1
+	}
+	repos, count, err := db.Teams.ListRepos(c.Req.Context(), c.Org.Team.ID, page, conf.UI.User.RepoPagingNum)
+	if err != nil {
+		c.Error(err, "list repositories")
 		return
 	}
+	c.Data["Repos"] = repos
+	c.Data["Page"] = paginater.New(int(count), conf.UI.User.RepoPagingNum, page, 5)
 	c.Success(TEAM_REPOSITORIES)
 }
 
@@ -222,7 +244,9 @@ func EditTeamPost(c *context.Context, f form.CreateTeam) {
 		return
 	}
 
-	isAuthChanged := false
+	opts := db.UpdateTeamOptions{
+		Description: &f.Description,
+	}
 	if !t.IsOwnerTeam() {
 		// Validate permission level.
 		var auth db.AccessMode
@@ -238,14 +262,12 @@ func EditTeamPost(c *context.Context, f form.CreateTeam) {
 			return
 		}
 
-		t.Name = f.TeamName
-		if t.Authorize != auth {
-			isAuthChanged = true
-			t.Authorize = auth
-		}
+		opts.Name = &f.TeamName
+		opts.Authorize = &auth
 	}
-	t.Description = f.Description
-	if err := db.UpdateTeam(t, isAuthChanged); err != nil {
+
+	ctx := db.WithAuditActor(c.Req.Context(), c.User.ID)
+	if err := db.Teams.Update(ctx, t.ID, opts); err != nil {
 		c.Data["Err_TeamName"] = true
 		switch {
 		case db.IsErrTeamAlreadyExist(err):
@@ -255,11 +277,15 @@ func EditTeamPost(c *context.Context, f form.CreateTeam) {
 		}
 		return
 	}
+	if opts.Name != nil {
+		t.LowerName = strings.ToLower(*opts.Name)
+	}
 	c.Redirect(c.Org.OrgLink + "/teams/" + t.LowerName)
 }
 
 func DeleteTeam(c *context.Context) {
-	if err := db.DeleteTeam(c.Org.Team); err != nil {
+	err := db.Teams.DeleteByID(db.WithAuditActor(c.Req.Context(), c.User.ID), c.Org.Team.ID)
+	if err != nil {
 		c.Flash.Error("DeleteTeam: " + err.Error())
 	} else {
 		c.Flash.Success(c.Tr("org.teams.delete_team_success"))
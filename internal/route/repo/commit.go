@@ -132,6 +132,18 @@ func Diff(c *context.Context) {
 		return
 	}
 
+	rawCommit, err := git.NewCommand("cat-file", "commit", commit.ID.String()).
+		RunInDirWithTimeout(time.Duration(conf.Git.Timeout.Diff)*time.Second, c.Repo.GitRepo.Path())
+	if err != nil {
+		c.Error(err, "get raw commit")
+		return
+	}
+	verification, err := db.Users.VerifyCommitSignature(c.Req.Context(), rawCommit)
+	if err != nil {
+		c.Error(err, "verify commit signature")
+		return
+	}
+
 	diff, err := gitutil.RepoDiff(c.Repo.GitRepo,
 		commitID, conf.Git.MaxDiffFiles, conf.Git.MaxDiffLines, conf.Git.MaxDiffLineChars,
 		git.DiffOptions{Timeout: time.Duration(conf.Git.Timeout.Diff) * time.Second},
@@ -165,6 +177,7 @@ func Diff(c *context.Context) {
 	c.Data["IsImageFileByIndex"] = commit.IsImageFileByIndex
 	c.Data["Commit"] = commit
 	c.Data["Author"] = tryGetUserByEmail(c.Req.Context(), commit.Author.Email)
+	c.Data["Verification"] = verification
 	c.Data["Diff"] = diff
 	c.Data["Parents"] = parents
 	c.Data["DiffNotAvailable"] = diff.NumFiles() == 0
@@ -80,6 +80,11 @@ func HTTPContexter() macaron.Handler {
 		repo, err := db.Repos.GetByName(c.Req.Context(), owner.ID, repoName)
 		if err != nil {
 			if db.IsErrRepoNotExist(err) {
+				// NOTE: The request is not authenticated yet, so only repositories that
+				// are accessible anonymously are redirected.
+				if context.RedirectRenamedRepo(c, nil, owner.ID, repoName) {
+					return
+				}
 				c.Status(http.StatusNotFound)
 			} else {
 				c.Status(http.StatusInternalServerError)
@@ -124,7 +129,8 @@ func HTTPContexter() macaron.Handler {
 			return
 		}
 
-		authUser, err := db.Users.Authenticate(c.Req.Context(), authUsername, authPassword, -1)
+		remoteIP := context.RemoteIP(c.Req.Request)
+		authUser, err := context.AuthenticateByPassword(c.Req.Context(), remoteIP, authUsername, authPassword)
 		if err != nil && !auth.IsErrBadCredentials(err) {
 			c.Status(http.StatusInternalServerError)
 			log.Error("Failed to authenticate user [name: %s]: %v", authUsername, err)
@@ -144,6 +150,7 @@ func HTTPContexter() macaron.Handler {
 				authUser, err = context.AuthenticateByToken(c.Req.Context(), authPassword)
 				if err != nil {
 					if db.IsErrAccessTokenNotExist(err) {
+						context.RecordFailedLogin(c.Req.Context(), remoteIP, authUsername)
 						askCredentials(c, http.StatusUnauthorized, "")
 					} else {
 						c.Status(http.StatusInternalServerError)
@@ -179,6 +186,24 @@ Please create and use personal access token on user settings page`)
 			return
 		}
 
+		if !isPull && owner.IsArchived {
+			c.Error(http.StatusForbidden, "Repository owner is archived and its repositories are read-only")
+			return
+		}
+
+		if !isPull {
+			err = db.Users.CheckStorageQuota(c.Req.Context(), repo.OwnerID)
+			if err != nil {
+				if db.IsErrStorageQuotaExceeded(err) {
+					c.Error(http.StatusForbidden, "Repository owner has reached the storage quota")
+				} else {
+					c.Status(http.StatusInternalServerError)
+					log.Error("Failed to check storage quota [owner_id: %d]: %v", repo.OwnerID, err)
+				}
+				return
+			}
+		}
+
 		c.Map(&HTTPContext{
 			Context:   c,
 			OwnerName: ownerName,
@@ -258,14 +283,20 @@ func serviceRPC(h serviceHandler, service string) {
 	var stderr bytes.Buffer
 	cmd := exec.Command("git", service, "--stateless-rpc", h.dir)
 	if service == "receive-pack" {
-		cmd.Env = append(os.Environ(), db.ComposeHookEnvs(db.ComposeHookEnvsOptions{
+		envs, err := db.ComposeHookEnvs(db.ComposeHookEnvsOptions{
 			AuthUser:  h.authUser,
 			OwnerName: h.ownerName,
 			OwnerSalt: h.ownerSalt,
 			RepoID:    h.repoID,
 			RepoName:  h.repoName,
 			RepoPath:  h.dir,
-		})...)
+		})
+		if err != nil {
+			log.Error("HTTP.serviceRPC: fail to compose hook envs: %v", err)
+			h.w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		cmd.Env = append(os.Environ(), envs...)
 	}
 	cmd.Dir = h.dir
 	cmd.Stdout = h.w
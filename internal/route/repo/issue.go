@@ -168,7 +168,7 @@ func issues(c *context.Context, isPullList bool) {
 	pager := paginater.New(total, conf.UI.IssuePagingNum, page, 5)
 	c.Data["Page"] = pager
 
-	issues, err := db.Issues(&db.IssuesOptions{
+	issues, err := db.ListIssues(&db.IssuesOptions{
 		UserID:      uid,
 		AssigneeID:  assigneeID,
 		RepoID:      repo.ID,
@@ -545,6 +545,9 @@ func viewIssue(c *context.Context, isPullList bool) {
 			PrepareMergedViewPullInfo(c, issue)
 		} else {
 			PrepareViewPullInfo(c, issue)
+			if !c.Written() && !issue.IsClosed {
+				PrepareViewPullApprovals(c, issue)
+			}
 		}
 		if c.Written() {
 			return
@@ -572,6 +575,13 @@ func viewIssue(c *context.Context, isPullList bool) {
 	c.Data["HasSelectedLabel"] = hasSelected
 	c.Data["Labels"] = labels
 
+	assignees, err := db.Issues.ListAssignees(c.Req.Context(), issue.ID)
+	if err != nil {
+		c.Error(err, "list assignees")
+		return
+	}
+	c.Data["IssueAssignees"] = assignees
+
 	// Check milestone and assignee.
 	if c.Repo.IsWriter() {
 		RetrieveRepoMilestonesAndAssignees(c, repo)
@@ -610,7 +620,7 @@ func viewIssue(c *context.Context, isPullList bool) {
 			}
 
 			if repo.IsOwnedBy(comment.PosterID) ||
-				(repo.Owner.IsOrganization() && repo.Owner.IsOwnedBy(comment.PosterID)) {
+				(repo.Owner.IsOrganization() && db.Orgs.IsOwnedBy(c.Req.Context(), repo.Owner.ID, comment.PosterID)) {
 				comment.ShowTag = db.COMMENT_TAG_OWNER
 			} else if db.Perms.Authorize(
 				c.Req.Context(),
@@ -816,16 +826,39 @@ func UpdateIssueAssignee(c *context.Context) {
 		return
 	}
 
-	assigneeID := c.QueryInt64("id")
-	if issue.AssigneeID == assigneeID {
-		c.JSONSuccess(map[string]any{
-			"ok": true,
-		})
+	if err := issue.ChangeAssignee(c.User, c.QueryInt64("id")); err != nil {
+		c.Error(err, "change assignee")
 		return
 	}
 
-	if err := issue.ChangeAssignee(c.User, assigneeID); err != nil {
-		c.Error(err, "change assignee")
+	c.JSONSuccess(map[string]any{
+		"ok": true,
+	})
+}
+
+// UpdateIssueAssignees replaces all assignees of the issue with users of given
+// comma-separated IDs, users who cannot be assigned are silently dropped.
+func UpdateIssueAssignees(c *context.Context) {
+	issue := getActionIssue(c)
+	if c.Written() {
+		return
+	}
+
+	assigneeIDs := make([]int64, 0, 2)
+	for _, id := range tool.StringsToInt64s(strings.Split(c.Query("ids"), ",")) {
+		_, err := c.Repo.Repository.GetAssigneeByID(id)
+		if err != nil {
+			if db.IsErrUserNotExist(err) {
+				continue
+			}
+			c.Error(err, "get assignee by ID")
+			return
+		}
+		assigneeIDs = append(assigneeIDs, id)
+	}
+
+	if err := issue.ChangeAssignees(c.User, assigneeIDs); err != nil {
+		c.Error(err, "change assignees")
 		return
 	}
 
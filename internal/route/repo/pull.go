@@ -5,6 +5,8 @@
 package repo
 
 import (
+	"bytes"
+	"fmt"
 	"net/http"
 	"path"
 	"strings"
@@ -20,6 +22,8 @@ import (
 	"gogs.io/gogs/internal/db"
 	"gogs.io/gogs/internal/form"
 	"gogs.io/gogs/internal/gitutil"
+	"gogs.io/gogs/internal/repoutil"
+	"gogs.io/gogs/internal/template"
 )
 
 const (
@@ -80,7 +84,7 @@ func parseBaseRepository(c *context.Context) *db.Repository {
 		c.Error(err, "list organizations")
 		return nil
 	}
-	c.Data["Orgs"] = orgs
+	c.Data["Orgs"] = template.NewOrganizations(c.Req.Context(), db.Orgs, orgs, c.User.ID)
 
 	return baseRepo
 }
@@ -105,10 +109,14 @@ func ForkPost(c *context.Context, f form.CreateRepo) {
 		return
 	}
 
+	// checkContextUser overwrites the organizations listed by parseBaseRepository,
+	// which are the ones the fork template is rendered with.
+	orgs := c.Data["Orgs"]
 	ctxUser := checkContextUser(c, f.UserID)
 	if c.Written() {
 		return
 	}
+	c.Data["Orgs"] = orgs
 	c.Data["ContextUser"] = ctxUser
 
 	if c.HasError() {
@@ -126,7 +134,7 @@ func ForkPost(c *context.Context, f form.CreateRepo) {
 	}
 
 	// Check ownership of organization.
-	if ctxUser.IsOrganization() && !ctxUser.IsOwnedBy(c.User.ID) {
+	if ctxUser.IsOrganization() && !db.Orgs.IsOwnedBy(c.Req.Context(), ctxUser.ID, c.User.ID) {
 		c.Status(http.StatusForbidden)
 		return
 	}
@@ -423,6 +431,16 @@ func MergePullRequest(c *context.Context) {
 		return
 	}
 
+	missingApprovals, err := codeOwnersMissingApprovals(c, pr)
+	if err != nil {
+		c.Error(err, "check code owners approvals")
+		return
+	} else if len(missingApprovals) > 0 {
+		c.Flash.Error(c.Tr("repo.pulls.code_owners_approval_required"))
+		c.Redirect(c.Repo.RepoLink + "/pulls/" + com.ToStr(pr.Index))
+		return
+	}
+
 	pr.Issue = issue
 	pr.Issue.Repo = c.Repo.Repository
 	if err = pr.Merge(c.User, c.Repo.GitRepo, db.MergeStyle(c.Query("merge_style")), c.Query("commit_description")); err != nil {
@@ -434,6 +452,101 @@ func MergePullRequest(c *context.Context) {
 	c.Redirect(c.Repo.RepoLink + "/pulls/" + com.ToStr(pr.Index))
 }
 
+// codeOwnersMissingApprovals returns the rules of the CODEOWNERS file in the
+// base branch that determine owners of files changed by the pull request, but
+// none of whose owners has approved the current head commit.
+func codeOwnersMissingApprovals(c *context.Context, pr *db.PullRequest) ([]*repoutil.CodeOwnersRule, error) {
+	commit, err := c.Repo.GitRepo.BranchCommit(pr.BaseBranch)
+	if err != nil {
+		return nil, fmt.Errorf("get base branch commit: %v", err)
+	}
+
+	var owners *repoutil.CodeOwners
+	for _, filename := range repoutil.CodeOwnersPaths {
+		entry, err := commit.TreeEntry(filename)
+		if err != nil {
+			continue
+		}
+		p, err := entry.Blob().Bytes()
+		if err != nil {
+			return nil, fmt.Errorf("read %q: %v", filename, err)
+		}
+
+		owners, err = repoutil.ParseCodeOwners(bytes.NewReader(p))
+		if err != nil {
+			// An invalid file should not prevent every pull request from being merged.
+			log.Warn("Failed to parse %q of repository %d: %v", filename, pr.BaseRepoID, err)
+			return nil, nil
+		}
+		break
+	}
+	if owners == nil {
+		return nil, nil
+	}
+
+	headCommitID, err := c.Repo.GitRepo.RevParse(pr.GitRefName())
+	if err != nil {
+		return nil, fmt.Errorf("get head commit: %v", err)
+	}
+	paths, err := c.Repo.GitRepo.DiffNameOnly(pr.BaseBranch, headCommitID, git.DiffNameOnlyOptions{NeedsMergeBase: true})
+	if err != nil {
+		return nil, fmt.Errorf("list changed files: %v", err)
+	}
+	approverIDs, err := db.Pulls.ListApproverIDs(c.Req.Context(), pr.ID, headCommitID)
+	if err != nil {
+		return nil, fmt.Errorf("list approvers: %v", err)
+	}
+	return db.CodeOwners.MissingApprovals(c.Req.Context(), owners, paths, approverIDs)
+}
+
+// PrepareViewPullApprovals sets the approval status of the open pull request
+// required by code owners.
+func PrepareViewPullApprovals(c *context.Context, issue *db.Issue) {
+	missingApprovals, err := codeOwnersMissingApprovals(c, issue.PullRequest)
+	if err != nil {
+		// The head reference may not exist yet when the pull request was just
+		// created, which should not prevent the pull request from being viewed.
+		log.Error("Failed to check code owners approvals of pull request %d: %v", issue.PullRequest.ID, err)
+		return
+	}
+	c.Data["CodeOwnersMissingApprovals"] = missingApprovals
+	c.Data["CanApprovePull"] = c.IsLogged && c.Repo.HasAccess() && c.User.ID != issue.PosterID
+}
+
+func ApprovePullRequest(c *context.Context) {
+	issue := checkPullInfo(c)
+	if c.Written() {
+		return
+	}
+	if issue.IsClosed || issue.PullRequest == nil || issue.PullRequest.HasMerged {
+		c.NotFound()
+		return
+	}
+
+	link := c.Repo.RepoLink + "/pulls/" + com.ToStr(issue.Index)
+	if issue.PosterID == c.User.ID {
+		c.Flash.Error(c.Tr("repo.pulls.cannot_approve_own"))
+		c.Redirect(link)
+		return
+	}
+
+	pr := issue.PullRequest
+	headCommitID, err := c.Repo.GitRepo.RevParse(pr.GitRefName())
+	if err != nil {
+		c.Error(err, "get head commit")
+		return
+	}
+	err = db.Pulls.Approve(c.Req.Context(), pr.ID, c.User.ID, headCommitID)
+	if err != nil {
+		c.Error(err, "approve pull request")
+		return
+	}
+
+	log.Trace("Pull request approved: %d [user_id: %d, commit_id: %s]", pr.ID, c.User.ID, headCommitID)
+	c.Flash.Success(c.Tr("repo.pulls.approve_success"))
+	c.Redirect(link)
+}
+
 func ParseCompareInfo(c *context.Context) (*db.User, *db.Repository, *git.Repository, *gitutil.PullRequestMeta, string, string) {
 	baseRepo := c.Repo.Repository
 
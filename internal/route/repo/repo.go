@@ -24,8 +24,9 @@ import (
 )
 
 const (
-	CREATE  = "repo/create"
-	MIGRATE = "repo/migrate"
+	CREATE               = "repo/create"
+	CREATE_FROM_TEMPLATE = "repo/create_from_template"
+	MIGRATE              = "repo/migrate"
 )
 
 func MustBeNotBare(c *context.Context) {
@@ -35,9 +36,9 @@ func MustBeNotBare(c *context.Context) {
 }
 
 func checkContextUser(c *context.Context, uid int64) *db.User {
-	orgs, err := db.GetOwnedOrgsByUserIDDesc(c.User.ID, "updated_unix")
+	orgs, err := db.Orgs.ListOrgsWithRepoCreatePermission(c.Req.Context(), c.User.ID)
 	if err != nil {
-		c.Error(err, "get owned organization by user ID")
+		c.Error(err, "list organizations with repository creation permission")
 		return nil
 	}
 	c.Data["Orgs"] = orgs
@@ -57,8 +58,15 @@ func checkContextUser(c *context.Context, uid int64) *db.User {
 		return nil
 	}
 
-	// Check ownership of organization.
-	if !org.IsOrganization() || !(c.User.IsAdmin || org.IsOwnedBy(c.User.ID)) {
+	// Check repository creation permission of organization.
+	canCreate := c.User.IsAdmin
+	for _, o := range orgs {
+		if o.ID == org.ID {
+			canCreate = true
+			break
+		}
+	}
+	if !org.IsOrganization() || !canCreate {
 		c.Status(http.StatusForbidden)
 		return nil
 	}
@@ -144,6 +152,90 @@ func CreatePost(c *context.Context, f form.CreateRepo) {
 	handleCreateError(c, err, "CreatePost", CREATE, &f)
 }
 
+// parseTemplateRepository returns the template repository of the ID in the
+// route parameters, it responds 404 when the repository is not a template or
+// not accessible by the current user.
+func parseTemplateRepository(c *context.Context) *db.Repository {
+	templateRepo, err := db.GetRepositoryByID(c.ParamsInt64(":repoid"))
+	if err != nil {
+		c.NotFoundOrError(err, "get repository by ID")
+		return nil
+	}
+
+	if !templateRepo.IsTemplate || !templateRepo.HasAccess(c.User.ID) {
+		c.NotFound()
+		return nil
+	}
+
+	if err = templateRepo.GetOwner(); err != nil {
+		c.Error(err, "get owner")
+		return nil
+	}
+	c.Data["TemplateFrom"] = templateRepo.Owner.Name + "/" + templateRepo.Name
+	return templateRepo
+}
+
+func CreateFromTemplate(c *context.Context) {
+	c.Title("new_from_template")
+	c.RequireAutosize()
+	c.Data["private"] = c.User.LastRepoVisibility
+	c.Data["IsForcedPrivate"] = conf.Repository.ForcePrivate
+	c.Data["include_labels"] = true
+
+	templateRepo := parseTemplateRepository(c)
+	if c.Written() {
+		return
+	}
+	c.Data["description"] = templateRepo.Description
+
+	ctxUser := checkContextUser(c, c.QueryInt64("org"))
+	if c.Written() {
+		return
+	}
+	c.Data["ContextUser"] = ctxUser
+
+	c.Success(CREATE_FROM_TEMPLATE)
+}
+
+func CreateFromTemplatePost(c *context.Context, f form.CreateRepoFromTemplate) {
+	c.Title("new_from_template")
+	c.Data["IsForcedPrivate"] = conf.Repository.ForcePrivate
+
+	templateRepo := parseTemplateRepository(c)
+	if c.Written() {
+		return
+	}
+
+	ctxUser := checkContextUser(c, f.UserID)
+	if c.Written() {
+		return
+	}
+	c.Data["ContextUser"] = ctxUser
+
+	if c.HasError() {
+		c.Success(CREATE_FROM_TEMPLATE)
+		return
+	}
+
+	repo, err := db.Repos.CreateFromTemplate(c.Req.Context(), templateRepo.ID, ctxUser.ID,
+		db.CreateFromTemplateOptions{
+			Name:          f.RepoName,
+			Description:   f.Description,
+			Private:       f.Private || conf.Repository.ForcePrivate,
+			IncludeLabels: f.IncludeLabels,
+			IncludeIssues: f.IncludeIssues,
+			DoerID:        c.User.ID,
+		},
+	)
+	if err != nil {
+		handleCreateError(c, err, "create from template", CREATE_FROM_TEMPLATE, &f)
+		return
+	}
+
+	log.Trace("Repository created from template '%s' -> '%s'", templateRepo.FullName(), repo.FullName())
+	c.Redirect(repo.Link())
+}
+
 func Migrate(c *context.Context) {
 	c.Data["Title"] = c.Tr("new_migrate")
 	c.Data["private"] = c.User.LastRepoVisibility
@@ -9,6 +9,7 @@ import (
 	"io"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/gogs/git-module"
 	"github.com/unknwon/com"
@@ -40,6 +41,18 @@ func Settings(c *context.Context) {
 	c.Title("repo.settings")
 	c.PageIs("SettingsOptions")
 	c.RequireAutosize()
+
+	topics, err := db.Repos.ListTopics(c.Req.Context(), c.Repo.Repository.ID)
+	if err != nil {
+		c.Error(err, "list topics")
+		return
+	}
+	names := make([]string, len(topics))
+	for i := range topics {
+		names[i] = topics[i].Name
+	}
+	c.Data["Topics"] = strings.Join(names, ", ")
+
 	c.Success(SETTINGS_OPTIONS)
 }
 
@@ -57,6 +70,20 @@ func SettingsPost(c *context.Context, f form.RepoSetting) {
 			return
 		}
 
+		topics := strings.FieldsFunc(f.Topics, func(r rune) bool {
+			return r == ',' || unicode.IsSpace(r)
+		})
+		if err := db.Repos.SetTopics(c.Req.Context(), repo.ID, topics); err != nil {
+			if db.IsErrInvalidTopic(err) {
+				c.Data["Topics"] = f.Topics
+				c.FormErr("Topics")
+				c.RenderWithErr(c.Tr("repo.settings.invalid_topics"), SETTINGS_OPTIONS, &f)
+			} else {
+				c.Error(err, "set topics")
+			}
+			return
+		}
+
 		isNameChanged := false
 		oldRepoName := repo.Name
 		newRepoName := f.RepoName
@@ -91,13 +118,23 @@ func SettingsPost(c *context.Context, f form.RepoSetting) {
 			f.Unlisted = repo.BaseRepo.IsUnlisted
 		}
 
-		visibilityChanged := repo.IsPrivate != f.Private || repo.IsUnlisted != f.Unlisted
-		repo.IsPrivate = f.Private
+		// The private flag is changed separately after other settings are saved,
+		// so that accesses, watches and forks are updated accordingly.
+		privateChanged := repo.IsPrivate != f.Private
+		unlistedChanged := repo.IsUnlisted != f.Unlisted
 		repo.IsUnlisted = f.Unlisted
-		if err := db.UpdateRepository(repo, visibilityChanged); err != nil {
+		repo.IsTemplate = f.Template
+		if err := db.UpdateRepository(repo, unlistedChanged); err != nil {
 			c.Error(err, "update repository")
 			return
 		}
+		if privateChanged {
+			if err := db.Repos.SetPrivate(c.Req.Context(), repo.ID, f.Private); err != nil {
+				c.Error(err, "set private")
+				return
+			}
+			repo.IsPrivate = f.Private
+		}
 		log.Trace("Repository basic settings updated: %s/%s", c.Repo.Owner.Name, repo.Name)
 
 		if isNameChanged {
@@ -184,7 +221,7 @@ func SettingsPost(c *context.Context, f form.RepoSetting) {
 		}
 
 		if c.Repo.Owner.IsOrganization() {
-			if !c.Repo.Owner.IsOwnedBy(c.User.ID) {
+			if !db.Orgs.IsOwnedBy(c.Req.Context(), c.Repo.Owner.ID, c.User.ID) {
 				c.NotFound()
 				return
 			}
@@ -207,6 +244,26 @@ func SettingsPost(c *context.Context, f form.RepoSetting) {
 		c.Flash.Success(c.Tr("repo.settings.convert_succeed"))
 		c.Redirect(conf.Server.Subpath + "/" + c.Repo.Owner.Name + "/" + repo.Name)
 
+	case "archive", "unarchive":
+		if !c.Repo.IsOwner() {
+			c.NotFound()
+			return
+		}
+
+		archived := c.Query("action") == "archive"
+		if err := db.Repos.SetArchived(c.Req.Context(), repo.ID, archived); err != nil {
+			c.Error(err, "set archived")
+			return
+		}
+		log.Trace("Repository archived status updated [archived: %t]: %s/%s", archived, c.Repo.Owner.Name, repo.Name)
+
+		if archived {
+			c.Flash.Success(c.Tr("repo.settings.archive_success"))
+		} else {
+			c.Flash.Success(c.Tr("repo.settings.unarchive_success"))
+		}
+		c.Redirect(c.Repo.RepoLink + "/settings")
+
 	case "transfer":
 		if !c.Repo.IsOwner() {
 			c.NotFound()
@@ -218,14 +275,22 @@ func SettingsPost(c *context.Context, f form.RepoSetting) {
 		}
 
 		if c.Repo.Owner.IsOrganization() && !c.User.IsAdmin {
-			if !c.Repo.Owner.IsOwnedBy(c.User.ID) {
+			if !db.Orgs.IsOwnedBy(c.Req.Context(), c.Repo.Owner.ID, c.User.ID) {
 				c.NotFound()
 				return
 			}
 		}
 
 		newOwner := c.Query("new_owner_name")
-		if !db.Users.IsUsernameUsed(c.Req.Context(), newOwner, c.Repo.Owner.ID) {
+		owner, err := db.Users.GetByUsername(c.Req.Context(), newOwner)
+		if err != nil {
+			if db.IsErrUserNotExist(err) {
+				c.RenderWithErr(c.Tr("form.enterred_invalid_owner_name"), SETTINGS_OPTIONS, nil)
+			} else {
+				c.Error(err, "get user by name")
+			}
+			return
+		} else if owner.ID == c.Repo.Owner.ID {
 			c.RenderWithErr(c.Tr("form.enterred_invalid_owner_name"), SETTINGS_OPTIONS, nil)
 			return
 		}
@@ -253,13 +318,13 @@ func SettingsPost(c *context.Context, f form.RepoSetting) {
 		}
 
 		if c.Repo.Owner.IsOrganization() && !c.User.IsAdmin {
-			if !c.Repo.Owner.IsOwnedBy(c.User.ID) {
+			if !db.Orgs.IsOwnedBy(c.Req.Context(), c.Repo.Owner.ID, c.User.ID) {
 				c.NotFound()
 				return
 			}
 		}
 
-		if err := db.DeleteRepository(c.Repo.Owner.ID, repo.ID); err != nil {
+		if err := db.DeleteRepositoryWithRetention(c.Repo.Owner.ID, repo.ID); err != nil {
 			c.Error(err, "delete repository")
 			return
 		}
@@ -279,7 +344,7 @@ func SettingsPost(c *context.Context, f form.RepoSetting) {
 		}
 
 		if c.Repo.Owner.IsOrganization() && !c.User.IsAdmin {
-			if !c.Repo.Owner.IsOwnedBy(c.User.ID) {
+			if !db.Orgs.IsOwnedBy(c.Req.Context(), c.Repo.Owner.ID, c.User.ID) {
 				c.NotFound()
 				return
 			}
@@ -625,7 +690,7 @@ func SettingsDeployKeys(c *context.Context) {
 	c.Success(SETTINGS_DEPLOY_KEYS)
 }
 
-func SettingsDeployKeysPost(c *context.Context, f form.AddSSHKey) {
+func SettingsDeployKeysPost(c *context.Context, f form.AddDeployKey) {
 	c.Data["Title"] = c.Tr("repo.settings.deploy_keys")
 	c.Data["PageIsSettingsKeys"] = true
 
@@ -654,14 +719,14 @@ func SettingsDeployKeysPost(c *context.Context, f form.AddSSHKey) {
 		}
 	}
 
-	key, err := db.AddDeployKey(c.Repo.Repository.ID, f.Title, content)
+	err = db.Repos.AddDeployKey(c.Req.Context(), c.Repo.Repository.ID, f.Title, content, !f.AllowWrite)
 	if err != nil {
 		c.Data["HasError"] = true
 		switch {
-		case db.IsErrKeyAlreadyExist(err):
+		case db.IsErrKeyAlreadyExist(err), db.IsErrDeployKeyAlreadyExist(err):
 			c.Data["Err_Content"] = true
 			c.RenderWithErr(c.Tr("repo.settings.key_been_used"), SETTINGS_DEPLOY_KEYS, &f)
-		case db.IsErrKeyNameAlreadyUsed(err):
+		case db.IsErrDeployKeyNameAlreadyUsed(err):
 			c.Data["Err_Title"] = true
 			c.RenderWithErr(c.Tr("repo.settings.key_name_used"), SETTINGS_DEPLOY_KEYS, &f)
 		default:
@@ -671,7 +736,7 @@ func SettingsDeployKeysPost(c *context.Context, f form.AddSSHKey) {
 	}
 
 	log.Trace("Deploy key added: %d", c.Repo.Repository.ID)
-	c.Flash.Success(c.Tr("repo.settings.add_key_success", key.Name))
+	c.Flash.Success(c.Tr("repo.settings.add_key_success", f.Title))
 	c.Redirect(c.Repo.RepoLink + "/settings/keys")
 }
 
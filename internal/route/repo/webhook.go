@@ -8,12 +8,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"path"
 	"strings"
 	"time"
 
 	"github.com/gogs/git-module"
 	api "github.com/gogs/go-gogs-client"
 	jsoniter "github.com/json-iterator/go"
+	"github.com/unknwon/paginater"
 	"gopkg.in/macaron.v1"
 
 	"gogs.io/gogs/internal/conf"
@@ -87,7 +89,7 @@ func Webhooks(c *context.Context, orCtx *orgRepoContext) {
 		ws, err = db.GetWebhooksByRepoID(orCtx.RepoID)
 	} else {
 		c.Data["Description"] = c.Tr("org.settings.hooks_desc")
-		ws, err = db.GetWebhooksByOrgID(orCtx.OrgID)
+		ws, err = db.Webhooks.ListByOrg(c.Req.Context(), orCtx.OrgID)
 	}
 	if err != nil {
 		c.Error(err, "get webhooks")
@@ -131,6 +133,10 @@ func validateWebhook(l macaron.Locale, w *db.Webhook) (field, msg string, ok boo
 	if netutil.IsBlockedLocalHostname(payloadURL.Hostname(), conf.Security.LocalNetworkAllowlist) {
 		return "PayloadURL", l.Tr("repo.settings.webhook.url_resolved_to_blocked_local_address"), false
 	}
+
+	if _, err = path.Match(w.BranchFilter, ""); err != nil {
+		return "BranchFilter", l.Tr("repo.settings.webhook.err_invalid_branch_filter", err), false
+	}
 	return "", "", true
 }
 
@@ -149,7 +155,12 @@ func validateAndCreateWebhook(c *context.Context, orCtx *orgRepoContext, w *db.W
 		return
 	}
 
-	if err := w.UpdateEvent(); err != nil {
+	if orCtx.OrgID > 0 {
+		if err := db.Webhooks.CreateForOrg(c.Req.Context(), orCtx.OrgID, w); err != nil {
+			c.Error(err, "create webhook for organization")
+			return
+		}
+	} else if err := w.UpdateEvent(); err != nil {
 		c.Error(err, "update event")
 		return
 	} else if err := db.CreateWebhook(w); err != nil {
@@ -197,6 +208,7 @@ func WebhooksNewPost(c *context.Context, orCtx *orgRepoContext, f form.NewWebhoo
 		ContentType:  contentType,
 		Secret:       f.Secret,
 		HookEvent:    toHookEvent(f.Webhook),
+		BranchFilter: f.BranchFilter,
 		IsActive:     f.Active,
 		HookTaskType: db.GOGS,
 	}
@@ -228,6 +240,7 @@ func WebhooksSlackNewPost(c *context.Context, orCtx *orgRepoContext, f form.NewS
 		URL:          f.PayloadURL,
 		ContentType:  db.JSON,
 		HookEvent:    toHookEvent(f.Webhook),
+		BranchFilter: f.BranchFilter,
 		IsActive:     f.Active,
 		HookTaskType: db.SLACK,
 		Meta:         string(p),
@@ -260,6 +273,7 @@ func WebhooksDiscordNewPost(c *context.Context, orCtx *orgRepoContext, f form.Ne
 		URL:          f.PayloadURL,
 		ContentType:  db.JSON,
 		HookEvent:    toHookEvent(f.Webhook),
+		BranchFilter: f.BranchFilter,
 		IsActive:     f.Active,
 		HookTaskType: db.DISCORD,
 		Meta:         string(p),
@@ -279,6 +293,7 @@ func WebhooksDingtalkNewPost(c *context.Context, orCtx *orgRepoContext, f form.N
 		URL:          f.PayloadURL,
 		ContentType:  db.JSON,
 		HookEvent:    toHookEvent(f.Webhook),
+		BranchFilter: f.BranchFilter,
 		IsActive:     f.Active,
 		HookTaskType: db.DINGTALK,
 		OrgID:        orCtx.OrgID,
@@ -317,11 +332,17 @@ func loadWebhook(c *context.Context, orCtx *orgRepoContext) *db.Webhook {
 	c.Data["FormURL"] = fmt.Sprintf("%s/settings/hooks/%s/%d", orCtx.Link, c.Data["HookType"], w.ID)
 	c.Data["DeleteURL"] = fmt.Sprintf("%s/settings/hooks/delete", orCtx.Link)
 
-	c.Data["History"], err = w.History(1)
+	page := c.QueryInt("page")
+	if page <= 1 {
+		page = 1
+	}
+	history, count, err := db.Webhooks.ListTasks(c.Req.Context(), w.ID, page, conf.Webhook.PagingNum)
 	if err != nil {
-		c.Error(err, "get history")
+		c.Error(err, "list tasks")
 		return nil
 	}
+	c.Data["History"] = history
+	c.Data["Page"] = paginater.New(int(count), conf.Webhook.PagingNum, page, 5)
 	return w
 }
 
@@ -384,6 +405,7 @@ func WebhooksEditPost(c *context.Context, orCtx *orgRepoContext, f form.NewWebho
 	w.ContentType = contentType
 	w.Secret = f.Secret
 	w.HookEvent = toHookEvent(f.Webhook)
+	w.BranchFilter = f.BranchFilter
 	w.IsActive = f.Active
 	validateAndUpdateWebhook(c, orCtx, w)
 }
@@ -412,6 +434,7 @@ func WebhooksSlackEditPost(c *context.Context, orCtx *orgRepoContext, f form.New
 	w.URL = f.PayloadURL
 	w.Meta = string(meta)
 	w.HookEvent = toHookEvent(f.Webhook)
+	w.BranchFilter = f.BranchFilter
 	w.IsActive = f.Active
 	validateAndUpdateWebhook(c, orCtx, w)
 }
@@ -439,6 +462,7 @@ func WebhooksDiscordEditPost(c *context.Context, orCtx *orgRepoContext, f form.N
 	w.URL = f.PayloadURL
 	w.Meta = string(meta)
 	w.HookEvent = toHookEvent(f.Webhook)
+	w.BranchFilter = f.BranchFilter
 	w.IsActive = f.Active
 	validateAndUpdateWebhook(c, orCtx, w)
 }
@@ -455,6 +479,7 @@ func WebhooksDingtalkEditPost(c *context.Context, orCtx *orgRepoContext, f form.
 
 	w.URL = f.PayloadURL
 	w.HookEvent = toHookEvent(f.Webhook)
+	w.BranchFilter = f.BranchFilter
 	w.IsActive = f.Active
 	validateAndUpdateWebhook(c, orCtx, w)
 }
@@ -554,6 +579,23 @@ func TestWebhook(c *context.Context) {
 	c.Status(http.StatusOK)
 }
 
+func PingWebhook(c *context.Context) {
+	webhook, err := db.GetWebhookOfRepoByID(c.Repo.Repository.ID, c.ParamsInt64(":id"))
+	if err != nil {
+		c.NotFoundOrError(err, "get webhook")
+		return
+	}
+
+	hookTask, err := db.Webhooks.CreatePingTask(c.Req.Context(), webhook.ID)
+	if err != nil {
+		c.Error(err, "create ping task")
+		return
+	}
+
+	c.Flash.Info(c.Tr("repo.settings.webhook.ping_success", hookTask.UUID))
+	c.Status(http.StatusOK)
+}
+
 func RedeliveryWebhook(c *context.Context) {
 	webhook, err := db.GetWebhookOfRepoByID(c.Repo.Repository.ID, c.ParamsInt64(":id"))
 	if err != nil {
@@ -567,17 +609,39 @@ func RedeliveryWebhook(c *context.Context) {
 		return
 	}
 
-	hookTask.IsDelivered = false
-	if err = db.UpdateHookTask(hookTask); err != nil {
-		c.Error(err, "update hook task")
+	hookTask, err = db.Webhooks.ReplayTask(c.Req.Context(), hookTask.ID)
+	if err != nil {
+		c.Error(err, "replay task")
 		return
 	}
 
-	go db.HookQueue.Add(c.Repo.Repository.ID)
 	c.Flash.Info(c.Tr("repo.settings.webhook.redelivery_success", hookTask.UUID))
 	c.Status(http.StatusOK)
 }
 
+func RotateWebhookSecret(c *context.Context, orCtx *orgRepoContext) {
+	var err error
+	var w *db.Webhook
+	if orCtx.RepoID > 0 {
+		w, err = db.GetWebhookOfRepoByID(orCtx.RepoID, c.ParamsInt64(":id"))
+	} else {
+		w, err = db.GetWebhookByOrgID(orCtx.OrgID, c.ParamsInt64(":id"))
+	}
+	if err != nil {
+		c.NotFoundOrError(err, "get webhook")
+		return
+	}
+
+	_, err = db.Webhooks.RotateSecret(c.Req.Context(), w.ID)
+	if err != nil {
+		c.Error(err, "rotate secret")
+		return
+	}
+
+	c.Flash.Success(c.Tr("repo.settings.webhook.rotate_secret_success"))
+	c.Redirect(fmt.Sprintf("%s/settings/hooks/%d", orCtx.Link, w.ID))
+}
+
 func DeleteWebhook(c *context.Context, orCtx *orgRepoContext) {
 	var err error
 	if orCtx.RepoID > 0 {
@@ -42,6 +42,14 @@ func Test_validateWebhook(t *testing.T) {
 			expMsg:   "repo.settings.webhook.url_resolved_to_blocked_local_address",
 			expOK:    false,
 		},
+
+		{
+			name:     "invalid branch filter",
+			webhook:  &db.Webhook{URL: "https://8.8.8.8", BranchFilter: "release/["},
+			expField: "BranchFilter",
+			expMsg:   "repo.settings.webhook.err_invalid_branch_filter",
+			expOK:    false,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -71,6 +71,7 @@ func AutoLogin(c *context.Context) (bool, error) {
 	isSucceed = true
 	_ = c.Session.Set("uid", u.ID)
 	_ = c.Session.Set("uname", u.Name)
+	_ = c.Session.Set("sessionEpoch", u.SessionEpoch)
 	c.SetCookie(conf.Session.CSRFCookieName, "", -1, conf.Server.Subpath)
 	if conf.Security.EnableLoginStatusCookie {
 		c.SetCookie(conf.Security.LoginStatusCookieName, "true", 0, conf.Server.Subpath)
@@ -122,7 +123,18 @@ func Login(c *context.Context) {
 	c.Success(LOGIN)
 }
 
+// consumeOrgInvitations accepts pending organization invitations of verified
+// email addresses of the user. Failures are logged but not fatal.
+func consumeOrgInvitations(c *context.Context, userID int64) {
+	err := db.Orgs.ConsumeInvitations(c.Req.Context(), userID)
+	if err != nil {
+		log.Error("Failed to consume organization invitations [user_id: %d]: %v", userID, err)
+	}
+}
+
 func afterLogin(c *context.Context, u *db.User, remember bool) {
+	consumeOrgInvitations(c, u.ID)
+
 	if remember {
 		days := 86400 * conf.Security.LoginRememberDays
 		c.SetCookie(conf.Security.CookieUsername, u.Name, days, conf.Server.Subpath, "", conf.Security.CookieSecure, true)
@@ -131,6 +143,7 @@ func afterLogin(c *context.Context, u *db.User, remember bool) {
 
 	_ = c.Session.Set("uid", u.ID)
 	_ = c.Session.Set("uname", u.Name)
+	_ = c.Session.Set("sessionEpoch", u.SessionEpoch)
 	_ = c.Session.Delete("twoFactorRemember")
 	_ = c.Session.Delete("twoFactorUserID")
 
@@ -165,10 +178,18 @@ func LoginPost(c *context.Context, f form.SignIn) {
 		return
 	}
 
+	remoteIP := context.RemoteIP(c.Req.Request)
+	if db.Users.IsLockedOut(c.Req.Context(), f.UserName, remoteIP) {
+		c.FormErr("UserName", "Password")
+		c.RenderWithErr(c.Tr("form.login_locked_out"), LOGIN, &f)
+		return
+	}
+
 	u, err := db.Users.Authenticate(c.Req.Context(), f.UserName, f.Password, f.LoginSource)
 	if err != nil {
 		switch {
 		case auth.IsErrBadCredentials(err):
+			context.RecordFailedLogin(c.Req.Context(), remoteIP, f.UserName)
 			c.FormErr("UserName", "Password")
 			c.RenderWithErr(c.Tr("form.username_password_incorrect"), LOGIN, &f)
 		case db.IsErrLoginSourceMismatch(err):
@@ -187,6 +208,10 @@ func LoginPost(c *context.Context, f form.SignIn) {
 		return
 	}
 
+	if err = db.Users.ClearFailedLogins(c.Req.Context(), f.UserName, remoteIP); err != nil {
+		log.Error("Failed to clear failed logins of %q: %v", f.UserName, err)
+	}
+
 	if !db.TwoFactors.IsEnabled(c.Req.Context(), u.ID) {
 		afterLogin(c, u, f.Remember)
 		return
@@ -355,6 +380,9 @@ func SignUpPost(c *context.Context, cpt *captcha.Captcha, f form.Register) {
 		case db.IsErrNameNotAllowed(err):
 			c.FormErr("UserName")
 			c.RenderWithErr(c.Tr("user.form.name_not_allowed", err.(db.ErrNameNotAllowed).Value()), SIGNUP, &f)
+		case db.IsErrPasswordPolicy(err):
+			c.FormErr("Password")
+			c.RenderWithErr(c.TrPasswordPolicy(err.(db.ErrPasswordPolicy)), SIGNUP, &f)
 		default:
 			c.Error(err, "create user")
 		}
@@ -498,9 +526,11 @@ func Activate(c *context.Context) {
 		}
 
 		log.Trace("User activated: %s", user.Name)
+		consumeOrgInvitations(c, user.ID)
 
 		_ = c.Session.Set("uid", user.ID)
 		_ = c.Session.Set("uname", user.Name)
+		_ = c.Session.Set("sessionEpoch", user.SessionEpoch)
 		c.RedirectSubpath("/")
 		return
 	}
@@ -522,6 +552,7 @@ func ActivateEmail(c *context.Context) {
 		}
 
 		log.Trace("Email activated: %s", email.Email)
+		consumeOrgInvitations(c, email.UserID)
 		c.Flash.Success(c.Tr("settings.add_email_success"))
 	}
 
@@ -623,10 +654,22 @@ func ResetPasswdPost(c *context.Context) {
 
 		err := db.Users.Update(c.Req.Context(), u.ID, db.UpdateUserOptions{Password: &password})
 		if err != nil {
+			if db.IsErrPasswordPolicy(err) {
+				c.Data["IsResetForm"] = true
+				c.Data["Err_Password"] = true
+				c.RenderWithErr(c.TrPasswordPolicy(err.(db.ErrPasswordPolicy)), RESET_PASSWORD, nil)
+				return
+			}
 			c.Error(err, "update user")
 			return
 		}
 
+		err = db.Users.InvalidateSessions(c.Req.Context(), u.ID)
+		if err != nil {
+			c.Error(err, "invalidate sessions")
+			return
+		}
+
 		log.Trace("User password reset: %s", u.Name)
 		c.RedirectSubpath("/user/login")
 		return
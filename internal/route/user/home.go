@@ -314,7 +314,7 @@ func Issues(c *context.Context) {
 		issueOptions.PosterID = ctxUser.ID
 	}
 
-	issues, err := db.Issues(issueOptions)
+	issues, err := db.ListIssues(issueOptions)
 	if err != nil {
 		c.Error(err, "list issues")
 		return
@@ -432,11 +432,40 @@ func showOrgProfile(c *context.Context) {
 	}
 	c.Data["Page"] = paginater.New(int(count), conf.UI.User.RepoPagingNum, page, 5)
 
-	if err := org.GetMembers(12); err != nil {
-		c.Error(err, "get members")
+	pinnedRepos, err := db.Orgs.ListPinnedRepos(c.Req.Context(), org.ID)
+	if err != nil {
+		c.Error(err, "list pinned repositories")
+		return
+	}
+	visiblePinnedRepos := make([]*db.Repository, 0, len(pinnedRepos))
+	for _, repo := range pinnedRepos {
+		if !db.Perms.Authorize(c.Req.Context(), c.UserID(), repo.ID, db.AccessModeRead,
+			db.AccessModeOptions{
+				OwnerID: repo.OwnerID,
+				Private: repo.IsPrivate,
+			},
+		) {
+			continue
+		}
+		repo.Owner = org
+		visiblePinnedRepos = append(visiblePinnedRepos, repo)
+	}
+	c.Data["PinnedRepos"] = visiblePinnedRepos
+
+	// Visitors who are not members of the organization can only see members with
+	// public membership, and so does the number of members.
+	members, numMembers, err := db.OrgsReplica.ListMembers(c.Req.Context(), org.ID,
+		db.ListOrgMembersOptions{
+			Limit:      12,
+			PublicOnly: !c.Org.IsMember,
+		},
+	)
+	if err != nil {
+		c.Error(err, "list members")
 		return
 	}
-	c.Data["Members"] = org.Members
+	c.Data["Members"] = members
+	c.Data["NumMembers"] = numMembers
 
 	c.Data["Teams"] = org.Teams
 
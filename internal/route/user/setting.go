@@ -210,9 +210,29 @@ func SettingsPasswordPost(c *context.Context, f form.ChangePassword) {
 			},
 		)
 		if err != nil {
+			if db.IsErrPasswordPolicy(err) {
+				c.Flash.Error(c.TrPasswordPolicy(err.(db.ErrPasswordPolicy)))
+				c.RedirectSubpath("/user/settings/password")
+				return
+			}
 			c.Errorf(err, "update user")
 			return
 		}
+
+		err = db.Users.InvalidateSessions(c.Req.Context(), c.User.ID)
+		if err != nil {
+			c.Errorf(err, "invalidate sessions")
+			return
+		}
+
+		// Keep the current session valid with the new epoch.
+		u, err := db.Users.GetByID(c.Req.Context(), c.User.ID)
+		if err != nil {
+			c.Errorf(err, "get user by ID")
+			return
+		}
+		_ = c.Session.Set("sessionEpoch", u.SessionEpoch)
+
 		c.Flash.Success(c.Tr("settings.change_password_success"))
 	}
 
@@ -562,15 +582,41 @@ func SettingsOrganizations(c *context.Context) {
 	}
 	c.Data["Orgs"] = orgs
 
+	notifyLevels, err := db.Orgs.ListNotifyLevelsOfMember(c.Req.Context(), c.User.ID)
+	if err != nil {
+		c.Errorf(err, "list notify levels of member")
+		return
+	}
+	c.Data["NotifyLevels"] = notifyLevels
+
 	c.Success(SETTINGS_ORGANIZATIONS)
 }
 
+func SettingsOrganizationNotifyLevel(c *context.Context) {
+	orgID := c.QueryInt64("id")
+	if !db.Orgs.HasMember(c.Req.Context(), orgID, c.User.ID) {
+		c.NotFound()
+		return
+	}
+
+	if err := db.Orgs.SetMemberNotifyLevel(c.Req.Context(), orgID, c.User.ID, c.QueryInt("level")); err != nil {
+		c.Errorf(err, "set member notify level")
+		return
+	}
+
+	c.Flash.Success(c.Tr("settings.orgs.update_notify_level_success"))
+	c.RedirectSubpath("/user/settings/organizations")
+}
+
 func SettingsLeaveOrganization(c *context.Context) {
-	if err := db.RemoveOrgUser(c.QueryInt64("id"), c.User.ID); err != nil {
+	if err := db.Orgs.LeaveOrg(c.Req.Context(), c.QueryInt64("id"), c.User.ID); err != nil {
 		if db.IsErrLastOrgOwner(err) {
 			c.Flash.Error(c.Tr("form.last_org_owner"))
+		} else if db.IsErrNotOrgMember(err) {
+			c.NotFound()
+			return
 		} else {
-			c.Errorf(err, "remove organization user")
+			c.Errorf(err, "leave organization")
 			return
 		}
 	}
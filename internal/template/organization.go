@@ -0,0 +1,35 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package template
+
+import (
+	"context"
+
+	"gogs.io/gogs/internal/db"
+)
+
+// Organization is an organization to be rendered in templates, with the
+// viewer-specific information resolved beforehand so that templates do not
+// need to query the database.
+type Organization struct {
+	*db.Organization
+	// Whether the viewer is an owner of the organization.
+	IsOwner bool
+}
+
+// NewOrganizations returns the template representation of given organizations
+// for the viewer, using the store to check ownership of each organization.
+func NewOrganizations(ctx context.Context, orgsStore db.OrgsStore, orgs []*db.Organization, viewerID int64) []*Organization {
+	results := make([]*Organization, 0, len(orgs))
+	for _, org := range orgs {
+		results = append(results,
+			&Organization{
+				Organization: org,
+				IsOwner:      orgsStore.IsOwnedBy(ctx, org.ID, viewerID),
+			},
+		)
+	}
+	return results
+}
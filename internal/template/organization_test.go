@@ -0,0 +1,30 @@
+// Copyright 2024 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package template
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gogs.io/gogs/internal/db"
+)
+
+func TestNewOrganizations(t *testing.T) {
+	mockOrgsStore := NewMockOrgsStore()
+	mockOrgsStore.IsOwnedByFunc.SetDefaultHook(func(_ context.Context, orgID, userID int64) bool {
+		return orgID == 1 && userID == 2
+	})
+
+	orgs := []*db.Organization{{ID: 1}, {ID: 3}}
+	got := NewOrganizations(context.Background(), mockOrgsStore, orgs, 2)
+	require.Len(t, got, 2)
+	assert.Equal(t, int64(1), got[0].ID)
+	assert.True(t, got[0].IsOwner)
+	assert.Equal(t, int64(3), got[1].ID)
+	assert.False(t, got[1].IsOwner)
+}
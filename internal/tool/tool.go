@@ -132,7 +132,12 @@ func HashEmail(email string) string {
 // which includes app sub-url as prefix. However, it is possible
 // to return full URL if user enables Gravatar-like service.
 func AvatarLink(email string) (url string) {
-	if conf.Picture.EnableFederatedAvatar && conf.Picture.LibravatarService != nil &&
+	switch conf.Picture.AvatarSource {
+	case conf.AvatarSourceInternal, conf.AvatarSourceDisabled:
+		return conf.UserDefaultAvatarURLPath()
+	}
+
+	if conf.Picture.AvatarSource == conf.AvatarSourceLibravatar && conf.Picture.LibravatarService != nil &&
 		strings.Contains(email, "@") {
 		var err error
 		url, err = conf.Picture.LibravatarService.FromEmail(email)
@@ -140,11 +145,8 @@ func AvatarLink(email string) (url string) {
 			log.Warn("AvatarLink.LibravatarService.FromEmail [%s]: %v", email, err)
 		}
 	}
-	if url == "" && !conf.Picture.DisableGravatar {
-		url = conf.Picture.GravatarSource + HashEmail(email) + "?d=identicon"
-	}
 	if url == "" {
-		url = conf.Server.Subpath + "/img/avatar_default.png"
+		url = conf.Picture.GravatarSource + HashEmail(email) + "?d=identicon"
 	}
 	return url
 }
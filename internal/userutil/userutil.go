@@ -16,6 +16,8 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/nfnt/resize"
 	"github.com/pkg/errors"
@@ -54,6 +56,12 @@ func CustomAvatarPath(userID int64) string {
 	return filepath.Join(conf.Picture.AvatarUploadPath, strconv.FormatInt(userID, 10))
 }
 
+// CustomTeamAvatarPath returns the absolute path of the team custom avatar
+// file.
+func CustomTeamAvatarPath(teamID int64) string {
+	return filepath.Join(conf.Picture.AvatarUploadPath, "teams", strconv.FormatInt(teamID, 10))
+}
+
 // GenerateRandomAvatar generates a random avatar and stores to local file
 // system using given user information.
 func GenerateRandomAvatar(userID int64, name, email string) error {
@@ -87,12 +95,20 @@ func GenerateRandomAvatar(userID int64, name, email string) error {
 
 // SaveAvatar saves the given avatar for the user.
 func SaveAvatar(userID int64, data []byte) error {
+	return saveAvatar(CustomAvatarPath(userID), data)
+}
+
+// SaveTeamAvatar saves the given avatar for the team.
+func SaveTeamAvatar(teamID int64, data []byte) error {
+	return saveAvatar(CustomTeamAvatarPath(teamID), data)
+}
+
+func saveAvatar(avatarPath string, data []byte) error {
 	img, _, err := image.Decode(bytes.NewReader(data))
 	if err != nil {
 		return errors.Wrap(err, "decode image")
 	}
 
-	avatarPath := CustomAvatarPath(userID)
 	err = os.MkdirAll(filepath.Dir(avatarPath), os.ModePerm)
 	if err != nil {
 		return errors.Wrap(err, "create avatar directory")
@@ -124,6 +140,94 @@ func ValidatePassword(encoded, salt, password string) bool {
 	return subtle.ConstantTimeCompare([]byte(encoded), []byte(got)) == 1
 }
 
+// Character classes that a password policy can require.
+const (
+	PasswordClassLower   = "lower"
+	PasswordClassUpper   = "upper"
+	PasswordClassDigit   = "digit"
+	PasswordClassSpecial = "special"
+)
+
+// Rules of a password policy that a password can violate, in addition to the
+// character classes.
+const (
+	PasswordRuleMinLength = "min_length"
+	PasswordRuleCommon    = "common"
+	PasswordRuleBanned    = "banned"
+)
+
+// commonPasswords is a list of most commonly used passwords, which are the first
+// to be tried by attackers.
+var commonPasswords = map[string]bool{
+	"123456": true, "123456789": true, "12345678": true, "1234567890": true,
+	"12345": true, "1234567": true, "111111": true, "123123": true,
+	"000000": true, "654321": true, "666666": true, "121212": true,
+	"password": true, "password1": true, "password123": true, "passw0rd": true,
+	"qwerty": true, "qwerty123": true, "qwertyuiop": true, "1q2w3e4r": true,
+	"abc123": true, "iloveyou": true, "admin": true, "admin123": true,
+	"welcome": true, "letmein": true, "monkey": true, "dragon": true,
+	"football": true, "baseball": true, "sunshine": true, "princess": true,
+	"master": true, "superman": true, "trustno1": true, "changeme": true,
+}
+
+// PasswordPolicy is a set of rules that passwords must comply with.
+type PasswordPolicy struct {
+	// The minimum number of characters, 0 means no limit.
+	MinLength int
+	// The character classes that must all be present, see PasswordClass* for
+	// possible values. Unknown classes are ignored.
+	RequiredClasses []string
+	// Whether to reject passwords that are on the built-in list of common
+	// passwords.
+	RejectCommon bool
+	// The list of additional passwords to reject, matched case-insensitively.
+	Banned []string
+}
+
+// Violations returns the rules of the policy that the password violates in a
+// stable order, see PasswordRule* and PasswordClass* for possible values. The
+// password complies with the policy when the returned list is empty.
+func (p PasswordPolicy) Violations(password string) []string {
+	var violations []string
+	if p.MinLength > 0 && utf8.RuneCountInString(password) < p.MinLength {
+		violations = append(violations, PasswordRuleMinLength)
+	}
+
+	has := make(map[string]bool, 4)
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			has[PasswordClassLower] = true
+		case unicode.IsUpper(r):
+			has[PasswordClassUpper] = true
+		case unicode.IsDigit(r):
+			has[PasswordClassDigit] = true
+		case !unicode.IsLetter(r):
+			has[PasswordClassSpecial] = true
+		}
+	}
+	for _, class := range []string{PasswordClassLower, PasswordClassUpper, PasswordClassDigit, PasswordClassSpecial} {
+		for _, required := range p.RequiredClasses {
+			if strings.EqualFold(strings.TrimSpace(required), class) && !has[class] {
+				violations = append(violations, class)
+				break
+			}
+		}
+	}
+
+	lower := strings.ToLower(password)
+	if p.RejectCommon && commonPasswords[lower] {
+		violations = append(violations, PasswordRuleCommon)
+	}
+	for _, banned := range p.Banned {
+		if banned != "" && strings.ToLower(strings.TrimSpace(banned)) == lower {
+			violations = append(violations, PasswordRuleBanned)
+			break
+		}
+	}
+	return violations
+}
+
 // MailResendCacheKey returns the key used for caching mail resend.
 func MailResendCacheKey(userID int64) string {
 	return fmt.Sprintf("mailResend::%d", userID)
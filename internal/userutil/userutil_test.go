@@ -106,6 +106,27 @@ func TestSaveAvatar(t *testing.T) {
 	assert.True(t, got)
 }
 
+func TestSaveTeamAvatar(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping testing on Windows")
+		return
+	}
+
+	conf.SetMockPicture(t,
+		conf.PictureOpts{
+			AvatarUploadPath: t.TempDir(),
+		},
+	)
+
+	avatar, err := public.Files.ReadFile("img/avatar_default.png")
+	require.NoError(t, err)
+
+	err = SaveTeamAvatar(1, avatar)
+	require.NoError(t, err)
+	got := osutil.IsFile(CustomTeamAvatarPath(1))
+	assert.True(t, got)
+}
+
 func TestEncodePassword(t *testing.T) {
 	want := EncodePassword("123456", "rands")
 	tests := []struct {
@@ -182,6 +203,62 @@ func TestValidatePassword(t *testing.T) {
 	}
 }
 
+func TestPasswordPolicy_Violations(t *testing.T) {
+	policy := PasswordPolicy{
+		MinLength:       8,
+		RequiredClasses: []string{"lower", " Upper", "digit", "special", "unknown"},
+		RejectCommon:    true,
+		Banned:          []string{"Gogs-Rocks-2024"},
+	}
+	tests := []struct {
+		name     string
+		policy   PasswordPolicy
+		password string
+		want     []string
+	}{
+		{
+			name:     "empty policy",
+			password: "1",
+			want:     nil,
+		},
+		{
+			name:     "compliant",
+			policy:   policy,
+			password: "c0rrect-Horse",
+			want:     nil,
+		},
+		{
+			name:     "too short in characters",
+			policy:   policy,
+			password: "pässwö1",
+			want:     []string{PasswordRuleMinLength, PasswordClassUpper, PasswordClassSpecial},
+		},
+		{
+			name:     "missing classes",
+			policy:   policy,
+			password: "ALLUPPERCASE",
+			want:     []string{PasswordClassLower, PasswordClassDigit, PasswordClassSpecial},
+		},
+		{
+			name:     "common",
+			policy:   PasswordPolicy{RejectCommon: true},
+			password: "PASSWORD",
+			want:     []string{PasswordRuleCommon},
+		},
+		{
+			name:     "banned",
+			policy:   policy,
+			password: "gogs-rocks-2024",
+			want:     []string{PasswordClassUpper, PasswordRuleBanned},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, test.policy.Violations(test.password))
+		})
+	}
+}
+
 func TestMailResendCacheKey(t *testing.T) {
 	got := MailResendCacheKey(1)
 	assert.Equal(t, "mailResend::1", got)